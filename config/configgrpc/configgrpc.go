@@ -25,6 +25,7 @@ import (
 	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/reflection"
 	"google.golang.org/grpc/status"
 
 	"go.opentelemetry.io/collector/client"
@@ -172,6 +173,10 @@ type ServerConfig struct {
 	// MaxRecvMsgSizeMiB sets the maximum size (in MiB) of messages accepted by the server.
 	MaxRecvMsgSizeMiB int `mapstructure:"max_recv_msg_size_mib"`
 
+	// MaxSendMsgSizeMiB sets the maximum size (in MiB) of messages sent by the server, independently
+	// of MaxRecvMsgSizeMiB. The gRPC default applies if zero.
+	MaxSendMsgSizeMiB int `mapstructure:"max_send_msg_size_mib"`
+
 	// MaxConcurrentStreams sets the limit on the number of concurrent streams to each ServerTransport.
 	// It has effect only for streaming RPCs.
 	MaxConcurrentStreams uint32 `mapstructure:"max_concurrent_streams"`
@@ -192,6 +197,10 @@ type ServerConfig struct {
 
 	// Include propagates the incoming connection's metadata to downstream consumers.
 	IncludeMetadata bool `mapstructure:"include_metadata"`
+
+	// EnableReflection registers the gRPC server reflection service, allowing clients such as grpcurl
+	// to list and describe the services of the server. The default value is false.
+	EnableReflection bool `mapstructure:"enable_reflection"`
 }
 
 // NewDefaultServerConfig returns a new instance of ServerConfig with default values.
@@ -324,6 +333,10 @@ func (gss *ServerConfig) Validate() error {
 		return fmt.Errorf("invalid max_recv_msg_size_mib value, must be between 1 and %d: %d", math.MaxInt/1024/1024, gss.MaxRecvMsgSizeMiB)
 	}
 
+	if gss.MaxSendMsgSizeMiB*1024*1024 < 0 {
+		return fmt.Errorf("invalid max_send_msg_size_mib value, must be between 1 and %d: %d", math.MaxInt/1024/1024, gss.MaxSendMsgSizeMiB)
+	}
+
 	if gss.ReadBufferSize < 0 {
 		return fmt.Errorf("invalid read_buffer_size value: %d", gss.ReadBufferSize)
 	}
@@ -342,7 +355,11 @@ func (gss *ServerConfig) ToServer(_ context.Context, host component.Host, settin
 		return nil, err
 	}
 	opts = append(opts, extraOpts...)
-	return grpc.NewServer(opts...), nil
+	srv := grpc.NewServer(opts...)
+	if gss.EnableReflection {
+		reflection.Register(srv)
+	}
+	return srv, nil
 }
 
 func (gss *ServerConfig) toServerOption(host component.Host, settings component.TelemetrySettings) ([]grpc.ServerOption, error) {
@@ -365,6 +382,10 @@ func (gss *ServerConfig) toServerOption(host component.Host, settings component.
 		opts = append(opts, grpc.MaxRecvMsgSize(gss.MaxRecvMsgSizeMiB*1024*1024))
 	}
 
+	if gss.MaxSendMsgSizeMiB > 0 && gss.MaxSendMsgSizeMiB*1024*1024 > 0 {
+		opts = append(opts, grpc.MaxSendMsgSize(gss.MaxSendMsgSizeMiB*1024*1024))
+	}
+
 	if gss.MaxConcurrentStreams > 0 {
 		opts = append(opts, grpc.MaxConcurrentStreams(gss.MaxConcurrentStreams))
 	}
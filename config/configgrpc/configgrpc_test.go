@@ -10,11 +10,13 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest/observer"
 	"google.golang.org/grpc"
@@ -22,6 +24,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/reflection/grpc_reflection_v1"
 	"google.golang.org/grpc/status"
 
 	"go.opentelemetry.io/collector/client"
@@ -36,6 +39,7 @@ import (
 	"go.opentelemetry.io/collector/extension/auth/authtest"
 	"go.opentelemetry.io/collector/featuregate"
 	"go.opentelemetry.io/collector/internal/localhostgate"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
 )
 
@@ -272,6 +276,15 @@ func TestGrpcServerValidate(t *testing.T) {
 			},
 			err: "invalid max_recv_msg_size_mib value",
 		},
+		{
+			gss: &ServerConfig{
+				MaxSendMsgSizeMiB: -1,
+				NetAddr: confignet.AddrConfig{
+					Endpoint: "0.0.0.0:1234",
+				},
+			},
+			err: "invalid max_send_msg_size_mib value",
+		},
 		{
 			gss: &ServerConfig{
 				ReadBufferSize: -1,
@@ -312,6 +325,7 @@ func TestAllGrpcServerSettingsExceptAuth(t *testing.T) {
 			ClientCAFile: "",
 		},
 		MaxRecvMsgSizeMiB:    1,
+		MaxSendMsgSizeMiB:    1,
 		MaxConcurrentStreams: 1024,
 		ReadBufferSize:       1024,
 		WriteBufferSize:      1024,
@@ -331,7 +345,7 @@ func TestAllGrpcServerSettingsExceptAuth(t *testing.T) {
 	}
 	opts, err := gss.toServerOption(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
 	assert.NoError(t, err)
-	assert.Len(t, opts, 10)
+	assert.Len(t, opts, 11)
 }
 
 func TestGrpcServerAuthSettings(t *testing.T) {
@@ -766,6 +780,56 @@ func TestHttpReception(t *testing.T) {
 	}
 }
 
+func TestOCSPStaplingServerShutdown(t *testing.T) {
+	defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+
+	ocspPath := filepath.Join(t.TempDir(), "ocsp.der")
+	require.NoError(t, os.WriteFile(ocspPath, []byte("ocsp-response"), 0600))
+	gss := &ServerConfig{
+		NetAddr: confignet.AddrConfig{
+			Endpoint:  "localhost:0",
+			Transport: confignet.TransportTypeTCP,
+		},
+		TLSSetting: &configtls.ServerConfig{
+			Config: configtls.Config{
+				CertFile:       filepath.Join("testdata", "server.crt"),
+				KeyFile:        filepath.Join("testdata", "server.key"),
+				ReloadInterval: time.Millisecond,
+			},
+			OCSPFile: ocspPath,
+		},
+	}
+	ln, err := gss.NetAddr.Listen(context.Background())
+	require.NoError(t, err)
+	s, err := gss.ToServer(context.Background(), componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	ptraceotlp.RegisterGRPCServer(s, &grpcTraceServer{})
+	go func() {
+		_ = s.Serve(ln)
+	}()
+
+	gcs := &ClientConfig{
+		Endpoint: ln.Addr().String(),
+		TLSSetting: configtls.ClientConfig{
+			Config: configtls.Config{
+				CAFile: filepath.Join("testdata", "ca.crt"),
+			},
+			ServerName: "localhost",
+		},
+	}
+	grpcClientConn, err := gcs.ToClientConn(context.Background(), componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	c := ptraceotlp.NewGRPCClient(grpcClientConn)
+	ctx, cancelFunc := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancelFunc()
+	_, err = c.Export(ctx, ptraceotlp.NewExportRequest(), grpc.WaitForReady(true))
+	require.NoError(t, err)
+
+	// No goroutine is left refreshing the OCSP response once the server is stopped.
+	require.NoError(t, grpcClientConn.Close())
+	s.Stop()
+}
+
 func TestReceiveOnUnixDomainSocket(t *testing.T) {
 	if runtime.GOOS == "windows" {
 		t.Skip("skipping test on windows")
@@ -809,6 +873,161 @@ func TestReceiveOnUnixDomainSocket(t *testing.T) {
 	srv.Stop()
 }
 
+// largeResponseTraceServer responds with a partial success error message of responseSize bytes.
+type largeResponseTraceServer struct {
+	ptraceotlp.UnimplementedGRPCServer
+	responseSize int
+}
+
+func (s *largeResponseTraceServer) Export(context.Context, ptraceotlp.ExportRequest) (ptraceotlp.ExportResponse, error) {
+	resp := ptraceotlp.NewExportResponse()
+	resp.PartialSuccess().SetErrorMessage(strings.Repeat("x", s.responseSize))
+	return resp, nil
+}
+
+func TestGRPCServerMaxMsgSizes(t *testing.T) {
+	const twoMiB = 2 * 1024 * 1024
+	tests := []struct {
+		name              string
+		maxRecvMsgSizeMiB int
+		maxSendMsgSizeMiB int
+		requestSize       int
+		responseSize      int
+		wantCode          codes.Code
+	}{
+		{
+			name:              "recv limit does not apply to responses",
+			maxRecvMsgSizeMiB: 1,
+			responseSize:      twoMiB,
+			wantCode:          codes.OK,
+		},
+		{
+			name:              "recv limit applies to requests",
+			maxRecvMsgSizeMiB: 1,
+			requestSize:       twoMiB,
+			wantCode:          codes.ResourceExhausted,
+		},
+		{
+			name:              "send limit does not apply to requests",
+			maxSendMsgSizeMiB: 1,
+			requestSize:       twoMiB,
+			wantCode:          codes.OK,
+		},
+		{
+			name:              "send limit applies to responses",
+			maxSendMsgSizeMiB: 1,
+			responseSize:      twoMiB,
+			wantCode:          codes.ResourceExhausted,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gss := &ServerConfig{
+				NetAddr: confignet.AddrConfig{
+					Endpoint:  "localhost:0",
+					Transport: confignet.TransportTypeTCP,
+				},
+				MaxRecvMsgSizeMiB: tt.maxRecvMsgSizeMiB,
+				MaxSendMsgSizeMiB: tt.maxSendMsgSizeMiB,
+			}
+			ln, err := gss.NetAddr.Listen(context.Background())
+			require.NoError(t, err)
+			srv, err := gss.ToServer(context.Background(), componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+			require.NoError(t, err)
+			ptraceotlp.RegisterGRPCServer(srv, &largeResponseTraceServer{responseSize: tt.responseSize})
+			go func() {
+				_ = srv.Serve(ln)
+			}()
+			defer srv.Stop()
+
+			gcs := &ClientConfig{
+				Endpoint: ln.Addr().String(),
+				TLSSetting: configtls.ClientConfig{
+					Insecure: true,
+				},
+			}
+			grpcClientConn, err := gcs.ToClientConn(context.Background(), componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+			require.NoError(t, err)
+			defer func() { assert.NoError(t, grpcClientConn.Close()) }()
+
+			td := ptrace.NewTraces()
+			td.ResourceSpans().AppendEmpty().Resource().Attributes().PutStr("payload", strings.Repeat("x", tt.requestSize))
+			ctx, cancelFunc := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancelFunc()
+			_, err = ptraceotlp.NewGRPCClient(grpcClientConn).Export(ctx, ptraceotlp.NewExportRequestFromTraces(td), grpc.WaitForReady(true))
+			assert.Equal(t, tt.wantCode, status.Code(err))
+		})
+	}
+}
+
+func TestServerReflection(t *testing.T) {
+	tests := []struct {
+		name             string
+		enableReflection bool
+		wantCode         codes.Code
+	}{
+		{
+			name:             "enabled",
+			enableReflection: true,
+			wantCode:         codes.OK,
+		},
+		{
+			name:             "disabled",
+			enableReflection: false,
+			wantCode:         codes.Unimplemented,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gss := &ServerConfig{
+				NetAddr: confignet.AddrConfig{
+					Endpoint:  "localhost:0",
+					Transport: confignet.TransportTypeTCP,
+				},
+				EnableReflection: tt.enableReflection,
+			}
+			ln, err := gss.NetAddr.Listen(context.Background())
+			require.NoError(t, err)
+			srv, err := gss.ToServer(context.Background(), componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+			require.NoError(t, err)
+			ptraceotlp.RegisterGRPCServer(srv, &grpcTraceServer{})
+			go func() {
+				_ = srv.Serve(ln)
+			}()
+			defer srv.Stop()
+
+			gcs := &ClientConfig{
+				Endpoint: ln.Addr().String(),
+				TLSSetting: configtls.ClientConfig{
+					Insecure: true,
+				},
+			}
+			grpcClientConn, err := gcs.ToClientConn(context.Background(), componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+			require.NoError(t, err)
+			defer func() { assert.NoError(t, grpcClientConn.Close()) }()
+
+			ctx, cancelFunc := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancelFunc()
+			stream, err := grpc_reflection_v1.NewServerReflectionClient(grpcClientConn).ServerReflectionInfo(ctx, grpc.WaitForReady(true))
+			require.NoError(t, err)
+			require.NoError(t, stream.Send(&grpc_reflection_v1.ServerReflectionRequest{
+				MessageRequest: &grpc_reflection_v1.ServerReflectionRequest_ListServices{},
+			}))
+			resp, err := stream.Recv()
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			if tt.wantCode != codes.OK {
+				return
+			}
+			var services []string
+			for _, svc := range resp.GetListServicesResponse().GetService() {
+				services = append(services, svc.GetName())
+			}
+			assert.Contains(t, services, "opentelemetry.proto.collector.trace.v1.TraceService")
+			assert.Contains(t, services, "grpc.reflection.v1.ServerReflection")
+		})
+	}
+}
+
 func TestContextWithClient(t *testing.T) {
 	testCases := []struct {
 		desc       string
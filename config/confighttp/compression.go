@@ -84,6 +84,11 @@ func newCompressRoundTripper(rt http.RoundTripper, compressionType configcompres
 	}, nil
 }
 
+// CloseIdleConnections closes the idle connections of the next transport.
+func (r *compressRoundTripper) CloseIdleConnections() {
+	closeIdleConnections(r.rt)
+}
+
 func (r *compressRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
 	if req.Header.Get(headerContentEncoding) != "" {
 		// If the header already specifies a content encoding then skip compression
@@ -230,7 +230,8 @@ func (hcs *ClientConfig) ToClient(ctx context.Context, host component.Host, sett
 	}
 	// wrapping http transport with otelhttp transport to enable otel instrumentation
 	if settings.TracerProvider != nil && settings.MeterProvider != nil {
-		clientTransport = otelhttp.NewTransport(clientTransport, otelOpts...)
+		// The otelhttp transport does not close the idle connections of the transport it wraps.
+		clientTransport = &closeIdleRoundTripper{RoundTripper: otelhttp.NewTransport(clientTransport, otelOpts...), transport: transport}
 	}
 
 	var jar http.CookieJar
@@ -270,6 +271,37 @@ func (interceptor *headerRoundTripper) RoundTrip(req *http.Request) (*http.Respo
 	return interceptor.transport.RoundTrip(req)
 }
 
+// CloseIdleConnections closes the idle connections of the next transport.
+func (interceptor *headerRoundTripper) CloseIdleConnections() {
+	closeIdleConnections(interceptor.transport)
+}
+
+// closeIdler is implemented by the RoundTrippers closing their idle connections, see
+// http.Client.CloseIdleConnections.
+type closeIdler interface {
+	CloseIdleConnections()
+}
+
+// closeIdleRoundTripper closes the idle connections of the transport wrapped by a RoundTripper which does not
+// close them itself, i.e. the otelhttp one.
+type closeIdleRoundTripper struct {
+	http.RoundTripper
+	transport *http.Transport
+}
+
+func (rt *closeIdleRoundTripper) CloseIdleConnections() {
+	rt.transport.CloseIdleConnections()
+}
+
+// closeIdleConnections closes the idle connections of rt, if it supports it. The RoundTrippers of the
+// authentication extensions must implement CloseIdleConnections for the connections of the transport they wrap
+// to be closed.
+func closeIdleConnections(rt http.RoundTripper) {
+	if ci, ok := rt.(closeIdler); ok {
+		ci.CloseIdleConnections()
+	}
+}
+
 // ServerConfig defines settings for creating an HTTP server.
 type ServerConfig struct {
 	// Endpoint configures the listening address for the server.
@@ -327,6 +359,12 @@ type ServerConfig struct {
 	// is zero, the value of ReadTimeout is used. If both are
 	// zero, there is no timeout.
 	IdleTimeout time.Duration `mapstructure:"idle_timeout"`
+
+	// MaxConnections is the maximum number of simultaneous connections
+	// accepted by the server. The connections beyond the limit are closed
+	// as soon as they are accepted. A zero or negative value means there
+	// is no limit.
+	MaxConnections int `mapstructure:"max_connections"`
 }
 
 // NewDefaultServerConfig returns ServerConfig type object with default values.
@@ -359,6 +397,10 @@ func (hss *ServerConfig) ToListener(ctx context.Context) (net.Listener, error) {
 		return nil, err
 	}
 
+	if hss.MaxConnections > 0 {
+		listener = newLimitListener(listener, hss.MaxConnections)
+	}
+
 	if hss.TLSSetting != nil {
 		var tlsCfg *tls.Config
 		tlsCfg, err = hss.TLSSetting.LoadTLSConfig(ctx)
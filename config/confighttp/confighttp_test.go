@@ -4,6 +4,7 @@
 package confighttp
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"errors"
@@ -747,6 +748,65 @@ func TestHttpReception(t *testing.T) {
 	}
 }
 
+func TestHttpServerMaxConnections(t *testing.T) {
+	hss := &ServerConfig{
+		Endpoint:       "localhost:0",
+		MaxConnections: 2,
+	}
+	ln, err := hss.ToListener(context.Background())
+	require.NoError(t, err)
+	s, err := hss.ToServer(
+		context.Background(),
+		componenttest.NewNopHost(),
+		componenttest.NewNopTelemetrySettings(),
+		http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, errWrite := fmt.Fprint(w, "test")
+			assert.NoError(t, errWrite)
+		}))
+	require.NoError(t, err)
+	go func() {
+		_ = s.Serve(ln)
+	}()
+	defer func() {
+		_ = s.Close()
+	}()
+
+	// get sends a request on a kept alive connection, and returns whether it was served.
+	get := func(conn net.Conn) bool {
+		if _, errWrite := fmt.Fprintf(conn, "GET / HTTP/1.1\r\nHost: %s\r\n\r\n", ln.Addr()); errWrite != nil {
+			return false
+		}
+		resp, errRead := http.ReadResponse(bufio.NewReader(conn), nil)
+		if errRead != nil {
+			return false
+		}
+		defer resp.Body.Close()
+		return resp.StatusCode == http.StatusOK
+	}
+	dial := func() net.Conn {
+		conn, errDial := net.Dial("tcp", ln.Addr().String())
+		require.NoError(t, errDial)
+		t.Cleanup(func() { _ = conn.Close() })
+		return conn
+	}
+
+	first, second := dial(), dial()
+	assert.True(t, get(first))
+	assert.True(t, get(second))
+
+	// The connections beyond the limit are refused.
+	for i := 0; i < 3; i++ {
+		assert.False(t, get(dial()))
+	}
+	assert.True(t, get(first))
+
+	// Closing a connection frees a slot.
+	require.NoError(t, first.Close())
+	assert.Eventually(t, func() bool {
+		return get(dial())
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
 func TestHttpCors(t *testing.T) {
 	tests := []struct {
 		name string
@@ -1072,6 +1132,68 @@ func TestHttpClientHeaders(t *testing.T) {
 	}
 }
 
+func TestHTTPClientCloseIdleConnections(t *testing.T) {
+	tests := []struct {
+		name      string
+		settings  ClientConfig
+		telemetry component.TelemetrySettings
+	}{
+		{
+			name:      "no_wrapping",
+			telemetry: nilProvidersSettings,
+		},
+		{
+			name:      "telemetry",
+			telemetry: componenttest.NewNopTelemetrySettings(),
+		},
+		{
+			name: "headers_and_compression",
+			settings: ClientConfig{
+				Headers:     map[string]configopaque.String{"header1": "value1"},
+				Compression: configcompression.TypeGzip,
+			},
+			telemetry: componenttest.NewNopTelemetrySettings(),
+		},
+		{
+			name: "headers_and_compression_without_telemetry",
+			settings: ClientConfig{
+				Headers:     map[string]configopaque.String{"header1": "value1"},
+				Compression: configcompression.TypeGzip,
+			},
+			telemetry: nilProvidersSettings,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+			closed := make(chan struct{})
+			server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+				if state == http.StateClosed {
+					close(closed)
+				}
+			}
+			server.Start()
+			defer server.Close()
+			tt.settings.Endpoint = server.URL
+			client, err := tt.settings.ToClient(context.Background(), componenttest.NewNopHost(), tt.telemetry)
+			require.NoError(t, err)
+			resp, err := client.Post(server.URL, "text/plain", strings.NewReader("test"))
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+
+			// The idle connection is closed by the client.
+			client.CloseIdleConnections()
+			select {
+			case <-closed:
+			case <-time.After(time.Second):
+				t.Fatal("the idle connection was not closed")
+			}
+		})
+	}
+}
+
 func TestHttpClientHostHeader(t *testing.T) {
 	hostHeader := "th"
 	tt := struct {
@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confighttp // import "go.opentelemetry.io/collector/config/confighttp"
+
+import (
+	"net"
+	"sync"
+)
+
+// limitListener is a net.Listener accepting at most max simultaneous connections.
+// Unlike golang.org/x/net/netutil.LimitListener, the connections beyond the limit
+// are not left waiting in the backlog: they are accepted and closed right away.
+type limitListener struct {
+	net.Listener
+
+	mu     sync.Mutex
+	active int
+	max    int
+}
+
+func newLimitListener(l net.Listener, maxConns int) net.Listener {
+	return &limitListener{Listener: l, max: maxConns}
+}
+
+func (l *limitListener) Accept() (net.Conn, error) {
+	for {
+		c, err := l.Listener.Accept()
+		if err != nil {
+			return nil, err
+		}
+		if l.acquire() {
+			return &limitListenerConn{Conn: c, release: l.release}, nil
+		}
+		_ = c.Close()
+	}
+}
+
+func (l *limitListener) acquire() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.active >= l.max {
+		return false
+	}
+	l.active++
+	return true
+}
+
+func (l *limitListener) release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.active--
+}
+
+type limitListenerConn struct {
+	net.Conn
+	releaseOnce sync.Once
+	release     func()
+}
+
+func (c *limitListenerConn) Close() error {
+	err := c.Conn.Close()
+	c.releaseOnce.Do(c.release)
+	return err
+}
@@ -15,6 +15,12 @@
 // like fmt.Stringer, encoding.TextMarshaler and others to ensure that the
 // underlying value is masked when printed or serialized.
 //
+// To keep secrets out of the configuration, a configopaque.String can be sourced from
+// a file with the `${file:<path>}` syntax of the file provider of the configuration,
+// for example `${file:/etc/otelcol/token}`. The value is still masked. The trailing
+// newline of a file made of a single line is trimmed, so the file can be written with
+// e.g. `echo "$TOKEN" > /etc/otelcol/token`.
+//
 // If new interfaces that would leak opaque values are added to the standard library
 // or become widely used in the Go ecosystem, these will eventually be implemented
 // by configopaque.String as well. This is not considered a breaking change.
@@ -123,6 +123,11 @@ type ServerConfig struct {
 	// Reload the ClientCAs file when it is modified
 	// (optional, default false)
 	ReloadClientCAFile bool `mapstructure:"client_ca_file_reload"`
+
+	// Path to a DER-encoded OCSP response to staple to the server certificate during
+	// the TLS handshake. The file is reloaded on the first handshake after ReloadInterval, and
+	// the last valid response keeps being stapled if reloading fails. (optional)
+	OCSPFile string `mapstructure:"ocsp_file"`
 }
 
 // NewDefaultServerConfig creates a new TLSServerSetting with any default values set.
@@ -196,6 +201,10 @@ func (c Config) Validate() error {
 		return errors.New("invalid TLS configuration: min_version cannot be greater than max_version")
 	}
 
+	if _, err := convertCipherSuites(c.CipherSuites); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -410,6 +419,16 @@ func (c ServerConfig) LoadTLSConfig(_ context.Context) (*tls.Config, error) {
 		tlsCfg.ClientCAs = reloader.certPool
 		tlsCfg.ClientAuth = tls.RequireAndVerifyClientCert
 	}
+	if c.OCSPFile != "" {
+		if tlsCfg.GetCertificate == nil {
+			return nil, errors.New("failed to load TLS config: ocsp_file requires a server certificate")
+		}
+		stapler, err := newOCSPStapler(c.OCSPFile, c.ReloadInterval)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load TLS config: %w", err)
+		}
+		tlsCfg.GetCertificate = stapler.wrapGetCertificate(tlsCfg.GetCertificate)
+	}
 	return tlsCfg, nil
 }
 
@@ -672,6 +672,9 @@ func TestConfigValidate(t *testing.T) {
 		{name: `TLS Config ["0.4", ""] to give [Error]`, tlsConfig: Config{MinVersion: "0.4", MaxVersion: ""}, errorTxt: `invalid TLS min_version: unsupported TLS version: "0.4"`},
 		{name: `TLS Config ["1.2", "1.1"] to give [Error]`, tlsConfig: Config{MinVersion: "1.2", MaxVersion: "1.1"}, errorTxt: `invalid TLS configuration: min_version cannot be greater than max_version`},
 		{name: `TLS Config with both CA File and PEM`, tlsConfig: Config{CAFile: "test", CAPem: "test"}, errorTxt: `provide either a CA file or the PEM-encoded string, but not both`},
+		{name: `TLS Config with known cipher suites to be valid`, tlsConfig: Config{CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", "TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384"}}},
+		{name: `TLS Config with unknown cipher suite to give [Error]`, tlsConfig: Config{CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", "FOO"}}, errorTxt: `invalid TLS cipher suite: "FOO"`},
+		{name: `TLS Config with insecure cipher suite to give [Error]`, tlsConfig: Config{CipherSuites: []string{"TLS_RSA_WITH_RC4_128_SHA"}}, errorTxt: `invalid TLS cipher suite: "TLS_RSA_WITH_RC4_128_SHA"`},
 	}
 
 	for _, test := range tests {
@@ -736,6 +739,27 @@ invalid TLS cipher suite: "BAR"`,
 	}
 }
 
+func TestLoadTLSServerConfigVersionsAndCipherSuites(t *testing.T) {
+	tlsSetting := ServerConfig{
+		Config: Config{
+			MinVersion:   "1.2",
+			MaxVersion:   "1.3",
+			CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", "TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384"},
+		},
+	}
+	require.NoError(t, tlsSetting.Validate())
+	tlsCfg, err := tlsSetting.LoadTLSConfig(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, uint16(tls.VersionTLS12), tlsCfg.MinVersion)
+	assert.Equal(t, uint16(tls.VersionTLS13), tlsCfg.MaxVersion)
+	assert.Equal(t, []uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256, tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384}, tlsCfg.CipherSuites)
+
+	// Without any setting the server does not accept versions older than TLS 1.2.
+	tlsCfg, err = NewDefaultServerConfig().LoadTLSConfig(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, uint16(tls.VersionTLS12), tlsCfg.MinVersion)
+}
+
 func TestSystemCertPool(t *testing.T) {
 	anError := errors.New("my error")
 	tests := []struct {
@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configtls // import "go.opentelemetry.io/collector/config/configtls"
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+)
+
+// ocspStapler holds the OCSP response stapled to the server certificate.
+// The response is reloaded from disk during the handshakes once the reload interval
+// has elapsed, and the last successfully loaded response is kept if reloading fails.
+type ocspStapler struct {
+	path     string
+	interval time.Duration
+	state    atomic.Pointer[ocspState]
+}
+
+// ocspState is the stapled response and the time after which it is reloaded.
+type ocspState struct {
+	staple     []byte
+	nextReload time.Time
+}
+
+func newOCSPStapler(path string, interval time.Duration) (*ocspStapler, error) {
+	staple, err := loadOCSPResponse(path)
+	if err != nil {
+		return nil, err
+	}
+	s := &ocspStapler{path: path, interval: interval}
+	s.state.Store(&ocspState{staple: staple, nextReload: time.Now().Add(interval)})
+	return s, nil
+}
+
+func loadOCSPResponse(path string) ([]byte, error) {
+	staple, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load OCSP response %s: %w", path, err)
+	}
+	if len(staple) == 0 {
+		return nil, fmt.Errorf("failed to load OCSP response %s: %w", path, errors.New("empty file"))
+	}
+	return staple, nil
+}
+
+// getStaple returns the current OCSP response, reloading it if the reload interval
+// has elapsed. Only the handshake claiming the reload reads the file, the others
+// keep stapling the current response meanwhile.
+func (s *ocspStapler) getStaple() []byte {
+	state := s.state.Load()
+	now := time.Now()
+	if s.interval == 0 || now.Before(state.nextReload) {
+		return state.staple
+	}
+	next := &ocspState{staple: state.staple, nextReload: now.Add(s.interval)}
+	if !s.state.CompareAndSwap(state, next) {
+		return state.staple
+	}
+	staple, err := loadOCSPResponse(s.path)
+	if err != nil {
+		return next.staple
+	}
+	s.state.CompareAndSwap(next, &ocspState{staple: staple, nextReload: next.nextReload})
+	return staple
+}
+
+// wrapGetCertificate returns a GetCertificate function that staples the current
+// OCSP response to the certificates returned by getCertificate.
+func (s *ocspStapler) wrapGetCertificate(getCertificate func(*tls.ClientHelloInfo) (*tls.Certificate, error)) func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return func(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+		cert, err := getCertificate(hello)
+		if err != nil || cert == nil {
+			return cert, err
+		}
+		stapled := *cert
+		stapled.OCSPStaple = s.getStaple()
+		return &stapled, nil
+	}
+}
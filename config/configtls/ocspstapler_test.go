@@ -0,0 +1,143 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configtls
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeOCSPResponse(t *testing.T, path string, content string) {
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+}
+
+// handshakeOCSPResponse performs a TLS handshake against a server using tlsCfg
+// and returns the OCSP response stapled by the server.
+func handshakeOCSPResponse(t *testing.T, tlsCfg *tls.Config) []byte {
+	serverConn, clientConn := net.Pipe()
+	defer clientConn.Close()
+	defer serverConn.Close()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- tls.Server(serverConn, tlsCfg).Handshake()
+	}()
+
+	//nolint:gosec // The test only checks the stapled OCSP response.
+	client := tls.Client(clientConn, &tls.Config{InsecureSkipVerify: true})
+	require.NoError(t, client.Handshake())
+	require.NoError(t, <-done)
+	return client.ConnectionState().OCSPResponse
+}
+
+func TestLoadTLSServerConfigOCSPStapling(t *testing.T) {
+	ocspPath := filepath.Join(t.TempDir(), "ocsp.der")
+	writeOCSPResponse(t, ocspPath, "ocsp-response-1")
+
+	tlsSetting := ServerConfig{
+		Config: Config{
+			CertFile: filepath.Join("testdata", "server-1.crt"),
+			KeyFile:  filepath.Join("testdata", "server-1.key"),
+		},
+		OCSPFile: ocspPath,
+	}
+	tlsCfg, err := tlsSetting.LoadTLSConfig(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, []byte("ocsp-response-1"), handshakeOCSPResponse(t, tlsCfg))
+
+	// Without a reload interval the response is not reloaded.
+	writeOCSPResponse(t, ocspPath, "ocsp-response-2")
+	assert.Equal(t, []byte("ocsp-response-1"), handshakeOCSPResponse(t, tlsCfg))
+}
+
+func TestLoadTLSServerConfigOCSPStaplingReload(t *testing.T) {
+	ocspPath := filepath.Join(t.TempDir(), "ocsp.der")
+	writeOCSPResponse(t, ocspPath, "ocsp-response-1")
+
+	tlsSetting := ServerConfig{
+		Config: Config{
+			CertFile:       filepath.Join("testdata", "server-1.crt"),
+			KeyFile:        filepath.Join("testdata", "server-1.key"),
+			ReloadInterval: 10 * time.Millisecond,
+		},
+		OCSPFile: ocspPath,
+	}
+	tlsCfg, err := tlsSetting.LoadTLSConfig(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []byte("ocsp-response-1"), handshakeOCSPResponse(t, tlsCfg))
+
+	writeOCSPResponse(t, ocspPath, "ocsp-response-2")
+	assert.Eventually(t, func() bool {
+		return string(handshakeOCSPResponse(t, tlsCfg)) == "ocsp-response-2"
+	}, 5*time.Second, 20*time.Millisecond)
+
+	// A failed reload keeps the last valid response.
+	require.NoError(t, os.Remove(ocspPath))
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, []byte("ocsp-response-2"), handshakeOCSPResponse(t, tlsCfg))
+
+	// The response is reloaded again once the file is back.
+	writeOCSPResponse(t, ocspPath, "ocsp-response-3")
+	assert.Eventually(t, func() bool {
+		return string(handshakeOCSPResponse(t, tlsCfg)) == "ocsp-response-3"
+	}, 5*time.Second, 20*time.Millisecond)
+}
+
+func TestLoadTLSServerConfigOCSPStaplingErrors(t *testing.T) {
+	emptyPath := filepath.Join(t.TempDir(), "empty.der")
+	writeOCSPResponse(t, emptyPath, "")
+
+	tests := []struct {
+		name       string
+		tlsSetting ServerConfig
+		errorTxt   string
+	}{
+		{
+			name:       "no certificate",
+			tlsSetting: ServerConfig{OCSPFile: emptyPath},
+			errorTxt:   "failed to load TLS config: ocsp_file requires a server certificate",
+		},
+		{
+			name: "missing file",
+			tlsSetting: ServerConfig{
+				Config: Config{
+					CertFile: filepath.Join("testdata", "server-1.crt"),
+					KeyFile:  filepath.Join("testdata", "server-1.key"),
+				},
+				OCSPFile: "doesnt/exist",
+			},
+			errorTxt: "failed to load TLS config: failed to load OCSP response doesnt/exist: open doesnt/exist: no such file or directory",
+		},
+		{
+			name: "empty file",
+			tlsSetting: ServerConfig{
+				Config: Config{
+					CertFile: filepath.Join("testdata", "server-1.crt"),
+					KeyFile:  filepath.Join("testdata", "server-1.key"),
+				},
+				OCSPFile: emptyPath,
+			},
+			errorTxt: "failed to load TLS config: failed to load OCSP response " + emptyPath + ": empty file",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tlsCfg, err := test.tlsSetting.LoadTLSConfig(context.Background())
+			assert.Error(t, err)
+			if test.errorTxt != "" {
+				assert.EqualError(t, err, test.errorTxt)
+			}
+			assert.Nil(t, tlsCfg)
+		})
+	}
+}
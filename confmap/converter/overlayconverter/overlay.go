@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package overlayconverter // import "go.opentelemetry.io/collector/confmap/converter/overlayconverter"
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+
+	"go.opentelemetry.io/collector/confmap"
+)
+
+type converter struct {
+	envVar   string
+	overlays map[string]map[string]any
+}
+
+// NewFactory returns a factory for a confmap.Converter, which merges an environment-specific overlay
+// over the configuration. The overlay is selected from overlays by the environment name read from the
+// envVar environment variable. If the variable is unset or empty, the configuration is left unchanged.
+// Naming an environment without an overlay is an error.
+func NewFactory(envVar string, overlays map[string]map[string]any) confmap.ConverterFactory {
+	return confmap.NewConverterFactory(func(confmap.ConverterSettings) confmap.Converter {
+		return converter{
+			envVar:   envVar,
+			overlays: overlays,
+		}
+	})
+}
+
+func (c converter) Convert(_ context.Context, conf *confmap.Conf) error {
+	env := os.Getenv(c.envVar)
+	if env == "" {
+		return nil
+	}
+	overlay, ok := c.overlays[env]
+	if !ok {
+		return fmt.Errorf("unknown environment %q set in %s, valid environments are: %v", env, c.envVar, c.environments())
+	}
+	return conf.Merge(confmap.NewFromStringMap(overlay))
+}
+
+func (c converter) environments() []string {
+	envs := make([]string, 0, len(c.overlays))
+	for env := range c.overlays {
+		envs = append(envs, env)
+	}
+	sort.Strings(envs)
+	return envs
+}
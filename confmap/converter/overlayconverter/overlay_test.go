@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package overlayconverter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/confmap"
+)
+
+const envVar = "OTELCOL_TEST_ENVIRONMENT"
+
+var overlays = map[string]map[string]any{
+	"staging": {
+		"exporters": map[string]any{
+			"otlp": map[string]any{
+				"endpoint": "staging:4317",
+			},
+		},
+	},
+	"production": {
+		"exporters": map[string]any{
+			"otlp": map[string]any{
+				"endpoint":    "production:4317",
+				"compression": "zstd",
+			},
+		},
+	},
+}
+
+func newBaseConf() *confmap.Conf {
+	return confmap.NewFromStringMap(map[string]any{
+		"exporters": map[string]any{
+			"otlp": map[string]any{
+				"endpoint":    "localhost:4317",
+				"compression": "gzip",
+				"timeout":     "5s",
+			},
+		},
+	})
+}
+
+func convert(conf *confmap.Conf) error {
+	c := NewFactory(envVar, overlays).Create(confmap.ConverterSettings{Logger: zap.NewNop()})
+	return c.Convert(context.Background(), conf)
+}
+
+func TestConvertOverlay(t *testing.T) {
+	tests := []struct {
+		env      string
+		expected map[string]any
+	}{
+		{
+			env: "",
+			expected: map[string]any{
+				"endpoint":    "localhost:4317",
+				"compression": "gzip",
+				"timeout":     "5s",
+			},
+		},
+		{
+			env: "staging",
+			expected: map[string]any{
+				"endpoint":    "staging:4317",
+				"compression": "gzip",
+				"timeout":     "5s",
+			},
+		},
+		{
+			env: "production",
+			expected: map[string]any{
+				"endpoint":    "production:4317",
+				"compression": "zstd",
+				"timeout":     "5s",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.env, func(t *testing.T) {
+			t.Setenv(envVar, tt.env)
+			conf := newBaseConf()
+			require.NoError(t, convert(conf))
+			assert.Equal(t, map[string]any{"exporters": map[string]any{"otlp": tt.expected}}, conf.ToStringMap())
+		})
+	}
+}
+
+func TestConvertUnknownEnvironment(t *testing.T) {
+	t.Setenv(envVar, "dev")
+	conf := newBaseConf()
+	require.EqualError(t, convert(conf),
+		`unknown environment "dev" set in OTELCOL_TEST_ENVIRONMENT, valid environments are: [production staging]`)
+	assert.Equal(t, newBaseConf().ToStringMap(), conf.ToStringMap())
+}
@@ -5,6 +5,8 @@ package e2etest
 
 import (
 	"context"
+	"fmt"
+	"os"
 	"path/filepath"
 	"testing"
 
@@ -657,3 +659,55 @@ func TestIssue10949_UnsetVar(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, 0, cfg.Field)
 }
+
+func TestOpaqueStringFromFile(t *testing.T) {
+	dir := t.TempDir()
+	secretFile := filepath.Join(dir, "secret")
+	// The trailing newline of the file is trimmed.
+	require.NoError(t, os.WriteFile(secretFile, []byte("s3cr3t\n"), 0o600))
+	configFile := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte("field: ${file:"+secretFile+"}\n"), 0o600))
+
+	resolver, err := confmap.NewResolver(confmap.ResolverSettings{
+		URIs:              []string{configFile},
+		ProviderFactories: []confmap.ProviderFactory{fileprovider.NewFactory()},
+	})
+	require.NoError(t, err)
+	conf, err := resolver.Resolve(context.Background())
+	require.NoError(t, err)
+
+	var cfg TargetConfig[configopaque.String]
+	require.NoError(t, conf.Unmarshal(&cfg))
+	require.Equal(t, configopaque.String("s3cr3t"), cfg.Field)
+	// The value read from the file is still masked, when printed and when marshaled.
+	require.Equal(t, "{[REDACTED]}", fmt.Sprint(cfg))
+	text, err := cfg.Field.MarshalText()
+	require.NoError(t, err)
+	require.Equal(t, "[REDACTED]", string(text))
+	marshaled := confmap.New()
+	require.NoError(t, marshaled.Marshal(cfg))
+	require.Equal(t, map[string]any{"field": "[REDACTED]"}, marshaled.ToStringMap())
+}
+
+func TestOpaqueStringInlineNewline(t *testing.T) {
+	t.Setenv("SECRET", "s3cr3t\n")
+	for _, value := range []string{`"s3cr3t\n"`, "${env:SECRET}"} {
+		t.Run(value, func(t *testing.T) {
+			configFile := filepath.Join(t.TempDir(), "config.yaml")
+			require.NoError(t, os.WriteFile(configFile, []byte("field: "+value+"\n"), 0o600))
+
+			resolver, err := confmap.NewResolver(confmap.ResolverSettings{
+				URIs:              []string{configFile},
+				ProviderFactories: []confmap.ProviderFactory{fileprovider.NewFactory(), envprovider.NewFactory()},
+			})
+			require.NoError(t, err)
+			conf, err := resolver.Resolve(context.Background())
+			require.NoError(t, err)
+
+			// Only the values read from a file have their trailing newline trimmed.
+			var cfg TargetConfig[configopaque.String]
+			require.NoError(t, conf.Unmarshal(&cfg))
+			require.Equal(t, configopaque.String("s3cr3t\n"), cfg.Field)
+		})
+	}
+}
@@ -4,6 +4,7 @@
 package fileprovider // import "go.opentelemetry.io/collector/confmap/provider/fileprovider"
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"os"
@@ -32,6 +33,9 @@ type provider struct{}
 // `file:/path/to/file` - absolute path (unix, windows)
 // `file:c:/path/to/file` - absolute path including drive-letter (windows)
 // `file:c:\path\to\file` - absolute path including drive-letter (windows)
+//
+// The trailing newline of a file made of a single line is trimmed, so that a secret written with e.g.
+// `echo "$TOKEN" > /path/to/token` can be referenced with `${file:/path/to/token}`.
 func NewFactory() confmap.ProviderFactory {
 	return confmap.NewProviderFactory(newProvider)
 }
@@ -51,7 +55,17 @@ func (fmp *provider) Retrieve(_ context.Context, uri string, _ confmap.WatcherFu
 		return nil, fmt.Errorf("unable to read the file %v: %w", uri, err)
 	}
 
-	return confmap.NewRetrievedFromYAML(content)
+	return confmap.NewRetrievedFromYAML(trimTrailingNewline(content))
+}
+
+// trimTrailingNewline returns content without its trailing newline if it is made of a single line.
+func trimTrailingNewline(content []byte) []byte {
+	trimmed := bytes.TrimSuffix(content, []byte("\n"))
+	trimmed = bytes.TrimSuffix(trimmed, []byte("\r"))
+	if bytes.ContainsAny(trimmed, "\r\n") {
+		return content
+	}
+	return trimmed
 }
 
 func (*provider) Scheme() string {
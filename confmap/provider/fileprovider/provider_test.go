@@ -62,6 +62,27 @@ func TestInvalidYAML(t *testing.T) {
 	require.NoError(t, fp.Shutdown(context.Background()))
 }
 
+func TestTrailingNewline(t *testing.T) {
+	fp := createProvider()
+	for content, expected := range map[string]string{
+		"s3cr3t\n":              "s3cr3t",
+		"s3cr3t\r\n":            "s3cr3t",
+		"s3cr3t":                "s3cr3t",
+		"s3cr3t\n\n":            "s3cr3t\n\n",
+		"line1\nline2\n":        "line1\nline2\n",
+		"endpoint: localhost\n": "endpoint: localhost",
+	} {
+		path := filepath.Join(t.TempDir(), "file")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		ret, err := fp.Retrieve(context.Background(), fileSchemePrefix+path, nil)
+		require.NoError(t, err)
+		str, err := ret.AsString()
+		require.NoError(t, err)
+		assert.Equal(t, expected, str, content)
+	}
+	require.NoError(t, fp.Shutdown(context.Background()))
+}
+
 func TestRelativePath(t *testing.T) {
 	fp := createProvider()
 	ret, err := fp.Retrieve(context.Background(), fileSchemePrefix+filepath.Join("testdata", "default-config.yaml"), nil)
@@ -0,0 +1,6 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package attributekeys provides consumers renaming or dropping the attributes whose key does not
+// follow a naming convention, lower-case dot-separated keys by default.
+package attributekeys // import "go.opentelemetry.io/collector/consumer/attributekeys"
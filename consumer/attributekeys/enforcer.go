@@ -0,0 +1,266 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package attributekeys // import "go.opentelemetry.io/collector/consumer/attributekeys"
+
+import (
+	"strings"
+	"sync/atomic"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/internal/wrapper"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// Policy specifies how an Enforcer handles the attribute keys not matching the convention.
+type Policy int32
+
+const (
+	// PolicyDrop drops the attributes whose key does not match the convention.
+	PolicyDrop Policy = iota
+	// PolicyNormalize renames the attributes whose key does not match the convention with the normalized key.
+	PolicyNormalize
+)
+
+// Config configures an Enforcer.
+type Config struct {
+	// Policy is the handling of the attribute keys not matching the convention.
+	Policy Policy
+
+	// Conforms reports whether an attribute key matches the convention.
+	// IsLowerDotted is used when nil.
+	Conforms func(key string) bool
+
+	// Normalize returns the key to use for a non-conforming attribute key with PolicyNormalize.
+	// NormalizeLowerDotted is used when nil.
+	Normalize func(key string) string
+}
+
+// Enforcer enforces a naming convention on the keys of the resource, scope, span, span event, span link,
+// metric data point and log record attributes. The keys of the maps nested in the attribute values are
+// left unchanged.
+//
+// With PolicyNormalize, the attributes whose normalized key still does not match the convention, or
+// matches a key already present in the same attributes, are dropped.
+type Enforcer struct {
+	policy    Policy
+	conforms  func(string) bool
+	normalize func(string) string
+
+	normalizedKeys atomic.Int64
+	droppedKeys    atomic.Int64
+}
+
+// New returns an Enforcer for the configuration.
+func New(cfg Config) *Enforcer {
+	e := &Enforcer{
+		policy:    cfg.Policy,
+		conforms:  cfg.Conforms,
+		normalize: cfg.Normalize,
+	}
+	if e.conforms == nil {
+		e.conforms = IsLowerDotted
+	}
+	if e.normalize == nil {
+		e.normalize = NormalizeLowerDotted
+	}
+	return e
+}
+
+// NormalizedKeys returns the number of attributes which were renamed with their normalized key.
+func (e *Enforcer) NormalizedKeys() int64 {
+	return e.normalizedKeys.Load()
+}
+
+// DroppedKeys returns the number of attributes which were dropped.
+func (e *Enforcer) DroppedKeys() int64 {
+	return e.droppedKeys.Load()
+}
+
+// Traces returns a consumer.Traces enforcing the convention on the traces before passing them to next.
+func (e *Enforcer) Traces(next consumer.Traces) (consumer.Traces, error) {
+	return wrapper.ProcessTraces(next, true, func(td ptrace.Traces) error {
+		rss := td.ResourceSpans()
+		for i := 0; i < rss.Len(); i++ {
+			rs := rss.At(i)
+			e.enforce(rs.Resource().Attributes())
+			sss := rs.ScopeSpans()
+			for j := 0; j < sss.Len(); j++ {
+				ss := sss.At(j)
+				e.enforce(ss.Scope().Attributes())
+				spans := ss.Spans()
+				for k := 0; k < spans.Len(); k++ {
+					span := spans.At(k)
+					e.enforce(span.Attributes())
+					events := span.Events()
+					for l := 0; l < events.Len(); l++ {
+						e.enforce(events.At(l).Attributes())
+					}
+					links := span.Links()
+					for l := 0; l < links.Len(); l++ {
+						e.enforce(links.At(l).Attributes())
+					}
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// Metrics returns a consumer.Metrics enforcing the convention on the metrics before passing them to next.
+func (e *Enforcer) Metrics(next consumer.Metrics) (consumer.Metrics, error) {
+	return wrapper.ProcessMetrics(next, true, func(md pmetric.Metrics) error {
+		rms := md.ResourceMetrics()
+		for i := 0; i < rms.Len(); i++ {
+			rm := rms.At(i)
+			e.enforce(rm.Resource().Attributes())
+			sms := rm.ScopeMetrics()
+			for j := 0; j < sms.Len(); j++ {
+				sm := sms.At(j)
+				e.enforce(sm.Scope().Attributes())
+				metrics := sm.Metrics()
+				for k := 0; k < metrics.Len(); k++ {
+					e.enforceMetric(metrics.At(k))
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// Logs returns a consumer.Logs enforcing the convention on the logs before passing them to next.
+func (e *Enforcer) Logs(next consumer.Logs) (consumer.Logs, error) {
+	return wrapper.ProcessLogs(next, true, func(ld plog.Logs) error {
+		rls := ld.ResourceLogs()
+		for i := 0; i < rls.Len(); i++ {
+			rl := rls.At(i)
+			e.enforce(rl.Resource().Attributes())
+			sls := rl.ScopeLogs()
+			for j := 0; j < sls.Len(); j++ {
+				sl := sls.At(j)
+				e.enforce(sl.Scope().Attributes())
+				lrs := sl.LogRecords()
+				for k := 0; k < lrs.Len(); k++ {
+					e.enforce(lrs.At(k).Attributes())
+				}
+			}
+		}
+		return nil
+	})
+}
+
+func (e *Enforcer) enforceMetric(m pmetric.Metric) {
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		dps := m.Gauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			e.enforce(dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeSum:
+		dps := m.Sum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			e.enforce(dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeHistogram:
+		dps := m.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			e.enforce(dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		dps := m.ExponentialHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			e.enforce(dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeSummary:
+		dps := m.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			e.enforce(dps.At(i).Attributes())
+		}
+	}
+}
+
+// enforce drops or renames the attributes of m whose key does not match the convention.
+func (e *Enforcer) enforce(m pcommon.Map) {
+	var renamed pcommon.Map
+	hasRenamed := false
+	m.RemoveIf(func(k string, v pcommon.Value) bool {
+		if e.conforms(k) {
+			return false
+		}
+		if e.policy == PolicyNormalize {
+			if !hasRenamed {
+				renamed = pcommon.NewMap()
+				hasRenamed = true
+			}
+			nk := e.normalize(k)
+			if _, dup := renamed.Get(nk); e.conforms(nk) && !dup {
+				v.CopyTo(renamed.PutEmpty(nk))
+				return true
+			}
+		}
+		e.droppedKeys.Add(1)
+		return true
+	})
+	if !hasRenamed {
+		return
+	}
+	renamed.Range(func(k string, v pcommon.Value) bool {
+		if _, exists := m.Get(k); exists {
+			e.droppedKeys.Add(1)
+			return true
+		}
+		v.CopyTo(m.PutEmpty(k))
+		e.normalizedKeys.Add(1)
+		return true
+	})
+}
+
+// IsLowerDotted reports whether key is made of one or more non-empty segments separated by dots, the
+// segments being made of lower case ASCII letters, digits and underscores, e.g. "http.request.method".
+func IsLowerDotted(key string) bool {
+	if key == "" {
+		return false
+	}
+	for _, segment := range strings.Split(key, ".") {
+		if segment == "" {
+			return false
+		}
+		for i := 0; i < len(segment); i++ {
+			if !isLowerDottedChar(segment[i]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// NormalizeLowerDotted lower cases key, replaces the characters other than ASCII letters, digits,
+// underscores and dots with underscores, and removes the empty segments, e.g. "HTTP-Method..Name"
+// is normalized to "http_method.name".
+func NormalizeLowerDotted(key string) string {
+	var sb strings.Builder
+	sb.Grow(len(key))
+	for _, segment := range strings.Split(key, ".") {
+		if segment == "" {
+			continue
+		}
+		if sb.Len() > 0 {
+			sb.WriteByte('.')
+		}
+		for _, r := range strings.ToLower(segment) {
+			if r < 0x80 && isLowerDottedChar(byte(r)) {
+				sb.WriteRune(r)
+			} else {
+				sb.WriteByte('_')
+			}
+		}
+	}
+	return sb.String()
+}
+
+func isLowerDottedChar(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') || c == '_'
+}
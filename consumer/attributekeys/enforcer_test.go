@@ -0,0 +1,165 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package attributekeys
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func newLogs() plog.Logs {
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("Host.Name", "host")
+	lr := rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	lr.Attributes().PutStr("http.request.method", "GET")
+	lr.Attributes().PutStr("User-Agent", "curl")
+	lr.Attributes().PutStr("user_agent", "existing")
+	lr.Attributes().PutStr("Trace..ID", "abc")
+	lr.Attributes().PutStr("...", "empty")
+	return ld
+}
+
+func TestLogsDrop(t *testing.T) {
+	var received []plog.Logs
+	next, err := consumer.NewLogs(func(_ context.Context, ld plog.Logs) error {
+		received = append(received, ld)
+		return nil
+	})
+	require.NoError(t, err)
+	e := New(Config{Policy: PolicyDrop})
+	c, err := e.Logs(next)
+	require.NoError(t, err)
+	assert.True(t, c.Capabilities().MutatesData)
+	require.NoError(t, c.ConsumeLogs(context.Background(), newLogs()))
+
+	require.Len(t, received, 1)
+	rl := received[0].ResourceLogs().At(0)
+	assert.Zero(t, rl.Resource().Attributes().Len())
+	assert.Equal(t, map[string]any{
+		"http.request.method": "GET",
+		"user_agent":          "existing",
+	}, rl.ScopeLogs().At(0).LogRecords().At(0).Attributes().AsRaw())
+	assert.Equal(t, int64(4), e.DroppedKeys())
+	assert.Zero(t, e.NormalizedKeys())
+}
+
+func TestLogsNormalize(t *testing.T) {
+	var received []plog.Logs
+	next, err := consumer.NewLogs(func(_ context.Context, ld plog.Logs) error {
+		received = append(received, ld)
+		return nil
+	})
+	require.NoError(t, err)
+	e := New(Config{Policy: PolicyNormalize})
+	c, err := e.Logs(next)
+	require.NoError(t, err)
+	require.NoError(t, c.ConsumeLogs(context.Background(), newLogs()))
+
+	require.Len(t, received, 1)
+	rl := received[0].ResourceLogs().At(0)
+	assert.Equal(t, map[string]any{"host.name": "host"}, rl.Resource().Attributes().AsRaw())
+	// "User-Agent" collides with the existing "user_agent" and "..." normalizes to an empty key: both are dropped.
+	assert.Equal(t, map[string]any{
+		"http.request.method": "GET",
+		"user_agent":          "existing",
+		"trace.id":            "abc",
+	}, rl.ScopeLogs().At(0).LogRecords().At(0).Attributes().AsRaw())
+	assert.Equal(t, int64(2), e.NormalizedKeys())
+	assert.Equal(t, int64(2), e.DroppedKeys())
+}
+
+func TestCustomConvention(t *testing.T) {
+	var received []ptrace.Traces
+	next, err := consumer.NewTraces(func(_ context.Context, td ptrace.Traces) error {
+		received = append(received, td)
+		return nil
+	})
+	require.NoError(t, err)
+	e := New(Config{
+		Policy:    PolicyNormalize,
+		Conforms:  func(key string) bool { return strings.HasPrefix(key, "app.") },
+		Normalize: func(key string) string { return "app." + key },
+	})
+	c, err := e.Traces(next)
+	require.NoError(t, err)
+
+	td := ptrace.NewTraces()
+	ss := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty()
+	ss.Scope().Attributes().PutStr("app.scope", "kept")
+	span := ss.Spans().AppendEmpty()
+	span.Attributes().PutInt("retries", 3)
+	span.Events().AppendEmpty().Attributes().PutStr("reason", "timeout")
+	span.Links().AppendEmpty().Attributes().PutBool("app.sampled", true)
+	require.NoError(t, c.ConsumeTraces(context.Background(), td))
+
+	ss = received[0].ResourceSpans().At(0).ScopeSpans().At(0)
+	assert.Equal(t, map[string]any{"app.scope": "kept"}, ss.Scope().Attributes().AsRaw())
+	span = ss.Spans().At(0)
+	assert.Equal(t, map[string]any{"app.retries": int64(3)}, span.Attributes().AsRaw())
+	assert.Equal(t, map[string]any{"app.reason": "timeout"}, span.Events().At(0).Attributes().AsRaw())
+	assert.Equal(t, map[string]any{"app.sampled": true}, span.Links().At(0).Attributes().AsRaw())
+	assert.Equal(t, int64(2), e.NormalizedKeys())
+	assert.Zero(t, e.DroppedKeys())
+}
+
+func TestMetrics(t *testing.T) {
+	md := pmetric.NewMetrics()
+	metrics := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	metrics.AppendEmpty().SetEmptyGauge().DataPoints().AppendEmpty().Attributes().PutStr("Path", "/a")
+	metrics.AppendEmpty().SetEmptySum().DataPoints().AppendEmpty().Attributes().PutStr("path", "/b")
+	metrics.AppendEmpty().SetEmptyHistogram().DataPoints().AppendEmpty().Attributes().PutStr("Status Code", "200")
+	metrics.AppendEmpty().SetEmptyExponentialHistogram().DataPoints().AppendEmpty().Attributes().PutStr("Path", "/c")
+	metrics.AppendEmpty().SetEmptySummary().DataPoints().AppendEmpty().Attributes().PutStr("Path", "/d")
+
+	var received []pmetric.Metrics
+	next, err := consumer.NewMetrics(func(_ context.Context, md pmetric.Metrics) error {
+		received = append(received, md)
+		return nil
+	})
+	require.NoError(t, err)
+	e := New(Config{Policy: PolicyDrop})
+	c, err := e.Metrics(next)
+	require.NoError(t, err)
+	require.NoError(t, c.ConsumeMetrics(context.Background(), md))
+
+	// The data points are kept without their non-conforming attributes.
+	assert.Equal(t, 5, received[0].DataPointCount())
+	metrics = received[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	assert.Zero(t, metrics.At(0).Gauge().DataPoints().At(0).Attributes().Len())
+	assert.Equal(t, map[string]any{"path": "/b"}, metrics.At(1).Sum().DataPoints().At(0).Attributes().AsRaw())
+	assert.Equal(t, int64(4), e.DroppedKeys())
+}
+
+func TestIsLowerDotted(t *testing.T) {
+	for _, key := range []string{"a", "http.request.method", "k8s.pod.name", "user_agent.original"} {
+		assert.Truef(t, IsLowerDotted(key), "key %q", key)
+	}
+	for _, key := range []string{"", ".", "a.", ".a", "a..b", "Host", "user-agent", "with space", "héllo"} {
+		assert.Falsef(t, IsLowerDotted(key), "key %q", key)
+	}
+}
+
+func TestNormalizeLowerDotted(t *testing.T) {
+	tests := map[string]string{
+		"http.request.method": "http.request.method",
+		"HTTP-Method..Name":   "http_method.name",
+		".Leading.Trailing.":  "leading.trailing",
+		"Status Code":         "status_code",
+		"héllo":               "h_llo",
+		"...":                 "",
+	}
+	for key, want := range tests {
+		assert.Equalf(t, want, NormalizeLowerDotted(key), "key %q", key)
+	}
+}
@@ -0,0 +1,6 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package attributetypes provides consumers coercing the values of configured resource attributes
+// to a single type, e.g. so that a port sent either as a string or an int is always an int.
+package attributetypes // import "go.opentelemetry.io/collector/consumer/attributetypes"
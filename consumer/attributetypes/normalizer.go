@@ -0,0 +1,170 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package attributetypes // import "go.opentelemetry.io/collector/consumer/attributetypes"
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"sync/atomic"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/internal/wrapper"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// Config configures a Normalizer.
+type Config struct {
+	// Types maps the resource attribute keys to the type their values are coerced to, one of
+	// pcommon.ValueTypeStr, pcommon.ValueTypeInt, pcommon.ValueTypeDouble and pcommon.ValueTypeBool.
+	Types map[string]pcommon.ValueType
+
+	// DropUncoercible drops the attributes whose value cannot be coerced to the configured type.
+	// They are left unchanged otherwise.
+	DropUncoercible bool
+}
+
+// Normalizer coerces the values of the configured resource attributes to their configured type.
+//
+// The values are coerced as follows:
+//   - to a string, the ints, doubles and bools are formatted.
+//   - to an int, the strings are parsed as base 10 integers, and the doubles without a fractional
+//     part are converted.
+//   - to a double, the strings are parsed as floating-point numbers, and the ints are converted.
+//   - to a bool, the strings are parsed with strconv.ParseBool.
+//
+// The other values, e.g. the maps, slices and bytes, cannot be coerced.
+type Normalizer struct {
+	types           map[string]pcommon.ValueType
+	dropUncoercible bool
+
+	coercedValues     atomic.Int64
+	uncoercibleValues atomic.Int64
+}
+
+// New returns a Normalizer for the configuration.
+func New(cfg Config) (*Normalizer, error) {
+	types := make(map[string]pcommon.ValueType, len(cfg.Types))
+	for k, t := range cfg.Types {
+		switch t {
+		case pcommon.ValueTypeStr, pcommon.ValueTypeInt, pcommon.ValueTypeDouble, pcommon.ValueTypeBool:
+			types[k] = t
+		default:
+			return nil, fmt.Errorf("unsupported type %q for the attribute %q", t, k)
+		}
+	}
+	return &Normalizer{types: types, dropUncoercible: cfg.DropUncoercible}, nil
+}
+
+// CoercedValues returns the number of attribute values which were coerced to their configured type.
+func (n *Normalizer) CoercedValues() int64 {
+	return n.coercedValues.Load()
+}
+
+// UncoercibleValues returns the number of attribute values which could not be coerced to their
+// configured type, and were dropped or left unchanged.
+func (n *Normalizer) UncoercibleValues() int64 {
+	return n.uncoercibleValues.Load()
+}
+
+// Traces returns a consumer.Traces normalizing the resource attributes of the traces before passing them to next.
+func (n *Normalizer) Traces(next consumer.Traces) (consumer.Traces, error) {
+	return wrapper.ProcessTraces(next, true, func(td ptrace.Traces) error {
+		rss := td.ResourceSpans()
+		for i := 0; i < rss.Len(); i++ {
+			n.normalize(rss.At(i).Resource().Attributes())
+		}
+		return nil
+	})
+}
+
+// Metrics returns a consumer.Metrics normalizing the resource attributes of the metrics before passing them to next.
+func (n *Normalizer) Metrics(next consumer.Metrics) (consumer.Metrics, error) {
+	return wrapper.ProcessMetrics(next, true, func(md pmetric.Metrics) error {
+		rms := md.ResourceMetrics()
+		for i := 0; i < rms.Len(); i++ {
+			n.normalize(rms.At(i).Resource().Attributes())
+		}
+		return nil
+	})
+}
+
+// Logs returns a consumer.Logs normalizing the resource attributes of the logs before passing them to next.
+func (n *Normalizer) Logs(next consumer.Logs) (consumer.Logs, error) {
+	return wrapper.ProcessLogs(next, true, func(ld plog.Logs) error {
+		rls := ld.ResourceLogs()
+		for i := 0; i < rls.Len(); i++ {
+			n.normalize(rls.At(i).Resource().Attributes())
+		}
+		return nil
+	})
+}
+
+// normalize coerces the values of the configured attributes of m, dropping the uncoercible ones if configured.
+func (n *Normalizer) normalize(m pcommon.Map) {
+	if len(n.types) == 0 {
+		return
+	}
+	m.RemoveIf(func(k string, v pcommon.Value) bool {
+		t, ok := n.types[k]
+		if !ok || v.Type() == t {
+			return false
+		}
+		if coerce(v, t) {
+			n.coercedValues.Add(1)
+			return false
+		}
+		n.uncoercibleValues.Add(1)
+		return n.dropUncoercible
+	})
+}
+
+// coerce sets v to its value coerced to t, and reports whether the value could be coerced.
+// v is left unchanged if not.
+func coerce(v pcommon.Value, t pcommon.ValueType) bool {
+	switch t {
+	case pcommon.ValueTypeStr:
+		switch v.Type() {
+		case pcommon.ValueTypeInt, pcommon.ValueTypeDouble, pcommon.ValueTypeBool:
+			v.SetStr(v.AsString())
+			return true
+		}
+	case pcommon.ValueTypeInt:
+		switch v.Type() {
+		case pcommon.ValueTypeStr:
+			if i, err := strconv.ParseInt(v.Str(), 10, 64); err == nil {
+				v.SetInt(i)
+				return true
+			}
+		case pcommon.ValueTypeDouble:
+			// The doubles from -2^63 included to 2^63 excluded fit in an int64.
+			if d := v.Double(); d == math.Trunc(d) && d >= math.MinInt64 && d < math.MaxInt64 {
+				v.SetInt(int64(d))
+				return true
+			}
+		}
+	case pcommon.ValueTypeDouble:
+		switch v.Type() {
+		case pcommon.ValueTypeStr:
+			if d, err := strconv.ParseFloat(v.Str(), 64); err == nil {
+				v.SetDouble(d)
+				return true
+			}
+		case pcommon.ValueTypeInt:
+			v.SetDouble(float64(v.Int()))
+			return true
+		}
+	case pcommon.ValueTypeBool:
+		if v.Type() == pcommon.ValueTypeStr {
+			if b, err := strconv.ParseBool(v.Str()); err == nil {
+				v.SetBool(b)
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,172 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package attributetypes
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+var testTypes = map[string]pcommon.ValueType{
+	"http.status_code": pcommon.ValueTypeInt,
+	"service.version":  pcommon.ValueTypeStr,
+	"sampling.ratio":   pcommon.ValueTypeDouble,
+	"debug":            pcommon.ValueTypeBool,
+}
+
+// putResource fills a resource with values of all the types for the configured keys.
+func putResource(res pcommon.Resource, statusCode string, version int64, ratio string, debug string) {
+	res.Attributes().PutStr("http.status_code", statusCode)
+	res.Attributes().PutInt("service.version", version)
+	res.Attributes().PutStr("sampling.ratio", ratio)
+	res.Attributes().PutStr("debug", debug)
+	res.Attributes().PutStr("other", "404")
+}
+
+func TestLogs(t *testing.T) {
+	n, err := New(Config{Types: testTypes})
+	require.NoError(t, err)
+	var received []plog.Logs
+	next, err := consumer.NewLogs(func(_ context.Context, ld plog.Logs) error {
+		received = append(received, ld)
+		return nil
+	})
+	require.NoError(t, err)
+	c, err := n.Logs(next)
+	require.NoError(t, err)
+	assert.True(t, c.Capabilities().MutatesData)
+
+	ld := plog.NewLogs()
+	putResource(ld.ResourceLogs().AppendEmpty().Resource(), "404", 2, "0.5", "true")
+	putResource(ld.ResourceLogs().AppendEmpty().Resource(), "not found", 3, "half", "yes")
+	require.NoError(t, c.ConsumeLogs(context.Background(), ld))
+
+	require.Len(t, received, 1)
+	rls := received[0].ResourceLogs()
+	assert.Equal(t, map[string]any{
+		"http.status_code": int64(404),
+		"service.version":  "2",
+		"sampling.ratio":   0.5,
+		"debug":            true,
+		"other":            "404",
+	}, rls.At(0).Resource().Attributes().AsRaw())
+	// The uncoercible values are left unchanged.
+	assert.Equal(t, map[string]any{
+		"http.status_code": "not found",
+		"service.version":  "3",
+		"sampling.ratio":   "half",
+		"debug":            "yes",
+		"other":            "404",
+	}, rls.At(1).Resource().Attributes().AsRaw())
+	assert.Equal(t, int64(5), n.CoercedValues())
+	assert.Equal(t, int64(3), n.UncoercibleValues())
+}
+
+func TestMetricsDropUncoercible(t *testing.T) {
+	n, err := New(Config{Types: testTypes, DropUncoercible: true})
+	require.NoError(t, err)
+	var received []pmetric.Metrics
+	next, err := consumer.NewMetrics(func(_ context.Context, md pmetric.Metrics) error {
+		received = append(received, md)
+		return nil
+	})
+	require.NoError(t, err)
+	c, err := n.Metrics(next)
+	require.NoError(t, err)
+
+	md := pmetric.NewMetrics()
+	putResource(md.ResourceMetrics().AppendEmpty().Resource(), "not found", 3, "half", "yes")
+	require.NoError(t, c.ConsumeMetrics(context.Background(), md))
+
+	require.Len(t, received, 1)
+	assert.Equal(t, map[string]any{
+		"service.version": "3",
+		"other":           "404",
+	}, received[0].ResourceMetrics().At(0).Resource().Attributes().AsRaw())
+	assert.Equal(t, int64(1), n.CoercedValues())
+	assert.Equal(t, int64(3), n.UncoercibleValues())
+}
+
+func TestTraces(t *testing.T) {
+	n, err := New(Config{Types: testTypes})
+	require.NoError(t, err)
+	var received []ptrace.Traces
+	next, err := consumer.NewTraces(func(_ context.Context, td ptrace.Traces) error {
+		received = append(received, td)
+		return nil
+	})
+	require.NoError(t, err)
+	c, err := n.Traces(next)
+	require.NoError(t, err)
+
+	td := ptrace.NewTraces()
+	attrs := td.ResourceSpans().AppendEmpty().Resource().Attributes()
+	attrs.PutInt("http.status_code", 200)
+	attrs.PutStr("service.version", "1.0")
+	span := td.ResourceSpans().At(0).ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.Attributes().PutStr("http.status_code", "200")
+	require.NoError(t, c.ConsumeTraces(context.Background(), td))
+
+	require.Len(t, received, 1)
+	rs := received[0].ResourceSpans().At(0)
+	assert.Equal(t, map[string]any{
+		"http.status_code": int64(200),
+		"service.version":  "1.0",
+	}, rs.Resource().Attributes().AsRaw())
+	// Only the resource attributes are normalized.
+	assert.Equal(t, map[string]any{"http.status_code": "200"}, rs.ScopeSpans().At(0).Spans().At(0).Attributes().AsRaw())
+	assert.Zero(t, n.CoercedValues())
+	assert.Zero(t, n.UncoercibleValues())
+}
+
+func TestCoerce(t *testing.T) {
+	tests := []struct {
+		name  string
+		value func(pcommon.Value)
+		to    pcommon.ValueType
+		want  any
+		ok    bool
+	}{
+		{name: "int to str", value: func(v pcommon.Value) { v.SetInt(42) }, to: pcommon.ValueTypeStr, want: "42", ok: true},
+		{name: "double to str", value: func(v pcommon.Value) { v.SetDouble(1.5) }, to: pcommon.ValueTypeStr, want: "1.5", ok: true},
+		{name: "bool to str", value: func(v pcommon.Value) { v.SetBool(false) }, to: pcommon.ValueTypeStr, want: "false", ok: true},
+		{name: "map to str", value: func(v pcommon.Value) { v.SetEmptyMap().PutInt("a", 1) }, to: pcommon.ValueTypeStr, want: map[string]any{"a": int64(1)}},
+		{name: "str to int", value: func(v pcommon.Value) { v.SetStr("-7") }, to: pcommon.ValueTypeInt, want: int64(-7), ok: true},
+		{name: "invalid str to int", value: func(v pcommon.Value) { v.SetStr("7.5") }, to: pcommon.ValueTypeInt, want: "7.5"},
+		{name: "integral double to int", value: func(v pcommon.Value) { v.SetDouble(3) }, to: pcommon.ValueTypeInt, want: int64(3), ok: true},
+		{name: "fractional double to int", value: func(v pcommon.Value) { v.SetDouble(3.5) }, to: pcommon.ValueTypeInt, want: 3.5},
+		{name: "out of range double to int", value: func(v pcommon.Value) { v.SetDouble(math.MaxInt64) }, to: pcommon.ValueTypeInt, want: float64(math.MaxInt64)},
+		{name: "infinite double to int", value: func(v pcommon.Value) { v.SetDouble(math.Inf(1)) }, to: pcommon.ValueTypeInt, want: math.Inf(1)},
+		{name: "bool to int", value: func(v pcommon.Value) { v.SetBool(true) }, to: pcommon.ValueTypeInt, want: true},
+		{name: "str to double", value: func(v pcommon.Value) { v.SetStr("2.25") }, to: pcommon.ValueTypeDouble, want: 2.25, ok: true},
+		{name: "int to double", value: func(v pcommon.Value) { v.SetInt(2) }, to: pcommon.ValueTypeDouble, want: 2.0, ok: true},
+		{name: "bytes to double", value: func(v pcommon.Value) { v.SetEmptyBytes().FromRaw([]byte{1}) }, to: pcommon.ValueTypeDouble, want: []byte{1}},
+		{name: "str to bool", value: func(v pcommon.Value) { v.SetStr("FALSE") }, to: pcommon.ValueTypeBool, want: false, ok: true},
+		{name: "int to bool", value: func(v pcommon.Value) { v.SetInt(1) }, to: pcommon.ValueTypeBool, want: int64(1)},
+		{name: "empty to bool", value: func(pcommon.Value) {}, to: pcommon.ValueTypeBool, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := pcommon.NewValueEmpty()
+			tt.value(v)
+			assert.Equal(t, tt.ok, coerce(v, tt.to))
+			assert.Equal(t, tt.want, v.AsRaw())
+		})
+	}
+}
+
+func TestNewUnsupportedType(t *testing.T) {
+	_, err := New(Config{Types: map[string]pcommon.ValueType{"labels": pcommon.ValueTypeMap}})
+	assert.EqualError(t, err, `unsupported type "Map" for the attribute "labels"`)
+}
@@ -0,0 +1,245 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package batchchecksum // import "go.opentelemetry.io/collector/consumer/batchchecksum"
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sync/atomic"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/internal/wrapper"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// DefaultAttribute is the resource attribute holding the checksums when none is configured.
+const DefaultAttribute = "otel.checksum"
+
+var (
+	tracesMarshaler  = &ptrace.ProtoMarshaler{}
+	metricsMarshaler = &pmetric.ProtoMarshaler{}
+	logsMarshaler    = &plog.ProtoMarshaler{}
+)
+
+// Stamper stamps each resource of the data with the hex encoded SHA-256 checksum of the OTLP protobuf
+// serialization of the resource and its content, the checksum attribute excluded.
+//
+// The checksums are computed per resource so that they can still be verified after the data is split
+// or batched with other data, as long as the content of the resources is unchanged.
+type Stamper struct {
+	attribute string
+}
+
+// NewStamper returns a Stamper setting the checksums in the attribute, DefaultAttribute if empty.
+func NewStamper(attribute string) *Stamper {
+	if attribute == "" {
+		attribute = DefaultAttribute
+	}
+	return &Stamper{attribute: attribute}
+}
+
+// Traces returns a consumer.Traces stamping the traces with their checksums before passing them to next.
+func (s *Stamper) Traces(next consumer.Traces) (consumer.Traces, error) {
+	return wrapper.ProcessTraces(next, true, func(td ptrace.Traces) error {
+		rss := td.ResourceSpans()
+		for i := 0; i < rss.Len(); i++ {
+			sum, err := resourceSpansChecksum(rss.At(i), s.attribute)
+			if err != nil {
+				return err
+			}
+			rss.At(i).Resource().Attributes().PutStr(s.attribute, sum)
+		}
+		return nil
+	})
+}
+
+// Metrics returns a consumer.Metrics stamping the metrics with their checksums before passing them to next.
+func (s *Stamper) Metrics(next consumer.Metrics) (consumer.Metrics, error) {
+	return wrapper.ProcessMetrics(next, true, func(md pmetric.Metrics) error {
+		rms := md.ResourceMetrics()
+		for i := 0; i < rms.Len(); i++ {
+			sum, err := resourceMetricsChecksum(rms.At(i), s.attribute)
+			if err != nil {
+				return err
+			}
+			rms.At(i).Resource().Attributes().PutStr(s.attribute, sum)
+		}
+		return nil
+	})
+}
+
+// Logs returns a consumer.Logs stamping the logs with their checksums before passing them to next.
+func (s *Stamper) Logs(next consumer.Logs) (consumer.Logs, error) {
+	return wrapper.ProcessLogs(next, true, func(ld plog.Logs) error {
+		rls := ld.ResourceLogs()
+		for i := 0; i < rls.Len(); i++ {
+			sum, err := resourceLogsChecksum(rls.At(i), s.attribute)
+			if err != nil {
+				return err
+			}
+			rls.At(i).Resource().Attributes().PutStr(s.attribute, sum)
+		}
+		return nil
+	})
+}
+
+// VerifierConfig configures a Verifier.
+type VerifierConfig struct {
+	// Attribute is the resource attribute holding the checksums, DefaultAttribute if empty.
+	Attribute string
+
+	// Remove removes the checksum attribute from the resources once verified, e.g. to not export it.
+	Remove bool
+
+	// OnMismatch is called with the stamped and the computed checksums of each resource whose content
+	// does not match its checksum, e.g. to log it. Optional.
+	OnMismatch func(stamped, computed string)
+}
+
+// Verifier verifies the checksums stamped by a Stamper. The data is passed to the next consumer whether
+// the checksums match or not, the mismatches being counted and reported to the OnMismatch function.
+type Verifier struct {
+	attribute  string
+	remove     bool
+	onMismatch func(stamped, computed string)
+
+	verified   atomic.Int64
+	mismatched atomic.Int64
+	missing    atomic.Int64
+}
+
+// NewVerifier returns a Verifier for the configuration.
+func NewVerifier(cfg VerifierConfig) *Verifier {
+	v := &Verifier{attribute: cfg.Attribute, remove: cfg.Remove, onMismatch: cfg.OnMismatch}
+	if v.attribute == "" {
+		v.attribute = DefaultAttribute
+	}
+	return v
+}
+
+// Verified returns the number of resources whose content matched their checksum.
+func (v *Verifier) Verified() int64 {
+	return v.verified.Load()
+}
+
+// Mismatched returns the number of resources whose content did not match their checksum.
+func (v *Verifier) Mismatched() int64 {
+	return v.mismatched.Load()
+}
+
+// Missing returns the number of resources without a string checksum attribute, which were not verified.
+func (v *Verifier) Missing() int64 {
+	return v.missing.Load()
+}
+
+// Traces returns a consumer.Traces verifying the checksums of the traces before passing them to next.
+func (v *Verifier) Traces(next consumer.Traces) (consumer.Traces, error) {
+	return wrapper.ProcessTraces(next, v.remove, func(td ptrace.Traces) error {
+		rss := td.ResourceSpans()
+		for i := 0; i < rss.Len(); i++ {
+			sum, err := resourceSpansChecksum(rss.At(i), v.attribute)
+			if err != nil {
+				return err
+			}
+			v.verify(rss.At(i).Resource(), sum)
+		}
+		return nil
+	})
+}
+
+// Metrics returns a consumer.Metrics verifying the checksums of the metrics before passing them to next.
+func (v *Verifier) Metrics(next consumer.Metrics) (consumer.Metrics, error) {
+	return wrapper.ProcessMetrics(next, v.remove, func(md pmetric.Metrics) error {
+		rms := md.ResourceMetrics()
+		for i := 0; i < rms.Len(); i++ {
+			sum, err := resourceMetricsChecksum(rms.At(i), v.attribute)
+			if err != nil {
+				return err
+			}
+			v.verify(rms.At(i).Resource(), sum)
+		}
+		return nil
+	})
+}
+
+// Logs returns a consumer.Logs verifying the checksums of the logs before passing them to next.
+func (v *Verifier) Logs(next consumer.Logs) (consumer.Logs, error) {
+	return wrapper.ProcessLogs(next, v.remove, func(ld plog.Logs) error {
+		rls := ld.ResourceLogs()
+		for i := 0; i < rls.Len(); i++ {
+			sum, err := resourceLogsChecksum(rls.At(i), v.attribute)
+			if err != nil {
+				return err
+			}
+			v.verify(rls.At(i).Resource(), sum)
+		}
+		return nil
+	})
+}
+
+// verify compares the checksum stamped on res with the computed one, and removes it if configured.
+func (v *Verifier) verify(res pcommon.Resource, computed string) {
+	stamped, ok := res.Attributes().Get(v.attribute)
+	switch {
+	case !ok || stamped.Type() != pcommon.ValueTypeStr:
+		v.missing.Add(1)
+	case stamped.Str() == computed:
+		v.verified.Add(1)
+	default:
+		v.mismatched.Add(1)
+		if v.onMismatch != nil {
+			v.onMismatch(stamped.Str(), computed)
+		}
+	}
+	if v.remove {
+		res.Attributes().Remove(v.attribute)
+	}
+}
+
+// resourceSpansChecksum returns the checksum of rs, the attribute excluded.
+func resourceSpansChecksum(rs ptrace.ResourceSpans, attribute string) (string, error) {
+	td := ptrace.NewTraces()
+	dest := td.ResourceSpans().AppendEmpty()
+	rs.CopyTo(dest)
+	dest.Resource().Attributes().Remove(attribute)
+	buf, err := tracesMarshaler.MarshalTraces(td)
+	if err != nil {
+		return "", err
+	}
+	return checksum(buf), nil
+}
+
+// resourceMetricsChecksum returns the checksum of rm, the attribute excluded.
+func resourceMetricsChecksum(rm pmetric.ResourceMetrics, attribute string) (string, error) {
+	md := pmetric.NewMetrics()
+	dest := md.ResourceMetrics().AppendEmpty()
+	rm.CopyTo(dest)
+	dest.Resource().Attributes().Remove(attribute)
+	buf, err := metricsMarshaler.MarshalMetrics(md)
+	if err != nil {
+		return "", err
+	}
+	return checksum(buf), nil
+}
+
+// resourceLogsChecksum returns the checksum of rl, the attribute excluded.
+func resourceLogsChecksum(rl plog.ResourceLogs, attribute string) (string, error) {
+	ld := plog.NewLogs()
+	dest := ld.ResourceLogs().AppendEmpty()
+	rl.CopyTo(dest)
+	dest.Resource().Attributes().Remove(attribute)
+	buf, err := logsMarshaler.MarshalLogs(ld)
+	if err != nil {
+		return "", err
+	}
+	return checksum(buf), nil
+}
+
+func checksum(buf []byte) string {
+	sum := sha256.Sum256(buf)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,163 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package batchchecksum
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/testdata"
+)
+
+// newTracesPipeline returns a consumer stamping the traces, then passing them to corrupt before the verifier.
+func newTracesPipeline(t *testing.T, v *Verifier, corrupt func(ptrace.Traces)) (consumer.Traces, *[]ptrace.Traces) {
+	var received []ptrace.Traces
+	sink, err := consumer.NewTraces(func(_ context.Context, td ptrace.Traces) error {
+		received = append(received, td)
+		return nil
+	})
+	require.NoError(t, err)
+	verifier, err := v.Traces(sink)
+	require.NoError(t, err)
+	transit, err := consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
+		corrupt(td)
+		return verifier.ConsumeTraces(ctx, td)
+	})
+	require.NoError(t, err)
+	stamper, err := NewStamper("").Traces(transit)
+	require.NoError(t, err)
+	return stamper, &received
+}
+
+func TestTracesRoundTrip(t *testing.T) {
+	v := NewVerifier(VerifierConfig{})
+	c, received := newTracesPipeline(t, v, func(ptrace.Traces) {})
+	td := testdata.GenerateTraces(2)
+	td.ResourceSpans().AppendEmpty().Resource().Attributes().PutStr("service.name", "other")
+	require.NoError(t, c.ConsumeTraces(context.Background(), td))
+
+	require.Len(t, *received, 1)
+	rss := (*received)[0].ResourceSpans()
+	sum0, ok := rss.At(0).Resource().Attributes().Get(DefaultAttribute)
+	require.True(t, ok)
+	assert.Len(t, sum0.Str(), 64)
+	sum1, ok := rss.At(1).Resource().Attributes().Get(DefaultAttribute)
+	require.True(t, ok)
+	assert.NotEqual(t, sum0.Str(), sum1.Str())
+	assert.Equal(t, int64(2), v.Verified())
+	assert.Zero(t, v.Mismatched())
+	assert.Zero(t, v.Missing())
+}
+
+func TestTracesCorrupted(t *testing.T) {
+	var mismatches [][2]string
+	v := NewVerifier(VerifierConfig{OnMismatch: func(stamped, computed string) {
+		mismatches = append(mismatches, [2]string{stamped, computed})
+	}})
+	c, received := newTracesPipeline(t, v, func(td ptrace.Traces) {
+		td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(1).SetName("corrupted")
+	})
+	td := testdata.GenerateTraces(2)
+	td.ResourceSpans().AppendEmpty().Resource().Attributes().PutStr("service.name", "other")
+	require.NoError(t, c.ConsumeTraces(context.Background(), td))
+
+	// The corrupted data is still passed to the next consumer.
+	require.Len(t, *received, 1)
+	assert.Equal(t, int64(1), v.Verified())
+	assert.Equal(t, int64(1), v.Mismatched())
+	require.Len(t, mismatches, 1)
+	stamped, _ := (*received)[0].ResourceSpans().At(0).Resource().Attributes().Get(DefaultAttribute)
+	assert.Equal(t, stamped.Str(), mismatches[0][0])
+	assert.NotEqual(t, mismatches[0][0], mismatches[0][1])
+}
+
+func TestTracesRebatched(t *testing.T) {
+	var stamped []ptrace.Traces
+	sink, err := consumer.NewTraces(func(_ context.Context, td ptrace.Traces) error {
+		stamped = append(stamped, td)
+		return nil
+	})
+	require.NoError(t, err)
+	stamper, err := NewStamper("checksum").Traces(sink)
+	require.NoError(t, err)
+	require.NoError(t, stamper.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	require.NoError(t, stamper.ConsumeTraces(context.Background(), testdata.GenerateTraces(3)))
+
+	// The resources stamped separately are batched together before the verification.
+	batch := ptrace.NewTraces()
+	for _, td := range stamped {
+		td.ResourceSpans().MoveAndAppendTo(batch.ResourceSpans())
+	}
+	v := NewVerifier(VerifierConfig{Attribute: "checksum", Remove: true})
+	var received []ptrace.Traces
+	next, err := consumer.NewTraces(func(_ context.Context, td ptrace.Traces) error {
+		received = append(received, td)
+		return nil
+	})
+	require.NoError(t, err)
+	verifier, err := v.Traces(next)
+	require.NoError(t, err)
+	assert.True(t, verifier.Capabilities().MutatesData)
+	require.NoError(t, verifier.ConsumeTraces(context.Background(), batch))
+
+	assert.Equal(t, int64(2), v.Verified())
+	require.Len(t, received, 1)
+	_, ok := received[0].ResourceSpans().At(0).Resource().Attributes().Get("checksum")
+	assert.False(t, ok)
+	assert.Equal(t, testdata.GenerateTraces(1).ResourceSpans().At(0).Resource().Attributes().AsRaw(),
+		received[0].ResourceSpans().At(0).Resource().Attributes().AsRaw())
+}
+
+func TestMetrics(t *testing.T) {
+	v := NewVerifier(VerifierConfig{})
+	next, err := consumer.NewMetrics(func(context.Context, pmetric.Metrics) error { return nil })
+	require.NoError(t, err)
+	verifier, err := v.Metrics(next)
+	require.NoError(t, err)
+	assert.False(t, verifier.Capabilities().MutatesData)
+	stamper, err := NewStamper("").Metrics(verifier)
+	require.NoError(t, err)
+	require.NoError(t, stamper.ConsumeMetrics(context.Background(), testdata.GenerateMetrics(5)))
+	assert.Equal(t, int64(1), v.Verified())
+
+	md := testdata.GenerateMetrics(5)
+	md.ResourceMetrics().At(0).Resource().Attributes().PutStr(DefaultAttribute, "0000")
+	require.NoError(t, verifier.ConsumeMetrics(context.Background(), md))
+	assert.Equal(t, int64(1), v.Mismatched())
+
+	// The data which was not stamped is not verified.
+	require.NoError(t, verifier.ConsumeMetrics(context.Background(), testdata.GenerateMetrics(5)))
+	assert.Equal(t, int64(1), v.Missing())
+}
+
+func TestLogs(t *testing.T) {
+	v := NewVerifier(VerifierConfig{})
+	var corrupt bool
+	next, err := consumer.NewLogs(func(context.Context, plog.Logs) error { return nil })
+	require.NoError(t, err)
+	verifier, err := v.Logs(next)
+	require.NoError(t, err)
+	transit, err := consumer.NewLogs(func(ctx context.Context, ld plog.Logs) error {
+		if corrupt {
+			ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().SetStr("corrupted")
+		}
+		return verifier.ConsumeLogs(ctx, ld)
+	})
+	require.NoError(t, err)
+	stamper, err := NewStamper("").Logs(transit)
+	require.NoError(t, err)
+
+	require.NoError(t, stamper.ConsumeLogs(context.Background(), testdata.GenerateLogs(3)))
+	assert.Equal(t, int64(1), v.Verified())
+	corrupt = true
+	require.NoError(t, stamper.ConsumeLogs(context.Background(), testdata.GenerateLogs(3)))
+	assert.Equal(t, int64(1), v.Mismatched())
+}
@@ -0,0 +1,6 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package batchchecksum provides consumers stamping each resource with a checksum of its content, and
+// consumers verifying the checksums further in the pipeline to detect the data corrupted in between.
+package batchchecksum // import "go.opentelemetry.io/collector/consumer/batchchecksum"
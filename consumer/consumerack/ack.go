@@ -0,0 +1,118 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package consumerack // import "go.opentelemetry.io/collector/consumer/consumerack"
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+type trackerKey struct{}
+
+// tracker counts the acknowledgements deferred by the components consuming the data of a request.
+type tracker struct {
+	mu      sync.Mutex
+	pending int
+	// closed is set once the request is consumed, the acknowledgements deferred later being ignored.
+	closed bool
+	err    error
+	// done is closed once the request is consumed and all the deferred acknowledgements are received.
+	done chan struct{}
+}
+
+// Defer defers the acknowledgement of the data consumed with ctx to the receiver which received it, and returns
+// the function to call with the result once the data is durably accepted, e.g. sent by an exporter. A non-nil
+// error is returned to the client of the receiver. The function must be called exactly once, later calls being
+// ignored.
+//
+// It returns nil if the receiver does not wait for the acknowledgements, i.e. ctx does not come from the
+// consumers of this package, or if the receiver already responded.
+func Defer(ctx context.Context) func(error) {
+	t, ok := ctx.Value(trackerKey{}).(*tracker)
+	if !ok {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.closed {
+		return nil
+	}
+	t.pending++
+	var once sync.Once
+	return func(err error) {
+		once.Do(func() { t.ack(err) })
+	}
+}
+
+func (t *tracker) ack(err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.err == nil {
+		t.err = err
+	} else if err != nil {
+		t.err = errors.Join(t.err, err)
+	}
+	t.pending--
+	if t.closed && t.pending == 0 {
+		close(t.done)
+	}
+}
+
+// consume calls consume with a context carrying a new tracker, and waits for the acknowledgements deferred
+// while consuming, until ctx is done.
+func consume(ctx context.Context, consume func(context.Context) error) error {
+	t := &tracker{done: make(chan struct{})}
+	err := consume(context.WithValue(ctx, trackerKey{}, t))
+
+	t.mu.Lock()
+	t.closed = true
+	pending := t.pending
+	t.mu.Unlock()
+	if err != nil || pending == 0 {
+		return err
+	}
+
+	select {
+	case <-t.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.err
+}
+
+// NewTraces returns a consumer.Traces passing the data to next, and returning once the acknowledgements deferred
+// by the components consuming it are received. The components must consume the data with the context of the
+// call, so the acknowledgements are not deferred past the processors replacing it, e.g. batching the data.
+func NewTraces(next consumer.Traces) consumer.Traces {
+	tr, _ := consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
+		return consume(ctx, func(ctx context.Context) error { return next.ConsumeTraces(ctx, td) })
+	}, consumer.WithCapabilities(next.Capabilities()))
+	return tr
+}
+
+// NewMetrics returns a consumer.Metrics passing the data to next, and returning once the acknowledgements deferred
+// by the components consuming it are received, like NewTraces.
+func NewMetrics(next consumer.Metrics) consumer.Metrics {
+	mr, _ := consumer.NewMetrics(func(ctx context.Context, md pmetric.Metrics) error {
+		return consume(ctx, func(ctx context.Context) error { return next.ConsumeMetrics(ctx, md) })
+	}, consumer.WithCapabilities(next.Capabilities()))
+	return mr
+}
+
+// NewLogs returns a consumer.Logs passing the data to next, and returning once the acknowledgements deferred
+// by the components consuming it are received, like NewTraces.
+func NewLogs(next consumer.Logs) consumer.Logs {
+	lr, _ := consumer.NewLogs(func(ctx context.Context, ld plog.Logs) error {
+		return consume(ctx, func(ctx context.Context) error { return next.ConsumeLogs(ctx, ld) })
+	}, consumer.WithCapabilities(next.Capabilities()))
+	return lr
+}
@@ -0,0 +1,121 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package consumerack
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// deferring returns a consumer.Traces deferring n acknowledgements, sent to acks.
+func deferring(n int, acks chan<- func(error)) consumer.Traces {
+	tr, _ := consumer.NewTraces(func(ctx context.Context, _ ptrace.Traces) error {
+		for i := 0; i < n; i++ {
+			acks <- Defer(ctx)
+		}
+		return nil
+	})
+	return tr
+}
+
+func TestTracesWaitsForAcks(t *testing.T) {
+	acks := make(chan func(error), 2)
+	tr := NewTraces(deferring(2, acks))
+
+	done := make(chan error, 1)
+	go func() { done <- tr.ConsumeTraces(context.Background(), ptrace.NewTraces()) }()
+	first, second := <-acks, <-acks
+	require.NotNil(t, first)
+	require.NotNil(t, second)
+
+	first(nil)
+	select {
+	case <-done:
+		t.Fatal("the traces were acknowledged before all the deferred acknowledgements")
+	case <-time.After(10 * time.Millisecond):
+	}
+	second(nil)
+	// The later calls are ignored.
+	second(errors.New("ignored"))
+	assert.NoError(t, <-done)
+}
+
+func TestTracesAckError(t *testing.T) {
+	acks := make(chan func(error), 2)
+	tr := NewTraces(deferring(2, acks))
+
+	done := make(chan error, 1)
+	go func() { done <- tr.ConsumeTraces(context.Background(), ptrace.NewTraces()) }()
+	errFirst, errSecond := errors.New("first"), errors.New("second")
+	(<-acks)(errFirst)
+	(<-acks)(errSecond)
+	err := <-done
+	require.ErrorIs(t, err, errFirst)
+	require.ErrorIs(t, err, errSecond)
+}
+
+func TestTracesContextDone(t *testing.T) {
+	acks := make(chan func(error), 1)
+	tr := NewTraces(deferring(1, acks))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- tr.ConsumeTraces(ctx, ptrace.NewTraces()) }()
+	ack := <-acks
+	cancel()
+	require.ErrorIs(t, <-done, context.Canceled)
+	// Acknowledging after the receiver responded has no effect.
+	ack(nil)
+}
+
+func TestTracesConsumeError(t *testing.T) {
+	errConsume := errors.New("consume")
+	var ack func(error)
+	next, _ := consumer.NewTraces(func(ctx context.Context, _ ptrace.Traces) error {
+		ack = Defer(ctx)
+		return errConsume
+	})
+	require.ErrorIs(t, NewTraces(next).ConsumeTraces(context.Background(), ptrace.NewTraces()), errConsume)
+	require.NotNil(t, ack)
+	ack(nil)
+}
+
+func TestDefer(t *testing.T) {
+	assert.Nil(t, Defer(context.Background()))
+
+	var ctx context.Context
+	next, _ := consumer.NewLogs(func(c context.Context, _ plog.Logs) error {
+		ctx = c
+		return nil
+	})
+	require.NoError(t, NewLogs(next).ConsumeLogs(context.Background(), plog.NewLogs()))
+	// The receiver already responded.
+	assert.Nil(t, Defer(ctx))
+}
+
+func TestMetricsWaitsForAcks(t *testing.T) {
+	acks := make(chan func(error), 1)
+	next, _ := consumer.NewMetrics(func(ctx context.Context, _ pmetric.Metrics) error {
+		acks <- Defer(ctx)
+		return nil
+	}, consumer.WithCapabilities(consumer.Capabilities{MutatesData: true}))
+	mr := NewMetrics(next)
+	assert.True(t, mr.Capabilities().MutatesData)
+
+	done := make(chan error, 1)
+	go func() { done <- mr.ConsumeMetrics(context.Background(), pmetric.NewMetrics()) }()
+	errExport := errors.New("export")
+	(<-acks)(errExport)
+	assert.ErrorIs(t, <-done, errExport)
+}
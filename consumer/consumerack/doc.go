@@ -0,0 +1,7 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package consumerack propagates the acknowledgement of the data from the exporters back to the receiver
+// which received it, so the receiver can respond to its client once the data is durably accepted rather than
+// once it is passed to the pipeline, e.g. put in the in-memory sending queue of an exporter.
+package consumerack // import "go.opentelemetry.io/collector/consumer/consumerack"
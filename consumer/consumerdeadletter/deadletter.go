@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package consumerdeadletter // import "go.opentelemetry.io/collector/consumer/consumerdeadletter"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+type reasonKey struct{}
+
+// NewContext returns a copy of ctx holding the reason the data consumed with it was dropped.
+func NewContext(ctx context.Context, reason string) context.Context {
+	return context.WithValue(ctx, reasonKey{}, reason)
+}
+
+// ReasonFromContext returns the reason the data consumed with ctx was dropped, empty if ctx does not come from
+// the Consumers of this package.
+func ReasonFromContext(ctx context.Context) string {
+	reason, _ := ctx.Value(reasonKey{}).(string)
+	return reason
+}
+
+// Consumers are the dead-letter consumers the dropped data is sent to, for each signal. The data of the signals
+// without a consumer is discarded.
+type Consumers struct {
+	Traces  consumer.Traces
+	Metrics consumer.Metrics
+	Logs    consumer.Logs
+}
+
+// Provider is an extra interface for `component.Host` implementations, giving the processors and exporters started
+// with the host the dead-letter consumers of their pipelines, set when the pipeline configures a dead-letter exporter.
+//
+// Experimental: This API is at the early stage of development and may change without backward compatibility.
+type Provider interface {
+	// DeadLetterConsumers returns the dead-letter consumers of the component started with the host, nil if it has
+	// none.
+	DeadLetterConsumers() *Consumers
+}
+
+// SendTraces sends td, dropped for reason, to the traces consumer, if any, and returns its error.
+func (c Consumers) SendTraces(ctx context.Context, td ptrace.Traces, reason string) error {
+	if c.Traces == nil {
+		return nil
+	}
+	return c.Traces.ConsumeTraces(NewContext(ctx, reason), td)
+}
+
+// SendMetrics sends md, dropped for reason, to the metrics consumer, if any, and returns its error.
+func (c Consumers) SendMetrics(ctx context.Context, md pmetric.Metrics, reason string) error {
+	if c.Metrics == nil {
+		return nil
+	}
+	return c.Metrics.ConsumeMetrics(NewContext(ctx, reason), md)
+}
+
+// SendLogs sends ld, dropped for reason, to the logs consumer, if any, and returns its error.
+func (c Consumers) SendLogs(ctx context.Context, ld plog.Logs, reason string) error {
+	if c.Logs == nil {
+		return nil
+	}
+	return c.Logs.ConsumeLogs(NewContext(ctx, reason), ld)
+}
@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package consumerdeadletter
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestReasonFromContext(t *testing.T) {
+	assert.Empty(t, ReasonFromContext(context.Background()))
+	assert.Equal(t, "filtered", ReasonFromContext(NewContext(context.Background(), "filtered")))
+}
+
+func TestConsumers(t *testing.T) {
+	var reasons []string
+	var items []int
+	tc, err := consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
+		reasons = append(reasons, ReasonFromContext(ctx))
+		items = append(items, td.SpanCount())
+		return nil
+	})
+	require.NoError(t, err)
+	mc, err := consumer.NewMetrics(func(ctx context.Context, md pmetric.Metrics) error {
+		reasons = append(reasons, ReasonFromContext(ctx))
+		items = append(items, md.DataPointCount())
+		return nil
+	})
+	require.NoError(t, err)
+	lc, err := consumer.NewLogs(func(ctx context.Context, ld plog.Logs) error {
+		reasons = append(reasons, ReasonFromContext(ctx))
+		items = append(items, ld.LogRecordCount())
+		return errors.New("full")
+	})
+	require.NoError(t, err)
+	c := Consumers{Traces: tc, Metrics: mc, Logs: lc}
+
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	md := pmetric.NewMetrics()
+	md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptyGauge().DataPoints().AppendEmpty()
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	require.NoError(t, c.SendTraces(context.Background(), td, "a"))
+	require.NoError(t, c.SendMetrics(context.Background(), md, "b"))
+	require.EqualError(t, c.SendLogs(context.Background(), ld, "c"), "full")
+	assert.Equal(t, []string{"a", "b", "c"}, reasons)
+	assert.Equal(t, []int{1, 1, 1}, items)
+
+	// The data of the signals without a consumer is discarded.
+	var none Consumers
+	assert.NoError(t, none.SendTraces(context.Background(), td, "a"))
+	assert.NoError(t, none.SendMetrics(context.Background(), md, "b"))
+	assert.NoError(t, none.SendLogs(context.Background(), ld, "c"))
+}
@@ -0,0 +1,7 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package consumerdeadletter diverts the data dropped by a component to dead-letter consumers, e.g. an exporter
+// writing it to a file for later analysis, instead of discarding it. The reason of the drop is passed to the
+// dead-letter consumers in the context.
+package consumerdeadletter // import "go.opentelemetry.io/collector/consumer/consumerdeadletter"
@@ -0,0 +1,36 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package histogramclamp // import "go.opentelemetry.io/collector/consumer/histogramclamp"
+
+import (
+	"sync/atomic"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/internal/wrapper"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// Clamper resets the negative bucket counts of the histogram data points to zero, as described by
+// pmetric.Metrics.ClampBucketCounts, and counts the corrected data points.
+type Clamper struct {
+	correctedPoints atomic.Int64
+}
+
+// New returns a Clamper.
+func New() *Clamper {
+	return &Clamper{}
+}
+
+// CorrectedPoints returns the number of histogram data points which were corrected.
+func (c *Clamper) CorrectedPoints() int64 {
+	return c.correctedPoints.Load()
+}
+
+// Metrics returns a consumer.Metrics clamping the bucket counts before passing the metrics to next.
+func (c *Clamper) Metrics(next consumer.Metrics) (consumer.Metrics, error) {
+	return wrapper.ProcessMetrics(next, true, func(md pmetric.Metrics) error {
+		c.correctedPoints.Add(int64(md.ClampBucketCounts()))
+		return nil
+	})
+}
@@ -0,0 +1,49 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package histogramclamp
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestMetrics(t *testing.T) {
+	var received []pmetric.Metrics
+	next, err := consumer.NewMetrics(func(_ context.Context, md pmetric.Metrics) error {
+		received = append(received, md)
+		return nil
+	})
+	require.NoError(t, err)
+	c := New()
+	cm, err := c.Metrics(next)
+	require.NoError(t, err)
+	assert.True(t, cm.Capabilities().MutatesData)
+
+	md := pmetric.NewMetrics()
+	dps := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptyHistogram().DataPoints()
+	// A count of -1 received as an unsigned count.
+	dps.AppendEmpty().BucketCounts().FromRaw([]uint64{2, math.MaxUint64, 1})
+	dps.AppendEmpty().BucketCounts().FromRaw([]uint64{math.MaxUint64})
+	dps.AppendEmpty().BucketCounts().FromRaw([]uint64{4})
+	for i := 0; i < 2; i++ {
+		require.NoError(t, cm.ConsumeMetrics(context.Background(), md))
+	}
+
+	require.Len(t, received, 2)
+	dps = received[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Histogram().DataPoints()
+	assert.Equal(t, []uint64{2, 0, 1}, dps.At(0).BucketCounts().AsRaw())
+	assert.Equal(t, uint64(3), dps.At(0).Count())
+	assert.Equal(t, []uint64{0}, dps.At(1).BucketCounts().AsRaw())
+	assert.Equal(t, uint64(0), dps.At(1).Count())
+	assert.Equal(t, []uint64{4}, dps.At(2).BucketCounts().AsRaw())
+	// The data points are corrected once.
+	assert.Equal(t, int64(2), c.CorrectedPoints())
+}
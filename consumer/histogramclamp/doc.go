@@ -0,0 +1,6 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package histogramclamp provides a consumer resetting the negative histogram bucket counts to zero
+// before passing the metrics to the next consumer.
+package histogramclamp // import "go.opentelemetry.io/collector/consumer/histogramclamp"
@@ -0,0 +1,6 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package wrapper provides the consumers wrapping a next consumer shared by
+// the packages of the consumer module.
+package wrapper // import "go.opentelemetry.io/collector/consumer/internal/wrapper"
@@ -0,0 +1,47 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package wrapper // import "go.opentelemetry.io/collector/consumer/internal/wrapper"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// ProcessTraces returns a consumer.Traces calling process on the traces before passing them to next.
+// See ProcessLogs for the details.
+func ProcessTraces(next consumer.Traces, mutatesData bool, process func(ptrace.Traces) error) (consumer.Traces, error) {
+	return consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
+		if err := process(td); err != nil {
+			return err
+		}
+		return next.ConsumeTraces(ctx, td)
+	}, consumer.WithCapabilities(consumer.Capabilities{MutatesData: mutatesData}))
+}
+
+// ProcessMetrics returns a consumer.Metrics calling process on the metrics before passing them to next.
+// See ProcessLogs for the details.
+func ProcessMetrics(next consumer.Metrics, mutatesData bool, process func(pmetric.Metrics) error) (consumer.Metrics, error) {
+	return consumer.NewMetrics(func(ctx context.Context, md pmetric.Metrics) error {
+		if err := process(md); err != nil {
+			return err
+		}
+		return next.ConsumeMetrics(ctx, md)
+	}, consumer.WithCapabilities(consumer.Capabilities{MutatesData: mutatesData}))
+}
+
+// ProcessLogs returns a consumer.Logs calling process on the logs before passing them to next, process
+// modifying them in place if mutatesData is set. If process fails, the logs are not passed to next and
+// its error is returned.
+func ProcessLogs(next consumer.Logs, mutatesData bool, process func(plog.Logs) error) (consumer.Logs, error) {
+	return consumer.NewLogs(func(ctx context.Context, ld plog.Logs) error {
+		if err := process(ld); err != nil {
+			return err
+		}
+		return next.ConsumeLogs(ctx, ld)
+	}, consumer.WithCapabilities(consumer.Capabilities{MutatesData: mutatesData}))
+}
@@ -0,0 +1,45 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package wrapper
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func TestProcessLogs(t *testing.T) {
+	var received []plog.Logs
+	next, err := consumer.NewLogs(func(_ context.Context, ld plog.Logs) error {
+		received = append(received, ld)
+		return errors.New("next failed")
+	})
+	require.NoError(t, err)
+	c, err := ProcessLogs(next, true, func(ld plog.Logs) error {
+		ld.ResourceLogs().At(0).Resource().Attributes().PutBool("processed", true)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.True(t, c.Capabilities().MutatesData)
+
+	// The processed logs are passed once, and the error of next returned as is.
+	ld := newLogs(2)
+	require.EqualError(t, c.ConsumeLogs(context.Background(), ld), "next failed")
+	require.Len(t, received, 1)
+	assert.Equal(t, ld, received[0])
+	assert.Equal(t, map[string]any{"resource": int64(0), "processed": true}, ld.ResourceLogs().At(0).Resource().Attributes().AsRaw())
+
+	// The logs are not passed if process fails.
+	c, err = ProcessLogs(next, false, func(plog.Logs) error { return errors.New("process failed") })
+	require.NoError(t, err)
+	assert.False(t, c.Capabilities().MutatesData)
+	require.EqualError(t, c.ConsumeLogs(context.Background(), newLogs(1)), "process failed")
+	assert.Len(t, received, 1)
+}
@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package wrapper // import "go.opentelemetry.io/collector/consumer/internal/wrapper"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// SplitTraces returns a consumer.Traces passing to next, in order, the batches the data is moved into by split,
+// which returns nil for the data to be passed as is. See SplitLogs for the details.
+func SplitTraces(next consumer.Traces, mutatesData bool, split func(ptrace.Traces) []ptrace.Traces) (consumer.Traces, error) {
+	return consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
+		batches := split(td)
+		if batches == nil {
+			return next.ConsumeTraces(ctx, td)
+		}
+		for i, batch := range batches {
+			if err := next.ConsumeTraces(ctx, batch); err != nil {
+				undelivered := ptrace.NewTraces()
+				for _, b := range batches[i:] {
+					b.ResourceSpans().MoveAndAppendTo(undelivered.ResourceSpans())
+				}
+				return consumererror.NewTraces(err, undelivered)
+			}
+		}
+		return nil
+	}, consumer.WithCapabilities(consumer.Capabilities{MutatesData: mutatesData}))
+}
+
+// SplitMetrics returns a consumer.Metrics passing to next, in order, the batches the data is moved into by split,
+// which returns nil for the data to be passed as is. See SplitLogs for the details.
+func SplitMetrics(next consumer.Metrics, mutatesData bool, split func(pmetric.Metrics) []pmetric.Metrics) (consumer.Metrics, error) {
+	return consumer.NewMetrics(func(ctx context.Context, md pmetric.Metrics) error {
+		batches := split(md)
+		if batches == nil {
+			return next.ConsumeMetrics(ctx, md)
+		}
+		for i, batch := range batches {
+			if err := next.ConsumeMetrics(ctx, batch); err != nil {
+				undelivered := pmetric.NewMetrics()
+				for _, b := range batches[i:] {
+					b.ResourceMetrics().MoveAndAppendTo(undelivered.ResourceMetrics())
+				}
+				return consumererror.NewMetrics(err, undelivered)
+			}
+		}
+		return nil
+	}, consumer.WithCapabilities(consumer.Capabilities{MutatesData: mutatesData}))
+}
+
+// SplitLogs returns a consumer.Logs passing to next, in order, the batches the data is moved into by split,
+// which returns nil for the data to be passed as is. mutatesData is set if split modifies the data, or if
+// next does when the data may be passed as is.
+//
+// The first failed batch stops the sending: its error is returned as a consumererror.Logs holding the log
+// records of the failed and following batches, which were not delivered, so that retrying them does not
+// send the delivered records again. The error of the data passed as is is returned as is.
+func SplitLogs(next consumer.Logs, mutatesData bool, split func(plog.Logs) []plog.Logs) (consumer.Logs, error) {
+	return consumer.NewLogs(func(ctx context.Context, ld plog.Logs) error {
+		batches := split(ld)
+		if batches == nil {
+			return next.ConsumeLogs(ctx, ld)
+		}
+		for i, batch := range batches {
+			if err := next.ConsumeLogs(ctx, batch); err != nil {
+				undelivered := plog.NewLogs()
+				for _, b := range batches[i:] {
+					b.ResourceLogs().MoveAndAppendTo(undelivered.ResourceLogs())
+				}
+				return consumererror.NewLogs(err, undelivered)
+			}
+		}
+		return nil
+	}, consumer.WithCapabilities(consumer.Capabilities{MutatesData: mutatesData}))
+}
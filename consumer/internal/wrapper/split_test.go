@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package wrapper
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// splitRecords moves every resource of ld into its own batch, or returns nil for a single resource.
+func splitRecords(ld plog.Logs) []plog.Logs {
+	rls := ld.ResourceLogs()
+	if rls.Len() <= 1 {
+		return nil
+	}
+	batches := make([]plog.Logs, rls.Len())
+	for i := range batches {
+		batches[i] = plog.NewLogs()
+		rls.At(i).MoveTo(batches[i].ResourceLogs().AppendEmpty())
+	}
+	return batches
+}
+
+func newLogs(resources int) plog.Logs {
+	ld := plog.NewLogs()
+	for i := 0; i < resources; i++ {
+		rl := ld.ResourceLogs().AppendEmpty()
+		rl.Resource().Attributes().PutInt("resource", int64(i))
+		rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	}
+	return ld
+}
+
+func TestSplitLogs(t *testing.T) {
+	var received []plog.Logs
+	next, err := consumer.NewLogs(func(_ context.Context, ld plog.Logs) error {
+		received = append(received, ld)
+		return nil
+	})
+	require.NoError(t, err)
+	c, err := SplitLogs(next, true, splitRecords)
+	require.NoError(t, err)
+	assert.True(t, c.Capabilities().MutatesData)
+
+	ld := newLogs(1)
+	require.NoError(t, c.ConsumeLogs(context.Background(), ld))
+	require.Len(t, received, 1)
+	assert.Equal(t, ld, received[0])
+
+	require.NoError(t, c.ConsumeLogs(context.Background(), newLogs(3)))
+	require.Len(t, received, 4)
+	for i, batch := range received[1:] {
+		require.Equal(t, 1, batch.ResourceLogs().Len())
+		assert.Equal(t, map[string]any{"resource": int64(i)}, batch.ResourceLogs().At(0).Resource().Attributes().AsRaw())
+	}
+}
+
+func TestSplitLogsError(t *testing.T) {
+	calls := 0
+	next, err := consumer.NewLogs(func(context.Context, plog.Logs) error {
+		calls++
+		if calls == 2 {
+			return consumererror.NewPermanent(errors.New("second batch failed"))
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	c, err := SplitLogs(next, true, splitRecords)
+	require.NoError(t, err)
+
+	// The batches following the failed one are not sent, and returned with it.
+	err = c.ConsumeLogs(context.Background(), newLogs(4))
+	require.EqualError(t, err, "Permanent error: second batch failed")
+	assert.Equal(t, 2, calls)
+	assert.True(t, consumererror.IsPermanent(err))
+	var logsErr consumererror.Logs
+	require.ErrorAs(t, err, &logsErr)
+	undelivered := logsErr.Data().ResourceLogs()
+	require.Equal(t, 3, undelivered.Len())
+	for i := 0; i < undelivered.Len(); i++ {
+		assert.Equal(t, map[string]any{"resource": int64(i + 1)}, undelivered.At(i).Resource().Attributes().AsRaw())
+	}
+
+	// The error of the data passed as is is not wrapped.
+	calls = 1
+	err = c.ConsumeLogs(context.Background(), newLogs(1))
+	require.EqualError(t, err, "Permanent error: second batch failed")
+	require.False(t, errors.As(err, &logsErr))
+}
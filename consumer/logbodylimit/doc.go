@@ -0,0 +1,6 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package logbodylimit provides a consumer truncating, or dropping the log records of, the log record
+// bodies larger than a maximum size.
+package logbodylimit // import "go.opentelemetry.io/collector/consumer/logbodylimit"
@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logbodylimit // import "go.opentelemetry.io/collector/consumer/logbodylimit"
+
+import (
+	"errors"
+	"sync/atomic"
+	"unicode/utf8"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/internal/wrapper"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// Policy specifies how a Limiter handles the log records whose body exceeds the maximum size.
+type Policy int32
+
+const (
+	// PolicyTruncate truncates the string and bytes bodies to the maximum size. The map and slice
+	// bodies cannot be truncated without breaking their structure, so their log records are dropped.
+	PolicyTruncate Policy = iota
+	// PolicyDrop drops the log records whose body exceeds the maximum size.
+	PolicyDrop
+)
+
+// Limiter enforces a maximum size, in bytes, of the log record bodies.
+//
+// The size of a string body is the length of the string, and the size of a bytes body the number of bytes.
+// The size of a map or slice body is the length of its JSON representation. The bodies of the other types
+// never exceed the maximum size.
+type Limiter struct {
+	maxBytes int
+	policy   Policy
+
+	truncatedBodies atomic.Int64
+	droppedRecords  atomic.Int64
+}
+
+// New returns a Limiter handling the log record bodies larger than maxBytes with the policy,
+// or an error if maxBytes is not positive.
+func New(maxBytes int, policy Policy) (*Limiter, error) {
+	if maxBytes <= 0 {
+		return nil, errors.New("maximum body size must be positive")
+	}
+	return &Limiter{maxBytes: maxBytes, policy: policy}, nil
+}
+
+// TruncatedBodies returns the number of log record bodies which were truncated.
+func (l *Limiter) TruncatedBodies() int64 {
+	return l.truncatedBodies.Load()
+}
+
+// DroppedRecords returns the number of log records which were dropped.
+func (l *Limiter) DroppedRecords() int64 {
+	return l.droppedRecords.Load()
+}
+
+// Logs returns a consumer.Logs enforcing the maximum body size before passing the logs to next.
+func (l *Limiter) Logs(next consumer.Logs) (consumer.Logs, error) {
+	return wrapper.ProcessLogs(next, true, func(ld plog.Logs) error {
+		rls := ld.ResourceLogs()
+		for i := 0; i < rls.Len(); i++ {
+			sls := rls.At(i).ScopeLogs()
+			for j := 0; j < sls.Len(); j++ {
+				sls.At(j).LogRecords().RemoveIf(func(lr plog.LogRecord) bool {
+					if !l.exceeds(lr.Body()) {
+						return false
+					}
+					if l.policy == PolicyTruncate && l.truncate(lr.Body()) {
+						l.truncatedBodies.Add(1)
+						return false
+					}
+					l.droppedRecords.Add(1)
+					return true
+				})
+			}
+		}
+		return nil
+	})
+}
+
+func (l *Limiter) exceeds(body pcommon.Value) bool {
+	switch body.Type() {
+	case pcommon.ValueTypeStr:
+		return len(body.Str()) > l.maxBytes
+	case pcommon.ValueTypeBytes:
+		return body.Bytes().Len() > l.maxBytes
+	case pcommon.ValueTypeMap, pcommon.ValueTypeSlice:
+		return len(body.AsString()) > l.maxBytes
+	}
+	return false
+}
+
+// truncate truncates body to the maximum size, and returns whether it could be truncated.
+// A string body is cut at a UTF-8 character boundary, and may be shorter than the maximum size.
+func (l *Limiter) truncate(body pcommon.Value) bool {
+	switch body.Type() {
+	case pcommon.ValueTypeStr:
+		s := body.Str()
+		i := l.maxBytes
+		for i > 0 && !utf8.RuneStart(s[i]) {
+			i--
+		}
+		body.SetStr(s[:i])
+		return true
+	case pcommon.ValueTypeBytes:
+		b := body.Bytes()
+		b.FromRaw(b.AsRaw()[:l.maxBytes])
+		return true
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logbodylimit
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func newLogs() plog.Logs {
+	ld := plog.NewLogs()
+	lrs := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	lrs.AppendEmpty().Body().SetStr("short")
+	// The 10th byte is in the middle of "é".
+	lrs.AppendEmpty().Body().SetStr("123456789é" + strings.Repeat("x", 10))
+	lrs.AppendEmpty().Body().SetEmptyBytes().FromRaw([]byte(strings.Repeat("b", 20)))
+	lrs.AppendEmpty().Body().SetEmptyMap().PutStr("message", strings.Repeat("m", 20))
+	lrs.AppendEmpty().Body().SetEmptyMap().PutStr("k", "v")
+	lrs.AppendEmpty().Body().SetEmptySlice().AppendEmpty().SetStr(strings.Repeat("s", 20))
+	lrs.AppendEmpty().Body().SetInt(1234567890123)
+	lrs.AppendEmpty()
+	return ld
+}
+
+func TestNewInvalid(t *testing.T) {
+	_, err := New(0, PolicyDrop)
+	assert.EqualError(t, err, "maximum body size must be positive")
+}
+
+func TestLogsTruncate(t *testing.T) {
+	var received []plog.Logs
+	next, err := consumer.NewLogs(func(_ context.Context, ld plog.Logs) error {
+		received = append(received, ld)
+		return nil
+	})
+	require.NoError(t, err)
+	l, err := New(10, PolicyTruncate)
+	require.NoError(t, err)
+	c, err := l.Logs(next)
+	require.NoError(t, err)
+	assert.True(t, c.Capabilities().MutatesData)
+	require.NoError(t, c.ConsumeLogs(context.Background(), newLogs()))
+
+	require.Len(t, received, 1)
+	lrs := received[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	require.Equal(t, 6, lrs.Len())
+	assert.Equal(t, "short", lrs.At(0).Body().Str())
+	assert.Equal(t, "123456789", lrs.At(1).Body().Str())
+	assert.Equal(t, []byte("bbbbbbbbbb"), lrs.At(2).Body().Bytes().AsRaw())
+	// The oversized map and slice bodies cannot be truncated and are dropped.
+	assert.Equal(t, map[string]any{"k": "v"}, lrs.At(3).Body().Map().AsRaw())
+	assert.Equal(t, int64(1234567890123), lrs.At(4).Body().Int())
+	assert.Equal(t, pcommon.ValueTypeEmpty, lrs.At(5).Body().Type())
+	assert.Equal(t, int64(2), l.TruncatedBodies())
+	assert.Equal(t, int64(2), l.DroppedRecords())
+}
+
+func TestLogsDrop(t *testing.T) {
+	var received []plog.Logs
+	next, err := consumer.NewLogs(func(_ context.Context, ld plog.Logs) error {
+		received = append(received, ld)
+		return nil
+	})
+	require.NoError(t, err)
+	l, err := New(10, PolicyDrop)
+	require.NoError(t, err)
+	c, err := l.Logs(next)
+	require.NoError(t, err)
+	require.NoError(t, c.ConsumeLogs(context.Background(), newLogs()))
+
+	require.Len(t, received, 1)
+	lrs := received[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	require.Equal(t, 4, lrs.Len())
+	assert.Equal(t, "short", lrs.At(0).Body().Str())
+	assert.Equal(t, pcommon.ValueTypeMap, lrs.At(1).Body().Type())
+	assert.Equal(t, pcommon.ValueTypeInt, lrs.At(2).Body().Type())
+	assert.Equal(t, pcommon.ValueTypeEmpty, lrs.At(3).Body().Type())
+	assert.Zero(t, l.TruncatedBodies())
+	assert.Equal(t, int64(4), l.DroppedRecords())
+}
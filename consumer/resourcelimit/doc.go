@@ -0,0 +1,6 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package resourcelimit provides consumers splitting the data they receive
+// into batches holding a limited number of distinct resources.
+package resourcelimit // import "go.opentelemetry.io/collector/consumer/resourcelimit"
@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package resourcelimit // import "go.opentelemetry.io/collector/consumer/resourcelimit"
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/internal/wrapper"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+var errInvalidMaxResources = errors.New("maxResources must be positive")
+
+// NewTraces returns a consumer.Traces passing the data to next in batches of at most maxResources
+// distinct resources. See NewLogs for the details.
+func NewTraces(maxResources int, next consumer.Traces) (consumer.Traces, error) {
+	if maxResources <= 0 {
+		return nil, errInvalidMaxResources
+	}
+	return wrapper.SplitTraces(next, true, func(td ptrace.Traces) []ptrace.Traces {
+		rss := td.ResourceSpans()
+		indexes, count := batchIndexes(rss.Len(), func(i int) pcommon.Resource { return rss.At(i).Resource() }, maxResources)
+		if count <= 1 {
+			return nil
+		}
+		batches := make([]ptrace.Traces, count)
+		for i := range batches {
+			batches[i] = ptrace.NewTraces()
+		}
+		for i := 0; i < rss.Len(); i++ {
+			rss.At(i).MoveTo(batches[indexes[i]].ResourceSpans().AppendEmpty())
+		}
+		return batches
+	})
+}
+
+// NewSingleResourceTraces returns a consumer.Traces passing the data to next in batches of a single
+// resource, for destinations assuming that all the spans of a batch share a resource. See NewLogs for
+// the details.
+func NewSingleResourceTraces(next consumer.Traces) (consumer.Traces, error) {
+	return NewTraces(1, next)
+}
+
+// NewMetrics returns a consumer.Metrics passing the data to next in batches of at most maxResources
+// distinct resources. See NewLogs for the details.
+func NewMetrics(maxResources int, next consumer.Metrics) (consumer.Metrics, error) {
+	if maxResources <= 0 {
+		return nil, errInvalidMaxResources
+	}
+	return wrapper.SplitMetrics(next, true, func(md pmetric.Metrics) []pmetric.Metrics {
+		rms := md.ResourceMetrics()
+		indexes, count := batchIndexes(rms.Len(), func(i int) pcommon.Resource { return rms.At(i).Resource() }, maxResources)
+		if count <= 1 {
+			return nil
+		}
+		batches := make([]pmetric.Metrics, count)
+		for i := range batches {
+			batches[i] = pmetric.NewMetrics()
+		}
+		for i := 0; i < rms.Len(); i++ {
+			rms.At(i).MoveTo(batches[indexes[i]].ResourceMetrics().AppendEmpty())
+		}
+		return batches
+	})
+}
+
+// NewLogs returns a consumer.Logs passing the data to next in batches of at most maxResources
+// distinct resources.
+//
+// Resources with the same attributes are the same resource: their ResourceLogs are always sent in
+// the same batch, keeping their order, so the records of a resource are never split across batches.
+// The batches are sent in the order of the first appearance of their resources. The first failed
+// batch stops the sending, its error being returned as a consumererror.Logs holding the records of
+// the failed and following batches, which were not delivered: only this data is to be retried.
+// Data which does not exceed maxResources distinct resources is passed as is, and its error
+// returned as is.
+func NewLogs(maxResources int, next consumer.Logs) (consumer.Logs, error) {
+	if maxResources <= 0 {
+		return nil, errInvalidMaxResources
+	}
+	return wrapper.SplitLogs(next, true, func(ld plog.Logs) []plog.Logs {
+		rls := ld.ResourceLogs()
+		indexes, count := batchIndexes(rls.Len(), func(i int) pcommon.Resource { return rls.At(i).Resource() }, maxResources)
+		if count <= 1 {
+			return nil
+		}
+		batches := make([]plog.Logs, count)
+		for i := range batches {
+			batches[i] = plog.NewLogs()
+		}
+		for i := 0; i < rls.Len(); i++ {
+			rls.At(i).MoveTo(batches[indexes[i]].ResourceLogs().AppendEmpty())
+		}
+		return batches
+	})
+}
+
+// resourceKey identifies a distinct resource.
+type resourceKey struct {
+	attributes pcommon.Fingerprint
+	dropped    uint32
+}
+
+// batchIndexes returns the index of the batch of each of the n resources, and the number of batches.
+// The distinct resources are assigned to batches of maxResources in the order of their first appearance.
+func batchIndexes(n int, resource func(i int) pcommon.Resource, maxResources int) ([]int, int) {
+	distinct := make(map[resourceKey]int)
+	indexes := make([]int, n)
+	for i := 0; i < n; i++ {
+		r := resource(i)
+		key := resourceKey{attributes: r.Attributes().Fingerprint(), dropped: r.DroppedAttributesCount()}
+		idx, ok := distinct[key]
+		if !ok {
+			idx = len(distinct)
+			distinct[key] = idx
+		}
+		indexes[i] = idx / maxResources
+	}
+	return indexes, (len(distinct) + maxResources - 1) / maxResources
+}
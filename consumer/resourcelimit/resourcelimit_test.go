@@ -0,0 +1,223 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package resourcelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func nopTraces(t *testing.T) consumer.Traces {
+	c, err := consumer.NewTraces(func(context.Context, ptrace.Traces) error { return nil })
+	require.NoError(t, err)
+	return c
+}
+
+// resources lists the resource of each ResourceX of the generated data: 25 distinct resources,
+// where every fifth one is repeated at the end.
+func resources() []int64 {
+	var res []int64
+	for i := int64(0); i < 25; i++ {
+		res = append(res, i)
+	}
+	for i := int64(0); i < 25; i += 5 {
+		res = append(res, i)
+	}
+	return res
+}
+
+func TestLogsSplit(t *testing.T) {
+	ld := plog.NewLogs()
+	for _, r := range resources() {
+		rl := ld.ResourceLogs().AppendEmpty()
+		rl.Resource().Attributes().PutInt("resource", r)
+		for i := 0; i < 3; i++ {
+			rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Attributes().PutInt("resource", r)
+		}
+	}
+
+	var batches []plog.Logs
+	next, err := consumer.NewLogs(func(_ context.Context, ld plog.Logs) error {
+		batches = append(batches, ld)
+		return nil
+	})
+	require.NoError(t, err)
+	c, err := NewLogs(10, next)
+	require.NoError(t, err)
+	assert.True(t, c.Capabilities().MutatesData)
+	require.NoError(t, c.ConsumeLogs(context.Background(), ld))
+
+	require.Len(t, batches, 3)
+	count := 0
+	for _, batch := range batches {
+		count += batch.LogRecordCount()
+	}
+	assert.Equal(t, 90, count)
+	seen := map[int64]int{}
+	for b, batch := range batches {
+		distinct := map[int64]bool{}
+		rls := batch.ResourceLogs()
+		for i := 0; i < rls.Len(); i++ {
+			v, ok := rls.At(i).Resource().Attributes().Get("resource")
+			require.True(t, ok)
+			r := v.Int()
+			distinct[r] = true
+			// Resources are assigned to batches in the order of their first appearance,
+			// and never split across batches.
+			assert.Equal(t, int(r/10), b)
+			if prev, ok := seen[r]; ok {
+				assert.Equal(t, b, prev)
+			}
+			seen[r] = b
+			lrs := rls.At(i).ScopeLogs()
+			for j := 0; j < lrs.Len(); j++ {
+				rv, _ := lrs.At(j).LogRecords().At(0).Attributes().Get("resource")
+				assert.Equal(t, r, rv.Int())
+			}
+		}
+		assert.LessOrEqual(t, len(distinct), 10)
+	}
+	assert.Len(t, seen, 25)
+	// The repeated resources keep their order after the others of their batch.
+	assert.Equal(t, 12, batches[0].ResourceLogs().Len())
+	v, _ := batches[0].ResourceLogs().At(10).Resource().Attributes().Get("resource")
+	assert.Equal(t, int64(0), v.Int())
+	assert.Equal(t, 6, batches[2].ResourceLogs().Len())
+}
+
+func TestTracesSplit(t *testing.T) {
+	td := ptrace.NewTraces()
+	for _, r := range resources() {
+		rs := td.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().PutInt("resource", r)
+		rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	}
+
+	var batches []ptrace.Traces
+	next, err := consumer.NewTraces(func(_ context.Context, td ptrace.Traces) error {
+		batches = append(batches, td)
+		return nil
+	})
+	require.NoError(t, err)
+	c, err := NewTraces(25, next)
+	require.NoError(t, err)
+	require.NoError(t, c.ConsumeTraces(context.Background(), td))
+	// Repeated resources do not count as distinct ones.
+	require.Len(t, batches, 1)
+	assert.Equal(t, td, batches[0])
+
+	td = ptrace.NewTraces()
+	for _, r := range resources() {
+		rs := td.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().PutInt("resource", r)
+		rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	}
+	batches = nil
+	c, err = NewTraces(7, next)
+	require.NoError(t, err)
+	require.NoError(t, c.ConsumeTraces(context.Background(), td))
+	var lens []int
+	for _, batch := range batches {
+		lens = append(lens, batch.ResourceSpans().Len())
+	}
+	assert.Equal(t, []int{9, 8, 9, 4}, lens)
+}
+
+func TestSingleResourceTraces(t *testing.T) {
+	td := ptrace.NewTraces()
+	for _, r := range resources() {
+		rs := td.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().PutInt("resource", r)
+		rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("span")
+	}
+
+	var batches []ptrace.Traces
+	next, err := consumer.NewTraces(func(_ context.Context, td ptrace.Traces) error {
+		batches = append(batches, td)
+		return nil
+	})
+	require.NoError(t, err)
+	c, err := NewSingleResourceTraces(next)
+	require.NoError(t, err)
+	require.NoError(t, c.ConsumeTraces(context.Background(), td))
+
+	require.Len(t, batches, 25)
+	count := 0
+	for b, batch := range batches {
+		count += batch.SpanCount()
+		rss := batch.ResourceSpans()
+		for i := 0; i < rss.Len(); i++ {
+			v, ok := rss.At(i).Resource().Attributes().Get("resource")
+			require.True(t, ok)
+			assert.Equal(t, int64(b), v.Int())
+		}
+		// The spans of the repeated resources join the batch of their resource.
+		if b%5 == 0 {
+			assert.Equal(t, 2, rss.Len())
+		} else {
+			assert.Equal(t, 1, rss.Len())
+		}
+	}
+	assert.Equal(t, 30, count)
+
+	// Data with a single resource is passed as is.
+	batches = nil
+	td = ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	require.NoError(t, c.ConsumeTraces(context.Background(), td))
+	require.Len(t, batches, 1)
+	assert.Equal(t, td, batches[0])
+}
+
+func TestMetricsSplitErrors(t *testing.T) {
+	md := pmetric.NewMetrics()
+	for _, r := range resources() {
+		rm := md.ResourceMetrics().AppendEmpty()
+		rm.Resource().Attributes().PutInt("resource", r)
+		rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptyGauge().DataPoints().AppendEmpty()
+	}
+
+	calls := 0
+	next, err := consumer.NewMetrics(func(context.Context, pmetric.Metrics) error {
+		calls++
+		if calls == 1 {
+			return errors.New("first batch failed")
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	c, err := NewMetrics(20, next)
+	require.NoError(t, err)
+	// The failed batch stops the sending, and is returned with the following one.
+	err = c.ConsumeMetrics(context.Background(), md)
+	require.EqualError(t, err, "first batch failed")
+	assert.Equal(t, 1, calls)
+	var metricsErr consumererror.Metrics
+	require.ErrorAs(t, err, &metricsErr)
+	undelivered := metricsErr.Data().ResourceMetrics()
+	// The 24 ResourceMetrics of the first batch, followed by the 6 of the second one.
+	require.Equal(t, 30, undelivered.Len())
+	assert.Equal(t, map[string]any{"resource": int64(20)}, undelivered.At(24).Resource().Attributes().AsRaw())
+
+	// The undelivered data is split again when retried.
+	require.NoError(t, c.ConsumeMetrics(context.Background(), metricsErr.Data()))
+	assert.Equal(t, 3, calls)
+}
+
+func TestInvalidMaxResources(t *testing.T) {
+	_, err := NewTraces(0, nopTraces(t))
+	require.ErrorIs(t, err, errInvalidMaxResources)
+	_, err = NewTraces(-1, nopTraces(t))
+	require.ErrorIs(t, err, errInvalidMaxResources)
+}
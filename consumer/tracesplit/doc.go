@@ -0,0 +1,6 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package tracesplit provides a consumer splitting the traces it receives into batches of a limited
+// number of spans, without dividing the spans of a trace across batches.
+package tracesplit // import "go.opentelemetry.io/collector/consumer/tracesplit"
@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tracesplit // import "go.opentelemetry.io/collector/consumer/tracesplit"
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/internal/wrapper"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+var errInvalidMaxSpans = errors.New("maxSpans must be positive")
+
+// NewTraces returns a consumer.Traces passing the data to next in batches of at most maxSpans spans, all the
+// spans of a trace being passed in the same batch, so the processors grouping the spans by trace see the
+// whole traces. A trace with more than maxSpans spans is passed alone in its batch, which exceeds the limit.
+// See ptrace.Traces.SplitByTraceID for the details.
+//
+// The batches are passed in order. The first failed batch stops the sending, its error being returned as a
+// consumererror.Traces holding the spans of the failed and following batches, which were not delivered: only
+// this data is to be retried. Data which does not exceed maxSpans spans is passed as is, and its error returned
+// as is.
+func NewTraces(maxSpans int, next consumer.Traces) (consumer.Traces, error) {
+	if maxSpans <= 0 {
+		return nil, errInvalidMaxSpans
+	}
+	return wrapper.SplitTraces(next, next.Capabilities().MutatesData, func(td ptrace.Traces) []ptrace.Traces {
+		if batches := td.SplitByTraceID(maxSpans); len(batches) > 1 {
+			return batches
+		}
+		return nil
+	})
+}
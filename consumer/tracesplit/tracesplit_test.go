@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tracesplit
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// generateTraces returns Traces where the trace i has sizes[i] spans, interleaved over 2 resources.
+func generateTraces(sizes ...int) ptrace.Traces {
+	td := ptrace.NewTraces()
+	rss := []ptrace.SpanSlice{
+		td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans(),
+		td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans(),
+	}
+	n := 0
+	for id, size := range sizes {
+		for i := 0; i < size; i++ {
+			span := rss[n%2].AppendEmpty()
+			span.SetTraceID(pcommon.TraceID([16]byte{byte(id + 1)}))
+			n++
+		}
+	}
+	return td
+}
+
+// traceIDs returns the trace IDs of the spans of td.
+func traceIDs(td ptrace.Traces) map[pcommon.TraceID]int {
+	ids := make(map[pcommon.TraceID]int)
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		sss := rss.At(i).ScopeSpans()
+		for j := 0; j < sss.Len(); j++ {
+			spans := sss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				ids[spans.At(k).TraceID()]++
+			}
+		}
+	}
+	return ids
+}
+
+func TestTraces(t *testing.T) {
+	sizes := []int{3, 2, 4, 1, 7}
+	var batches []ptrace.Traces
+	next, err := consumer.NewTraces(func(_ context.Context, td ptrace.Traces) error {
+		batches = append(batches, td)
+		return nil
+	})
+	require.NoError(t, err)
+	tr, err := NewTraces(5, next)
+	require.NoError(t, err)
+	require.NoError(t, tr.ConsumeTraces(context.Background(), generateTraces(sizes...)))
+
+	require.Len(t, batches, 4)
+	seen := make(map[pcommon.TraceID]int)
+	for b, batch := range batches {
+		for id, count := range traceIDs(batch) {
+			_, ok := seen[id]
+			assert.False(t, ok, "trace %v split across batches", id)
+			seen[id] = b
+			// All the spans of the trace are in the batch.
+			assert.Equal(t, sizes[id[0]-1], count)
+		}
+	}
+	// The traces are batched in the order of their first span, the fourth one only having a span in the second
+	// resource. Only the batch of the trace larger than the limit exceeds it.
+	var counts []int
+	for _, batch := range batches {
+		counts = append(counts, batch.SpanCount())
+	}
+	assert.Equal(t, []int{5, 4, 7, 1}, counts)
+}
+
+func TestTracesErrors(t *testing.T) {
+	errFirst := errors.New("first batch")
+	calls := 0
+	next, err := consumer.NewTraces(func(context.Context, ptrace.Traces) error {
+		calls++
+		if calls == 1 {
+			return errFirst
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	tr, err := NewTraces(2, next)
+	require.NoError(t, err)
+	// The failed batch stops the sending, and is returned with the following ones.
+	err = tr.ConsumeTraces(context.Background(), generateTraces(2, 2, 2))
+	require.ErrorIs(t, err, errFirst)
+	assert.Equal(t, 1, calls)
+	var tracesErr consumererror.Traces
+	require.ErrorAs(t, err, &tracesErr)
+	assert.Equal(t, 6, tracesErr.Data().SpanCount())
+
+	// The undelivered traces are split again when retried.
+	require.NoError(t, tr.ConsumeTraces(context.Background(), tracesErr.Data()))
+	assert.Equal(t, 4, calls)
+}
+
+func TestTracesInvalidMaxSpans(t *testing.T) {
+	next, err := consumer.NewTraces(func(context.Context, ptrace.Traces) error { return nil })
+	require.NoError(t, err)
+	_, err = NewTraces(0, next)
+	require.ErrorIs(t, err, errInvalidMaxSpans)
+}
@@ -0,0 +1,6 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package utf8sanitizer provides consumers replacing the invalid UTF-8 sequences of the string values,
+// or dropping the records holding them, so that the data can be marshaled as protobuf.
+package utf8sanitizer // import "go.opentelemetry.io/collector/consumer/utf8sanitizer"
@@ -0,0 +1,222 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package utf8sanitizer // import "go.opentelemetry.io/collector/consumer/utf8sanitizer"
+
+import (
+	"strings"
+	"sync/atomic"
+	"unicode/utf8"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/internal/wrapper"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// Policy specifies how a Sanitizer handles the records holding invalid UTF-8 string values.
+type Policy int32
+
+const (
+	// PolicyReplace replaces the invalid UTF-8 sequences with the Unicode replacement character U+FFFD.
+	PolicyReplace Policy = iota
+	// PolicyDrop drops the spans, metric data points and log records holding invalid UTF-8 string values.
+	PolicyDrop
+)
+
+// Sanitizer enforces the UTF-8 validity of the string values of the resource, scope, span, span event,
+// span link, metric data point and log record attributes, and of the log record bodies. The string values
+// nested in maps and slices are sanitized too.
+//
+// The invalid values of the resource and scope attributes are always replaced, since the Sanitizer only
+// drops the records holding invalid values in their own attributes or body.
+type Sanitizer struct {
+	policy Policy
+
+	replacedValues atomic.Int64
+	droppedRecords atomic.Int64
+}
+
+// New returns a Sanitizer handling the invalid UTF-8 string values with the policy.
+func New(policy Policy) *Sanitizer {
+	return &Sanitizer{policy: policy}
+}
+
+// ReplacedValues returns the number of string values whose invalid UTF-8 sequences were replaced.
+func (s *Sanitizer) ReplacedValues() int64 {
+	return s.replacedValues.Load()
+}
+
+// DroppedRecords returns the number of spans, metric data points and log records which were dropped.
+func (s *Sanitizer) DroppedRecords() int64 {
+	return s.droppedRecords.Load()
+}
+
+// Traces returns a consumer.Traces sanitizing the traces before passing them to next.
+func (s *Sanitizer) Traces(next consumer.Traces) (consumer.Traces, error) {
+	return wrapper.ProcessTraces(next, true, func(td ptrace.Traces) error {
+		rss := td.ResourceSpans()
+		for i := 0; i < rss.Len(); i++ {
+			rs := rss.At(i)
+			s.replaceMap(rs.Resource().Attributes())
+			sss := rs.ScopeSpans()
+			for j := 0; j < sss.Len(); j++ {
+				ss := sss.At(j)
+				s.replaceMap(ss.Scope().Attributes())
+				ss.Spans().RemoveIf(func(span ptrace.Span) bool {
+					events := span.Events()
+					links := span.Links()
+					if s.policy == PolicyDrop {
+						invalid := !validMap(span.Attributes())
+						for k := 0; k < events.Len() && !invalid; k++ {
+							invalid = !validMap(events.At(k).Attributes())
+						}
+						for k := 0; k < links.Len() && !invalid; k++ {
+							invalid = !validMap(links.At(k).Attributes())
+						}
+						return s.drop(invalid)
+					}
+					s.replaceMap(span.Attributes())
+					for k := 0; k < events.Len(); k++ {
+						s.replaceMap(events.At(k).Attributes())
+					}
+					for k := 0; k < links.Len(); k++ {
+						s.replaceMap(links.At(k).Attributes())
+					}
+					return false
+				})
+			}
+		}
+		return nil
+	})
+}
+
+// Metrics returns a consumer.Metrics sanitizing the metrics before passing them to next.
+func (s *Sanitizer) Metrics(next consumer.Metrics) (consumer.Metrics, error) {
+	return wrapper.ProcessMetrics(next, true, func(md pmetric.Metrics) error {
+		rms := md.ResourceMetrics()
+		for i := 0; i < rms.Len(); i++ {
+			rm := rms.At(i)
+			s.replaceMap(rm.Resource().Attributes())
+			sms := rm.ScopeMetrics()
+			for j := 0; j < sms.Len(); j++ {
+				sm := sms.At(j)
+				s.replaceMap(sm.Scope().Attributes())
+				metrics := sm.Metrics()
+				for k := 0; k < metrics.Len(); k++ {
+					s.sanitizeMetric(metrics.At(k))
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// Logs returns a consumer.Logs sanitizing the logs before passing them to next.
+func (s *Sanitizer) Logs(next consumer.Logs) (consumer.Logs, error) {
+	return wrapper.ProcessLogs(next, true, func(ld plog.Logs) error {
+		rls := ld.ResourceLogs()
+		for i := 0; i < rls.Len(); i++ {
+			rl := rls.At(i)
+			s.replaceMap(rl.Resource().Attributes())
+			sls := rl.ScopeLogs()
+			for j := 0; j < sls.Len(); j++ {
+				sl := sls.At(j)
+				s.replaceMap(sl.Scope().Attributes())
+				sl.LogRecords().RemoveIf(func(lr plog.LogRecord) bool {
+					if s.policy == PolicyDrop {
+						return s.drop(!validMap(lr.Attributes()) || !validValue(lr.Body()))
+					}
+					s.replaceMap(lr.Attributes())
+					s.replaceValue(lr.Body())
+					return false
+				})
+			}
+		}
+		return nil
+	})
+}
+
+func (s *Sanitizer) sanitizeMetric(m pmetric.Metric) {
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		m.Gauge().DataPoints().RemoveIf(func(dp pmetric.NumberDataPoint) bool { return s.sanitizeRecord(dp.Attributes()) })
+	case pmetric.MetricTypeSum:
+		m.Sum().DataPoints().RemoveIf(func(dp pmetric.NumberDataPoint) bool { return s.sanitizeRecord(dp.Attributes()) })
+	case pmetric.MetricTypeHistogram:
+		m.Histogram().DataPoints().RemoveIf(func(dp pmetric.HistogramDataPoint) bool { return s.sanitizeRecord(dp.Attributes()) })
+	case pmetric.MetricTypeExponentialHistogram:
+		m.ExponentialHistogram().DataPoints().RemoveIf(func(dp pmetric.ExponentialHistogramDataPoint) bool { return s.sanitizeRecord(dp.Attributes()) })
+	case pmetric.MetricTypeSummary:
+		m.Summary().DataPoints().RemoveIf(func(dp pmetric.SummaryDataPoint) bool { return s.sanitizeRecord(dp.Attributes()) })
+	}
+}
+
+// sanitizeRecord sanitizes the attributes of a record according to the policy, and returns whether the
+// record must be dropped.
+func (s *Sanitizer) sanitizeRecord(attrs pcommon.Map) bool {
+	if s.policy == PolicyDrop {
+		return s.drop(!validMap(attrs))
+	}
+	s.replaceMap(attrs)
+	return false
+}
+
+func (s *Sanitizer) drop(invalid bool) bool {
+	if invalid {
+		s.droppedRecords.Add(1)
+	}
+	return invalid
+}
+
+func (s *Sanitizer) replaceMap(m pcommon.Map) {
+	m.Range(func(_ string, v pcommon.Value) bool {
+		s.replaceValue(v)
+		return true
+	})
+}
+
+func (s *Sanitizer) replaceValue(v pcommon.Value) {
+	switch v.Type() {
+	case pcommon.ValueTypeStr:
+		if !utf8.ValidString(v.Str()) {
+			v.SetStr(strings.ToValidUTF8(v.Str(), string(utf8.RuneError)))
+			s.replacedValues.Add(1)
+		}
+	case pcommon.ValueTypeMap:
+		s.replaceMap(v.Map())
+	case pcommon.ValueTypeSlice:
+		sl := v.Slice()
+		for i := 0; i < sl.Len(); i++ {
+			s.replaceValue(sl.At(i))
+		}
+	}
+}
+
+func validMap(m pcommon.Map) bool {
+	valid := true
+	m.Range(func(_ string, v pcommon.Value) bool {
+		valid = validValue(v)
+		return valid
+	})
+	return valid
+}
+
+func validValue(v pcommon.Value) bool {
+	switch v.Type() {
+	case pcommon.ValueTypeStr:
+		return utf8.ValidString(v.Str())
+	case pcommon.ValueTypeMap:
+		return validMap(v.Map())
+	case pcommon.ValueTypeSlice:
+		sl := v.Slice()
+		for i := 0; i < sl.Len(); i++ {
+			if !validValue(sl.At(i)) {
+				return false
+			}
+		}
+	}
+	return true
+}
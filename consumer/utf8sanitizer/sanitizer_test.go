@@ -0,0 +1,144 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package utf8sanitizer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+const invalid = "bad\xff\xfevalue"
+
+func newLogs() plog.Logs {
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("host.name", invalid)
+	lrs := rl.ScopeLogs().AppendEmpty().LogRecords()
+	lrs.AppendEmpty().Body().SetStr("valid")
+	lrs.AppendEmpty().Body().SetStr(invalid)
+	lrs.AppendEmpty().Attributes().PutEmptySlice("tags").AppendEmpty().SetStr(invalid)
+	return ld
+}
+
+func TestLogsReplace(t *testing.T) {
+	var received []plog.Logs
+	next, err := consumer.NewLogs(func(_ context.Context, ld plog.Logs) error {
+		received = append(received, ld)
+		return nil
+	})
+	require.NoError(t, err)
+	s := New(PolicyReplace)
+	c, err := s.Logs(next)
+	require.NoError(t, err)
+	assert.True(t, c.Capabilities().MutatesData)
+	require.NoError(t, c.ConsumeLogs(context.Background(), newLogs()))
+
+	require.Len(t, received, 1)
+	rl := received[0].ResourceLogs().At(0)
+	v, _ := rl.Resource().Attributes().Get("host.name")
+	assert.Equal(t, "bad�value", v.Str())
+	lrs := rl.ScopeLogs().At(0).LogRecords()
+	require.Equal(t, 3, lrs.Len())
+	assert.Equal(t, "valid", lrs.At(0).Body().Str())
+	assert.Equal(t, "bad�value", lrs.At(1).Body().Str())
+	v, _ = lrs.At(2).Attributes().Get("tags")
+	assert.Equal(t, "bad�value", v.Slice().At(0).Str())
+	assert.Equal(t, int64(3), s.ReplacedValues())
+	assert.Zero(t, s.DroppedRecords())
+}
+
+func TestLogsDrop(t *testing.T) {
+	var received []plog.Logs
+	next, err := consumer.NewLogs(func(_ context.Context, ld plog.Logs) error {
+		received = append(received, ld)
+		return nil
+	})
+	require.NoError(t, err)
+	s := New(PolicyDrop)
+	c, err := s.Logs(next)
+	require.NoError(t, err)
+	require.NoError(t, c.ConsumeLogs(context.Background(), newLogs()))
+
+	require.Len(t, received, 1)
+	rl := received[0].ResourceLogs().At(0)
+	// The resource attributes are replaced, the invalid records dropped.
+	v, _ := rl.Resource().Attributes().Get("host.name")
+	assert.Equal(t, "bad�value", v.Str())
+	lrs := rl.ScopeLogs().At(0).LogRecords()
+	require.Equal(t, 1, lrs.Len())
+	assert.Equal(t, "valid", lrs.At(0).Body().Str())
+	assert.Equal(t, int64(1), s.ReplacedValues())
+	assert.Equal(t, int64(2), s.DroppedRecords())
+}
+
+func TestTraces(t *testing.T) {
+	newTraces := func() ptrace.Traces {
+		td := ptrace.NewTraces()
+		spans := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+		spans.AppendEmpty().Attributes().PutStr("ok", "valid")
+		spans.AppendEmpty().Events().AppendEmpty().Attributes().PutStr("exception.message", invalid)
+		spans.AppendEmpty().Links().AppendEmpty().Attributes().PutEmptyMap("nested").PutStr("key", invalid)
+		return td
+	}
+
+	for _, policy := range []Policy{PolicyReplace, PolicyDrop} {
+		var received []ptrace.Traces
+		next, err := consumer.NewTraces(func(_ context.Context, td ptrace.Traces) error {
+			received = append(received, td)
+			return nil
+		})
+		require.NoError(t, err)
+		s := New(policy)
+		c, err := s.Traces(next)
+		require.NoError(t, err)
+		require.NoError(t, c.ConsumeTraces(context.Background(), newTraces()))
+
+		spans := received[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+		if policy == PolicyDrop {
+			assert.Equal(t, 1, spans.Len())
+			assert.Equal(t, int64(2), s.DroppedRecords())
+			continue
+		}
+		require.Equal(t, 3, spans.Len())
+		v, _ := spans.At(1).Events().At(0).Attributes().Get("exception.message")
+		assert.Equal(t, "bad�value", v.Str())
+		v, _ = spans.At(2).Links().At(0).Attributes().Get("nested")
+		nested, _ := v.Map().Get("key")
+		assert.Equal(t, "bad�value", nested.Str())
+		assert.Equal(t, int64(2), s.ReplacedValues())
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	md := pmetric.NewMetrics()
+	metrics := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	dps := metrics.AppendEmpty().SetEmptyGauge().DataPoints()
+	dps.AppendEmpty().Attributes().PutStr("path", invalid)
+	dps.AppendEmpty().Attributes().PutStr("path", "/valid")
+	metrics.AppendEmpty().SetEmptyHistogram().DataPoints().AppendEmpty().Attributes().PutStr("path", invalid)
+
+	var received []pmetric.Metrics
+	next, err := consumer.NewMetrics(func(_ context.Context, md pmetric.Metrics) error {
+		received = append(received, md)
+		return nil
+	})
+	require.NoError(t, err)
+	s := New(PolicyDrop)
+	c, err := s.Metrics(next)
+	require.NoError(t, err)
+	require.NoError(t, c.ConsumeMetrics(context.Background(), md))
+
+	assert.Equal(t, 1, received[0].DataPointCount())
+	v, _ := received[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Gauge().DataPoints().At(0).Attributes().Get("path")
+	assert.Equal(t, "/valid", v.Str())
+	assert.Equal(t, int64(2), s.DroppedRecords())
+}
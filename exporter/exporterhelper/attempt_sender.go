@@ -0,0 +1,133 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporterhelper"
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// SendAttemptEvent describes a single attempt to send a request to the destination.
+// Experimental: This API is at the early stage of development and may change without backward compatibility.
+type SendAttemptEvent struct {
+	// RequestID identifies the request across its attempts. It is unique for the exporter.
+	RequestID uint64
+	// Attempt is the number of the attempt for the request, starting at 1.
+	Attempt int
+	// Items is the number of items sent in the attempt.
+	Items int
+	// Err is the error returned by the attempt, nil if it succeeded.
+	Err error
+	// Latency is the time the attempt took.
+	Latency time.Duration
+}
+
+// SendAttemptFunc receives the SendAttemptEvent of every attempt to send a request.
+// It is called synchronously on the send path, so it must not block.
+// Experimental: This API is at the early stage of development and may change without backward compatibility.
+type SendAttemptFunc func(SendAttemptEvent)
+
+// WithSendAttemptCallback registers a callback receiving a SendAttemptEvent for each attempt to send a request,
+// including the retries. At most maxEventsPerSecond events are passed to the callback every second, the others
+// are dropped. If maxEventsPerSecond is not positive, events are not rate-limited.
+// Experimental: This API is at the early stage of development and may change without backward compatibility.
+func WithSendAttemptCallback(callback SendAttemptFunc, maxEventsPerSecond int) Option {
+	return func(o *baseExporter) error {
+		o.attemptReporter = &attemptReporter{
+			callback:           callback,
+			maxEventsPerSecond: maxEventsPerSecond,
+			now:                time.Now,
+		}
+		return nil
+	}
+}
+
+// WithSendAttemptsHistogram records the number of attempts made to send each request, including the retries,
+// in the exporter_send_attempts histogram when the request succeeds or fails without further retries.
+// Experimental: This API is at the early stage of development and may change without backward compatibility.
+func WithSendAttemptsHistogram() Option {
+	return func(o *baseExporter) error {
+		o.recordSendAttempts = true
+		return nil
+	}
+}
+
+// attemptReporter passes the send attempt events to the registered callback, rate-limiting them.
+type attemptReporter struct {
+	callback           SendAttemptFunc
+	maxEventsPerSecond int
+	now                func() time.Time
+
+	lastRequestID atomic.Uint64
+
+	mu          sync.Mutex
+	windowStart time.Time
+	windowCount int
+}
+
+// nextRequestID returns the ID to use for a new request.
+func (ar *attemptReporter) nextRequestID() uint64 {
+	return ar.lastRequestID.Add(1)
+}
+
+// attempt sends req with the next sender as the given attempt for the request, reporting the outcome.
+func (ar *attemptReporter) attempt(ctx context.Context, next requestSender, req Request, requestID uint64, attempt int) error {
+	items := req.ItemsCount()
+	start := ar.now()
+	err := next.send(ctx, req)
+	latency := ar.now().Sub(start)
+	if ar.allow() {
+		ar.callback(SendAttemptEvent{
+			RequestID: requestID,
+			Attempt:   attempt,
+			Items:     items,
+			Err:       err,
+			Latency:   latency,
+		})
+	}
+	return err
+}
+
+// allow returns whether an event can be reported in the current one-second window.
+func (ar *attemptReporter) allow() bool {
+	if ar.maxEventsPerSecond <= 0 {
+		return true
+	}
+	ar.mu.Lock()
+	defer ar.mu.Unlock()
+	now := ar.now()
+	if now.Sub(ar.windowStart) >= time.Second {
+		ar.windowStart = now
+		ar.windowCount = 0
+	}
+	if ar.windowCount >= ar.maxEventsPerSecond {
+		return false
+	}
+	ar.windowCount++
+	return true
+}
+
+// attemptSender reports the single send attempt of each request when retries are disabled.
+type attemptSender struct {
+	baseRequestSender
+	// reporter reports the attempt if not nil.
+	reporter *attemptReporter
+	// attemptsObsrep records the number of attempts if not nil.
+	attemptsObsrep *obsReport
+}
+
+func (as *attemptSender) send(ctx context.Context, req Request) error {
+	var err error
+	if as.reporter != nil {
+		err = as.reporter.attempt(ctx, as.nextSender, req, as.reporter.nextRequestID(), 1)
+	} else {
+		err = as.nextSender.send(ctx, req)
+	}
+	if as.attemptsObsrep != nil {
+		as.attemptsObsrep.recordSendAttempts(context.WithoutCancel(ctx), 1, err)
+	}
+	return err
+}
@@ -0,0 +1,266 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterhelper
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+)
+
+// flakyRequest fails the first failures exports, the following exports returning finalErr.
+type flakyRequest struct {
+	mu       sync.Mutex
+	failures int
+	finalErr error
+}
+
+func (r *flakyRequest) Export(context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.failures > 0 {
+		r.failures--
+		return errors.New("transient error")
+	}
+	return r.finalErr
+}
+
+func (r *flakyRequest) ItemsCount() int {
+	return 3
+}
+
+type attemptRecorder struct {
+	mu     sync.Mutex
+	events []SendAttemptEvent
+}
+
+func (r *attemptRecorder) record(ev SendAttemptEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, ev)
+}
+
+func (r *attemptRecorder) get() []SendAttemptEvent {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]SendAttemptEvent(nil), r.events...)
+}
+
+func TestSendAttemptCallbackWithRetries(t *testing.T) {
+	rCfg := configretry.NewDefaultBackOffConfig()
+	rCfg.InitialInterval = time.Millisecond
+	rCfg.RandomizationFactor = 0
+	rec := &attemptRecorder{}
+	be, err := newBaseExporter(defaultSettings, defaultDataType, newNoopObsrepSender,
+		WithSendAttemptCallback(rec.record, 0), WithRetry(rCfg))
+	require.NoError(t, err)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	require.NoError(t, be.send(context.Background(), &flakyRequest{failures: 2}))
+	require.NoError(t, be.send(context.Background(), &flakyRequest{}))
+
+	events := rec.get()
+	require.Len(t, events, 4)
+	for i, ev := range events[:3] {
+		assert.Equal(t, events[0].RequestID, ev.RequestID)
+		assert.Equal(t, i+1, ev.Attempt)
+		assert.Equal(t, 3, ev.Items)
+		assert.GreaterOrEqual(t, ev.Latency, time.Duration(0))
+	}
+	require.Error(t, events[0].Err)
+	require.Error(t, events[1].Err)
+	require.NoError(t, events[2].Err)
+
+	// The second request is a new one, succeeding at its first attempt.
+	assert.NotEqual(t, events[0].RequestID, events[3].RequestID)
+	assert.Equal(t, 1, events[3].Attempt)
+	assert.NoError(t, events[3].Err)
+}
+
+func TestSendAttemptCallbackWithoutRetries(t *testing.T) {
+	rec := &attemptRecorder{}
+	be, err := newBaseExporter(defaultSettings, defaultDataType, newNoopObsrepSender,
+		WithSendAttemptCallback(rec.record, 0))
+	require.NoError(t, err)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	require.Error(t, be.send(context.Background(), &flakyRequest{failures: 1}))
+	events := rec.get()
+	require.Len(t, events, 1)
+	assert.Equal(t, 1, events[0].Attempt)
+	assert.EqualError(t, events[0].Err, "transient error")
+}
+
+func TestSendAttemptCallbackRateLimited(t *testing.T) {
+	rec := &attemptRecorder{}
+	be, err := newBaseExporter(defaultSettings, defaultDataType, newNoopObsrepSender,
+		WithSendAttemptCallback(rec.record, 2))
+	require.NoError(t, err)
+	now := time.Now()
+	be.attemptReporter.now = func() time.Time { return now }
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	for i := 0; i < 5; i++ {
+		require.NoError(t, be.send(context.Background(), &flakyRequest{}))
+	}
+	assert.Len(t, rec.get(), 2)
+
+	// A new window allows new events.
+	now = now.Add(time.Second)
+	require.NoError(t, be.send(context.Background(), &flakyRequest{}))
+	events := rec.get()
+	require.Len(t, events, 3)
+	assert.Equal(t, uint64(6), events[2].RequestID)
+}
+
+// sendAttemptsSettings returns exporter.Settings recording the exporter metrics in metricReader.
+func sendAttemptsSettings(metricReader *sdkmetric.ManualReader) exporter.Settings {
+	set := exportertest.NewNopSettings()
+	set.TelemetrySettings.MetricsLevel = configtelemetry.LevelBasic
+	set.TelemetrySettings.LeveledMeterProvider = func(level configtelemetry.Level) metric.MeterProvider {
+		if level >= configtelemetry.LevelBasic {
+			return sdkmetric.NewMeterProvider(sdkmetric.WithReader(metricReader))
+		}
+		return nil
+	}
+	return set
+}
+
+// sendAttempts returns the data points of the exporter_send_attempts histogram by outcome.
+func sendAttempts(t *testing.T, metricReader *sdkmetric.ManualReader) map[string]metricdata.HistogramDataPoint[int64] {
+	ownMetrics := new(metricdata.ResourceMetrics)
+	require.NoError(t, metricReader.Collect(context.Background(), ownMetrics))
+	dps := map[string]metricdata.HistogramDataPoint[int64]{}
+	for _, sm := range ownMetrics.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != "otelcol_exporter_send_attempts" {
+				continue
+			}
+			for _, dp := range m.Data.(metricdata.Histogram[int64]).DataPoints {
+				outcome, _ := dp.Attributes.Value("outcome")
+				dps[outcome.AsString()] = dp
+			}
+		}
+	}
+	return dps
+}
+
+func TestSendAttemptsHistogramWithRetries(t *testing.T) {
+	metricReader := sdkmetric.NewManualReader()
+	set := sendAttemptsSettings(metricReader)
+	rCfg := configretry.NewDefaultBackOffConfig()
+	rCfg.InitialInterval = time.Millisecond
+	rCfg.RandomizationFactor = 0
+	be, err := newBaseExporter(set, defaultDataType, newNoopObsrepSender, WithSendAttemptsHistogram(), WithRetry(rCfg))
+	require.NoError(t, err)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	// The requests succeed at their third and first attempts, and fail permanently at their second attempt.
+	require.NoError(t, be.send(context.Background(), &flakyRequest{failures: 2}))
+	require.NoError(t, be.send(context.Background(), &flakyRequest{}))
+	require.Error(t, be.send(context.Background(), &flakyRequest{failures: 1, finalErr: consumererror.NewPermanent(errors.New("bad data"))}))
+
+	dps := sendAttempts(t, metricReader)
+	require.Len(t, dps, 2)
+	success := dps["success"]
+	assert.Equal(t, uint64(2), success.Count)
+	assert.Equal(t, int64(4), success.Sum)
+	assert.Equal(t, metricdata.NewExtrema(int64(1)), success.Min)
+	assert.Equal(t, metricdata.NewExtrema(int64(3)), success.Max)
+	exporterID, _ := success.Attributes.Value("exporter")
+	assert.Equal(t, set.ID.String(), exporterID.AsString())
+	failure := dps["failure"]
+	assert.Equal(t, uint64(1), failure.Count)
+	assert.Equal(t, int64(2), failure.Sum)
+}
+
+func TestSendAttemptsHistogramRetriesExhausted(t *testing.T) {
+	metricReader := sdkmetric.NewManualReader()
+	rCfg := configretry.NewDefaultBackOffConfig()
+	rCfg.InitialInterval = time.Millisecond
+	rCfg.MaxInterval = time.Millisecond
+	rCfg.RandomizationFactor = 0
+	rCfg.MaxElapsedTime = 50 * time.Millisecond
+	be, err := newBaseExporter(sendAttemptsSettings(metricReader), defaultDataType, newNoopObsrepSender,
+		WithSendAttemptsHistogram(), WithRetry(rCfg))
+	require.NoError(t, err)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	req := &flakyRequest{failures: math.MaxInt}
+	require.Error(t, be.send(context.Background(), req))
+
+	dps := sendAttempts(t, metricReader)
+	require.Len(t, dps, 1)
+	assert.Equal(t, uint64(1), dps["failure"].Count)
+	assert.Equal(t, int64(math.MaxInt-req.failures), dps["failure"].Sum)
+}
+
+func TestSendAttemptsHistogramWithoutRetries(t *testing.T) {
+	metricReader := sdkmetric.NewManualReader()
+	rec := &attemptRecorder{}
+	be, err := newBaseExporter(sendAttemptsSettings(metricReader), defaultDataType, newNoopObsrepSender,
+		WithSendAttemptsHistogram(), WithSendAttemptCallback(rec.record, 0))
+	require.NoError(t, err)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	require.NoError(t, be.send(context.Background(), &flakyRequest{}))
+	require.Error(t, be.send(context.Background(), &flakyRequest{failures: 1}))
+
+	dps := sendAttempts(t, metricReader)
+	require.Len(t, dps, 2)
+	assert.Equal(t, uint64(1), dps["success"].Count)
+	assert.Equal(t, int64(1), dps["success"].Sum)
+	assert.Equal(t, uint64(1), dps["failure"].Count)
+	assert.Equal(t, int64(1), dps["failure"].Sum)
+	assert.Len(t, rec.get(), 2)
+}
+
+func TestSendAttemptsHistogramDisabled(t *testing.T) {
+	metricReader := sdkmetric.NewManualReader()
+	rCfg := configretry.NewDefaultBackOffConfig()
+	rCfg.InitialInterval = time.Millisecond
+	be, err := newBaseExporter(sendAttemptsSettings(metricReader), defaultDataType, newNoopObsrepSender, WithRetry(rCfg))
+	require.NoError(t, err)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	require.NoError(t, be.send(context.Background(), &flakyRequest{failures: 1}))
+	assert.Empty(t, sendAttempts(t, metricReader))
+}
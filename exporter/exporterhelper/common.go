@@ -13,9 +13,11 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configretry"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumerdeadletter"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exporterbatcher"
 	"go.opentelemetry.io/collector/exporter/exporterqueue"
+	"go.opentelemetry.io/collector/exporter/internal/queue"
 )
 
 // requestSender is an abstraction of a sender for a request independent of the type of the data (traces, metrics, logs).
@@ -102,15 +104,23 @@ func WithQueue(config QueueSettings) Option {
 			Marshaler:   o.marshaler,
 			Unmarshaler: o.unmarshaler,
 		})
+		qCfg := exporterqueue.Config{
+			Enabled:                   config.Enabled,
+			NumConsumers:              config.NumConsumers,
+			QueueSize:                 config.QueueSize,
+			MaxAge:                    config.MaxAge,
+			BlockOnOverflow:           config.BlockOnOverflow,
+			WaitTimePercentilesWindow: config.WaitTimePercentilesWindow,
+		}
 		q := qf(context.Background(), exporterqueue.Settings{
 			DataType:         o.signal,
 			ExporterSettings: o.set,
-		}, exporterqueue.Config{
-			Enabled:      config.Enabled,
-			NumConsumers: config.NumConsumers,
-			QueueSize:    config.QueueSize,
-		})
-		o.queueSender = newQueueSender(q, o.set, config.NumConsumers, o.exportFailureMessage, o.obsrep)
+		}, qCfg)
+		qs, err := newQueueSender(q, o.set, qCfg, o.exportFailureMessage, o.obsrep)
+		if err != nil {
+			return err
+		}
+		o.queueSender = qs
 		return nil
 	}
 }
@@ -134,6 +144,67 @@ func WithRequestQueue(cfg exporterqueue.Config, queueFactory exporterqueue.Facto
 	}
 }
 
+// WithQueuePartitioner makes the queue consumers process the requests by partition: the key returned by
+// partitioner for a request is mapped to a queue consumer by its hash, so all the requests with the same key
+// are processed by the same consumer, in the order they were queued. It has no effect if queueing is disabled.
+// A consumer slower than the others only delays them once several requests wait for it. As with unpartitioned
+// consumers, a request of the persistent queue is removed from the storage once it is exported.
+// Experimental: This API is at the early stage of development and may change without backward compatibility.
+func WithQueuePartitioner(partitioner func(ctx context.Context, req Request) string) Option {
+	return func(o *baseExporter) error {
+		if partitioner == nil {
+			return fmt.Errorf("WithQueuePartitioner must be provided with a non-nil function")
+		}
+		o.queuePartitioner = partitioner
+		return nil
+	}
+}
+
+// WithQueuePauser makes the sending of the requests of the queue paused and resumed by pauser.
+// The option has no effect if the queue is not enabled. A QueuePauser can be shared by several exporters.
+// Experimental: This API is at the early stage of development and may change without backward compatibility.
+func WithQueuePauser(pauser *QueuePauser) Option {
+	return func(o *baseExporter) error {
+		if pauser == nil {
+			return fmt.Errorf("WithQueuePauser must be provided with a non-nil QueuePauser")
+		}
+		o.queuePauser = pauser
+		return nil
+	}
+}
+
+// WithAcknowledgement makes the requests put in the in-memory sending queue acknowledged to the receiver which
+// received them once they are sent, rather than once they are queued, if the receiver waits for the
+// acknowledgements with the consumers of the consumerack package. The error of the sending, e.g. once the retries
+// are exhausted, is returned to the client of the receiver. With batching, the requests are acknowledged once the
+// batch they are merged in is sent.
+// The option has no effect if the queue is not enabled, the requests being sent before the receiver responds, or
+// with the persistent queue, the requests being acknowledged once durably queued.
+// Experimental: This API is at the early stage of development and may change without backward compatibility.
+func WithAcknowledgement() Option {
+	return func(o *baseExporter) error {
+		o.acknowledge = true
+		return nil
+	}
+}
+
+// WithDeadLetter sends the data dropped by the exporter to the dead-letter consumers instead of discarding it:
+// the requests taken from the sending queue which fail to be sent, e.g. once the retries are exhausted, or which
+// exceed the max age of the queue, and, for the request-based exporters, the data failing to be converted to
+// requests. The reason of the drop, available with consumerdeadletter.ReasonFromContext, is the message of the error
+// the data is dropped with. The failures of the dead-letter consumers are logged.
+// The requests of the request-based exporters are not sent to the dead-letter consumers, and the option cannot be
+// used with WithBatcher, whose batches merge the data of the queued requests. By default, the dropped data is sent to
+// the dead-letter consumers the host provides on start with consumerdeadletter.Provider, set when the pipeline
+// configures a dead-letter exporter, if any, unless WithBatcher is used.
+// Experimental: This API is at the early stage of development and may change without backward compatibility.
+func WithDeadLetter(consumers consumerdeadletter.Consumers) Option {
+	return func(o *baseExporter) error {
+		o.deadLetter = &consumers
+		return nil
+	}
+}
+
 // WithCapabilities overrides the default Capabilities() function for a Consumer.
 // The default is non-mutable data.
 // TODO: Verify if we can change the default to be mutable as we do for processors.
@@ -237,6 +308,24 @@ type baseExporter struct {
 	queueFactory exporterqueue.Factory[Request]
 	batcherCfg   exporterbatcher.Config
 	batcherOpts  []BatcherOption
+
+	attemptReporter *attemptReporter
+	// recordSendAttempts records the number of attempts of every request.
+	recordSendAttempts bool
+
+	// queuePartitioner, if set, returns the partition key used to assign a request to a queue consumer.
+	queuePartitioner func(context.Context, Request) string
+
+	// queuePauser, if set, pauses and resumes the queue consumers.
+	queuePauser *QueuePauser
+
+	// acknowledge defers the acknowledgement of the requests to the receivers until they are sent.
+	acknowledge bool
+
+	// deadLetter, if set, receives the data dropped by the exporter.
+	deadLetter *consumerdeadletter.Consumers
+	// deadLetterFromHost gets deadLetter from the host on start, if not given with WithDeadLetter.
+	deadLetterFromHost bool
 }
 
 func newBaseExporter(set exporter.Settings, signal component.DataType, osf obsrepSenderFactory, options ...Option) (*baseExporter, error) {
@@ -252,7 +341,7 @@ func newBaseExporter(set exporter.Settings, signal component.DataType, osf obsre
 		queueSender:   &baseRequestSender{},
 		obsrepSender:  osf(obsReport),
 		retrySender:   &baseRequestSender{},
-		timeoutSender: &timeoutSender{cfg: NewDefaultTimeoutSettings()},
+		timeoutSender: &timeoutSender{cfg: NewDefaultTimeoutSettings(), obsrep: obsReport},
 
 		set:    set,
 		obsrep: obsReport,
@@ -265,6 +354,24 @@ func newBaseExporter(set exporter.Settings, signal component.DataType, osf obsre
 		return nil, err
 	}
 
+	if be.attemptReporter != nil || be.recordSendAttempts {
+		attemptsObsrep := be.obsrep
+		if !be.recordSendAttempts {
+			attemptsObsrep = nil
+		}
+		if rs, ok := be.retrySender.(*retrySender); ok {
+			rs.attemptReporter = be.attemptReporter
+			rs.attemptsObsrep = attemptsObsrep
+		} else {
+			be.retrySender = &attemptSender{reporter: be.attemptReporter, attemptsObsrep: attemptsObsrep}
+		}
+	}
+
+	if be.batcherCfg.Enabled && be.deadLetter != nil {
+		return nil, errDeadLetterWithBatcher
+	}
+	be.deadLetterFromHost = be.deadLetter == nil && !be.batcherCfg.Enabled
+
 	if be.batcherCfg.Enabled {
 		bs := newBatchSender(be.batcherCfg, be.set, be.batchMergeFunc, be.batchMergeSplitfunc)
 		for _, opt := range be.batcherOpts {
@@ -281,7 +388,11 @@ func newBaseExporter(set exporter.Settings, signal component.DataType, osf obsre
 			DataType:         be.signal,
 			ExporterSettings: be.set,
 		}
-		be.queueSender = newQueueSender(be.queueFactory(context.Background(), set, be.queueCfg), be.set, be.queueCfg.NumConsumers, be.exportFailureMessage, be.obsrep)
+		qs, qsErr := newQueueSender(be.queueFactory(context.Background(), set, be.queueCfg), be.set, be.queueCfg, be.exportFailureMessage, be.obsrep)
+		if qsErr != nil {
+			return nil, qsErr
+		}
+		be.queueSender = qs
 		for _, op := range options {
 			err = multierr.Append(err, op(be))
 		}
@@ -291,6 +402,20 @@ func newBaseExporter(set exporter.Settings, signal component.DataType, osf obsre
 		return nil, err
 	}
 
+	if qs, ok := be.queueSender.(*queueSender); ok && be.queuePartitioner != nil {
+		qs.setPartitioner(be.queuePartitioner)
+	}
+	if qs, ok := be.queueSender.(*queueSender); ok && be.queuePauser != nil {
+		qs.pauser = be.queuePauser
+		be.queuePauser.register(qs.consumers)
+	}
+	if qs, ok := be.queueSender.(*queueSender); ok && be.acknowledge && !queue.IsPersistent[Request](qs.queue) {
+		qs.acknowledge = true
+	}
+	if qs, ok := be.queueSender.(*queueSender); ok && (be.deadLetter != nil || be.deadLetterFromHost) {
+		qs.deadLetter = be.sendToDeadLetter
+	}
+
 	be.connectSenders()
 
 	if bs, ok := be.batchSender.(*batchSender); ok {
@@ -315,6 +440,32 @@ func (be *baseExporter) send(ctx context.Context, req Request) error {
 	return err
 }
 
+// sendToDeadLetter sends the data of req, dropped for reason, to the dead-letter consumer of its signal. The
+// requests of the request-based exporters, and the data of the exporters without dead-letter consumers, are
+// discarded.
+func (be *baseExporter) sendToDeadLetter(ctx context.Context, req Request, reason string) {
+	if be.deadLetter == nil {
+		return
+	}
+	var err error
+	switch r := req.(type) {
+	case *tracesRequest:
+		err = be.deadLetter.SendTraces(ctx, r.td, reason)
+	case *metricsRequest:
+		err = be.deadLetter.SendMetrics(ctx, r.md, reason)
+	case *logsRequest:
+		err = be.deadLetter.SendLogs(ctx, r.ld, reason)
+	}
+	be.logDeadLetterFailure(reason, err)
+}
+
+func (be *baseExporter) logDeadLetterFailure(reason string, err error) {
+	if err != nil {
+		be.set.Logger.Warn("Failed to send the dropped data to the dead-letter consumer",
+			zap.String("reason", reason), zap.Error(err))
+	}
+}
+
 // connectSenders connects the senders in the predefined order.
 func (be *baseExporter) connectSenders() {
 	be.queueSender.setNextSender(be.batchSender)
@@ -324,6 +475,10 @@ func (be *baseExporter) connectSenders() {
 }
 
 func (be *baseExporter) Start(ctx context.Context, host component.Host) error {
+	if provider, ok := host.(consumerdeadletter.Provider); ok && be.deadLetterFromHost {
+		be.deadLetter = provider.DeadLetterConsumers()
+	}
+
 	// First start the wrapped exporter.
 	if err := be.StartFunc.Start(ctx, host); err != nil {
 		return err
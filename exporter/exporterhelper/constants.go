@@ -24,4 +24,13 @@ var (
 	errNilMetricsConverter = errors.New("nil RequestFromMetricsFunc")
 	// errNilLogsConverter is returned when a nil RequestFromLogsFunc is given.
 	errNilLogsConverter = errors.New("nil RequestFromLogsFunc")
+	// errDeadLetterWithBatcher is returned when WithDeadLetter is given with WithBatcher.
+	errDeadLetterWithBatcher = errors.New("WithDeadLetter cannot be used with WithBatcher")
+	// errMaxAgeExceeded is returned for the requests dropped for exceeding the queue max age.
+	errMaxAgeExceeded = errors.New("request exceeded the queue max age")
+	// errMaxAgePersistentQueue is returned when the max age is set for a persistent queue, whose requests do not
+	// keep the time they were queued at.
+	errMaxAgePersistentQueue = errors.New("max age is not supported with the persistent queue")
+	// errWaitTimePersistentQueue is returned when the wait time percentiles are enabled for a persistent queue.
+	errWaitTimePersistentQueue = errors.New("wait time percentiles are not supported with the persistent queue")
 )
@@ -0,0 +1,215 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterhelper
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumerdeadletter"
+	"go.opentelemetry.io/collector/exporter/exporterbatcher"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/testdata"
+)
+
+// deadLetterSink records the number of items and the reasons of the data sent to the dead-letter consumers.
+type deadLetterSink struct {
+	consumerdeadletter.Consumers
+	mu      sync.Mutex
+	reasons []string
+	items   []int
+}
+
+func (dls *deadLetterSink) record(ctx context.Context, items int) {
+	dls.mu.Lock()
+	defer dls.mu.Unlock()
+	dls.reasons = append(dls.reasons, consumerdeadletter.ReasonFromContext(ctx))
+	dls.items = append(dls.items, items)
+}
+
+func (dls *deadLetterSink) recorded() ([]string, []int) {
+	dls.mu.Lock()
+	defer dls.mu.Unlock()
+	return dls.reasons, dls.items
+}
+
+func newDeadLetterSink(t *testing.T) *deadLetterSink {
+	dls := &deadLetterSink{}
+	var err error
+	dls.Traces, err = consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
+		dls.record(ctx, td.SpanCount())
+		return nil
+	})
+	require.NoError(t, err)
+	dls.Metrics, err = consumer.NewMetrics(func(ctx context.Context, md pmetric.Metrics) error {
+		dls.record(ctx, md.DataPointCount())
+		return nil
+	})
+	require.NoError(t, err)
+	dls.Logs, err = consumer.NewLogs(func(ctx context.Context, ld plog.Logs) error {
+		dls.record(ctx, ld.LogRecordCount())
+		return errors.New("dead letter unavailable")
+	})
+	require.NoError(t, err)
+	return dls
+}
+
+func TestDeadLetterExportFailure(t *testing.T) {
+	dls := newDeadLetterSink(t)
+	qCfg := NewDefaultQueueSettings()
+	qCfg.NumConsumers = 1
+	rCfg := configretry.NewDefaultBackOffConfig()
+	rCfg.Enabled = false
+	te, err := NewTracesExporter(context.Background(), exportertest.NewNopSettings(), &fakeTracesExporterConfig,
+		newTraceDataPusher(errors.New("backend unavailable")), WithQueue(qCfg), WithRetry(rCfg), WithDeadLetter(dls.Consumers))
+	require.NoError(t, err)
+	le, err := NewLogsExporter(context.Background(), exportertest.NewNopSettings(), &fakeLogsExporterConfig,
+		newPushLogsData(errors.New("backend unavailable")), WithQueue(qCfg), WithRetry(rCfg), WithDeadLetter(dls.Consumers))
+	require.NoError(t, err)
+	require.NoError(t, te.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, le.Start(context.Background(), componenttest.NewNopHost()))
+
+	require.NoError(t, te.ConsumeTraces(context.Background(), testdata.GenerateTraces(3)))
+	require.NoError(t, te.Shutdown(context.Background()))
+	// The failures of the dead-letter consumers are only logged.
+	require.NoError(t, le.ConsumeLogs(context.Background(), testdata.GenerateLogs(2)))
+	require.NoError(t, le.Shutdown(context.Background()))
+
+	reasons, items := dls.recorded()
+	assert.Equal(t, []string{"backend unavailable", "backend unavailable"}, reasons)
+	assert.Equal(t, []int{3, 2}, items)
+}
+
+func TestDeadLetterMaxAge(t *testing.T) {
+	dls := newDeadLetterSink(t)
+	qCfg := NewDefaultQueueSettings()
+	qCfg.NumConsumers = 1
+	qCfg.MaxAge = time.Minute
+	me, err := NewMetricsExporter(context.Background(), exportertest.NewNopSettings(), &fakeMetricsExporterConfig,
+		newPushMetricsData(nil), WithQueue(qCfg), WithDeadLetter(dls.Consumers))
+	require.NoError(t, err)
+	qs := me.(*metricsExporter).queueSender.(*queueSender)
+	now := time.Now()
+	qs.now = func() time.Time { return now }
+
+	// The request is enqueued before the consumers start, and expires before being dequeued.
+	require.NoError(t, me.ConsumeMetrics(context.Background(), testdata.GenerateMetrics(2)))
+	now = now.Add(2 * time.Minute)
+	require.NoError(t, me.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, me.Shutdown(context.Background()))
+
+	reasons, items := dls.recorded()
+	assert.Equal(t, []string{errMaxAgeExceeded.Error()}, reasons)
+	assert.Equal(t, []int{4}, items)
+}
+
+func TestDeadLetterNotDropped(t *testing.T) {
+	dls := newDeadLetterSink(t)
+	// The data sent, or whose error is returned to the caller without a queue, is not dropped.
+	te, err := NewTracesExporter(context.Background(), exportertest.NewNopSettings(), &fakeTracesExporterConfig,
+		newTraceDataPusher(nil), WithQueue(NewDefaultQueueSettings()), WithDeadLetter(dls.Consumers))
+	require.NoError(t, err)
+	require.NoError(t, te.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, te.ConsumeTraces(context.Background(), testdata.GenerateTraces(3)))
+	require.NoError(t, te.Shutdown(context.Background()))
+
+	want := errors.New("backend unavailable")
+	te, err = NewTracesExporter(context.Background(), exportertest.NewNopSettings(), &fakeTracesExporterConfig,
+		newTraceDataPusher(want), WithDeadLetter(dls.Consumers))
+	require.NoError(t, err)
+	require.Equal(t, want, te.ConsumeTraces(context.Background(), testdata.GenerateTraces(3)))
+
+	reasons, _ := dls.recorded()
+	assert.Empty(t, reasons)
+}
+
+func TestDeadLetterConvertError(t *testing.T) {
+	dls := newDeadLetterSink(t)
+	want := errors.New("convert_error")
+	me, err := NewMetricsRequestExporter(context.Background(), exportertest.NewNopSettings(),
+		(&fakeRequestConverter{metricsError: want}).requestFromMetricsFunc, WithDeadLetter(dls.Consumers))
+	require.NoError(t, err)
+	require.Error(t, me.ConsumeMetrics(context.Background(), testdata.GenerateMetrics(1)))
+
+	reasons, items := dls.recorded()
+	assert.Equal(t, []string{"convert_error"}, reasons)
+	assert.Equal(t, []int{2}, items)
+}
+
+func TestDeadLetterWithBatcher(t *testing.T) {
+	_, err := NewTracesExporter(context.Background(), exportertest.NewNopSettings(), &fakeTracesExporterConfig,
+		newTraceDataPusher(nil), WithBatcher(exporterbatcher.NewDefaultConfig()), WithDeadLetter(consumerdeadletter.Consumers{}))
+	require.ErrorIs(t, err, errDeadLetterWithBatcher)
+}
+
+// deadLetterHost is a host providing dead-letter consumers.
+type deadLetterHost struct {
+	component.Host
+	consumers *consumerdeadletter.Consumers
+}
+
+func (h *deadLetterHost) DeadLetterConsumers() *consumerdeadletter.Consumers {
+	return h.consumers
+}
+
+func TestDeadLetterHost(t *testing.T) {
+	// The dead-letter consumers of the host are used unless WithDeadLetter is given.
+	dls := newDeadLetterSink(t)
+	host := &deadLetterHost{Host: componenttest.NewNopHost(), consumers: &dls.Consumers}
+	me, err := NewMetricsRequestExporter(context.Background(), exportertest.NewNopSettings(),
+		(&fakeRequestConverter{metricsError: errors.New("convert_error")}).requestFromMetricsFunc)
+	require.NoError(t, err)
+	require.NoError(t, me.Start(context.Background(), host))
+	require.Error(t, me.ConsumeMetrics(context.Background(), testdata.GenerateMetrics(1)))
+	require.NoError(t, me.Shutdown(context.Background()))
+	reasons, items := dls.recorded()
+	assert.Equal(t, []string{"convert_error"}, reasons)
+	assert.Equal(t, []int{2}, items)
+
+	// The requests dropped by the queue consumers are sent to them too.
+	qCfg := NewDefaultQueueSettings()
+	qCfg.NumConsumers = 1
+	rCfg := configretry.NewDefaultBackOffConfig()
+	rCfg.Enabled = false
+	te, err := NewTracesExporter(context.Background(), exportertest.NewNopSettings(), &fakeTracesExporterConfig,
+		newTraceDataPusher(errors.New("backend unavailable")), WithQueue(qCfg), WithRetry(rCfg))
+	require.NoError(t, err)
+	require.NoError(t, te.Start(context.Background(), host))
+	require.NoError(t, te.ConsumeTraces(context.Background(), testdata.GenerateTraces(3)))
+	require.NoError(t, te.Shutdown(context.Background()))
+	reasons, items = dls.recorded()
+	assert.Equal(t, []string{"convert_error", "backend unavailable"}, reasons)
+	assert.Equal(t, []int{2, 3}, items)
+
+	// The data is discarded if the host provides no dead-letter consumers.
+	te, err = NewTracesExporter(context.Background(), exportertest.NewNopSettings(), &fakeTracesExporterConfig,
+		newTraceDataPusher(errors.New("backend unavailable")), WithQueue(qCfg), WithRetry(rCfg))
+	require.NoError(t, err)
+	require.NoError(t, te.Start(context.Background(), &deadLetterHost{Host: componenttest.NewNopHost()}))
+	require.NoError(t, te.ConsumeTraces(context.Background(), testdata.GenerateTraces(3)))
+	require.NoError(t, te.Shutdown(context.Background()))
+	reasons, _ = dls.recorded()
+	assert.Len(t, reasons, 2)
+
+	// They are not used by the exporters with a batcher.
+	be, err := newBaseExporter(exportertest.NewNopSettings(), defaultDataType, newNoopObsrepSender,
+		WithBatcher(exporterbatcher.NewDefaultConfig(), WithRequestBatchFuncs(fakeBatchMergeFunc, fakeBatchMergeSplitFunc)))
+	require.NoError(t, err)
+	require.NoError(t, be.Start(context.Background(), host))
+	assert.Nil(t, be.deadLetter)
+	require.NoError(t, be.Shutdown(context.Background()))
+}
@@ -33,8 +33,13 @@ type TelemetryBuilder struct {
 	ExporterEnqueueFailedLogRecords   metric.Int64Counter
 	ExporterEnqueueFailedMetricPoints metric.Int64Counter
 	ExporterEnqueueFailedSpans        metric.Int64Counter
+	ExporterLastSuccessUnixtime       metric.Int64ObservableGauge
 	ExporterQueueCapacity             metric.Int64ObservableGauge
+	ExporterQueueExpiredItems         metric.Int64Counter
 	ExporterQueueSize                 metric.Int64ObservableGauge
+	ExporterQueueWaitTime             metric.Float64ObservableGauge
+	ExporterSendAttempts              metric.Int64Histogram
+	ExporterSendDuration              metric.Float64Histogram
 	ExporterSendFailedLogRecords      metric.Int64Counter
 	ExporterSendFailedMetricPoints    metric.Int64Counter
 	ExporterSendFailedSpans           metric.Int64Counter
@@ -47,6 +52,24 @@ type TelemetryBuilder struct {
 // telemetryBuilderOption applies changes to default builder.
 type telemetryBuilderOption func(*TelemetryBuilder)
 
+// InitExporterLastSuccessUnixtime configures the ExporterLastSuccessUnixtime metric.
+func (builder *TelemetryBuilder) InitExporterLastSuccessUnixtime(cb func() int64, opts ...metric.ObserveOption) error {
+	var err error
+	builder.ExporterLastSuccessUnixtime, err = builder.meters[configtelemetry.LevelBasic].Int64ObservableGauge(
+		"otelcol_exporter_last_success_unixtime",
+		metric.WithDescription("Time of the last request successfully sent to destination, in seconds since the Unix epoch, or 0 until the first one."),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return err
+	}
+	_, err = builder.meters[configtelemetry.LevelBasic].RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		o.ObserveInt64(builder.ExporterLastSuccessUnixtime, cb(), opts...)
+		return nil
+	}, builder.ExporterLastSuccessUnixtime)
+	return err
+}
+
 // InitExporterQueueCapacity configures the ExporterQueueCapacity metric.
 func (builder *TelemetryBuilder) InitExporterQueueCapacity(cb func() int64, opts ...metric.ObserveOption) error {
 	var err error
@@ -83,6 +106,24 @@ func (builder *TelemetryBuilder) InitExporterQueueSize(cb func() int64, opts ...
 	return err
 }
 
+// InitExporterQueueWaitTime configures the ExporterQueueWaitTime metric.
+func (builder *TelemetryBuilder) InitExporterQueueWaitTime(cb func() float64, opts ...metric.ObserveOption) error {
+	var err error
+	builder.ExporterQueueWaitTime, err = builder.meters[configtelemetry.LevelBasic].Float64ObservableGauge(
+		"otelcol_exporter_queue_wait_time",
+		metric.WithDescription("Percentile of the time waited in the sending queue by the batches dequeued within the configured window."),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return err
+	}
+	_, err = builder.meters[configtelemetry.LevelBasic].RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		o.ObserveFloat64(builder.ExporterQueueWaitTime, cb(), opts...)
+		return nil
+	}, builder.ExporterQueueWaitTime)
+	return err
+}
+
 // NewTelemetryBuilder provides a struct with methods to update all internal telemetry
 // for a component
 func NewTelemetryBuilder(settings component.TelemetrySettings, options ...telemetryBuilderOption) (*TelemetryBuilder, error) {
@@ -91,6 +132,7 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...teleme
 		op(&builder)
 	}
 	builder.meters[configtelemetry.LevelBasic] = LeveledMeter(settings, configtelemetry.LevelBasic)
+	builder.meters[configtelemetry.LevelDetailed] = LeveledMeter(settings, configtelemetry.LevelDetailed)
 	var err, errs error
 	builder.ExporterEnqueueFailedLogRecords, err = builder.meters[configtelemetry.LevelBasic].Int64Counter(
 		"otelcol_exporter_enqueue_failed_log_records",
@@ -110,6 +152,24 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...teleme
 		metric.WithUnit("{spans}"),
 	)
 	errs = errors.Join(errs, err)
+	builder.ExporterQueueExpiredItems, err = builder.meters[configtelemetry.LevelBasic].Int64Counter(
+		"otelcol_exporter_queue_expired_items",
+		metric.WithDescription("Number of items dropped from the sending queue because they exceeded the configured max age."),
+		metric.WithUnit("{items}"),
+	)
+	errs = errors.Join(errs, err)
+	builder.ExporterSendAttempts, err = builder.meters[configtelemetry.LevelBasic].Int64Histogram(
+		"otelcol_exporter_send_attempts",
+		metric.WithDescription("Number of attempts made to send a request to the destination, recorded when it succeeds or fails without further retries."),
+		metric.WithUnit("{attempts}"), metric.WithExplicitBucketBoundaries([]float64{1, 2, 3, 4, 5, 6, 8, 10, 15, 20, 30, 50}...),
+	)
+	errs = errors.Join(errs, err)
+	builder.ExporterSendDuration, err = builder.meters[configtelemetry.LevelDetailed].Float64Histogram(
+		"otelcol_exporter_send_duration",
+		metric.WithDescription("Duration of the attempts to send a request to the destination, excluding the time waited in the sending queue and between retries."),
+		metric.WithUnit("s"), metric.WithExplicitBucketBoundaries([]float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60}...),
+	)
+	errs = errors.Join(errs, err)
 	builder.ExporterSendFailedLogRecords, err = builder.meters[configtelemetry.LevelBasic].Int64Counter(
 		"otelcol_exporter_send_failed_log_records",
 		metric.WithDescription("Number of log records in failed attempts to send to destination."),
@@ -129,6 +129,9 @@ func NewLogsRequestExporter(
 			set.Logger.Error("Failed to convert logs. Dropping data.",
 				zap.Int("dropped_log_records", ld.LogRecordCount()),
 				zap.Error(err))
+			if be.deadLetter != nil {
+				be.logDeadLetterFailure(cErr.Error(), be.deadLetter.SendLogs(ctx, ld, cErr.Error()))
+			}
 			return consumererror.NewPermanent(cErr)
 		}
 		sErr := be.send(ctx, req)
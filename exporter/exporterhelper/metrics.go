@@ -129,6 +129,9 @@ func NewMetricsRequestExporter(
 			set.Logger.Error("Failed to convert metrics. Dropping data.",
 				zap.Int("dropped_data_points", md.DataPointCount()),
 				zap.Error(err))
+			if be.deadLetter != nil {
+				be.logDeadLetterFailure(cErr.Error(), be.deadLetter.SendMetrics(ctx, md, cErr.Error()))
+			}
 			return consumererror.NewPermanent(cErr)
 		}
 		sErr := be.send(ctx, req)
@@ -5,6 +5,8 @@ package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporte
 
 import (
 	"context"
+	"sync/atomic"
+	"time"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
@@ -24,7 +26,12 @@ type obsReport struct {
 	dataType       component.DataType
 
 	otelAttrs        []attribute.KeyValue
+	successAttrs     metric.MeasurementOption
+	failureAttrs     metric.MeasurementOption
 	telemetryBuilder *metadata.TelemetryBuilder
+
+	// lastSuccess is the Unix time, in seconds, of the last request successfully exported.
+	lastSuccess atomic.Int64
 }
 
 // obsReportSettings are settings for creating an obsReport.
@@ -40,15 +47,23 @@ func newExporter(cfg obsReportSettings) (*obsReport, error) {
 		return nil, err
 	}
 
-	return &obsReport{
+	exporterAttr := attribute.String(obsmetrics.ExporterKey, cfg.exporterID.String())
+	or := &obsReport{
 		spanNamePrefix: obsmetrics.ExporterPrefix + cfg.exporterID.String(),
 		tracer:         cfg.exporterCreateSettings.TracerProvider.Tracer(cfg.exporterID.String()),
 		dataType:       cfg.dataType,
 		otelAttrs: []attribute.KeyValue{
-			attribute.String(obsmetrics.ExporterKey, cfg.exporterID.String()),
+			exporterAttr,
 		},
+		successAttrs:     metric.WithAttributes(exporterAttr, attribute.String("outcome", "success")),
+		failureAttrs:     metric.WithAttributes(exporterAttr, attribute.String("outcome", "failure")),
 		telemetryBuilder: telemetryBuilder,
-	}, nil
+	}
+	if err = telemetryBuilder.InitExporterLastSuccessUnixtime(or.lastSuccess.Load,
+		metric.WithAttributeSet(attribute.NewSet(exporterAttr))); err != nil {
+		return nil, err
+	}
+	return or, nil
 }
 
 // startTracesOp is called at the start of an Export operation.
@@ -122,6 +137,29 @@ func (or *obsReport) recordMetrics(ctx context.Context, dataType component.DataT
 	failedMeasure.Add(ctx, failed, metric.WithAttributes(or.otelAttrs...))
 }
 
+// recordSendDuration records the duration of an attempt to export a request, which failed if err is not nil.
+func (or *obsReport) recordSendDuration(ctx context.Context, duration time.Duration, err error) {
+	attrs := or.successAttrs
+	if err != nil {
+		attrs = or.failureAttrs
+	}
+	or.telemetryBuilder.ExporterSendDuration.Record(ctx, duration.Seconds(), attrs)
+}
+
+// recordSendAttempts records the number of attempts made to send a request, which finally failed if err is not nil.
+func (or *obsReport) recordSendAttempts(ctx context.Context, attempts int, err error) {
+	attrs := or.successAttrs
+	if err != nil {
+		attrs = or.failureAttrs
+	}
+	or.telemetryBuilder.ExporterSendAttempts.Record(ctx, int64(attempts), attrs)
+}
+
+// recordSuccess records the time at which a request was successfully exported.
+func (or *obsReport) recordSuccess(now time.Time) {
+	or.lastSuccess.Store(now.Unix())
+}
+
 func endSpan(ctx context.Context, err error, numSent, numFailedToSend int64, sentItemsKey, failedToSendItemsKey string) {
 	span := trace.SpanFromContext(ctx)
 	// End the span according to errors.
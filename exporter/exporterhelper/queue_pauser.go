@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporterhelper"
+
+import (
+	"sync"
+
+	"go.opentelemetry.io/collector/exporter/internal/queue"
+)
+
+// QueuePauser pauses and resumes the sending of the requests of the exporters created with WithQueuePauser,
+// e.g. during the maintenance window of their destination. While paused, the requests keep being added to
+// the sending queue of the exporters, and are sent once resumed. The requests already taken from the queue,
+// at most one per queue consumer, are held by the paused consumers. The exporters are resumed, and no longer
+// paused by the QueuePauser, on shutdown to drain their queue. The time spent paused counts towards the
+// max_age of the queue: the requests older than max_age once resumed are dropped.
+// Experimental: This API is at the early stage of development and may change without backward compatibility.
+type QueuePauser struct {
+	mu        sync.Mutex
+	paused    bool
+	consumers []*queue.Consumers[Request]
+}
+
+// NewQueuePauser returns a QueuePauser which is not paused.
+func NewQueuePauser() *QueuePauser {
+	return &QueuePauser{}
+}
+
+// Pause stops the sending of the requests of the queue until Resume is called.
+func (p *QueuePauser) Pause() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.paused = true
+	for _, qc := range p.consumers {
+		qc.Pause()
+	}
+}
+
+// Resume sends the requests buffered in the queue while paused, and the next ones.
+func (p *QueuePauser) Resume() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.paused = false
+	for _, qc := range p.consumers {
+		qc.Resume()
+	}
+}
+
+// Paused reports whether the sending of the requests is paused.
+func (p *QueuePauser) Paused() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.paused
+}
+
+// register makes the QueuePauser pause and resume qc, pausing it if the QueuePauser is paused.
+func (p *QueuePauser) register(qc *queue.Consumers[Request]) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.consumers = append(p.consumers, qc)
+	if p.paused {
+		qc.Pause()
+	}
+}
+
+// unregister makes the QueuePauser no longer pause and resume qc.
+func (p *QueuePauser) unregister(qc *queue.Consumers[Request]) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i, c := range p.consumers {
+		if c == qc {
+			p.consumers = append(p.consumers[:i], p.consumers[i+1:]...)
+			return
+		}
+	}
+}
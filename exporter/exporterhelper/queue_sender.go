@@ -6,6 +6,9 @@ package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporte
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sync"
+	"time"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
@@ -14,6 +17,8 @@ import (
 	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumerack"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exporterqueue"
 	"go.opentelemetry.io/collector/exporter/internal/queue"
@@ -35,6 +40,19 @@ type QueueSettings struct {
 	// StorageID if not empty, enables the persistent storage and uses the component specified
 	// as a storage extension for the persistent queue
 	StorageID *component.ID `mapstructure:"storage"`
+	// MaxAge is the maximum time a batch can wait in the queue. Batches older than MaxAge
+	// are dropped when dequeued instead of being sent. Zero, the default, disables the limit.
+	// It is not supported with the persistent queue. The time spent paused by a QueuePauser, see
+	// WithQueuePauser, counts towards MaxAge.
+	MaxAge time.Duration `mapstructure:"max_age"`
+	// BlockOnOverflow controls what happens to a batch sent while the queue is full. When true, the caller is
+	// blocked until space is available in the queue or its context is done, propagating the backpressure to the
+	// receivers. When false, the default, the batch is dropped and counted as failed to enqueue.
+	BlockOnOverflow bool `mapstructure:"block_on_overflow"`
+	// WaitTimePercentilesWindow enables the exporter_queue_wait_time metric, reporting the p50, p95 and p99 of
+	// the time waited in the queue by the batches dequeued within this rolling window. Zero, the default, disables
+	// the metric. It is not supported with the persistent queue.
+	WaitTimePercentilesWindow time.Duration `mapstructure:"wait_time_percentiles_window"`
 }
 
 // NewDefaultQueueSettings returns the default settings for QueueSettings.
@@ -63,39 +81,123 @@ func (qCfg *QueueSettings) Validate() error {
 		return errors.New("number of queue consumers must be positive")
 	}
 
+	if qCfg.MaxAge < 0 {
+		return errors.New("max age must not be negative")
+	}
+
+	if qCfg.MaxAge > 0 && qCfg.StorageID != nil {
+		return errMaxAgePersistentQueue
+	}
+
+	if qCfg.WaitTimePercentilesWindow < 0 {
+		return errors.New("wait time percentiles window must not be negative")
+	}
+
+	if qCfg.WaitTimePercentilesWindow > 0 && qCfg.StorageID != nil {
+		return errWaitTimePersistentQueue
+	}
+
 	return nil
 }
 
+// enqueuedAtKey is the context key holding the time a request was added to the queue.
+type enqueuedAtKey struct{}
+
+// ackKey is the context key holding the function acknowledging a queued request to the receiver.
+type ackKey struct{}
+
 type queueSender struct {
 	baseRequestSender
-	queue          exporterqueue.Queue[Request]
-	numConsumers   int
+	queue        exporterqueue.Queue[Request]
+	numConsumers int
+	maxAge       time.Duration
+	// waitTimes is nil if the wait time percentiles are disabled.
+	waitTimes      *waitTimes
 	traceAttribute attribute.KeyValue
 	consumers      *queue.Consumers[Request]
+	consumeFunc    func(context.Context, Request) error
+	// pauser, if set, pauses and resumes the consumers until shutdown.
+	pauser *QueuePauser
+
+	// acknowledge defers the acknowledgement of the queued requests to the receivers until they are sent.
+	acknowledge bool
+
+	// deadLetter, if set, is called with the requests dropped by the queue consumers and the reason of the drop.
+	deadLetter func(ctx context.Context, req Request, reason string)
+
+	blockOnOverflow bool
+	// spaceFreed is closed, and replaced, every time a request is taken from the queue. It is only used if
+	// blockOnOverflow is set.
+	spaceMu    sync.Mutex
+	spaceFreed chan struct{}
+	stopped    chan struct{}
+	stopOnce   sync.Once
 
 	obsrep     *obsReport
 	exporterID component.ID
+
+	// now returns the current time, it can be replaced in tests.
+	now func() time.Time
 }
 
-func newQueueSender(q exporterqueue.Queue[Request], set exporter.Settings, numConsumers int,
-	exportFailureMessage string, obsrep *obsReport) *queueSender {
+func newQueueSender(q exporterqueue.Queue[Request], set exporter.Settings, cfg exporterqueue.Config,
+	exportFailureMessage string, obsrep *obsReport) (*queueSender, error) {
+	if cfg.MaxAge > 0 && queue.IsPersistent(q) {
+		return nil, errMaxAgePersistentQueue
+	}
+	if cfg.WaitTimePercentilesWindow > 0 && queue.IsPersistent(q) {
+		return nil, errWaitTimePersistentQueue
+	}
 	qs := &queueSender{
-		queue:          q,
-		numConsumers:   numConsumers,
-		traceAttribute: attribute.String(obsmetrics.ExporterKey, set.ID.String()),
-		obsrep:         obsrep,
-		exporterID:     set.ID,
-	}
-	consumeFunc := func(ctx context.Context, req Request) error {
-		err := qs.nextSender.send(ctx, req)
+		queue:           q,
+		numConsumers:    cfg.NumConsumers,
+		maxAge:          cfg.MaxAge,
+		traceAttribute:  attribute.String(obsmetrics.ExporterKey, set.ID.String()),
+		blockOnOverflow: cfg.BlockOnOverflow,
+		spaceFreed:      make(chan struct{}),
+		stopped:         make(chan struct{}),
+		obsrep:          obsrep,
+		exporterID:      set.ID,
+		now:             time.Now,
+	}
+	if cfg.WaitTimePercentilesWindow > 0 {
+		qs.waitTimes = newWaitTimes(cfg.WaitTimePercentilesWindow)
+	}
+	consumeFunc := func(ctx context.Context, req Request) (err error) {
+		if ack, ok := ctx.Value(ackKey{}).(func(error)); ok {
+			defer func() { ack(err) }()
+		}
+		// The request has been taken from the queue, so there is space for the blocked senders.
+		qs.notifySpaceFreed()
+		qs.recordWaitTime(ctx)
+		if age, expired := qs.expired(ctx); expired {
+			set.Logger.Error("Request exceeded the queue max age. Dropping data.",
+				zap.Duration("age", age), zap.Duration("max_age", qs.maxAge), zap.Int("dropped_items", req.ItemsCount()))
+			qs.obsrep.telemetryBuilder.ExporterQueueExpiredItems.Add(ctx, int64(req.ItemsCount()), metric.WithAttributes(
+				qs.traceAttribute, attribute.String(obsmetrics.DataTypeKey, qs.obsrep.dataType.String())))
+			if qs.deadLetter != nil {
+				qs.deadLetter(ctx, req, errMaxAgeExceeded.Error())
+			}
+			return consumererror.NewPermanent(errMaxAgeExceeded)
+		}
+		err = qs.nextSender.send(ctx, req)
 		if err != nil {
 			set.Logger.Error("Exporting failed. Dropping data."+exportFailureMessage,
 				zap.Error(err), zap.Int("dropped_items", req.ItemsCount()))
+			if qs.deadLetter != nil {
+				qs.deadLetter(ctx, req, err.Error())
+			}
 		}
 		return err
 	}
-	qs.consumers = queue.NewQueueConsumers[Request](q, numConsumers, consumeFunc)
-	return qs
+	qs.consumeFunc = consumeFunc
+	qs.consumers = queue.NewQueueConsumers[Request](q, cfg.NumConsumers, consumeFunc)
+	return qs, nil
+}
+
+// setPartitioner makes the queue consumers process the requests of each partition key by the same consumer.
+func (qs *queueSender) setPartitioner(partitioner func(context.Context, Request) string) {
+	qs.consumers = queue.NewPartitionedQueueConsumers[Request](qs.queue, qs.numConsumers, partitioner, qs.consumeFunc)
 }
 
 // Start is invoked during service startup.
@@ -105,18 +207,32 @@ func (qs *queueSender) Start(ctx context.Context, host component.Host) error {
 	}
 
 	dataTypeAttr := attribute.String(obsmetrics.DataTypeKey, qs.obsrep.dataType.String())
-	return multierr.Append(
+	err := multierr.Append(
 		qs.obsrep.telemetryBuilder.InitExporterQueueSize(func() int64 { return int64(qs.queue.Size()) },
 			metric.WithAttributeSet(attribute.NewSet(qs.traceAttribute, dataTypeAttr))),
 		qs.obsrep.telemetryBuilder.InitExporterQueueCapacity(func() int64 { return int64(qs.queue.Capacity()) },
 			metric.WithAttributeSet(attribute.NewSet(qs.traceAttribute))),
 	)
+	if qs.waitTimes != nil {
+		for _, p := range queueWaitTimePercentiles {
+			percentile := p.percentile
+			err = multierr.Append(err, qs.obsrep.telemetryBuilder.InitExporterQueueWaitTime(
+				func() float64 { return qs.waitTimes.percentile(qs.now(), percentile) },
+				metric.WithAttributeSet(attribute.NewSet(qs.traceAttribute, dataTypeAttr, attribute.String("percentile", p.name)))))
+		}
+	}
+	return err
 }
 
 // Shutdown is invoked during service shutdown.
 func (qs *queueSender) Shutdown(ctx context.Context) error {
 	// Stop the queue and consumers, this will drain the queue and will call the retry (which is stopped) that will only
 	// try once every request.
+	qs.stopOnce.Do(func() { close(qs.stopped) })
+	if qs.pauser != nil {
+		// The pauser must not pause the consumers draining the queue, nor keep them once shut down.
+		qs.pauser.unregister(qs.consumers)
+	}
 	return qs.consumers.Shutdown(ctx)
 }
 
@@ -125,13 +241,96 @@ func (qs *queueSender) send(ctx context.Context, req Request) error {
 	// Prevent cancellation and deadline to propagate to the context stored in the queue.
 	// The grpc/http based receivers will cancel the request context after this function returns.
 	c := context.WithoutCancel(ctx)
+	if qs.maxAge > 0 || qs.waitTimes != nil {
+		c = context.WithValue(c, enqueuedAtKey{}, qs.now())
+	}
+	var ack func(error)
+	if qs.acknowledge {
+		if ack = consumerack.Defer(ctx); ack != nil {
+			c = context.WithValue(c, ackKey{}, ack)
+		}
+	}
 
 	span := trace.SpanFromContext(c)
-	if err := qs.queue.Offer(c, req); err != nil {
+	if err := qs.offer(ctx, c, req); err != nil {
 		span.AddEvent("Failed to enqueue item.", trace.WithAttributes(qs.traceAttribute))
+		if ack != nil {
+			// The error is returned to the receiver right away.
+			ack(nil)
+		}
 		return err
 	}
 
 	span.AddEvent("Enqueued item.", trace.WithAttributes(qs.traceAttribute))
 	return nil
 }
+
+// offer puts the request in the queue with the context queueCtx. If the queue is full and blockOnOverflow is set,
+// it waits for space until ctx is done or the queueSender is shut down, and returns ErrQueueIsFull if it is.
+func (qs *queueSender) offer(ctx context.Context, queueCtx context.Context, req Request) error {
+	for {
+		// The channel must be taken before offering, so a request taken in between is not missed.
+		spaceFreed := qs.spaceFreedChan()
+		err := qs.queue.Offer(queueCtx, req)
+		if !qs.blockOnOverflow || !errors.Is(err, queue.ErrQueueIsFull) {
+			return err
+		}
+		select {
+		case <-spaceFreed:
+		case <-ctx.Done():
+			return fmt.Errorf("%w: %w", queue.ErrQueueIsFull, ctx.Err())
+		case <-qs.stopped:
+			return err
+		}
+		// The requests are also taken while the queue is drained on shutdown, when it must not be offered to.
+		select {
+		case <-qs.stopped:
+			return err
+		default:
+		}
+	}
+}
+
+func (qs *queueSender) spaceFreedChan() <-chan struct{} {
+	qs.spaceMu.Lock()
+	defer qs.spaceMu.Unlock()
+	return qs.spaceFreed
+}
+
+// notifySpaceFreed wakes up the senders blocked on a full queue.
+func (qs *queueSender) notifySpaceFreed() {
+	if !qs.blockOnOverflow {
+		return
+	}
+	qs.spaceMu.Lock()
+	defer qs.spaceMu.Unlock()
+	close(qs.spaceFreed)
+	qs.spaceFreed = make(chan struct{})
+}
+
+// expired returns the time the request spent in the queue, and whether it exceeds the max age.
+// Requests without an enqueue time, e.g. restored from a persistent queue, never expire.
+func (qs *queueSender) expired(ctx context.Context) (time.Duration, bool) {
+	if qs.maxAge <= 0 {
+		return 0, false
+	}
+	enqueuedAt, ok := ctx.Value(enqueuedAtKey{}).(time.Time)
+	if !ok {
+		return 0, false
+	}
+	age := qs.now().Sub(enqueuedAt)
+	return age, age > qs.maxAge
+}
+
+// recordWaitTime records the time the request spent in the queue, if the wait time percentiles are enabled.
+func (qs *queueSender) recordWaitTime(ctx context.Context) {
+	if qs.waitTimes == nil {
+		return
+	}
+	enqueuedAt, ok := ctx.Value(enqueuedAtKey{}).(time.Time)
+	if !ok {
+		return
+	}
+	now := qs.now()
+	qs.waitTimes.record(now, now.Sub(enqueuedAt))
+}
@@ -6,23 +6,34 @@ package exporterhelper
 import (
 	"context"
 	"errors"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest/observer"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/consumer/consumerack"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exporterqueue"
 	"go.opentelemetry.io/collector/exporter/exportertest"
 	"go.opentelemetry.io/collector/exporter/internal/queue"
 	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/testdata"
 )
 
 func TestQueuedRetry_StopWhileWaiting(t *testing.T) {
@@ -248,6 +259,204 @@ func TestNoCancellationContext(t *testing.T) {
 	assert.True(t, d.IsZero())
 }
 
+func TestQueuedRetry_MaxAge(t *testing.T) {
+	metricReader := sdkmetric.NewManualReader()
+	set := exportertest.NewNopSettings()
+	set.TelemetrySettings.MetricsLevel = configtelemetry.LevelBasic
+	set.TelemetrySettings.LeveledMeterProvider = func(level configtelemetry.Level) metric.MeterProvider {
+		if level >= configtelemetry.LevelBasic {
+			return sdkmetric.NewMeterProvider(sdkmetric.WithReader(metricReader))
+		}
+		return nil
+	}
+
+	qCfg := NewDefaultQueueSettings()
+	qCfg.NumConsumers = 1
+	qCfg.MaxAge = time.Minute
+	be, err := newBaseExporter(set, defaultDataType, newObservabilityConsumerSender,
+		withMarshaler(mockRequestMarshaler), withUnmarshaler(mockRequestUnmarshaler(&mockRequest{})),
+		WithQueue(qCfg))
+	require.NoError(t, err)
+	ocs := be.obsrepSender.(*observabilityConsumerSender)
+	qs := be.queueSender.(*queueSender)
+
+	now := time.Now()
+	qs.now = func() time.Time { return now }
+
+	// Both requests are enqueued before the consumers start, the old one first.
+	oldReq := newMockRequest(2, nil)
+	require.NoError(t, be.send(context.Background(), oldReq))
+	now = now.Add(2 * time.Minute)
+	freshReq := newMockRequest(3, nil)
+	ocs.run(func() {
+		require.NoError(t, be.send(context.Background(), freshReq))
+	})
+
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	ocs.awaitAsyncProcessing()
+	require.NoError(t, be.Shutdown(context.Background()))
+
+	// Only the fresh request is exported, the old one is dropped when dequeued.
+	oldReq.checkNumRequests(t, 0)
+	freshReq.checkNumRequests(t, 1)
+	ocs.checkSendItemsCount(t, 3)
+	ocs.checkDroppedItemsCount(t, 0)
+
+	ownMetrics := new(metricdata.ResourceMetrics)
+	require.NoError(t, metricReader.Collect(context.Background(), ownMetrics))
+	require.Len(t, ownMetrics.ScopeMetrics, 1)
+	var found bool
+	for _, m := range ownMetrics.ScopeMetrics[0].Metrics {
+		if m.Name != "otelcol_exporter_queue_expired_items" {
+			continue
+		}
+		found = true
+		metricdatatest.AssertAggregationsEqual(t, metricdata.Sum[int64]{
+			Temporality: metricdata.CumulativeTemporality,
+			IsMonotonic: true,
+			DataPoints: []metricdata.DataPoint[int64]{
+				{
+					Attributes: attribute.NewSet(
+						attribute.String(obsmetrics.ExporterKey, set.ID.String()),
+						attribute.String(obsmetrics.DataTypeKey, defaultDataType.String()),
+					),
+					Value: 2,
+				},
+			},
+		}, m.Data, metricdatatest.IgnoreTimestamp())
+	}
+	assert.True(t, found)
+}
+
+func TestQueuedRetry_MaxAgePersistentQueue(t *testing.T) {
+	storageID := component.MustNewIDWithName("file_storage", "storage")
+	qCfg := NewDefaultQueueSettings()
+	qCfg.MaxAge = time.Minute
+	qCfg.StorageID = &storageID
+	_, err := newBaseExporter(defaultSettings, defaultDataType, newNoopObsrepSender,
+		withMarshaler(mockRequestMarshaler), withUnmarshaler(mockRequestUnmarshaler(&mockRequest{})), WithQueue(qCfg))
+	require.ErrorIs(t, err, errMaxAgePersistentQueue)
+
+	// The max age is also rejected for the persistent queue of the request exporters, whose configuration is
+	// not checked by QueueSettings.Validate.
+	_, err = newBaseExporter(defaultSettings, defaultDataType, newNoopObsrepSender,
+		WithRequestQueue(exporterqueue.Config{Enabled: true, QueueSize: 10, NumConsumers: 1, MaxAge: time.Minute},
+			exporterqueue.NewPersistentQueueFactory[Request](&storageID, exporterqueue.PersistentQueueSettings[Request]{
+				Marshaler:   mockRequestMarshaler,
+				Unmarshaler: mockRequestUnmarshaler(&mockRequest{}),
+			})))
+	require.ErrorIs(t, err, errMaxAgePersistentQueue)
+}
+
+// partitionedRequest records the order it is exported in for its partition key.
+type partitionedRequest struct {
+	key  string
+	seq  int
+	sink *partitionedSink
+}
+
+type partitionedSink struct {
+	mu       sync.Mutex
+	exported map[string][]int
+	inFlight map[string]int
+	overlaps int
+}
+
+func (r *partitionedRequest) Export(context.Context) error {
+	r.sink.mu.Lock()
+	r.sink.inFlight[r.key]++
+	if r.sink.inFlight[r.key] > 1 {
+		r.sink.overlaps++
+	}
+	r.sink.mu.Unlock()
+
+	time.Sleep(time.Millisecond)
+
+	r.sink.mu.Lock()
+	defer r.sink.mu.Unlock()
+	r.sink.inFlight[r.key]--
+	r.sink.exported[r.key] = append(r.sink.exported[r.key], r.seq)
+	return nil
+}
+
+func (r *partitionedRequest) ItemsCount() int {
+	return 1
+}
+
+func TestQueuedRetry_Partitioner(t *testing.T) {
+	qCfg := exporterqueue.NewDefaultConfig()
+	qCfg.NumConsumers = 4
+	be, err := newBaseExporter(defaultSettings, defaultDataType, newNoopObsrepSender,
+		WithRequestQueue(qCfg, exporterqueue.NewMemoryQueueFactory[Request]()),
+		WithQueuePartitioner(func(_ context.Context, req Request) string {
+			return req.(*partitionedRequest).key
+		}))
+	require.NoError(t, err)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+
+	sink := &partitionedSink{exported: make(map[string][]int), inFlight: make(map[string]int)}
+	keys := []string{"a", "b", "c", "d", "e"}
+	for seq := 0; seq < 10; seq++ {
+		for _, key := range keys {
+			require.NoError(t, be.send(context.Background(), &partitionedRequest{key: key, seq: seq, sink: sink}))
+		}
+	}
+	require.NoError(t, be.Shutdown(context.Background()))
+
+	assert.Zero(t, sink.overlaps)
+	for _, key := range keys {
+		assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, sink.exported[key], "key %q", key)
+	}
+}
+
+func TestWithQueuePartitionerNil(t *testing.T) {
+	_, err := newBaseExporter(defaultSettings, defaultDataType, newNoopObsrepSender,
+		WithRequestQueue(exporterqueue.NewDefaultConfig(), exporterqueue.NewMemoryQueueFactory[Request]()),
+		WithQueuePartitioner(nil))
+	require.EqualError(t, err, "WithQueuePartitioner must be provided with a non-nil function")
+}
+
+func TestQueuedRetry_Pauser(t *testing.T) {
+	pauser := NewQueuePauser()
+	pauser.Pause()
+	assert.True(t, pauser.Paused())
+	qCfg := exporterqueue.NewDefaultConfig()
+	qCfg.NumConsumers = 2
+	be, err := newBaseExporter(defaultSettings, defaultDataType, newNoopObsrepSender,
+		WithRequestQueue(qCfg, exporterqueue.NewMemoryQueueFactory[Request]()),
+		WithQueuePauser(pauser))
+	require.NoError(t, err)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+
+	// The requests are enqueued while paused, but not exported.
+	exported := &atomic.Int64{}
+	for i := 0; i < 5; i++ {
+		require.NoError(t, be.send(context.Background(), &mockRequest{cnt: 1, requestCount: exported}))
+	}
+	time.Sleep(50 * time.Millisecond)
+	assert.Zero(t, exported.Load())
+	assert.GreaterOrEqual(t, be.queueSender.(*queueSender).queue.Size(), 3)
+
+	pauser.Resume()
+	assert.False(t, pauser.Paused())
+	assert.Eventually(t, func() bool { return exported.Load() == 5 }, time.Second, time.Millisecond)
+
+	// The paused exporter drains its queue on shutdown.
+	pauser.Pause()
+	require.NoError(t, be.send(context.Background(), &mockRequest{cnt: 1, requestCount: exported}))
+	require.NoError(t, be.Shutdown(context.Background()))
+	assert.EqualValues(t, 6, exported.Load())
+	// The shut down exporter is no longer paused and resumed.
+	assert.Empty(t, pauser.consumers)
+}
+
+func TestWithQueuePauserNil(t *testing.T) {
+	_, err := newBaseExporter(defaultSettings, defaultDataType, newNoopObsrepSender,
+		WithRequestQueue(exporterqueue.NewDefaultConfig(), exporterqueue.NewMemoryQueueFactory[Request]()),
+		WithQueuePauser(nil))
+	require.EqualError(t, err, "WithQueuePauser must be provided with a non-nil QueuePauser")
+}
+
 func TestQueueSettings_Validate(t *testing.T) {
 	qCfg := NewDefaultQueueSettings()
 	assert.NoError(t, qCfg.Validate())
@@ -260,6 +469,17 @@ func TestQueueSettings_Validate(t *testing.T) {
 
 	assert.EqualError(t, qCfg.Validate(), "number of queue consumers must be positive")
 
+	qCfg = NewDefaultQueueSettings()
+	qCfg.MaxAge = -time.Second
+	assert.EqualError(t, qCfg.Validate(), "max age must not be negative")
+
+	qCfg = NewDefaultQueueSettings()
+	qCfg.MaxAge = time.Minute
+	assert.NoError(t, qCfg.Validate())
+	storageID := component.MustNewIDWithName("file_storage", "storage")
+	qCfg.StorageID = &storageID
+	assert.EqualError(t, qCfg.Validate(), "max age is not supported with the persistent queue")
+
 	// Confirm Validate doesn't return error with invalid config when feature is disabled
 	qCfg.Enabled = false
 	assert.NoError(t, qCfg.Validate())
@@ -437,10 +657,108 @@ func TestQueueSenderNoStartShutdown(t *testing.T) {
 		exporterCreateSettings: exportertest.NewNopSettings(),
 	})
 	assert.NoError(t, err)
-	qs := newQueueSender(queue, set, 1, "", obsrep)
+	qs, err := newQueueSender(queue, set, exporterqueue.Config{NumConsumers: 1}, "", obsrep)
+	require.NoError(t, err)
 	assert.NoError(t, qs.Shutdown(context.Background()))
 }
 
+// blockingSender blocks every request until release is closed.
+type blockingSender struct {
+	baseRequestSender
+	received chan Request
+	release  chan struct{}
+}
+
+func (bs *blockingSender) send(_ context.Context, req Request) error {
+	bs.received <- req
+	<-bs.release
+	return nil
+}
+
+// newFullQueueSender returns a started queueSender with a single consumer blocked exporting a request, and its
+// queue of capacity 1 full.
+func newFullQueueSender(t *testing.T, blockOnOverflow bool) (*queueSender, *blockingSender) {
+	q := queue.NewBoundedMemoryQueue[Request](queue.MemoryQueueSettings[Request]{
+		Sizer:    &queue.RequestSizer[Request]{},
+		Capacity: 1,
+	})
+	obsrep, err := newExporter(obsReportSettings{
+		exporterID:             exporterID,
+		exporterCreateSettings: exportertest.NewNopSettings(),
+	})
+	require.NoError(t, err)
+	qs, err := newQueueSender(q, exportertest.NewNopSettings(), exporterqueue.Config{NumConsumers: 1, BlockOnOverflow: blockOnOverflow}, "", obsrep)
+	require.NoError(t, err)
+	bs := &blockingSender{received: make(chan Request, 3), release: make(chan struct{})}
+	qs.setNextSender(bs)
+	require.NoError(t, qs.Start(context.Background(), componenttest.NewNopHost()))
+
+	require.NoError(t, qs.send(context.Background(), newMockRequest(1, nil)))
+	<-bs.received
+	require.NoError(t, qs.send(context.Background(), newMockRequest(1, nil)))
+	return qs, bs
+}
+
+func TestQueueSender_DropOnOverflow(t *testing.T) {
+	qs, bs := newFullQueueSender(t, false)
+	err := qs.send(context.Background(), newMockRequest(1, nil))
+	require.ErrorIs(t, err, queue.ErrQueueIsFull)
+
+	close(bs.release)
+	require.NoError(t, qs.Shutdown(context.Background()))
+	// Only the request taken by the consumer and the queued one are exported.
+	assert.Len(t, bs.received, 1)
+}
+
+func TestQueueSender_BlockOnOverflow(t *testing.T) {
+	qs, bs := newFullQueueSender(t, true)
+	done := make(chan error, 1)
+	go func() {
+		done <- qs.send(context.Background(), newMockRequest(1, nil))
+	}()
+
+	select {
+	case err := <-done:
+		t.Fatalf("send returned while the queue is full: %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// Releasing the consumer frees space in the queue, which unblocks the sender.
+	close(bs.release)
+	require.NoError(t, <-done)
+	require.NoError(t, qs.Shutdown(context.Background()))
+	assert.Len(t, bs.received, 2)
+}
+
+func TestQueueSender_BlockOnOverflowContextDone(t *testing.T) {
+	qs, bs := newFullQueueSender(t, true)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err := qs.send(ctx, newMockRequest(1, nil))
+	require.ErrorIs(t, err, queue.ErrQueueIsFull)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	close(bs.release)
+	require.NoError(t, qs.Shutdown(context.Background()))
+	assert.Len(t, bs.received, 1)
+}
+
+func TestQueueSender_BlockOnOverflowShutdown(t *testing.T) {
+	qs, bs := newFullQueueSender(t, true)
+	done := make(chan error, 1)
+	go func() {
+		done <- qs.send(context.Background(), newMockRequest(1, nil))
+	}()
+
+	shutdownDone := make(chan error, 1)
+	go func() {
+		shutdownDone <- qs.Shutdown(context.Background())
+	}()
+	require.ErrorIs(t, <-done, queue.ErrQueueIsFull)
+	close(bs.release)
+	require.NoError(t, <-shutdownDone)
+}
+
 type mockHost struct {
 	component.Host
 	ext map[component.ID]component.Component
@@ -449,3 +767,55 @@ type mockHost struct {
 func (nh *mockHost) GetExtensions() map[component.ID]component.Component {
 	return nh.ext
 }
+
+func TestQueueSender_Acknowledgement(t *testing.T) {
+	release := make(chan error)
+	te, err := NewTracesExporter(context.Background(), exportertest.NewNopSettings(), &fakeTracesExporterConfig,
+		func(context.Context, ptrace.Traces) error { return <-release },
+		WithQueue(NewDefaultQueueSettings()), WithAcknowledgement())
+	require.NoError(t, err)
+	require.NoError(t, te.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, te.Shutdown(context.Background())) })
+	receiver := consumerack.NewTraces(te)
+
+	for _, exportErr := range []error{nil, consumererror.NewPermanent(errors.New("export failed"))} {
+		responded := make(chan error, 1)
+		go func() { responded <- receiver.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)) }()
+		select {
+		case <-responded:
+			t.Fatal("the receiver responded before the request was exported")
+		case <-time.After(50 * time.Millisecond):
+		}
+		release <- exportErr
+		assert.Equal(t, exportErr, <-responded)
+	}
+}
+
+func TestQueueSender_AcknowledgementDisabled(t *testing.T) {
+	release := make(chan struct{})
+	te, err := NewTracesExporter(context.Background(), exportertest.NewNopSettings(), &fakeTracesExporterConfig,
+		func(context.Context, ptrace.Traces) error {
+			<-release
+			return nil
+		},
+		WithQueue(NewDefaultQueueSettings()))
+	require.NoError(t, err)
+	require.NoError(t, te.Start(context.Background(), componenttest.NewNopHost()))
+
+	// The request is acknowledged once queued.
+	require.NoError(t, consumerack.NewTraces(te).ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	close(release)
+	require.NoError(t, te.Shutdown(context.Background()))
+}
+
+func TestQueueSender_AcknowledgementPersistentQueue(t *testing.T) {
+	qCfg := NewDefaultQueueSettings()
+	storageID := component.MustNewIDWithName("file_storage", "storage")
+	qCfg.StorageID = &storageID
+	be, err := newBaseExporter(defaultSettings, defaultDataType, newNoopObsrepSender,
+		withMarshaler(mockRequestMarshaler), withUnmarshaler(mockRequestUnmarshaler(&mockRequest{})),
+		WithQueue(qCfg), WithAcknowledgement())
+	require.NoError(t, err)
+	// The requests are acknowledged once durably queued.
+	assert.False(t, be.queueSender.(*queueSender).acknowledge)
+}
@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporterhelper"
+
+import (
+	"math"
+	"slices"
+	"sync"
+	"time"
+)
+
+// maxWaitTimeSamples bounds the number of wait times kept over the window, the oldest ones being
+// discarded first.
+const maxWaitTimeSamples = 10_000
+
+// queueWaitTimePercentiles are the percentiles of the wait times reported by the exporter_queue_wait_time metric,
+// by value of the percentile attribute.
+var queueWaitTimePercentiles = []struct {
+	name       string
+	percentile float64
+}{
+	{name: "p50", percentile: 0.5},
+	{name: "p95", percentile: 0.95},
+	{name: "p99", percentile: 0.99},
+}
+
+type waitTimeSample struct {
+	dequeuedAt time.Time
+	wait       time.Duration
+}
+
+// waitTimes records the time the requests waited in the queue over a rolling window.
+type waitTimes struct {
+	window time.Duration
+
+	mu sync.Mutex
+	// samples are ordered by dequeue time.
+	samples []waitTimeSample
+}
+
+func newWaitTimes(window time.Duration) *waitTimes {
+	return &waitTimes{window: window}
+}
+
+// record adds the wait time of a request dequeued at now.
+func (wt *waitTimes) record(now time.Time, wait time.Duration) {
+	wt.mu.Lock()
+	defer wt.mu.Unlock()
+	wt.prune(now)
+	if len(wt.samples) == maxWaitTimeSamples {
+		wt.samples = wt.samples[1:]
+	}
+	wt.samples = append(wt.samples, waitTimeSample{dequeuedAt: now, wait: wait})
+}
+
+// percentile returns the nearest-rank percentile, in seconds, of the wait times of the requests dequeued
+// within the window ending at now, or 0 if there are none.
+func (wt *waitTimes) percentile(now time.Time, percentile float64) float64 {
+	wt.mu.Lock()
+	wt.prune(now)
+	waits := make([]time.Duration, len(wt.samples))
+	for i, s := range wt.samples {
+		waits[i] = s.wait
+	}
+	wt.mu.Unlock()
+
+	if len(waits) == 0 {
+		return 0
+	}
+	slices.Sort(waits)
+	rank := int(math.Ceil(percentile*float64(len(waits)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	return waits[rank].Seconds()
+}
+
+// prune discards the samples dequeued before the window ending at now. It must be called with mu held.
+func (wt *waitTimes) prune(now time.Time) {
+	start := now.Add(-wt.window)
+	i := 0
+	for i < len(wt.samples) && !wt.samples[i].dequeuedAt.After(start) {
+		i++
+	}
+	wt.samples = wt.samples[i:]
+}
@@ -0,0 +1,193 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exporterhelper
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/exporter/exporterqueue"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
+)
+
+func TestWaitTimesPercentile(t *testing.T) {
+	now := time.Now()
+	wt := newWaitTimes(time.Minute)
+	assert.Zero(t, wt.percentile(now, 0.5))
+
+	// Wait times of 1 to 100ms, in shuffled order.
+	for i := 0; i < 100; i++ {
+		wt.record(now, time.Duration((i*37)%100+1)*time.Millisecond)
+	}
+	assert.InDelta(t, 0.050, wt.percentile(now, 0.5), 0.001)
+	assert.InDelta(t, 0.095, wt.percentile(now, 0.95), 0.001)
+	assert.InDelta(t, 0.099, wt.percentile(now, 0.99), 0.001)
+	assert.InDelta(t, 0.100, wt.percentile(now, 1), 0.001)
+	assert.InDelta(t, 0.001, wt.percentile(now, 0), 0.001)
+}
+
+func TestWaitTimesWindow(t *testing.T) {
+	now := time.Now()
+	wt := newWaitTimes(time.Minute)
+	for i := 0; i < 10; i++ {
+		wt.record(now, time.Second)
+	}
+	now = now.Add(30 * time.Second)
+	wt.record(now, 3*time.Second)
+	assert.InDelta(t, 1, wt.percentile(now, 0.5), 0.001)
+
+	// The first wait times leave the window.
+	now = now.Add(45 * time.Second)
+	assert.InDelta(t, 3, wt.percentile(now, 0.5), 0.001)
+	now = now.Add(time.Minute)
+	assert.Zero(t, wt.percentile(now, 0.5))
+	assert.Empty(t, wt.samples)
+}
+
+func TestWaitTimesMaxSamples(t *testing.T) {
+	now := time.Now()
+	wt := newWaitTimes(time.Hour)
+	for i := 0; i < maxWaitTimeSamples; i++ {
+		wt.record(now, time.Hour)
+	}
+	for i := 0; i < maxWaitTimeSamples; i++ {
+		wt.record(now, time.Second)
+	}
+	assert.Len(t, wt.samples, maxWaitTimeSamples)
+	assert.InDelta(t, 1, wt.percentile(now, 0.99), 0.001)
+}
+
+func TestQueuedRetry_WaitTimePercentiles(t *testing.T) {
+	metricReader := sdkmetric.NewManualReader()
+	set := exportertest.NewNopSettings()
+	set.TelemetrySettings.MetricsLevel = configtelemetry.LevelBasic
+	set.TelemetrySettings.LeveledMeterProvider = func(level configtelemetry.Level) metric.MeterProvider {
+		if level >= configtelemetry.LevelBasic {
+			return sdkmetric.NewMeterProvider(sdkmetric.WithReader(metricReader))
+		}
+		return nil
+	}
+
+	qCfg := NewDefaultQueueSettings()
+	qCfg.NumConsumers = 1
+	qCfg.WaitTimePercentilesWindow = time.Minute
+	be, err := newBaseExporter(set, defaultDataType, newObservabilityConsumerSender,
+		withMarshaler(mockRequestMarshaler), withUnmarshaler(mockRequestUnmarshaler(&mockRequest{})),
+		WithQueue(qCfg))
+	require.NoError(t, err)
+	ocs := be.obsrepSender.(*observabilityConsumerSender)
+	qs := be.queueSender.(*queueSender)
+
+	// The requests are enqueued every millisecond before the consumers start, so they wait from 100ms
+	// down to 1ms once dequeued.
+	start := time.Now()
+	for i := 0; i < 100; i++ {
+		qs.now = func() time.Time { return start.Add(time.Duration(i) * time.Millisecond) }
+		ocs.run(func() {
+			require.NoError(t, be.send(context.Background(), newMockRequest(1, nil)))
+		})
+	}
+	qs.now = func() time.Time { return start.Add(100 * time.Millisecond) }
+
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	ocs.awaitAsyncProcessing()
+	ocs.checkSendItemsCount(t, 100)
+
+	ownMetrics := new(metricdata.ResourceMetrics)
+	require.NoError(t, metricReader.Collect(context.Background(), ownMetrics))
+	require.NoError(t, be.Shutdown(context.Background()))
+
+	want := map[string]float64{"p50": 0.050, "p95": 0.095, "p99": 0.099}
+	got := map[string]float64{}
+	require.Len(t, ownMetrics.ScopeMetrics, 1)
+	for _, m := range ownMetrics.ScopeMetrics[0].Metrics {
+		if m.Name != "otelcol_exporter_queue_wait_time" {
+			continue
+		}
+		gauge, ok := m.Data.(metricdata.Gauge[float64])
+		require.True(t, ok)
+		for _, dp := range gauge.DataPoints {
+			exporterID, _ := dp.Attributes.Value(obsmetrics.ExporterKey)
+			assert.Equal(t, set.ID.String(), exporterID.AsString())
+			dataType, _ := dp.Attributes.Value(obsmetrics.DataTypeKey)
+			assert.Equal(t, defaultDataType.String(), dataType.AsString())
+			percentile, _ := dp.Attributes.Value(attribute.Key("percentile"))
+			got[percentile.AsString()] = dp.Value
+		}
+	}
+	require.Len(t, got, len(want))
+	for percentile, value := range want {
+		assert.InDelta(t, value, got[percentile], 0.001, percentile)
+	}
+}
+
+func TestQueuedRetry_WaitTimePercentilesDisabled(t *testing.T) {
+	metricReader := sdkmetric.NewManualReader()
+	set := exportertest.NewNopSettings()
+	set.TelemetrySettings.MetricsLevel = configtelemetry.LevelBasic
+	set.TelemetrySettings.LeveledMeterProvider = func(configtelemetry.Level) metric.MeterProvider {
+		return sdkmetric.NewMeterProvider(sdkmetric.WithReader(metricReader))
+	}
+
+	be, err := newBaseExporter(set, defaultDataType, newObservabilityConsumerSender,
+		withMarshaler(mockRequestMarshaler), withUnmarshaler(mockRequestUnmarshaler(&mockRequest{})),
+		WithQueue(NewDefaultQueueSettings()))
+	require.NoError(t, err)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	ocs := be.obsrepSender.(*observabilityConsumerSender)
+	ocs.run(func() {
+		require.NoError(t, be.send(context.Background(), newMockRequest(1, nil)))
+	})
+	ocs.awaitAsyncProcessing()
+
+	ownMetrics := new(metricdata.ResourceMetrics)
+	require.NoError(t, metricReader.Collect(context.Background(), ownMetrics))
+	require.NoError(t, be.Shutdown(context.Background()))
+	for _, sm := range ownMetrics.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			assert.NotEqual(t, "otelcol_exporter_queue_wait_time", m.Name)
+		}
+	}
+}
+
+func TestQueueSettings_ValidateWaitTimePercentiles(t *testing.T) {
+	qCfg := NewDefaultQueueSettings()
+	qCfg.WaitTimePercentilesWindow = -time.Second
+	assert.EqualError(t, qCfg.Validate(), "wait time percentiles window must not be negative")
+
+	qCfg.WaitTimePercentilesWindow = time.Minute
+	assert.NoError(t, qCfg.Validate())
+	storageID := component.MustNewIDWithName("file_storage", "storage")
+	qCfg.StorageID = &storageID
+	assert.EqualError(t, qCfg.Validate(), "wait time percentiles are not supported with the persistent queue")
+}
+
+func TestQueueSender_WaitTimePercentilesPersistentQueue(t *testing.T) {
+	storageID := component.MustNewIDWithName("file_storage", "storage")
+	_, err := newBaseExporter(defaultSettings, defaultDataType, newNoopObsrepSender,
+		WithRequestQueue(exporterqueue.Config{Enabled: true, QueueSize: 10, NumConsumers: 1, WaitTimePercentilesWindow: time.Minute},
+			exporterqueue.NewPersistentQueueFactory[Request](&storageID, exporterqueue.PersistentQueueSettings[Request]{
+				Marshaler:   mockRequestMarshaler,
+				Unmarshaler: mockRequestUnmarshaler(&mockRequest{}),
+			})))
+	require.ErrorIs(t, err, errWaitTimePersistentQueue)
+
+	// The in-memory queue of the request exporters supports the percentiles.
+	_, err = newBaseExporter(defaultSettings, defaultDataType, newNoopObsrepSender,
+		WithRequestQueue(exporterqueue.Config{Enabled: true, QueueSize: 10, NumConsumers: 1, WaitTimePercentilesWindow: time.Minute},
+			exporterqueue.NewMemoryQueueFactory[Request]()))
+	require.NoError(t, err)
+}
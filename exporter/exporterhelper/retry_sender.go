@@ -49,6 +49,10 @@ type retrySender struct {
 	cfg            configretry.BackOffConfig
 	stopCh         chan struct{}
 	logger         *zap.Logger
+	// attemptReporter reports every attempt if not nil.
+	attemptReporter *attemptReporter
+	// attemptsObsrep records the number of attempts of every request if not nil.
+	attemptsObsrep *obsReport
 }
 
 func newRetrySender(config configretry.BackOffConfig, set exporter.Settings) *retrySender {
@@ -81,18 +85,29 @@ func (rs *retrySender) send(ctx context.Context, req Request) error {
 	expBackoff.Reset()
 	span := trace.SpanFromContext(ctx)
 	retryNum := int64(0)
+	var requestID uint64
+	if rs.attemptReporter != nil {
+		requestID = rs.attemptReporter.nextRequestID()
+	}
 	for {
 		span.AddEvent(
 			"Sending request.",
 			trace.WithAttributes(rs.traceAttribute, attribute.Int64("retry_num", retryNum)))
 
-		err := rs.nextSender.send(ctx, req)
+		var err error
+		if rs.attemptReporter != nil {
+			err = rs.attemptReporter.attempt(ctx, rs.nextSender, req, requestID, int(retryNum)+1)
+		} else {
+			err = rs.nextSender.send(ctx, req)
+		}
 		if err == nil {
+			rs.recordAttempts(ctx, retryNum, nil)
 			return nil
 		}
 
 		// Immediately drop data on permanent errors.
 		if consumererror.IsPermanent(err) {
+			rs.recordAttempts(ctx, retryNum, err)
 			return fmt.Errorf("not retryable error: %w", err)
 		}
 
@@ -100,6 +115,7 @@ func (rs *retrySender) send(ctx context.Context, req Request) error {
 
 		backoffDelay := expBackoff.NextBackOff()
 		if backoffDelay == backoff.Stop {
+			rs.recordAttempts(ctx, retryNum, err)
 			return fmt.Errorf("no more retries left: %w", err)
 		}
 
@@ -125,6 +141,7 @@ func (rs *retrySender) send(ctx context.Context, req Request) error {
 		// back-off, but get interrupted when shutting down or request is cancelled or timed out.
 		select {
 		case <-ctx.Done():
+			rs.recordAttempts(ctx, retryNum, err)
 			return fmt.Errorf("request is cancelled or timed out %w", err)
 		case <-rs.stopCh:
 			return experr.NewShutdownErr(err)
@@ -133,6 +150,15 @@ func (rs *retrySender) send(ctx context.Context, req Request) error {
 	}
 }
 
+// recordAttempts records the number of attempts of a request completed after retryNum retries, which failed
+// if err is not nil. The requests interrupted by the shutdown are not recorded, since they may be retried
+// from the queue later.
+func (rs *retrySender) recordAttempts(ctx context.Context, retryNum int64, err error) {
+	if rs.attemptsObsrep != nil {
+		rs.attemptsObsrep.recordSendAttempts(context.WithoutCancel(ctx), int(retryNum)+1, err)
+	}
+}
+
 // max returns the larger of x or y.
 func max(x, y time.Duration) time.Duration {
 	if x < y {
@@ -32,12 +32,26 @@ func NewDefaultTimeoutSettings() TimeoutSettings {
 }
 
 // timeoutSender is a requestSender that adds a `timeout` to every request that passes this sender.
+// Being the last sender, it also records the duration of the export of the requests.
 type timeoutSender struct {
 	baseRequestSender
-	cfg TimeoutSettings
+	cfg    TimeoutSettings
+	obsrep *obsReport
 }
 
 func (ts *timeoutSender) send(ctx context.Context, req Request) error {
+	start := time.Now()
+	err := ts.export(ctx, req)
+	if ts.obsrep != nil {
+		ts.obsrep.recordSendDuration(context.WithoutCancel(ctx), time.Since(start), err)
+		if err == nil {
+			ts.obsrep.recordSuccess(time.Now())
+		}
+	}
+	return err
+}
+
+func (ts *timeoutSender) export(ctx context.Context, req Request) error {
 	// TODO: Remove this by avoiding to create the timeout sender if timeout is 0.
 	if ts.cfg.Timeout == 0 {
 		return req.Export(ctx)
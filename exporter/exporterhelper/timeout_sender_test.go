@@ -4,10 +4,22 @@
 package exporterhelper
 
 import (
+	"context"
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/metric"
+	noopmetric "go.opentelemetry.io/otel/metric/noop"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
 )
 
 func TestNewDefaultTimeoutSettings(t *testing.T) {
@@ -22,3 +34,150 @@ func TestInvalidTimeout(t *testing.T) {
 	cfg.Timeout = -1
 	assert.Error(t, cfg.Validate())
 }
+
+// slowRequest is a mockRequest whose export takes at least the given delay.
+type slowRequest struct {
+	*mockRequest
+	delay time.Duration
+}
+
+func (r *slowRequest) Export(ctx context.Context) error {
+	time.Sleep(r.delay)
+	return r.mockRequest.Export(ctx)
+}
+
+func TestTimeoutSender_SendDuration(t *testing.T) {
+	metricReader := sdkmetric.NewManualReader()
+	meterProvider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(metricReader))
+	set := exportertest.NewNopSettings()
+	set.TelemetrySettings.MetricsLevel = configtelemetry.LevelDetailed
+	set.TelemetrySettings.LeveledMeterProvider = func(configtelemetry.Level) metric.MeterProvider {
+		return meterProvider
+	}
+
+	qCfg := NewDefaultQueueSettings()
+	qCfg.NumConsumers = 1
+	be, err := newBaseExporter(set, defaultDataType, newObservabilityConsumerSender,
+		withMarshaler(mockRequestMarshaler), withUnmarshaler(mockRequestUnmarshaler(&mockRequest{})),
+		WithQueue(qCfg))
+	require.NoError(t, err)
+	ocs := be.obsrepSender.(*observabilityConsumerSender)
+
+	// The requests wait in the queue much longer than their export takes.
+	const exportDelay = 20 * time.Millisecond
+	const queueWait = 300 * time.Millisecond
+	ocs.run(func() {
+		require.NoError(t, be.send(context.Background(), &slowRequest{mockRequest: newMockRequest(2, nil), delay: exportDelay}))
+	})
+	ocs.run(func() {
+		require.NoError(t, be.send(context.Background(), newMockRequest(3, errors.New("transient error"))))
+	})
+	time.Sleep(queueWait)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	ocs.awaitAsyncProcessing()
+	require.NoError(t, be.Shutdown(context.Background()))
+
+	ownMetrics := new(metricdata.ResourceMetrics)
+	require.NoError(t, metricReader.Collect(context.Background(), ownMetrics))
+	got := map[string]metricdata.HistogramDataPoint[float64]{}
+	for _, sm := range ownMetrics.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != "otelcol_exporter_send_duration" {
+				continue
+			}
+			hist, ok := m.Data.(metricdata.Histogram[float64])
+			require.True(t, ok)
+			for _, dp := range hist.DataPoints {
+				exporterID, _ := dp.Attributes.Value(obsmetrics.ExporterKey)
+				assert.Equal(t, set.ID.String(), exporterID.AsString())
+				outcome, _ := dp.Attributes.Value("outcome")
+				got[outcome.AsString()] = dp
+			}
+		}
+	}
+	require.Len(t, got, 2)
+	assert.Equal(t, uint64(1), got["success"].Count)
+	assert.GreaterOrEqual(t, got["success"].Sum, exportDelay.Seconds())
+	assert.Less(t, got["success"].Sum, queueWait.Seconds())
+	assert.Equal(t, uint64(1), got["failure"].Count)
+	assert.Less(t, got["failure"].Sum, queueWait.Seconds())
+}
+
+func TestTimeoutSender_SendDurationNotDetailed(t *testing.T) {
+	metricReader := sdkmetric.NewManualReader()
+	meterProvider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(metricReader))
+	set := exportertest.NewNopSettings()
+	set.TelemetrySettings.MetricsLevel = configtelemetry.LevelNormal
+	set.TelemetrySettings.LeveledMeterProvider = func(level configtelemetry.Level) metric.MeterProvider {
+		if level <= configtelemetry.LevelNormal {
+			return meterProvider
+		}
+		return noopmetric.NewMeterProvider()
+	}
+
+	be, err := newBaseExporter(set, defaultDataType, newNoopObsrepSender)
+	require.NoError(t, err)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, be.send(context.Background(), newMockRequest(1, nil)))
+	require.NoError(t, be.Shutdown(context.Background()))
+
+	ownMetrics := new(metricdata.ResourceMetrics)
+	require.NoError(t, metricReader.Collect(context.Background(), ownMetrics))
+	for _, sm := range ownMetrics.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			assert.NotEqual(t, "otelcol_exporter_send_duration", m.Name)
+		}
+	}
+}
+
+// lastSuccessUnixtime returns the value of the exporter_last_success_unixtime gauge of the exporter.
+func lastSuccessUnixtime(t *testing.T, reader *sdkmetric.ManualReader, exporterID string) int64 {
+	ownMetrics := new(metricdata.ResourceMetrics)
+	require.NoError(t, reader.Collect(context.Background(), ownMetrics))
+	for _, sm := range ownMetrics.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != "otelcol_exporter_last_success_unixtime" {
+				continue
+			}
+			gauge, ok := m.Data.(metricdata.Gauge[int64])
+			require.True(t, ok)
+			require.Len(t, gauge.DataPoints, 1)
+			id, _ := gauge.DataPoints[0].Attributes.Value(obsmetrics.ExporterKey)
+			assert.Equal(t, exporterID, id.AsString())
+			return gauge.DataPoints[0].Value
+		}
+	}
+	require.Fail(t, "otelcol_exporter_last_success_unixtime not found")
+	return 0
+}
+
+func TestTimeoutSender_LastSuccessUnixtime(t *testing.T) {
+	metricReader := sdkmetric.NewManualReader()
+	meterProvider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(metricReader))
+	set := exportertest.NewNopSettings()
+	set.TelemetrySettings.MeterProvider = meterProvider
+	set.TelemetrySettings.LeveledMeterProvider = func(configtelemetry.Level) metric.MeterProvider {
+		return meterProvider
+	}
+
+	be, err := newBaseExporter(set, defaultDataType, newNoopObsrepSender)
+	require.NoError(t, err)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, be.Shutdown(context.Background()))
+	}()
+
+	require.Error(t, be.send(context.Background(), newMockRequest(1, errors.New("transient error"))))
+	assert.Zero(t, lastSuccessUnixtime(t, metricReader, set.ID.String()))
+
+	before := time.Now().Unix()
+	require.NoError(t, be.send(context.Background(), newMockRequest(1, nil)))
+	lastSuccess := lastSuccessUnixtime(t, metricReader, set.ID.String())
+	assert.GreaterOrEqual(t, lastSuccess, before)
+	assert.LessOrEqual(t, lastSuccess, time.Now().Unix())
+
+	// A failed send does not update the gauge.
+	be.obsrep.lastSuccess.Store(before - 100)
+	require.Error(t, be.send(context.Background(), newMockRequest(1, errors.New("transient error"))))
+	assert.Equal(t, before-100, lastSuccessUnixtime(t, metricReader, set.ID.String()))
+}
@@ -129,6 +129,9 @@ func NewTracesRequestExporter(
 			set.Logger.Error("Failed to convert traces. Dropping data.",
 				zap.Int("dropped_spans", td.SpanCount()),
 				zap.Error(err))
+			if be.deadLetter != nil {
+				be.logDeadLetterFailure(cErr.Error(), be.deadLetter.SendTraces(ctx, td, cErr.Error()))
+			}
 			return consumererror.NewPermanent(cErr)
 		}
 		sErr := be.send(ctx, req)
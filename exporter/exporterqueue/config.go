@@ -5,6 +5,7 @@ package exporterqueue // import "go.opentelemetry.io/collector/exporter/exporter
 
 import (
 	"errors"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 )
@@ -20,6 +21,16 @@ type Config struct {
 	NumConsumers int `mapstructure:"num_consumers"`
 	// QueueSize is the maximum number of requests allowed in queue at any given time.
 	QueueSize int `mapstructure:"queue_size"`
+	// MaxAge is the maximum time a request can wait in the queue before being dropped.
+	// Zero disables the limit. It is not supported with the persistent queue.
+	MaxAge time.Duration `mapstructure:"max_age"`
+	// BlockOnOverflow makes the requests sent while the queue is full block the caller until space is available,
+	// instead of being dropped.
+	BlockOnOverflow bool `mapstructure:"block_on_overflow"`
+	// WaitTimePercentilesWindow enables the exporter_queue_wait_time metric, reporting the percentiles of the time
+	// waited in the queue by the requests dequeued within this rolling window. Zero disables the metric. It is not
+	// supported with the persistent queue.
+	WaitTimePercentilesWindow time.Duration `mapstructure:"wait_time_percentiles_window"`
 }
 
 // NewDefaultConfig returns the default Config.
@@ -44,6 +55,12 @@ func (qCfg *Config) Validate() error {
 	if qCfg.QueueSize <= 0 {
 		return errors.New("queue size must be positive")
 	}
+	if qCfg.MaxAge < 0 {
+		return errors.New("max age must not be negative")
+	}
+	if qCfg.WaitTimePercentilesWindow < 0 {
+		return errors.New("wait time percentiles window must not be negative")
+	}
 	return nil
 }
 
@@ -5,6 +5,7 @@ package exporterqueue
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -20,6 +21,10 @@ func TestQueueConfig_Validate(t *testing.T) {
 	qCfg.QueueSize = 0
 	assert.EqualError(t, qCfg.Validate(), "queue size must be positive")
 
+	qCfg = NewDefaultConfig()
+	qCfg.MaxAge = -time.Second
+	assert.EqualError(t, qCfg.Validate(), "max age must not be negative")
+
 	// Confirm Validate doesn't return error with invalid config when feature is disabled
 	qCfg.Enabled = false
 	assert.NoError(t, qCfg.Validate())
@@ -44,12 +44,18 @@ func (q *boundedMemoryQueue[T]) Offer(ctx context.Context, req T) error {
 // The call blocks until there is an item available or the queue is stopped.
 // The function returns true when an item is consumed or false if the queue is stopped and emptied.
 func (q *boundedMemoryQueue[T]) Consume(consumeFunc func(context.Context, T) error) bool {
+	return q.consumeAsync(func(ctx context.Context, req T, done func(error)) {
+		done(consumeFunc(ctx, req))
+	})
+}
+
+func (q *boundedMemoryQueue[T]) consumeAsync(consumeFunc func(context.Context, T, func(error))) bool {
 	item, ok := q.sizedChannel.pop(func(el memQueueEl[T]) int64 { return q.sizer.Sizeof(el.req) })
 	if !ok {
 		return false
 	}
 	// the memory queue doesn't handle consume errors
-	_ = consumeFunc(item.ctx, item.req)
+	consumeFunc(item.ctx, item.req, func(error) {})
 	return true
 }
 
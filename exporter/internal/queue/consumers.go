@@ -5,16 +5,22 @@ package queue // import "go.opentelemetry.io/collector/exporter/internal/queue"
 
 import (
 	"context"
+	"hash/fnv"
 	"sync"
 
 	"go.opentelemetry.io/collector/component"
 )
 
 type Consumers[T any] struct {
-	queue        Queue[T]
-	numConsumers int
-	consumeFunc  func(context.Context, T) error
-	stopWG       sync.WaitGroup
+	queue         Queue[T]
+	numConsumers  int
+	consumeFunc   func(context.Context, T) error
+	partitionFunc func(context.Context, T) string
+	stopWG        sync.WaitGroup
+
+	pauseMu sync.Mutex
+	// resumed is closed when the consumers are resumed, nil if they are not paused.
+	resumed chan struct{}
 }
 
 func NewQueueConsumers[T any](q Queue[T], numConsumers int, consumeFunc func(context.Context, T) error) *Consumers[T] {
@@ -26,12 +32,31 @@ func NewQueueConsumers[T any](q Queue[T], numConsumers int, consumeFunc func(con
 	}
 }
 
+// NewPartitionedQueueConsumers returns Consumers dispatching every item to one of the numConsumers consumers
+// by the hash of its partition key. Items with the same key are always consumed by the same consumer, in the
+// order they were added to the queue.
+// The items are taken from the queue by a single dispatcher, and wait for the consumer of their key, up to
+// partitionBufferSize per consumer, so a slow consumer delays the others only once that many items wait for it.
+// The queues of this package consider an item consumed, e.g. remove it from their storage, only once its consumer
+// is done with it. Other queues consider it consumed once it is handed over to its consumer.
+func NewPartitionedQueueConsumers[T any](q Queue[T], numConsumers int, partitionFunc func(context.Context, T) string,
+	consumeFunc func(context.Context, T) error) *Consumers[T] {
+	qc := NewQueueConsumers(q, numConsumers, consumeFunc)
+	qc.partitionFunc = partitionFunc
+	return qc
+}
+
 // Start ensures that queue and all consumers are started.
 func (qc *Consumers[T]) Start(ctx context.Context, host component.Host) error {
 	if err := qc.queue.Start(ctx, host); err != nil {
 		return err
 	}
 
+	if qc.partitionFunc != nil {
+		qc.startPartitioned()
+		return nil
+	}
+
 	var startWG sync.WaitGroup
 	for i := 0; i < qc.numConsumers; i++ {
 		qc.stopWG.Add(1)
@@ -40,7 +65,7 @@ func (qc *Consumers[T]) Start(ctx context.Context, host component.Host) error {
 			startWG.Done()
 			defer qc.stopWG.Done()
 			for {
-				if !qc.queue.Consume(qc.consumeFunc) {
+				if !qc.queue.Consume(qc.pausable(qc.consumeFunc)) {
 					return
 				}
 			}
@@ -51,8 +76,114 @@ func (qc *Consumers[T]) Start(ctx context.Context, host component.Host) error {
 	return nil
 }
 
+// partitionBufferSize is the number of items taken from the queue which can wait for the consumer of their partition.
+const partitionBufferSize = 10
+
+type partitionedItem[T any] struct {
+	ctx  context.Context
+	item T
+	// done finishes the processing of the item by the queue.
+	done func(error)
+}
+
+// startPartitioned starts the consumers, each one reading from its own channel, and the dispatcher
+// taking the items from the queue and sending them to the channel of the consumer owning their key.
+func (qc *Consumers[T]) startPartitioned() {
+	consume := qc.pausable(qc.consumeFunc)
+	partitions := make([]chan partitionedItem[T], qc.numConsumers)
+	for i := range partitions {
+		partitions[i] = make(chan partitionedItem[T], partitionBufferSize)
+		qc.stopWG.Add(1)
+		go func(partition <-chan partitionedItem[T]) {
+			defer qc.stopWG.Done()
+			for pi := range partition {
+				pi.done(consume(pi.ctx, pi.item))
+			}
+		}(partitions[i])
+	}
+
+	dispatch := func(ctx context.Context, item T, done func(error)) {
+		partitions[partitionIndex(qc.partitionFunc(ctx, item), qc.numConsumers)] <- partitionedItem[T]{ctx: ctx, item: item, done: done}
+	}
+	qc.stopWG.Add(1)
+	go func() {
+		defer qc.stopWG.Done()
+		for {
+			// The items are left in the queue while paused.
+			qc.waitResumed()
+			if !qc.consumeAsync(dispatch) {
+				break
+			}
+		}
+		// The queue is stopped and drained, let the consumers finish the items handed over to them.
+		for _, partition := range partitions {
+			close(partition)
+		}
+	}()
+}
+
+// consumeAsync takes the head of the queue, letting consumeFunc finish its processing by the queue after returning
+// if the queue supports it. Otherwise, the queue finishes processing the item once consumeFunc returns.
+func (qc *Consumers[T]) consumeAsync(consumeFunc func(context.Context, T, func(error))) bool {
+	if aq, ok := qc.queue.(asyncQueue[T]); ok {
+		return aq.consumeAsync(consumeFunc)
+	}
+	return qc.queue.Consume(func(ctx context.Context, item T) error {
+		consumeFunc(ctx, item, func(error) {})
+		return nil
+	})
+}
+
+// partitionIndex returns the index of the consumer owning the given partition key.
+func partitionIndex(key string, numConsumers int) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	return int(h.Sum32() % uint32(numConsumers))
+}
+
+// Pause makes the consumers stop consuming the items of the queue until Resume is called. The items taken from
+// the queue are held until then, i.e. one per consumer, or with partitioned consumers the ones already waiting for
+// their consumer.
+func (qc *Consumers[T]) Pause() {
+	qc.pauseMu.Lock()
+	defer qc.pauseMu.Unlock()
+	if qc.resumed == nil {
+		qc.resumed = make(chan struct{})
+	}
+}
+
+// Resume makes the paused consumers consume the items of the queue again.
+func (qc *Consumers[T]) Resume() {
+	qc.pauseMu.Lock()
+	defer qc.pauseMu.Unlock()
+	if qc.resumed != nil {
+		close(qc.resumed)
+		qc.resumed = nil
+	}
+}
+
+// pausable returns consumeFunc waiting for the consumers to be resumed before consuming an item.
+func (qc *Consumers[T]) pausable(consumeFunc func(context.Context, T) error) func(context.Context, T) error {
+	return func(ctx context.Context, item T) error {
+		qc.waitResumed()
+		return consumeFunc(ctx, item)
+	}
+}
+
+// waitResumed waits for the consumers to be resumed if they are paused.
+func (qc *Consumers[T]) waitResumed() {
+	qc.pauseMu.Lock()
+	resumed := qc.resumed
+	qc.pauseMu.Unlock()
+	if resumed != nil {
+		<-resumed
+	}
+}
+
 // Shutdown ensures that queue and all consumers are stopped.
+// The paused consumers are resumed to drain the queue.
 func (qc *Consumers[T]) Shutdown(ctx context.Context) error {
+	qc.Resume()
 	if err := qc.queue.Shutdown(ctx); err != nil {
 		return err
 	}
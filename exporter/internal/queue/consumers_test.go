@@ -0,0 +1,284 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package queue
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/exporter/internal/experr"
+)
+
+type keyedItem struct {
+	key string
+	seq int
+}
+
+func partitionByKey(_ context.Context, item keyedItem) string {
+	return item.key
+}
+
+func TestPartitionedQueueConsumersOrdering(t *testing.T) {
+	const numConsumers = 4
+	q := NewBoundedMemoryQueue[keyedItem](MemoryQueueSettings[keyedItem]{Sizer: &RequestSizer[keyedItem]{}, Capacity: 1000})
+
+	var mu sync.Mutex
+	consumed := make(map[string][]int)
+	inFlight := make(map[string]int)
+	consumers := NewPartitionedQueueConsumers(q, numConsumers, partitionByKey, func(_ context.Context, item keyedItem) error {
+		mu.Lock()
+		inFlight[item.key]++
+		assert.Equal(t, 1, inFlight[item.key], "items of the same key consumed concurrently")
+		mu.Unlock()
+
+		time.Sleep(time.Millisecond)
+
+		mu.Lock()
+		defer mu.Unlock()
+		inFlight[item.key]--
+		consumed[item.key] = append(consumed[item.key], item.seq)
+		return nil
+	})
+	require.NoError(t, consumers.Start(context.Background(), componenttest.NewNopHost()))
+
+	keys := []string{"a", "b", "c", "d", "e", "f"}
+	for seq := 0; seq < 20; seq++ {
+		for _, key := range keys {
+			require.NoError(t, q.Offer(context.Background(), keyedItem{key: key, seq: seq}))
+		}
+	}
+	require.NoError(t, consumers.Shutdown(context.Background()))
+
+	for _, key := range keys {
+		expected := make([]int, 20)
+		for i := range expected {
+			expected[i] = i
+		}
+		assert.Equal(t, expected, consumed[key], "key %q", key)
+	}
+}
+
+func TestPartitionedQueueConsumersSameConsumer(t *testing.T) {
+	const numConsumers = 2
+	// Find keys owned by the same consumer and by the other one.
+	blocked := "key-0"
+	var sameConsumer, otherConsumer string
+	for i := 1; sameConsumer == "" || otherConsumer == ""; i++ {
+		key := "key-" + strconv.Itoa(i)
+		if partitionIndex(key, numConsumers) == partitionIndex(blocked, numConsumers) {
+			sameConsumer = key
+		} else {
+			otherConsumer = key
+		}
+	}
+
+	q := NewBoundedMemoryQueue[keyedItem](MemoryQueueSettings[keyedItem]{Sizer: &RequestSizer[keyedItem]{}, Capacity: 10})
+	unblock := make(chan struct{})
+	consumedCh := make(chan string, 10)
+	consumers := NewPartitionedQueueConsumers(q, numConsumers, partitionByKey, func(_ context.Context, item keyedItem) error {
+		if item.key == blocked {
+			<-unblock
+		}
+		consumedCh <- item.key
+		return nil
+	})
+	require.NoError(t, consumers.Start(context.Background(), componenttest.NewNopHost()))
+
+	require.NoError(t, q.Offer(context.Background(), keyedItem{key: blocked}))
+	require.NoError(t, q.Offer(context.Background(), keyedItem{key: otherConsumer}))
+	// The other consumer is not blocked by the first item.
+	assert.Equal(t, otherConsumer, <-consumedCh)
+
+	require.NoError(t, q.Offer(context.Background(), keyedItem{key: sameConsumer}))
+	// The item waits for the consumer owning its key to be done with the blocked item.
+	select {
+	case key := <-consumedCh:
+		t.Fatalf("unexpected item consumed: %q", key)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(unblock)
+	assert.Equal(t, blocked, <-consumedCh)
+	assert.Equal(t, sameConsumer, <-consumedCh)
+	require.NoError(t, consumers.Shutdown(context.Background()))
+}
+
+func TestPartitionedQueueConsumersSlowConsumer(t *testing.T) {
+	const numConsumers = 2
+	blocked := "key-0"
+	var otherConsumer string
+	for i := 1; otherConsumer == ""; i++ {
+		if key := "key-" + strconv.Itoa(i); partitionIndex(key, numConsumers) != partitionIndex(blocked, numConsumers) {
+			otherConsumer = key
+		}
+	}
+
+	q := NewBoundedMemoryQueue[keyedItem](MemoryQueueSettings[keyedItem]{Sizer: &RequestSizer[keyedItem]{}, Capacity: 100})
+	unblock := make(chan struct{})
+	consumedCh := make(chan keyedItem, 100)
+	consumers := NewPartitionedQueueConsumers(q, numConsumers, partitionByKey, func(_ context.Context, item keyedItem) error {
+		if item.key == blocked {
+			<-unblock
+		}
+		consumedCh <- item
+		return nil
+	})
+	require.NoError(t, consumers.Start(context.Background(), componenttest.NewNopHost()))
+
+	// The items waiting for the blocked consumer do not delay the other one.
+	for seq := 0; seq <= partitionBufferSize; seq++ {
+		require.NoError(t, q.Offer(context.Background(), keyedItem{key: blocked, seq: seq}))
+	}
+	require.NoError(t, q.Offer(context.Background(), keyedItem{key: otherConsumer}))
+	select {
+	case item := <-consumedCh:
+		assert.Equal(t, otherConsumer, item.key)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the other consumer is delayed by the blocked one")
+	}
+
+	close(unblock)
+	for seq := 0; seq <= partitionBufferSize; seq++ {
+		assert.Equal(t, keyedItem{key: blocked, seq: seq}, <-consumedCh)
+	}
+	require.NoError(t, consumers.Shutdown(context.Background()))
+}
+
+func TestPartitionedQueueConsumersPersistentQueue(t *testing.T) {
+	pq := NewPersistentQueue[tracesRequest](PersistentQueueSettings[tracesRequest]{
+		Sizer:            &RequestSizer[tracesRequest]{},
+		Capacity:         100,
+		DataType:         component.DataTypeTraces,
+		StorageID:        component.ID{},
+		Marshaler:        marshalTracesRequest,
+		Unmarshaler:      unmarshalTracesRequest,
+		ExporterSettings: exportertest.NewNopSettings(),
+	}).(*persistentQueue[tracesRequest])
+	unblock := make(chan struct{})
+	consumed := make(chan struct{})
+	consumers := NewPartitionedQueueConsumers[tracesRequest](pq, 2, func(context.Context, tracesRequest) string { return "" },
+		func(context.Context, tracesRequest) error {
+			<-unblock
+			consumed <- struct{}{}
+			return experr.NewShutdownErr(errors.New("export interrupted"))
+		})
+	host := &mockHost{ext: map[component.ID]component.Component{{}: NewMockStorageExtension(nil)}}
+	require.NoError(t, consumers.Start(context.Background(), host))
+
+	require.NoError(t, pq.Offer(context.Background(), newTracesRequest(1, 1)))
+	require.NoError(t, pq.Offer(context.Background(), newTracesRequest(1, 1)))
+	// The items are kept in the storage while they are not consumed.
+	assert.Eventually(t, func() bool { return pq.Size() == 0 }, 5*time.Second, 5*time.Millisecond)
+	requireCurrentlyDispatchedItemsEqual(t, pq, []uint64{0, 1})
+
+	// The result of the consumption is passed to the queue: the items interrupted by the shutdown are kept
+	// to be consumed after a restart.
+	close(unblock)
+	<-consumed
+	<-consumed
+	require.NoError(t, consumers.Shutdown(context.Background()))
+	requireCurrentlyDispatchedItemsEqual(t, pq, []uint64{0, 1})
+}
+
+// customQueue is a Queue implemented outside of this package, which cannot implement consumeAsync.
+type customQueue[T any] struct {
+	Queue[T]
+}
+
+func TestPartitionedQueueConsumersCustomQueue(t *testing.T) {
+	var _ asyncQueue[keyedItem] = (*boundedMemoryQueue[keyedItem])(nil)
+	var _ asyncQueue[keyedItem] = (*persistentQueue[keyedItem])(nil)
+
+	q := customQueue[keyedItem]{Queue: NewBoundedMemoryQueue[keyedItem](MemoryQueueSettings[keyedItem]{Sizer: &RequestSizer[keyedItem]{}, Capacity: 100})}
+	_, ok := Queue[keyedItem](q).(asyncQueue[keyedItem])
+	require.False(t, ok)
+
+	var mu sync.Mutex
+	consumed := make(map[string][]int)
+	consumers := NewPartitionedQueueConsumers[keyedItem](q, 2, partitionByKey, func(_ context.Context, item keyedItem) error {
+		mu.Lock()
+		defer mu.Unlock()
+		consumed[item.key] = append(consumed[item.key], item.seq)
+		return nil
+	})
+	require.NoError(t, consumers.Start(context.Background(), componenttest.NewNopHost()))
+	for seq := 0; seq < 10; seq++ {
+		require.NoError(t, q.Offer(context.Background(), keyedItem{key: "a", seq: seq}))
+		require.NoError(t, q.Offer(context.Background(), keyedItem{key: "b", seq: seq}))
+	}
+	require.NoError(t, consumers.Shutdown(context.Background()))
+
+	expected := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	assert.Equal(t, map[string][]int{"a": expected, "b": expected}, consumed)
+}
+
+func TestPartitionIndex(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		key := strconv.Itoa(i)
+		idx := partitionIndex(key, 7)
+		assert.GreaterOrEqual(t, idx, 0)
+		assert.Less(t, idx, 7)
+		assert.Equal(t, idx, partitionIndex(key, 7))
+	}
+}
+
+func TestQueueConsumersPause(t *testing.T) {
+	for _, partitioned := range []bool{false, true} {
+		t.Run("partitioned="+strconv.FormatBool(partitioned), func(t *testing.T) {
+			q := NewBoundedMemoryQueue[keyedItem](MemoryQueueSettings[keyedItem]{Sizer: &RequestSizer[keyedItem]{}, Capacity: 100})
+			var consumed sync.Map
+			consumeFunc := func(_ context.Context, item keyedItem) error {
+				consumed.Store(item.seq, true)
+				return nil
+			}
+			consumers := NewQueueConsumers(q, 2, consumeFunc)
+			if partitioned {
+				consumers = NewPartitionedQueueConsumers(q, 2, partitionByKey, consumeFunc)
+			}
+			require.NoError(t, consumers.Start(context.Background(), componenttest.NewNopHost()))
+
+			consumers.Pause()
+			// Pausing twice has no effect.
+			consumers.Pause()
+			for seq := 0; seq < 10; seq++ {
+				require.NoError(t, q.Offer(context.Background(), keyedItem{key: strconv.Itoa(seq), seq: seq}))
+			}
+			time.Sleep(50 * time.Millisecond)
+			consumed.Range(func(any, any) bool {
+				assert.Fail(t, "item consumed while paused")
+				return false
+			})
+			// The items are buffered in the queue, but the ones taken by the paused consumers.
+			assert.GreaterOrEqual(t, q.Size(), 8)
+
+			consumers.Resume()
+			consumers.Resume()
+			assert.Eventually(t, func() bool {
+				for seq := 0; seq < 10; seq++ {
+					if _, ok := consumed.Load(seq); !ok {
+						return false
+					}
+				}
+				return true
+			}, time.Second, 5*time.Millisecond)
+
+			// The paused consumers are resumed to drain the queue on shutdown.
+			consumers.Pause()
+			require.NoError(t, q.Offer(context.Background(), keyedItem{key: "a", seq: 10}))
+			require.NoError(t, consumers.Shutdown(context.Background()))
+			_, ok := consumed.Load(10)
+			assert.True(t, ok)
+		})
+	}
+}
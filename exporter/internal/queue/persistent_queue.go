@@ -192,6 +192,12 @@ func (pq *persistentQueue[T]) restoreQueueSizeFromStorage(ctx context.Context) (
 // The call blocks until there is an item available or the queue is stopped.
 // The function returns true when an item is consumed or false if the queue is stopped.
 func (pq *persistentQueue[T]) Consume(consumeFunc func(context.Context, T) error) bool {
+	return pq.consumeAsync(func(ctx context.Context, req T, done func(error)) {
+		done(consumeFunc(ctx, req))
+	})
+}
+
+func (pq *persistentQueue[T]) consumeAsync(consumeFunc func(context.Context, T, func(error))) bool {
 	for {
 		var (
 			req                  T
@@ -212,7 +218,7 @@ func (pq *persistentQueue[T]) Consume(consumeFunc func(context.Context, T) error
 			return false
 		}
 		if consumed {
-			onProcessingFinished(consumeFunc(context.Background(), req))
+			consumeFunc(context.Background(), req, onProcessingFinished)
 			return true
 		}
 	}
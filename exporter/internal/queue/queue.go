@@ -35,6 +35,21 @@ type Queue[T any] interface {
 	Capacity() int
 }
 
+// asyncQueue is implemented by the queues of this package, which can finish processing an item after it is taken.
+// It is not part of Queue, so that Queue can be implemented outside of this module.
+type asyncQueue[T any] interface {
+	// consumeAsync takes the head of the queue as Consume does, but the queue finishes processing the item, e.g.
+	// removes it from its storage, only once done is called with the result of its consumption, which consumeFunc
+	// may do after returning. The call blocks until there is an item available or the queue is stopped.
+	consumeAsync(consumeFunc func(ctx context.Context, item T, done func(error))) bool
+}
+
+// IsPersistent reports whether q stores its items in a storage extension, so they are durably queued.
+func IsPersistent[T any](q Queue[T]) bool {
+	_, ok := q.(*persistentQueue[T])
+	return ok
+}
+
 type itemsCounter interface {
 	ItemsCount() int
 }
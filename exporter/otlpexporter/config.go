@@ -10,6 +10,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configgrpc"
@@ -29,6 +30,107 @@ type Config struct {
 	BatcherConfig exporterbatcher.Config `mapstructure:"batcher"`
 
 	configgrpc.ClientConfig `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+
+	// UserAgent configures the User-Agent header of the requests. By default, it holds the
+	// description and the version of the collector.
+	UserAgent UserAgentConfig `mapstructure:"user_agent"`
+
+	// Dedup configures the exporter to skip the requests whose content was already sent recently.
+	Dedup DedupConfig `mapstructure:"dedup"`
+
+	// Hedging configures the exporter to send duplicates of the requests which are slow to get a response.
+	Hedging HedgingConfig `mapstructure:"hedging"`
+}
+
+// DedupConfig defines the client-side deduplication of the requests, for the destinations which do
+// not deduplicate them.
+type DedupConfig struct {
+	// Enabled skips sending a request if a request with the same content was sent within the window.
+	Enabled bool `mapstructure:"enabled"`
+
+	// Window is the period during which the content of a sent request is remembered.
+	Window time.Duration `mapstructure:"window"`
+
+	// MaxEntries is the maximum number of remembered requests. When it is reached, the least recently
+	// sent request is forgotten.
+	MaxEntries int `mapstructure:"max_entries"`
+}
+
+func (c *DedupConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.Window <= 0 {
+		return errors.New(`"window" must be positive`)
+	}
+	if c.MaxEntries <= 0 {
+		return errors.New(`"max_entries" must be positive`)
+	}
+	return nil
+}
+
+// HedgingConfig defines the hedging of the requests: when a request has not received a response after
+// the delay, a duplicate is sent, and the first successful response is used while the other requests
+// are cancelled.
+type HedgingConfig struct {
+	// Enabled sends hedged requests.
+	Enabled bool `mapstructure:"enabled"`
+
+	// Delay is the time waited for a response before sending each hedged request.
+	Delay time.Duration `mapstructure:"delay"`
+
+	// MaxHedges is the maximum number of hedged requests sent in addition to the original request.
+	MaxHedges int `mapstructure:"max_hedges"`
+
+	// RequestIDHeader is the name of a header holding an ID identical for the original and hedged
+	// requests, but unique across requests, allowing the destination to discard the duplicates.
+	// No header is sent when empty.
+	RequestIDHeader string `mapstructure:"request_id_header"`
+}
+
+func (c *HedgingConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.Delay <= 0 {
+		return errors.New(`"delay" must be positive`)
+	}
+	if c.MaxHedges <= 0 {
+		return errors.New(`"max_hedges" must be positive`)
+	}
+	return nil
+}
+
+// UserAgentConfig defines the User-Agent header sent by the exporter.
+type UserAgentConfig struct {
+	// Suffix is appended to the default User-Agent, separated by a space.
+	Suffix string `mapstructure:"suffix"`
+
+	// Override replaces the default User-Agent, including the collector version.
+	Override string `mapstructure:"override"`
+}
+
+func (c *UserAgentConfig) Validate() error {
+	if c.Suffix != "" && c.Override != "" {
+		return errors.New(`"suffix" and "override" cannot be both set`)
+	}
+	for _, v := range []string{c.Suffix, c.Override} {
+		if !validHeaderValue(v) {
+			return fmt.Errorf("invalid User-Agent value %q", v)
+		}
+	}
+	return nil
+}
+
+// validHeaderValue returns whether v is a legal HTTP header field value, as defined by RFC 7230.
+func validHeaderValue(v string) bool {
+	for i := 0; i < len(v); i++ {
+		b := v[i]
+		if (b < ' ' && b != '\t') || b == 0x7f {
+			return false
+		}
+	}
+	return strings.TrimSpace(v) == v
 }
 
 func (c *Config) Validate() error {
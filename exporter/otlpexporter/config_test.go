@@ -87,6 +87,17 @@ func TestUnmarshalConfig(t *testing.T) {
 				BalancerName:    "round_robin",
 				Auth:            &configauth.Authentication{AuthenticatorID: component.MustNewID("nop")},
 			},
+			Dedup: DedupConfig{
+				Enabled:    true,
+				Window:     time.Minute,
+				MaxEntries: 1000,
+			},
+			Hedging: HedgingConfig{
+				Enabled:         true,
+				Delay:           200 * time.Millisecond,
+				MaxHedges:       2,
+				RequestIDHeader: "x-request-id",
+			},
 		}, cfg)
 }
 
@@ -130,6 +141,30 @@ func TestUnmarshalInvalidConfig(t *testing.T) {
 			name:     "invalid_port",
 			errorMsg: `invalid port "port"`,
 		},
+		{
+			name:     "user_agent_suffix_and_override",
+			errorMsg: `"suffix" and "override" cannot be both set`,
+		},
+		{
+			name:     "invalid_dedup_window",
+			errorMsg: `"window" must be positive`,
+		},
+		{
+			name:     "invalid_dedup_max_entries",
+			errorMsg: `"max_entries" must be positive`,
+		},
+		{
+			name:     "invalid_hedging_delay",
+			errorMsg: `"delay" must be positive`,
+		},
+		{
+			name:     "invalid_hedging_max_hedges",
+			errorMsg: `"max_hedges" must be positive`,
+		},
+		{
+			name:     "invalid_user_agent",
+			errorMsg: `invalid User-Agent value "my-team\nX-Injected: true"`,
+		},
 	} {
 		t.Run(test.name, func(t *testing.T) {
 			cfg := factory.CreateDefaultConfig()
@@ -0,0 +1,171 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpexporter // import "go.opentelemetry.io/collector/exporter/otlpexporter"
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+)
+
+// dedupStore is a bounded store of the content hashes of the recently sent requests.
+type dedupStore struct {
+	window     time.Duration
+	maxEntries int
+	now        func() time.Time
+
+	mu      sync.Mutex
+	entries map[[sha256.Size]byte]*list.Element
+	// sent holds the dedupEntry of every hash, from the least to the most recently sent.
+	sent *list.List
+	// sending holds the hashes of the requests being sent, with a channel closed once they are sent or failed.
+	sending map[[sha256.Size]byte]chan struct{}
+}
+
+type dedupEntry struct {
+	hash   [sha256.Size]byte
+	sentAt time.Time
+}
+
+func newDedupStore(cfg DedupConfig) *dedupStore {
+	if !cfg.Enabled {
+		return nil
+	}
+	return &dedupStore{
+		window:     cfg.Window,
+		maxEntries: cfg.MaxEntries,
+		now:        time.Now,
+		entries:    make(map[[sha256.Size]byte]*list.Element),
+		sent:       list.New(),
+		sending:    make(map[[sha256.Size]byte]chan struct{}),
+	}
+}
+
+// acquire checks and reserves, atomically, the hash of the content of a request about to be sent. It returns
+// false if a request with the content was sent within the window. If one is being sent, it waits for its outcome,
+// so the duplicates sent concurrently are sent once. If it returns true, release must be called with the hash
+// once the request is sent or failed.
+func (s *dedupStore) acquire(ctx context.Context, content []byte) ([sha256.Size]byte, bool, error) {
+	hash := sha256.Sum256(content)
+	for {
+		s.mu.Lock()
+		s.expire()
+		if _, ok := s.entries[hash]; ok {
+			s.mu.Unlock()
+			return hash, false, nil
+		}
+		done, ok := s.sending[hash]
+		if !ok {
+			s.sending[hash] = make(chan struct{})
+			s.mu.Unlock()
+			return hash, true, nil
+		}
+		s.mu.Unlock()
+		select {
+		case <-done:
+		case <-ctx.Done():
+			return hash, false, ctx.Err()
+		}
+	}
+}
+
+// release releases the hash reserved by acquire, recording it if the request was sent, and evicting the least
+// recently sent hash if the store is full.
+func (s *dedupStore) release(hash [sha256.Size]byte, sent bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	close(s.sending[hash])
+	delete(s.sending, hash)
+	if !sent {
+		return
+	}
+	if elem, ok := s.entries[hash]; ok {
+		s.sent.Remove(elem)
+	}
+	s.entries[hash] = s.sent.PushBack(&dedupEntry{hash: hash, sentAt: s.now()})
+	for s.sent.Len() > s.maxEntries {
+		s.remove(s.sent.Front())
+	}
+	s.expire()
+}
+
+// expire removes the hashes sent before the window. It must be called with the lock held.
+func (s *dedupStore) expire() {
+	cutoff := s.now().Add(-s.window)
+	for elem := s.sent.Front(); elem != nil && !elem.Value.(*dedupEntry).sentAt.After(cutoff); elem = s.sent.Front() {
+		s.remove(elem)
+	}
+}
+
+func (s *dedupStore) remove(elem *list.Element) {
+	s.sent.Remove(elem)
+	delete(s.entries, elem.Value.(*dedupEntry).hash)
+}
+
+// rawCodec is the gRPC codec sending the requests already marshaled to compute their hash, so they are not
+// marshaled again, and unmarshaling the OTLP responses.
+type rawCodec struct{}
+
+var _ encoding.Codec = rawCodec{}
+
+func (rawCodec) Marshal(v any) ([]byte, error) {
+	content, ok := v.([]byte)
+	if !ok {
+		return nil, fmt.Errorf("unexpected request type %T", v)
+	}
+	return content, nil
+}
+
+func (rawCodec) Unmarshal(data []byte, v any) error {
+	resp, ok := v.(interface{ UnmarshalProto([]byte) error })
+	if !ok {
+		return fmt.Errorf("unexpected response type %T", v)
+	}
+	return resp.UnmarshalProto(data)
+}
+
+// Name returns the name of the proto codec, the content being the protobuf encoding of the request.
+func (rawCodec) Name() string {
+	return "proto"
+}
+
+// exportDeduplicated sends the request with send or, if the deduplication is enabled, sends its content to the
+// gRPC method unless it is a duplicate, in which case an empty response is returned.
+func exportDeduplicated[Req interface{ MarshalProto() ([]byte, error) }, Resp any](ctx context.Context, e *baseExporter,
+	req Req, method string, send func(context.Context, Req) (Resp, error), newResponse func() Resp) (Resp, error) {
+	ctx = e.enhanceContext(ctx)
+	if e.dedup == nil {
+		resp, err := hedgedExport(ctx, e.hedger, func(ctx context.Context) (Resp, error) {
+			return send(ctx, req)
+		})
+		return resp, processError(err)
+	}
+
+	content, err := req.MarshalProto()
+	if err != nil {
+		return newResponse(), err
+	}
+	hash, acquired, err := e.dedup.acquire(ctx, content)
+	if err != nil {
+		return newResponse(), err
+	}
+	if !acquired {
+		e.settings.Logger.Debug("Skipping a request already sent within the deduplication window")
+		return newResponse(), nil
+	}
+	callOptions := append([]grpc.CallOption{grpc.ForceCodec(rawCodec{})}, e.callOptions...)
+	resp, err := hedgedExport(ctx, e.hedger, func(ctx context.Context) (Resp, error) {
+		resp := newResponse()
+		return resp, e.clientConn.Invoke(ctx, method, content, resp, callOptions...)
+	})
+	err = processError(err)
+	e.dedup.release(hash, err == nil)
+	return resp, err
+}
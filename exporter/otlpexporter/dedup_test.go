@@ -0,0 +1,239 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpexporter
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configgrpc"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	"go.opentelemetry.io/collector/pdata/testdata"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func newTestDedupStore(window time.Duration, maxEntries int) (*dedupStore, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC)}
+	store := newDedupStore(DedupConfig{Enabled: true, Window: window, MaxEntries: maxEntries})
+	store.now = clock.Now
+	return store, clock
+}
+
+// send records the content as sent through the store, returning false if it is skipped as a duplicate.
+func send(t *testing.T, store *dedupStore, content string) bool {
+	hash, acquired, err := store.acquire(context.Background(), []byte(content))
+	require.NoError(t, err)
+	if acquired {
+		store.release(hash, true)
+	}
+	return acquired
+}
+
+func TestDedupStore(t *testing.T) {
+	store, clock := newTestDedupStore(time.Minute, 10)
+	assert.True(t, send(t, store, "a"))
+	assert.False(t, send(t, store, "a"))
+
+	clock.now = clock.now.Add(30 * time.Second)
+	assert.True(t, send(t, store, "b"))
+	assert.False(t, send(t, store, "a"))
+
+	// The skipped duplicates do not restart the window.
+	clock.now = clock.now.Add(30 * time.Second)
+	assert.True(t, send(t, store, "a"))
+	assert.False(t, send(t, store, "b"))
+
+	clock.now = clock.now.Add(45 * time.Second)
+	assert.True(t, send(t, store, "b"))
+	assert.False(t, send(t, store, "a"))
+}
+
+func TestDedupStoreFailedRequest(t *testing.T) {
+	store, _ := newTestDedupStore(time.Minute, 10)
+	hash, acquired, err := store.acquire(context.Background(), []byte("a"))
+	require.NoError(t, err)
+	require.True(t, acquired)
+	store.release(hash, false)
+	// A failed request is not remembered.
+	assert.True(t, send(t, store, "a"))
+	assert.False(t, send(t, store, "a"))
+}
+
+func TestDedupStoreConcurrentDuplicates(t *testing.T) {
+	store, _ := newTestDedupStore(time.Minute, 10)
+	hash, acquired, err := store.acquire(context.Background(), []byte("a"))
+	require.NoError(t, err)
+	require.True(t, acquired)
+
+	// A duplicate waits for the outcome of the request being sent.
+	result := make(chan bool)
+	go func() {
+		_, dupAcquired, dupErr := store.acquire(context.Background(), []byte("a"))
+		assert.NoError(t, dupErr)
+		result <- dupAcquired
+	}()
+	select {
+	case <-result:
+		t.Fatal("the duplicate did not wait for the request being sent")
+	case <-time.After(20 * time.Millisecond):
+	}
+	store.release(hash, true)
+	assert.False(t, <-result)
+
+	// A duplicate waiting for a failed request is sent instead.
+	hash, acquired, err = store.acquire(context.Background(), []byte("b"))
+	require.NoError(t, err)
+	require.True(t, acquired)
+	go func() {
+		dupHash, dupAcquired, dupErr := store.acquire(context.Background(), []byte("b"))
+		assert.NoError(t, dupErr)
+		if dupAcquired {
+			store.release(dupHash, true)
+		}
+		result <- dupAcquired
+	}()
+	time.Sleep(10 * time.Millisecond)
+	store.release(hash, false)
+	assert.True(t, <-result)
+
+	// The wait ends with its context.
+	hash, acquired, err = store.acquire(context.Background(), []byte("c"))
+	require.NoError(t, err)
+	require.True(t, acquired)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, _, err = store.acquire(ctx, []byte("c"))
+	require.ErrorIs(t, err, context.Canceled)
+	store.release(hash, true)
+}
+
+func TestDedupStoreMaxEntries(t *testing.T) {
+	store, _ := newTestDedupStore(time.Minute, 2)
+	assert.True(t, send(t, store, "a"))
+	assert.True(t, send(t, store, "b"))
+	assert.True(t, send(t, store, "c"))
+	assert.Len(t, store.entries, 2)
+	// The least recently sent request is forgotten first.
+	assert.False(t, send(t, store, "c"))
+	assert.True(t, send(t, store, "a"))
+	assert.True(t, send(t, store, "b"))
+	assert.False(t, send(t, store, "a"))
+}
+
+func TestDedupStoreDisabled(t *testing.T) {
+	assert.Nil(t, newDedupStore(DedupConfig{Window: time.Minute, MaxEntries: 10}))
+}
+
+func TestSendTracesDedup(t *testing.T) {
+	ln, err := net.Listen("tcp", "localhost:")
+	require.NoError(t, err)
+	rcv, _ := otlpTracesReceiverOnGRPCServer(ln, false)
+	defer rcv.srv.GracefulStop()
+
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.ClientConfig = configgrpc.ClientConfig{
+		Endpoint:   ln.Addr().String(),
+		TLSSetting: configtls.ClientConfig{Insecure: true},
+	}
+	cfg.Dedup = DedupConfig{Enabled: true, Window: time.Minute, MaxEntries: 10}
+	exp := newExporter(cfg, exportertest.NewNopSettings())
+	clock := &fakeClock{now: time.Now()}
+	exp.dedup.now = clock.Now
+	require.NoError(t, exp.start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, exp.shutdown(context.Background()))
+	}()
+
+	require.NoError(t, exp.pushTraces(context.Background(), testdata.GenerateTraces(2)))
+	assert.EqualValues(t, 1, rcv.requestCount.Load())
+	assert.Equal(t, testdata.GenerateTraces(2), rcv.getLastRequest())
+
+	// A duplicate within the window is skipped, while a different request is sent.
+	clock.now = clock.now.Add(30 * time.Second)
+	require.NoError(t, exp.pushTraces(context.Background(), testdata.GenerateTraces(2)))
+	assert.EqualValues(t, 1, rcv.requestCount.Load())
+	require.NoError(t, exp.pushTraces(context.Background(), testdata.GenerateTraces(1)))
+	assert.EqualValues(t, 2, rcv.requestCount.Load())
+
+	// A duplicate outside the window is sent.
+	clock.now = clock.now.Add(time.Minute)
+	require.NoError(t, exp.pushTraces(context.Background(), testdata.GenerateTraces(2)))
+	assert.EqualValues(t, 3, rcv.requestCount.Load())
+}
+
+func TestSendTracesDedupPartialSuccess(t *testing.T) {
+	ln, err := net.Listen("tcp", "localhost:")
+	require.NoError(t, err)
+	rcv, _ := otlpTracesReceiverOnGRPCServer(ln, false)
+	defer rcv.srv.GracefulStop()
+	rcv.setExportResponse(func() ptraceotlp.ExportResponse {
+		response := ptraceotlp.NewExportResponse()
+		response.PartialSuccess().SetRejectedSpans(1)
+		return response
+	})
+
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.ClientConfig = configgrpc.ClientConfig{
+		Endpoint:   ln.Addr().String(),
+		TLSSetting: configtls.ClientConfig{Insecure: true},
+	}
+	cfg.Dedup = DedupConfig{Enabled: true, Window: time.Minute, MaxEntries: 10}
+	set := exportertest.NewNopSettings()
+	logger, observed := observer.New(zap.DebugLevel)
+	set.Logger = zap.New(logger)
+	exp := newExporter(cfg, set)
+	require.NoError(t, exp.start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, exp.shutdown(context.Background()))
+	}()
+
+	// The response of the request sent with its marshaled content is unmarshaled.
+	require.NoError(t, exp.pushTraces(context.Background(), testdata.GenerateTraces(2)))
+	warnings := observed.FilterMessage("Partial success response").All()
+	require.Len(t, warnings, 1)
+	assert.Equal(t, int64(1), warnings[0].ContextMap()["dropped_spans"])
+}
+
+func TestSendTracesDedupFailedRequest(t *testing.T) {
+	ln, err := net.Listen("tcp", "localhost:")
+	require.NoError(t, err)
+	rcv, _ := otlpTracesReceiverOnGRPCServer(ln, false)
+	defer rcv.srv.GracefulStop()
+	rcv.setExportError(assert.AnError)
+
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.ClientConfig = configgrpc.ClientConfig{
+		Endpoint:   ln.Addr().String(),
+		TLSSetting: configtls.ClientConfig{Insecure: true},
+	}
+	cfg.Dedup = DedupConfig{Enabled: true, Window: time.Minute, MaxEntries: 10}
+	exp := newExporter(cfg, exportertest.NewNopSettings())
+	require.NoError(t, exp.start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, exp.shutdown(context.Background()))
+	}()
+
+	// A request which failed is not remembered, so it is sent again.
+	require.Error(t, exp.pushTraces(context.Background(), testdata.GenerateTraces(1)))
+	rcv.setExportError(nil)
+	require.NoError(t, exp.pushTraces(context.Background(), testdata.GenerateTraces(1)))
+	assert.EqualValues(t, 2, rcv.requestCount.Load())
+}
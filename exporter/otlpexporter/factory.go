@@ -5,6 +5,7 @@ package otlpexporter // import "go.opentelemetry.io/collector/exporter/otlpexpor
 
 import (
 	"context"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configcompression"
@@ -45,6 +46,14 @@ func createDefaultConfig() component.Config {
 			// We almost read 0 bytes, so no need to tune ReadBufferSize.
 			WriteBufferSize: 512 * 1024,
 		},
+		Dedup: DedupConfig{
+			Window:     5 * time.Minute,
+			MaxEntries: 10000,
+		},
+		Hedging: HedgingConfig{
+			Delay:     time.Second,
+			MaxHedges: 1,
+		},
 	}
 }
 
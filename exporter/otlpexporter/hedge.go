@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpexporter // import "go.opentelemetry.io/collector/exporter/otlpexporter"
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/metadata"
+)
+
+// hedger sends hedged requests for the requests which do not receive a response within the delay.
+type hedger struct {
+	delay           time.Duration
+	maxHedges       int
+	requestIDHeader string
+	logger          *zap.Logger
+}
+
+func newHedger(cfg HedgingConfig, logger *zap.Logger) *hedger {
+	if !cfg.Enabled {
+		return nil
+	}
+	return &hedger{
+		delay:           cfg.Delay,
+		maxHedges:       cfg.MaxHedges,
+		requestIDHeader: cfg.RequestIDHeader,
+		logger:          logger,
+	}
+}
+
+type hedgeResult[T any] struct {
+	resp T
+	err  error
+}
+
+// hedgedExport calls export, and calls it again every delay of h while no call succeeded, up to the
+// maximum number of hedges. It returns the response of the first call which succeeds, cancelling the
+// context of the other calls, or the error of the last call once all of them failed. The hedges of a
+// request being a single attempt for the retry sender, no hedge is sent after all the calls failed.
+// If h is nil, export is called once.
+func hedgedExport[T any](ctx context.Context, h *hedger, export func(ctx context.Context) (T, error)) (T, error) {
+	if h == nil {
+		return export(ctx)
+	}
+	if h.requestIDHeader != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, h.requestIDHeader, uuid.NewString())
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	// Cancel the calls still in flight once a response is returned.
+	defer cancel()
+
+	results := make(chan hedgeResult[T], h.maxHedges+1)
+	send := func() {
+		go func() {
+			resp, err := export(ctx)
+			results <- hedgeResult[T]{resp: resp, err: err}
+		}()
+	}
+	send()
+	sent, pending := 1, 1
+	timer := time.NewTimer(h.delay)
+	defer timer.Stop()
+	for {
+		select {
+		case res := <-results:
+			pending--
+			if res.err == nil || pending == 0 {
+				return res.resp, res.err
+			}
+		case <-timer.C:
+			h.logger.Debug("Sending a hedged request", zap.Int("hedge", sent))
+			send()
+			sent++
+			pending++
+			if sent <= h.maxHedges {
+				timer.Reset(h.delay)
+			}
+		}
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpexporter
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configgrpc"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	"go.opentelemetry.io/collector/pdata/testdata"
+)
+
+// slowTracesReceiver blocks the first slowRequests requests until they are cancelled, and responds
+// immediately to the others.
+type slowTracesReceiver struct {
+	ptraceotlp.UnimplementedGRPCServer
+	slowRequests int32
+
+	requests  atomic.Int32
+	cancelled atomic.Int32
+
+	mu         sync.Mutex
+	requestIDs []string
+}
+
+func (r *slowTracesReceiver) Export(ctx context.Context, _ ptraceotlp.ExportRequest) (ptraceotlp.ExportResponse, error) {
+	n := r.requests.Add(1)
+	md, _ := metadata.FromIncomingContext(ctx)
+	r.mu.Lock()
+	r.requestIDs = append(r.requestIDs, md.Get("x-request-id")...)
+	r.mu.Unlock()
+	if n <= r.slowRequests {
+		<-ctx.Done()
+		r.cancelled.Add(1)
+		return ptraceotlp.NewExportResponse(), ctx.Err()
+	}
+	return ptraceotlp.NewExportResponse(), nil
+}
+
+func TestSendTracesHedging(t *testing.T) {
+	ln, err := net.Listen("tcp", "localhost:")
+	require.NoError(t, err)
+	rcv := &slowTracesReceiver{slowRequests: 1}
+	srv := grpc.NewServer()
+	ptraceotlp.RegisterGRPCServer(srv, rcv)
+	go func() {
+		_ = srv.Serve(ln)
+	}()
+	defer srv.Stop()
+
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.ClientConfig = configgrpc.ClientConfig{
+		Endpoint:   ln.Addr().String(),
+		TLSSetting: configtls.ClientConfig{Insecure: true},
+	}
+	cfg.Hedging = HedgingConfig{Enabled: true, Delay: 50 * time.Millisecond, MaxHedges: 2, RequestIDHeader: "x-request-id"}
+	exp := newExporter(cfg, exportertest.NewNopSettings())
+	require.NoError(t, exp.start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, exp.shutdown(context.Background()))
+	}()
+
+	// The first request never responds, the hedge sent after the delay wins.
+	require.NoError(t, exp.pushTraces(context.Background(), testdata.GenerateTraces(2)))
+	assert.EqualValues(t, 2, rcv.requests.Load())
+	// The first request is cancelled.
+	assert.Eventually(t, func() bool { return rcv.cancelled.Load() == 1 }, time.Second, 5*time.Millisecond)
+
+	rcv.mu.Lock()
+	defer rcv.mu.Unlock()
+	require.Len(t, rcv.requestIDs, 2)
+	assert.NotEmpty(t, rcv.requestIDs[0])
+	assert.Equal(t, rcv.requestIDs[0], rcv.requestIDs[1])
+}
+
+func testHedger(maxHedges int) *hedger {
+	return newHedger(HedgingConfig{Enabled: true, Delay: 10 * time.Millisecond, MaxHedges: maxHedges}, zap.NewNop())
+}
+
+func TestHedgedExportFastResponse(t *testing.T) {
+	var calls atomic.Int32
+	resp, err := hedgedExport(context.Background(), testHedger(2), func(context.Context) (int, error) {
+		return int(calls.Add(1)), nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, resp)
+	assert.EqualValues(t, 1, calls.Load())
+}
+
+func TestHedgedExportMaxHedges(t *testing.T) {
+	var calls atomic.Int32
+	_, err := hedgedExport(context.Background(), testHedger(2), func(context.Context) (int, error) {
+		calls.Add(1)
+		time.Sleep(100 * time.Millisecond)
+		return 0, errors.New("failed")
+	})
+	// The error is returned once the original request and all the hedges failed.
+	require.EqualError(t, err, "failed")
+	assert.EqualValues(t, 3, calls.Load())
+}
+
+func TestHedgedExportFailedRequest(t *testing.T) {
+	var calls atomic.Int32
+	_, err := hedgedExport(context.Background(), testHedger(2), func(context.Context) (int, error) {
+		calls.Add(1)
+		return 0, errors.New("failed")
+	})
+	// No hedge is sent once all the requests failed, the retry sender handles the error.
+	require.EqualError(t, err, "failed")
+	time.Sleep(30 * time.Millisecond)
+	assert.EqualValues(t, 1, calls.Load())
+}
+
+func TestHedgedExportDisabled(t *testing.T) {
+	assert.Nil(t, newHedger(HedgingConfig{Delay: time.Second, MaxHedges: 1}, zap.NewNop()))
+	var calls atomic.Int32
+	_, err := hedgedExport(context.Background(), nil, func(context.Context) (int, error) {
+		calls.Add(1)
+		return 0, errors.New("failed")
+	})
+	require.EqualError(t, err, "failed")
+	assert.EqualValues(t, 1, calls.Load())
+}
@@ -44,6 +44,12 @@ type baseExporter struct {
 
 	// Default user-agent header.
 	userAgent string
+
+	// dedup holds the content hashes of the recently sent requests, nil if the deduplication is disabled.
+	dedup *dedupStore
+
+	// hedger sends the hedged requests, nil if the hedging is disabled.
+	hedger *hedger
 }
 
 func newExporter(cfg component.Config, set exporter.Settings) *baseExporter {
@@ -51,8 +57,20 @@ func newExporter(cfg component.Config, set exporter.Settings) *baseExporter {
 
 	userAgent := fmt.Sprintf("%s/%s (%s/%s)",
 		set.BuildInfo.Description, set.BuildInfo.Version, runtime.GOOS, runtime.GOARCH)
+	switch {
+	case oCfg.UserAgent.Override != "":
+		userAgent = oCfg.UserAgent.Override
+	case oCfg.UserAgent.Suffix != "":
+		userAgent += " " + oCfg.UserAgent.Suffix
+	}
 
-	return &baseExporter{config: oCfg, settings: set.TelemetrySettings, userAgent: userAgent}
+	return &baseExporter{
+		config:    oCfg,
+		settings:  set.TelemetrySettings,
+		userAgent: userAgent,
+		dedup:     newDedupStore(oCfg.Dedup),
+		hedger:    newHedger(oCfg.Hedging, set.Logger),
+	}
 }
 
 // start actually creates the gRPC connection. The client construction is deferred till this point as this
@@ -85,8 +103,11 @@ func (e *baseExporter) shutdown(context.Context) error {
 
 func (e *baseExporter) pushTraces(ctx context.Context, td ptrace.Traces) error {
 	req := ptraceotlp.NewExportRequestFromTraces(td)
-	resp, respErr := e.traceExporter.Export(e.enhanceContext(ctx), req, e.callOptions...)
-	if err := processError(respErr); err != nil {
+	resp, err := exportDeduplicated(ctx, e, req, "/opentelemetry.proto.collector.trace.v1.TraceService/Export",
+		func(ctx context.Context, req ptraceotlp.ExportRequest) (ptraceotlp.ExportResponse, error) {
+			return e.traceExporter.Export(ctx, req, e.callOptions...)
+		}, ptraceotlp.NewExportResponse)
+	if err != nil {
 		return err
 	}
 	partialSuccess := resp.PartialSuccess()
@@ -101,8 +122,11 @@ func (e *baseExporter) pushTraces(ctx context.Context, td ptrace.Traces) error {
 
 func (e *baseExporter) pushMetrics(ctx context.Context, md pmetric.Metrics) error {
 	req := pmetricotlp.NewExportRequestFromMetrics(md)
-	resp, respErr := e.metricExporter.Export(e.enhanceContext(ctx), req, e.callOptions...)
-	if err := processError(respErr); err != nil {
+	resp, err := exportDeduplicated(ctx, e, req, "/opentelemetry.proto.collector.metrics.v1.MetricsService/Export",
+		func(ctx context.Context, req pmetricotlp.ExportRequest) (pmetricotlp.ExportResponse, error) {
+			return e.metricExporter.Export(ctx, req, e.callOptions...)
+		}, pmetricotlp.NewExportResponse)
+	if err != nil {
 		return err
 	}
 	partialSuccess := resp.PartialSuccess()
@@ -117,8 +141,11 @@ func (e *baseExporter) pushMetrics(ctx context.Context, md pmetric.Metrics) erro
 
 func (e *baseExporter) pushLogs(ctx context.Context, ld plog.Logs) error {
 	req := plogotlp.NewExportRequestFromLogs(ld)
-	resp, respErr := e.logExporter.Export(e.enhanceContext(ctx), req, e.callOptions...)
-	if err := processError(respErr); err != nil {
+	resp, err := exportDeduplicated(ctx, e, req, "/opentelemetry.proto.collector.logs.v1.LogsService/Export",
+		func(ctx context.Context, req plogotlp.ExportRequest) (plogotlp.ExportResponse, error) {
+			return e.logExporter.Export(ctx, req, e.callOptions...)
+		}, plogotlp.NewExportResponse)
+	if err != nil {
 		return err
 	}
 	partialSuccess := resp.PartialSuccess()
@@ -5,9 +5,11 @@ package otlpexporter
 
 import (
 	"context"
+	"fmt"
 	"net"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -322,6 +324,63 @@ func TestSendTraces(t *testing.T) {
 	assert.Contains(t, observed.FilterLevelExact(zap.WarnLevel).All()[0].Message, "Partial success")
 }
 
+func TestSendTracesUserAgent(t *testing.T) {
+	tests := []struct {
+		name      string
+		userAgent UserAgentConfig
+		expected  string
+	}{
+		{
+			name:     "default",
+			expected: fmt.Sprintf("Collector/1.2.3test (%s/%s)", runtime.GOOS, runtime.GOARCH),
+		},
+		{
+			name:      "suffix",
+			userAgent: UserAgentConfig{Suffix: "team-a/2.0"},
+			expected:  fmt.Sprintf("Collector/1.2.3test (%s/%s) team-a/2.0", runtime.GOOS, runtime.GOARCH),
+		},
+		{
+			name:      "override",
+			userAgent: UserAgentConfig{Override: "my-agent/1.0"},
+			expected:  "my-agent/1.0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ln, err := net.Listen("tcp", "localhost:")
+			require.NoError(t, err)
+			rcv, _ := otlpTracesReceiverOnGRPCServer(ln, false)
+			defer rcv.srv.GracefulStop()
+
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig().(*Config)
+			cfg.QueueConfig.Enabled = false
+			cfg.ClientConfig = configgrpc.ClientConfig{
+				Endpoint: ln.Addr().String(),
+				TLSSetting: configtls.ClientConfig{
+					Insecure: true,
+				},
+			}
+			cfg.UserAgent = tt.userAgent
+			set := exportertest.NewNopSettings()
+			set.BuildInfo.Description = "Collector"
+			set.BuildInfo.Version = "1.2.3test"
+			exp, err := factory.CreateTracesExporter(context.Background(), set, cfg)
+			require.NoError(t, err)
+			require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+			defer func() {
+				assert.NoError(t, exp.Shutdown(context.Background()))
+			}()
+
+			require.NoError(t, exp.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+			userAgent := rcv.getMetadata().Get("User-Agent")
+			require.Len(t, userAgent, 1)
+			// gRPC appends its own product token.
+			assert.True(t, strings.HasPrefix(userAgent[0], tt.expected+" grpc-go/"), userAgent[0])
+		})
+	}
+}
+
 func TestSendTracesWhenEndpointHasHttpScheme(t *testing.T) {
 	tests := []struct {
 		name               string
@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlphttpexporter // import "go.opentelemetry.io/collector/exporter/otlphttpexporter"
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configcompression"
+)
+
+// compressionHints follows the compression preferred by the destination, as advertised by the
+// configured response header, creating an HTTP client per compression when it is first used.
+type compressionHints struct {
+	header   string
+	exporter *baseExporter
+	host     component.Host
+
+	mu      sync.Mutex
+	current configcompression.Type
+	clients map[configcompression.Type]*http.Client
+}
+
+func newCompressionHints(e *baseExporter, host component.Host) *compressionHints {
+	return &compressionHints{
+		header:   e.config.CompressionHintHeader,
+		exporter: e,
+		host:     host,
+		current:  e.config.Compression,
+		clients:  map[configcompression.Type]*http.Client{e.config.Compression: e.client},
+	}
+}
+
+// client returns the HTTP client using the compression currently preferred by the destination.
+func (ch *compressionHints) client() *http.Client {
+	ch.mu.Lock()
+	defer ch.mu.Unlock()
+	return ch.clients[ch.current]
+}
+
+// closeIdleConnections closes the idle connections of the clients of all the compressions.
+func (ch *compressionHints) closeIdleConnections() {
+	ch.mu.Lock()
+	defer ch.mu.Unlock()
+	for _, client := range ch.clients {
+		client.CloseIdleConnections()
+	}
+}
+
+// observe switches to the compression advertised by resp, if any. The unsupported compressions
+// are ignored, and "identity" stands for no compression.
+func (ch *compressionHints) observe(resp *http.Response) {
+	hint := strings.ToLower(strings.TrimSpace(resp.Header.Get(ch.header)))
+	if hint == "" {
+		return
+	}
+	if hint == "identity" {
+		hint = "none"
+	}
+	var compression configcompression.Type
+	if err := compression.UnmarshalText([]byte(hint)); err != nil {
+		ch.exporter.logger.Debug("Ignoring unsupported compression hint", zap.String("hint", hint))
+		return
+	}
+
+	ch.mu.Lock()
+	defer ch.mu.Unlock()
+	if compression == ch.current || (!compression.IsCompressed() && !ch.current.IsCompressed()) {
+		return
+	}
+	if _, ok := ch.clients[compression]; !ok {
+		clientConfig := ch.exporter.config.ClientConfig
+		clientConfig.Compression = compression
+		client, err := clientConfig.ToClient(context.Background(), ch.host, ch.exporter.settings)
+		if err != nil {
+			ch.exporter.logger.Warn("Failed to create a client for the hinted compression", zap.String("compression", string(compression)), zap.Error(err))
+			return
+		}
+		ch.clients[compression] = client
+	}
+	ch.exporter.logger.Debug("Switching compression", zap.String("from", string(ch.current)), zap.String("to", string(compression)))
+	ch.current = compression
+}
@@ -7,6 +7,8 @@ import (
 	"encoding"
 	"errors"
 	"fmt"
+	"mime"
+	"strings"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/confighttp"
@@ -60,6 +62,18 @@ type Config struct {
 
 	// The encoding to export telemetry (default: "proto")
 	Encoding EncodingType `mapstructure:"encoding"`
+
+	// ContentType overrides the Content-Type header sent with the requests, for destinations requiring
+	// a specific media type for the configured encoding, e.g. "application/protobuf".
+	// If empty, the Content-Type matching the encoding is used.
+	ContentType string `mapstructure:"content_type"`
+
+	// CompressionHintHeader is the name of a response header through which the destination advertises
+	// the compression it prefers, e.g. "X-Prefer-Encoding". When set, the requests are sent with the
+	// compression of the configured ClientConfig.Compression until a response advertises another
+	// supported compression, which is then used for the subsequent requests. The values "none" and
+	// "identity" disable the compression. If empty, the compression never changes.
+	CompressionHintHeader string `mapstructure:"compression_hint_header"`
 }
 
 var _ component.Config = (*Config)(nil)
@@ -69,5 +83,13 @@ func (cfg *Config) Validate() error {
 	if cfg.Endpoint == "" && cfg.TracesEndpoint == "" && cfg.MetricsEndpoint == "" && cfg.LogsEndpoint == "" {
 		return errors.New("at least one endpoint must be specified")
 	}
+	if cfg.ContentType != "" {
+		if _, _, err := mime.ParseMediaType(cfg.ContentType); err != nil {
+			return fmt.Errorf("invalid content_type %q: %w", cfg.ContentType, err)
+		}
+	}
+	if strings.ContainsAny(cfg.CompressionHintHeader, " \t\r\n:") {
+		return fmt.Errorf("invalid compression_hint_header %q", cfg.CompressionHintHeader)
+	}
 	return nil
 }
@@ -75,6 +75,25 @@ func TestUnmarshalConfig(t *testing.T) {
 		}, cfg)
 }
 
+func TestConfigValidateContentType(t *testing.T) {
+	cfg := &Config{ClientConfig: confighttp.ClientConfig{Endpoint: "http://localhost:4318"}, ContentType: "application/protobuf"}
+	assert.NoError(t, cfg.Validate())
+
+	cfg.ContentType = "application/json; charset=utf-8"
+	assert.NoError(t, cfg.Validate())
+
+	cfg.ContentType = "application/"
+	assert.ErrorContains(t, cfg.Validate(), `invalid content_type "application/"`)
+}
+
+func TestConfigValidateCompressionHintHeader(t *testing.T) {
+	cfg := &Config{ClientConfig: confighttp.ClientConfig{Endpoint: "http://localhost:4318"}, CompressionHintHeader: "X-Prefer-Encoding"}
+	assert.NoError(t, cfg.Validate())
+
+	cfg.CompressionHintHeader = "X-Prefer: Encoding"
+	assert.EqualError(t, cfg.Validate(), `invalid compression_hint_header "X-Prefer: Encoding"`)
+}
+
 func TestUnmarshalConfigInvalidEncoding(t *testing.T) {
 	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "bad_invalid_encoding.yaml"))
 	require.NoError(t, err)
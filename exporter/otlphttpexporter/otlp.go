@@ -24,6 +24,7 @@ import (
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.opentelemetry.io/collector/internal/httphelper"
+	"go.opentelemetry.io/collector/internal/otlppassthrough"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
 	"go.opentelemetry.io/collector/pdata/pmetric"
@@ -34,13 +35,15 @@ import (
 
 type baseExporter struct {
 	// Input configuration.
-	config     *Config
-	client     *http.Client
-	tracesURL  string
-	metricsURL string
-	logsURL    string
-	logger     *zap.Logger
-	settings   component.TelemetrySettings
+	config *Config
+	client *http.Client
+	// compressionHints is set when the compression follows the hints of the destination.
+	compressionHints *compressionHints
+	tracesURL        string
+	metricsURL       string
+	logsURL          string
+	logger           *zap.Logger
+	settings         component.TelemetrySettings
 	// Default user-agent header.
 	userAgent string
 }
@@ -84,6 +87,19 @@ func (e *baseExporter) start(ctx context.Context, host component.Host) error {
 		return err
 	}
 	e.client = client
+	if e.config.CompressionHintHeader != "" {
+		e.compressionHints = newCompressionHints(e, host)
+	}
+	return nil
+}
+
+// shutdown closes the idle connections of the HTTP clients, which are not used anymore.
+func (e *baseExporter) shutdown(context.Context) error {
+	if e.compressionHints != nil {
+		e.compressionHints.closeIdleConnections()
+	} else if e.client != nil {
+		e.client.CloseIdleConnections()
+	}
 	return nil
 }
 
@@ -96,7 +112,10 @@ func (e *baseExporter) pushTraces(ctx context.Context, td ptrace.Traces) error {
 	case EncodingJSON:
 		request, err = tr.MarshalJSON()
 	case EncodingProto:
-		request, err = tr.MarshalProto()
+		var ok bool
+		if request, ok = otlppassthrough.TracesFromContext(ctx, td); !ok {
+			request, err = tr.MarshalProto()
+		}
 	default:
 		err = fmt.Errorf("invalid encoding: %s", e.config.Encoding)
 	}
@@ -117,7 +136,10 @@ func (e *baseExporter) pushMetrics(ctx context.Context, md pmetric.Metrics) erro
 	case EncodingJSON:
 		request, err = tr.MarshalJSON()
 	case EncodingProto:
-		request, err = tr.MarshalProto()
+		var ok bool
+		if request, ok = otlppassthrough.MetricsFromContext(ctx, md); !ok {
+			request, err = tr.MarshalProto()
+		}
 	default:
 		err = fmt.Errorf("invalid encoding: %s", e.config.Encoding)
 	}
@@ -137,7 +159,10 @@ func (e *baseExporter) pushLogs(ctx context.Context, ld plog.Logs) error {
 	case EncodingJSON:
 		request, err = tr.MarshalJSON()
 	case EncodingProto:
-		request, err = tr.MarshalProto()
+		var ok bool
+		if request, ok = otlppassthrough.LogsFromContext(ctx, ld); !ok {
+			request, err = tr.MarshalProto()
+		}
 	default:
 		err = fmt.Errorf("invalid encoding: %s", e.config.Encoding)
 	}
@@ -164,13 +189,23 @@ func (e *baseExporter) export(ctx context.Context, url string, request []byte, p
 	default:
 		return fmt.Errorf("invalid encoding: %s", e.config.Encoding)
 	}
+	if e.config.ContentType != "" {
+		req.Header.Set("Content-Type", e.config.ContentType)
+	}
 
 	req.Header.Set("User-Agent", e.userAgent)
 
-	resp, err := e.client.Do(req)
+	client := e.client
+	if e.compressionHints != nil {
+		client = e.compressionHints.client()
+	}
+	resp, err := client.Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to make an HTTP request: %w", err)
 	}
+	if e.compressionHints != nil {
+		e.compressionHints.observe(resp)
+	}
 
 	defer func() {
 		// Discard any remaining response body when we are done reading.
@@ -5,13 +5,16 @@ package otlphttpexporter
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -24,11 +27,14 @@ import (
 	"google.golang.org/protobuf/proto"
 
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configcompression"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/internal/otlppassthrough"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
 	"go.opentelemetry.io/collector/pdata/pmetric"
@@ -992,6 +998,239 @@ func TestEncoding(t *testing.T) {
 	})
 }
 
+func TestEncodingWithCompression(t *testing.T) {
+	tests := []struct {
+		name                string
+		encoding            EncodingType
+		contentType         string
+		expectedContentType string
+	}{
+		{
+			name:                "json",
+			encoding:            EncodingJSON,
+			expectedContentType: "application/json",
+		},
+		{
+			name:                "proto",
+			encoding:            EncodingProto,
+			expectedContentType: "application/x-protobuf",
+		},
+		{
+			name:                "proto_custom_content_type",
+			encoding:            EncodingProto,
+			contentType:         "application/protobuf",
+			expectedContentType: "application/protobuf",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			received := make(chan ptraceotlp.ExportRequest, 1)
+			srv := createBackend("/v1/traces", func(writer http.ResponseWriter, request *http.Request) {
+				assert.Equal(t, test.expectedContentType, request.Header.Get("Content-Type"))
+				assert.Equal(t, "gzip", request.Header.Get("Content-Encoding"))
+
+				gr, err := gzip.NewReader(request.Body)
+				if !assert.NoError(t, err) {
+					writer.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				body, err := io.ReadAll(gr)
+				assert.NoError(t, err)
+
+				req := ptraceotlp.NewExportRequest()
+				if test.encoding == EncodingJSON {
+					assert.NoError(t, req.UnmarshalJSON(body))
+				} else {
+					assert.NoError(t, req.UnmarshalProto(body))
+				}
+				received <- req
+				writer.WriteHeader(http.StatusOK)
+			})
+			defer srv.Close()
+
+			cfg := &Config{
+				ClientConfig: confighttp.ClientConfig{
+					Compression: configcompression.TypeGzip,
+				},
+				TracesEndpoint: fmt.Sprintf("%s/v1/traces", srv.URL),
+				Encoding:       test.encoding,
+				ContentType:    test.contentType,
+			}
+			require.NoError(t, cfg.Validate())
+			exp, err := createTracesExporter(context.Background(), exportertest.NewNopSettings(), cfg)
+			require.NoError(t, err)
+			require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+			t.Cleanup(func() {
+				require.NoError(t, exp.Shutdown(context.Background()))
+			})
+
+			traces := ptrace.NewTraces()
+			span := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+			span.SetName("compressed")
+			require.NoError(t, exp.ConsumeTraces(context.Background(), traces))
+
+			req := <-received
+			assert.Equal(t, traces, req.Traces())
+		})
+	}
+}
+
+func TestCompressionHint(t *testing.T) {
+	// The backend advertises the compression for the next request.
+	hints := []string{"zstd", "unsupported", "identity", "gzip", ""}
+	var encodings []string
+	srv := createBackend("/v1/traces", func(writer http.ResponseWriter, request *http.Request) {
+		encodings = append(encodings, request.Header.Get("Content-Encoding"))
+		writer.Header().Set("X-Prefer-Encoding", hints[len(encodings)-1])
+		writer.WriteHeader(http.StatusOK)
+	})
+	defer srv.Close()
+
+	cfg := &Config{
+		ClientConfig: confighttp.ClientConfig{
+			Compression: configcompression.TypeGzip,
+		},
+		TracesEndpoint:        fmt.Sprintf("%s/v1/traces", srv.URL),
+		Encoding:              EncodingProto,
+		CompressionHintHeader: "X-Prefer-Encoding",
+	}
+	require.NoError(t, cfg.Validate())
+	exp, err := createTracesExporter(context.Background(), exportertest.NewNopSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		require.NoError(t, exp.Shutdown(context.Background()))
+	})
+
+	for range hints {
+		traces := ptrace.NewTraces()
+		traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("hinted")
+		require.NoError(t, exp.ConsumeTraces(context.Background(), traces))
+	}
+	assert.Equal(t, []string{"gzip", "zstd", "zstd", "", "gzip"}, encodings)
+}
+
+func TestCompressionHintShutdown(t *testing.T) {
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+		writer.Header().Set("X-Prefer-Encoding", "zstd")
+		writer.WriteHeader(http.StatusOK)
+	}))
+	closed := &atomic.Int64{}
+	srv.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateClosed {
+			closed.Add(1)
+		}
+	}
+	srv.Start()
+	defer srv.Close()
+
+	cfg := &Config{
+		ClientConfig: confighttp.ClientConfig{
+			Compression: configcompression.TypeGzip,
+		},
+		TracesEndpoint:        fmt.Sprintf("%s/v1/traces", srv.URL),
+		Encoding:              EncodingProto,
+		CompressionHintHeader: "X-Prefer-Encoding",
+	}
+	exp, err := createTracesExporter(context.Background(), exportertest.NewNopSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+
+	// The gzip and zstd clients each keep an idle connection.
+	for i := 0; i < 2; i++ {
+		require.NoError(t, exp.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+	}
+	assert.Zero(t, closed.Load())
+	require.NoError(t, exp.Shutdown(context.Background()))
+	assert.Eventually(t, func() bool { return closed.Load() == 2 }, time.Second, time.Millisecond)
+}
+
+func TestOTLPPassthrough(t *testing.T) {
+	var bodies [][]byte
+	srv := createBackend("/v1/traces", func(writer http.ResponseWriter, request *http.Request) {
+		body, err := io.ReadAll(request.Body)
+		assert.NoError(t, err)
+		bodies = append(bodies, body)
+		writer.WriteHeader(http.StatusOK)
+	})
+	defer srv.Close()
+
+	cfg := &Config{
+		TracesEndpoint: fmt.Sprintf("%s/v1/traces", srv.URL),
+		Encoding:       EncodingProto,
+	}
+	exp, err := createTracesExporter(context.Background(), exportertest.NewNopSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		require.NoError(t, exp.Shutdown(context.Background()))
+	})
+
+	traces := ptrace.NewTraces()
+	traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("forwarded")
+	marshaled, err := ptraceotlp.NewExportRequestFromTraces(traces).MarshalProto()
+	require.NoError(t, err)
+	// The received request has an unknown field, which would be lost by encoding the data again.
+	received := append(append([]byte{}, marshaled...), 0xa0, 0x06, 0x01)
+
+	setPassthroughGate := func(enabled bool) {
+		require.NoError(t, featuregate.GlobalRegistry().Set(otlppassthrough.Gate.ID(), enabled))
+	}
+	prev := otlppassthrough.Gate.IsEnabled()
+	t.Cleanup(func() { setPassthroughGate(prev) })
+
+	setPassthroughGate(true)
+	ctx := otlppassthrough.ContextWithTraces(context.Background(), traces, received)
+	require.NoError(t, exp.ConsumeTraces(ctx, traces))
+	// Other data than the received one, e.g. modified by a processor, is encoded.
+	copied := ptrace.NewTraces()
+	traces.CopyTo(copied)
+	require.NoError(t, exp.ConsumeTraces(ctx, copied))
+	require.NoError(t, exp.ConsumeTraces(context.Background(), traces))
+	setPassthroughGate(false)
+	require.NoError(t, exp.ConsumeTraces(ctx, traces))
+
+	assert.Equal(t, [][]byte{received, marshaled, marshaled, marshaled}, bodies)
+}
+
+func TestOTLPPassthroughSendingQueue(t *testing.T) {
+	bodies := make(chan []byte, 1)
+	srv := createBackend("/v1/traces", func(writer http.ResponseWriter, request *http.Request) {
+		// The default config compresses the requests with gzip.
+		gz, err := gzip.NewReader(request.Body)
+		assert.NoError(t, err)
+		body, err := io.ReadAll(gz)
+		assert.NoError(t, err)
+		bodies <- body
+		writer.WriteHeader(http.StatusOK)
+	})
+	defer srv.Close()
+
+	prev := otlppassthrough.Gate.IsEnabled()
+	require.NoError(t, featuregate.GlobalRegistry().Set(otlppassthrough.Gate.ID(), true))
+	t.Cleanup(func() { require.NoError(t, featuregate.GlobalRegistry().Set(otlppassthrough.Gate.ID(), prev)) })
+
+	// The in-memory sending queue of the default config keeps the received request along with the data.
+	cfg := createDefaultConfig().(*Config)
+	cfg.TracesEndpoint = fmt.Sprintf("%s/v1/traces", srv.URL)
+	require.True(t, cfg.QueueConfig.Enabled)
+	exp, err := createTracesExporter(context.Background(), exportertest.NewNopSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		require.NoError(t, exp.Shutdown(context.Background()))
+	})
+
+	traces := ptrace.NewTraces()
+	traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("forwarded")
+	marshaled, err := ptraceotlp.NewExportRequestFromTraces(traces).MarshalProto()
+	require.NoError(t, err)
+	received := append(append([]byte{}, marshaled...), 0xa0, 0x06, 0x01)
+	require.NoError(t, exp.ConsumeTraces(otlppassthrough.ContextWithTraces(context.Background(), traces, received), traces))
+	assert.Equal(t, received, <-bodies)
+}
+
 func createBackend(endpoint string, handler func(writer http.ResponseWriter, request *http.Request)) *httptest.Server {
 	mux := http.NewServeMux()
 	mux.HandleFunc(endpoint, handler)
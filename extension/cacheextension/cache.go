@@ -0,0 +1,174 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cacheextension // import "go.opentelemetry.io/collector/extension/cacheextension"
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+)
+
+// Cache is a namespace of the cache shared by the components.
+// It is safe for concurrent use.
+type Cache interface {
+	// Get returns the value of the key, and whether it was found.
+	Get(key string) (any, bool)
+	// Put sets the value of the key, evicting the least recently used entries
+	// if the namespace or the cache is full.
+	Put(key string, value any)
+	// Delete removes the key.
+	Delete(key string)
+	// Len returns the number of entries of the namespace.
+	Len() int
+}
+
+// Extension is the interface implemented by the cache extension. The components looking up
+// the extension in component.Host.GetExtensions use it to get the Cache of their namespace.
+type Extension interface {
+	extension.Extension
+	// Namespace returns the Cache of the namespace. The keys of different namespaces never collide.
+	Namespace(name string) Cache
+}
+
+// GetCache returns the Cache of the namespace from the cache extension with the given ID.
+func GetCache(host component.Host, id component.ID, namespace string) (Cache, error) {
+	ext, ok := host.GetExtensions()[id]
+	if !ok {
+		return nil, fmt.Errorf("cache extension %q not found", id)
+	}
+	c, ok := ext.(Extension)
+	if !ok {
+		return nil, fmt.Errorf("extension %q is not a cache extension", id)
+	}
+	return c.Namespace(namespace), nil
+}
+
+type sharedCache struct {
+	maxEntries int
+	quotas     map[string]int
+
+	mu         sync.Mutex
+	lru        *list.List
+	namespaces map[string]*namespace
+}
+
+// namespace is the Cache of a namespace, its entries are also part of the LRU list of the shared cache.
+type namespace struct {
+	cache   *sharedCache
+	quota   int
+	lru     *list.List
+	entries map[string]*entry
+}
+
+type entry struct {
+	ns     *namespace
+	key    string
+	value  any
+	global *list.Element
+	local  *list.Element
+}
+
+var _ Extension = (*sharedCache)(nil)
+
+func newCache(cfg *Config) *sharedCache {
+	quotas := make(map[string]int, len(cfg.Namespaces))
+	for name, ns := range cfg.Namespaces {
+		quotas[name] = ns.MaxEntries
+	}
+	return &sharedCache{
+		maxEntries: cfg.MaxEntries,
+		quotas:     quotas,
+		lru:        list.New(),
+		namespaces: make(map[string]*namespace),
+	}
+}
+
+func (c *sharedCache) Start(context.Context, component.Host) error {
+	return nil
+}
+
+func (c *sharedCache) Shutdown(context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, ns := range c.namespaces {
+		ns.lru.Init()
+		clear(ns.entries)
+	}
+	c.lru.Init()
+	return nil
+}
+
+func (c *sharedCache) Namespace(name string) Cache {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ns, ok := c.namespaces[name]
+	if !ok {
+		ns = &namespace{
+			cache:   c,
+			quota:   c.quotas[name],
+			lru:     list.New(),
+			entries: make(map[string]*entry),
+		}
+		c.namespaces[name] = ns
+	}
+	return ns
+}
+
+// remove removes the entry from the cache. The caller must hold the lock.
+func (c *sharedCache) remove(e *entry) {
+	c.lru.Remove(e.global)
+	e.ns.lru.Remove(e.local)
+	delete(e.ns.entries, e.key)
+}
+
+func (ns *namespace) Get(key string) (any, bool) {
+	ns.cache.mu.Lock()
+	defer ns.cache.mu.Unlock()
+	e, ok := ns.entries[key]
+	if !ok {
+		return nil, false
+	}
+	ns.cache.lru.MoveToFront(e.global)
+	ns.lru.MoveToFront(e.local)
+	return e.value, true
+}
+
+func (ns *namespace) Put(key string, value any) {
+	ns.cache.mu.Lock()
+	defer ns.cache.mu.Unlock()
+	if e, ok := ns.entries[key]; ok {
+		e.value = value
+		ns.cache.lru.MoveToFront(e.global)
+		ns.lru.MoveToFront(e.local)
+		return
+	}
+	if ns.quota > 0 && ns.lru.Len() >= ns.quota {
+		ns.cache.remove(ns.lru.Back().Value.(*entry))
+	}
+	if ns.cache.lru.Len() >= ns.cache.maxEntries {
+		ns.cache.remove(ns.cache.lru.Back().Value.(*entry))
+	}
+	e := &entry{ns: ns, key: key, value: value}
+	e.global = ns.cache.lru.PushFront(e)
+	e.local = ns.lru.PushFront(e)
+	ns.entries[key] = e
+}
+
+func (ns *namespace) Delete(key string) {
+	ns.cache.mu.Lock()
+	defer ns.cache.mu.Unlock()
+	if e, ok := ns.entries[key]; ok {
+		ns.cache.remove(e)
+	}
+}
+
+func (ns *namespace) Len() int {
+	ns.cache.mu.Lock()
+	defer ns.cache.mu.Unlock()
+	return ns.lru.Len()
+}
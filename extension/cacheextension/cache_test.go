@@ -0,0 +1,169 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cacheextension
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+)
+
+type extensionsHost struct {
+	component.Host
+	extensions map[component.ID]component.Component
+}
+
+func (h extensionsHost) GetExtensions() map[component.ID]component.Component {
+	return h.extensions
+}
+
+type nopComponent struct {
+	component.StartFunc
+	component.ShutdownFunc
+}
+
+func newHost(t *testing.T, cfg *Config) (component.Host, component.ID) {
+	id := component.MustNewID("cache")
+	ext, err := NewFactory().CreateExtension(context.Background(), extensiontest.NewNopSettings(), cfg)
+	require.NoError(t, err)
+	host := extensionsHost{Host: componenttest.NewNopHost(), extensions: map[component.ID]component.Component{id: ext}}
+	require.NoError(t, ext.Start(context.Background(), host))
+	t.Cleanup(func() { assert.NoError(t, ext.Shutdown(context.Background())) })
+	return host, id
+}
+
+func TestSharedCacheQuotas(t *testing.T) {
+	host, id := newHost(t, &Config{
+		MaxEntries: 10,
+		Namespaces: map[string]NamespaceConfig{"dedup": {MaxEntries: 3}},
+	})
+
+	// Two processors get their namespace of the same cache.
+	dedup, err := GetCache(host, id, "dedup")
+	require.NoError(t, err)
+	enrichment, err := GetCache(host, id, "enrichment")
+	require.NoError(t, err)
+
+	// Keys are namespaced.
+	dedup.Put("key", "dedup")
+	enrichment.Put("key", "enrichment")
+	v, ok := dedup.Get("key")
+	require.True(t, ok)
+	assert.Equal(t, "dedup", v)
+	v, ok = enrichment.Get("key")
+	require.True(t, ok)
+	assert.Equal(t, "enrichment", v)
+
+	// The dedup quota evicts its least recently used entry.
+	dedup.Put("a", 1)
+	dedup.Put("b", 2)
+	_, ok = dedup.Get("key")
+	require.True(t, ok)
+	dedup.Put("c", 3)
+	assert.Equal(t, 3, dedup.Len())
+	_, ok = dedup.Get("a")
+	assert.False(t, ok)
+	for _, k := range []string{"key", "b", "c"} {
+		_, ok = dedup.Get(k)
+		assert.True(t, ok, k)
+	}
+
+	_, ok = enrichment.Get("key")
+	require.True(t, ok)
+
+	// The entries of dedup are not evicted by enrichment while the cache is not full.
+	for i := 0; i < 6; i++ {
+		enrichment.Put(fmt.Sprint(i), i)
+	}
+	assert.Equal(t, 3, dedup.Len())
+	assert.Equal(t, 7, enrichment.Len())
+
+	// Once the cache is full, its least recently used entry is evicted, whatever its namespace.
+	enrichment.Put("6", 6)
+	assert.Equal(t, 2, dedup.Len())
+	assert.Equal(t, 8, enrichment.Len())
+	_, ok = dedup.Get("key")
+	assert.False(t, ok)
+
+	dedup.Delete("b")
+	assert.Equal(t, 1, dedup.Len())
+	enrichment.Put("7", 7)
+	assert.Equal(t, 1, dedup.Len())
+	assert.Equal(t, 9, enrichment.Len())
+}
+
+func TestSharedCacheUpdate(t *testing.T) {
+	host, id := newHost(t, &Config{MaxEntries: 2})
+	c, err := GetCache(host, id, "ns")
+	require.NoError(t, err)
+
+	c.Put("a", 1)
+	c.Put("b", 2)
+	// Updating an entry makes it the most recently used one, without eviction.
+	c.Put("a", 3)
+	assert.Equal(t, 2, c.Len())
+	c.Put("c", 4)
+	_, ok := c.Get("b")
+	assert.False(t, ok)
+	v, ok := c.Get("a")
+	require.True(t, ok)
+	assert.Equal(t, 3, v)
+}
+
+func TestSharedCacheConcurrent(t *testing.T) {
+	host, id := newHost(t, &Config{MaxEntries: 100, Namespaces: map[string]NamespaceConfig{"ns0": {MaxEntries: 10}}})
+	var wg sync.WaitGroup
+	for n := 0; n < 4; n++ {
+		c, err := GetCache(host, id, fmt.Sprintf("ns%d", n))
+		require.NoError(t, err)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				c.Put(fmt.Sprint(i%50), i)
+				c.Get(fmt.Sprint(i % 30))
+			}
+		}()
+	}
+	wg.Wait()
+	total := 0
+	for n := 0; n < 4; n++ {
+		c, err := GetCache(host, id, fmt.Sprintf("ns%d", n))
+		require.NoError(t, err)
+		total += c.Len()
+		if n == 0 {
+			assert.LessOrEqual(t, c.Len(), 10)
+		}
+	}
+	assert.LessOrEqual(t, total, 100)
+}
+
+func TestGetCacheErrors(t *testing.T) {
+	host, _ := newHost(t, &Config{MaxEntries: 1})
+	_, err := GetCache(host, component.MustNewIDWithName("cache", "missing"), "ns")
+	require.EqualError(t, err, `cache extension "cache/missing" not found`)
+
+	other := component.MustNewID("other")
+	host = extensionsHost{Host: host, extensions: map[component.ID]component.Component{other: nopComponent{}}}
+	_, err = GetCache(host, other, "ns")
+	require.EqualError(t, err, `extension "other" is not a cache extension`)
+}
+
+func TestShutdownClearsCache(t *testing.T) {
+	ext := newCache(&Config{MaxEntries: 5})
+	c := ext.Namespace("ns")
+	c.Put("a", 1)
+	require.NoError(t, ext.Shutdown(context.Background()))
+	assert.Zero(t, c.Len())
+	_, ok := c.Get("a")
+	assert.False(t, ok)
+}
@@ -0,0 +1,44 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cacheextension // import "go.opentelemetry.io/collector/extension/cacheextension"
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// Config defines configuration for the cache extension.
+type Config struct {
+	// MaxEntries is the maximum number of entries of the cache, shared by all the namespaces.
+	// When it is reached, the least recently used entry of the cache is evicted.
+	MaxEntries int `mapstructure:"max_entries"`
+
+	// Namespaces sets the quota of the namespaces. When the MaxEntries of a namespace is reached,
+	// the least recently used entry of the namespace is evicted. The namespaces which are not
+	// listed are only limited by the MaxEntries of the cache.
+	Namespaces map[string]NamespaceConfig `mapstructure:"namespaces"`
+}
+
+// NamespaceConfig defines the quota of a namespace.
+type NamespaceConfig struct {
+	// MaxEntries is the maximum number of entries of the namespace.
+	MaxEntries int `mapstructure:"max_entries"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the extension configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.MaxEntries <= 0 {
+		return errors.New("max_entries must be greater than 0")
+	}
+	for name, ns := range cfg.Namespaces {
+		if ns.MaxEntries <= 0 || ns.MaxEntries > cfg.MaxEntries {
+			return fmt.Errorf("namespaces::%s::max_entries must be greater than 0 and not greater than max_entries", name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cacheextension
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestUnmarshalConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	cfg := NewFactory().CreateDefaultConfig()
+	require.NoError(t, cm.Unmarshal(&cfg))
+	assert.Equal(t, &Config{
+		MaxEntries: 1000,
+		Namespaces: map[string]NamespaceConfig{
+			"dedup":      {MaxEntries: 200},
+			"enrichment": {MaxEntries: 500},
+		},
+	}, cfg)
+	assert.NoError(t, component.ValidateConfig(cfg))
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		cfg    *Config
+		expErr string
+	}{
+		{
+			name:   "no max entries",
+			cfg:    &Config{},
+			expErr: "max_entries must be greater than 0",
+		},
+		{
+			name: "namespace quota above max entries",
+			cfg: &Config{
+				MaxEntries: 10,
+				Namespaces: map[string]NamespaceConfig{"dedup": {MaxEntries: 11}},
+			},
+			expErr: "namespaces::dedup::max_entries must be greater than 0 and not greater than max_entries",
+		},
+		{
+			name: "no namespace quota",
+			cfg: &Config{
+				MaxEntries: 10,
+				Namespaces: map[string]NamespaceConfig{"dedup": {}},
+			},
+			expErr: "namespaces::dedup::max_entries must be greater than 0 and not greater than max_entries",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.EqualError(t, tt.cfg.Validate(), tt.expErr)
+		})
+	}
+}
@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cacheextension // import "go.opentelemetry.io/collector/extension/cacheextension"
+
+//go:generate mdatagen metadata.yaml
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/extension/cacheextension/internal/metadata"
+)
+
+const defaultMaxEntries = 10000
+
+// NewFactory returns a new factory for the cache extension.
+func NewFactory() extension.Factory {
+	return extension.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		createExtension,
+		metadata.ExtensionStability)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		MaxEntries: defaultMaxEntries,
+	}
+}
+
+func createExtension(_ context.Context, _ extension.Settings, cfg component.Config) (extension.Extension, error) {
+	return newCache(cfg.(*Config)), nil
+}
@@ -0,0 +1,45 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package queuesnapshotextension // import "go.opentelemetry.io/collector/extension/queuesnapshotextension"
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+)
+
+// Config defines configuration for the queue snapshot extension.
+type Config struct {
+	// Storage is the ID of the storage extension holding the entries of the queues.
+	Storage component.ID `mapstructure:"storage"`
+
+	// Directory is the directory the snapshots are written to, one file per storage client.
+	Directory string `mapstructure:"directory"`
+
+	// Remote is the HTTP client configuration of the object store the snapshots are uploaded to, with a PUT
+	// request to <endpoint>/<file name> for every storage client. The snapshots are not uploaded if its
+	// endpoint is empty.
+	Remote confighttp.ClientConfig `mapstructure:"remote"`
+
+	// Interval is the period between two snapshots.
+	Interval time.Duration `mapstructure:"interval"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the extension configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.Storage == (component.ID{}) {
+		return errors.New("storage must be set")
+	}
+	if cfg.Directory == "" && cfg.Remote.Endpoint == "" {
+		return errors.New("directory or remote endpoint must be set")
+	}
+	if cfg.Interval <= 0 {
+		return errors.New("interval must be greater than 0")
+	}
+	return nil
+}
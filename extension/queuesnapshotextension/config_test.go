@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package queuesnapshotextension
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestUnmarshalConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	cfg := NewFactory().CreateDefaultConfig()
+	require.NoError(t, cm.Unmarshal(&cfg))
+	remote := confighttp.NewDefaultClientConfig()
+	remote.Endpoint = "https://snapshots.example.com/otelcol"
+	assert.Equal(t, &Config{
+		Storage:   component.MustNewIDWithName("file_storage", "queue"),
+		Directory: "/var/lib/otelcol/snapshots",
+		Remote:    remote,
+		Interval:  5 * time.Minute,
+	}, cfg)
+	assert.NoError(t, component.ValidateConfig(cfg))
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		cfg    *Config
+		expErr string
+	}{
+		{
+			name:   "no storage",
+			cfg:    &Config{Directory: "snapshots", Interval: time.Minute},
+			expErr: "storage must be set",
+		},
+		{
+			name:   "no destination",
+			cfg:    &Config{Storage: component.MustNewID("file_storage"), Interval: time.Minute},
+			expErr: "directory or remote endpoint must be set",
+		},
+		{
+			name:   "no interval",
+			cfg:    &Config{Storage: component.MustNewID("file_storage"), Directory: "snapshots"},
+			expErr: "interval must be greater than 0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.EqualError(t, tt.cfg.Validate(), tt.expErr)
+		})
+	}
+}
@@ -0,0 +1,38 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package queuesnapshotextension // import "go.opentelemetry.io/collector/extension/queuesnapshotextension"
+
+//go:generate mdatagen metadata.yaml
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/extension/queuesnapshotextension/internal/metadata"
+)
+
+const defaultInterval = time.Minute
+
+// NewFactory returns a new factory for the queue snapshot extension.
+func NewFactory() extension.Factory {
+	return extension.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		createExtension,
+		metadata.ExtensionStability)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		Remote:   confighttp.NewDefaultClientConfig(),
+		Interval: defaultInterval,
+	}
+}
+
+func createExtension(_ context.Context, set extension.Settings, cfg component.Config) (extension.Extension, error) {
+	return newSnapshotter(cfg.(*Config), set.TelemetrySettings), nil
+}
@@ -0,0 +1,349 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package queuesnapshotextension // import "go.opentelemetry.io/collector/extension/queuesnapshotextension"
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/extension/extensioncapabilities"
+)
+
+// The keys and the encoding of the persistent queue of the exporterhelper, which the snapshots follow to
+// read the entries of the queues from the storage.
+const (
+	readIndexKey                = "ri"
+	writeIndexKey               = "wi"
+	currentlyDispatchedItemsKey = "di"
+	queueSizeKey                = "si"
+)
+
+// snapshot is the content of a snapshot file.
+type snapshot struct {
+	// Entries are the values of the keys of the storage client.
+	Entries map[string][]byte `json:"entries"`
+}
+
+// snapshotter is a storage extension passing the operations through to the configured storage
+// extension, and periodically writing the entries of the queues of every client to their snapshot.
+type snapshotter struct {
+	cfg       *Config
+	telemetry component.TelemetrySettings
+
+	storage storage.Extension
+	remote  *http.Client
+
+	mu      sync.Mutex
+	clients map[*client]struct{}
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+var (
+	_ storage.Extension               = (*snapshotter)(nil)
+	_ extensioncapabilities.Dependent = (*snapshotter)(nil)
+)
+
+func newSnapshotter(cfg *Config, set component.TelemetrySettings) *snapshotter {
+	return &snapshotter{
+		cfg:       cfg,
+		telemetry: set,
+		clients:   make(map[*client]struct{}),
+	}
+}
+
+func (s *snapshotter) Dependencies() []component.ID {
+	return []component.ID{s.cfg.Storage}
+}
+
+func (s *snapshotter) Start(ctx context.Context, host component.Host) error {
+	ext, ok := host.GetExtensions()[s.cfg.Storage]
+	if !ok {
+		return fmt.Errorf("storage extension %q not found", s.cfg.Storage)
+	}
+	s.storage, ok = ext.(storage.Extension)
+	if !ok {
+		return fmt.Errorf("extension %q is not a storage extension", s.cfg.Storage)
+	}
+	if s.cfg.Directory != "" {
+		if err := os.MkdirAll(s.cfg.Directory, 0o700); err != nil {
+			return fmt.Errorf("failed to create the snapshot directory: %w", err)
+		}
+	}
+	if s.cfg.Remote.Endpoint != "" {
+		remote, err := s.cfg.Remote.ToClient(ctx, host, s.telemetry)
+		if err != nil {
+			return fmt.Errorf("failed to create the remote snapshot client: %w", err)
+		}
+		s.remote = remote
+	}
+
+	s.stopCh = make(chan struct{})
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		ticker := time.NewTicker(s.cfg.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := s.snapshotAll(context.Background()); err != nil {
+					s.telemetry.Logger.Warn("Failed to write the queue snapshots", zap.Error(err))
+				}
+			case <-s.stopCh:
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+func (s *snapshotter) Shutdown(ctx context.Context) error {
+	if s.stopCh == nil {
+		return nil
+	}
+	close(s.stopCh)
+	s.wg.Wait()
+	err := s.snapshotAll(ctx)
+	if s.remote != nil {
+		s.remote.CloseIdleConnections()
+	}
+	return err
+}
+
+func (s *snapshotter) GetClient(ctx context.Context, kind component.Kind, id component.ID, name string) (storage.Client, error) {
+	if s.storage == nil {
+		return nil, errors.New("queue snapshot extension is not started")
+	}
+	c, err := s.storage.GetClient(ctx, kind, id, name)
+	if err != nil {
+		return nil, err
+	}
+	sc := &client{
+		Client:   c,
+		owner:    s,
+		filename: snapshotFilename(kind, id, name),
+	}
+	s.mu.Lock()
+	s.clients[sc] = struct{}{}
+	s.mu.Unlock()
+	return sc, nil
+}
+
+// snapshotAll writes the snapshot of every client.
+func (s *snapshotter) snapshotAll(ctx context.Context) error {
+	s.mu.Lock()
+	clients := make([]*client, 0, len(s.clients))
+	for c := range s.clients {
+		clients = append(clients, c)
+	}
+	s.mu.Unlock()
+
+	var errs error
+	for _, c := range clients {
+		errs = errors.Join(errs, c.snapshot(ctx))
+	}
+	return errs
+}
+
+// write writes the snapshot data to the snapshot directory and uploads it to the remote store, if configured.
+func (s *snapshotter) write(ctx context.Context, filename string, data []byte) error {
+	var errs error
+	if s.cfg.Directory != "" {
+		errs = writeFile(filepath.Join(s.cfg.Directory, filename), data)
+	}
+	if s.remote != nil {
+		errs = errors.Join(errs, s.upload(ctx, filename, data))
+	}
+	return errs
+}
+
+// upload sends the snapshot data with a PUT request to <endpoint>/<filename>.
+func (s *snapshotter) upload(ctx context.Context, filename string, data []byte) error {
+	url := strings.TrimSuffix(s.cfg.Remote.Endpoint, "/") + "/" + filename
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.remote.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to upload the snapshot %q: %w", filename, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("failed to upload the snapshot %q: %s", filename, resp.Status)
+	}
+	return nil
+}
+
+// writeFile replaces the file atomically, so a failing write leaves the previous snapshot in place.
+func writeFile(filename string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(filename), filepath.Base(filename)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), filename)
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+	}
+	return err
+}
+
+// snapshotFilename returns the name of the snapshot file of a client, e.g. exporter_otlp_traces.json.
+func snapshotFilename(kind component.Kind, id component.ID, name string) string {
+	parts := []string{strings.ToLower(kind.String()), id.String()}
+	if name != "" {
+		parts = append(parts, name)
+	}
+	return strings.ReplaceAll(strings.Join(parts, "_"), string(filepath.Separator), "_") + ".json"
+}
+
+// client is a storage client whose queue entries are read from the storage to be written to its snapshot.
+type client struct {
+	storage.Client
+	owner    *snapshotter
+	filename string
+
+	// mu prevents reading the entries of the client while it is closed.
+	mu     sync.Mutex
+	closed bool
+}
+
+// Close writes the last snapshot of the client before closing it.
+func (c *client) Close(ctx context.Context) error {
+	c.owner.mu.Lock()
+	delete(c.owner.clients, c)
+	c.owner.mu.Unlock()
+	err := c.snapshot(ctx)
+	c.mu.Lock()
+	c.closed = true
+	c.mu.Unlock()
+	return errors.Join(err, c.Client.Close(ctx))
+}
+
+// snapshot writes the entries of the queue of the client to its snapshot.
+func (c *client) snapshot(ctx context.Context) error {
+	entries, err := c.entries(ctx)
+	if err != nil || entries == nil {
+		return err
+	}
+	data, err := json.Marshal(snapshot{Entries: entries})
+	if err != nil {
+		return err
+	}
+	return c.owner.write(ctx, c.filename, data)
+}
+
+// entries reads from the storage the indexes of the queue, its pending items, from the read index to the write
+// index, and its dispatched items. They include the items written before the collector started. The items
+// consumed while the entries are read are left out. It returns nil if the client is closed.
+func (c *client) entries(ctx context.Context) (map[string][]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return nil, nil
+	}
+
+	indexOps := []storage.Operation{
+		storage.GetOperation(readIndexKey),
+		storage.GetOperation(writeIndexKey),
+		storage.GetOperation(currentlyDispatchedItemsKey),
+		storage.GetOperation(queueSizeKey),
+	}
+	if err := c.Client.Batch(ctx, indexOps...); err != nil {
+		return nil, err
+	}
+	entries := make(map[string][]byte)
+	for _, op := range indexOps {
+		if op.Value != nil {
+			entries[op.Key] = op.Value
+		}
+	}
+
+	readIndex, writeIndex := bytesToIndex(indexOps[0].Value), bytesToIndex(indexOps[1].Value)
+	var itemOps []storage.Operation
+	for _, index := range bytesToIndexes(indexOps[2].Value) {
+		itemOps = append(itemOps, storage.GetOperation(strconv.FormatUint(index, 10)))
+	}
+	for index := readIndex; index < writeIndex; index++ {
+		itemOps = append(itemOps, storage.GetOperation(strconv.FormatUint(index, 10)))
+	}
+	if len(itemOps) > 0 {
+		if err := c.Client.Batch(ctx, itemOps...); err != nil {
+			return nil, err
+		}
+	}
+	for _, op := range itemOps {
+		if op.Value != nil {
+			entries[op.Key] = op.Value
+		}
+	}
+	return entries, nil
+}
+
+// bytesToIndex decodes an index of the queue, 0 if it is not set or invalid.
+func bytesToIndex(buf []byte) uint64 {
+	if len(buf) < 8 {
+		return 0
+	}
+	return binary.LittleEndian.Uint64(buf)
+}
+
+// bytesToIndexes decodes the indexes of the dispatched items: their count followed by the indexes.
+func bytesToIndexes(buf []byte) []uint64 {
+	if len(buf) < 4 {
+		return nil
+	}
+	size := int(binary.LittleEndian.Uint32(buf))
+	buf = buf[4:]
+	if len(buf) < size*8 {
+		return nil
+	}
+	indexes := make([]uint64, size)
+	for i := range indexes {
+		indexes[i] = binary.LittleEndian.Uint64(buf[i*8:])
+	}
+	return indexes
+}
+
+// Restore sets the entries of the snapshot file into the storage client, e.g. to restore the queue of
+// an exporter from its last snapshot before starting the collector.
+func Restore(ctx context.Context, filename string, c storage.Client) error {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	var snap snapshot
+	if err = json.Unmarshal(data, &snap); err != nil {
+		return fmt.Errorf("invalid snapshot file %q: %w", filename, err)
+	}
+	ops := make([]storage.Operation, 0, len(snap.Entries))
+	for key, value := range snap.Entries {
+		ops = append(ops, storage.SetOperation(key, value))
+	}
+	return c.Batch(ctx, ops...)
+}
@@ -0,0 +1,271 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package queuesnapshotextension
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+)
+
+type extensionsHost struct {
+	component.Host
+	extensions map[component.ID]component.Component
+}
+
+func (h extensionsHost) GetExtensions() map[component.ID]component.Component {
+	return h.extensions
+}
+
+type nopComponent struct {
+	component.StartFunc
+	component.ShutdownFunc
+}
+
+// memStorage is a storage extension holding the entries of its clients in memory.
+type memStorage struct {
+	component.StartFunc
+	component.ShutdownFunc
+	clients map[string]*memClient
+}
+
+func (s memStorage) GetClient(_ context.Context, kind component.Kind, id component.ID, name string) (storage.Client, error) {
+	key := snapshotFilename(kind, id, name)
+	if s.clients[key] == nil {
+		s.clients[key] = &memClient{entries: map[string][]byte{}}
+	}
+	return s.clients[key], nil
+}
+
+type memClient struct {
+	mu      sync.Mutex
+	entries map[string][]byte
+}
+
+func (c *memClient) Get(_ context.Context, key string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.entries[key], nil
+}
+
+func (c *memClient) Set(_ context.Context, key string, value []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = value
+	return nil
+}
+
+func (c *memClient) Delete(_ context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, key)
+	return nil
+}
+
+func (c *memClient) Batch(ctx context.Context, ops ...storage.Operation) error {
+	for _, op := range ops {
+		switch op.Type {
+		case storage.Get:
+			op.Value, _ = c.Get(ctx, op.Key)
+		case storage.Set:
+			_ = c.Set(ctx, op.Key, op.Value)
+		case storage.Delete:
+			_ = c.Delete(ctx, op.Key)
+		}
+	}
+	return nil
+}
+
+func (c *memClient) Close(context.Context) error {
+	return nil
+}
+
+func index(i uint64) []byte {
+	return binary.LittleEndian.AppendUint64(nil, i)
+}
+
+func newSnapshotExtension(t *testing.T, cfg *Config, clients map[string]*memClient) *snapshotter {
+	cfg.Storage = component.MustNewID("memory_storage")
+	ext, err := NewFactory().CreateExtension(context.Background(), extensiontest.NewNopSettings(), cfg)
+	require.NoError(t, err)
+	host := extensionsHost{
+		Host:       componenttest.NewNopHost(),
+		extensions: map[component.ID]component.Component{cfg.Storage: memStorage{clients: clients}},
+	}
+	require.NoError(t, ext.Start(context.Background(), host))
+	return ext.(*snapshotter)
+}
+
+func readSnapshot(t *testing.T, filename string) map[string][]byte {
+	data, err := os.ReadFile(filename)
+	require.NoError(t, err)
+	var snap snapshot
+	require.NoError(t, json.Unmarshal(data, &snap))
+	return snap.Entries
+}
+
+func TestSnapshotPendingEntries(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "snapshots")
+	// The items 1 and 2 were written by a previous run, the item 0 being dispatched.
+	clients := map[string]*memClient{"exporter_otlp_backup_traces.json": {entries: map[string][]byte{
+		"0":  []byte("item 0"),
+		"1":  []byte("item 1"),
+		"2":  []byte("item 2"),
+		"ri": index(1),
+		"wi": index(3),
+		"di": binary.LittleEndian.AppendUint64(binary.LittleEndian.AppendUint32(nil, 1), 0),
+		// Not an item of the queue.
+		"other": []byte("other"),
+	}}}
+	ext := newSnapshotExtension(t, &Config{Directory: dir, Interval: 10 * time.Millisecond}, clients)
+	ctx := context.Background()
+	client, err := ext.GetClient(ctx, component.KindExporter, component.MustNewIDWithName("otlp", "backup"), "traces")
+	require.NoError(t, err)
+
+	filename := filepath.Join(dir, "exporter_otlp_backup_traces.json")
+	want := map[string][]byte{
+		"0":  []byte("item 0"),
+		"1":  []byte("item 1"),
+		"2":  []byte("item 2"),
+		"ri": index(1),
+		"wi": index(3),
+		"di": binary.LittleEndian.AppendUint64(binary.LittleEndian.AppendUint32(nil, 1), 0),
+	}
+	assert.EventuallyWithT(t, func(tt *assert.CollectT) {
+		data, err := os.ReadFile(filename)
+		if !assert.NoError(tt, err) {
+			return
+		}
+		var snap snapshot
+		assert.NoError(tt, json.Unmarshal(data, &snap))
+		assert.Equal(tt, want, snap.Entries)
+	}, time.Second, 10*time.Millisecond)
+
+	// The dispatched item 0 is consumed, and the item 3 written.
+	require.NoError(t, client.Batch(ctx,
+		storage.DeleteOperation("0"),
+		storage.SetOperation("di", binary.LittleEndian.AppendUint32(nil, 0)),
+		storage.SetOperation("3", []byte("item 3")),
+		storage.SetOperation("wi", index(4)),
+	))
+	require.NoError(t, ext.Shutdown(ctx))
+	assert.Equal(t, map[string][]byte{
+		"1":  []byte("item 1"),
+		"2":  []byte("item 2"),
+		"3":  []byte("item 3"),
+		"ri": index(1),
+		"wi": index(4),
+		"di": binary.LittleEndian.AppendUint32(nil, 0),
+	}, readSnapshot(t, filename))
+}
+
+func TestSnapshotOnClose(t *testing.T) {
+	dir := t.TempDir()
+	ext := newSnapshotExtension(t, &Config{Directory: dir, Interval: time.Hour}, map[string]*memClient{})
+	ctx := context.Background()
+	client, err := ext.GetClient(ctx, component.KindExporter, component.MustNewID("otlp"), "logs")
+	require.NoError(t, err)
+	require.NoError(t, client.Batch(ctx, storage.SetOperation("0", []byte("item 0")), storage.SetOperation("wi", index(1))))
+	require.NoError(t, client.Close(ctx))
+	require.NoError(t, ext.Shutdown(ctx))
+
+	assert.Equal(t, map[string][]byte{"0": []byte("item 0"), "wi": index(1)}, readSnapshot(t, filepath.Join(dir, "exporter_otlp_logs.json")))
+}
+
+func TestSnapshotRemote(t *testing.T) {
+	var mu sync.Mutex
+	uploads := map[string][]byte{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		mu.Lock()
+		uploads[r.URL.Path] = body
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	remote := confighttp.NewDefaultClientConfig()
+	remote.Endpoint = server.URL + "/snapshots/"
+	ext := newSnapshotExtension(t, &Config{Remote: remote, Interval: time.Hour}, map[string]*memClient{})
+	ctx := context.Background()
+	client, err := ext.GetClient(ctx, component.KindExporter, component.MustNewID("otlp"), "metrics")
+	require.NoError(t, err)
+	require.NoError(t, client.Batch(ctx, storage.SetOperation("0", []byte("item 0")), storage.SetOperation("wi", index(1))))
+	require.NoError(t, ext.Shutdown(ctx))
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Contains(t, uploads, "/snapshots/exporter_otlp_metrics.json")
+	var snap snapshot
+	require.NoError(t, json.Unmarshal(uploads["/snapshots/exporter_otlp_metrics.json"], &snap))
+	assert.Equal(t, map[string][]byte{"0": []byte("item 0"), "wi": index(1)}, snap.Entries)
+}
+
+func TestSnapshotRemoteError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	remote := confighttp.NewDefaultClientConfig()
+	remote.Endpoint = server.URL
+	ext := newSnapshotExtension(t, &Config{Remote: remote, Interval: time.Hour}, map[string]*memClient{})
+	_, err := ext.GetClient(context.Background(), component.KindExporter, component.MustNewID("otlp"), "metrics")
+	require.NoError(t, err)
+	require.EqualError(t, ext.Shutdown(context.Background()), `failed to upload the snapshot "exporter_otlp_metrics.json": 403 Forbidden`)
+}
+
+func TestRestore(t *testing.T) {
+	dir := t.TempDir()
+	ext := newSnapshotExtension(t, &Config{Directory: dir, Interval: time.Hour}, map[string]*memClient{})
+	ctx := context.Background()
+	client, err := ext.GetClient(ctx, component.KindExporter, component.MustNewID("otlp"), "metrics")
+	require.NoError(t, err)
+	require.NoError(t, client.Set(ctx, "0", []byte("item 0")))
+	require.NoError(t, client.Set(ctx, "wi", index(1)))
+	require.NoError(t, ext.Shutdown(ctx))
+
+	restored := &memClient{entries: map[string][]byte{}}
+	require.NoError(t, Restore(ctx, filepath.Join(dir, "exporter_otlp_metrics.json"), restored))
+	assert.Equal(t, map[string][]byte{"0": []byte("item 0"), "wi": index(1)}, restored.entries)
+}
+
+func TestStartErrors(t *testing.T) {
+	storageID := component.MustNewID("memory_storage")
+	ext := newSnapshotter(&Config{Storage: storageID, Directory: t.TempDir(), Interval: time.Minute}, componenttest.NewNopTelemetrySettings())
+	assert.Equal(t, []component.ID{storageID}, ext.Dependencies())
+
+	_, err := ext.GetClient(context.Background(), component.KindExporter, component.MustNewID("otlp"), "traces")
+	require.EqualError(t, err, "queue snapshot extension is not started")
+
+	err = ext.Start(context.Background(), componenttest.NewNopHost())
+	require.EqualError(t, err, `storage extension "memory_storage" not found`)
+
+	err = ext.Start(context.Background(), extensionsHost{
+		Host:       componenttest.NewNopHost(),
+		extensions: map[component.ID]component.Component{storageID: nopComponent{}},
+	})
+	require.EqualError(t, err, `extension "memory_storage" is not a storage extension`)
+}
@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fanoutconsumer // import "go.opentelemetry.io/collector/internal/fanoutconsumer"
+
+import (
+	"sync"
+
+	"go.uber.org/multierr"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+)
+
+// Delivery is the way a fan-out consumer delivers the data to its consumers.
+type Delivery int
+
+const (
+	// DeliveryDefault delivers the data to the consumers mutating it first, then to the other
+	// consumers, one consumer at a time.
+	DeliveryDefault Delivery = iota
+	// DeliverySequential delivers the data to the consumers in their declared order, one consumer at a time.
+	DeliverySequential
+	// DeliveryConcurrent delivers the data to all the consumers at the same time, and waits for all of
+	// them to return.
+	DeliveryConcurrent
+)
+
+// Config configures the delivery of the data by a fan-out consumer.
+type Config struct {
+	// Delivery is the way the data is delivered to the consumers.
+	Delivery Delivery
+	// StopOnPermanentError stops the DeliverySequential delivery at the first consumer returning a
+	// permanent error, the following consumers not receiving the data.
+	StopOnPermanentError bool
+}
+
+// deliver calls consume for every consumer in their declared order, or concurrently if configured
+// so, and returns the combined errors.
+func (cfg Config) deliver(n int, consume func(i int) error) error {
+	if cfg.Delivery == DeliveryConcurrent {
+		errs := make([]error, n)
+		var wg sync.WaitGroup
+		wg.Add(n)
+		for i := 0; i < n; i++ {
+			go func(i int) {
+				defer wg.Done()
+				errs[i] = consume(i)
+			}(i)
+		}
+		wg.Wait()
+		return multierr.Combine(errs...)
+	}
+
+	var errs error
+	for i := 0; i < n; i++ {
+		err := consume(i)
+		errs = multierr.Append(errs, err)
+		if err != nil && cfg.StopOnPermanentError && consumererror.IsPermanent(err) {
+			break
+		}
+	}
+	return errs
+}
+
+// originalIndex returns the index of the consumer receiving the original data with the DeliverySequential
+// and DeliveryConcurrent deliveries, or -1 if all the consumers mutating the data receive a copy. As with the
+// DeliveryDefault delivery, the last consumer mutating the data receives the original data only if no other
+// consumer receives it and the data is mutable.
+func originalIndex(mutates []bool, readOnlyData bool) int {
+	if readOnlyData {
+		return -1
+	}
+	last := -1
+	for i, m := range mutates {
+		if !m {
+			return -1
+		}
+		last = i
+	}
+	return last
+}
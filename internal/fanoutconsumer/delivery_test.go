@@ -0,0 +1,230 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fanoutconsumer
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumerprofiles"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/testdata"
+)
+
+// deliveryRecorder records the order in which its consumers receive the data.
+type deliveryRecorder struct {
+	mu    sync.Mutex
+	order []string
+}
+
+func (dr *deliveryRecorder) record(name string) {
+	dr.mu.Lock()
+	defer dr.mu.Unlock()
+	dr.order = append(dr.order, name)
+}
+
+func (dr *deliveryRecorder) traces(t *testing.T, name string, mutates bool, err error) consumer.Traces {
+	tc, cerr := consumer.NewTraces(func(context.Context, ptrace.Traces) error {
+		dr.record(name)
+		return err
+	}, consumer.WithCapabilities(consumer.Capabilities{MutatesData: mutates}))
+	require.NoError(t, cerr)
+	return tc
+}
+
+func TestTracesSequentialDelivery(t *testing.T) {
+	dr := &deliveryRecorder{}
+	var received []ptrace.Traces
+	var mu sync.Mutex
+	receive := func(mutates bool) consumer.Traces {
+		tc, err := consumer.NewTraces(func(_ context.Context, td ptrace.Traces) error {
+			mu.Lock()
+			defer mu.Unlock()
+			received = append(received, td)
+			return nil
+		}, consumer.WithCapabilities(consumer.Capabilities{MutatesData: mutates}))
+		require.NoError(t, err)
+		return tc
+	}
+	tfc := NewTracesWithConfig([]consumer.Traces{
+		dr.traces(t, "readonly1", false, nil),
+		dr.traces(t, "mutating1", true, nil),
+		dr.traces(t, "readonly2", false, nil),
+		dr.traces(t, "mutating2", true, nil),
+		receive(true),
+		receive(false),
+	}, Config{Delivery: DeliverySequential})
+	assert.False(t, tfc.Capabilities().MutatesData)
+
+	td := testdata.GenerateTraces(1)
+	require.NoError(t, tfc.ConsumeTraces(context.Background(), td))
+	assert.Equal(t, []string{"readonly1", "mutating1", "readonly2", "mutating2"}, dr.order)
+	// The mutating consumer receives a copy since the data is shared with read-only consumers.
+	require.Len(t, received, 2)
+	assert.False(t, td == received[0])
+	assert.Equal(t, testdata.GenerateTraces(1), received[0])
+	assert.True(t, td == received[1])
+	assert.True(t, td.IsReadOnly())
+}
+
+func TestTracesSequentialDeliveryAllMutating(t *testing.T) {
+	p1 := &mutatingTracesSink{TracesSink: new(consumertest.TracesSink)}
+	p2 := &mutatingTracesSink{TracesSink: new(consumertest.TracesSink)}
+	tfc := NewTracesWithConfig([]consumer.Traces{p1, p2}, Config{Delivery: DeliverySequential})
+	assert.True(t, tfc.Capabilities().MutatesData)
+
+	td := testdata.GenerateTraces(1)
+	require.NoError(t, tfc.ConsumeTraces(context.Background(), td))
+	// Only the last consumer receives the original data.
+	assert.False(t, td == p1.AllTraces()[0])
+	assert.EqualValues(t, td, p1.AllTraces()[0])
+	assert.True(t, td == p2.AllTraces()[0])
+	assert.False(t, td.IsReadOnly())
+}
+
+func TestTracesSequentialDeliveryErrors(t *testing.T) {
+	permanent := consumererror.NewPermanent(errors.New("permanent"))
+	retryable := errors.New("retryable")
+	tests := []struct {
+		name                 string
+		err                  error
+		stopOnPermanentError bool
+		wantOrder            []string
+	}{
+		{
+			name:                 "stop_on_permanent_error",
+			err:                  permanent,
+			stopOnPermanentError: true,
+			wantOrder:            []string{"first", "failing"},
+		},
+		{
+			name:                 "continue_on_retryable_error",
+			err:                  retryable,
+			stopOnPermanentError: true,
+			wantOrder:            []string{"first", "failing", "last"},
+		},
+		{
+			name:      "continue_on_permanent_error",
+			err:       permanent,
+			wantOrder: []string{"first", "failing", "last"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dr := &deliveryRecorder{}
+			tfc := NewTracesWithConfig([]consumer.Traces{
+				dr.traces(t, "first", false, nil),
+				dr.traces(t, "failing", true, tt.err),
+				dr.traces(t, "last", false, nil),
+			}, Config{Delivery: DeliverySequential, StopOnPermanentError: tt.stopOnPermanentError})
+			err := tfc.ConsumeTraces(context.Background(), testdata.GenerateTraces(1))
+			require.ErrorIs(t, err, tt.err)
+			assert.Equal(t, tt.wantOrder, dr.order)
+		})
+	}
+}
+
+func TestTracesConcurrentDelivery(t *testing.T) {
+	const consumers = 3
+	// Every consumer waits for all the others to receive the data, which only
+	// returns if the data is delivered to them concurrently.
+	var arrived sync.WaitGroup
+	arrived.Add(consumers)
+	errFailing := errors.New("failing")
+	var tcs []consumer.Traces
+	for i := 0; i < consumers; i++ {
+		var err error
+		if i == 0 {
+			err = errFailing
+		}
+		tc, cerr := consumer.NewTraces(func(context.Context, ptrace.Traces) error {
+			arrived.Done()
+			arrived.Wait()
+			return err
+		}, consumer.WithCapabilities(consumer.Capabilities{MutatesData: i%2 == 0}))
+		require.NoError(t, cerr)
+		tcs = append(tcs, tc)
+	}
+	tfc := NewTracesWithConfig(tcs, Config{Delivery: DeliveryConcurrent, StopOnPermanentError: true})
+
+	done := make(chan error)
+	go func() {
+		done <- tfc.ConsumeTraces(context.Background(), testdata.GenerateTraces(1))
+	}()
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, errFailing)
+	case <-time.After(10 * time.Second):
+		t.Fatal("the consumers did not receive the data concurrently")
+	}
+}
+
+func TestMetricsSequentialDelivery(t *testing.T) {
+	dr := &deliveryRecorder{}
+	newConsumer := func(name string, mutates bool) consumer.Metrics {
+		mc, err := consumer.NewMetrics(func(context.Context, pmetric.Metrics) error {
+			dr.record(name)
+			return nil
+		}, consumer.WithCapabilities(consumer.Capabilities{MutatesData: mutates}))
+		require.NoError(t, err)
+		return mc
+	}
+	mfc := NewMetricsWithConfig([]consumer.Metrics{
+		newConsumer("readonly", false),
+		newConsumer("mutating", true),
+		newConsumer("last", false),
+	}, Config{Delivery: DeliverySequential})
+	require.NoError(t, mfc.ConsumeMetrics(context.Background(), testdata.GenerateMetrics(1)))
+	assert.Equal(t, []string{"readonly", "mutating", "last"}, dr.order)
+}
+
+func TestLogsSequentialDelivery(t *testing.T) {
+	dr := &deliveryRecorder{}
+	newConsumer := func(name string, mutates bool) consumer.Logs {
+		lc, err := consumer.NewLogs(func(context.Context, plog.Logs) error {
+			dr.record(name)
+			return nil
+		}, consumer.WithCapabilities(consumer.Capabilities{MutatesData: mutates}))
+		require.NoError(t, err)
+		return lc
+	}
+	lfc := NewLogsWithConfig([]consumer.Logs{
+		newConsumer("readonly", false),
+		newConsumer("mutating", true),
+		newConsumer("last", false),
+	}, Config{Delivery: DeliverySequential})
+	require.NoError(t, lfc.ConsumeLogs(context.Background(), testdata.GenerateLogs(1)))
+	assert.Equal(t, []string{"readonly", "mutating", "last"}, dr.order)
+}
+
+func TestProfilesSequentialDelivery(t *testing.T) {
+	dr := &deliveryRecorder{}
+	newConsumer := func(name string, mutates bool) consumerprofiles.Profiles {
+		pc, err := consumerprofiles.NewProfiles(func(context.Context, pprofile.Profiles) error {
+			dr.record(name)
+			return nil
+		}, consumer.WithCapabilities(consumer.Capabilities{MutatesData: mutates}))
+		require.NoError(t, err)
+		return pc
+	}
+	pfc := NewProfilesWithConfig([]consumerprofiles.Profiles{
+		newConsumer("readonly", false),
+		newConsumer("mutating", true),
+		newConsumer("last", false),
+	}, Config{Delivery: DeliverySequential})
+	require.NoError(t, pfc.ConsumeProfiles(context.Background(), testdata.GenerateProfiles(1)))
+	assert.Equal(t, []string{"readonly", "mutating", "last"}, dr.order)
+}
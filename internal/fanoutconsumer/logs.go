@@ -19,14 +19,20 @@ import (
 //   - Clones only to the consumer that needs to mutate the data.
 //   - If all consumers needs to mutate the data one will get the original mutable data.
 func NewLogs(lcs []consumer.Logs) consumer.Logs {
+	return NewLogsWithConfig(lcs, Config{})
+}
+
+// NewLogsWithConfig is like NewLogs, delivering the data to the consumers as configured by cfg.
+func NewLogsWithConfig(lcs []consumer.Logs, cfg Config) consumer.Logs {
 	// Don't wrap if there is only one non-mutating consumer.
 	if len(lcs) == 1 && !lcs[0].Capabilities().MutatesData {
 		return lcs[0]
 	}
 
-	lc := &logsConsumer{}
+	lc := &logsConsumer{cfg: cfg, all: lcs, mutates: make([]bool, len(lcs))}
 	for i := 0; i < len(lcs); i++ {
-		if lcs[i].Capabilities().MutatesData {
+		lc.mutates[i] = lcs[i].Capabilities().MutatesData
+		if lc.mutates[i] {
 			lc.mutable = append(lc.mutable, lcs[i])
 		} else {
 			lc.readonly = append(lc.readonly, lcs[i])
@@ -38,6 +44,11 @@ func NewLogs(lcs []consumer.Logs) consumer.Logs {
 type logsConsumer struct {
 	mutable  []consumer.Logs
 	readonly []consumer.Logs
+
+	cfg Config
+	// all are the consumers in their declared order, and mutates whether each of them mutates the data.
+	all     []consumer.Logs
+	mutates []bool
 }
 
 func (lsc *logsConsumer) Capabilities() consumer.Capabilities {
@@ -47,6 +58,10 @@ func (lsc *logsConsumer) Capabilities() consumer.Capabilities {
 
 // ConsumeLogs exports the plog.Logs to all consumers wrapped by the current one.
 func (lsc *logsConsumer) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	if lsc.cfg.Delivery != DeliveryDefault {
+		return lsc.consumeInOrder(ctx, ld)
+	}
+
 	var errs error
 
 	if len(lsc.mutable) > 0 {
@@ -76,6 +91,27 @@ func (lsc *logsConsumer) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
 	return errs
 }
 
+// consumeInOrder delivers the plog.Logs to the consumers in their declared order or concurrently, copying the
+// data for the consumers mutating it before any delivery.
+func (lsc *logsConsumer) consumeInOrder(ctx context.Context, ld plog.Logs) error {
+	original := originalIndex(lsc.mutates, ld.IsReadOnly())
+	data := make([]plog.Logs, len(lsc.all))
+	for i := range lsc.all {
+		if lsc.mutates[i] && i != original {
+			data[i] = cloneLogs(ld)
+		} else {
+			data[i] = ld
+		}
+	}
+	// Mark the data as read-only if it will be sent to more than one read-only consumer.
+	if len(lsc.readonly) > 1 && !ld.IsReadOnly() {
+		ld.MarkReadOnly()
+	}
+	return lsc.cfg.deliver(len(lsc.all), func(i int) error {
+		return lsc.all[i].ConsumeLogs(ctx, data[i])
+	})
+}
+
 func cloneLogs(ld plog.Logs) plog.Logs {
 	clonedLogs := plog.NewLogs()
 	ld.CopyTo(clonedLogs)
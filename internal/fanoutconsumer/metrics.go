@@ -17,14 +17,20 @@ import (
 //   - Clones only to the consumer that needs to mutate the data.
 //   - If all consumers needs to mutate the data one will get the original mutable data.
 func NewMetrics(mcs []consumer.Metrics) consumer.Metrics {
+	return NewMetricsWithConfig(mcs, Config{})
+}
+
+// NewMetricsWithConfig is like NewMetrics, delivering the data to the consumers as configured by cfg.
+func NewMetricsWithConfig(mcs []consumer.Metrics, cfg Config) consumer.Metrics {
 	// Don't wrap if there is only one non-mutating consumer.
 	if len(mcs) == 1 && !mcs[0].Capabilities().MutatesData {
 		return mcs[0]
 	}
 
-	mc := &metricsConsumer{}
+	mc := &metricsConsumer{cfg: cfg, all: mcs, mutates: make([]bool, len(mcs))}
 	for i := 0; i < len(mcs); i++ {
-		if mcs[i].Capabilities().MutatesData {
+		mc.mutates[i] = mcs[i].Capabilities().MutatesData
+		if mc.mutates[i] {
 			mc.mutable = append(mc.mutable, mcs[i])
 		} else {
 			mc.readonly = append(mc.readonly, mcs[i])
@@ -36,6 +42,11 @@ func NewMetrics(mcs []consumer.Metrics) consumer.Metrics {
 type metricsConsumer struct {
 	mutable  []consumer.Metrics
 	readonly []consumer.Metrics
+
+	cfg Config
+	// all are the consumers in their declared order, and mutates whether each of them mutates the data.
+	all     []consumer.Metrics
+	mutates []bool
 }
 
 func (msc *metricsConsumer) Capabilities() consumer.Capabilities {
@@ -45,6 +56,10 @@ func (msc *metricsConsumer) Capabilities() consumer.Capabilities {
 
 // ConsumeMetrics exports the pmetric.Metrics to all consumers wrapped by the current one.
 func (msc *metricsConsumer) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	if msc.cfg.Delivery != DeliveryDefault {
+		return msc.consumeInOrder(ctx, md)
+	}
+
 	var errs error
 
 	if len(msc.mutable) > 0 {
@@ -74,6 +89,27 @@ func (msc *metricsConsumer) ConsumeMetrics(ctx context.Context, md pmetric.Metri
 	return errs
 }
 
+// consumeInOrder delivers the pmetric.Metrics to the consumers in their declared order or concurrently, copying the
+// data for the consumers mutating it before any delivery.
+func (msc *metricsConsumer) consumeInOrder(ctx context.Context, md pmetric.Metrics) error {
+	original := originalIndex(msc.mutates, md.IsReadOnly())
+	data := make([]pmetric.Metrics, len(msc.all))
+	for i := range msc.all {
+		if msc.mutates[i] && i != original {
+			data[i] = cloneMetrics(md)
+		} else {
+			data[i] = md
+		}
+	}
+	// Mark the data as read-only if it will be sent to more than one read-only consumer.
+	if len(msc.readonly) > 1 && !md.IsReadOnly() {
+		md.MarkReadOnly()
+	}
+	return msc.cfg.deliver(len(msc.all), func(i int) error {
+		return msc.all[i].ConsumeMetrics(ctx, data[i])
+	})
+}
+
 func cloneMetrics(md pmetric.Metrics) pmetric.Metrics {
 	clonedMetrics := pmetric.NewMetrics()
 	md.CopyTo(clonedMetrics)
@@ -18,14 +18,20 @@ import (
 //   - Clones only to the consumer that needs to mutate the data.
 //   - If all consumers needs to mutate the data one will get the original mutable data.
 func NewProfiles(tcs []consumerprofiles.Profiles) consumerprofiles.Profiles {
+	return NewProfilesWithConfig(tcs, Config{})
+}
+
+// NewProfilesWithConfig is like NewProfiles, delivering the data to the consumers as configured by cfg.
+func NewProfilesWithConfig(tcs []consumerprofiles.Profiles, cfg Config) consumerprofiles.Profiles {
 	// Don't wrap if there is only one non-mutating consumer.
 	if len(tcs) == 1 && !tcs[0].Capabilities().MutatesData {
 		return tcs[0]
 	}
 
-	tc := &profilesConsumer{}
+	tc := &profilesConsumer{cfg: cfg, all: tcs, mutates: make([]bool, len(tcs))}
 	for i := 0; i < len(tcs); i++ {
-		if tcs[i].Capabilities().MutatesData {
+		tc.mutates[i] = tcs[i].Capabilities().MutatesData
+		if tc.mutates[i] {
 			tc.mutable = append(tc.mutable, tcs[i])
 		} else {
 			tc.readonly = append(tc.readonly, tcs[i])
@@ -37,6 +43,11 @@ func NewProfiles(tcs []consumerprofiles.Profiles) consumerprofiles.Profiles {
 type profilesConsumer struct {
 	mutable  []consumerprofiles.Profiles
 	readonly []consumerprofiles.Profiles
+
+	cfg Config
+	// all are the consumers in their declared order, and mutates whether each of them mutates the data.
+	all     []consumerprofiles.Profiles
+	mutates []bool
 }
 
 func (tsc *profilesConsumer) Capabilities() consumer.Capabilities {
@@ -46,6 +57,10 @@ func (tsc *profilesConsumer) Capabilities() consumer.Capabilities {
 
 // ConsumeProfiles exports the pprofile.Profiles to all consumers wrapped by the current one.
 func (tsc *profilesConsumer) ConsumeProfiles(ctx context.Context, td pprofile.Profiles) error {
+	if tsc.cfg.Delivery != DeliveryDefault {
+		return tsc.consumeInOrder(ctx, td)
+	}
+
 	var errs error
 
 	if len(tsc.mutable) > 0 {
@@ -75,6 +90,27 @@ func (tsc *profilesConsumer) ConsumeProfiles(ctx context.Context, td pprofile.Pr
 	return errs
 }
 
+// consumeInOrder delivers the pprofile.Profiles to the consumers in their declared order or concurrently, copying the
+// data for the consumers mutating it before any delivery.
+func (tsc *profilesConsumer) consumeInOrder(ctx context.Context, td pprofile.Profiles) error {
+	original := originalIndex(tsc.mutates, td.IsReadOnly())
+	data := make([]pprofile.Profiles, len(tsc.all))
+	for i := range tsc.all {
+		if tsc.mutates[i] && i != original {
+			data[i] = cloneProfiles(td)
+		} else {
+			data[i] = td
+		}
+	}
+	// Mark the data as read-only if it will be sent to more than one read-only consumer.
+	if len(tsc.readonly) > 1 && !td.IsReadOnly() {
+		td.MarkReadOnly()
+	}
+	return tsc.cfg.deliver(len(tsc.all), func(i int) error {
+		return tsc.all[i].ConsumeProfiles(ctx, data[i])
+	})
+}
+
 func cloneProfiles(td pprofile.Profiles) pprofile.Profiles {
 	clonedProfiles := pprofile.NewProfiles()
 	td.CopyTo(clonedProfiles)
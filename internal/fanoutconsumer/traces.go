@@ -17,14 +17,20 @@ import (
 //   - Clones only to the consumer that needs to mutate the data.
 //   - If all consumers needs to mutate the data one will get the original mutable data.
 func NewTraces(tcs []consumer.Traces) consumer.Traces {
+	return NewTracesWithConfig(tcs, Config{})
+}
+
+// NewTracesWithConfig is like NewTraces, delivering the data to the consumers as configured by cfg.
+func NewTracesWithConfig(tcs []consumer.Traces, cfg Config) consumer.Traces {
 	// Don't wrap if there is only one non-mutating consumer.
 	if len(tcs) == 1 && !tcs[0].Capabilities().MutatesData {
 		return tcs[0]
 	}
 
-	tc := &tracesConsumer{}
+	tc := &tracesConsumer{cfg: cfg, all: tcs, mutates: make([]bool, len(tcs))}
 	for i := 0; i < len(tcs); i++ {
-		if tcs[i].Capabilities().MutatesData {
+		tc.mutates[i] = tcs[i].Capabilities().MutatesData
+		if tc.mutates[i] {
 			tc.mutable = append(tc.mutable, tcs[i])
 		} else {
 			tc.readonly = append(tc.readonly, tcs[i])
@@ -36,6 +42,11 @@ func NewTraces(tcs []consumer.Traces) consumer.Traces {
 type tracesConsumer struct {
 	mutable  []consumer.Traces
 	readonly []consumer.Traces
+
+	cfg Config
+	// all are the consumers in their declared order, and mutates whether each of them mutates the data.
+	all     []consumer.Traces
+	mutates []bool
 }
 
 func (tsc *tracesConsumer) Capabilities() consumer.Capabilities {
@@ -45,6 +56,10 @@ func (tsc *tracesConsumer) Capabilities() consumer.Capabilities {
 
 // ConsumeTraces exports the ptrace.Traces to all consumers wrapped by the current one.
 func (tsc *tracesConsumer) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	if tsc.cfg.Delivery != DeliveryDefault {
+		return tsc.consumeInOrder(ctx, td)
+	}
+
 	var errs error
 
 	if len(tsc.mutable) > 0 {
@@ -74,6 +89,27 @@ func (tsc *tracesConsumer) ConsumeTraces(ctx context.Context, td ptrace.Traces)
 	return errs
 }
 
+// consumeInOrder delivers the ptrace.Traces to the consumers in their declared order or concurrently, copying the
+// data for the consumers mutating it before any delivery.
+func (tsc *tracesConsumer) consumeInOrder(ctx context.Context, td ptrace.Traces) error {
+	original := originalIndex(tsc.mutates, td.IsReadOnly())
+	data := make([]ptrace.Traces, len(tsc.all))
+	for i := range tsc.all {
+		if tsc.mutates[i] && i != original {
+			data[i] = cloneTraces(td)
+		} else {
+			data[i] = td
+		}
+	}
+	// Mark the data as read-only if it will be sent to more than one read-only consumer.
+	if len(tsc.readonly) > 1 && !td.IsReadOnly() {
+		td.MarkReadOnly()
+	}
+	return tsc.cfg.deliver(len(tsc.all), func(i int) error {
+		return tsc.all[i].ConsumeTraces(ctx, data[i])
+	})
+}
+
 func cloneTraces(td ptrace.Traces) ptrace.Traces {
 	clonedTraces := ptrace.NewTraces()
 	td.CopyTo(clonedTraces)
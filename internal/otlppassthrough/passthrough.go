@@ -0,0 +1,120 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package otlppassthrough allows the OTLP exporters to forward the protobuf encoded requests received by the
+// OTLP receivers as is, instead of encoding their data again, when the data was not modified in between.
+//
+// The receiver attaches the received bytes to the context along with the data decoded from them. The service
+// removes them from the context of the pipelines which may mutate the data, and the exporter only uses them if
+// it is given the same, unmodified, data they were decoded into. The context, and so the received bytes, are kept
+// by the in-memory sending queue of the exporters, but not by a persistent queue, which only stores the data.
+package otlppassthrough // import "go.opentelemetry.io/collector/internal/otlppassthrough"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// Gate controls whether the OTLP/HTTP exporter forwards the protobuf requests received by the OTLP/HTTP receiver
+// without encoding them again.
+var Gate = featuregate.GlobalRegistry().MustRegister("otlp.zeroCopyPassthrough",
+	featuregate.StageAlpha,
+	featuregate.WithRegisterFromVersion("v0.110.0"),
+	featuregate.WithRegisterDescription("When enabled, the OTLP/HTTP exporter forwards the protobuf requests received "+
+		"by the OTLP/HTTP receiver as is, in the pipelines which do not mutate the data."))
+
+type ctxKey struct{}
+
+// payload is the data decoded from a received request, along with the request bytes. The number of items
+// detects the data modified without being replaced, e.g. merged into by the exporter batching.
+type payload struct {
+	data  any
+	items int
+	raw   []byte
+}
+
+// ContextWithTraces returns a copy of ctx carrying raw, the protobuf encoding of the request td was decoded from.
+// If the Gate is disabled, ctx is returned unchanged.
+func ContextWithTraces(ctx context.Context, td ptrace.Traces, raw []byte) context.Context {
+	if !Gate.IsEnabled() {
+		return ctx
+	}
+	return context.WithValue(ctx, ctxKey{}, &payload{data: td, items: td.SpanCount(), raw: raw})
+}
+
+// ContextWithMetrics returns a copy of ctx carrying raw, the protobuf encoding of the request md was decoded from.
+// If the Gate is disabled, ctx is returned unchanged.
+func ContextWithMetrics(ctx context.Context, md pmetric.Metrics, raw []byte) context.Context {
+	if !Gate.IsEnabled() {
+		return ctx
+	}
+	return context.WithValue(ctx, ctxKey{}, &payload{data: md, items: md.DataPointCount(), raw: raw})
+}
+
+// ContextWithLogs returns a copy of ctx carrying raw, the protobuf encoding of the request ld was decoded from.
+// If the Gate is disabled, ctx is returned unchanged.
+func ContextWithLogs(ctx context.Context, ld plog.Logs, raw []byte) context.Context {
+	if !Gate.IsEnabled() {
+		return ctx
+	}
+	return context.WithValue(ctx, ctxKey{}, &payload{data: ld, items: ld.LogRecordCount(), raw: raw})
+}
+
+// Without returns a copy of ctx not carrying any received request. It is used before the data is mutated.
+func Without(ctx context.Context) context.Context {
+	if _, ok := ctx.Value(ctxKey{}).(*payload); !ok {
+		return ctx
+	}
+	return context.WithValue(ctx, ctxKey{}, nil)
+}
+
+// TracesFromContext returns the protobuf encoding of the received request carried by ctx, if td is the data
+// decoded from it and the Gate is enabled.
+func TracesFromContext(ctx context.Context, td ptrace.Traces) ([]byte, bool) {
+	p, ok := fromContext(ctx)
+	if !ok {
+		return nil, false
+	}
+	if orig, ok := p.data.(ptrace.Traces); !ok || orig != td || p.items != td.SpanCount() {
+		return nil, false
+	}
+	return p.raw, true
+}
+
+// MetricsFromContext returns the protobuf encoding of the received request carried by ctx, if md is the data
+// decoded from it and the Gate is enabled.
+func MetricsFromContext(ctx context.Context, md pmetric.Metrics) ([]byte, bool) {
+	p, ok := fromContext(ctx)
+	if !ok {
+		return nil, false
+	}
+	if orig, ok := p.data.(pmetric.Metrics); !ok || orig != md || p.items != md.DataPointCount() {
+		return nil, false
+	}
+	return p.raw, true
+}
+
+// LogsFromContext returns the protobuf encoding of the received request carried by ctx, if ld is the data
+// decoded from it and the Gate is enabled.
+func LogsFromContext(ctx context.Context, ld plog.Logs) ([]byte, bool) {
+	p, ok := fromContext(ctx)
+	if !ok {
+		return nil, false
+	}
+	if orig, ok := p.data.(plog.Logs); !ok || orig != ld || p.items != ld.LogRecordCount() {
+		return nil, false
+	}
+	return p.raw, true
+}
+
+func fromContext(ctx context.Context) (*payload, bool) {
+	if !Gate.IsEnabled() {
+		return nil, false
+	}
+	p, ok := ctx.Value(ctxKey{}).(*payload)
+	return p, ok
+}
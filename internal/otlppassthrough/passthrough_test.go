@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlppassthrough
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func setGate(t *testing.T, enabled bool) {
+	prev := Gate.IsEnabled()
+	require.NoError(t, featuregate.GlobalRegistry().Set(Gate.ID(), enabled))
+	t.Cleanup(func() {
+		require.NoError(t, featuregate.GlobalRegistry().Set(Gate.ID(), prev))
+	})
+}
+
+func TestTraces(t *testing.T) {
+	setGate(t, true)
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	raw, err := (&ptrace.ProtoMarshaler{}).MarshalTraces(td)
+	require.NoError(t, err)
+
+	ctx := ContextWithTraces(context.Background(), td, raw)
+	got, ok := TracesFromContext(ctx, td)
+	require.True(t, ok)
+	assert.Equal(t, raw, got)
+
+	// Other data, e.g. a copy, does not match the request.
+	other := ptrace.NewTraces()
+	td.CopyTo(other)
+	_, ok = TracesFromContext(ctx, other)
+	assert.False(t, ok)
+
+	// Neither does the data once modified, nor once removed from the context.
+	_, ok = TracesFromContext(Without(ctx), td)
+	assert.False(t, ok)
+	td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().AppendEmpty()
+	_, ok = TracesFromContext(ctx, td)
+	assert.False(t, ok)
+
+	_, ok = TracesFromContext(context.Background(), td)
+	assert.False(t, ok)
+}
+
+func TestMetrics(t *testing.T) {
+	setGate(t, true)
+	md := pmetric.NewMetrics()
+	md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptyGauge().DataPoints().AppendEmpty()
+	raw, err := (&pmetric.ProtoMarshaler{}).MarshalMetrics(md)
+	require.NoError(t, err)
+
+	ctx := ContextWithMetrics(context.Background(), md, raw)
+	got, ok := MetricsFromContext(ctx, md)
+	require.True(t, ok)
+	assert.Equal(t, raw, got)
+	_, ok = MetricsFromContext(ctx, pmetric.NewMetrics())
+	assert.False(t, ok)
+	// The data of another signal never matches.
+	_, ok = LogsFromContext(ctx, plog.NewLogs())
+	assert.False(t, ok)
+}
+
+func TestLogs(t *testing.T) {
+	setGate(t, true)
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	raw, err := (&plog.ProtoMarshaler{}).MarshalLogs(ld)
+	require.NoError(t, err)
+
+	ctx := ContextWithLogs(context.Background(), ld, raw)
+	got, ok := LogsFromContext(ctx, ld)
+	require.True(t, ok)
+	assert.Equal(t, raw, got)
+	_, ok = LogsFromContext(Without(ctx), ld)
+	assert.False(t, ok)
+}
+
+func TestGateDisabled(t *testing.T) {
+	setGate(t, false)
+	td := ptrace.NewTraces()
+	ctx := ContextWithTraces(context.Background(), td, []byte("request"))
+	assert.Equal(t, context.Background(), ctx)
+	_, ok := TracesFromContext(ctx, td)
+	assert.False(t, ok)
+}
@@ -152,6 +152,17 @@ func (cfg *Config) Validate() error {
 			}
 			return fmt.Errorf("service::pipelines::%s: references exporter %q which is not configured", pipelineID, ref)
 		}
+
+		// Validate pipeline dead-letter exporter name reference.
+		if ref := pipeline.DeadLetter; ref != nil {
+			if _, ok := cfg.Exporters[*ref]; !ok {
+				return fmt.Errorf("service::pipelines::%s: references dead-letter exporter %q which is not configured", pipelineID, *ref)
+			}
+		}
+	}
+
+	if cfg.Service.ValidateExtensionReferences {
+		return cfg.validateExtensionReferences()
 	}
 	return nil
 }
@@ -34,6 +34,32 @@ func (c *errConfig) Validate() error {
 	return c.validateErr
 }
 
+// extensionRefConfig is a component configuration referencing extensions.
+type extensionRefConfig struct {
+	Auth    *authConfig   `mapstructure:"auth"`
+	Storage *component.ID `mapstructure:"storage"`
+	// Pipelines are not extension references.
+	Pipelines []component.ID `mapstructure:"pipelines"`
+}
+
+type authConfig struct {
+	AuthenticatorID component.ID `mapstructure:"authenticator"`
+}
+
+func generateConfigWithExtensionReferences() *Config {
+	cfg := generateConfig()
+	cfg.Service.ValidateExtensionReferences = true
+	storageID := component.MustNewID("nop")
+	cfg.Exporters[component.MustNewID("nop")] = &extensionRefConfig{
+		Storage:   &storageID,
+		Pipelines: []component.ID{component.MustNewID("traces")},
+	}
+	cfg.Receivers[component.MustNewID("nop")] = &extensionRefConfig{
+		Auth: &authConfig{AuthenticatorID: component.MustNewID("nop")},
+	}
+	return cfg
+}
+
 func TestConfigValidate(t *testing.T) {
 	var testCases = []struct {
 		name     string // test case name (also file name containing config yaml)
@@ -124,6 +150,17 @@ func TestConfigValidate(t *testing.T) {
 			},
 			expected: errors.New(`service::pipelines::traces: references exporter "nop/2" which is not configured`),
 		},
+		{
+			name: "invalid-dead-letter-reference",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				pipe := cfg.Service.Pipelines[component.MustNewID("traces")]
+				deadLetter := component.MustNewIDWithName("nop", "2")
+				pipe.DeadLetter = &deadLetter
+				return cfg
+			},
+			expected: errors.New(`service::pipelines::traces: references dead-letter exporter "nop/2" which is not configured`),
+		},
 		{
 			name: "invalid-receiver-config",
 			cfgFn: func() *Config {
@@ -225,6 +262,45 @@ func TestConfigValidate(t *testing.T) {
 			},
 			expected: errors.New(`service::pipelines::traces: references exporter "nop/conn2" which is not configured`),
 		},
+		{
+			name:     "valid-extension-references",
+			cfgFn:    generateConfigWithExtensionReferences,
+			expected: nil,
+		},
+		{
+			name: "missing-extension-reference",
+			cfgFn: func() *Config {
+				cfg := generateConfigWithExtensionReferences()
+				cfg.Receivers[component.MustNewID("nop")] = &extensionRefConfig{
+					Auth: &authConfig{AuthenticatorID: component.MustNewID("oidc")},
+				}
+				return cfg
+			},
+			expected: errors.New(`receivers::nop: references extension "oidc" which is not configured`),
+		},
+		{
+			name: "disabled-extension-reference",
+			cfgFn: func() *Config {
+				cfg := generateConfigWithExtensionReferences()
+				cfg.Extensions[component.MustNewIDWithName("nop", "storage")] = &errConfig{}
+				storageID := component.MustNewIDWithName("nop", "storage")
+				cfg.Exporters[component.MustNewID("nop")] = &extensionRefConfig{Storage: &storageID}
+				return cfg
+			},
+			expected: errors.New(`exporters::nop: references extension "nop/storage" which is not enabled in service::extensions`),
+		},
+		{
+			name: "missing-extension-reference-not-validated",
+			cfgFn: func() *Config {
+				cfg := generateConfigWithExtensionReferences()
+				cfg.Service.ValidateExtensionReferences = false
+				cfg.Receivers[component.MustNewID("nop")] = &extensionRefConfig{
+					Auth: &authConfig{AuthenticatorID: component.MustNewID("oidc")},
+				}
+				return cfg
+			},
+			expected: nil,
+		},
 		{
 			name: "invalid-service-config",
 			cfgFn: func() *Config {
@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol // import "go.opentelemetry.io/collector/otelcol"
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+var componentIDType = reflect.TypeOf(component.ID{})
+
+// validateExtensionReferences checks that the extensions referenced by the configurations of the receivers,
+// processors, exporters, connectors and extensions, e.g. an authenticator or a storage, are configured
+// and enabled in the service.
+func (cfg *Config) validateExtensionReferences() error {
+	kinds := []struct {
+		name    string
+		configs map[component.ID]component.Config
+	}{
+		{name: "receivers", configs: cfg.Receivers},
+		{name: "processors", configs: cfg.Processors},
+		{name: "exporters", configs: cfg.Exporters},
+		{name: "connectors", configs: cfg.Connectors},
+		{name: "extensions", configs: cfg.Extensions},
+	}
+	for _, kind := range kinds {
+		for id, compCfg := range kind.configs {
+			for _, ref := range extensionReferences(compCfg) {
+				// The connectors, e.g. routing ones, reference pipelines by their ID.
+				if _, ok := cfg.Service.Pipelines[ref]; ok {
+					continue
+				}
+				if _, ok := cfg.Extensions[ref]; !ok {
+					return fmt.Errorf("%s::%s: references extension %q which is not configured", kind.name, id, ref)
+				}
+				if !slices.Contains(cfg.Service.Extensions, ref) {
+					return fmt.Errorf("%s::%s: references extension %q which is not enabled in service::extensions", kind.name, id, ref)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// extensionReferences returns the non-empty component IDs found in the exported fields of the
+// configuration, in the order they are found.
+func extensionReferences(compCfg component.Config) []component.ID {
+	var refs []component.ID
+	collectComponentIDs(reflect.ValueOf(compCfg), &refs)
+	return refs
+}
+
+func collectComponentIDs(v reflect.Value, refs *[]component.ID) {
+	if !v.IsValid() {
+		return
+	}
+	if v.Type() == componentIDType {
+		if id := v.Interface().(component.ID); id != (component.ID{}) {
+			*refs = append(*refs, id)
+		}
+		return
+	}
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !v.IsNil() {
+			collectComponentIDs(v.Elem(), refs)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				collectComponentIDs(v.Field(i), refs)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			collectComponentIDs(v.Index(i), refs)
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			collectComponentIDs(iter.Value(), refs)
+		}
+	}
+}
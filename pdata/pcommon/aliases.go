@@ -0,0 +1,46 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pcommon // import "go.opentelemetry.io/collector/pdata/pcommon"
+
+import "sort"
+
+// AddAliases copies the value of every canonical key of aliases present in the Map under its alias key,
+// overwriting any existing value of the alias. If removeCanonical is true, the canonical keys are then removed.
+// A mapping whose alias is the canonical key itself is ignored.
+//
+// The mappings are applied in the sorted order of their canonical keys, so the mappings are chained when an alias
+// is also a canonical key: with "a" -> "b" and "b" -> "c", "c" gets the value of "a", which was copied to "b" first.
+// The canonical keys are removed once all the mappings were applied, including those which are also aliases.
+// It returns the number of aliases that were added.
+func (m Map) AddAliases(aliases map[string]string, removeCanonical bool) int {
+	canonicals := make([]string, 0, len(aliases))
+	for canonical := range aliases {
+		canonicals = append(canonicals, canonical)
+	}
+	sort.Strings(canonicals)
+
+	added := 0
+	for _, canonical := range canonicals {
+		alias := aliases[canonical]
+		if canonical == alias {
+			continue
+		}
+		if _, ok := m.Get(canonical); !ok {
+			continue
+		}
+		dest := m.PutEmpty(alias)
+		// The canonical value is looked up again, as adding the alias may have moved it.
+		src, _ := m.Get(canonical)
+		src.CopyTo(dest)
+		added++
+	}
+	if removeCanonical {
+		for canonical, alias := range aliases {
+			if canonical != alias {
+				m.Remove(canonical)
+			}
+		}
+	}
+	return added
+}
@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pcommon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapAddAliases(t *testing.T) {
+	aliases := map[string]string{
+		"service.instance.id": "instance",
+		"http.request.method": "http.method",
+		"url.full":            "http.url",
+		"host.name":           "host.name",
+	}
+	newMap := func() Map {
+		m := NewMap()
+		m.PutStr("service.instance.id", "instance-1")
+		m.PutEmptySlice("http.request.method").AppendEmpty().SetStr("GET")
+		m.PutStr("http.method", "POST")
+		m.PutStr("host.name", "localhost")
+		return m
+	}
+
+	m := newMap()
+	assert.Equal(t, 2, m.AddAliases(aliases, false))
+	assert.Equal(t, map[string]any{
+		"service.instance.id": "instance-1",
+		"instance":            "instance-1",
+		"http.request.method": []any{"GET"},
+		"http.method":         []any{"GET"},
+		"host.name":           "localhost",
+	}, m.AsRaw())
+
+	m = newMap()
+	assert.Equal(t, 2, m.AddAliases(aliases, true))
+	assert.Equal(t, map[string]any{
+		"instance":    "instance-1",
+		"http.method": []any{"GET"},
+		"host.name":   "localhost",
+	}, m.AsRaw())
+
+	// The copies are independent of their canonical value.
+	m = newMap()
+	m.AddAliases(aliases, false)
+	v, _ := m.Get("http.request.method")
+	v.Slice().AppendEmpty().SetStr("PUT")
+	v, _ = m.Get("http.method")
+	assert.Equal(t, 1, v.Slice().Len())
+
+	assert.Equal(t, 0, NewMap().AddAliases(aliases, true))
+}
+
+func TestMapAddAliasesChained(t *testing.T) {
+	aliases := map[string]string{
+		"a": "b",
+		"b": "c",
+		"x": "y",
+		"w": "x",
+	}
+	// The result does not depend on the iteration order of aliases.
+	for i := 0; i < 100; i++ {
+		m := NewMap()
+		require.NoError(t, m.FromRaw(map[string]any{"a": "1", "b": "2", "w": "3", "x": "4"}))
+		assert.Equal(t, 4, m.AddAliases(aliases, false))
+		assert.Equal(t, map[string]any{"a": "1", "b": "1", "c": "1", "w": "3", "x": "3", "y": "3"}, m.AsRaw())
+
+		m = NewMap()
+		require.NoError(t, m.FromRaw(map[string]any{"a": "1", "b": "2", "w": "3", "x": "4"}))
+		assert.Equal(t, 4, m.AddAliases(aliases, true))
+		assert.Equal(t, map[string]any{"c": "1", "y": "3"}, m.AsRaw())
+	}
+}
@@ -0,0 +1,171 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pcommon // import "go.opentelemetry.io/collector/pdata/pcommon"
+
+// TruncateMapDepth removes from m the values nested deeper than maxDepth, the depth of a value being the number
+// of maps and slices containing it, m included, e.g. {"a": {"b": {"c": "v"}}} is truncated to {"a": {"b": {}}}
+// with a maxDepth of 2. The maps and slices at maxDepth are left empty.
+// There is no limit if maxDepth is zero or negative.
+//
+// It returns the number of maps and slices emptied.
+func TruncateMapDepth(m Map, maxDepth int) int {
+	if maxDepth <= 0 {
+		return 0
+	}
+	truncated := 0
+	m.Range(func(_ string, v Value) bool {
+		truncated += truncateValue(v, maxDepth, 1)
+		return true
+	})
+	return truncated
+}
+
+func truncateValue(v Value, maxDepth int, depth int) int {
+	truncated := 0
+	switch v.Type() {
+	case ValueTypeMap:
+		if v.Map().Len() == 0 {
+			return 0
+		}
+		if depth == maxDepth {
+			v.SetEmptyMap()
+			return 1
+		}
+		v.Map().Range(func(_ string, child Value) bool {
+			truncated += truncateValue(child, maxDepth, depth+1)
+			return true
+		})
+	case ValueTypeSlice:
+		if v.Slice().Len() == 0 {
+			return 0
+		}
+		if depth == maxDepth {
+			v.SetEmptySlice()
+			return 1
+		}
+		for i := 0; i < v.Slice().Len(); i++ {
+			truncated += truncateValue(v.Slice().At(i), maxDepth, depth+1)
+		}
+	}
+	return truncated
+}
+
+// FlattenMapBeyondDepth modifies m so that no value is nested deeper than maxDepth, as defined by TruncateMapDepth,
+// keeping the values nested too deep. The maps at maxDepth are replaced in their parent map by their entries under
+// their key joined to the key of the entry with sep, as done by FlattenMap, e.g. {"a": {"b": {"c": "v"}}} is flattened to {"a": {"b.c": "v"}}
+// with the "." separator and a maxDepth of 2. The other maps and slices at maxDepth, which cannot be flattened
+// into a parent map, are replaced by their JSON representation, e.g. {"a": [["v"]]} is changed to {"a": ["[\"v\"]"]}
+// with a maxDepth of 2. There is no limit if maxDepth is zero or negative.
+//
+// If a flattened key is already in the parent map, the flattened value is dropped. It returns the number of
+// maps and slices flattened or replaced plus the number of dropped values.
+// It panics if sep is empty.
+func FlattenMapBeyondDepth(m Map, sep string, maxDepth int) int {
+	if sep == "" {
+		panic("pcommon: empty FlattenMapBeyondDepth separator")
+	}
+	if maxDepth <= 0 {
+		return 0
+	}
+	return flattenMapBeyondDepth(m, sep, maxDepth, 1)
+}
+
+// flattenMapBeyondDepth limits the depth of the values of m, which are at depth.
+func flattenMapBeyondDepth(m Map, sep string, maxDepth int, depth int) int {
+	changed := 0
+	var tooDeep []string
+	m.Range(func(k string, v Value) bool {
+		if depth == maxDepth && v.Type() == ValueTypeMap && v.Map().Len() > 0 {
+			tooDeep = append(tooDeep, k)
+			return true
+		}
+		changed += flattenValueBeyondDepth(v, sep, maxDepth, depth)
+		return true
+	})
+	for _, k := range tooDeep {
+		v, _ := m.Get(k)
+		nested := NewMap()
+		v.Map().CopyTo(nested)
+		m.Remove(k)
+		changed += 1 + moveFlattened(m, nested, k, sep)
+	}
+	return changed
+}
+
+// flattenValueBeyondDepth limits the depth of v, which is at depth, except for the maps at maxDepth which are
+// flattened into their parent map by flattenMapBeyondDepth.
+func flattenValueBeyondDepth(v Value, sep string, maxDepth int, depth int) int {
+	switch v.Type() {
+	case ValueTypeMap:
+		if v.Map().Len() == 0 {
+			return 0
+		}
+		if depth == maxDepth {
+			v.SetStr(v.AsString())
+			return 1
+		}
+		return flattenMapBeyondDepth(v.Map(), sep, maxDepth, depth+1)
+	case ValueTypeSlice:
+		if v.Slice().Len() == 0 {
+			return 0
+		}
+		if depth == maxDepth {
+			v.SetStr(v.AsString())
+			return 1
+		}
+		changed := 0
+		for i := 0; i < v.Slice().Len(); i++ {
+			changed += flattenValueBeyondDepth(v.Slice().At(i), sep, maxDepth, depth+1)
+		}
+		return changed
+	}
+	return 0
+}
+
+// moveFlattened moves the entries of nested to dest under prefix joined to their key with sep, the entries of the
+// nested maps being flattened too. The maps and slices which cannot be flattened are replaced by their JSON
+// representation. It returns the number of maps and slices flattened or replaced plus the number of dropped values.
+// The nested maps are walked with an explicit stack rather than recursively, nested being arbitrarily deep.
+func moveFlattened(dest Map, nested Map, prefix string, sep string) int {
+	type entry struct {
+		key   string
+		value Value
+	}
+	entries := func(m Map, prefix string) []entry {
+		es := make([]entry, 0, m.Len())
+		m.Range(func(k string, v Value) bool {
+			es = append(es, entry{key: prefix + sep + k, value: v})
+			return true
+		})
+		return es
+	}
+
+	changed := 0
+	// stack holds the entries left to move of each map being flattened, the innermost one last.
+	stack := [][]entry{entries(nested, prefix)}
+	for len(stack) > 0 {
+		top := stack[len(stack)-1]
+		if len(top) == 0 {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		e := top[0]
+		stack[len(stack)-1] = top[1:]
+		switch v := e.value; {
+		case v.Type() == ValueTypeMap && v.Map().Len() > 0:
+			changed++
+			stack = append(stack, entries(v.Map(), e.key))
+			continue
+		case v.Type() == ValueTypeSlice && v.Slice().Len() > 0:
+			v.SetStr(v.AsString())
+			changed++
+		}
+		if _, ok := dest.Get(e.key); ok {
+			changed++
+			continue
+		}
+		e.value.CopyTo(dest.PutEmpty(e.key))
+	}
+	return changed
+}
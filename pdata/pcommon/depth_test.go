@@ -0,0 +1,116 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pcommon
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// deepMap returns a map with a chain of depth nested maps under the "n" key, the
+// deepest one holding the "v" string.
+func deepMap(depth int) map[string]any {
+	m := map[string]any{"v": "leaf"}
+	for i := 1; i < depth; i++ {
+		m = map[string]any{"n": m}
+	}
+	return m
+}
+
+// chainLen returns the number of maps nested under the "n" key of m, and the deepest one.
+func chainLen(m Map) (int, Map) {
+	n := 0
+	for v, ok := m.Get("n"); ok && v.Type() == ValueTypeMap; v, ok = m.Get("n") {
+		m = v.Map()
+		n++
+	}
+	return n, m
+}
+
+func TestTruncateMapDepth(t *testing.T) {
+	m := newMapFromRaw(t, map[string]any{
+		"a":     map[string]any{"b": map[string]any{"c": "v"}, "d": "kept"},
+		"s":     []any{[]any{"v"}, "kept"},
+		"empty": map[string]any{"e": map[string]any{}},
+		"str":   "kept",
+	})
+	assert.Equal(t, 2, TruncateMapDepth(m, 2))
+	assert.Equal(t, map[string]any{
+		"a":     map[string]any{"b": map[string]any{}, "d": "kept"},
+		"s":     []any{[]any{}, "kept"},
+		"empty": map[string]any{"e": map[string]any{}},
+		"str":   "kept",
+	}, m.AsRaw())
+
+	// The map is left unchanged if it is not too deep.
+	assert.Zero(t, TruncateMapDepth(m, 2))
+	assert.Zero(t, TruncateMapDepth(m, 0))
+	assert.Equal(t, 3, TruncateMapDepth(m, 1))
+	assert.Equal(t, map[string]any{"a": map[string]any{}, "s": []any{}, "empty": map[string]any{}, "str": "kept"}, m.AsRaw())
+}
+
+func TestTruncateMapDepthOverDeep(t *testing.T) {
+	m := newMapFromRaw(t, deepMap(10000))
+	assert.Equal(t, 1, TruncateMapDepth(m, 32))
+	n, deepest := chainLen(m)
+	assert.Equal(t, 32, n)
+	assert.Zero(t, deepest.Len())
+}
+
+func TestFlattenMapBeyondDepth(t *testing.T) {
+	m := newMapFromRaw(t, map[string]any{
+		"a": map[string]any{
+			"b": map[string]any{"c": "v", "d": map[string]any{"e": int64(1)}, "s": []any{"x"}},
+			"f": "kept",
+		},
+		"s":     []any{[]any{"v"}, map[string]any{"k": "v"}, "kept"},
+		"empty": map[string]any{"e": map[string]any{}},
+	})
+	// Flattened b and d, replaced the slice nested in b and the slice and map nested in s.
+	assert.Equal(t, 5, FlattenMapBeyondDepth(m, ".", 2))
+	assert.Equal(t, map[string]any{
+		"a": map[string]any{
+			"b.c":   "v",
+			"b.d.e": int64(1),
+			"b.s":   `["x"]`,
+			"f":     "kept",
+		},
+		"s":     []any{`["v"]`, `{"k":"v"}`, "kept"},
+		"empty": map[string]any{"e": map[string]any{}},
+	}, m.AsRaw())
+
+	assert.Zero(t, FlattenMapBeyondDepth(m, ".", 2))
+	assert.Zero(t, FlattenMapBeyondDepth(m, ".", 0))
+}
+
+func TestFlattenMapBeyondDepthOverDeep(t *testing.T) {
+	m := newMapFromRaw(t, deepMap(10000))
+	// The 9999 nested maps from the depth 32 are flattened.
+	assert.Equal(t, 9999-31, FlattenMapBeyondDepth(m, "/", 32))
+	n, deepest := chainLen(m)
+	assert.Equal(t, 31, n)
+	assert.Equal(t, map[string]any{strings.Repeat("n/", 9999-31) + "v": "leaf"}, deepest.AsRaw())
+}
+
+func TestFlattenMapBeyondDepthCollision(t *testing.T) {
+	m := newMapFromRaw(t, map[string]any{
+		"a.b": "flat",
+		"a":   map[string]any{"b": "dropped", "c": "moved"},
+	})
+	assert.Equal(t, 2, FlattenMapBeyondDepth(m, ".", 1))
+	assert.Equal(t, map[string]any{"a.b": "flat", "a.c": "moved"}, m.AsRaw())
+
+	// The entries are moved in order, those of a nested map before the following ones.
+	m = NewMap()
+	nested := m.PutEmptyMap("a")
+	nested.PutEmptyMap("b").PutInt("c", 1)
+	nested.PutInt("b.c", 2)
+	nested.PutEmptyMap("d").PutEmptyMap("e").PutInt("f", 3)
+	assert.Equal(t, 5, FlattenMapBeyondDepth(m, ".", 1))
+	assert.Equal(t, map[string]any{"a.b.c": int64(1), "a.d.e.f": int64(3)}, m.AsRaw())
+
+	assert.Panics(t, func() { FlattenMapBeyondDepth(m, "", 1) })
+}
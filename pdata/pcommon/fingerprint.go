@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pcommon // import "go.opentelemetry.io/collector/pdata/pcommon"
+
+import (
+	"encoding/binary"
+	"hash"
+	"hash/fnv"
+	"math"
+	"sort"
+)
+
+// Fingerprint is a hash identifying the content of a pdata value.
+// Two values with the same content always have the same Fingerprint.
+type Fingerprint [16]byte
+
+// Fingerprint returns the Fingerprint of the Map. The order of the keys does not affect the result.
+func (m Map) Fingerprint() Fingerprint {
+	h := fnv.New128a()
+	writeMap(h, m)
+	return sum(h)
+}
+
+// Fingerprint returns the Fingerprint of the Value.
+func (v Value) Fingerprint() Fingerprint {
+	h := fnv.New128a()
+	writeValue(h, v)
+	return sum(h)
+}
+
+// Fingerprint returns the Fingerprint of the Resource, computed from its attributes
+// and its dropped attributes count.
+func (ms Resource) Fingerprint() Fingerprint {
+	h := fnv.New128a()
+	writeMap(h, ms.Attributes())
+	writeUint64(h, uint64(ms.DroppedAttributesCount()))
+	return sum(h)
+}
+
+func sum(h hash.Hash) Fingerprint {
+	var fp Fingerprint
+	h.Sum(fp[:0])
+	return fp
+}
+
+func writeUint64(h hash.Hash, v uint64) {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], v)
+	_, _ = h.Write(buf[:])
+}
+
+// writeString writes the length before the content, so that adjacent strings cannot be confused.
+func writeString(h hash.Hash, s string) {
+	writeUint64(h, uint64(len(s)))
+	_, _ = h.Write([]byte(s))
+}
+
+func writeMap(h hash.Hash, m Map) {
+	keys := make([]string, 0, m.Len())
+	m.Range(func(k string, _ Value) bool {
+		keys = append(keys, k)
+		return true
+	})
+	sort.Strings(keys)
+	writeUint64(h, uint64(len(keys)))
+	for _, k := range keys {
+		writeString(h, k)
+		v, _ := m.Get(k)
+		writeValue(h, v)
+	}
+}
+
+func writeValue(h hash.Hash, v Value) {
+	_, _ = h.Write([]byte{byte(v.Type())})
+	switch v.Type() {
+	case ValueTypeStr:
+		writeString(h, v.Str())
+	case ValueTypeInt:
+		writeUint64(h, uint64(v.Int()))
+	case ValueTypeDouble:
+		writeUint64(h, math.Float64bits(v.Double()))
+	case ValueTypeBool:
+		if v.Bool() {
+			_, _ = h.Write([]byte{1})
+		} else {
+			_, _ = h.Write([]byte{0})
+		}
+	case ValueTypeBytes:
+		writeString(h, string(v.Bytes().AsRaw()))
+	case ValueTypeMap:
+		writeMap(h, v.Map())
+	case ValueTypeSlice:
+		s := v.Slice()
+		writeUint64(h, uint64(s.Len()))
+		for i := 0; i < s.Len(); i++ {
+			writeValue(h, s.At(i))
+		}
+	}
+}
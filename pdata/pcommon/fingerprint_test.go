@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pcommon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapFingerprint(t *testing.T) {
+	raw := map[string]any{
+		"str":    "value",
+		"int":    int64(1),
+		"double": 1.5,
+		"bool":   true,
+		"bytes":  []byte{1, 2},
+		"slice":  []any{"a", int64(2)},
+		"map":    map[string]any{"nested": "value"},
+		"empty":  nil,
+	}
+	m1 := NewMap()
+	require.NoError(t, m1.FromRaw(raw))
+	m2 := NewMap()
+	require.NoError(t, m2.FromRaw(raw))
+	assert.Equal(t, m1.Fingerprint(), m2.Fingerprint())
+
+	// Insertion order does not matter.
+	m3 := NewMap()
+	m3.PutStr("b", "2")
+	m3.PutStr("a", "1")
+	m4 := NewMap()
+	m4.PutStr("a", "1")
+	m4.PutStr("b", "2")
+	assert.Equal(t, m3.Fingerprint(), m4.Fingerprint())
+
+	m2.PutStr("str", "other")
+	assert.NotEqual(t, m1.Fingerprint(), m2.Fingerprint())
+}
+
+func TestMapFingerprintDistinguishesTypes(t *testing.T) {
+	values := []Value{
+		NewValueEmpty(),
+		NewValueStr("1"),
+		NewValueInt(1),
+		NewValueDouble(1),
+		NewValueBool(true),
+		NewValueMap(),
+		NewValueSlice(),
+		NewValueBytes(),
+	}
+	seen := map[Fingerprint]ValueType{}
+	for _, v := range values {
+		fp := v.Fingerprint()
+		_, exists := seen[fp]
+		assert.False(t, exists, "fingerprint of %v collides", v.Type())
+		seen[fp] = v.Type()
+	}
+}
+
+func TestMapFingerprintDistinguishesBoundaries(t *testing.T) {
+	m1 := NewMap()
+	m1.PutStr("ab", "c")
+	m2 := NewMap()
+	m2.PutStr("a", "bc")
+	assert.NotEqual(t, m1.Fingerprint(), m2.Fingerprint())
+}
+
+func TestResourceFingerprint(t *testing.T) {
+	r1 := NewResource()
+	r1.Attributes().PutStr("service.name", "svc")
+	r2 := NewResource()
+	r2.Attributes().PutStr("service.name", "svc")
+	assert.Equal(t, r1.Fingerprint(), r2.Fingerprint())
+
+	r2.Attributes().PutStr("host.name", "host")
+	assert.NotEqual(t, r1.Fingerprint(), r2.Fingerprint())
+
+	r2.Attributes().Remove("host.name")
+	r2.SetDroppedAttributesCount(1)
+	assert.NotEqual(t, r1.Fingerprint(), r2.Fingerprint())
+}
@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pcommon // import "go.opentelemetry.io/collector/pdata/pcommon"
+
+import (
+	"strings"
+)
+
+// FlattenMap returns a copy of m in which the entries of the nested maps are moved up under the key of their
+// parent joined to their own key with sep, e.g. {"http": {"method": "GET"}} is flattened to {"http.method": "GET"}
+// with the "." separator. Empty maps are kept as values.
+//
+// Only the first maxDepth levels of nested maps are flattened, the deeper maps being kept as values.
+// There is no limit if maxDepth is zero or negative.
+//
+// If several values are flattened to the same key, e.g. with {"a.b": 1, "a": {"b": 2}}, the first one in the
+// iteration order of m is kept and the others are dropped. It returns the number of dropped values.
+// It panics if sep is empty.
+func FlattenMap(m Map, sep string, maxDepth int) (Map, int) {
+	if sep == "" {
+		panic("pcommon: empty FlattenMap separator")
+	}
+	dest := NewMap()
+	dest.EnsureCapacity(m.Len())
+	dropped := flattenInto(dest, m, "", sep, maxDepth, 0)
+	return dest, dropped
+}
+
+func flattenInto(dest Map, m Map, prefix string, sep string, maxDepth int, depth int) int {
+	dropped := 0
+	m.Range(func(k string, v Value) bool {
+		if depth > 0 {
+			k = prefix + sep + k
+		}
+		if v.Type() == ValueTypeMap && v.Map().Len() > 0 && (maxDepth <= 0 || depth < maxDepth) {
+			dropped += flattenInto(dest, v.Map(), k, sep, maxDepth, depth+1)
+			return true
+		}
+		if _, ok := dest.Get(k); ok {
+			dropped++
+			return true
+		}
+		v.CopyTo(dest.PutEmpty(k))
+		return true
+	})
+	return dropped
+}
+
+// NestMap returns a copy of m in which the keys of m are split on sep into nested maps, e.g. {"http.method": "GET"}
+// is nested to {"http": {"method": "GET"}} with the "." separator. The keys of the map values of m are not split.
+//
+// The keys are split into at most maxDepth+1 segments, the last one keeping the remaining separators.
+// There is no limit if maxDepth is zero or negative.
+//
+// The values nested under the same key are merged if they are all maps, e.g. {"a.b": 1, "a": {"c": 2}} is
+// nested to {"a": {"b": 1, "c": 2}}. Otherwise, the first one in the iteration order of m is kept and the others
+// are dropped, e.g. with {"a": 1, "a.b": 2}. It returns the number of dropped values.
+// It panics if sep is empty.
+func NestMap(m Map, sep string, maxDepth int) (Map, int) {
+	if sep == "" {
+		panic("pcommon: empty NestMap separator")
+	}
+	segmentsLimit := -1
+	if maxDepth > 0 {
+		segmentsLimit = maxDepth + 1
+	}
+	dest := NewMap()
+	dropped := 0
+	m.Range(func(k string, v Value) bool {
+		segments := strings.SplitN(k, sep, segmentsLimit)
+		parent := dest
+		for _, segment := range segments[:len(segments)-1] {
+			child, ok := parent.Get(segment)
+			if !ok {
+				parent = parent.PutEmptyMap(segment)
+				continue
+			}
+			if child.Type() != ValueTypeMap {
+				dropped++
+				return true
+			}
+			parent = child.Map()
+		}
+		dropped += mergeValue(parent, segments[len(segments)-1], v)
+		return true
+	})
+	return dest, dropped
+}
+
+// mergeValue copies v under key in dest, merging it into the existing value if both are maps, and returns
+// the number of values dropped because they collided with an existing value.
+func mergeValue(dest Map, key string, v Value) int {
+	existing, ok := dest.Get(key)
+	if !ok {
+		v.CopyTo(dest.PutEmpty(key))
+		return 0
+	}
+	if existing.Type() != ValueTypeMap || v.Type() != ValueTypeMap {
+		return 1
+	}
+	dropped := 0
+	v.Map().Range(func(k string, child Value) bool {
+		dropped += mergeValue(existing.Map(), k, child)
+		return true
+	})
+	return dropped
+}
@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pcommon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMapFromRaw(t *testing.T, raw map[string]any) Map {
+	m := NewMap()
+	require.NoError(t, m.FromRaw(raw))
+	return m
+}
+
+func TestFlattenNestMapRoundTrip(t *testing.T) {
+	nested := map[string]any{
+		"http": map[string]any{
+			"request": map[string]any{"method": "GET", "size": int64(10)},
+			"status":  int64(200),
+		},
+		"service": "checkout",
+		"empty":   map[string]any{},
+		"tags":    []any{"a", map[string]any{"b": "c"}},
+	}
+	flat := map[string]any{
+		"http.request.method": "GET",
+		"http.request.size":   int64(10),
+		"http.status":         int64(200),
+		"service":             "checkout",
+		"empty":               map[string]any{},
+		"tags":                []any{"a", map[string]any{"b": "c"}},
+	}
+
+	got, dropped := FlattenMap(newMapFromRaw(t, nested), ".", 0)
+	assert.Zero(t, dropped)
+	assert.Equal(t, flat, got.AsRaw())
+
+	got, dropped = NestMap(got, ".", 0)
+	assert.Zero(t, dropped)
+	assert.Equal(t, nested, got.AsRaw())
+}
+
+func TestFlattenNestMapDepth(t *testing.T) {
+	nested := map[string]any{"a": map[string]any{"b": map[string]any{"c": map[string]any{"d": "v"}}}}
+
+	flat, dropped := FlattenMap(newMapFromRaw(t, nested), "/", 2)
+	assert.Zero(t, dropped)
+	assert.Equal(t, map[string]any{"a/b/c": map[string]any{"d": "v"}}, flat.AsRaw())
+	got, dropped := NestMap(flat, "/", 2)
+	assert.Zero(t, dropped)
+	assert.Equal(t, nested, got.AsRaw())
+
+	// The last segment keeps the remaining separators.
+	got, dropped = NestMap(newMapFromRaw(t, map[string]any{"a/b/c/d": "v"}), "/", 1)
+	assert.Zero(t, dropped)
+	assert.Equal(t, map[string]any{"a": map[string]any{"b/c/d": "v"}}, got.AsRaw())
+}
+
+func TestFlattenMapCollision(t *testing.T) {
+	m := NewMap()
+	m.PutStr("a.b", "flat")
+	m.PutEmptyMap("a").PutStr("b", "nested")
+	m.PutStr("c", "kept")
+
+	got, dropped := FlattenMap(m, ".", 0)
+	assert.Equal(t, 1, dropped)
+	assert.Equal(t, map[string]any{"a.b": "flat", "c": "kept"}, got.AsRaw())
+	// The source map is left unchanged.
+	assert.Equal(t, 3, m.Len())
+}
+
+func TestNestMapCollision(t *testing.T) {
+	m := NewMap()
+	m.PutStr("a", "value")
+	m.PutStr("a.b", "dropped")
+	m.PutStr("c.d", "merged")
+	c := m.PutEmptyMap("c")
+	c.PutStr("e", "merged")
+	c.PutStr("d", "dropped")
+	m.PutStr("f.g", "value")
+	m.PutStr("f.g.h", "dropped")
+
+	got, dropped := NestMap(m, ".", 0)
+	assert.Equal(t, 3, dropped)
+	assert.Equal(t, map[string]any{
+		"a": "value",
+		"c": map[string]any{"d": "merged", "e": "merged"},
+		"f": map[string]any{"g": "value"},
+	}, got.AsRaw())
+}
+
+func TestFlattenNestMapEmptySeparator(t *testing.T) {
+	assert.Panics(t, func() { FlattenMap(NewMap(), "", 0) })
+	assert.Panics(t, func() { NestMap(NewMap(), "", 0) })
+}
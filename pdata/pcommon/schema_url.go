@@ -0,0 +1,57 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pcommon // import "go.opentelemetry.io/collector/pdata/pcommon"
+
+import (
+	"fmt"
+)
+
+// SchemaURLPolicy specifies how MergeSchemaURL resolves two different schema URLs.
+type SchemaURLPolicy int32
+
+const (
+	// SchemaURLPolicyKeepFirst keeps the schema URL of the object merged into.
+	SchemaURLPolicyKeepFirst SchemaURLPolicy = iota
+	// SchemaURLPolicyKeepLast keeps the schema URL of the object being merged.
+	SchemaURLPolicyKeepLast
+	// SchemaURLPolicyError returns an error if the schema URLs are different.
+	SchemaURLPolicyError
+)
+
+// String returns the string representation of the SchemaURLPolicy.
+func (p SchemaURLPolicy) String() string {
+	switch p {
+	case SchemaURLPolicyKeepFirst:
+		return "KeepFirst"
+	case SchemaURLPolicyKeepLast:
+		return "KeepLast"
+	case SchemaURLPolicyError:
+		return "Error"
+	}
+	return ""
+}
+
+// MergeSchemaURL returns the schema URL to set when merging a resource or scope with the schema URL last
+// into one with the schema URL first, e.g. the ResourceSpans or ScopeSpans of two batches.
+//
+// An empty schema URL does not conflict with another one: the non-empty schema URL is returned whatever
+// the policy. Otherwise, the schema URLs are resolved according to the policy, and an error is returned
+// if the policy is SchemaURLPolicyError and they are different.
+func MergeSchemaURL(first, last string, policy SchemaURLPolicy) (string, error) {
+	switch {
+	case first == last || last == "":
+		return first, nil
+	case first == "":
+		return last, nil
+	}
+	switch policy {
+	case SchemaURLPolicyKeepFirst:
+		return first, nil
+	case SchemaURLPolicyKeepLast:
+		return last, nil
+	case SchemaURLPolicyError:
+		return "", fmt.Errorf("conflicting schema URLs %q and %q", first, last)
+	}
+	return "", fmt.Errorf("unsupported schema URL policy %d", policy)
+}
@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pcommon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	schemaURLv1 = "https://opentelemetry.io/schemas/1.21.0"
+	schemaURLv2 = "https://opentelemetry.io/schemas/1.26.0"
+)
+
+func TestMergeSchemaURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		first   string
+		last    string
+		policy  SchemaURLPolicy
+		want    string
+		wantErr string
+	}{
+		{name: "keep_first", first: schemaURLv1, last: schemaURLv2, policy: SchemaURLPolicyKeepFirst, want: schemaURLv1},
+		{name: "keep_last", first: schemaURLv1, last: schemaURLv2, policy: SchemaURLPolicyKeepLast, want: schemaURLv2},
+		{
+			name: "error", first: schemaURLv1, last: schemaURLv2, policy: SchemaURLPolicyError,
+			wantErr: `conflicting schema URLs "https://opentelemetry.io/schemas/1.21.0" and "https://opentelemetry.io/schemas/1.26.0"`,
+		},
+		{name: "unsupported_policy", first: schemaURLv1, last: schemaURLv2, policy: SchemaURLPolicy(100), wantErr: "unsupported schema URL policy 100"},
+		{name: "same", first: schemaURLv1, last: schemaURLv1, policy: SchemaURLPolicyError, want: schemaURLv1},
+		{name: "empty_first", last: schemaURLv2, policy: SchemaURLPolicyKeepFirst, want: schemaURLv2},
+		{name: "empty_last", first: schemaURLv1, policy: SchemaURLPolicyKeepLast, want: schemaURLv1},
+		{name: "empty_error", last: schemaURLv2, policy: SchemaURLPolicyError, want: schemaURLv2},
+		{name: "both_empty", policy: SchemaURLPolicyError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MergeSchemaURL(tt.first, tt.last, tt.policy)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSchemaURLPolicyString(t *testing.T) {
+	assert.Equal(t, "KeepFirst", SchemaURLPolicyKeepFirst.String())
+	assert.Equal(t, "KeepLast", SchemaURLPolicyKeepLast.String())
+	assert.Equal(t, "Error", SchemaURLPolicyError.String())
+	assert.Equal(t, "", SchemaURLPolicy(100).String())
+}
@@ -4,9 +4,15 @@
 package pcommon // import "go.opentelemetry.io/collector/pdata/pcommon"
 
 import (
+	"fmt"
+	"strings"
+
 	"go.opentelemetry.io/collector/pdata/internal"
 )
 
+// maxTraceStateMembers is the maximum number of list-members of a tracestate.
+const maxTraceStateMembers = 32
+
 // TraceState represents the trace state from the w3c-trace-context.
 //
 // Must use NewTraceState function to create new instances.
@@ -51,3 +57,154 @@ func (ms TraceState) CopyTo(dest TraceState) {
 	dest.getState().AssertMutable()
 	*dest.getOrig() = *ms.getOrig()
 }
+
+// Get returns the value of the list-member of the tracestate with the key, and whether it exists.
+func (ms TraceState) Get(key string) (string, bool) {
+	for _, m := range parseTraceState(*ms.getOrig()) {
+		if m.key == key {
+			return m.value, true
+		}
+	}
+	return "", false
+}
+
+// Range calls f sequentially for each list-member of the tracestate, in order.
+// If f returns false, range stops the iteration.
+func (ms TraceState) Range(f func(key string, value string) bool) {
+	for _, m := range parseTraceState(*ms.getOrig()) {
+		if !f(m.key, m.value) {
+			return
+		}
+	}
+}
+
+// Len returns the number of list-members of the tracestate.
+func (ms TraceState) Len() int {
+	return len(parseTraceState(*ms.getOrig()))
+}
+
+// Put sets the value of the list-member with the key, and moves it to the beginning of the tracestate, as
+// the w3c-trace-context requires for updated list-members. If the tracestate then has more than 32
+// list-members, the last ones are removed. The tracestate is normalized, as by Normalize.
+// It returns an error, leaving the tracestate unchanged, if the key or the value is not valid.
+func (ms TraceState) Put(key string, value string) error {
+	ms.getState().AssertMutable()
+	if !isValidTraceStateKey(key) {
+		return fmt.Errorf("invalid tracestate key %q", key)
+	}
+	if !isValidTraceStateValue(value) {
+		return fmt.Errorf("invalid tracestate value %q", value)
+	}
+	members := []traceStateMember{{key: key, value: value}}
+	for _, m := range parseTraceState(*ms.getOrig()) {
+		if m.key != key {
+			members = append(members, m)
+		}
+	}
+	*ms.getOrig() = formatTraceState(members)
+	return nil
+}
+
+// Remove removes the list-member with the key from the tracestate, and returns whether it existed.
+// The tracestate is normalized, as by Normalize.
+func (ms TraceState) Remove(key string) bool {
+	ms.getState().AssertMutable()
+	members := parseTraceState(*ms.getOrig())
+	removed := false
+	for i, m := range members {
+		if m.key == key {
+			members = append(members[:i], members[i+1:]...)
+			removed = true
+			break
+		}
+	}
+	*ms.getOrig() = formatTraceState(members)
+	return removed
+}
+
+// Normalize rewrites the tracestate without the optional whitespaces and the empty list-members, and
+// removes the malformed list-members, the duplicates of a key after its first list-member, and the
+// list-members beyond the first 32. The order of the remaining list-members is preserved.
+func (ms TraceState) Normalize() {
+	ms.getState().AssertMutable()
+	*ms.getOrig() = formatTraceState(parseTraceState(*ms.getOrig()))
+}
+
+type traceStateMember struct {
+	key   string
+	value string
+}
+
+// parseTraceState returns the valid list-members of the tracestate s in order, skipping the duplicates of
+// a key after its first list-member.
+func parseTraceState(s string) []traceStateMember {
+	var members []traceStateMember
+	seen := map[string]bool{}
+	for _, member := range strings.Split(s, ",") {
+		member = strings.Trim(member, " \t")
+		key, value, ok := strings.Cut(member, "=")
+		if !ok || !isValidTraceStateKey(key) || !isValidTraceStateValue(value) || seen[key] {
+			continue
+		}
+		seen[key] = true
+		members = append(members, traceStateMember{key: key, value: value})
+	}
+	return members
+}
+
+func formatTraceState(members []traceStateMember) string {
+	if len(members) > maxTraceStateMembers {
+		members = members[:maxTraceStateMembers]
+	}
+	var sb strings.Builder
+	for i, m := range members {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(m.key)
+		sb.WriteByte('=')
+		sb.WriteString(m.value)
+	}
+	return sb.String()
+}
+
+// isValidTraceStateKey returns whether key is a simple-key, or a multi-tenant-key of the form tenant-id@system-id.
+func isValidTraceStateKey(key string) bool {
+	tenant, system, multiTenant := strings.Cut(key, "@")
+	if !multiTenant {
+		return len(key) <= 256 && isLowerAlpha(key, 0) && isTraceStateKeyChars(key)
+	}
+	return len(tenant) >= 1 && len(tenant) <= 241 && (isLowerAlpha(tenant, 0) || isDigit(tenant, 0)) && isTraceStateKeyChars(tenant) &&
+		len(system) <= 14 && isLowerAlpha(system, 0) && isTraceStateKeyChars(system)
+}
+
+func isLowerAlpha(s string, i int) bool {
+	return i < len(s) && s[i] >= 'a' && s[i] <= 'z'
+}
+
+func isDigit(s string, i int) bool {
+	return i < len(s) && s[i] >= '0' && s[i] <= '9'
+}
+
+func isTraceStateKeyChars(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if !isLowerAlpha(s, i) && !isDigit(s, i) && s[i] != '_' && s[i] != '-' && s[i] != '*' && s[i] != '/' {
+			return false
+		}
+	}
+	return true
+}
+
+// isValidTraceStateValue returns whether value is made of 1 to 256 printable ASCII characters other than ','
+// and '=', and does not end with a space.
+func isValidTraceStateValue(value string) bool {
+	if len(value) == 0 || len(value) > 256 || value[len(value)-1] == ' ' {
+		return false
+	}
+	for i := 0; i < len(value); i++ {
+		if value[i] < 0x20 || value[i] > 0x7e || value[i] == ',' || value[i] == '=' {
+			return false
+		}
+	}
+	return true
+}
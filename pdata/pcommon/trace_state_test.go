@@ -4,9 +4,12 @@
 package pcommon
 
 import (
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/collector/pdata/internal"
 )
@@ -36,6 +39,97 @@ func TestTraceState_FromRaw_AsRaw(t *testing.T) {
 	assert.Equal(t, "congo=t61rcWkgMzE", ms.AsRaw())
 }
 
+func TestTraceState_Get_Range(t *testing.T) {
+	ms := NewTraceState()
+	ms.FromRaw("rojo=00f067aa0ba902b7, congo=t61rcWkgMzE,,tenant@vendor=x,invalid,rojo=duplicate")
+	assert.Equal(t, 3, ms.Len())
+
+	v, ok := ms.Get("congo")
+	assert.True(t, ok)
+	assert.Equal(t, "t61rcWkgMzE", v)
+	v, ok = ms.Get("rojo")
+	assert.True(t, ok)
+	assert.Equal(t, "00f067aa0ba902b7", v)
+	_, ok = ms.Get("invalid")
+	assert.False(t, ok)
+
+	var keys []string
+	ms.Range(func(k string, _ string) bool {
+		keys = append(keys, k)
+		return true
+	})
+	assert.Equal(t, []string{"rojo", "congo", "tenant@vendor"}, keys)
+
+	keys = nil
+	ms.Range(func(k string, _ string) bool {
+		keys = append(keys, k)
+		return false
+	})
+	assert.Equal(t, []string{"rojo"}, keys)
+}
+
+func TestTraceState_Put(t *testing.T) {
+	ms := NewTraceState()
+	ms.FromRaw("rojo=00f067aa0ba902b7, congo=t61rcWkgMzE,ot=th:8")
+
+	// An updated list-member is moved to the beginning, the others keep their order.
+	require.NoError(t, ms.Put("congo", "ucfJifl5GOE"))
+	assert.Equal(t, "congo=ucfJifl5GOE,rojo=00f067aa0ba902b7,ot=th:8", ms.AsRaw())
+	require.NoError(t, ms.Put("new", "value"))
+	assert.Equal(t, "new=value,congo=ucfJifl5GOE,rojo=00f067aa0ba902b7,ot=th:8", ms.AsRaw())
+
+	assert.EqualError(t, ms.Put("Invalid", "value"), `invalid tracestate key "Invalid"`)
+	assert.EqualError(t, ms.Put("key", "a=b"), `invalid tracestate value "a=b"`)
+	assert.EqualError(t, ms.Put("key", "trailing "), `invalid tracestate value "trailing "`)
+	assert.EqualError(t, ms.Put("key", ""), `invalid tracestate value ""`)
+	assert.Equal(t, "new=value,congo=ucfJifl5GOE,rojo=00f067aa0ba902b7,ot=th:8", ms.AsRaw())
+}
+
+func TestTraceState_PutMaxMembers(t *testing.T) {
+	members := make([]string, maxTraceStateMembers)
+	for i := range members {
+		members[i] = "k" + strconv.Itoa(i) + "=v"
+	}
+	ms := NewTraceState()
+	ms.FromRaw(strings.Join(members, ","))
+
+	require.NoError(t, ms.Put("first", "v"))
+	assert.Equal(t, maxTraceStateMembers, ms.Len())
+	_, ok := ms.Get("k31")
+	assert.False(t, ok)
+	_, ok = ms.Get("k30")
+	assert.True(t, ok)
+}
+
+func TestTraceState_Remove(t *testing.T) {
+	ms := NewTraceState()
+	ms.FromRaw("rojo=00f067aa0ba902b7, congo=t61rcWkgMzE,ot=th:8")
+	assert.True(t, ms.Remove("congo"))
+	assert.Equal(t, "rojo=00f067aa0ba902b7,ot=th:8", ms.AsRaw())
+	assert.False(t, ms.Remove("congo"))
+	assert.Equal(t, "rojo=00f067aa0ba902b7,ot=th:8", ms.AsRaw())
+}
+
+func TestTraceState_Normalize(t *testing.T) {
+	ms := NewTraceState()
+	ms.FromRaw(" rojo=00f067aa0ba902b7 ,\tcongo=t61rcWkgMzE, ,key=a=b,rojo=dup,1tenant@vendor=v")
+	ms.Normalize()
+	assert.Equal(t, "rojo=00f067aa0ba902b7,congo=t61rcWkgMzE,1tenant@vendor=v", ms.AsRaw())
+
+	ms.FromRaw("")
+	ms.Normalize()
+	assert.Equal(t, "", ms.AsRaw())
+}
+
+func TestIsValidTraceStateKey(t *testing.T) {
+	for _, key := range []string{"a", "congo", "a_b-c*d/e", "tenant@vendor", "0tenant@v", strings.Repeat("a", 256)} {
+		assert.True(t, isValidTraceStateKey(key), key)
+	}
+	for _, key := range []string{"", "Congo", "0congo", "a b", "@vendor", "tenant@", "tenant@0vendor", "tenant@" + strings.Repeat("v", 15), strings.Repeat("a", 257)} {
+		assert.False(t, isValidTraceStateKey(key), key)
+	}
+}
+
 func TestInvalidTraceState(t *testing.T) {
 	v := TraceState{}
 
@@ -43,4 +137,6 @@ func TestInvalidTraceState(t *testing.T) {
 	assert.Panics(t, func() { v.FromRaw("") })
 	assert.Panics(t, func() { v.MoveTo(TraceState{}) })
 	assert.Panics(t, func() { v.CopyTo(TraceState{}) })
+	assert.Panics(t, func() { _, _ = v.Get("key") })
+	assert.Panics(t, func() { _ = v.Put("key", "value") })
 }
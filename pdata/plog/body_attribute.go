@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package plog // import "go.opentelemetry.io/collector/pdata/plog"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// CopyBodyToAttribute copies the body of every log record having a string body into the attribute key,
+// overwriting any existing value. If clearBody is true, the body of these log records is then cleared.
+// The log records with a body of any other type are left untouched.
+// It returns the number of log records whose body was copied.
+func (ms Logs) CopyBodyToAttribute(key string, clearBody bool) int {
+	copied := 0
+	rls := ms.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		sls := rls.At(i).ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			lrs := sls.At(j).LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				lr := lrs.At(k)
+				if lr.Body().Type() != pcommon.ValueTypeStr {
+					continue
+				}
+				lr.Attributes().PutStr(key, lr.Body().Str())
+				if clearBody {
+					pcommon.NewValueEmpty().CopyTo(lr.Body())
+				}
+				copied++
+			}
+		}
+	}
+	return copied
+}
@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package plog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func generateBodyLogs() Logs {
+	ld := NewLogs()
+	lrs := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	lrs.AppendEmpty().Body().SetStr("first message")
+	lrs.AppendEmpty().Body().SetEmptyMap().PutStr("structured", "message")
+	lrs.AppendEmpty()
+	lr := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	lr.Body().SetStr("second message")
+	lr.Attributes().PutInt("message", 1)
+	return ld
+}
+
+func TestCopyBodyToAttribute(t *testing.T) {
+	ld := generateBodyLogs()
+	assert.Equal(t, 2, ld.CopyBodyToAttribute("message", false))
+
+	lrs := ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	assert.Equal(t, map[string]any{"message": "first message"}, lrs.At(0).Attributes().AsRaw())
+	assert.Equal(t, "first message", lrs.At(0).Body().Str())
+
+	// Non-string bodies are left untouched.
+	assert.Equal(t, 0, lrs.At(1).Attributes().Len())
+	assert.Equal(t, map[string]any{"structured": "message"}, lrs.At(1).Body().Map().AsRaw())
+	assert.Equal(t, 0, lrs.At(2).Attributes().Len())
+	assert.Equal(t, pcommon.ValueTypeEmpty, lrs.At(2).Body().Type())
+
+	// Existing attributes are overwritten.
+	lr := ld.ResourceLogs().At(1).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, map[string]any{"message": "second message"}, lr.Attributes().AsRaw())
+	assert.Equal(t, "second message", lr.Body().Str())
+}
+
+func TestCopyBodyToAttributeClearBody(t *testing.T) {
+	ld := generateBodyLogs()
+	assert.Equal(t, 2, ld.CopyBodyToAttribute("message", true))
+
+	lrs := ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	assert.Equal(t, map[string]any{"message": "first message"}, lrs.At(0).Attributes().AsRaw())
+	assert.Equal(t, pcommon.ValueTypeEmpty, lrs.At(0).Body().Type())
+	assert.Equal(t, map[string]any{"structured": "message"}, lrs.At(1).Body().Map().AsRaw())
+
+	lr := ld.ResourceLogs().At(1).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, map[string]any{"message": "second message"}, lr.Attributes().AsRaw())
+	assert.Equal(t, pcommon.ValueTypeEmpty, lr.Body().Type())
+
+	// The bodies were cleared, so nothing is left to copy.
+	assert.Equal(t, 0, ld.CopyBodyToAttribute("message", true))
+}
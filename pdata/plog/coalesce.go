@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package plog // import "go.opentelemetry.io/collector/pdata/plog"
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// RecordCountAttribute is the attribute holding the number of identical log records coalesced into a record
+// by CoalesceRecords.
+const RecordCountAttribute = "otel.record.count"
+
+type coalesceKey struct {
+	body       pcommon.Fingerprint
+	attributes pcommon.Fingerprint
+}
+
+type coalescedRecord struct {
+	record LogRecord
+	// start is the time of the record, starting its window.
+	start pcommon.Timestamp
+	count int64
+}
+
+// CoalesceRecords coalesces the log records of each ScopeLogs of ms with the same body and attributes into
+// the first one, which is given the number of records it represents in the RecordCountAttribute attribute.
+// The other fields of the coalesced records, e.g. their severity or trace context, are dropped.
+//
+// A record is coalesced into the last identical record kept before it if its time is within the window
+// starting at the time of that record, the time of a record being its Timestamp, or its ObservedTimestamp
+// if it has none. Otherwise, the record is kept and starts a new window. There is no window if window is
+// zero or negative. The RecordCountAttribute attribute of the records, e.g. already coalesced upstream, is
+// ignored to identify them, and counted. It returns the number of removed records.
+func (ms Logs) CoalesceRecords(window time.Duration) int {
+	removed := 0
+	rls := ms.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		sls := rls.At(i).ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			removed += coalesceRecords(sls.At(j).LogRecords(), window)
+		}
+	}
+	return removed
+}
+
+func coalesceRecords(lrs LogRecordSlice, window time.Duration) int {
+	removed := 0
+	last := make(map[coalesceKey]*coalescedRecord)
+	var kept []*coalescedRecord
+	lrs.RemoveIf(func(lr LogRecord) bool {
+		key := coalesceKey{body: lr.Body().Fingerprint(), attributes: attributesFingerprint(lr.Attributes())}
+		ts := lr.Timestamp()
+		if ts == 0 {
+			ts = lr.ObservedTimestamp()
+		}
+		count := recordCount(lr)
+		if c, ok := last[key]; ok && (window <= 0 || ts >= c.start && ts-c.start < pcommon.Timestamp(window)) {
+			c.count += count
+			removed++
+			return true
+		}
+		c := &coalescedRecord{record: lr, start: ts, count: count}
+		last[key] = c
+		kept = append(kept, c)
+		return false
+	})
+	for _, c := range kept {
+		if c.count > 1 {
+			c.record.Attributes().PutInt(RecordCountAttribute, c.count)
+		}
+	}
+	return removed
+}
+
+// attributesFingerprint returns the fingerprint of attrs without the RecordCountAttribute attribute.
+func attributesFingerprint(attrs pcommon.Map) pcommon.Fingerprint {
+	if _, ok := attrs.Get(RecordCountAttribute); !ok {
+		return attrs.Fingerprint()
+	}
+	withoutCount := pcommon.NewMap()
+	attrs.CopyTo(withoutCount)
+	withoutCount.Remove(RecordCountAttribute)
+	return withoutCount.Fingerprint()
+}
+
+// recordCount returns the number of records represented by lr, in its RecordCountAttribute attribute if positive.
+func recordCount(lr LogRecord) int64 {
+	if v, ok := lr.Attributes().Get(RecordCountAttribute); ok && v.Type() == pcommon.ValueTypeInt && v.Int() > 0 {
+		return v.Int()
+	}
+	return 1
+}
@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package plog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func appendRecord(t *testing.T, sl ScopeLogs, body string, ts time.Duration, attrs map[string]any) {
+	lr := sl.LogRecords().AppendEmpty()
+	lr.Body().SetStr(body)
+	lr.SetTimestamp(pcommon.Timestamp(ts))
+	require.NoError(t, lr.Attributes().FromRaw(attrs))
+}
+
+func recordsOf(sl ScopeLogs) []map[string]any {
+	var records []map[string]any
+	for i := 0; i < sl.LogRecords().Len(); i++ {
+		lr := sl.LogRecords().At(i)
+		records = append(records, map[string]any{"body": lr.Body().Str(), "attributes": lr.Attributes().AsRaw()})
+	}
+	return records
+}
+
+func TestCoalesceRecords(t *testing.T) {
+	ld := NewLogs()
+	sl := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty()
+	for i := 0; i < 3; i++ {
+		appendRecord(t, sl, "connection refused", time.Duration(i)*time.Second, map[string]any{"host": "a"})
+	}
+	appendRecord(t, sl, "connection refused", time.Second, map[string]any{"host": "b"})
+	appendRecord(t, sl, "timeout", 2*time.Second, map[string]any{"host": "a"})
+	appendRecord(t, sl, "connection refused", 3*time.Second, map[string]any{"host": "a"})
+	// Identical records of another scope are not coalesced.
+	other := ld.ResourceLogs().At(0).ScopeLogs().AppendEmpty()
+	appendRecord(t, other, "connection refused", 0, map[string]any{"host": "a"})
+
+	assert.Equal(t, 3, ld.CoalesceRecords(0))
+	assert.Equal(t, []map[string]any{
+		{"body": "connection refused", "attributes": map[string]any{"host": "a", RecordCountAttribute: int64(4)}},
+		{"body": "connection refused", "attributes": map[string]any{"host": "b"}},
+		{"body": "timeout", "attributes": map[string]any{"host": "a"}},
+	}, recordsOf(sl))
+	assert.Equal(t, pcommon.Timestamp(0), sl.LogRecords().At(0).Timestamp())
+	assert.Equal(t, []map[string]any{
+		{"body": "connection refused", "attributes": map[string]any{"host": "a"}},
+	}, recordsOf(other))
+}
+
+func TestCoalesceRecordsWindow(t *testing.T) {
+	ld := NewLogs()
+	sl := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty()
+	for _, ts := range []time.Duration{0, 4 * time.Second, 5 * time.Second, 6 * time.Second, 12 * time.Second} {
+		appendRecord(t, sl, "retrying", ts, nil)
+	}
+
+	assert.Equal(t, 2, ld.CoalesceRecords(5*time.Second))
+	require.Equal(t, 3, sl.LogRecords().Len())
+	// The record at 5s is out of the window starting at 0, and starts a new one.
+	assert.Equal(t, map[string]any{RecordCountAttribute: int64(2)}, sl.LogRecords().At(0).Attributes().AsRaw())
+	assert.Equal(t, pcommon.Timestamp(5*time.Second), sl.LogRecords().At(1).Timestamp())
+	assert.Equal(t, map[string]any{RecordCountAttribute: int64(2)}, sl.LogRecords().At(1).Attributes().AsRaw())
+	assert.Equal(t, pcommon.Timestamp(12*time.Second), sl.LogRecords().At(2).Timestamp())
+	assert.Equal(t, map[string]any{}, sl.LogRecords().At(2).Attributes().AsRaw())
+}
+
+func TestCoalesceRecordsObservedTimestamp(t *testing.T) {
+	ld := NewLogs()
+	sl := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty()
+	for _, ts := range []time.Duration{0, 10 * time.Second} {
+		lr := sl.LogRecords().AppendEmpty()
+		lr.Body().SetStr("retrying")
+		lr.SetObservedTimestamp(pcommon.Timestamp(ts))
+	}
+	assert.Zero(t, ld.CoalesceRecords(time.Second))
+	assert.Equal(t, 2, sl.LogRecords().Len())
+}
+
+func TestCoalesceRecordsAlreadyCoalesced(t *testing.T) {
+	ld := NewLogs()
+	sl := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty()
+	appendRecord(t, sl, "disk full", 0, map[string]any{RecordCountAttribute: int64(3)})
+	appendRecord(t, sl, "disk full", 0, nil)
+	appendRecord(t, sl, "disk full", 0, map[string]any{RecordCountAttribute: int64(2)})
+
+	assert.Equal(t, 2, ld.CoalesceRecords(0))
+	assert.Equal(t, []map[string]any{
+		{"body": "disk full", "attributes": map[string]any{RecordCountAttribute: int64(6)}},
+	}, recordsOf(sl))
+}
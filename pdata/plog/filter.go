@@ -0,0 +1,45 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package plog // import "go.opentelemetry.io/collector/pdata/plog"
+
+import "go.opentelemetry.io/collector/pdata/pcommon"
+
+// Filter returns new Logs with a copy of the log records of ms for which keep returns true, with a copy of their
+// resource and scope. The resources and scopes without any log record kept are not copied. ms is not modified.
+func (ms Logs) Filter(keep func(pcommon.Resource, pcommon.InstrumentationScope, LogRecord) bool) Logs {
+	filtered := NewLogs()
+	var destRs ResourceLogs
+	var destLogRecords LogRecordSlice
+	rss := ms.ResourceLogs()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		newResource := true
+		sss := rs.ScopeLogs()
+		for j := 0; j < sss.Len(); j++ {
+			ss := sss.At(j)
+			newScope := true
+			items := ss.LogRecords()
+			for k := 0; k < items.Len(); k++ {
+				if !keep(rs.Resource(), ss.Scope(), items.At(k)) {
+					continue
+				}
+				if newResource {
+					destRs = filtered.ResourceLogs().AppendEmpty()
+					rs.Resource().CopyTo(destRs.Resource())
+					destRs.SetSchemaUrl(rs.SchemaUrl())
+					newResource = false
+				}
+				if newScope {
+					destSs := destRs.ScopeLogs().AppendEmpty()
+					ss.Scope().CopyTo(destSs.Scope())
+					destSs.SetSchemaUrl(ss.SchemaUrl())
+					destLogRecords = destSs.LogRecords()
+					newScope = false
+				}
+				items.At(k).CopyTo(destLogRecords.AppendEmpty())
+			}
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package plog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// generateFilterLogs returns Logs with two resources of two scopes of three log records named by their
+// resource, scope and index, keeping only the log records for which keep returns true if it is not nil.
+func generateFilterLogs(keep func(r, s, i int) bool) Logs {
+	ld := NewLogs()
+	for r := 0; r < 2; r++ {
+		var rs ResourceLogs
+		for s := 0; s < 2; s++ {
+			var ss ScopeLogs
+			for i := 0; i < 3; i++ {
+				if keep != nil && !keep(r, s, i) {
+					continue
+				}
+				if ss == (ScopeLogs{}) {
+					if rs == (ResourceLogs{}) {
+						rs = ld.ResourceLogs().AppendEmpty()
+						rs.SetSchemaUrl("https://opentelemetry.io/schemas/1.24.0")
+						rs.Resource().Attributes().PutInt("resource", int64(r))
+					}
+					ss = rs.ScopeLogs().AppendEmpty()
+					ss.SetSchemaUrl("https://opentelemetry.io/schemas/1.25.0")
+					ss.Scope().SetName("scope")
+					ss.Scope().SetVersion(string(rune('a' + s)))
+				}
+				lr := ss.LogRecords().AppendEmpty()
+				lr.Body().SetStr(string(rune('a'+r)) + string(rune('a'+s)) + string(rune('a'+i)))
+				lr.Attributes().PutInt("index", int64(i))
+			}
+		}
+	}
+	return ld
+}
+
+func TestLogsFilter(t *testing.T) {
+	ld := generateFilterLogs(nil)
+	filtered := ld.Filter(func(res pcommon.Resource, scope pcommon.InstrumentationScope, lr LogRecord) bool {
+		r, _ := res.Attributes().Get("resource")
+		return r.Int() == 1 && scope.Version() == "b" || lr.Body().Str()[2] == 'a'
+	})
+	assert.Equal(t, generateFilterLogs(func(r, s, i int) bool {
+		return r == 1 && s == 1 || i == 0
+	}), filtered)
+	// The source is unchanged.
+	assert.Equal(t, generateFilterLogs(nil), ld)
+}
+
+func TestLogsFilterNone(t *testing.T) {
+	ld := generateFilterLogs(nil)
+	filtered := ld.Filter(func(pcommon.Resource, pcommon.InstrumentationScope, LogRecord) bool {
+		return false
+	})
+	assert.Equal(t, NewLogs(), filtered)
+	assert.Equal(t, 12, ld.LogRecordCount())
+}
@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package plog // import "go.opentelemetry.io/collector/pdata/plog"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+type resourceKey struct {
+	fingerprint pcommon.Fingerprint
+	schemaURL   string
+}
+
+// GroupByResource merges the ResourceLogs having an identical resource and schema URL,
+// identified by the resource Fingerprint, so each distinct resource appears only once.
+// The ScopeLogs of the duplicates are appended, in order, to the first ResourceLogs
+// with the same resource, which keeps its position in the batch.
+func (ms Logs) GroupByResource() {
+	rls := ms.ResourceLogs()
+	if rls.Len() < 2 {
+		return
+	}
+	first := make(map[resourceKey]ResourceLogs, rls.Len())
+	rls.RemoveIf(func(rl ResourceLogs) bool {
+		key := resourceKey{fingerprint: rl.Resource().Fingerprint(), schemaURL: rl.SchemaUrl()}
+		dest, ok := first[key]
+		if !ok {
+			first[key] = rl
+			return false
+		}
+		rl.ScopeLogs().MoveAndAppendTo(dest.ScopeLogs())
+		return true
+	})
+}
+
+type scopeKey struct {
+	name       string
+	version    string
+	schemaURL  string
+	attributes pcommon.Fingerprint
+	dropped    uint32
+}
+
+// GroupByScope merges, within each ResourceLogs, the ScopeLogs having an identical instrumentation scope and
+// schema URL, i.e. the same name, version, attributes and dropped attributes count, so each distinct
+// scope appears only once per resource. The log records of the duplicates are appended, in order, to the
+// first ScopeLogs with the same scope, which keeps its position in the resource.
+func (ms Logs) GroupByScope() {
+	rss := ms.ResourceLogs()
+	for i := 0; i < rss.Len(); i++ {
+		sss := rss.At(i).ScopeLogs()
+		if sss.Len() < 2 {
+			continue
+		}
+		first := make(map[scopeKey]ScopeLogs, sss.Len())
+		sss.RemoveIf(func(ss ScopeLogs) bool {
+			scope := ss.Scope()
+			key := scopeKey{
+				name:       scope.Name(),
+				version:    scope.Version(),
+				schemaURL:  ss.SchemaUrl(),
+				attributes: scope.Attributes().Fingerprint(),
+				dropped:    scope.DroppedAttributesCount(),
+			}
+			dest, ok := first[key]
+			if !ok {
+				first[key] = ss
+				return false
+			}
+			ss.LogRecords().MoveAndAppendTo(dest.LogRecords())
+			return true
+		})
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package plog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func appendResourceLogs(ld Logs, service string, body string) ResourceLogs {
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", service)
+	rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr(body)
+	return rl
+}
+
+func TestGroupByResource(t *testing.T) {
+	ld := NewLogs()
+	appendResourceLogs(ld, "a", "a1")
+	appendResourceLogs(ld, "b", "b1")
+	appendResourceLogs(ld, "a", "a2")
+	appendResourceLogs(ld, "c", "c1")
+	appendResourceLogs(ld, "b", "b2")
+	appendResourceLogs(ld, "a", "a3")
+
+	ld.GroupByResource()
+
+	assert.Equal(t, 6, ld.LogRecordCount())
+	rls := ld.ResourceLogs()
+	assert.Equal(t, 3, rls.Len())
+	expected := map[string][]string{
+		"a": {"a1", "a2", "a3"},
+		"b": {"b1", "b2"},
+		"c": {"c1"},
+	}
+	for i, service := range []string{"a", "b", "c"} {
+		rl := rls.At(i)
+		name, _ := rl.Resource().Attributes().Get("service.name")
+		assert.Equal(t, service, name.Str())
+		var bodies []string
+		for j := 0; j < rl.ScopeLogs().Len(); j++ {
+			lrs := rl.ScopeLogs().At(j).LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				bodies = append(bodies, lrs.At(k).Body().Str())
+			}
+		}
+		assert.Equal(t, expected[service], bodies)
+	}
+}
+
+func TestGroupByResourceSchemaURL(t *testing.T) {
+	ld := NewLogs()
+	appendResourceLogs(ld, "a", "a1").SetSchemaUrl("https://opentelemetry.io/schemas/1.20.0")
+	appendResourceLogs(ld, "a", "a2").SetSchemaUrl("https://opentelemetry.io/schemas/1.21.0")
+
+	ld.GroupByResource()
+
+	assert.Equal(t, 2, ld.ResourceLogs().Len())
+}
+
+func TestGroupByResourceNoDuplicates(t *testing.T) {
+	ld := NewLogs()
+	appendResourceLogs(ld, "a", "a1")
+	appendResourceLogs(ld, "b", "b1")
+	expected := NewLogs()
+	ld.CopyTo(expected)
+
+	ld.GroupByResource()
+
+	assert.Equal(t, expected, ld)
+}
+
+func appendScopeLogs(rl ResourceLogs, scope, version string, bodies ...string) ScopeLogs {
+	sl := rl.ScopeLogs().AppendEmpty()
+	sl.Scope().SetName(scope)
+	sl.Scope().SetVersion(version)
+	for _, body := range bodies {
+		sl.LogRecords().AppendEmpty().Body().SetStr(body)
+	}
+	return sl
+}
+
+func TestGroupByScope(t *testing.T) {
+	ld := NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	appendScopeLogs(rl, "a", "1.0", "a1", "a2")
+	appendScopeLogs(rl, "b", "1.0", "b1")
+	appendScopeLogs(rl, "a", "1.0", "a3")
+	appendScopeLogs(rl, "a", "2.0", "a4")
+	appendScopeLogs(rl, "b", "1.0", "b2")
+	appendScopeLogs(rl, "a", "1.0", "a5").Scope().Attributes().PutStr("k", "v")
+	appendScopeLogs(rl, "a", "1.0", "a6").SetSchemaUrl("https://opentelemetry.io/schemas/1.21.0")
+	appendScopeLogs(rl, "a", "1.0", "a7").Scope().Attributes().PutStr("k", "v")
+	// Scopes are only merged within the same resource.
+	other := ld.ResourceLogs().AppendEmpty()
+	appendScopeLogs(other, "a", "1.0", "o1")
+
+	ld.GroupByScope()
+
+	assert.Equal(t, 10, ld.LogRecordCount())
+	var got [][]string
+	for i := 0; i < rl.ScopeLogs().Len(); i++ {
+		var bodies []string
+		lrs := rl.ScopeLogs().At(i).LogRecords()
+		for j := 0; j < lrs.Len(); j++ {
+			bodies = append(bodies, lrs.At(j).Body().Str())
+		}
+		got = append(got, bodies)
+	}
+	assert.Equal(t, [][]string{{"a1", "a2", "a3"}, {"b1", "b2"}, {"a4"}, {"a5", "a7"}, {"a6"}}, got)
+	assert.Equal(t, "a", rl.ScopeLogs().At(0).Scope().Name())
+	assert.Equal(t, "b", rl.ScopeLogs().At(1).Scope().Name())
+	assert.Equal(t, "2.0", rl.ScopeLogs().At(2).Scope().Version())
+	assert.Equal(t, 1, rl.ScopeLogs().At(3).Scope().Attributes().Len())
+	assert.Equal(t, "https://opentelemetry.io/schemas/1.21.0", rl.ScopeLogs().At(4).SchemaUrl())
+	assert.Equal(t, 1, other.ScopeLogs().Len())
+}
@@ -55,6 +55,21 @@ func (ms Logs) LogRecordCount() int {
 	return logCount
 }
 
+// LogRecordCountByScope calculates the number of log records per instrumentation scope name.
+// The log records of the scopes with the same name, across resources or versions, are counted together.
+func (ms Logs) LogRecordCountByScope() map[string]int {
+	counts := make(map[string]int)
+	rls := ms.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		sls := rls.At(i).ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			sl := sls.At(j)
+			counts[sl.Scope().Name()] += sl.LogRecords().Len()
+		}
+	}
+	return counts
+}
+
 // ResourceLogs returns the ResourceLogsSlice associated with this Logs.
 func (ms Logs) ResourceLogs() ResourceLogsSlice {
 	return newResourceLogsSlice(&ms.getOrig().ResourceLogs, internal.GetLogsState(internal.Logs(ms)))
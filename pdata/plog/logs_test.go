@@ -41,6 +41,27 @@ func TestLogRecordCount(t *testing.T) {
 	assert.EqualValues(t, 6, logs.LogRecordCount())
 }
 
+func TestLogRecordCountByScope(t *testing.T) {
+	logs := NewLogs()
+	assert.Empty(t, logs.LogRecordCountByScope())
+
+	sls := logs.ResourceLogs().AppendEmpty().ScopeLogs()
+	sl := sls.AppendEmpty()
+	sl.Scope().SetName("app")
+	sl.LogRecords().AppendEmpty()
+	sl.LogRecords().AppendEmpty()
+	sl = sls.AppendEmpty()
+	sl.Scope().SetName("runtime")
+	sl.LogRecords().AppendEmpty()
+
+	// The scopes with the same name are counted together, also across resources.
+	sl = logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty()
+	sl.Scope().SetName("app")
+	sl.LogRecords().AppendEmpty()
+
+	assert.Equal(t, map[string]int{"app": 3, "runtime": 1}, logs.LogRecordCountByScope())
+}
+
 func TestLogRecordCountWithEmpty(t *testing.T) {
 	assert.Zero(t, NewLogs().LogRecordCount())
 	assert.Zero(t, newLogs(&otlpcollectorlog.ExportLogsServiceRequest{
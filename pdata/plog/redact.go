@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package plog // import "go.opentelemetry.io/collector/pdata/plog"
+
+import (
+	"regexp"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// Redact replaces the parts of the string values matching any of the patterns with the replacement, in the
+// resource, scope and log record attributes and in the log record bodies. Values nested in maps and slices
+// are redacted too. The replacement is applied as in regexp.Regexp.ReplaceAllString, so it can refer to the
+// submatches of the pattern. The patterns are applied in order, each one to the result of the previous one.
+// It returns the number of values which were modified.
+func (ms Logs) Redact(patterns []*regexp.Regexp, replacement string) int {
+	if len(patterns) == 0 {
+		return 0
+	}
+	r := redactor{patterns: patterns, replacement: replacement}
+	rls := ms.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		r.redactMap(rl.Resource().Attributes())
+		sls := rl.ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			sl := sls.At(j)
+			r.redactMap(sl.Scope().Attributes())
+			lrs := sl.LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				lr := lrs.At(k)
+				r.redactMap(lr.Attributes())
+				r.redactValue(lr.Body())
+			}
+		}
+	}
+	return r.redacted
+}
+
+type redactor struct {
+	patterns    []*regexp.Regexp
+	replacement string
+	redacted    int
+}
+
+func (r *redactor) redactMap(m pcommon.Map) {
+	m.Range(func(_ string, v pcommon.Value) bool {
+		r.redactValue(v)
+		return true
+	})
+}
+
+func (r *redactor) redactValue(v pcommon.Value) {
+	switch v.Type() {
+	case pcommon.ValueTypeStr:
+		str := v.Str()
+		redacted := str
+		for _, p := range r.patterns {
+			if p.MatchString(redacted) {
+				redacted = p.ReplaceAllString(redacted, r.replacement)
+			}
+		}
+		if redacted != str {
+			v.SetStr(redacted)
+			r.redacted++
+		}
+	case pcommon.ValueTypeMap:
+		r.redactMap(v.Map())
+	case pcommon.ValueTypeSlice:
+		s := v.Slice()
+		for i := 0; i < s.Len(); i++ {
+			r.redactValue(s.At(i))
+		}
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package plog
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var creditCardPattern = regexp.MustCompile(`\b\d{4}[- ]?\d{4}[- ]?\d{4}[- ]?\d{4}\b`)
+
+func TestLogsRedact(t *testing.T) {
+	ld := NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("owner", "card 4111-1111-1111-1111")
+	rl.Resource().Attributes().PutStr("host", "server-1")
+	sl := rl.ScopeLogs().AppendEmpty()
+	sl.Scope().Attributes().PutStr("token", "secret=abc123")
+	lr := sl.LogRecords().AppendEmpty()
+	lr.Body().SetStr("payment with 4111 1111 1111 1111 accepted, token secret=xyz")
+	lr.Attributes().PutInt("amount", 42)
+	lr.Attributes().PutStr("status", "accepted")
+	nested := lr.Attributes().PutEmptyMap("card")
+	nested.PutStr("number", "4111111111111111")
+	nested.PutEmptySlice("history").AppendEmpty().SetStr("4111111111111111 and 4222222222222222")
+	lr = sl.LogRecords().AppendEmpty()
+	lr.Body().SetEmptyMap().PutStr("message", "secret=qwerty")
+	lr = sl.LogRecords().AppendEmpty()
+	lr.Body().SetStr("nothing to hide")
+
+	patterns := []*regexp.Regexp{creditCardPattern, regexp.MustCompile(`secret=(\w+)`)}
+	assert.Equal(t, 6, ld.Redact(patterns, "***"))
+
+	assert.Equal(t, map[string]any{"owner": "card ***", "host": "server-1"}, rl.Resource().Attributes().AsRaw())
+	assert.Equal(t, map[string]any{"token": "***"}, sl.Scope().Attributes().AsRaw())
+	lrs := sl.LogRecords()
+	assert.Equal(t, "payment with *** accepted, token ***", lrs.At(0).Body().Str())
+	assert.Equal(t, map[string]any{
+		"amount": int64(42),
+		"status": "accepted",
+		"card": map[string]any{
+			"number":  "***",
+			"history": []any{"*** and ***"},
+		},
+	}, lrs.At(0).Attributes().AsRaw())
+	assert.Equal(t, map[string]any{"message": "***"}, lrs.At(1).Body().Map().AsRaw())
+	assert.Equal(t, "nothing to hide", lrs.At(2).Body().Str())
+}
+
+func TestLogsRedactSubmatch(t *testing.T) {
+	ld := NewLogs()
+	lr := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	lr.Body().SetStr("user=alice password=hunter2")
+	assert.Equal(t, 1, ld.Redact([]*regexp.Regexp{regexp.MustCompile(`(password)=\S+`)}, "$1=<redacted>"))
+	assert.Equal(t, "user=alice password=<redacted>", lr.Body().Str())
+}
+
+func TestLogsRedactNoPatterns(t *testing.T) {
+	ld := NewLogs()
+	lr := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	lr.Body().SetStr("4111-1111-1111-1111")
+	assert.Equal(t, 0, ld.Redact(nil, "***"))
+	assert.Equal(t, "4111-1111-1111-1111", lr.Body().Str())
+}
+
+func BenchmarkLogsRedact(b *testing.B) {
+	patterns := []*regexp.Regexp{creditCardPattern}
+	ld := NewLogs()
+	lrs := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	for i := 0; i < 100; i++ {
+		lr := lrs.AppendEmpty()
+		lr.Body().SetStr("a log line without sensitive data")
+		lr.Attributes().PutStr("http.url", "https://example.com/checkout")
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		ld.Redact(patterns, "***")
+	}
+}
@@ -0,0 +1,17 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package plog // import "go.opentelemetry.io/collector/pdata/plog"
+
+// AddResourceAttributeAliases adds the aliases of the canonical keys to the attributes of every resource,
+// as described by pcommon.Map.AddAliases, e.g. to emit both the old and new attribute keys during a semantic
+// conventions migration. If removeCanonical is true, the canonical keys are then removed.
+// It returns the number of aliases that were added across all resources.
+func (ms Logs) AddResourceAttributeAliases(aliases map[string]string, removeCanonical bool) int {
+	added := 0
+	rs := ms.ResourceLogs()
+	for i := 0; i < rs.Len(); i++ {
+		added += rs.At(i).Resource().Attributes().AddAliases(aliases, removeCanonical)
+	}
+	return added
+}
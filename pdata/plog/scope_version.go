@@ -0,0 +1,24 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package plog // import "go.opentelemetry.io/collector/pdata/plog"
+
+// SetDefaultScopeVersion sets the version of every instrumentation scope without a version to version.
+// The scopes which already have a version are left untouched.
+// It returns the number of scopes whose version was set.
+func (ms Logs) SetDefaultScopeVersion(version string) int {
+	set := 0
+	ss := ms.ResourceLogs()
+	for i := 0; i < ss.Len(); i++ {
+		sls := ss.At(i).ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			scope := sls.At(j).Scope()
+			if scope.Version() != "" {
+				continue
+			}
+			scope.SetVersion(version)
+			set++
+		}
+	}
+	return set
+}
@@ -0,0 +1,32 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package plog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetDefaultScopeVersion(t *testing.T) {
+	data := NewLogs()
+	assert.Equal(t, 0, data.SetDefaultScopeVersion("v1.0.0"))
+
+	sls := data.ResourceLogs().AppendEmpty().ScopeLogs()
+	sls.AppendEmpty().Scope().SetName("unversioned")
+	versioned := sls.AppendEmpty().Scope()
+	versioned.SetName("versioned")
+	versioned.SetVersion("v0.1.0")
+	data.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty()
+
+	assert.Equal(t, 2, data.SetDefaultScopeVersion("v1.0.0"))
+	assert.Equal(t, "v1.0.0", sls.At(0).Scope().Version())
+	assert.Equal(t, "unversioned", sls.At(0).Scope().Name())
+	assert.Equal(t, "v0.1.0", sls.At(1).Scope().Version())
+	assert.Equal(t, "v1.0.0", data.ResourceLogs().At(1).ScopeLogs().At(0).Scope().Version())
+
+	// All the scopes now have a version.
+	assert.Equal(t, 0, data.SetDefaultScopeVersion("v2.0.0"))
+	assert.Equal(t, "v1.0.0", sls.At(0).Scope().Version())
+}
@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package plog // import "go.opentelemetry.io/collector/pdata/plog"
+
+import (
+	"strings"
+)
+
+// severityTexts maps the upper case severity texts to their severity number: the names of the severity
+// numbers, e.g. INFO or ERROR2, and common aliases such as WARNING or CRITICAL.
+var severityTexts = func() map[string]SeverityNumber {
+	texts := map[string]SeverityNumber{
+		"DBG":           SeverityNumberDebug,
+		"INFORMATION":   SeverityNumberInfo,
+		"INFORMATIONAL": SeverityNumberInfo,
+		"NOTICE":        SeverityNumberInfo2,
+		"WARNING":       SeverityNumberWarn,
+		"ERR":           SeverityNumberError,
+		"CRIT":          SeverityNumberFatal,
+		"CRITICAL":      SeverityNumberFatal,
+		"ALERT":         SeverityNumberFatal2,
+		"EMERG":         SeverityNumberFatal3,
+		"EMERGENCY":     SeverityNumberFatal3,
+		"PANIC":         SeverityNumberFatal3,
+	}
+	for sn := SeverityNumberTrace; sn <= SeverityNumberFatal4; sn++ {
+		texts[strings.ToUpper(sn.String())] = sn
+	}
+	return texts
+}()
+
+// SetSeverityNumberFromText sets the severity number of every log record with a severity text but
+// no severity number from its text, compared case-insensitively and ignoring surrounding spaces.
+// The known texts are the names of the severity numbers, e.g. INFO, WARN or ERROR2, and common aliases
+// such as WARNING, ERR or CRITICAL. The log records with an unknown text get the severity number unknown,
+// so SeverityNumberUnspecified leaves them unset.
+// It returns the number of log records whose severity number was set.
+func (ms Logs) SetSeverityNumberFromText(unknown SeverityNumber) int {
+	set := 0
+	rls := ms.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		sls := rls.At(i).ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			lrs := sls.At(j).LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				lr := lrs.At(k)
+				if lr.SeverityNumber() != SeverityNumberUnspecified || lr.SeverityText() == "" {
+					continue
+				}
+				sn, ok := severityTexts[strings.ToUpper(strings.TrimSpace(lr.SeverityText()))]
+				if !ok {
+					sn = unknown
+				}
+				if sn == SeverityNumberUnspecified {
+					continue
+				}
+				lr.SetSeverityNumber(sn)
+				set++
+			}
+		}
+	}
+	return set
+}
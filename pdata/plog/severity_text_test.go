@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package plog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func generateSeverityTextLogs(texts ...string) Logs {
+	ld := NewLogs()
+	lrs := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	for _, text := range texts {
+		lrs.AppendEmpty().SetSeverityText(text)
+	}
+	return ld
+}
+
+func severityNumbers(ld Logs) []SeverityNumber {
+	var sns []SeverityNumber
+	lrs := ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	for i := 0; i < lrs.Len(); i++ {
+		sns = append(sns, lrs.At(i).SeverityNumber())
+	}
+	return sns
+}
+
+func TestSetSeverityNumberFromText(t *testing.T) {
+	tests := []struct {
+		text string
+		want SeverityNumber
+	}{
+		{text: "TRACE", want: SeverityNumberTrace},
+		{text: "debug", want: SeverityNumberDebug},
+		{text: "Info", want: SeverityNumberInfo},
+		{text: "INFO3", want: SeverityNumberInfo3},
+		{text: "warn", want: SeverityNumberWarn},
+		{text: "WARNING", want: SeverityNumberWarn},
+		{text: " error ", want: SeverityNumberError},
+		{text: "err", want: SeverityNumberError},
+		{text: "Fatal", want: SeverityNumberFatal},
+		{text: "FATAL4", want: SeverityNumberFatal4},
+		{text: "critical", want: SeverityNumberFatal},
+		{text: "notice", want: SeverityNumberInfo2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			ld := generateSeverityTextLogs(tt.text)
+			assert.Equal(t, 1, ld.SetSeverityNumberFromText(SeverityNumberUnspecified))
+			assert.Equal(t, []SeverityNumber{tt.want}, severityNumbers(ld))
+			// The severity text is kept.
+			assert.Equal(t, tt.text, ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).SeverityText())
+		})
+	}
+}
+
+func TestSetSeverityNumberFromTextUnknown(t *testing.T) {
+	ld := generateSeverityTextLogs("verbose", "INFO", "Unspecified", "")
+	assert.Equal(t, 1, ld.SetSeverityNumberFromText(SeverityNumberUnspecified))
+	assert.Equal(t, []SeverityNumber{SeverityNumberUnspecified, SeverityNumberInfo, SeverityNumberUnspecified, SeverityNumberUnspecified}, severityNumbers(ld))
+
+	// Unknown texts get the given severity number, records without a text are left unset.
+	ld = generateSeverityTextLogs("verbose", "INFO", "Unspecified", "")
+	assert.Equal(t, 3, ld.SetSeverityNumberFromText(SeverityNumberInfo))
+	assert.Equal(t, []SeverityNumber{SeverityNumberInfo, SeverityNumberInfo, SeverityNumberInfo, SeverityNumberUnspecified}, severityNumbers(ld))
+}
+
+func TestSetSeverityNumberFromTextExistingNumber(t *testing.T) {
+	ld := generateSeverityTextLogs("ERROR")
+	ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).SetSeverityNumber(SeverityNumberWarn)
+	assert.Equal(t, 0, ld.SetSeverityNumberFromText(SeverityNumberInfo))
+	assert.Equal(t, []SeverityNumber{SeverityNumberWarn}, severityNumbers(ld))
+}
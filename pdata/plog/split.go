@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package plog // import "go.opentelemetry.io/collector/pdata/plog"
+
+// SplitByAttributeCount splits the Logs into batches whose log records have at most maxAttributes attributes
+// in total, keeping the log records in order. The attributes of the resources and scopes are not counted.
+// Every batch carries a copy of the resource and scope of the log records it contains.
+//
+// A log record with more than maxAttributes attributes is put alone in its batch, which exceeds the budget.
+//
+// If maxAttributes is not positive or the log records of the Logs have no more than maxAttributes attributes
+// in total, the returned slice only contains the Logs itself. Otherwise, the Logs is not modified.
+func (ms Logs) SplitByAttributeCount(maxAttributes int) []Logs {
+	if maxAttributes <= 0 || ms.recordAttributeCount() <= maxAttributes {
+		return []Logs{ms}
+	}
+
+	var batches []Logs
+	var destRl ResourceLogs
+	var destLrs LogRecordSlice
+	// count is the number of attributes of the log records of the current batch.
+	count := 0
+	rls := ms.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		newResource := true
+		sls := rl.ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			sl := sls.At(j)
+			newScope := true
+			lrs := sl.LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				lr := lrs.At(k)
+				attrs := lr.Attributes().Len()
+				if len(batches) == 0 || (count > 0 && count+attrs > maxAttributes) {
+					batches = append(batches, NewLogs())
+					count = 0
+					newResource = true
+				}
+				if newResource {
+					destRl = batches[len(batches)-1].ResourceLogs().AppendEmpty()
+					rl.Resource().CopyTo(destRl.Resource())
+					destRl.SetSchemaUrl(rl.SchemaUrl())
+					newResource = false
+					newScope = true
+				}
+				if newScope {
+					destSl := destRl.ScopeLogs().AppendEmpty()
+					sl.Scope().CopyTo(destSl.Scope())
+					destSl.SetSchemaUrl(sl.SchemaUrl())
+					destLrs = destSl.LogRecords()
+					newScope = false
+				}
+				lr.CopyTo(destLrs.AppendEmpty())
+				count += attrs
+			}
+		}
+	}
+	return batches
+}
+
+// recordAttributeCount returns the total number of attributes of the log records.
+func (ms Logs) recordAttributeCount() int {
+	count := 0
+	rls := ms.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		sls := rls.At(i).ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			lrs := sls.At(j).LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				count += lrs.At(k).Attributes().Len()
+			}
+		}
+	}
+	return count
+}
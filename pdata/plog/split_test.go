@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package plog
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// generateAttributeHeavyLogs returns Logs with two resources of two scopes each, whose log records have
+// the given number of attributes, in order.
+func generateAttributeHeavyLogs(attrCounts ...int) Logs {
+	ld := NewLogs()
+	for i, n := range attrCounts {
+		if i%4 == 0 {
+			rl := ld.ResourceLogs().AppendEmpty()
+			rl.SetSchemaUrl("https://opentelemetry.io/schemas/1.24.0")
+			rl.Resource().Attributes().PutInt("resource", int64(i/4))
+		}
+		rl := ld.ResourceLogs().At(ld.ResourceLogs().Len() - 1)
+		if i%2 == 0 {
+			rl.ScopeLogs().AppendEmpty().Scope().SetName("scope" + strconv.Itoa(i/2%2))
+		}
+		lr := rl.ScopeLogs().At(rl.ScopeLogs().Len() - 1).LogRecords().AppendEmpty()
+		lr.Body().SetInt(int64(i))
+		for a := 0; a < n; a++ {
+			lr.Attributes().PutInt("attr"+strconv.Itoa(a), int64(a))
+		}
+	}
+	return ld
+}
+
+// batchRecords returns, for every batch, the bodies of its log records, and checks the records keep their
+// resource and scope.
+func batchRecords(t *testing.T, batches []Logs) [][]int64 {
+	var got [][]int64
+	for _, batch := range batches {
+		var bodies []int64
+		rls := batch.ResourceLogs()
+		for i := 0; i < rls.Len(); i++ {
+			rl := rls.At(i)
+			sls := rl.ScopeLogs()
+			for j := 0; j < sls.Len(); j++ {
+				sl := sls.At(j)
+				lrs := sl.LogRecords()
+				for k := 0; k < lrs.Len(); k++ {
+					body := lrs.At(k).Body().Int()
+					resource, _ := rl.Resource().Attributes().Get("resource")
+					assert.Equal(t, body/4, resource.Int())
+					assert.Equal(t, "https://opentelemetry.io/schemas/1.24.0", rl.SchemaUrl())
+					assert.Equal(t, "scope"+strconv.FormatInt(body/2%2, 10), sl.Scope().Name())
+					bodies = append(bodies, body)
+				}
+			}
+		}
+		got = append(got, bodies)
+	}
+	return got
+}
+
+func TestSplitByAttributeCount(t *testing.T) {
+	ld := generateAttributeHeavyLogs(3, 4, 2, 5, 1, 0, 6, 2)
+	orig := NewLogs()
+	ld.CopyTo(orig)
+
+	batches := ld.SplitByAttributeCount(8)
+	assert.Equal(t, [][]int64{{0, 1}, {2, 3, 4, 5}, {6, 7}}, batchRecords(t, batches))
+	for _, batch := range batches {
+		assert.LessOrEqual(t, batch.recordAttributeCount(), 8)
+	}
+	// The original Logs is left untouched.
+	assert.Equal(t, orig, ld)
+}
+
+func TestSplitByAttributeCountOversizedRecord(t *testing.T) {
+	ld := generateAttributeHeavyLogs(1, 12, 0, 2, 3)
+
+	// The record over the budget is alone in its batch.
+	batches := ld.SplitByAttributeCount(4)
+	assert.Equal(t, [][]int64{{0}, {1}, {2, 3}, {4}}, batchRecords(t, batches))
+	assert.Equal(t, 12, batches[1].recordAttributeCount())
+}
+
+func TestSplitByAttributeCountNoSplit(t *testing.T) {
+	ld := generateAttributeHeavyLogs(3, 4, 2)
+	batches := ld.SplitByAttributeCount(9)
+	require.Len(t, batches, 1)
+	assert.Equal(t, ld, batches[0])
+
+	batches = ld.SplitByAttributeCount(0)
+	require.Len(t, batches, 1)
+	assert.Equal(t, ld, batches[0])
+}
@@ -0,0 +1,53 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package plog // import "go.opentelemetry.io/collector/pdata/plog"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// Stats holds aggregate statistics about a Logs batch.
+type Stats struct {
+	// LogRecordCount is the number of log records.
+	LogRecordCount int
+	// ResourceCount is the number of distinct resources, identified by their Fingerprint.
+	ResourceCount int
+	// MinTimestamp and MaxTimestamp are the earliest and latest timestamps of the log records,
+	// ignoring the unset ones. They are zero if no log record has a timestamp.
+	MinTimestamp pcommon.Timestamp
+	MaxTimestamp pcommon.Timestamp
+	// EstimatedBytes is the size of the batch encoded as an OTLP protobuf message.
+	EstimatedBytes int
+}
+
+// Stats returns the aggregate statistics of the Logs.
+func (ms Logs) Stats() Stats {
+	var stats Stats
+	resources := make(map[pcommon.Fingerprint]struct{})
+	rls := ms.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		resources[rl.Resource().Fingerprint()] = struct{}{}
+		sls := rl.ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			lrs := sls.At(j).LogRecords()
+			stats.LogRecordCount += lrs.Len()
+			for k := 0; k < lrs.Len(); k++ {
+				ts := lrs.At(k).Timestamp()
+				if ts == 0 {
+					continue
+				}
+				if stats.MinTimestamp == 0 || ts < stats.MinTimestamp {
+					stats.MinTimestamp = ts
+				}
+				if ts > stats.MaxTimestamp {
+					stats.MaxTimestamp = ts
+				}
+			}
+		}
+	}
+	stats.ResourceCount = len(resources)
+	stats.EstimatedBytes = ms.getOrig().Size()
+	return stats
+}
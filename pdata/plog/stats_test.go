@@ -0,0 +1,39 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package plog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestLogsStats(t *testing.T) {
+	ld := NewLogs()
+	for i := 0; i < 3; i++ {
+		rl := ld.ResourceLogs().AppendEmpty()
+		// The first and last resources are identical.
+		rl.Resource().Attributes().PutInt("host", int64(i%2))
+		lrs := rl.ScopeLogs().AppendEmpty().LogRecords()
+		for j := 0; j < i+1; j++ {
+			lrs.AppendEmpty().SetTimestamp(pcommon.Timestamp(100 + 10*i + j))
+		}
+	}
+	// Records without a timestamp are counted but do not affect the timestamps.
+	ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().AppendEmpty().Body().SetStr("no timestamp")
+
+	assert.Equal(t, Stats{
+		LogRecordCount: 7,
+		ResourceCount:  2,
+		MinTimestamp:   100,
+		MaxTimestamp:   122,
+		EstimatedBytes: (&ProtoMarshaler{}).LogsSize(ld),
+	}, ld.Stats())
+}
+
+func TestLogsStatsEmpty(t *testing.T) {
+	assert.Equal(t, Stats{}, NewLogs().Stats())
+}
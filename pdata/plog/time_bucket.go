@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package plog // import "go.opentelemetry.io/collector/pdata/plog"
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// SplitByTimeBucket returns copies of the log records of ms split by time bucket, keyed by the start
+// of the bucket. The buckets are consecutive intervals of the granularity since the Unix epoch, e.g.
+// the hours of the UTC time for a granularity of time.Hour. A record without Timestamp is put in the
+// bucket of its ObservedTimestamp.
+//
+// The records of a bucket keep the resource and scope grouping of ms: the records of a ResourceLogs
+// or ScopeLogs of ms are put in a single ResourceLogs or ScopeLogs of the bucket, with a copy of its
+// resource or scope. ms is left unchanged. SplitByTimeBucket panics if granularity is not positive.
+func (ms Logs) SplitByTimeBucket(granularity time.Duration) map[pcommon.Timestamp]Logs {
+	if granularity <= 0 {
+		panic("plog: SplitByTimeBucket granularity must be positive")
+	}
+	buckets := make(map[pcommon.Timestamp]Logs)
+	rls := ms.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		destRls := make(map[pcommon.Timestamp]ResourceLogs)
+		sls := rl.ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			sl := sls.At(j)
+			destSls := make(map[pcommon.Timestamp]ScopeLogs)
+			lrs := sl.LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				lr := lrs.At(k)
+				ts := lr.Timestamp()
+				if ts == 0 {
+					ts = lr.ObservedTimestamp()
+				}
+				bucket := ts - ts%pcommon.Timestamp(granularity)
+
+				destSl, ok := destSls[bucket]
+				if !ok {
+					destRl, ok := destRls[bucket]
+					if !ok {
+						dest, ok := buckets[bucket]
+						if !ok {
+							dest = NewLogs()
+							buckets[bucket] = dest
+						}
+						destRl = dest.ResourceLogs().AppendEmpty()
+						rl.Resource().CopyTo(destRl.Resource())
+						destRl.SetSchemaUrl(rl.SchemaUrl())
+						destRls[bucket] = destRl
+					}
+					destSl = destRl.ScopeLogs().AppendEmpty()
+					sl.Scope().CopyTo(destSl.Scope())
+					destSl.SetSchemaUrl(sl.SchemaUrl())
+					destSls[bucket] = destSl
+				}
+				lr.CopyTo(destSl.LogRecords().AppendEmpty())
+			}
+		}
+	}
+	return buckets
+}
@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package plog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestSplitByTimeBucket(t *testing.T) {
+	hour := time.Date(2024, 9, 1, 10, 0, 0, 0, time.UTC)
+	ld := NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", "a")
+	rl.SetSchemaUrl("https://opentelemetry.io/schemas/1.21.0")
+	sl := rl.ScopeLogs().AppendEmpty()
+	sl.Scope().SetName("scope")
+	for _, r := range []struct {
+		body string
+		ts   time.Time
+	}{
+		{"a1", hour.Add(5 * time.Minute)},
+		{"a2", hour.Add(65 * time.Minute)},
+		{"a3", hour.Add(59*time.Minute + 59*time.Second)},
+	} {
+		lr := sl.LogRecords().AppendEmpty()
+		lr.Body().SetStr(r.body)
+		lr.SetTimestamp(pcommon.NewTimestampFromTime(r.ts))
+	}
+	rl = ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", "b")
+	lr := rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	lr.Body().SetStr("b1")
+	lr.SetObservedTimestamp(pcommon.NewTimestampFromTime(hour.Add(90 * time.Minute)))
+
+	buckets := ld.SplitByTimeBucket(time.Hour)
+	require.Len(t, buckets, 2)
+	assert.Equal(t, 4, ld.LogRecordCount())
+
+	first := buckets[pcommon.NewTimestampFromTime(hour)]
+	require.Equal(t, 1, first.ResourceLogs().Len())
+	firstRl := first.ResourceLogs().At(0)
+	name, _ := firstRl.Resource().Attributes().Get("service.name")
+	assert.Equal(t, "a", name.Str())
+	assert.Equal(t, "https://opentelemetry.io/schemas/1.21.0", firstRl.SchemaUrl())
+	require.Equal(t, 1, firstRl.ScopeLogs().Len())
+	assert.Equal(t, "scope", firstRl.ScopeLogs().At(0).Scope().Name())
+	assert.Equal(t, []string{"a1", "a3"}, logBodies(firstRl))
+
+	second := buckets[pcommon.NewTimestampFromTime(hour.Add(time.Hour))]
+	require.Equal(t, 2, second.ResourceLogs().Len())
+	name, _ = second.ResourceLogs().At(0).Resource().Attributes().Get("service.name")
+	assert.Equal(t, "a", name.Str())
+	assert.Equal(t, "scope", second.ResourceLogs().At(0).ScopeLogs().At(0).Scope().Name())
+	assert.Equal(t, []string{"a2"}, logBodies(second.ResourceLogs().At(0)))
+	name, _ = second.ResourceLogs().At(1).Resource().Attributes().Get("service.name")
+	assert.Equal(t, "b", name.Str())
+	assert.Equal(t, []string{"b1"}, logBodies(second.ResourceLogs().At(1)))
+}
+
+func TestSplitByTimeBucketEmpty(t *testing.T) {
+	assert.Empty(t, NewLogs().SplitByTimeBucket(time.Hour))
+	assert.PanicsWithValue(t, "plog: SplitByTimeBucket granularity must be positive", func() { NewLogs().SplitByTimeBucket(0) })
+}
+
+func logBodies(rl ResourceLogs) []string {
+	var bodies []string
+	for i := 0; i < rl.ScopeLogs().Len(); i++ {
+		lrs := rl.ScopeLogs().At(i).LogRecords()
+		for j := 0; j < lrs.Len(); j++ {
+			bodies = append(bodies, lrs.At(j).Body().Str())
+		}
+	}
+	return bodies
+}
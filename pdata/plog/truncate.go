@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package plog // import "go.opentelemetry.io/collector/pdata/plog"
+
+import (
+	"errors"
+	"unicode/utf8"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// BodyTruncation configures how Logs.TruncateBodies truncates the log record bodies.
+type BodyTruncation struct {
+	// MaxBytes is the length, in bytes, above which a body is truncated.
+	MaxBytes int
+	// HeadPercent is the percentage of MaxBytes kept from the start of a truncated body.
+	HeadPercent int
+	// TailPercent is the percentage of MaxBytes kept from the end of a truncated body.
+	TailPercent int
+	// Marker is inserted between the head and the tail of a truncated body, e.g. "...".
+	Marker string
+}
+
+// TruncateBodies truncates the string bodies of the log records longer than t.MaxBytes, keeping their
+// first t.HeadPercent and last t.TailPercent percents of t.MaxBytes, separated by t.Marker. The head and
+// the tail are shortened to UTF-8 character boundaries, so a truncated body is never longer than
+// t.MaxBytes plus the length of t.Marker. The log records with a body of any other type are left untouched.
+// It returns the number of truncated bodies, or an error if the percentages are negative or their sum
+// exceeds 100, in which case the Logs are left unchanged.
+func (ms Logs) TruncateBodies(t BodyTruncation) (int, error) {
+	if t.HeadPercent < 0 || t.TailPercent < 0 || t.HeadPercent+t.TailPercent > 100 {
+		return 0, errors.New("head and tail percentages must not be negative and their sum must not exceed 100")
+	}
+	if t.MaxBytes < 0 {
+		return 0, errors.New("max bytes must not be negative")
+	}
+	head := t.MaxBytes * t.HeadPercent / 100
+	tail := t.MaxBytes * t.TailPercent / 100
+
+	truncated := 0
+	rls := ms.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		sls := rls.At(i).ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			lrs := sls.At(j).LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				body := lrs.At(k).Body()
+				if body.Type() != pcommon.ValueTypeStr || len(body.Str()) <= t.MaxBytes {
+					continue
+				}
+				s := body.Str()
+				body.SetStr(s[:runeStart(s, head)] + t.Marker + s[runeEnd(s, len(s)-tail):])
+				truncated++
+			}
+		}
+	}
+	return truncated, nil
+}
+
+// runeStart returns the largest index not greater than i at which a UTF-8 character of s starts.
+func runeStart(s string, i int) int {
+	for i > 0 && i < len(s) && !utf8.RuneStart(s[i]) {
+		i--
+	}
+	return i
+}
+
+// runeEnd returns the smallest index not lower than i at which a UTF-8 character of s starts.
+func runeEnd(s string, i int) int {
+	for i < len(s) && !utf8.RuneStart(s[i]) {
+		i++
+	}
+	return i
+}
@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package plog
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTruncateBodies(t *testing.T) {
+	ld := NewLogs()
+	lrs := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	stackTrace := "panic: boom\n" + strings.Repeat("\tat frame()\n", 100) + "caused by: root cause"
+	lrs.AppendEmpty().Body().SetStr(stackTrace)
+	lrs.AppendEmpty().Body().SetStr("short")
+	lrs.AppendEmpty().Body().SetEmptyMap().PutStr("key", stackTrace)
+
+	truncated, err := ld.TruncateBodies(BodyTruncation{MaxBytes: 100, HeadPercent: 20, TailPercent: 30, Marker: "[...]"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, truncated)
+
+	body := lrs.At(0).Body().Str()
+	assert.Equal(t, stackTrace[:20]+"[...]"+stackTrace[len(stackTrace)-30:], body)
+	assert.True(t, strings.HasPrefix(body, "panic: boom\n"))
+	assert.True(t, strings.HasSuffix(body, "caused by: root cause"))
+	assert.Equal(t, "short", lrs.At(1).Body().Str())
+	v, _ := lrs.At(2).Body().Map().Get("key")
+	assert.Equal(t, stackTrace, v.Str())
+}
+
+func TestTruncateBodiesUTF8(t *testing.T) {
+	ld := NewLogs()
+	lr := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	// Each character is 3 bytes long.
+	lr.Body().SetStr(strings.Repeat("€", 10))
+
+	truncated, err := ld.TruncateBodies(BodyTruncation{MaxBytes: 10, HeadPercent: 50, TailPercent: 50, Marker: "…"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, truncated)
+	// The 5 bytes of the head and the tail are shortened to full characters.
+	assert.Equal(t, "€…€", lr.Body().Str())
+}
+
+func TestTruncateBodiesHeadOnly(t *testing.T) {
+	ld := NewLogs()
+	lr := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	lr.Body().SetStr("0123456789abcdef")
+
+	_, err := ld.TruncateBodies(BodyTruncation{MaxBytes: 10, HeadPercent: 100})
+	require.NoError(t, err)
+	assert.Equal(t, "0123456789", lr.Body().Str())
+}
+
+func TestTruncateBodiesInvalid(t *testing.T) {
+	ld := NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("0123456789")
+
+	_, err := ld.TruncateBodies(BodyTruncation{MaxBytes: 5, HeadPercent: 60, TailPercent: 50})
+	require.Error(t, err)
+	_, err = ld.TruncateBodies(BodyTruncation{MaxBytes: 5, HeadPercent: -1})
+	require.Error(t, err)
+	_, err = ld.TruncateBodies(BodyTruncation{MaxBytes: -1})
+	require.Error(t, err)
+	assert.Equal(t, "0123456789", ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().Str())
+}
@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pmetric // import "go.opentelemetry.io/collector/pdata/pmetric"
+
+import (
+	"math"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// ClampBucketCounts resets to zero the negative bucket counts of the Histogram and ExponentialHistogram
+// data points, including the zero count of the latter.
+//
+// The bucket counts are unsigned, so the negative counts of corrupt data, e.g. converted from signed integers,
+// are the counts greater than math.MaxInt64. The count of a corrected data point is set to the sum of its bucket
+// counts, so it stays consistent with them.
+// It returns the number of corrected data points.
+func (ms Metrics) ClampBucketCounts() int {
+	corrected := 0
+	rms := ms.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		sms := rms.At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			metrics := sms.At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				m := metrics.At(k)
+				switch m.Type() {
+				case MetricTypeHistogram:
+					dps := m.Histogram().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						dp := dps.At(l)
+						if clampCounts(dp.BucketCounts()) {
+							dp.SetCount(sumCounts(dp.BucketCounts()))
+							corrected++
+						}
+					}
+				case MetricTypeExponentialHistogram:
+					dps := m.ExponentialHistogram().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						dp := dps.At(l)
+						clamped := clampCounts(dp.Positive().BucketCounts())
+						clamped = clampCounts(dp.Negative().BucketCounts()) || clamped
+						if isNegativeCount(dp.ZeroCount()) {
+							dp.SetZeroCount(0)
+							clamped = true
+						}
+						if clamped {
+							dp.SetCount(dp.ZeroCount() + sumCounts(dp.Positive().BucketCounts()) + sumCounts(dp.Negative().BucketCounts()))
+							corrected++
+						}
+					}
+				}
+			}
+		}
+	}
+	return corrected
+}
+
+func isNegativeCount(count uint64) bool {
+	return count > math.MaxInt64
+}
+
+// clampCounts resets the negative counts to zero, and returns whether there were any.
+func clampCounts(counts pcommon.UInt64Slice) bool {
+	clamped := false
+	for i := 0; i < counts.Len(); i++ {
+		if isNegativeCount(counts.At(i)) {
+			counts.SetAt(i, 0)
+			clamped = true
+		}
+	}
+	return clamped
+}
+
+func sumCounts(counts pcommon.UInt64Slice) uint64 {
+	var sum uint64
+	for i := 0; i < counts.Len(); i++ {
+		sum += counts.At(i)
+	}
+	return sum
+}
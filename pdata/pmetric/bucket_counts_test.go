@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pmetric
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// negative returns the unsigned count the corrupt negative count n is received as.
+func negative(n int64) uint64 {
+	return uint64(n)
+}
+
+func TestClampBucketCounts(t *testing.T) {
+	md := NewMetrics()
+	metrics := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+
+	hdps := metrics.AppendEmpty().SetEmptyHistogram().DataPoints()
+	corrupt := hdps.AppendEmpty()
+	corrupt.ExplicitBounds().FromRaw([]float64{1, 2})
+	corrupt.BucketCounts().FromRaw([]uint64{3, negative(-2), 4})
+	corrupt.SetCount(5)
+	valid := hdps.AppendEmpty()
+	valid.BucketCounts().FromRaw([]uint64{1, 2})
+	valid.SetCount(3)
+
+	edp := metrics.AppendEmpty().SetEmptyExponentialHistogram().DataPoints().AppendEmpty()
+	edp.SetZeroCount(negative(-1))
+	edp.Positive().BucketCounts().FromRaw([]uint64{2, negative(-5)})
+	edp.Negative().BucketCounts().FromRaw([]uint64{1})
+	edp.SetCount(negative(-3))
+
+	metrics.AppendEmpty().SetEmptyGauge().DataPoints().AppendEmpty().SetIntValue(-1)
+
+	assert.Equal(t, 2, md.ClampBucketCounts())
+	assert.Equal(t, []uint64{3, 0, 4}, corrupt.BucketCounts().AsRaw())
+	assert.Equal(t, uint64(7), corrupt.Count())
+	assert.Equal(t, []uint64{1, 2}, valid.BucketCounts().AsRaw())
+	assert.Equal(t, uint64(3), valid.Count())
+	assert.Equal(t, uint64(0), edp.ZeroCount())
+	assert.Equal(t, []uint64{2, 0}, edp.Positive().BucketCounts().AsRaw())
+	assert.Equal(t, []uint64{1}, edp.Negative().BucketCounts().AsRaw())
+	assert.Equal(t, uint64(3), edp.Count())
+
+	// The corrected data points are left untouched.
+	assert.Equal(t, 0, md.ClampBucketCounts())
+}
+
+func TestClampBucketCountsZeroCount(t *testing.T) {
+	md := NewMetrics()
+	edp := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().
+		SetEmptyExponentialHistogram().DataPoints().AppendEmpty()
+	edp.SetZeroCount(negative(-4))
+	edp.Positive().BucketCounts().FromRaw([]uint64{1, 1})
+
+	assert.Equal(t, 1, md.ClampBucketCounts())
+	assert.Equal(t, uint64(0), edp.ZeroCount())
+	assert.Equal(t, uint64(2), edp.Count())
+}
@@ -0,0 +1,186 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pmetric // import "go.opentelemetry.io/collector/pdata/pmetric"
+
+import (
+	"slices"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// Series is a data point of a Metrics, with the resource, scope and metric it belongs to.
+type Series struct {
+	Resource pcommon.Resource
+	Scope    pcommon.InstrumentationScope
+	Metric   Metric
+	// Index is the index of the data point in the data points of Metric.
+	Index int
+}
+
+// SeriesChange is a series present in both snapshots compared by DiffMetrics, with different values.
+type SeriesChange struct {
+	From Series
+	To   Series
+}
+
+// MetricsDiff is the difference between two Metrics snapshots, computed by DiffMetrics.
+type MetricsDiff struct {
+	// Added are the series of the second snapshot which are not in the first one, in their order.
+	Added []Series
+	// Removed are the series of the first snapshot which are not in the second one, in their order.
+	Removed []Series
+	// Changed are the series of both snapshots whose values differ, in the order of the second one.
+	Changed []SeriesChange
+}
+
+// Empty reports whether the snapshots have the same series with the same values.
+func (d MetricsDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// seriesID identifies a series across snapshots.
+type seriesID struct {
+	resource        pcommon.Fingerprint
+	scopeName       string
+	scopeVersion    string
+	scopeAttributes pcommon.Fingerprint
+	metric          metricKey
+	attributes      pcommon.Fingerprint
+}
+
+// DiffMetrics compares the series of two snapshots of Metrics, and returns the series added, removed and
+// changed from the first one to the second one.
+//
+// A series is identified by the attributes of its resource, the name, version and attributes of its scope,
+// the name, type, aggregation temporality and monotonicity of its metric, and the attributes of its data point.
+// If a snapshot holds several data points of the same series, e.g. with distinct timestamps, the last one is
+// compared. A series is changed if the values of its data points differ, their timestamps and exemplars being
+// ignored, e.g. the count, sum, min, max and buckets of the histograms.
+func DiffMetrics(from, to Metrics) MetricsDiff {
+	fromIDs, fromSeries := indexSeries(from)
+	toIDs, toSeries := indexSeries(to)
+	var diff MetricsDiff
+	for _, id := range fromIDs {
+		if _, ok := toSeries[id]; !ok {
+			diff.Removed = append(diff.Removed, fromSeries[id])
+		}
+	}
+	for _, id := range toIDs {
+		f, ok := fromSeries[id]
+		switch {
+		case !ok:
+			diff.Added = append(diff.Added, toSeries[id])
+		case !seriesValuesEqual(f, toSeries[id]):
+			diff.Changed = append(diff.Changed, SeriesChange{From: f, To: toSeries[id]})
+		}
+	}
+	return diff
+}
+
+// indexSeries returns the identities of the series of md in order, and the last data point of each of them.
+func indexSeries(md Metrics) ([]seriesID, map[seriesID]Series) {
+	var ids []seriesID
+	series := make(map[seriesID]Series)
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		res := rms.At(i).Resource()
+		resFingerprint := res.Attributes().Fingerprint()
+		sms := rms.At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			scope := sms.At(j).Scope()
+			scopeFingerprint := scope.Attributes().Fingerprint()
+			metrics := sms.At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				m := metrics.At(k)
+				key := newMetricKey(m)
+				for l := 0; l < dataPointsLen(m); l++ {
+					id := seriesID{
+						resource:        resFingerprint,
+						scopeName:       scope.Name(),
+						scopeVersion:    scope.Version(),
+						scopeAttributes: scopeFingerprint,
+						metric:          key,
+						attributes:      newDataPointKey(m, l).attributes,
+					}
+					if _, ok := series[id]; !ok {
+						ids = append(ids, id)
+					}
+					series[id] = Series{Resource: res, Scope: scope, Metric: m, Index: l}
+				}
+			}
+		}
+	}
+	return ids, series
+}
+
+// seriesValuesEqual reports whether the data points of a and b, of the same metric type, have the same values.
+func seriesValuesEqual(a, b Series) bool {
+	switch a.Metric.Type() {
+	case MetricTypeGauge:
+		return numberDataPointsEqual(a.Metric.Gauge().DataPoints().At(a.Index), b.Metric.Gauge().DataPoints().At(b.Index))
+	case MetricTypeSum:
+		return numberDataPointsEqual(a.Metric.Sum().DataPoints().At(a.Index), b.Metric.Sum().DataPoints().At(b.Index))
+	case MetricTypeHistogram:
+		return histogramDataPointsEqual(a.Metric.Histogram().DataPoints().At(a.Index), b.Metric.Histogram().DataPoints().At(b.Index))
+	case MetricTypeExponentialHistogram:
+		return exponentialHistogramDataPointsEqual(a.Metric.ExponentialHistogram().DataPoints().At(a.Index),
+			b.Metric.ExponentialHistogram().DataPoints().At(b.Index))
+	case MetricTypeSummary:
+		return summaryDataPointsEqual(a.Metric.Summary().DataPoints().At(a.Index), b.Metric.Summary().DataPoints().At(b.Index))
+	}
+	return true
+}
+
+func numberDataPointsEqual(a, b NumberDataPoint) bool {
+	if a.Flags() != b.Flags() || a.ValueType() != b.ValueType() {
+		return false
+	}
+	if a.ValueType() == NumberDataPointValueTypeInt {
+		return a.IntValue() == b.IntValue()
+	}
+	return a.DoubleValue() == b.DoubleValue()
+}
+
+func histogramDataPointsEqual(a, b HistogramDataPoint) bool {
+	return a.Flags() == b.Flags() &&
+		a.Count() == b.Count() &&
+		optionalEqual(a.HasSum(), a.Sum(), b.HasSum(), b.Sum()) &&
+		optionalEqual(a.HasMin(), a.Min(), b.HasMin(), b.Min()) &&
+		optionalEqual(a.HasMax(), a.Max(), b.HasMax(), b.Max()) &&
+		slices.Equal(a.ExplicitBounds().AsRaw(), b.ExplicitBounds().AsRaw()) &&
+		slices.Equal(a.BucketCounts().AsRaw(), b.BucketCounts().AsRaw())
+}
+
+func exponentialHistogramDataPointsEqual(a, b ExponentialHistogramDataPoint) bool {
+	return a.Flags() == b.Flags() &&
+		a.Count() == b.Count() &&
+		optionalEqual(a.HasSum(), a.Sum(), b.HasSum(), b.Sum()) &&
+		optionalEqual(a.HasMin(), a.Min(), b.HasMin(), b.Min()) &&
+		optionalEqual(a.HasMax(), a.Max(), b.HasMax(), b.Max()) &&
+		a.Scale() == b.Scale() &&
+		a.ZeroCount() == b.ZeroCount() &&
+		a.ZeroThreshold() == b.ZeroThreshold() &&
+		a.Positive().Offset() == b.Positive().Offset() &&
+		slices.Equal(a.Positive().BucketCounts().AsRaw(), b.Positive().BucketCounts().AsRaw()) &&
+		a.Negative().Offset() == b.Negative().Offset() &&
+		slices.Equal(a.Negative().BucketCounts().AsRaw(), b.Negative().BucketCounts().AsRaw())
+}
+
+func summaryDataPointsEqual(a, b SummaryDataPoint) bool {
+	if a.Flags() != b.Flags() || a.Count() != b.Count() || a.Sum() != b.Sum() || a.QuantileValues().Len() != b.QuantileValues().Len() {
+		return false
+	}
+	for i := 0; i < a.QuantileValues().Len(); i++ {
+		qa, qb := a.QuantileValues().At(i), b.QuantileValues().At(i)
+		if qa.Quantile() != qb.Quantile() || qa.Value() != qb.Value() {
+			return false
+		}
+	}
+	return true
+}
+
+// optionalEqual reports whether two optional values are both unset, or both set to the same value.
+func optionalEqual(hasA bool, a float64, hasB bool, b float64) bool {
+	return hasA == hasB && (!hasA || a == b)
+}
@@ -0,0 +1,160 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pmetric
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// newSnapshot returns Metrics with a "requests" cumulative sum per path of values, and a "latency" histogram
+// with the given count, all at timestamp ts.
+func newSnapshot(ts int, values map[string]int64, latencyCount uint64) Metrics {
+	md := NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "api")
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName("http")
+
+	sum := sm.Metrics().AppendEmpty()
+	sum.SetName("requests")
+	sum.SetEmptySum().SetAggregationTemporality(AggregationTemporalityCumulative)
+	for _, path := range []string{"/a", "/b", "/c"} {
+		v, ok := values[path]
+		if !ok {
+			continue
+		}
+		dp := sum.Sum().DataPoints().AppendEmpty()
+		dp.Attributes().PutStr("path", path)
+		dp.SetTimestamp(pcommon.Timestamp(ts))
+		dp.SetIntValue(v)
+	}
+
+	hist := sm.Metrics().AppendEmpty()
+	hist.SetName("latency")
+	dp := hist.SetEmptyHistogram().DataPoints().AppendEmpty()
+	dp.SetTimestamp(pcommon.Timestamp(ts))
+	dp.SetCount(latencyCount)
+	dp.ExplicitBounds().FromRaw([]float64{10})
+	dp.BucketCounts().FromRaw([]uint64{latencyCount, 0})
+	return md
+}
+
+func TestDiffMetrics(t *testing.T) {
+	from := newSnapshot(10, map[string]int64{"/a": 1, "/b": 2}, 3)
+	to := newSnapshot(20, map[string]int64{"/a": 1, "/b": 5, "/c": 1}, 4)
+
+	diff := DiffMetrics(from, to)
+	assert.False(t, diff.Empty())
+
+	require.Len(t, diff.Added, 1)
+	added := diff.Added[0]
+	assert.Equal(t, "requests", added.Metric.Name())
+	assert.Equal(t, "http", added.Scope.Name())
+	assert.Equal(t, map[string]any{"service.name": "api"}, added.Resource.Attributes().AsRaw())
+	assert.Equal(t, map[string]any{"path": "/c"}, added.Metric.Sum().DataPoints().At(added.Index).Attributes().AsRaw())
+
+	assert.Empty(t, diff.Removed)
+
+	require.Len(t, diff.Changed, 2)
+	assert.Equal(t, "requests", diff.Changed[0].To.Metric.Name())
+	assert.Equal(t, int64(2), diff.Changed[0].From.Metric.Sum().DataPoints().At(diff.Changed[0].From.Index).IntValue())
+	assert.Equal(t, int64(5), diff.Changed[0].To.Metric.Sum().DataPoints().At(diff.Changed[0].To.Index).IntValue())
+	assert.Equal(t, "latency", diff.Changed[1].To.Metric.Name())
+
+	reverse := DiffMetrics(to, from)
+	assert.Empty(t, reverse.Added)
+	require.Len(t, reverse.Removed, 1)
+	assert.Equal(t, map[string]any{"path": "/c"},
+		reverse.Removed[0].Metric.Sum().DataPoints().At(reverse.Removed[0].Index).Attributes().AsRaw())
+	assert.Len(t, reverse.Changed, 2)
+}
+
+func TestDiffMetricsIgnoresTimestamps(t *testing.T) {
+	from := newSnapshot(10, map[string]int64{"/a": 1}, 3)
+	to := newSnapshot(20, map[string]int64{"/a": 1}, 3)
+	assert.True(t, DiffMetrics(from, to).Empty())
+}
+
+func TestDiffMetricsIdentity(t *testing.T) {
+	from := newSnapshot(10, map[string]int64{"/a": 1}, 3)
+
+	// A different resource makes distinct series.
+	to := newSnapshot(10, map[string]int64{"/a": 1}, 3)
+	to.ResourceMetrics().At(0).Resource().Attributes().PutStr("service.name", "web")
+	diff := DiffMetrics(from, to)
+	assert.Len(t, diff.Added, 2)
+	assert.Len(t, diff.Removed, 2)
+	assert.Empty(t, diff.Changed)
+
+	// A different temporality makes a distinct series.
+	to = newSnapshot(10, map[string]int64{"/a": 1}, 3)
+	to.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().SetAggregationTemporality(AggregationTemporalityDelta)
+	diff = DiffMetrics(from, to)
+	require.Len(t, diff.Added, 1)
+	assert.Equal(t, "requests", diff.Added[0].Metric.Name())
+	require.Len(t, diff.Removed, 1)
+	assert.Equal(t, "requests", diff.Removed[0].Metric.Name())
+	assert.Empty(t, diff.Changed)
+}
+
+func TestDiffMetricsLastDataPoint(t *testing.T) {
+	from := newSnapshot(10, map[string]int64{"/a": 1}, 3)
+	to := newSnapshot(10, map[string]int64{"/a": 1}, 3)
+	// A later data point of the same series holds its current value.
+	dp := to.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().AppendEmpty()
+	dp.Attributes().PutStr("path", "/a")
+	dp.SetTimestamp(20)
+	dp.SetIntValue(4)
+
+	diff := DiffMetrics(from, to)
+	require.Len(t, diff.Changed, 1)
+	assert.Equal(t, 1, diff.Changed[0].To.Index)
+}
+
+func TestSeriesValuesEqual(t *testing.T) {
+	newSeries := func(fill func(m Metric)) Series {
+		m := NewMetric()
+		fill(m)
+		return Series{Metric: m}
+	}
+	tests := []struct {
+		name string
+		fill func(m Metric, v float64)
+	}{
+		{
+			name: "gauge",
+			fill: func(m Metric, v float64) { m.SetEmptyGauge().DataPoints().AppendEmpty().SetDoubleValue(v) },
+		},
+		{
+			name: "histogram sum",
+			fill: func(m Metric, v float64) { m.SetEmptyHistogram().DataPoints().AppendEmpty().SetSum(v) },
+		},
+		{
+			name: "exponential histogram positive offset",
+			fill: func(m Metric, v float64) {
+				m.SetEmptyExponentialHistogram().DataPoints().AppendEmpty().Positive().SetOffset(int32(v))
+			},
+		},
+		{
+			name: "summary quantile",
+			fill: func(m Metric, v float64) {
+				q := m.SetEmptySummary().DataPoints().AppendEmpty().QuantileValues().AppendEmpty()
+				q.SetQuantile(0.5)
+				q.SetValue(v)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newSeries(func(m Metric) { tt.fill(m, 1) })
+			assert.True(t, seriesValuesEqual(a, newSeries(func(m Metric) { tt.fill(m, 1) })))
+			assert.False(t, seriesValuesEqual(a, newSeries(func(m Metric) { tt.fill(m, 2) })))
+		})
+	}
+}
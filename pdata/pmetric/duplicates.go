@@ -0,0 +1,336 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pmetric // import "go.opentelemetry.io/collector/pdata/pmetric"
+
+import (
+	"fmt"
+	"slices"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// DuplicatePolicy specifies how MergeDuplicateDataPoints handles duplicate data points.
+type DuplicatePolicy int32
+
+const (
+	// DuplicatePolicySum merges the duplicate data points by adding their values. It is supported for
+	// Gauge, Sum and Histogram metrics. Histogram data points must have the same explicit bounds.
+	DuplicatePolicySum DuplicatePolicy = iota
+	// DuplicatePolicyLastWins keeps the value of the last duplicate data point.
+	DuplicatePolicyLastWins
+	// DuplicatePolicyError returns an error if there are duplicate data points.
+	DuplicatePolicyError
+)
+
+// String returns the string representation of the DuplicatePolicy.
+func (dp DuplicatePolicy) String() string {
+	switch dp {
+	case DuplicatePolicySum:
+		return "Sum"
+	case DuplicatePolicyLastWins:
+		return "LastWins"
+	case DuplicatePolicyError:
+		return "Error"
+	}
+	return ""
+}
+
+// metricKey identifies the metrics of a scope whose data points belong to the same series.
+type metricKey struct {
+	name        string
+	metricType  MetricType
+	temporality AggregationTemporality
+	monotonic   bool
+}
+
+func newMetricKey(m Metric) metricKey {
+	key := metricKey{name: m.Name(), metricType: m.Type()}
+	switch m.Type() {
+	case MetricTypeSum:
+		key.temporality = m.Sum().AggregationTemporality()
+		key.monotonic = m.Sum().IsMonotonic()
+	case MetricTypeHistogram:
+		key.temporality = m.Histogram().AggregationTemporality()
+	case MetricTypeExponentialHistogram:
+		key.temporality = m.ExponentialHistogram().AggregationTemporality()
+	}
+	return key
+}
+
+// dataPointKey identifies a data point within a metric.
+type dataPointKey struct {
+	attributes pcommon.Fingerprint
+	timestamp  pcommon.Timestamp
+}
+
+// MergeDuplicateDataPoints merges the duplicate data points of the Metrics according to the policy, and
+// returns the number of data points removed by the merge.
+//
+// Two data points are duplicates if they belong to metrics of the same scope with the same name, type,
+// aggregation temporality and monotonicity, and have the same attributes and timestamp. The metrics of a
+// scope with the same identity are merged into the first one, and the duplicate data points are merged
+// into the first one of the series, keeping its position.
+//
+// If the policy is DuplicatePolicyError, or DuplicatePolicySum is used with duplicates which cannot be
+// added, an error is returned and the Metrics are left unchanged.
+func (ms Metrics) MergeDuplicateDataPoints(policy DuplicatePolicy) (int, error) {
+	if err := ms.checkDuplicates(policy); err != nil {
+		return 0, err
+	}
+	if policy == DuplicatePolicyError {
+		return 0, nil
+	}
+
+	merged := 0
+	rms := ms.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		sms := rms.At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			metrics := sms.At(j).Metrics()
+			mergeDuplicateMetrics(metrics)
+			for k := 0; k < metrics.Len(); k++ {
+				merged += mergeDuplicateDataPoints(metrics.At(k), policy)
+			}
+		}
+	}
+	return merged, nil
+}
+
+// checkDuplicates returns an error if the duplicate data points cannot be merged with the policy.
+func (ms Metrics) checkDuplicates(policy DuplicatePolicy) error {
+	if policy != DuplicatePolicyError && policy != DuplicatePolicySum {
+		return nil
+	}
+	rms := ms.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		sms := rms.At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			if err := checkDuplicateDataPoints(policy, sms.At(j).Metrics()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// checkDuplicateDataPoints returns an error if the duplicate data points of the metrics, considered as a single
+// MetricSlice, cannot be merged with the policy DuplicatePolicyError or DuplicatePolicySum.
+func checkDuplicateDataPoints(policy DuplicatePolicy, metricSlices ...MetricSlice) error {
+	type seriesKey struct {
+		metric    metricKey
+		dataPoint dataPointKey
+	}
+	// The first data point of each series, used to check the histograms can be added.
+	seen := make(map[seriesKey]HistogramDataPoint)
+	for _, metrics := range metricSlices {
+		for k := 0; k < metrics.Len(); k++ {
+			m := metrics.At(k)
+			key := newMetricKey(m)
+			for l := 0; l < dataPointsLen(m); l++ {
+				sk := seriesKey{metric: key, dataPoint: newDataPointKey(m, l)}
+				first, ok := seen[sk]
+				if !ok {
+					if m.Type() == MetricTypeHistogram {
+						seen[sk] = m.Histogram().DataPoints().At(l)
+					} else {
+						seen[sk] = HistogramDataPoint{}
+					}
+					continue
+				}
+				if policy == DuplicatePolicyError {
+					return fmt.Errorf("duplicate data point for metric %q", m.Name())
+				}
+				switch m.Type() {
+				case MetricTypeExponentialHistogram, MetricTypeSummary:
+					return fmt.Errorf("cannot sum duplicate data points of %s metric %q", m.Type(), m.Name())
+				case MetricTypeHistogram:
+					if !slices.Equal(first.ExplicitBounds().AsRaw(), m.Histogram().DataPoints().At(l).ExplicitBounds().AsRaw()) {
+						return fmt.Errorf("cannot sum duplicate data points of Histogram metric %q with different explicit bounds", m.Name())
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// mergeDuplicateMetrics moves the data points of the metrics with the same identity into the first one.
+func mergeDuplicateMetrics(metrics MetricSlice) {
+	first := make(map[metricKey]Metric)
+	merged := make(map[int]bool)
+	for i := 0; i < metrics.Len(); i++ {
+		m := metrics.At(i)
+		key := newMetricKey(m)
+		dest, ok := first[key]
+		if !ok {
+			first[key] = m
+			continue
+		}
+		switch m.Type() {
+		case MetricTypeGauge:
+			m.Gauge().DataPoints().MoveAndAppendTo(dest.Gauge().DataPoints())
+		case MetricTypeSum:
+			m.Sum().DataPoints().MoveAndAppendTo(dest.Sum().DataPoints())
+		case MetricTypeHistogram:
+			m.Histogram().DataPoints().MoveAndAppendTo(dest.Histogram().DataPoints())
+		case MetricTypeExponentialHistogram:
+			m.ExponentialHistogram().DataPoints().MoveAndAppendTo(dest.ExponentialHistogram().DataPoints())
+		case MetricTypeSummary:
+			m.Summary().DataPoints().MoveAndAppendTo(dest.Summary().DataPoints())
+		default:
+			continue
+		}
+		merged[i] = true
+	}
+	if len(merged) == 0 {
+		return
+	}
+	i := 0
+	metrics.RemoveIf(func(Metric) bool {
+		remove := merged[i]
+		i++
+		return remove
+	})
+}
+
+// mergeDuplicateDataPoints merges the duplicate data points of the metric into the first one of their series,
+// and returns the number of removed data points.
+func mergeDuplicateDataPoints(m Metric, policy DuplicatePolicy) int {
+	first := make(map[dataPointKey]int)
+	duplicates := make(map[int]bool)
+	for i := 0; i < dataPointsLen(m); i++ {
+		key := newDataPointKey(m, i)
+		dest, ok := first[key]
+		if !ok {
+			first[key] = i
+			continue
+		}
+		duplicates[i] = true
+		switch m.Type() {
+		case MetricTypeGauge:
+			mergeNumberDataPoints(m.Gauge().DataPoints(), dest, i, policy)
+		case MetricTypeSum:
+			mergeNumberDataPoints(m.Sum().DataPoints(), dest, i, policy)
+		case MetricTypeHistogram:
+			dps := m.Histogram().DataPoints()
+			if policy == DuplicatePolicySum {
+				addHistogramDataPoint(dps.At(dest), dps.At(i))
+			} else {
+				dps.At(i).CopyTo(dps.At(dest))
+			}
+		case MetricTypeExponentialHistogram:
+			dps := m.ExponentialHistogram().DataPoints()
+			dps.At(i).CopyTo(dps.At(dest))
+		case MetricTypeSummary:
+			dps := m.Summary().DataPoints()
+			dps.At(i).CopyTo(dps.At(dest))
+		}
+	}
+	if len(duplicates) == 0 {
+		return 0
+	}
+
+	i := 0
+	isDuplicate := func() bool {
+		remove := duplicates[i]
+		i++
+		return remove
+	}
+	switch m.Type() {
+	case MetricTypeGauge:
+		m.Gauge().DataPoints().RemoveIf(func(NumberDataPoint) bool { return isDuplicate() })
+	case MetricTypeSum:
+		m.Sum().DataPoints().RemoveIf(func(NumberDataPoint) bool { return isDuplicate() })
+	case MetricTypeHistogram:
+		m.Histogram().DataPoints().RemoveIf(func(HistogramDataPoint) bool { return isDuplicate() })
+	case MetricTypeExponentialHistogram:
+		m.ExponentialHistogram().DataPoints().RemoveIf(func(ExponentialHistogramDataPoint) bool { return isDuplicate() })
+	case MetricTypeSummary:
+		m.Summary().DataPoints().RemoveIf(func(SummaryDataPoint) bool { return isDuplicate() })
+	}
+	return len(duplicates)
+}
+
+func mergeNumberDataPoints(dps NumberDataPointSlice, dest, src int, policy DuplicatePolicy) {
+	d, s := dps.At(dest), dps.At(src)
+	if policy != DuplicatePolicySum {
+		s.CopyTo(d)
+		return
+	}
+	if d.ValueType() == NumberDataPointValueTypeInt && s.ValueType() == NumberDataPointValueTypeInt {
+		d.SetIntValue(d.IntValue() + s.IntValue())
+		return
+	}
+	d.SetDoubleValue(numberValue(d) + numberValue(s))
+}
+
+func numberValue(dp NumberDataPoint) float64 {
+	if dp.ValueType() == NumberDataPointValueTypeInt {
+		return float64(dp.IntValue())
+	}
+	return dp.DoubleValue()
+}
+
+// addHistogramDataPoint adds src to dest. They must have the same explicit bounds.
+func addHistogramDataPoint(dest, src HistogramDataPoint) {
+	dest.SetCount(dest.Count() + src.Count())
+	if dest.HasSum() && src.HasSum() {
+		dest.SetSum(dest.Sum() + src.Sum())
+	} else {
+		dest.RemoveSum()
+	}
+	if src.HasMin() && (!dest.HasMin() || src.Min() < dest.Min()) {
+		dest.SetMin(src.Min())
+	}
+	if src.HasMax() && (!dest.HasMax() || src.Max() > dest.Max()) {
+		dest.SetMax(src.Max())
+	}
+	counts := dest.BucketCounts().AsRaw()
+	for i, c := range src.BucketCounts().AsRaw() {
+		if i < len(counts) {
+			counts[i] += c
+		} else {
+			counts = append(counts, c)
+		}
+	}
+	dest.BucketCounts().FromRaw(counts)
+	src.Exemplars().MoveAndAppendTo(dest.Exemplars())
+}
+
+func dataPointsLen(m Metric) int {
+	switch m.Type() {
+	case MetricTypeGauge:
+		return m.Gauge().DataPoints().Len()
+	case MetricTypeSum:
+		return m.Sum().DataPoints().Len()
+	case MetricTypeHistogram:
+		return m.Histogram().DataPoints().Len()
+	case MetricTypeExponentialHistogram:
+		return m.ExponentialHistogram().DataPoints().Len()
+	case MetricTypeSummary:
+		return m.Summary().DataPoints().Len()
+	}
+	return 0
+}
+
+func newDataPointKey(m Metric, i int) dataPointKey {
+	switch m.Type() {
+	case MetricTypeGauge:
+		dp := m.Gauge().DataPoints().At(i)
+		return dataPointKey{attributes: dp.Attributes().Fingerprint(), timestamp: dp.Timestamp()}
+	case MetricTypeSum:
+		dp := m.Sum().DataPoints().At(i)
+		return dataPointKey{attributes: dp.Attributes().Fingerprint(), timestamp: dp.Timestamp()}
+	case MetricTypeHistogram:
+		dp := m.Histogram().DataPoints().At(i)
+		return dataPointKey{attributes: dp.Attributes().Fingerprint(), timestamp: dp.Timestamp()}
+	case MetricTypeExponentialHistogram:
+		dp := m.ExponentialHistogram().DataPoints().At(i)
+		return dataPointKey{attributes: dp.Attributes().Fingerprint(), timestamp: dp.Timestamp()}
+	case MetricTypeSummary:
+		dp := m.Summary().DataPoints().At(i)
+		return dataPointKey{attributes: dp.Attributes().Fingerprint(), timestamp: dp.Timestamp()}
+	}
+	return dataPointKey{}
+}
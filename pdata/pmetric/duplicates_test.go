@@ -0,0 +1,168 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pmetric
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newDuplicateMetrics returns Metrics where the "requests" series {path=/a} at timestamp 10 is emitted
+// three times, twice in one metric and once in a second "requests" metric of the same scope.
+func newDuplicateMetrics() Metrics {
+	md := NewMetrics()
+	metrics := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+
+	m := metrics.AppendEmpty()
+	m.SetName("requests")
+	sum := m.SetEmptySum()
+	sum.SetAggregationTemporality(AggregationTemporalityCumulative)
+	sum.SetIsMonotonic(true)
+	for _, v := range []int64{1, 2} {
+		dp := sum.DataPoints().AppendEmpty()
+		dp.Attributes().PutStr("path", "/a")
+		dp.SetTimestamp(10)
+		dp.SetIntValue(v)
+	}
+	dp := sum.DataPoints().AppendEmpty()
+	dp.Attributes().PutStr("path", "/b")
+	dp.SetTimestamp(10)
+	dp.SetIntValue(5)
+	// Same series at a different timestamp: not a duplicate.
+	dp = sum.DataPoints().AppendEmpty()
+	dp.Attributes().PutStr("path", "/a")
+	dp.SetTimestamp(20)
+	dp.SetIntValue(7)
+
+	m = metrics.AppendEmpty()
+	m.SetName("requests")
+	sum = m.SetEmptySum()
+	sum.SetAggregationTemporality(AggregationTemporalityCumulative)
+	sum.SetIsMonotonic(true)
+	dp = sum.DataPoints().AppendEmpty()
+	dp.Attributes().PutStr("path", "/a")
+	dp.SetTimestamp(10)
+	dp.SetDoubleValue(0.5)
+
+	// Same name with a different type: not a duplicate.
+	m = metrics.AppendEmpty()
+	m.SetName("requests")
+	dp = m.SetEmptyGauge().DataPoints().AppendEmpty()
+	dp.Attributes().PutStr("path", "/a")
+	dp.SetTimestamp(10)
+	dp.SetIntValue(100)
+	return md
+}
+
+func TestMergeDuplicateDataPointsSum(t *testing.T) {
+	md := newDuplicateMetrics()
+	merged, err := md.MergeDuplicateDataPoints(DuplicatePolicySum)
+	require.NoError(t, err)
+	assert.Equal(t, 2, merged)
+
+	metrics := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 2, metrics.Len())
+	dps := metrics.At(0).Sum().DataPoints()
+	require.Equal(t, 3, dps.Len())
+	assert.Equal(t, NumberDataPointValueTypeDouble, dps.At(0).ValueType())
+	assert.InDelta(t, 3.5, dps.At(0).DoubleValue(), 1e-9)
+	assert.Equal(t, int64(5), dps.At(1).IntValue())
+	assert.Equal(t, int64(7), dps.At(2).IntValue())
+	assert.Equal(t, int64(100), metrics.At(1).Gauge().DataPoints().At(0).IntValue())
+}
+
+func TestMergeDuplicateDataPointsLastWins(t *testing.T) {
+	md := newDuplicateMetrics()
+	merged, err := md.MergeDuplicateDataPoints(DuplicatePolicyLastWins)
+	require.NoError(t, err)
+	assert.Equal(t, 2, merged)
+
+	metrics := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 2, metrics.Len())
+	dps := metrics.At(0).Sum().DataPoints()
+	require.Equal(t, 3, dps.Len())
+	assert.InDelta(t, 0.5, dps.At(0).DoubleValue(), 1e-9)
+	assert.Equal(t, int64(5), dps.At(1).IntValue())
+	assert.Equal(t, int64(7), dps.At(2).IntValue())
+}
+
+func TestMergeDuplicateDataPointsError(t *testing.T) {
+	md := newDuplicateMetrics()
+	_, err := md.MergeDuplicateDataPoints(DuplicatePolicyError)
+	require.EqualError(t, err, `duplicate data point for metric "requests"`)
+	assert.Equal(t, newDuplicateMetrics(), md)
+
+	// No duplicate, no error.
+	md = NewMetrics()
+	dps := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptyGauge().DataPoints()
+	dps.AppendEmpty().Attributes().PutStr("path", "/a")
+	dps.AppendEmpty().Attributes().PutStr("path", "/b")
+	merged, err := md.MergeDuplicateDataPoints(DuplicatePolicyError)
+	require.NoError(t, err)
+	assert.Zero(t, merged)
+}
+
+func TestMergeDuplicateDataPointsHistogram(t *testing.T) {
+	newHistograms := func(bounds ...[]float64) Metrics {
+		md := NewMetrics()
+		dps := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptyHistogram().DataPoints()
+		for i, b := range bounds {
+			dp := dps.AppendEmpty()
+			dp.SetCount(uint64(i + 1))
+			dp.SetSum(float64(10 * (i + 1)))
+			dp.SetMin(float64(i))
+			dp.SetMax(float64(i + 5))
+			dp.ExplicitBounds().FromRaw(b)
+			dp.BucketCounts().FromRaw([]uint64{uint64(i), 1})
+		}
+		return md
+	}
+
+	md := newHistograms([]float64{1}, []float64{1})
+	merged, err := md.MergeDuplicateDataPoints(DuplicatePolicySum)
+	require.NoError(t, err)
+	assert.Equal(t, 1, merged)
+	dps := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Histogram().DataPoints()
+	require.Equal(t, 1, dps.Len())
+	assert.Equal(t, uint64(3), dps.At(0).Count())
+	assert.InDelta(t, 30, dps.At(0).Sum(), 1e-9)
+	assert.InDelta(t, 0, dps.At(0).Min(), 1e-9)
+	assert.InDelta(t, 6, dps.At(0).Max(), 1e-9)
+	assert.Equal(t, []uint64{1, 2}, dps.At(0).BucketCounts().AsRaw())
+
+	md = newHistograms([]float64{1}, []float64{2})
+	_, err = md.MergeDuplicateDataPoints(DuplicatePolicySum)
+	require.EqualError(t, err, `cannot sum duplicate data points of Histogram metric "" with different explicit bounds`)
+	assert.Equal(t, newHistograms([]float64{1}, []float64{2}), md)
+
+	merged, err = md.MergeDuplicateDataPoints(DuplicatePolicyLastWins)
+	require.NoError(t, err)
+	assert.Equal(t, 1, merged)
+	dps = md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Histogram().DataPoints()
+	require.Equal(t, 1, dps.Len())
+	assert.Equal(t, []float64{2}, dps.At(0).ExplicitBounds().AsRaw())
+}
+
+func TestMergeDuplicateDataPointsSumUnsupported(t *testing.T) {
+	md := NewMetrics()
+	m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("latency")
+	m.SetEmptySummary().DataPoints().AppendEmpty()
+	m.Summary().DataPoints().AppendEmpty()
+	_, err := md.MergeDuplicateDataPoints(DuplicatePolicySum)
+	require.EqualError(t, err, `cannot sum duplicate data points of Summary metric "latency"`)
+
+	merged, err := md.MergeDuplicateDataPoints(DuplicatePolicyLastWins)
+	require.NoError(t, err)
+	assert.Equal(t, 1, merged)
+}
+
+func TestDuplicatePolicyString(t *testing.T) {
+	assert.Equal(t, "Sum", DuplicatePolicySum.String())
+	assert.Equal(t, "LastWins", DuplicatePolicyLastWins.String())
+	assert.Equal(t, "Error", DuplicatePolicyError.String())
+	assert.Equal(t, "", DuplicatePolicy(100).String())
+}
@@ -0,0 +1,133 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pmetric // import "go.opentelemetry.io/collector/pdata/pmetric"
+
+import (
+	"math"
+	"sort"
+)
+
+// ConvertExponentialHistogramToHistogram converts an ExponentialHistogram metric into a Histogram
+// metric with the given explicit bounds, keeping its aggregation temporality.
+// See ExponentialHistogramDataPoint.ConvertToExplicitBuckets for how the data points are converted.
+// It does nothing if the metric is not an ExponentialHistogram.
+func (ms Metric) ConvertExponentialHistogramToHistogram(bounds []float64) {
+	if ms.Type() != MetricTypeExponentialHistogram {
+		return
+	}
+	eh := NewExponentialHistogram()
+	ms.ExponentialHistogram().MoveTo(eh)
+	h := ms.SetEmptyHistogram()
+	h.SetAggregationTemporality(eh.AggregationTemporality())
+	dps := eh.DataPoints()
+	h.DataPoints().EnsureCapacity(dps.Len())
+	for i := 0; i < dps.Len(); i++ {
+		dps.At(i).ConvertToExplicitBuckets(bounds, h.DataPoints().AppendEmpty())
+	}
+}
+
+// ConvertToExplicitBuckets converts the ExponentialHistogramDataPoint into the dest HistogramDataPoint,
+// using the given explicit bounds, which must be sorted in increasing order.
+//
+// The conversion is lossy: observations are assumed to be uniformly distributed within each exponential
+// bucket, and the count of an exponential bucket is split across the explicit buckets it overlaps in
+// proportion to the overlap. Counts are rounded so the total count is preserved. Observations of the zero
+// bucket are counted in the explicit bucket containing zero, and negative buckets map to negative values.
+// The count, sum, min, max, timestamps, attributes, flags and exemplars are copied unchanged.
+func (ms ExponentialHistogramDataPoint) ConvertToExplicitBuckets(bounds []float64, dest HistogramDataPoint) {
+	ms.Attributes().CopyTo(dest.Attributes())
+	dest.SetStartTimestamp(ms.StartTimestamp())
+	dest.SetTimestamp(ms.Timestamp())
+	dest.SetCount(ms.Count())
+	dest.SetFlags(ms.Flags())
+	ms.Exemplars().CopyTo(dest.Exemplars())
+	dest.RemoveSum()
+	if ms.HasSum() {
+		dest.SetSum(ms.Sum())
+	}
+	dest.RemoveMin()
+	if ms.HasMin() {
+		dest.SetMin(ms.Min())
+	}
+	dest.RemoveMax()
+	if ms.HasMax() {
+		dest.SetMax(ms.Max())
+	}
+	dest.ExplicitBounds().FromRaw(bounds)
+
+	counts := make([]float64, len(bounds)+1)
+	counts[explicitBucketIndex(bounds, 0)] += float64(ms.ZeroCount())
+	base := math.Exp2(math.Exp2(-float64(ms.Scale())))
+	addExponentialBuckets(counts, bounds, ms.Positive(), base, false)
+	addExponentialBuckets(counts, bounds, ms.Negative(), base, true)
+
+	// Round the cumulative counts, so the rounding errors do not add up and the total is preserved.
+	bucketCounts := make([]uint64, len(counts))
+	var cumulative float64
+	var previous uint64
+	for i, c := range counts {
+		cumulative += c
+		rounded := uint64(math.Round(cumulative))
+		if rounded < previous {
+			rounded = previous
+		}
+		bucketCounts[i] = rounded - previous
+		previous = rounded
+	}
+	dest.BucketCounts().FromRaw(bucketCounts)
+}
+
+// addExponentialBuckets distributes the counts of the exponential buckets over the explicit buckets.
+func addExponentialBuckets(counts []float64, bounds []float64, buckets ExponentialHistogramDataPointBuckets, base float64, negative bool) {
+	bucketCounts := buckets.BucketCounts()
+	for i := 0; i < bucketCounts.Len(); i++ {
+		count := bucketCounts.At(i)
+		if count == 0 {
+			continue
+		}
+		index := float64(buckets.Offset()) + float64(i)
+		lower, upper := math.Pow(base, index), math.Pow(base, index+1)
+		if negative {
+			lower, upper = -upper, -lower
+		}
+		distribute(counts, bounds, lower, upper, float64(count))
+	}
+}
+
+// distribute splits count over the explicit buckets overlapping the [lower, upper] range in proportion to the overlap.
+func distribute(counts []float64, bounds []float64, lower, upper, count float64) {
+	width := upper - lower
+	if width <= 0 || math.IsInf(width, 0) || math.IsNaN(width) {
+		// The range is too small or too large to be split, attribute it to a single bucket.
+		if math.IsInf(lower, 0) {
+			counts[explicitBucketIndex(bounds, upper)] += count
+		} else {
+			counts[explicitBucketIndex(bounds, lower)] += count
+		}
+		return
+	}
+	first, last := explicitBucketIndex(bounds, lower), explicitBucketIndex(bounds, upper)
+	if first == last {
+		counts[first] += count
+		return
+	}
+	for j := first; j <= last; j++ {
+		lo, hi := lower, upper
+		if j > 0 && bounds[j-1] > lo {
+			lo = bounds[j-1]
+		}
+		if j < len(bounds) && bounds[j] < hi {
+			hi = bounds[j]
+		}
+		if hi > lo {
+			counts[j] += count * (hi - lo) / width
+		}
+	}
+}
+
+// explicitBucketIndex returns the index of the explicit bucket containing v, where the bucket i
+// contains the values in the (bounds[i-1], bounds[i]] range.
+func explicitBucketIndex(bounds []float64, v float64) int {
+	return sort.SearchFloat64s(bounds, v)
+}
@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pmetric
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func newTestExponentialHistogramDataPoint() ExponentialHistogramDataPoint {
+	dp := NewExponentialHistogramDataPoint()
+	dp.Attributes().PutStr("key", "value")
+	dp.SetStartTimestamp(pcommon.Timestamp(1))
+	dp.SetTimestamp(pcommon.Timestamp(2))
+	// With scale 0 the buckets are (1, 2], (2, 4] and (4, 8].
+	dp.SetScale(0)
+	dp.Positive().SetOffset(0)
+	dp.Positive().BucketCounts().FromRaw([]uint64{3, 2, 1})
+	dp.SetZeroCount(4)
+	dp.Negative().SetOffset(0)
+	dp.Negative().BucketCounts().FromRaw([]uint64{5})
+	dp.SetCount(15)
+	dp.SetSum(12.5)
+	dp.SetMin(-1.5)
+	dp.SetMax(7)
+	return dp
+}
+
+func TestConvertToExplicitBuckets(t *testing.T) {
+	dp := newTestExponentialHistogramDataPoint()
+	hdp := NewHistogramDataPoint()
+	dp.ConvertToExplicitBuckets([]float64{-1, 0, 2, 4, 8}, hdp)
+
+	assert.Equal(t, []float64{-1, 0, 2, 4, 8}, hdp.ExplicitBounds().AsRaw())
+	// (-inf, -1]: negative bucket [-2, -1), (-1, 0]: zero bucket, then the positive buckets.
+	assert.Equal(t, []uint64{5, 4, 3, 2, 1, 0}, hdp.BucketCounts().AsRaw())
+	assert.Equal(t, uint64(15), hdp.Count())
+	assert.Equal(t, 12.5, hdp.Sum())
+	assert.Equal(t, -1.5, hdp.Min())
+	assert.Equal(t, 7.0, hdp.Max())
+	assert.Equal(t, pcommon.Timestamp(1), hdp.StartTimestamp())
+	assert.Equal(t, pcommon.Timestamp(2), hdp.Timestamp())
+	assert.Equal(t, map[string]any{"key": "value"}, hdp.Attributes().AsRaw())
+}
+
+func TestConvertToExplicitBucketsProportional(t *testing.T) {
+	dp := NewExponentialHistogramDataPoint()
+	dp.SetScale(0)
+	dp.Positive().SetOffset(1)
+	// The (2, 4] bucket is split in half by the bound at 3.
+	dp.Positive().BucketCounts().FromRaw([]uint64{10, 8})
+	dp.SetCount(18)
+
+	hdp := NewHistogramDataPoint()
+	dp.ConvertToExplicitBuckets([]float64{3, 6}, hdp)
+
+	// (2, 4] splits 5/5 across (-inf, 3] and (3, 6], (4, 8] splits 4/4 across (3, 6] and (6, +inf).
+	assert.Equal(t, []uint64{5, 9, 4}, hdp.BucketCounts().AsRaw())
+	assert.False(t, hdp.HasSum())
+	assert.False(t, hdp.HasMin())
+	assert.False(t, hdp.HasMax())
+}
+
+func TestConvertToExplicitBucketsPreservesCount(t *testing.T) {
+	dp := NewExponentialHistogramDataPoint()
+	dp.SetScale(3)
+	dp.Positive().SetOffset(-20)
+	counts := make([]uint64, 60)
+	total := uint64(0)
+	for i := range counts {
+		counts[i] = uint64(i%7 + 1)
+		total += counts[i]
+	}
+	dp.Positive().BucketCounts().FromRaw(counts)
+	dp.SetCount(total)
+
+	hdp := NewHistogramDataPoint()
+	dp.ConvertToExplicitBuckets([]float64{0.5, 1, 2.5, 5, 10, 25, 50, 100}, hdp)
+
+	sum := uint64(0)
+	for _, c := range hdp.BucketCounts().AsRaw() {
+		sum += c
+	}
+	assert.Equal(t, total, sum)
+	assert.Equal(t, total, hdp.Count())
+}
+
+func TestConvertExponentialHistogramToHistogram(t *testing.T) {
+	m := NewMetric()
+	m.SetName("latency")
+	eh := m.SetEmptyExponentialHistogram()
+	eh.SetAggregationTemporality(AggregationTemporalityDelta)
+	newTestExponentialHistogramDataPoint().CopyTo(eh.DataPoints().AppendEmpty())
+
+	m.ConvertExponentialHistogramToHistogram([]float64{-1, 0, 2, 4, 8})
+
+	require.Equal(t, MetricTypeHistogram, m.Type())
+	assert.Equal(t, "latency", m.Name())
+	assert.Equal(t, AggregationTemporalityDelta, m.Histogram().AggregationTemporality())
+	require.Equal(t, 1, m.Histogram().DataPoints().Len())
+	assert.Equal(t, []uint64{5, 4, 3, 2, 1, 0}, m.Histogram().DataPoints().At(0).BucketCounts().AsRaw())
+}
+
+func TestConvertExponentialHistogramToHistogramOtherType(t *testing.T) {
+	m := NewMetric()
+	m.SetEmptyGauge().DataPoints().AppendEmpty().SetIntValue(1)
+	m.ConvertExponentialHistogramToHistogram([]float64{1})
+	assert.Equal(t, MetricTypeGauge, m.Type())
+}
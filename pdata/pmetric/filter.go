@@ -0,0 +1,45 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pmetric // import "go.opentelemetry.io/collector/pdata/pmetric"
+
+import "go.opentelemetry.io/collector/pdata/pcommon"
+
+// Filter returns new Metrics with a copy of the metrics of ms for which keep returns true, with a copy of their
+// resource and scope. The resources and scopes without any metric kept are not copied. ms is not modified.
+func (ms Metrics) Filter(keep func(pcommon.Resource, pcommon.InstrumentationScope, Metric) bool) Metrics {
+	filtered := NewMetrics()
+	var destRs ResourceMetrics
+	var destMetrics MetricSlice
+	rss := ms.ResourceMetrics()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		newResource := true
+		sss := rs.ScopeMetrics()
+		for j := 0; j < sss.Len(); j++ {
+			ss := sss.At(j)
+			newScope := true
+			items := ss.Metrics()
+			for k := 0; k < items.Len(); k++ {
+				if !keep(rs.Resource(), ss.Scope(), items.At(k)) {
+					continue
+				}
+				if newResource {
+					destRs = filtered.ResourceMetrics().AppendEmpty()
+					rs.Resource().CopyTo(destRs.Resource())
+					destRs.SetSchemaUrl(rs.SchemaUrl())
+					newResource = false
+				}
+				if newScope {
+					destSs := destRs.ScopeMetrics().AppendEmpty()
+					ss.Scope().CopyTo(destSs.Scope())
+					destSs.SetSchemaUrl(ss.SchemaUrl())
+					destMetrics = destSs.Metrics()
+					newScope = false
+				}
+				items.At(k).CopyTo(destMetrics.AppendEmpty())
+			}
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pmetric
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// generateFilterMetrics returns Metrics with two resources of two scopes of three metrics named by their
+// resource, scope and index, keeping only the metrics for which keep returns true if it is not nil.
+func generateFilterMetrics(keep func(r, s, i int) bool) Metrics {
+	md := NewMetrics()
+	for r := 0; r < 2; r++ {
+		var rs ResourceMetrics
+		for s := 0; s < 2; s++ {
+			var ss ScopeMetrics
+			for i := 0; i < 3; i++ {
+				if keep != nil && !keep(r, s, i) {
+					continue
+				}
+				if ss == (ScopeMetrics{}) {
+					if rs == (ResourceMetrics{}) {
+						rs = md.ResourceMetrics().AppendEmpty()
+						rs.SetSchemaUrl("https://opentelemetry.io/schemas/1.24.0")
+						rs.Resource().Attributes().PutInt("resource", int64(r))
+					}
+					ss = rs.ScopeMetrics().AppendEmpty()
+					ss.SetSchemaUrl("https://opentelemetry.io/schemas/1.25.0")
+					ss.Scope().SetName("scope")
+					ss.Scope().SetVersion(string(rune('a' + s)))
+				}
+				metric := ss.Metrics().AppendEmpty()
+				metric.SetName(string(rune('a'+r)) + string(rune('a'+s)) + string(rune('a'+i)))
+				metric.SetEmptyGauge().DataPoints().AppendEmpty().SetIntValue(int64(i))
+			}
+		}
+	}
+	return md
+}
+
+func TestMetricsFilter(t *testing.T) {
+	md := generateFilterMetrics(nil)
+	filtered := md.Filter(func(res pcommon.Resource, scope pcommon.InstrumentationScope, metric Metric) bool {
+		r, _ := res.Attributes().Get("resource")
+		return r.Int() == 1 && scope.Version() == "b" || metric.Gauge().DataPoints().At(0).IntValue() == 0
+	})
+	assert.Equal(t, generateFilterMetrics(func(r, s, i int) bool {
+		return r == 1 && s == 1 || i == 0
+	}), filtered)
+	// The source is unchanged.
+	assert.Equal(t, generateFilterMetrics(nil), md)
+}
+
+func TestMetricsFilterNone(t *testing.T) {
+	md := generateFilterMetrics(nil)
+	filtered := md.Filter(func(pcommon.Resource, pcommon.InstrumentationScope, Metric) bool {
+		return false
+	})
+	assert.Equal(t, NewMetrics(), filtered)
+	assert.Equal(t, 12, md.MetricCount())
+}
@@ -0,0 +1,49 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pmetric // import "go.opentelemetry.io/collector/pdata/pmetric"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+type scopeKey struct {
+	name       string
+	version    string
+	schemaURL  string
+	attributes pcommon.Fingerprint
+	dropped    uint32
+}
+
+// GroupByScope merges, within each ResourceMetrics, the ScopeMetrics having an identical instrumentation scope and
+// schema URL, i.e. the same name, version, attributes and dropped attributes count, so each distinct
+// scope appears only once per resource. The metrics of the duplicates are appended, in order, to the
+// first ScopeMetrics with the same scope, which keeps its position in the resource.
+// The metrics are appended as they are: metrics with the same name are not merged.
+func (ms Metrics) GroupByScope() {
+	rss := ms.ResourceMetrics()
+	for i := 0; i < rss.Len(); i++ {
+		sss := rss.At(i).ScopeMetrics()
+		if sss.Len() < 2 {
+			continue
+		}
+		first := make(map[scopeKey]ScopeMetrics, sss.Len())
+		sss.RemoveIf(func(ss ScopeMetrics) bool {
+			scope := ss.Scope()
+			key := scopeKey{
+				name:       scope.Name(),
+				version:    scope.Version(),
+				schemaURL:  ss.SchemaUrl(),
+				attributes: scope.Attributes().Fingerprint(),
+				dropped:    scope.DroppedAttributesCount(),
+			}
+			dest, ok := first[key]
+			if !ok {
+				first[key] = ss
+				return false
+			}
+			ss.Metrics().MoveAndAppendTo(dest.Metrics())
+			return true
+		})
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pmetric
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func appendScopeMetrics(rm ResourceMetrics, scope, version string, names ...string) ScopeMetrics {
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName(scope)
+	sm.Scope().SetVersion(version)
+	for _, name := range names {
+		sm.Metrics().AppendEmpty().SetName(name)
+	}
+	return sm
+}
+
+func TestGroupByScope(t *testing.T) {
+	md := NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	appendScopeMetrics(rm, "a", "1.0", "a1", "a2")
+	appendScopeMetrics(rm, "b", "1.0", "b1")
+	appendScopeMetrics(rm, "a", "1.0", "a3")
+	appendScopeMetrics(rm, "a", "2.0", "a4")
+	appendScopeMetrics(rm, "b", "1.0", "b2")
+	appendScopeMetrics(rm, "a", "1.0", "a5").Scope().Attributes().PutStr("k", "v")
+	appendScopeMetrics(rm, "a", "1.0", "a6").SetSchemaUrl("https://opentelemetry.io/schemas/1.21.0")
+	appendScopeMetrics(rm, "a", "1.0", "a7").Scope().Attributes().PutStr("k", "v")
+	// Scopes are only merged within the same resource.
+	other := md.ResourceMetrics().AppendEmpty()
+	appendScopeMetrics(other, "a", "1.0", "o1")
+
+	md.GroupByScope()
+
+	assert.Equal(t, 10, md.MetricCount())
+	var got [][]string
+	for i := 0; i < rm.ScopeMetrics().Len(); i++ {
+		var names []string
+		sm := rm.ScopeMetrics().At(i)
+		for j := 0; j < sm.Metrics().Len(); j++ {
+			names = append(names, sm.Metrics().At(j).Name())
+		}
+		got = append(got, names)
+	}
+	assert.Equal(t, [][]string{{"a1", "a2", "a3"}, {"b1", "b2"}, {"a4"}, {"a5", "a7"}, {"a6"}}, got)
+	assert.Equal(t, "a", rm.ScopeMetrics().At(0).Scope().Name())
+	assert.Equal(t, "b", rm.ScopeMetrics().At(1).Scope().Name())
+	assert.Equal(t, "2.0", rm.ScopeMetrics().At(2).Scope().Version())
+	assert.Equal(t, 1, rm.ScopeMetrics().At(3).Scope().Attributes().Len())
+	assert.Equal(t, "https://opentelemetry.io/schemas/1.21.0", rm.ScopeMetrics().At(4).SchemaUrl())
+	assert.Equal(t, 1, other.ScopeMetrics().Len())
+}
@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pmetric // import "go.opentelemetry.io/collector/pdata/pmetric"
+
+// MergeScopesConfig configures how MergeScopes merges the scopes of each resource.
+type MergeScopesConfig struct {
+	// ScopeName is the name of the scope the metrics of a resource are merged into, which replaces the scope,
+	// with its attributes and schema URL, of the first ScopeMetrics of the resource. If empty, the first
+	// ScopeMetrics keeps its scope.
+	ScopeName string
+	// ScopeVersion is the version of the scope the metrics are merged into, used if ScopeName is set.
+	ScopeVersion string
+	// Policy is how the data points of the same series, e.g. emitted by different scopes, are aggregated.
+	Policy DuplicatePolicy
+}
+
+// MergeScopes merges, within each ResourceMetrics, all the ScopeMetrics into the first one, and merges the data
+// points of the same series according to the configured policy, and returns the number of data points removed by
+// the merge. It allows aggregating at the resource level a metric emitted under several scopes.
+//
+// Two data points belong to the same series if they belong to metrics with the same name, type, aggregation
+// temporality and monotonicity, and have the same attributes and timestamp, whatever their scope. As with
+// MergeDuplicateDataPoints, the metrics with the same identity are merged into the first one, and the data points
+// of the same series into the first one of the series.
+//
+// If the policy is DuplicatePolicyError, or DuplicatePolicySum is used with data points which cannot be added,
+// an error is returned and the Metrics are left unchanged.
+func (ms Metrics) MergeScopes(cfg MergeScopesConfig) (int, error) {
+	rms := ms.ResourceMetrics()
+	if cfg.Policy == DuplicatePolicyError || cfg.Policy == DuplicatePolicySum {
+		for i := 0; i < rms.Len(); i++ {
+			sms := rms.At(i).ScopeMetrics()
+			metricSlices := make([]MetricSlice, 0, sms.Len())
+			for j := 0; j < sms.Len(); j++ {
+				metricSlices = append(metricSlices, sms.At(j).Metrics())
+			}
+			if err := checkDuplicateDataPoints(cfg.Policy, metricSlices...); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	merged := 0
+	for i := 0; i < rms.Len(); i++ {
+		sms := rms.At(i).ScopeMetrics()
+		if sms.Len() == 0 {
+			continue
+		}
+		dest := sms.At(0)
+		for j := 1; j < sms.Len(); j++ {
+			sms.At(j).Metrics().MoveAndAppendTo(dest.Metrics())
+		}
+		first := true
+		sms.RemoveIf(func(ScopeMetrics) bool {
+			remove := !first
+			first = false
+			return remove
+		})
+		if cfg.ScopeName != "" {
+			dest.SetSchemaUrl("")
+			scope := dest.Scope()
+			scope.Attributes().Clear()
+			scope.SetDroppedAttributesCount(0)
+			scope.SetName(cfg.ScopeName)
+			scope.SetVersion(cfg.ScopeVersion)
+		}
+		metrics := dest.Metrics()
+		mergeDuplicateMetrics(metrics)
+		for k := 0; k < metrics.Len(); k++ {
+			merged += mergeDuplicateDataPoints(metrics.At(k), cfg.Policy)
+		}
+	}
+	return merged, nil
+}
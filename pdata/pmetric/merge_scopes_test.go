@@ -0,0 +1,147 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pmetric
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newMultiScopeMetrics returns Metrics where the "requests" sum series {path=/a} at timestamp 10 and the
+// "latency" histogram series are emitted under two scopes of the same resource.
+func newMultiScopeMetrics() Metrics {
+	md := NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "svc")
+	for i, scopeName := range []string{"http", "grpc"} {
+		sm := rm.ScopeMetrics().AppendEmpty()
+		sm.SetSchemaUrl("https://opentelemetry.io/schemas/1.26.0")
+		sm.Scope().SetName(scopeName)
+		sm.Scope().Attributes().PutStr("library", scopeName)
+
+		m := sm.Metrics().AppendEmpty()
+		m.SetName("requests")
+		sum := m.SetEmptySum()
+		sum.SetAggregationTemporality(AggregationTemporalityCumulative)
+		sum.SetIsMonotonic(true)
+		dp := sum.DataPoints().AppendEmpty()
+		dp.Attributes().PutStr("path", "/a")
+		dp.SetTimestamp(10)
+		dp.SetIntValue(int64(i + 1))
+		// Only emitted by the first scope: kept as is.
+		if i == 0 {
+			dp = sum.DataPoints().AppendEmpty()
+			dp.Attributes().PutStr("path", "/b")
+			dp.SetTimestamp(10)
+			dp.SetIntValue(5)
+		}
+
+		m = sm.Metrics().AppendEmpty()
+		m.SetName("latency")
+		hdp := m.SetEmptyHistogram().DataPoints().AppendEmpty()
+		hdp.SetTimestamp(10)
+		hdp.ExplicitBounds().FromRaw([]float64{1, 2})
+		hdp.BucketCounts().FromRaw([]uint64{uint64(i), 1, 2})
+		hdp.SetCount(uint64(i + 3))
+		hdp.SetSum(float64(10 * (i + 1)))
+	}
+	return md
+}
+
+func TestMergeScopesSum(t *testing.T) {
+	md := newMultiScopeMetrics()
+	merged, err := md.MergeScopes(MergeScopesConfig{Policy: DuplicatePolicySum})
+	require.NoError(t, err)
+	assert.Equal(t, 2, merged)
+
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+	sms := md.ResourceMetrics().At(0).ScopeMetrics()
+	require.Equal(t, 1, sms.Len())
+	// The first scope is kept.
+	assert.Equal(t, "http", sms.At(0).Scope().Name())
+	assert.Equal(t, map[string]any{"library": "http"}, sms.At(0).Scope().Attributes().AsRaw())
+	assert.Equal(t, "https://opentelemetry.io/schemas/1.26.0", sms.At(0).SchemaUrl())
+
+	metrics := sms.At(0).Metrics()
+	require.Equal(t, 2, metrics.Len())
+	assert.Equal(t, "requests", metrics.At(0).Name())
+	dps := metrics.At(0).Sum().DataPoints()
+	require.Equal(t, 2, dps.Len())
+	assert.Equal(t, int64(3), dps.At(0).IntValue())
+	assert.Equal(t, int64(5), dps.At(1).IntValue())
+
+	assert.Equal(t, "latency", metrics.At(1).Name())
+	hdps := metrics.At(1).Histogram().DataPoints()
+	require.Equal(t, 1, hdps.Len())
+	assert.Equal(t, uint64(7), hdps.At(0).Count())
+	assert.InDelta(t, 30, hdps.At(0).Sum(), 1e-9)
+	assert.Equal(t, []uint64{1, 2, 4}, hdps.At(0).BucketCounts().AsRaw())
+}
+
+func TestMergeScopesLastWins(t *testing.T) {
+	md := newMultiScopeMetrics()
+	merged, err := md.MergeScopes(MergeScopesConfig{ScopeName: "merged", ScopeVersion: "v1", Policy: DuplicatePolicyLastWins})
+	require.NoError(t, err)
+	assert.Equal(t, 2, merged)
+
+	sms := md.ResourceMetrics().At(0).ScopeMetrics()
+	require.Equal(t, 1, sms.Len())
+	// The scope is replaced.
+	assert.Equal(t, "merged", sms.At(0).Scope().Name())
+	assert.Equal(t, "v1", sms.At(0).Scope().Version())
+	assert.Equal(t, 0, sms.At(0).Scope().Attributes().Len())
+	assert.Empty(t, sms.At(0).SchemaUrl())
+
+	metrics := sms.At(0).Metrics()
+	require.Equal(t, 2, metrics.Len())
+	assert.Equal(t, int64(2), metrics.At(0).Sum().DataPoints().At(0).IntValue())
+	assert.Equal(t, uint64(4), metrics.At(1).Histogram().DataPoints().At(0).Count())
+}
+
+func TestMergeScopesError(t *testing.T) {
+	md := newMultiScopeMetrics()
+	_, err := md.MergeScopes(MergeScopesConfig{Policy: DuplicatePolicyError})
+	require.EqualError(t, err, `duplicate data point for metric "requests"`)
+	// The Metrics are left unchanged.
+	assert.Equal(t, newMultiScopeMetrics(), md)
+
+	// The metrics are merged into the first scope if their series are not duplicated.
+	md.ResourceMetrics().At(0).ScopeMetrics().At(1).Metrics().At(0).Sum().DataPoints().At(0).SetTimestamp(20)
+	md.ResourceMetrics().At(0).ScopeMetrics().At(1).Metrics().At(1).Histogram().DataPoints().At(0).SetTimestamp(20)
+	merged, err := md.MergeScopes(MergeScopesConfig{Policy: DuplicatePolicyError})
+	require.NoError(t, err)
+	assert.Equal(t, 0, merged)
+	sms := md.ResourceMetrics().At(0).ScopeMetrics()
+	require.Equal(t, 1, sms.Len())
+	require.Equal(t, 2, sms.At(0).Metrics().Len())
+	assert.Equal(t, 3, sms.At(0).Metrics().At(0).Sum().DataPoints().Len())
+	assert.Equal(t, 2, sms.At(0).Metrics().At(1).Histogram().DataPoints().Len())
+}
+
+func TestMergeScopesSumUnsupported(t *testing.T) {
+	md := newMultiScopeMetrics()
+	md.ResourceMetrics().At(0).ScopeMetrics().At(1).Metrics().At(1).Histogram().DataPoints().At(0).ExplicitBounds().FromRaw([]float64{5})
+	_, err := md.MergeScopes(MergeScopesConfig{Policy: DuplicatePolicySum})
+	require.EqualError(t, err, `cannot sum duplicate data points of Histogram metric "latency" with different explicit bounds`)
+	assert.Equal(t, 2, md.ResourceMetrics().At(0).ScopeMetrics().Len())
+}
+
+func TestMergeScopesPerResource(t *testing.T) {
+	md := newMultiScopeMetrics()
+	newMultiScopeMetrics().ResourceMetrics().MoveAndAppendTo(md.ResourceMetrics())
+	md.ResourceMetrics().AppendEmpty()
+	merged, err := md.MergeScopes(MergeScopesConfig{Policy: DuplicatePolicySum})
+	require.NoError(t, err)
+	// The series of different resources are not merged.
+	assert.Equal(t, 4, merged)
+	require.Equal(t, 3, md.ResourceMetrics().Len())
+	for i := 0; i < 2; i++ {
+		sms := md.ResourceMetrics().At(i).ScopeMetrics()
+		require.Equal(t, 1, sms.Len())
+		assert.Equal(t, int64(3), sms.At(0).Metrics().At(0).Sum().DataPoints().At(0).IntValue())
+	}
+	assert.Equal(t, 0, md.ResourceMetrics().At(2).ScopeMetrics().Len())
+}
@@ -70,25 +70,49 @@ func (ms Metrics) DataPointCount() (dataPointCount int) {
 			ilm := ilms.At(j)
 			ms := ilm.Metrics()
 			for k := 0; k < ms.Len(); k++ {
-				m := ms.At(k)
-				switch m.Type() {
-				case MetricTypeGauge:
-					dataPointCount += m.Gauge().DataPoints().Len()
-				case MetricTypeSum:
-					dataPointCount += m.Sum().DataPoints().Len()
-				case MetricTypeHistogram:
-					dataPointCount += m.Histogram().DataPoints().Len()
-				case MetricTypeExponentialHistogram:
-					dataPointCount += m.ExponentialHistogram().DataPoints().Len()
-				case MetricTypeSummary:
-					dataPointCount += m.Summary().DataPoints().Len()
-				}
+				dataPointCount += metricDataPointCount(ms.At(k))
 			}
 		}
 	}
 	return
 }
 
+// DataPointCountByScope calculates the number of data points per instrumentation scope name.
+// The data points of the scopes with the same name, across resources or versions, are counted together.
+func (ms Metrics) DataPointCountByScope() map[string]int {
+	counts := make(map[string]int)
+	rms := ms.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		sms := rms.At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			sm := sms.At(j)
+			metrics := sm.Metrics()
+			count := 0
+			for k := 0; k < metrics.Len(); k++ {
+				count += metricDataPointCount(metrics.At(k))
+			}
+			counts[sm.Scope().Name()] += count
+		}
+	}
+	return counts
+}
+
+func metricDataPointCount(m Metric) int {
+	switch m.Type() {
+	case MetricTypeGauge:
+		return m.Gauge().DataPoints().Len()
+	case MetricTypeSum:
+		return m.Sum().DataPoints().Len()
+	case MetricTypeHistogram:
+		return m.Histogram().DataPoints().Len()
+	case MetricTypeExponentialHistogram:
+		return m.ExponentialHistogram().DataPoints().Len()
+	case MetricTypeSummary:
+		return m.Summary().DataPoints().Len()
+	}
+	return 0
+}
+
 // MarkReadOnly marks the Metrics as shared so that no further modifications can be done on it.
 func (ms Metrics) MarkReadOnly() {
 	internal.SetMetricsState(internal.Metrics(ms), internal.StateReadOnly)
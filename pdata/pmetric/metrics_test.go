@@ -160,6 +160,33 @@ func TestDataPointCountWithNilDataPoints(t *testing.T) {
 	assert.EqualValues(t, 0, metrics.DataPointCount())
 }
 
+func TestDataPointCountByScope(t *testing.T) {
+	md := NewMetrics()
+	assert.Empty(t, md.DataPointCountByScope())
+
+	sms := md.ResourceMetrics().AppendEmpty().ScopeMetrics()
+	sm := sms.AppendEmpty()
+	sm.Scope().SetName("receiver")
+	sm.Metrics().AppendEmpty().SetEmptyGauge().DataPoints().AppendEmpty()
+	sum := sm.Metrics().AppendEmpty().SetEmptySum()
+	sum.DataPoints().AppendEmpty()
+	sum.DataPoints().AppendEmpty()
+	sm = sms.AppendEmpty()
+	sm.Scope().SetName("processor")
+	sm.Metrics().AppendEmpty().SetEmptyHistogram().DataPoints().AppendEmpty()
+	sms.AppendEmpty().Metrics().AppendEmpty().SetEmptySummary().DataPoints().AppendEmpty()
+
+	// The scopes with the same name are counted together, also across resources.
+	sm = md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName("receiver")
+	sm.Scope().SetVersion("v2")
+	sm.Metrics().AppendEmpty().SetEmptyExponentialHistogram().DataPoints().AppendEmpty()
+	sm.Metrics().AppendEmpty().SetEmptyGauge()
+
+	assert.Equal(t, map[string]int{"receiver": 4, "processor": 1, "": 1}, md.DataPointCountByScope())
+	assert.Equal(t, 6, md.DataPointCount())
+}
+
 func TestHistogramWithNilSum(t *testing.T) {
 	metrics := NewMetrics()
 	ilm := metrics.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty()
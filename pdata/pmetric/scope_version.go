@@ -0,0 +1,24 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pmetric // import "go.opentelemetry.io/collector/pdata/pmetric"
+
+// SetDefaultScopeVersion sets the version of every instrumentation scope without a version to version.
+// The scopes which already have a version are left untouched.
+// It returns the number of scopes whose version was set.
+func (ms Metrics) SetDefaultScopeVersion(version string) int {
+	set := 0
+	ss := ms.ResourceMetrics()
+	for i := 0; i < ss.Len(); i++ {
+		sms := ss.At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			scope := sms.At(j).Scope()
+			if scope.Version() != "" {
+				continue
+			}
+			scope.SetVersion(version)
+			set++
+		}
+	}
+	return set
+}
@@ -0,0 +1,32 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pmetric
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetDefaultScopeVersion(t *testing.T) {
+	data := NewMetrics()
+	assert.Equal(t, 0, data.SetDefaultScopeVersion("v1.0.0"))
+
+	sms := data.ResourceMetrics().AppendEmpty().ScopeMetrics()
+	sms.AppendEmpty().Scope().SetName("unversioned")
+	versioned := sms.AppendEmpty().Scope()
+	versioned.SetName("versioned")
+	versioned.SetVersion("v0.1.0")
+	data.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty()
+
+	assert.Equal(t, 2, data.SetDefaultScopeVersion("v1.0.0"))
+	assert.Equal(t, "v1.0.0", sms.At(0).Scope().Version())
+	assert.Equal(t, "unversioned", sms.At(0).Scope().Name())
+	assert.Equal(t, "v0.1.0", sms.At(1).Scope().Version())
+	assert.Equal(t, "v1.0.0", data.ResourceMetrics().At(1).ScopeMetrics().At(0).Scope().Version())
+
+	// All the scopes now have a version.
+	assert.Equal(t, 0, data.SetDefaultScopeVersion("v2.0.0"))
+	assert.Equal(t, "v1.0.0", sms.At(0).Scope().Version())
+}
@@ -0,0 +1,39 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pmetric // import "go.opentelemetry.io/collector/pdata/pmetric"
+
+import (
+	"encoding/binary"
+)
+
+// ShardByResourceAttribute returns copies of the ResourceMetrics of ms split into the given number of
+// shards, keyed by shard index from 0 to shards-1. The shard of a ResourceMetrics is derived from the
+// Fingerprint of the value of its resource attribute key, so the resources with the same value are always
+// assigned the same shard, across calls and processes. The resources without this attribute are assigned
+// the shard 0. Only the shards holding at least one ResourceMetrics are returned.
+//
+// The ResourceMetrics are copied in order, ms is left unchanged.
+// ShardByResourceAttribute panics if shards is not positive.
+func (ms Metrics) ShardByResourceAttribute(key string, shards int) map[int]Metrics {
+	if shards <= 0 {
+		panic("pmetric: ShardByResourceAttribute shards must be positive")
+	}
+	result := make(map[int]Metrics)
+	rms := ms.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		shard := 0
+		if v, ok := rm.Resource().Attributes().Get(key); ok {
+			fp := v.Fingerprint()
+			shard = int(binary.LittleEndian.Uint64(fp[:8]) % uint64(shards))
+		}
+		dest, ok := result[shard]
+		if !ok {
+			dest = NewMetrics()
+			result[shard] = dest
+		}
+		rm.CopyTo(dest.ResourceMetrics().AppendEmpty())
+	}
+	return result
+}
@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pmetric
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func generateShardMetrics() Metrics {
+	md := NewMetrics()
+	for i := 0; i < 20; i++ {
+		rm := md.ResourceMetrics().AppendEmpty()
+		// Every value is used by two resources.
+		rm.Resource().Attributes().PutStr("service.name", fmt.Sprintf("service-%d", i%10))
+		rm.Resource().Attributes().PutInt("index", int64(i))
+		m := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+		m.SetName("metric")
+		m.SetEmptyGauge().DataPoints().AppendEmpty().SetIntValue(int64(i))
+	}
+	// A resource without the attribute.
+	md.ResourceMetrics().AppendEmpty().Resource().Attributes().PutInt("index", 20)
+	return md
+}
+
+func TestShardByResourceAttribute(t *testing.T) {
+	md := generateShardMetrics()
+	orig := NewMetrics()
+	md.CopyTo(orig)
+
+	shards := md.ShardByResourceAttribute("service.name", 4)
+	assert.Equal(t, orig, md)
+
+	total := 0
+	assignments := make(map[string]int)
+	for shard, sharded := range shards {
+		require.GreaterOrEqual(t, shard, 0)
+		require.Less(t, shard, 4)
+		rms := sharded.ResourceMetrics()
+		require.Positive(t, rms.Len())
+		prevIndex := int64(-1)
+		for i := 0; i < rms.Len(); i++ {
+			attrs := rms.At(i).Resource().Attributes()
+			// The resources are copied with their metrics, in order.
+			index, _ := attrs.Get("index")
+			assert.Greater(t, index.Int(), prevIndex)
+			prevIndex = index.Int()
+			name, ok := attrs.Get("service.name")
+			if !ok {
+				assert.Equal(t, 0, shard)
+				continue
+			}
+			assert.Equal(t, 1, rms.At(i).ScopeMetrics().At(0).Metrics().Len())
+			// The resources with the same value are in the same shard.
+			if prev, seen := assignments[name.Str()]; seen {
+				assert.Equal(t, prev, shard, name.Str())
+			}
+			assignments[name.Str()] = shard
+		}
+		total += rms.Len()
+	}
+	assert.Equal(t, 21, total)
+	assert.Len(t, assignments, 10)
+	// Ten distinct values are spread over more than a single shard.
+	assert.Greater(t, len(shards), 1)
+
+	// The assignment is deterministic.
+	for i := 0; i < 5; i++ {
+		again := generateShardMetrics().ShardByResourceAttribute("service.name", 4)
+		assert.Equal(t, shards, again)
+	}
+}
+
+func TestShardByResourceAttributeSingleShard(t *testing.T) {
+	md := generateShardMetrics()
+	shards := md.ShardByResourceAttribute("service.name", 1)
+	require.Len(t, shards, 1)
+	assert.Equal(t, md, shards[0])
+
+	assert.Empty(t, NewMetrics().ShardByResourceAttribute("service.name", 4))
+}
+
+func TestShardByResourceAttributeInvalidShards(t *testing.T) {
+	assert.PanicsWithValue(t, "pmetric: ShardByResourceAttribute shards must be positive", func() { NewMetrics().ShardByResourceAttribute("service.name", 0) })
+}
@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pmetric // import "go.opentelemetry.io/collector/pdata/pmetric"
+
+// SplitByMetricType partitions the Metrics into batches holding the metrics of a single MetricType each,
+// in the order of the MetricType values: gauges, sums, histograms, exponential histograms and summaries.
+// Every batch carries a copy of the resources and scopes of the metrics it contains, keeping their grouping
+// and order, and the metrics are copied in order.
+//
+// If the Metrics has metrics of at most one MetricType, the returned slice only contains the Metrics itself.
+// Otherwise, the Metrics is not modified.
+func (ms Metrics) SplitByMetricType() []Metrics {
+	var types []MetricType
+	seen := make(map[MetricType]bool)
+	rms := ms.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		sms := rms.At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			metrics := sms.At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				if t := metrics.At(k).Type(); !seen[t] {
+					seen[t] = true
+					types = append(types, t)
+				}
+			}
+		}
+	}
+	if len(types) <= 1 {
+		return []Metrics{ms}
+	}
+
+	var batches []Metrics
+	for _, t := range []MetricType{MetricTypeEmpty, MetricTypeGauge, MetricTypeSum, MetricTypeHistogram, MetricTypeExponentialHistogram, MetricTypeSummary} {
+		if seen[t] {
+			batches = append(batches, ms.copyMetricType(t))
+		}
+	}
+	return batches
+}
+
+// copyMetricType returns a copy of the Metrics holding only the metrics of the MetricType.
+func (ms Metrics) copyMetricType(t MetricType) Metrics {
+	dest := NewMetrics()
+	rms := ms.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		var destRm ResourceMetrics
+		newResource := true
+		sms := rm.ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			sm := sms.At(j)
+			var destMetrics MetricSlice
+			newScope := true
+			metrics := sm.Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				m := metrics.At(k)
+				if m.Type() != t {
+					continue
+				}
+				if newResource {
+					destRm = dest.ResourceMetrics().AppendEmpty()
+					rm.Resource().CopyTo(destRm.Resource())
+					destRm.SetSchemaUrl(rm.SchemaUrl())
+					newResource = false
+				}
+				if newScope {
+					destSm := destRm.ScopeMetrics().AppendEmpty()
+					sm.Scope().CopyTo(destSm.Scope())
+					destSm.SetSchemaUrl(sm.SchemaUrl())
+					destMetrics = destSm.Metrics()
+					newScope = false
+				}
+				m.CopyTo(destMetrics.AppendEmpty())
+			}
+		}
+	}
+	return dest
+}
@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pmetric
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitByMetricType(t *testing.T) {
+	md := NewMetrics()
+	for r := 0; r < 2; r++ {
+		rm := md.ResourceMetrics().AppendEmpty()
+		rm.SetSchemaUrl("https://opentelemetry.io/schemas/1.24.0")
+		rm.Resource().Attributes().PutInt("resource", int64(r))
+		for s := 0; s < 2; s++ {
+			sm := rm.ScopeMetrics().AppendEmpty()
+			sm.Scope().SetName("scope")
+			sm.Scope().SetVersion(string(rune('a' + s)))
+			metrics := sm.Metrics()
+			metrics.AppendEmpty().SetName("sum1")
+			metrics.At(0).SetEmptySum().DataPoints().AppendEmpty().SetIntValue(1)
+			metrics.AppendEmpty().SetName("gauge")
+			metrics.At(1).SetEmptyGauge().DataPoints().AppendEmpty().SetIntValue(2)
+			metrics.AppendEmpty().SetName("sum2")
+			metrics.At(2).SetEmptySum().DataPoints().AppendEmpty().SetIntValue(3)
+			// Only the second resource has histograms, in its first scope.
+			if r == 1 && s == 0 {
+				metrics.AppendEmpty().SetName("histogram")
+				metrics.At(3).SetEmptyHistogram().DataPoints().AppendEmpty().SetCount(4)
+			}
+		}
+	}
+	orig := NewMetrics()
+	md.CopyTo(orig)
+
+	batches := md.SplitByMetricType()
+	require.Len(t, batches, 3)
+	assert.Equal(t, orig, md)
+
+	expected := []struct {
+		metricType MetricType
+		names      []string
+		scopes     []int
+	}{
+		{metricType: MetricTypeGauge, names: []string{"gauge"}, scopes: []int{2, 2}},
+		{metricType: MetricTypeSum, names: []string{"sum1", "sum2"}, scopes: []int{2, 2}},
+		{metricType: MetricTypeHistogram, names: []string{"histogram"}, scopes: []int{1}},
+	}
+	for i, batch := range batches {
+		exp := expected[i]
+		rms := batch.ResourceMetrics()
+		require.Equal(t, len(exp.scopes), rms.Len())
+		for r := 0; r < rms.Len(); r++ {
+			rm := rms.At(r)
+			assert.Equal(t, "https://opentelemetry.io/schemas/1.24.0", rm.SchemaUrl())
+			sms := rm.ScopeMetrics()
+			require.Equal(t, exp.scopes[r], sms.Len())
+			for s := 0; s < sms.Len(); s++ {
+				assert.Equal(t, "scope", sms.At(s).Scope().Name())
+				metrics := sms.At(s).Metrics()
+				require.Equal(t, len(exp.names), metrics.Len())
+				for m := 0; m < metrics.Len(); m++ {
+					assert.Equal(t, exp.metricType, metrics.At(m).Type())
+					assert.Equal(t, exp.names[m], metrics.At(m).Name())
+				}
+			}
+		}
+	}
+	// The histograms keep their resource.
+	v, ok := batches[2].ResourceMetrics().At(0).Resource().Attributes().Get("resource")
+	require.True(t, ok)
+	assert.Equal(t, int64(1), v.Int())
+	assert.Equal(t, "a", batches[2].ResourceMetrics().At(0).ScopeMetrics().At(0).Scope().Version())
+	assert.Equal(t, md.DataPointCount(), batches[0].DataPointCount()+batches[1].DataPointCount()+batches[2].DataPointCount())
+}
+
+func TestSplitByMetricTypeHomogeneous(t *testing.T) {
+	md := NewMetrics()
+	metrics := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	metrics.AppendEmpty().SetEmptySum()
+	metrics.AppendEmpty().SetEmptySum()
+	batches := md.SplitByMetricType()
+	require.Len(t, batches, 1)
+	assert.Equal(t, md, batches[0])
+
+	md = NewMetrics()
+	batches = md.SplitByMetricType()
+	require.Len(t, batches, 1)
+	assert.Equal(t, md, batches[0])
+}
@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pmetric // import "go.opentelemetry.io/collector/pdata/pmetric"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// Stats holds aggregate statistics about a Metrics batch.
+type Stats struct {
+	// DataPointCount is the number of data points.
+	DataPointCount int
+	// ResourceCount is the number of distinct resources, identified by their Fingerprint.
+	ResourceCount int
+	// MinTimestamp and MaxTimestamp are the earliest and latest timestamps of the data points,
+	// ignoring the unset ones. They are zero if no data point has a timestamp.
+	MinTimestamp pcommon.Timestamp
+	MaxTimestamp pcommon.Timestamp
+	// EstimatedBytes is the size of the batch encoded as an OTLP protobuf message.
+	EstimatedBytes int
+}
+
+// Stats returns the aggregate statistics of the Metrics.
+func (ms Metrics) Stats() Stats {
+	var stats Stats
+	resources := make(map[pcommon.Fingerprint]struct{})
+	rms := ms.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		resources[rm.Resource().Fingerprint()] = struct{}{}
+		ilms := rm.ScopeMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			metrics := ilms.At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				m := metrics.At(k)
+				switch m.Type() {
+				case MetricTypeGauge:
+					stats.addNumberDataPoints(m.Gauge().DataPoints())
+				case MetricTypeSum:
+					stats.addNumberDataPoints(m.Sum().DataPoints())
+				case MetricTypeHistogram:
+					dps := m.Histogram().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						stats.addDataPoint(dps.At(l).Timestamp())
+					}
+				case MetricTypeExponentialHistogram:
+					dps := m.ExponentialHistogram().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						stats.addDataPoint(dps.At(l).Timestamp())
+					}
+				case MetricTypeSummary:
+					dps := m.Summary().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						stats.addDataPoint(dps.At(l).Timestamp())
+					}
+				}
+			}
+		}
+	}
+	stats.ResourceCount = len(resources)
+	stats.EstimatedBytes = ms.getOrig().Size()
+	return stats
+}
+
+func (s *Stats) addNumberDataPoints(dps NumberDataPointSlice) {
+	for i := 0; i < dps.Len(); i++ {
+		s.addDataPoint(dps.At(i).Timestamp())
+	}
+}
+
+func (s *Stats) addDataPoint(ts pcommon.Timestamp) {
+	s.DataPointCount++
+	if ts == 0 {
+		return
+	}
+	if s.MinTimestamp == 0 || ts < s.MinTimestamp {
+		s.MinTimestamp = ts
+	}
+	if ts > s.MaxTimestamp {
+		s.MaxTimestamp = ts
+	}
+}
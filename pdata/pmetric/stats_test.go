@@ -0,0 +1,47 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pmetric
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestMetricsStats(t *testing.T) {
+	md := NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("host", "a")
+	metrics := rm.ScopeMetrics().AppendEmpty().Metrics()
+	metrics.AppendEmpty().SetEmptyGauge().DataPoints().AppendEmpty().SetTimestamp(150)
+	sum := metrics.AppendEmpty().SetEmptySum().DataPoints()
+	sum.AppendEmpty().SetTimestamp(120)
+	sum.AppendEmpty()
+	metrics.AppendEmpty().SetEmptyHistogram().DataPoints().AppendEmpty().SetTimestamp(300)
+
+	rm = md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("host", "b")
+	metrics = rm.ScopeMetrics().AppendEmpty().Metrics()
+	metrics.AppendEmpty().SetEmptyExponentialHistogram().DataPoints().AppendEmpty().SetTimestamp(pcommon.Timestamp(110))
+	metrics.AppendEmpty().SetEmptySummary().DataPoints().AppendEmpty().SetTimestamp(pcommon.Timestamp(250))
+
+	rm = md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("host", "a")
+	rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptyGauge().DataPoints().AppendEmpty().SetTimestamp(200)
+
+	assert.Equal(t, Stats{
+		DataPointCount: md.DataPointCount(),
+		ResourceCount:  2,
+		MinTimestamp:   110,
+		MaxTimestamp:   300,
+		EstimatedBytes: (&ProtoMarshaler{}).MetricsSize(md),
+	}, md.Stats())
+	assert.Equal(t, 7, md.Stats().DataPointCount)
+}
+
+func TestMetricsStatsEmpty(t *testing.T) {
+	assert.Equal(t, Stats{}, NewMetrics().Stats())
+}
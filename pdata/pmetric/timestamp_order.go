@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pmetric // import "go.opentelemetry.io/collector/pdata/pmetric"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// TimestampOrderPolicy specifies how EnforceTimestampOrder handles the data points out of order.
+type TimestampOrderPolicy int32
+
+const (
+	// TimestampOrderPolicyDrop drops the data points whose timestamp is not after the timestamp of a
+	// previous data point of their series.
+	TimestampOrderPolicyDrop TimestampOrderPolicy = iota
+	// TimestampOrderPolicySort sorts the data points of the metrics holding data points out of order by
+	// timestamp. The data points with the same timestamp are kept in order.
+	TimestampOrderPolicySort
+)
+
+// String returns the string representation of the TimestampOrderPolicy.
+func (p TimestampOrderPolicy) String() string {
+	switch p {
+	case TimestampOrderPolicyDrop:
+		return "Drop"
+	case TimestampOrderPolicySort:
+		return "Sort"
+	}
+	return ""
+}
+
+// EnforceTimestampOrder makes the timestamps of the data points of every series increase, according to the
+// policy, and returns the number of corrected data points: the dropped ones with TimestampOrderPolicyDrop,
+// and the ones following a data point of their series with a later timestamp with TimestampOrderPolicySort.
+//
+// The series of a data point is identified by its metric and its attributes. The data points of the same
+// series in distinct metrics, e.g. metrics of the same scope with the same name, can be merged beforehand
+// with MergeDuplicateDataPoints.
+func (ms Metrics) EnforceTimestampOrder(policy TimestampOrderPolicy) int {
+	corrected := 0
+	rms := ms.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		sms := rms.At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			metrics := sms.At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				corrected += enforceTimestampOrder(metrics.At(k), policy)
+			}
+		}
+	}
+	return corrected
+}
+
+// enforceTimestampOrder corrects the data points of the metric out of order, and returns their number.
+func enforceTimestampOrder(m Metric, policy TimestampOrderPolicy) int {
+	// The latest timestamp of every series.
+	latest := make(map[pcommon.Fingerprint]pcommon.Timestamp)
+	outOfOrder := make(map[int]bool)
+	for i := 0; i < dataPointsLen(m); i++ {
+		key := newDataPointKey(m, i)
+		last, ok := latest[key.attributes]
+		switch {
+		case !ok || key.timestamp > last:
+			latest[key.attributes] = key.timestamp
+		case key.timestamp < last || policy == TimestampOrderPolicyDrop:
+			outOfOrder[i] = true
+		}
+	}
+	if len(outOfOrder) == 0 {
+		return 0
+	}
+
+	if policy == TimestampOrderPolicySort {
+		switch m.Type() {
+		case MetricTypeGauge:
+			m.Gauge().DataPoints().Sort(func(a, b NumberDataPoint) bool { return a.Timestamp() < b.Timestamp() })
+		case MetricTypeSum:
+			m.Sum().DataPoints().Sort(func(a, b NumberDataPoint) bool { return a.Timestamp() < b.Timestamp() })
+		case MetricTypeHistogram:
+			m.Histogram().DataPoints().Sort(func(a, b HistogramDataPoint) bool { return a.Timestamp() < b.Timestamp() })
+		case MetricTypeExponentialHistogram:
+			m.ExponentialHistogram().DataPoints().Sort(func(a, b ExponentialHistogramDataPoint) bool { return a.Timestamp() < b.Timestamp() })
+		case MetricTypeSummary:
+			m.Summary().DataPoints().Sort(func(a, b SummaryDataPoint) bool { return a.Timestamp() < b.Timestamp() })
+		}
+		return len(outOfOrder)
+	}
+
+	i := 0
+	isOutOfOrder := func() bool {
+		remove := outOfOrder[i]
+		i++
+		return remove
+	}
+	switch m.Type() {
+	case MetricTypeGauge:
+		m.Gauge().DataPoints().RemoveIf(func(NumberDataPoint) bool { return isOutOfOrder() })
+	case MetricTypeSum:
+		m.Sum().DataPoints().RemoveIf(func(NumberDataPoint) bool { return isOutOfOrder() })
+	case MetricTypeHistogram:
+		m.Histogram().DataPoints().RemoveIf(func(HistogramDataPoint) bool { return isOutOfOrder() })
+	case MetricTypeExponentialHistogram:
+		m.ExponentialHistogram().DataPoints().RemoveIf(func(ExponentialHistogramDataPoint) bool { return isOutOfOrder() })
+	case MetricTypeSummary:
+		m.Summary().DataPoints().RemoveIf(func(SummaryDataPoint) bool { return isOutOfOrder() })
+	}
+	return len(outOfOrder)
+}
@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pmetric
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// newOutOfOrderGauge returns Metrics with a gauge whose data points of the series "a" and "b" have the
+// given timestamps, alternating between the series, with the timestamp as value.
+func newOutOfOrderGauge(timestamps ...pcommon.Timestamp) Metrics {
+	md := NewMetrics()
+	dps := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptyGauge().DataPoints()
+	for i, ts := range timestamps {
+		dp := dps.AppendEmpty()
+		dp.Attributes().PutStr("series", string(rune('a'+i%2)))
+		dp.SetTimestamp(ts)
+		dp.SetIntValue(int64(ts))
+	}
+	return md
+}
+
+// gaugeSeries returns the timestamps of the data points of the gauge by series.
+func gaugeSeries(md Metrics) map[string][]pcommon.Timestamp {
+	series := map[string][]pcommon.Timestamp{}
+	dps := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Gauge().DataPoints()
+	for i := 0; i < dps.Len(); i++ {
+		name, _ := dps.At(i).Attributes().Get("series")
+		series[name.Str()] = append(series[name.Str()], dps.At(i).Timestamp())
+	}
+	return series
+}
+
+func TestEnforceTimestampOrderDrop(t *testing.T) {
+	// Series a: 10, 30, 20, 40. Series b: 15, 15, 5, 25.
+	md := newOutOfOrderGauge(10, 15, 30, 15, 20, 5, 40, 25)
+	assert.Equal(t, 3, md.EnforceTimestampOrder(TimestampOrderPolicyDrop))
+	assert.Equal(t, map[string][]pcommon.Timestamp{
+		"a": {10, 30, 40},
+		"b": {15, 25},
+	}, gaugeSeries(md))
+	// In order data points are left as is.
+	assert.Equal(t, 0, md.EnforceTimestampOrder(TimestampOrderPolicyDrop))
+}
+
+func TestEnforceTimestampOrderSort(t *testing.T) {
+	md := newOutOfOrderGauge(10, 15, 30, 15, 20, 5, 40, 25)
+	assert.Equal(t, 2, md.EnforceTimestampOrder(TimestampOrderPolicySort))
+	// The data points with the same timestamp are kept.
+	assert.Equal(t, map[string][]pcommon.Timestamp{
+		"a": {10, 20, 30, 40},
+		"b": {5, 15, 15, 25},
+	}, gaugeSeries(md))
+	dps := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Gauge().DataPoints()
+	for i := 0; i < dps.Len(); i++ {
+		assert.Equal(t, int64(dps.At(i).Timestamp()), dps.At(i).IntValue())
+	}
+	assert.Equal(t, 0, md.EnforceTimestampOrder(TimestampOrderPolicySort))
+}
+
+func TestEnforceTimestampOrderAllTypes(t *testing.T) {
+	md := NewMetrics()
+	metrics := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	sum := metrics.AppendEmpty().SetEmptySum().DataPoints()
+	sum.AppendEmpty().SetTimestamp(20)
+	sum.AppendEmpty().SetTimestamp(10)
+	histogram := metrics.AppendEmpty().SetEmptyHistogram().DataPoints()
+	histogram.AppendEmpty().SetTimestamp(20)
+	histogram.AppendEmpty().SetTimestamp(10)
+	expHistogram := metrics.AppendEmpty().SetEmptyExponentialHistogram().DataPoints()
+	expHistogram.AppendEmpty().SetTimestamp(20)
+	expHistogram.AppendEmpty().SetTimestamp(10)
+	summary := metrics.AppendEmpty().SetEmptySummary().DataPoints()
+	summary.AppendEmpty().SetTimestamp(20)
+	summary.AppendEmpty().SetTimestamp(10)
+	// The series are distinct by metric.
+	gauge := metrics.AppendEmpty().SetEmptyGauge().DataPoints()
+	gauge.AppendEmpty().SetTimestamp(5)
+	metrics.AppendEmpty()
+
+	sorted := NewMetrics()
+	md.CopyTo(sorted)
+	assert.Equal(t, 4, sorted.EnforceTimestampOrder(TimestampOrderPolicySort))
+	sortedMetrics := sorted.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	assert.Equal(t, pcommon.Timestamp(10), sortedMetrics.At(0).Sum().DataPoints().At(0).Timestamp())
+	assert.Equal(t, pcommon.Timestamp(10), sortedMetrics.At(1).Histogram().DataPoints().At(0).Timestamp())
+	assert.Equal(t, pcommon.Timestamp(10), sortedMetrics.At(2).ExponentialHistogram().DataPoints().At(0).Timestamp())
+	assert.Equal(t, pcommon.Timestamp(10), sortedMetrics.At(3).Summary().DataPoints().At(0).Timestamp())
+
+	assert.Equal(t, 4, md.EnforceTimestampOrder(TimestampOrderPolicyDrop))
+	assert.Equal(t, 5, md.DataPointCount())
+	assert.Equal(t, pcommon.Timestamp(20), metrics.At(3).Summary().DataPoints().At(0).Timestamp())
+}
+
+func TestTimestampOrderPolicyString(t *testing.T) {
+	assert.Equal(t, "Drop", TimestampOrderPolicyDrop.String())
+	assert.Equal(t, "Sort", TimestampOrderPolicySort.String())
+	assert.Equal(t, "", TimestampOrderPolicy(100).String())
+}
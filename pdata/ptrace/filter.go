@@ -0,0 +1,45 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ptrace // import "go.opentelemetry.io/collector/pdata/ptrace"
+
+import "go.opentelemetry.io/collector/pdata/pcommon"
+
+// Filter returns new Traces with a copy of the spans of ms for which keep returns true, with a copy of their
+// resource and scope. The resources and scopes without any span kept are not copied. ms is not modified.
+func (ms Traces) Filter(keep func(pcommon.Resource, pcommon.InstrumentationScope, Span) bool) Traces {
+	filtered := NewTraces()
+	var destRs ResourceSpans
+	var destSpans SpanSlice
+	rss := ms.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		newResource := true
+		sss := rs.ScopeSpans()
+		for j := 0; j < sss.Len(); j++ {
+			ss := sss.At(j)
+			newScope := true
+			items := ss.Spans()
+			for k := 0; k < items.Len(); k++ {
+				if !keep(rs.Resource(), ss.Scope(), items.At(k)) {
+					continue
+				}
+				if newResource {
+					destRs = filtered.ResourceSpans().AppendEmpty()
+					rs.Resource().CopyTo(destRs.Resource())
+					destRs.SetSchemaUrl(rs.SchemaUrl())
+					newResource = false
+				}
+				if newScope {
+					destSs := destRs.ScopeSpans().AppendEmpty()
+					ss.Scope().CopyTo(destSs.Scope())
+					destSs.SetSchemaUrl(ss.SchemaUrl())
+					destSpans = destSs.Spans()
+					newScope = false
+				}
+				items.At(k).CopyTo(destSpans.AppendEmpty())
+			}
+		}
+	}
+	return filtered
+}
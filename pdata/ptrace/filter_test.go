@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ptrace
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// generateFilterTraces returns Traces with two resources of two scopes of three spans named by their
+// resource, scope and index, keeping only the spans for which keep returns true if it is not nil.
+func generateFilterTraces(keep func(r, s, i int) bool) Traces {
+	td := NewTraces()
+	for r := 0; r < 2; r++ {
+		var rs ResourceSpans
+		for s := 0; s < 2; s++ {
+			var ss ScopeSpans
+			for i := 0; i < 3; i++ {
+				if keep != nil && !keep(r, s, i) {
+					continue
+				}
+				if ss == (ScopeSpans{}) {
+					if rs == (ResourceSpans{}) {
+						rs = td.ResourceSpans().AppendEmpty()
+						rs.SetSchemaUrl("https://opentelemetry.io/schemas/1.24.0")
+						rs.Resource().Attributes().PutInt("resource", int64(r))
+					}
+					ss = rs.ScopeSpans().AppendEmpty()
+					ss.SetSchemaUrl("https://opentelemetry.io/schemas/1.25.0")
+					ss.Scope().SetName("scope")
+					ss.Scope().SetVersion(string(rune('a' + s)))
+				}
+				span := ss.Spans().AppendEmpty()
+				span.SetName(string(rune('a'+r)) + string(rune('a'+s)) + string(rune('a'+i)))
+				span.SetSpanID(pcommon.SpanID([8]byte{byte(r), byte(s), byte(i)}))
+			}
+		}
+	}
+	return td
+}
+
+func TestTracesFilter(t *testing.T) {
+	td := generateFilterTraces(nil)
+	filtered := td.Filter(func(res pcommon.Resource, scope pcommon.InstrumentationScope, span Span) bool {
+		r, _ := res.Attributes().Get("resource")
+		return r.Int() == 1 && scope.Version() == "b" || span.SpanID()[2] == 0
+	})
+	assert.Equal(t, generateFilterTraces(func(r, s, i int) bool {
+		return r == 1 && s == 1 || i == 0
+	}), filtered)
+	// The source is unchanged.
+	assert.Equal(t, generateFilterTraces(nil), td)
+}
+
+func TestTracesFilterNone(t *testing.T) {
+	td := generateFilterTraces(nil)
+	filtered := td.Filter(func(pcommon.Resource, pcommon.InstrumentationScope, Span) bool {
+		return false
+	})
+	assert.Equal(t, NewTraces(), filtered)
+	assert.Equal(t, 12, td.SpanCount())
+}
@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ptrace // import "go.opentelemetry.io/collector/pdata/ptrace"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+type scopeKey struct {
+	name       string
+	version    string
+	schemaURL  string
+	attributes pcommon.Fingerprint
+	dropped    uint32
+}
+
+// GroupByScope merges, within each ResourceSpans, the ScopeSpans having an identical instrumentation scope and
+// schema URL, i.e. the same name, version, attributes and dropped attributes count, so each distinct
+// scope appears only once per resource. The spans of the duplicates are appended, in order, to the
+// first ScopeSpans with the same scope, which keeps its position in the resource.
+func (ms Traces) GroupByScope() {
+	rss := ms.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		sss := rss.At(i).ScopeSpans()
+		if sss.Len() < 2 {
+			continue
+		}
+		first := make(map[scopeKey]ScopeSpans, sss.Len())
+		sss.RemoveIf(func(ss ScopeSpans) bool {
+			scope := ss.Scope()
+			key := scopeKey{
+				name:       scope.Name(),
+				version:    scope.Version(),
+				schemaURL:  ss.SchemaUrl(),
+				attributes: scope.Attributes().Fingerprint(),
+				dropped:    scope.DroppedAttributesCount(),
+			}
+			dest, ok := first[key]
+			if !ok {
+				first[key] = ss
+				return false
+			}
+			ss.Spans().MoveAndAppendTo(dest.Spans())
+			return true
+		})
+	}
+}
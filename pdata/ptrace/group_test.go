@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ptrace
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func appendScopeSpans(rs ResourceSpans, scope, version string, names ...string) ScopeSpans {
+	ss := rs.ScopeSpans().AppendEmpty()
+	ss.Scope().SetName(scope)
+	ss.Scope().SetVersion(version)
+	for _, name := range names {
+		ss.Spans().AppendEmpty().SetName(name)
+	}
+	return ss
+}
+
+func TestGroupByScope(t *testing.T) {
+	td := NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	appendScopeSpans(rs, "a", "1.0", "a1", "a2")
+	appendScopeSpans(rs, "b", "1.0", "b1")
+	appendScopeSpans(rs, "a", "1.0", "a3")
+	appendScopeSpans(rs, "a", "2.0", "a4")
+	appendScopeSpans(rs, "b", "1.0", "b2")
+	appendScopeSpans(rs, "a", "1.0", "a5").Scope().Attributes().PutStr("k", "v")
+	appendScopeSpans(rs, "a", "1.0", "a6").SetSchemaUrl("https://opentelemetry.io/schemas/1.21.0")
+	appendScopeSpans(rs, "a", "1.0", "a7").Scope().Attributes().PutStr("k", "v")
+	// Scopes are only merged within the same resource.
+	other := td.ResourceSpans().AppendEmpty()
+	appendScopeSpans(other, "a", "1.0", "o1")
+
+	td.GroupByScope()
+
+	assert.Equal(t, 10, td.SpanCount())
+	var got [][]string
+	for i := 0; i < rs.ScopeSpans().Len(); i++ {
+		var names []string
+		ss := rs.ScopeSpans().At(i)
+		for j := 0; j < ss.Spans().Len(); j++ {
+			names = append(names, ss.Spans().At(j).Name())
+		}
+		got = append(got, names)
+	}
+	assert.Equal(t, [][]string{{"a1", "a2", "a3"}, {"b1", "b2"}, {"a4"}, {"a5", "a7"}, {"a6"}}, got)
+	assert.Equal(t, "a", rs.ScopeSpans().At(0).Scope().Name())
+	assert.Equal(t, "b", rs.ScopeSpans().At(1).Scope().Name())
+	assert.Equal(t, "2.0", rs.ScopeSpans().At(2).Scope().Version())
+	assert.Equal(t, 1, rs.ScopeSpans().At(3).Scope().Attributes().Len())
+	assert.Equal(t, "https://opentelemetry.io/schemas/1.21.0", rs.ScopeSpans().At(4).SchemaUrl())
+	assert.Equal(t, 1, other.ScopeSpans().Len())
+}
@@ -0,0 +1,25 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ptrace
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddResourceAttributeAliases(t *testing.T) {
+	data := NewTraces()
+	data.ResourceSpans().AppendEmpty().Resource().Attributes().PutStr("deployment.environment.name", "prod")
+	data.ResourceSpans().AppendEmpty().Resource().Attributes().PutStr("service.name", "svc")
+
+	aliases := map[string]string{"deployment.environment.name": "deployment.environment"}
+	assert.Equal(t, 1, data.AddResourceAttributeAliases(aliases, false))
+	assert.Equal(t, map[string]any{"deployment.environment.name": "prod", "deployment.environment": "prod"},
+		data.ResourceSpans().At(0).Resource().Attributes().AsRaw())
+	assert.Equal(t, map[string]any{"service.name": "svc"}, data.ResourceSpans().At(1).Resource().Attributes().AsRaw())
+
+	assert.Equal(t, 1, data.AddResourceAttributeAliases(aliases, true))
+	assert.Equal(t, map[string]any{"deployment.environment": "prod"}, data.ResourceSpans().At(0).Resource().Attributes().AsRaw())
+}
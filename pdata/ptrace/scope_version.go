@@ -0,0 +1,24 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ptrace // import "go.opentelemetry.io/collector/pdata/ptrace"
+
+// SetDefaultScopeVersion sets the version of every instrumentation scope without a version to version.
+// The scopes which already have a version are left untouched.
+// It returns the number of scopes whose version was set.
+func (ms Traces) SetDefaultScopeVersion(version string) int {
+	set := 0
+	ss := ms.ResourceSpans()
+	for i := 0; i < ss.Len(); i++ {
+		sss := ss.At(i).ScopeSpans()
+		for j := 0; j < sss.Len(); j++ {
+			scope := sss.At(j).Scope()
+			if scope.Version() != "" {
+				continue
+			}
+			scope.SetVersion(version)
+			set++
+		}
+	}
+	return set
+}
@@ -0,0 +1,32 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ptrace
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetDefaultScopeVersion(t *testing.T) {
+	data := NewTraces()
+	assert.Equal(t, 0, data.SetDefaultScopeVersion("v1.0.0"))
+
+	sss := data.ResourceSpans().AppendEmpty().ScopeSpans()
+	sss.AppendEmpty().Scope().SetName("unversioned")
+	versioned := sss.AppendEmpty().Scope()
+	versioned.SetName("versioned")
+	versioned.SetVersion("v0.1.0")
+	data.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty()
+
+	assert.Equal(t, 2, data.SetDefaultScopeVersion("v1.0.0"))
+	assert.Equal(t, "v1.0.0", sss.At(0).Scope().Version())
+	assert.Equal(t, "unversioned", sss.At(0).Scope().Name())
+	assert.Equal(t, "v0.1.0", sss.At(1).Scope().Version())
+	assert.Equal(t, "v1.0.0", data.ResourceSpans().At(1).ScopeSpans().At(0).Scope().Version())
+
+	// All the scopes now have a version.
+	assert.Equal(t, 0, data.SetDefaultScopeVersion("v2.0.0"))
+	assert.Equal(t, "v1.0.0", sss.At(0).Scope().Version())
+}
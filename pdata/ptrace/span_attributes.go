@@ -0,0 +1,37 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ptrace // import "go.opentelemetry.io/collector/pdata/ptrace"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// EnsureSpanAttributes makes sure every span in the Traces carries all the attributes in defaults.
+// Attributes missing on a span are set to a copy of the default value, attributes already present
+// are left untouched regardless of their value.
+// It returns the number of attributes that were defaulted across all spans.
+func (ms Traces) EnsureSpanAttributes(defaults pcommon.Map) int {
+	if defaults.Len() == 0 {
+		return 0
+	}
+	defaulted := 0
+	rss := ms.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		ilss := rss.At(i).ScopeSpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				attrs := spans.At(k).Attributes()
+				defaults.Range(func(key string, val pcommon.Value) bool {
+					if _, ok := attrs.Get(key); !ok {
+						val.CopyTo(attrs.PutEmpty(key))
+						defaulted++
+					}
+					return true
+				})
+			}
+		}
+	}
+	return defaulted
+}
@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ptrace
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestEnsureSpanAttributes(t *testing.T) {
+	td := NewTraces()
+	spans := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	spans.AppendEmpty().SetName("bare")
+	full := spans.AppendEmpty()
+	full.SetName("full")
+	full.Attributes().PutStr("sampling.priority", "high")
+	full.Attributes().PutInt("tenant.id", 7)
+	partial := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	partial.SetName("partial")
+	partial.Attributes().PutStr("sampling.priority", "low")
+
+	defaults := pcommon.NewMap()
+	defaults.PutStr("sampling.priority", "normal")
+	defaults.PutInt("tenant.id", 0)
+
+	assert.Equal(t, 3, td.EnsureSpanAttributes(defaults))
+
+	bare := spans.At(0).Attributes()
+	assert.Equal(t, map[string]any{"sampling.priority": "normal", "tenant.id": int64(0)}, bare.AsRaw())
+	assert.Equal(t, map[string]any{"sampling.priority": "high", "tenant.id": int64(7)}, full.Attributes().AsRaw())
+	assert.Equal(t, map[string]any{"sampling.priority": "low", "tenant.id": int64(0)}, partial.Attributes().AsRaw())
+
+	// Running again is a no-op.
+	assert.Equal(t, 0, td.EnsureSpanAttributes(defaults))
+}
+
+func TestEnsureSpanAttributesCopiesDefaults(t *testing.T) {
+	td := NewTraces()
+	span := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+
+	defaults := pcommon.NewMap()
+	require.NoError(t, defaults.FromRaw(map[string]any{"labels": map[string]any{"team": "core"}}))
+	assert.Equal(t, 1, td.EnsureSpanAttributes(defaults))
+
+	// Mutating the stamped value must not affect the defaults.
+	labels, ok := span.Attributes().Get("labels")
+	require.True(t, ok)
+	labels.Map().PutStr("team", "other")
+	assert.Equal(t, map[string]any{"labels": map[string]any{"team": "core"}}, defaults.AsRaw())
+}
+
+func TestEnsureSpanAttributesEmptyDefaults(t *testing.T) {
+	td := NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	assert.Equal(t, 0, td.EnsureSpanAttributes(pcommon.NewMap()))
+}
@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ptrace // import "go.opentelemetry.io/collector/pdata/ptrace"
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// RepairEndTimestamps sets the end timestamp of every span with a start timestamp but no end timestamp
+// to its start timestamp plus defaultDuration, which can be zero. The spans without start timestamp are left
+// unchanged since no end timestamp can be derived. The end timestamps already set are never modified, even
+// if they are before the start timestamp.
+// It returns the number of spans whose end timestamp was set.
+func (ms Traces) RepairEndTimestamps(defaultDuration time.Duration) int {
+	repaired := 0
+	rss := ms.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		ilss := rss.At(i).ScopeSpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				if span.EndTimestamp() != 0 || span.StartTimestamp() == 0 {
+					continue
+				}
+				span.SetEndTimestamp(span.StartTimestamp() + pcommon.Timestamp(defaultDuration))
+				repaired++
+			}
+		}
+	}
+	return repaired
+}
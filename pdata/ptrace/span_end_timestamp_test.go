@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ptrace
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestRepairEndTimestamps(t *testing.T) {
+	start := pcommon.NewTimestampFromTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	newTraces := func() Traces {
+		td := NewTraces()
+		spans := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+		// Missing end timestamp.
+		spans.AppendEmpty().SetStartTimestamp(start)
+		// Valid span.
+		valid := spans.AppendEmpty()
+		valid.SetStartTimestamp(start)
+		valid.SetEndTimestamp(start + pcommon.Timestamp(time.Second))
+		// No timestamps at all.
+		spans.AppendEmpty()
+		// Missing end timestamp, in another resource.
+		td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetStartTimestamp(start + 1)
+		return td
+	}
+	endTimestamps := func(td Traces) []pcommon.Timestamp {
+		var ends []pcommon.Timestamp
+		rss := td.ResourceSpans()
+		for i := 0; i < rss.Len(); i++ {
+			spans := rss.At(i).ScopeSpans().At(0).Spans()
+			for j := 0; j < spans.Len(); j++ {
+				ends = append(ends, spans.At(j).EndTimestamp())
+			}
+		}
+		return ends
+	}
+
+	td := newTraces()
+	assert.Equal(t, 2, td.RepairEndTimestamps(0))
+	assert.Equal(t, []pcommon.Timestamp{start, start + pcommon.Timestamp(time.Second), 0, start + 1}, endTimestamps(td))
+	// Running again is a no-op.
+	assert.Equal(t, 0, td.RepairEndTimestamps(0))
+
+	td = newTraces()
+	assert.Equal(t, 2, td.RepairEndTimestamps(time.Millisecond))
+	assert.Equal(t, []pcommon.Timestamp{
+		start + pcommon.Timestamp(time.Millisecond),
+		start + pcommon.Timestamp(time.Second),
+		0,
+		start + 1 + pcommon.Timestamp(time.Millisecond),
+	}, endTimestamps(td))
+}
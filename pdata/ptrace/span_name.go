@@ -0,0 +1,45 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ptrace // import "go.opentelemetry.io/collector/pdata/ptrace"
+
+import (
+	"regexp"
+)
+
+// SpanNameRule rewrites the parts of the span names matching Pattern with Replacement, in which $1 or ${name}
+// is replaced with the text of the corresponding group as with regexp.Regexp.ReplaceAllString. For example, the
+// rule {Pattern: regexp.MustCompile(`/\d+\b`), Replacement: "/:id"} rewrites "GET /users/42" to "GET /users/:id".
+type SpanNameRule struct {
+	Pattern     *regexp.Regexp
+	Replacement string
+}
+
+// RewriteSpanNames applies the rules to the name of every span, in order, each rule to the name rewritten by
+// the previous ones.
+// It returns the number of spans whose name was changed.
+func (ms Traces) RewriteSpanNames(rules []SpanNameRule) int {
+	if len(rules) == 0 {
+		return 0
+	}
+	rewritten := 0
+	rss := ms.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		ilss := rss.At(i).ScopeSpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				name := span.Name()
+				for _, rule := range rules {
+					name = rule.Pattern.ReplaceAllString(name, rule.Replacement)
+				}
+				if name != span.Name() {
+					span.SetName(name)
+					rewritten++
+				}
+			}
+		}
+	}
+	return rewritten
+}
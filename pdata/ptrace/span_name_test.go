@@ -0,0 +1,57 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ptrace
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRewriteSpanNames(t *testing.T) {
+	rules := []SpanNameRule{
+		{Pattern: regexp.MustCompile(`/\d+\b`), Replacement: "/:id"},
+		{Pattern: regexp.MustCompile(`/[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}\b`), Replacement: "/:uuid"},
+		// The groups of the pattern are expanded in the replacement.
+		{Pattern: regexp.MustCompile(`^(GET|POST) /v\d+/`), Replacement: "$1 /"},
+	}
+	names := []string{
+		"GET /users/42",
+		"GET /users/42/orders/1337",
+		"POST /v2/orders/0b7e0c6a-3c4a-4bb2-8a83-2b9a3e1f2c11",
+		"GET /users/42abc",
+		"GET /users/:id",
+		"SELECT users",
+	}
+	td := NewTraces()
+	spans := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	for _, name := range names[:3] {
+		spans.AppendEmpty().SetName(name)
+	}
+	spans = td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	for _, name := range names[3:] {
+		spans.AppendEmpty().SetName(name)
+	}
+
+	assert.Equal(t, 3, td.RewriteSpanNames(rules))
+	var got []string
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		spans := td.ResourceSpans().At(i).ScopeSpans().At(0).Spans()
+		for j := 0; j < spans.Len(); j++ {
+			got = append(got, spans.At(j).Name())
+		}
+	}
+	assert.Equal(t, []string{
+		"GET /users/:id",
+		"GET /users/:id/orders/:id",
+		"POST /orders/:uuid",
+		"GET /users/42abc",
+		"GET /users/:id",
+		"SELECT users",
+	}, got)
+	// The normalized names are not rewritten again.
+	assert.Equal(t, 0, td.RewriteSpanNames(rules))
+	assert.Equal(t, 0, td.RewriteSpanNames(nil))
+}
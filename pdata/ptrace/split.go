@@ -0,0 +1,150 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ptrace // import "go.opentelemetry.io/collector/pdata/ptrace"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// SplitBySpanCount splits the Traces into batches of at most maxSpans spans each, keeping the spans in order.
+// Every batch carries a copy of the resource and scope of the spans it contains, and the spans are copied
+// with their events and links, so the links between spans are preserved across batches.
+//
+// When the Traces is split and markerKey is not empty, every span of every batch gets the markerKey attribute
+// set to the index of its batch, so that downstream consumers can recognize the continuation batches.
+//
+// If maxSpans is not positive or the Traces has no more than maxSpans spans, the returned slice only contains
+// the Traces itself. Otherwise, the Traces is not modified.
+func (ms Traces) SplitBySpanCount(maxSpans int, markerKey string) []Traces {
+	if maxSpans <= 0 || ms.SpanCount() <= maxSpans {
+		return []Traces{ms}
+	}
+
+	var batches []Traces
+	var destRs ResourceSpans
+	var destSpans SpanSlice
+	count := maxSpans
+	rss := ms.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		newResource := true
+		ilss := rs.ScopeSpans()
+		for j := 0; j < ilss.Len(); j++ {
+			ils := ilss.At(j)
+			newScope := true
+			spans := ils.Spans()
+			for k := 0; k < spans.Len(); k++ {
+				if count == maxSpans {
+					batches = append(batches, NewTraces())
+					count = 0
+					newResource = true
+				}
+				if newResource {
+					destRs = batches[len(batches)-1].ResourceSpans().AppendEmpty()
+					rs.Resource().CopyTo(destRs.Resource())
+					destRs.SetSchemaUrl(rs.SchemaUrl())
+					newResource = false
+					newScope = true
+				}
+				if newScope {
+					destIls := destRs.ScopeSpans().AppendEmpty()
+					ils.Scope().CopyTo(destIls.Scope())
+					destIls.SetSchemaUrl(ils.SchemaUrl())
+					destSpans = destIls.Spans()
+					newScope = false
+				}
+				span := destSpans.AppendEmpty()
+				spans.At(k).CopyTo(span)
+				if markerKey != "" {
+					span.Attributes().PutInt(markerKey, int64(len(batches)-1))
+				}
+				count++
+			}
+		}
+	}
+	return batches
+}
+
+// SplitByTraceID splits the Traces into batches of at most maxSpans spans each, keeping all the spans of a
+// trace, i.e. with the same trace ID, in the same batch, so the parent and child spans stay together. The
+// traces are put in the batches in the order of their first span, and the spans keep their order.
+// Every batch carries a copy of the resource and scope of the spans it contains.
+//
+// A trace with more than maxSpans spans is put alone in its batch, which exceeds the limit.
+//
+// If maxSpans is not positive or the Traces has no more than maxSpans spans, the returned slice only contains
+// the Traces itself. Otherwise, the Traces is not modified.
+func (ms Traces) SplitByTraceID(maxSpans int) []Traces {
+	if maxSpans <= 0 || ms.SpanCount() <= maxSpans {
+		return []Traces{ms}
+	}
+
+	sizes := make(map[pcommon.TraceID]int)
+	var order []pcommon.TraceID
+	ms.forEachSpan(func(span Span) {
+		id := span.TraceID()
+		if _, ok := sizes[id]; !ok {
+			order = append(order, id)
+		}
+		sizes[id]++
+	})
+	batchOf := make(map[pcommon.TraceID]int, len(order))
+	var batches []Traces
+	// count is the number of spans of the current batch.
+	count := 0
+	for _, id := range order {
+		if len(batches) == 0 || (count > 0 && count+sizes[id] > maxSpans) {
+			batches = append(batches, NewTraces())
+			count = 0
+		}
+		batchOf[id] = len(batches) - 1
+		count += sizes[id]
+	}
+
+	rss := ms.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		destRss := make(map[int]ResourceSpans)
+		sss := rs.ScopeSpans()
+		for j := 0; j < sss.Len(); j++ {
+			ss := sss.At(j)
+			destSpans := make(map[int]SpanSlice)
+			spans := ss.Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				batch := batchOf[span.TraceID()]
+				dest, ok := destSpans[batch]
+				if !ok {
+					destRs, ok := destRss[batch]
+					if !ok {
+						destRs = batches[batch].ResourceSpans().AppendEmpty()
+						rs.Resource().CopyTo(destRs.Resource())
+						destRs.SetSchemaUrl(rs.SchemaUrl())
+						destRss[batch] = destRs
+					}
+					destSs := destRs.ScopeSpans().AppendEmpty()
+					ss.Scope().CopyTo(destSs.Scope())
+					destSs.SetSchemaUrl(ss.SchemaUrl())
+					dest = destSs.Spans()
+					destSpans[batch] = dest
+				}
+				span.CopyTo(dest.AppendEmpty())
+			}
+		}
+	}
+	return batches
+}
+
+func (ms Traces) forEachSpan(f func(Span)) {
+	rss := ms.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		sss := rss.At(i).ScopeSpans()
+		for j := 0; j < sss.Len(); j++ {
+			spans := sss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				f(spans.At(k))
+			}
+		}
+	}
+}
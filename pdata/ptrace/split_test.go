@@ -0,0 +1,190 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ptrace
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func generateSplitTraces() Traces {
+	td := NewTraces()
+	for r := 0; r < 2; r++ {
+		rs := td.ResourceSpans().AppendEmpty()
+		rs.SetSchemaUrl("https://opentelemetry.io/schemas/1.24.0")
+		rs.Resource().Attributes().PutInt("resource", int64(r))
+		for s := 0; s < 2; s++ {
+			ils := rs.ScopeSpans().AppendEmpty()
+			ils.Scope().SetName("scope")
+			ils.Scope().SetVersion(string(rune('a' + s)))
+			for i := 0; i < 3; i++ {
+				span := ils.Spans().AppendEmpty()
+				span.SetName("span")
+				span.SetSpanID(pcommon.SpanID([8]byte{byte(r), byte(s), byte(i)}))
+				span.SetTraceID(pcommon.TraceID([16]byte{1}))
+				link := span.Links().AppendEmpty()
+				link.SetTraceID(pcommon.TraceID([16]byte{1}))
+				link.SetSpanID(pcommon.SpanID([8]byte{0, 0, 0}))
+				link.Attributes().PutStr("link.kind", "parent-batch")
+			}
+		}
+	}
+	return td
+}
+
+func TestSplitBySpanCount(t *testing.T) {
+	td := generateSplitTraces()
+	orig := NewTraces()
+	td.CopyTo(orig)
+	require.Equal(t, 12, td.SpanCount())
+
+	batches := td.SplitBySpanCount(5, "split.batch")
+	require.Len(t, batches, 3)
+	assert.Equal(t, 5, batches[0].SpanCount())
+	assert.Equal(t, 5, batches[1].SpanCount())
+	assert.Equal(t, 2, batches[2].SpanCount())
+
+	// The original Traces is left untouched.
+	assert.Equal(t, orig, td)
+
+	// The spans keep their order, resource, scope and links, and carry the marker of their batch.
+	var idx int
+	for b, batch := range batches {
+		rss := batch.ResourceSpans()
+		for i := 0; i < rss.Len(); i++ {
+			rs := rss.At(i)
+			assert.Equal(t, "https://opentelemetry.io/schemas/1.24.0", rs.SchemaUrl())
+			ilss := rs.ScopeSpans()
+			for j := 0; j < ilss.Len(); j++ {
+				ils := ilss.At(j)
+				spans := ils.Spans()
+				for k := 0; k < spans.Len(); k++ {
+					span := spans.At(k)
+					r, s, n := idx/6, idx%6/3, idx%3
+					assert.Equal(t, pcommon.SpanID([8]byte{byte(r), byte(s), byte(n)}), span.SpanID())
+					resource, _ := rs.Resource().Attributes().Get("resource")
+					assert.Equal(t, int64(r), resource.Int())
+					assert.Equal(t, string(rune('a'+s)), ils.Scope().Version())
+
+					require.Equal(t, 1, span.Links().Len())
+					assert.Equal(t, pcommon.SpanID([8]byte{0, 0, 0}), span.Links().At(0).SpanID())
+					assert.Equal(t, map[string]any{"link.kind": "parent-batch"}, span.Links().At(0).Attributes().AsRaw())
+
+					marker, ok := span.Attributes().Get("split.batch")
+					require.True(t, ok)
+					assert.Equal(t, int64(b), marker.Int())
+					idx++
+				}
+			}
+		}
+	}
+	assert.Equal(t, 12, idx)
+
+	// The second batch continues the last scope of the first resource, then starts the second resource.
+	require.Equal(t, 1, batches[0].ResourceSpans().Len())
+	assert.Equal(t, 2, batches[0].ResourceSpans().At(0).ScopeSpans().Len())
+	require.Equal(t, 2, batches[1].ResourceSpans().Len())
+	assert.Equal(t, 1, batches[1].ResourceSpans().At(0).ScopeSpans().Len())
+	assert.Equal(t, 2, batches[1].ResourceSpans().At(1).ScopeSpans().Len())
+}
+
+func TestSplitBySpanCountNoMarker(t *testing.T) {
+	td := generateSplitTraces()
+	batches := td.SplitBySpanCount(4, "")
+	require.Len(t, batches, 3)
+	for _, batch := range batches {
+		assert.Equal(t, 4, batch.SpanCount())
+		span := batch.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+		assert.Equal(t, 0, span.Attributes().Len())
+	}
+}
+
+func TestSplitBySpanCountNotSplit(t *testing.T) {
+	td := generateSplitTraces()
+	batches := td.SplitBySpanCount(12, "split.batch")
+	require.Len(t, batches, 1)
+	assert.Equal(t, td, batches[0])
+	_, ok := td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes().Get("split.batch")
+	assert.False(t, ok)
+
+	batches = td.SplitBySpanCount(0, "split.batch")
+	require.Len(t, batches, 1)
+	assert.Equal(t, td, batches[0])
+}
+
+func TestSplitByTraceID(t *testing.T) {
+	td := NewTraces()
+	// Trace 1 has 2 spans, and traces 2 and 3 have 2 and 3 spans over 2 resources.
+	traceIDs := [][]byte{{1, 2, 1}, {3, 2}, {3, 3}}
+	for r, ids := range traceIDs {
+		rs := td.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().PutInt("resource", int64(r))
+		ss := rs.ScopeSpans().AppendEmpty()
+		ss.Scope().SetName("scope")
+		for i, id := range ids {
+			span := ss.Spans().AppendEmpty()
+			span.SetTraceID(pcommon.TraceID([16]byte{id}))
+			span.SetSpanID(pcommon.SpanID([8]byte{byte(r), byte(i)}))
+		}
+	}
+
+	batches := td.SplitByTraceID(5)
+	require.Len(t, batches, 2)
+	traceBatch := make(map[pcommon.TraceID]int)
+	for b, batch := range batches {
+		assert.LessOrEqual(t, batch.SpanCount(), 5)
+		batch.forEachSpan(func(span Span) {
+			prev, ok := traceBatch[span.TraceID()]
+			assert.True(t, !ok || prev == b, "trace %v split across batches", span.TraceID())
+			traceBatch[span.TraceID()] = b
+		})
+	}
+	assert.Equal(t, map[pcommon.TraceID]int{
+		pcommon.TraceID([16]byte{1}): 0,
+		pcommon.TraceID([16]byte{2}): 0,
+		pcommon.TraceID([16]byte{3}): 1,
+	}, traceBatch)
+
+	// The first batch keeps the resources and the order of the spans.
+	first := batches[0]
+	require.Equal(t, 2, first.ResourceSpans().Len())
+	assert.Equal(t, map[string]any{"resource": int64(0)}, first.ResourceSpans().At(0).Resource().Attributes().AsRaw())
+	assert.Equal(t, "scope", first.ResourceSpans().At(0).ScopeSpans().At(0).Scope().Name())
+	assert.Equal(t, 3, first.ResourceSpans().At(0).ScopeSpans().At(0).Spans().Len())
+	assert.Equal(t, 1, first.ResourceSpans().At(1).ScopeSpans().At(0).Spans().Len())
+	second := batches[1]
+	require.Equal(t, 2, second.ResourceSpans().Len())
+	assert.Equal(t, map[string]any{"resource": int64(1)}, second.ResourceSpans().At(0).Resource().Attributes().AsRaw())
+	assert.Equal(t, pcommon.SpanID([8]byte{1, 0}), second.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).SpanID())
+	assert.Equal(t, 2, second.ResourceSpans().At(1).ScopeSpans().At(0).Spans().Len())
+
+	// The Traces is not modified.
+	assert.Equal(t, 7, td.SpanCount())
+}
+
+func TestSplitByTraceIDLargeTrace(t *testing.T) {
+	td := NewTraces()
+	spans := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	for _, id := range []byte{1, 2, 2, 2, 3} {
+		spans.AppendEmpty().SetTraceID(pcommon.TraceID([16]byte{id}))
+	}
+
+	batches := td.SplitByTraceID(2)
+	require.Len(t, batches, 3)
+	// The trace larger than the limit is alone in its batch.
+	assert.Equal(t, []int{1, 3, 1}, []int{batches[0].SpanCount(), batches[1].SpanCount(), batches[2].SpanCount()})
+}
+
+func TestSplitByTraceIDNoSplit(t *testing.T) {
+	td := generateSplitTraces()
+	for _, maxSpans := range []int{0, td.SpanCount()} {
+		batches := td.SplitByTraceID(maxSpans)
+		require.Len(t, batches, 1)
+		assert.Equal(t, td, batches[0])
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ptrace // import "go.opentelemetry.io/collector/pdata/ptrace"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// Stats holds aggregate statistics about a Traces batch.
+type Stats struct {
+	// SpanCount is the number of spans.
+	SpanCount int
+	// ResourceCount is the number of distinct resources, identified by their Fingerprint.
+	ResourceCount int
+	// MinTimestamp is the earliest start timestamp and MaxTimestamp the latest end timestamp
+	// of the spans, ignoring the unset ones. They are zero if no span has such a timestamp.
+	MinTimestamp pcommon.Timestamp
+	MaxTimestamp pcommon.Timestamp
+	// EstimatedBytes is the size of the batch encoded as an OTLP protobuf message.
+	EstimatedBytes int
+}
+
+// Stats returns the aggregate statistics of the Traces.
+func (ms Traces) Stats() Stats {
+	var stats Stats
+	resources := make(map[pcommon.Fingerprint]struct{})
+	rss := ms.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		resources[rs.Resource().Fingerprint()] = struct{}{}
+		ilss := rs.ScopeSpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			stats.SpanCount += spans.Len()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				if start := span.StartTimestamp(); start != 0 && (stats.MinTimestamp == 0 || start < stats.MinTimestamp) {
+					stats.MinTimestamp = start
+				}
+				if end := span.EndTimestamp(); end > stats.MaxTimestamp {
+					stats.MaxTimestamp = end
+				}
+			}
+		}
+	}
+	stats.ResourceCount = len(resources)
+	stats.EstimatedBytes = ms.getOrig().Size()
+	return stats
+}
@@ -0,0 +1,40 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ptrace
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestTracesStats(t *testing.T) {
+	td := NewTraces()
+	for i := 0; i < 3; i++ {
+		rs := td.ResourceSpans().AppendEmpty()
+		// The first and last resources are identical.
+		rs.Resource().Attributes().PutInt("host", int64(i%2))
+		spans := rs.ScopeSpans().AppendEmpty().Spans()
+		for j := 0; j < i+1; j++ {
+			span := spans.AppendEmpty()
+			span.SetStartTimestamp(pcommon.Timestamp(100 + 10*i + j))
+			span.SetEndTimestamp(pcommon.Timestamp(200 + 10*i + j))
+		}
+	}
+	td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().AppendEmpty().SetName("no timestamp")
+
+	assert.Equal(t, Stats{
+		SpanCount:      7,
+		ResourceCount:  2,
+		MinTimestamp:   100,
+		MaxTimestamp:   222,
+		EstimatedBytes: (&ProtoMarshaler{}).TracesSize(td),
+	}, td.Stats())
+}
+
+func TestTracesStatsEmpty(t *testing.T) {
+	assert.Equal(t, Stats{}, NewTraces().Stats())
+}
@@ -54,6 +54,21 @@ func (ms Traces) SpanCount() int {
 	return spanCount
 }
 
+// SpanCountByScope calculates the number of spans per instrumentation scope name.
+// The spans of the scopes with the same name, across resources or versions, are counted together.
+func (ms Traces) SpanCountByScope() map[string]int {
+	counts := make(map[string]int)
+	rss := ms.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		sss := rss.At(i).ScopeSpans()
+		for j := 0; j < sss.Len(); j++ {
+			ss := sss.At(j)
+			counts[ss.Scope().Name()] += ss.Spans().Len()
+		}
+	}
+	return counts
+}
+
 // ResourceSpans returns the ResourceSpansSlice associated with this Metrics.
 func (ms Traces) ResourceSpans() ResourceSpansSlice {
 	return newResourceSpansSlice(&ms.getOrig().ResourceSpans, internal.GetTracesState(internal.Traces(ms)))
@@ -41,6 +41,28 @@ func TestSpanCount(t *testing.T) {
 	assert.EqualValues(t, 6, traces.SpanCount())
 }
 
+func TestSpanCountByScope(t *testing.T) {
+	traces := NewTraces()
+	assert.Empty(t, traces.SpanCountByScope())
+
+	sss := traces.ResourceSpans().AppendEmpty().ScopeSpans()
+	ss := sss.AppendEmpty()
+	ss.Scope().SetName("http")
+	ss.Spans().AppendEmpty()
+	ss.Spans().AppendEmpty()
+	ss = sss.AppendEmpty()
+	ss.Scope().SetName("grpc")
+	ss.Spans().AppendEmpty()
+	sss.AppendEmpty().Scope().SetName("db")
+
+	// The scopes with the same name are counted together, also across resources.
+	ss = traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty()
+	ss.Scope().SetName("http")
+	ss.Spans().AppendEmpty()
+
+	assert.Equal(t, map[string]int{"http": 3, "grpc": 1, "db": 0}, traces.SpanCountByScope())
+}
+
 func TestSpanCountWithEmpty(t *testing.T) {
 	assert.EqualValues(t, 0, newTraces(&otlpcollectortrace.ExportTraceServiceRequest{
 		ResourceSpans: []*otlptrace.ResourceSpans{{}},
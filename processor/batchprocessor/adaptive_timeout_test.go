@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package batchprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/testdata"
+	"go.opentelemetry.io/collector/processor/processortest"
+)
+
+func TestFlushTimeout(t *testing.T) {
+	bp := &batchProcessor{timeout: time.Second, minTimeout: 100 * time.Millisecond, sendBatchSize: 100}
+	assert.Equal(t, time.Second, bp.flushTimeout(0))
+	assert.Equal(t, 550*time.Millisecond, bp.flushTimeout(50))
+	assert.Equal(t, 100*time.Millisecond, bp.flushTimeout(100))
+	assert.Equal(t, 100*time.Millisecond, bp.flushTimeout(150))
+}
+
+// flushLatency returns the time the batch processor takes to send a batch of spanCount spans.
+func flushLatency(t *testing.T, sharedTimer bool, spanCount int) time.Duration {
+	sink := new(consumertest.TracesSink)
+	cfg := createDefaultConfig().(*Config)
+	cfg.SendBatchSize = 100
+	cfg.Timeout = 500 * time.Millisecond
+	cfg.MinTimeout = 20 * time.Millisecond
+	cfg.SharedTimer = sharedTimer
+	batcher, err := newBatchTracesProcessor(processortest.NewNopSettings(), sink, cfg)
+	require.NoError(t, err)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		require.NoError(t, batcher.Shutdown(context.Background()))
+	})
+
+	start := time.Now()
+	require.NoError(t, batcher.ConsumeTraces(context.Background(), testdata.GenerateTraces(spanCount)))
+	require.Eventually(t, func() bool { return sink.SpanCount() == spanCount }, 2*time.Second, time.Millisecond)
+	return time.Since(start)
+}
+
+func TestBatchProcessorAdaptiveTimeout(t *testing.T) {
+	for _, sharedTimer := range []bool{false, true} {
+		nearlyEmpty := flushLatency(t, sharedTimer, 10)
+		halfFull := flushLatency(t, sharedTimer, 50)
+		nearlyFull := flushLatency(t, sharedTimer, 90)
+
+		// The expected timeouts are 452ms, 260ms and 68ms.
+		assert.Less(t, halfFull, nearlyEmpty)
+		assert.Less(t, nearlyFull, halfFull)
+		assert.GreaterOrEqual(t, nearlyFull, 68*time.Millisecond)
+		assert.Less(t, nearlyFull, 260*time.Millisecond)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"go.opentelemetry.io/otel/attribute"
@@ -40,6 +41,7 @@ var errTooManyBatchers = consumererror.NewPermanent(errors.New("too many batcher
 type batchProcessor struct {
 	logger           *zap.Logger
 	timeout          time.Duration
+	minTimeout       time.Duration
 	sendBatchSize    int
 	sendBatchMaxSize int
 
@@ -56,11 +58,44 @@ type batchProcessor struct {
 	// metadataLimit is the limiting size of the batchers map.
 	metadataLimit int
 
+	// maxBatchAge is the configured MaxBatchAge, the batches pending
+	// for longer are dropped when sent unless sendExpiredBatches is set.
+	maxBatchAge        time.Duration
+	sendExpiredBatches bool
+
+	// now returns the current time, it can be replaced in tests.
+	now func() time.Time
+
+	// flushMarker is the configured marker attribute, which sends
+	// the batch immediately when its Key is not empty.
+	flushMarker FlushMarkerConfig
+
 	shutdownC  chan struct{}
 	goroutines sync.WaitGroup
 
+	// useSharedTimer is the configured SharedTimer mode. When true,
+	// shards do not run their own goroutine and items are added to
+	// batches by the caller.
+	useSharedTimer bool
+
+	// sharedTimer flushes the shards by deadline when useSharedTimer
+	// is set and batches are subject to a timeout, nil otherwise.
+	sharedTimer *sharedTimer
+
+	// preserveOrder is the configured PreserveOrder mode. When true,
+	// items are submitted one at a time under orderLock, and the
+	// pending batch of lastShard is sent before an item is submitted
+	// to another shard.
+	preserveOrder bool
+	orderLock     sync.Mutex
+	lastShard     *shard
+
 	telemetry *batchProcessorTelemetry
 
+	// store persists the batches pending on shutdown and restores them
+	// on start, nil if no storage is configured.
+	store *batchStore
+
 	//  batcher will be either *singletonBatcher or *multiBatcher
 	batcher batcher
 }
@@ -82,6 +117,9 @@ type shard struct {
 	// corresponding with this shard set.
 	exportCtx context.Context
 
+	// metadata holds the metadata key-values of exportCtx.
+	metadata map[string][]string
+
 	// timer informs the shard send a batch.
 	timer *time.Timer
 
@@ -91,11 +129,35 @@ type shard struct {
 	// batch is an in-flight data item containing one of the
 	// underlying data types.
 	batch batch
+
+	// mu guards batch when the shard is driven by the shared timer,
+	// since items are then added by the callers.
+	mu sync.Mutex
+
+	// deadline is the time, in Unix nanoseconds, after which the shared
+	// timer sends the batch of this shard. It is written with mu held,
+	// and read by the shared timer without it.
+	deadline atomic.Int64
+
+	// flushing is set while the shared timer sends the batch of this
+	// shard, so a slow export is not sent again by the next tick.
+	flushing atomic.Bool
+
+	// timerStart is the time the timer was last reset, from which the
+	// adaptive timeout is measured when minTimeout is set.
+	timerStart time.Time
+
+	// oldestAdded is the time the oldest item of the batch was added,
+	// from which its age is measured when maxBatchAge is set.
+	oldestAdded time.Time
 }
 
 // batch is an interface generalizing the individual signal types.
 type batch interface {
-	// export the current batch
+	// export the current batch, returning the number of items and bytes
+	// delivered to the next consumer, which excludes the failed requests.
+	// The error holds the undelivered data as a consumererror.Traces,
+	// Metrics or Logs.
 	export(ctx context.Context, sendBatchMaxSize int, returnBytes bool) (sentBatchSize int, sentBatchBytes int, err error)
 
 	// itemCount returns the size of the current batch
@@ -103,6 +165,12 @@ type batch interface {
 
 	// add item to the current batch
 	add(item any)
+
+	// marshal returns the current batch serialized in the OTLP protobuf format.
+	marshal() ([]byte, error)
+
+	// restore adds to the current batch the data serialized by marshal.
+	restore(buf []byte) error
 }
 
 var _ consumer.Traces = (*batchProcessor)(nil)
@@ -110,7 +178,7 @@ var _ consumer.Metrics = (*batchProcessor)(nil)
 var _ consumer.Logs = (*batchProcessor)(nil)
 
 // newBatchProcessor returns a new batch processor component.
-func newBatchProcessor(set processor.Settings, cfg *Config, batchFunc func() batch) (*batchProcessor, error) {
+func newBatchProcessor(set processor.Settings, cfg *Config, signal component.DataType, batchFunc func() batch) (*batchProcessor, error) {
 	// use lower-case, to be consistent with http/2 headers.
 	mks := make([]string, len(cfg.MetadataKeys))
 	for i, k := range cfg.MetadataKeys {
@@ -123,10 +191,25 @@ func newBatchProcessor(set processor.Settings, cfg *Config, batchFunc func() bat
 		sendBatchSize:    int(cfg.SendBatchSize),
 		sendBatchMaxSize: int(cfg.SendBatchMaxSize),
 		timeout:          cfg.Timeout,
+		minTimeout:       cfg.MinTimeout,
 		batchFunc:        batchFunc,
 		shutdownC:        make(chan struct{}, 1),
 		metadataKeys:     mks,
 		metadataLimit:    int(cfg.MetadataCardinalityLimit),
+		useSharedTimer:   cfg.SharedTimer || cfg.PreserveOrder,
+		flushMarker:      cfg.FlushMarker,
+		preserveOrder:    cfg.PreserveOrder,
+
+		maxBatchAge:        cfg.MaxBatchAge,
+		sendExpiredBatches: cfg.SendExpiredBatches,
+		now:                time.Now,
+	}
+	if cfg.StorageID != nil {
+		bp.store = newBatchStore(*cfg.StorageID, set.ID, signal)
+	}
+	if bp.useSharedTimer && bp.timeout != 0 && bp.sendBatchSize != 0 {
+		bp.sharedTimer = newSharedTimer(bp)
+		bp.sharedTimer.start()
 	}
 	if len(bp.metadataKeys) == 0 {
 		s := bp.newShard(nil)
@@ -154,10 +237,13 @@ func (bp *batchProcessor) newShard(md map[string][]string) *shard {
 	})
 	b := &shard{
 		processor: bp,
-		newItem:   make(chan any, runtime.NumCPU()),
 		exportCtx: exportCtx,
+		metadata:  md,
 		batch:     bp.batchFunc(),
 	}
+	if !bp.useSharedTimer {
+		b.newItem = make(chan any, runtime.NumCPU())
+	}
 	return b
 }
 
@@ -165,25 +251,77 @@ func (bp *batchProcessor) Capabilities() consumer.Capabilities {
 	return consumer.Capabilities{MutatesData: true}
 }
 
-// Start is invoked during service startup.
-func (bp *batchProcessor) Start(context.Context, component.Host) error {
+// Start is invoked during service startup. The batches pending on the last
+// shutdown, if persisted, are sent in the background.
+func (bp *batchProcessor) Start(ctx context.Context, host component.Host) error {
+	if bp.store == nil {
+		return nil
+	}
+	restored, err := bp.store.start(ctx, host)
+	if err != nil {
+		return err
+	}
+	if len(restored) > 0 {
+		bp.goroutines.Add(1)
+		go bp.sendRestored(restored)
+	}
 	return nil
 }
 
 // Shutdown is invoked during service shutdown.
-func (bp *batchProcessor) Shutdown(context.Context) error {
+func (bp *batchProcessor) Shutdown(ctx context.Context) error {
 	close(bp.shutdownC)
 
 	// Wait until all goroutines are done.
 	bp.goroutines.Wait()
+	if bp.store != nil {
+		return bp.store.shutdown(ctx)
+	}
 	return nil
 }
 
 func (b *shard) start() {
+	if b.processor.useSharedTimer {
+		if b.processor.sharedTimer != nil {
+			b.processor.sharedTimer.add(b)
+		}
+		return
+	}
 	b.processor.goroutines.Add(1)
 	go b.startLoop()
 }
 
+// submit hands an item to the shard, either through its goroutine or,
+// under the shared timer mode, by adding it to the batch directly.
+func (b *shard) submit(item any) {
+	if !b.processor.useSharedTimer {
+		b.newItem <- item
+		return
+	}
+	if b.processor.preserveOrder {
+		b.processor.orderLock.Lock()
+		defer b.processor.orderLock.Unlock()
+		if last := b.processor.lastShard; last != nil && last != b {
+			last.sendPending()
+		}
+		b.processor.lastShard = b
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.processItem(item)
+}
+
+// sendPending sends the pending batch of the shard, so the items
+// submitted afterward to other shards are sent after them.
+func (b *shard) sendPending() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.batch.itemCount() > 0 {
+		b.sendItems(triggerPreserveOrder)
+		b.resetTimer()
+	}
+}
+
 func (b *shard) startLoop() {
 	defer b.processor.goroutines.Done()
 
@@ -192,6 +330,7 @@ func (b *shard) startLoop() {
 	var timerCh <-chan time.Time
 	if b.processor.timeout != 0 && b.processor.sendBatchSize != 0 {
 		b.timer = time.NewTimer(b.processor.timeout)
+		b.timerStart = time.Now()
 		timerCh = b.timer.C
 	}
 	for {
@@ -207,11 +346,9 @@ func (b *shard) startLoop() {
 				}
 			}
 			// This is the close of the channel
-			if b.batch.itemCount() > 0 {
-				// TODO: Set a timeout on sendTraces or
-				// make it cancellable using the context that Shutdown gets as a parameter
-				b.sendItems(triggerTimeout)
-			}
+			// TODO: Set a timeout on sendTraces or
+			// make it cancellable using the context that Shutdown gets as a parameter
+			b.sendOnShutdown()
 			return
 		case item := <-b.newItem:
 			if item == nil {
@@ -228,44 +365,103 @@ func (b *shard) startLoop() {
 }
 
 func (b *shard) processItem(item any) {
+	// The item is moved into the batch by add, check it beforehand.
+	marked := b.processor.hasFlushMarker(item)
+	if b.processor.maxBatchAge > 0 && b.batch.itemCount() == 0 {
+		b.oldestAdded = b.processor.now()
+	}
 	b.batch.add(item)
 	sent := false
 	for b.batch.itemCount() > 0 && (!b.hasTimer() || b.batch.itemCount() >= b.processor.sendBatchSize) {
 		sent = true
 		b.sendItems(triggerBatchSize)
 	}
+	for marked && b.batch.itemCount() > 0 {
+		sent = true
+		b.sendItems(triggerFlushMarker)
+	}
 
 	if sent {
 		b.stopTimer()
 		b.resetTimer()
+		return
+	}
+	if b.processor.minTimeout > 0 && b.hasTimer() {
+		b.adaptTimer()
+	}
+}
+
+// flushTimeout returns the adaptive timeout of a batch of count items,
+// decreasing linearly from timeout when empty to minTimeout when it
+// has sendBatchSize items.
+func (bp *batchProcessor) flushTimeout(count int) time.Duration {
+	fill := float64(count) / float64(bp.sendBatchSize)
+	if fill > 1 {
+		fill = 1
+	}
+	return bp.timeout - time.Duration(float64(bp.timeout-bp.minTimeout)*fill)
+}
+
+// adaptTimer brings the timer forward to the adaptive timeout of the
+// current batch size. The timeout only shortens as the batch fills.
+func (b *shard) adaptTimer() {
+	deadline := b.timerStart.Add(b.processor.flushTimeout(b.batch.itemCount()))
+	switch {
+	case b.timer != nil:
+		b.stopTimer()
+		b.timer.Reset(time.Until(deadline))
+	case b.processor.sharedTimer != nil:
+		if deadline.UnixNano() < b.deadline.Load() {
+			b.deadline.Store(deadline.UnixNano())
+		}
 	}
 }
 
 func (b *shard) hasTimer() bool {
-	return b.timer != nil
+	return b.timer != nil || b.processor.sharedTimer != nil
 }
 
 func (b *shard) stopTimer() {
-	if b.hasTimer() && !b.timer.Stop() {
+	if b.timer != nil && !b.timer.Stop() {
 		<-b.timer.C
 	}
 }
 
 func (b *shard) resetTimer() {
-	if b.hasTimer() {
+	b.timerStart = time.Now()
+	switch {
+	case b.timer != nil:
 		b.timer.Reset(b.processor.timeout)
+	case b.processor.sharedTimer != nil:
+		b.deadline.Store(time.Now().Add(b.processor.timeout).UnixNano())
 	}
 }
 
 func (b *shard) sendItems(trigger trigger) {
+	if b.expired() {
+		b.processor.telemetry.recordExpired()
+		if !b.processor.sendExpiredBatches {
+			b.processor.logger.Warn("Batch exceeded the max batch age. Dropping data.",
+				zap.Duration("max_batch_age", b.processor.maxBatchAge), zap.Int("dropped_items", b.batch.itemCount()))
+			b.batch = b.processor.batchFunc()
+			return
+		}
+	}
 	sent, bytes, err := b.batch.export(b.exportCtx, b.processor.sendBatchMaxSize, b.processor.telemetry.detailed)
+	if sent > 0 {
+		b.processor.telemetry.record(trigger, int64(sent), int64(bytes))
+	}
 	if err != nil {
 		b.processor.logger.Warn("Sender failed", zap.Error(err))
-	} else {
-		b.processor.telemetry.record(trigger, int64(sent), int64(bytes))
 	}
 }
 
+// expired returns whether the oldest item of the batch was added more
+// than maxBatchAge ago.
+func (b *shard) expired() bool {
+	return b.processor.maxBatchAge > 0 && b.processor.now().Sub(b.oldestAdded) > b.processor.maxBatchAge
+}
+
 // singleShardBatcher is used when metadataKeys is empty, to avoid the
 // additional lock and map operations used in multiBatcher.
 type singleShardBatcher struct {
@@ -273,7 +469,7 @@ type singleShardBatcher struct {
 }
 
 func (sb *singleShardBatcher) consume(_ context.Context, data any) error {
-	sb.batcher.newItem <- data
+	sb.batcher.submit(data)
 	return nil
 }
 
@@ -331,7 +527,7 @@ func (mb *multiShardBatcher) consume(ctx context.Context, data any) error {
 		}
 		mb.lock.Unlock()
 	}
-	b.(*shard).newItem <- data
+	b.(*shard).submit(data)
 	return nil
 }
 
@@ -358,17 +554,29 @@ func (bp *batchProcessor) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
 
 // newBatchTracesProcessor creates a new batch processor that batches traces by size or with timeout
 func newBatchTracesProcessor(set processor.Settings, next consumer.Traces, cfg *Config) (*batchProcessor, error) {
-	return newBatchProcessor(set, cfg, func() batch { return newBatchTraces(next) })
+	return newBatchProcessor(set, cfg, component.DataTypeTraces, func() batch {
+		bt := newBatchTraces(next)
+		bt.singleScope = cfg.SingleScopePerBatch
+		return bt
+	})
 }
 
 // newBatchMetricsProcessor creates a new batch processor that batches metrics by size or with timeout
 func newBatchMetricsProcessor(set processor.Settings, next consumer.Metrics, cfg *Config) (*batchProcessor, error) {
-	return newBatchProcessor(set, cfg, func() batch { return newBatchMetrics(next) })
+	return newBatchProcessor(set, cfg, component.DataTypeMetrics, func() batch {
+		bm := newBatchMetrics(next)
+		bm.singleScope = cfg.SingleScopePerBatch
+		return bm
+	})
 }
 
 // newBatchLogsProcessor creates a new batch processor that batches logs by size or with timeout
 func newBatchLogsProcessor(set processor.Settings, next consumer.Logs, cfg *Config) (*batchProcessor, error) {
-	return newBatchProcessor(set, cfg, func() batch { return newBatchLogs(next) })
+	return newBatchProcessor(set, cfg, component.DataTypeLogs, func() batch {
+		bl := newBatchLogs(next)
+		bl.singleScope = cfg.SingleScopePerBatch
+		return bl
+	})
 }
 
 type batchTraces struct {
@@ -376,6 +584,8 @@ type batchTraces struct {
 	traceData    ptrace.Traces
 	spanCount    int
 	sizer        ptrace.Sizer
+	// singleScope sends each exported batch in one request per instrumentation scope.
+	singleScope bool
 }
 
 func newBatchTraces(nextConsumer consumer.Traces) *batchTraces {
@@ -408,21 +618,51 @@ func (bt *batchTraces) export(ctx context.Context, sendBatchMaxSize int, returnB
 		bt.traceData = ptrace.NewTraces()
 		bt.spanCount = 0
 	}
+	if bt.singleScope {
+		var sizer ptrace.Sizer
+		if returnBytes {
+			sizer = bt.sizer
+		}
+		return consumeTracesByScope(ctx, bt.nextConsumer, req, sizer)
+	}
 	if returnBytes {
 		bytes = bt.sizer.TracesSize(req)
 	}
-	return sent, bytes, bt.nextConsumer.ConsumeTraces(ctx, req)
+	if err := bt.nextConsumer.ConsumeTraces(ctx, req); err != nil {
+		// The next consumer may have reported the part of req it did not deliver.
+		var tracesErr consumererror.Traces
+		if !errors.As(err, &tracesErr) {
+			err = consumererror.NewTraces(err, req)
+		}
+		return 0, 0, err
+	}
+	return sent, bytes, nil
 }
 
 func (bt *batchTraces) itemCount() int {
 	return bt.spanCount
 }
 
+func (bt *batchTraces) marshal() ([]byte, error) {
+	return (&ptrace.ProtoMarshaler{}).MarshalTraces(bt.traceData)
+}
+
+func (bt *batchTraces) restore(buf []byte) error {
+	td, err := (&ptrace.ProtoUnmarshaler{}).UnmarshalTraces(buf)
+	if err != nil {
+		return err
+	}
+	bt.add(td)
+	return nil
+}
+
 type batchMetrics struct {
 	nextConsumer   consumer.Metrics
 	metricData     pmetric.Metrics
 	dataPointCount int
 	sizer          pmetric.Sizer
+	// singleScope sends each exported batch in one request per instrumentation scope.
+	singleScope bool
 }
 
 func newBatchMetrics(nextConsumer consumer.Metrics) *batchMetrics {
@@ -443,16 +683,43 @@ func (bm *batchMetrics) export(ctx context.Context, sendBatchMaxSize int, return
 		bm.metricData = pmetric.NewMetrics()
 		bm.dataPointCount = 0
 	}
+	if bm.singleScope {
+		var sizer pmetric.Sizer
+		if returnBytes {
+			sizer = bm.sizer
+		}
+		return consumeMetricsByScope(ctx, bm.nextConsumer, req, sizer)
+	}
 	if returnBytes {
 		bytes = bm.sizer.MetricsSize(req)
 	}
-	return sent, bytes, bm.nextConsumer.ConsumeMetrics(ctx, req)
+	if err := bm.nextConsumer.ConsumeMetrics(ctx, req); err != nil {
+		var metricsErr consumererror.Metrics
+		if !errors.As(err, &metricsErr) {
+			err = consumererror.NewMetrics(err, req)
+		}
+		return 0, 0, err
+	}
+	return sent, bytes, nil
 }
 
 func (bm *batchMetrics) itemCount() int {
 	return bm.dataPointCount
 }
 
+func (bm *batchMetrics) marshal() ([]byte, error) {
+	return (&pmetric.ProtoMarshaler{}).MarshalMetrics(bm.metricData)
+}
+
+func (bm *batchMetrics) restore(buf []byte) error {
+	md, err := (&pmetric.ProtoUnmarshaler{}).UnmarshalMetrics(buf)
+	if err != nil {
+		return err
+	}
+	bm.add(md)
+	return nil
+}
+
 func (bm *batchMetrics) add(item any) {
 	md := item.(pmetric.Metrics)
 
@@ -469,6 +736,8 @@ type batchLogs struct {
 	logData      plog.Logs
 	logCount     int
 	sizer        plog.Sizer
+	// singleScope sends each exported batch in one request per instrumentation scope.
+	singleScope bool
 }
 
 func newBatchLogs(nextConsumer consumer.Logs) *batchLogs {
@@ -490,16 +759,43 @@ func (bl *batchLogs) export(ctx context.Context, sendBatchMaxSize int, returnByt
 		bl.logData = plog.NewLogs()
 		bl.logCount = 0
 	}
+	if bl.singleScope {
+		var sizer plog.Sizer
+		if returnBytes {
+			sizer = bl.sizer
+		}
+		return consumeLogsByScope(ctx, bl.nextConsumer, req, sizer)
+	}
 	if returnBytes {
 		bytes = bl.sizer.LogsSize(req)
 	}
-	return sent, bytes, bl.nextConsumer.ConsumeLogs(ctx, req)
+	if err := bl.nextConsumer.ConsumeLogs(ctx, req); err != nil {
+		var logsErr consumererror.Logs
+		if !errors.As(err, &logsErr) {
+			err = consumererror.NewLogs(err, req)
+		}
+		return 0, 0, err
+	}
+	return sent, bytes, nil
 }
 
 func (bl *batchLogs) itemCount() int {
 	return bl.logCount
 }
 
+func (bl *batchLogs) marshal() ([]byte, error) {
+	return (&plog.ProtoMarshaler{}).MarshalLogs(bl.logData)
+}
+
+func (bl *batchLogs) restore(buf []byte) error {
+	ld, err := (&plog.ProtoUnmarshaler{}).UnmarshalLogs(buf)
+	if err != nil {
+		return err
+	}
+	bl.add(ld)
+	return nil
+}
+
 func (bl *batchLogs) add(item any) {
 	ld := item.(plog.Logs)
 
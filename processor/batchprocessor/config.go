@@ -44,6 +44,72 @@ type Config struct {
 	// batcher instances that will be created through a distinct
 	// combination of MetadataKeys.
 	MetadataCardinalityLimit uint32 `mapstructure:"metadata_cardinality_limit"`
+
+	// SharedTimer, when true, drives the Timeout of every batcher
+	// from a single background goroutine instead of starting one
+	// goroutine and timer per distinct combination of MetadataKeys.
+	// Items are then added to batches by the calling goroutine, and
+	// batches are sent within a tolerance of one tenth of Timeout.
+	SharedTimer bool `mapstructure:"shared_timer"`
+
+	// FlushMarker configures an attribute marking the spans, metric
+	// data points or log records after which the current batch is
+	// sent immediately. It is disabled when FlushMarker.Key is empty.
+	FlushMarker FlushMarkerConfig `mapstructure:"flush_marker"`
+
+	// PreserveOrder, when true, guarantees that items are sent in the
+	// order they were received, even across the batchers of distinct
+	// combinations of MetadataKeys: the pending batch of a batcher is
+	// sent before an item is added to another one. Items are then added
+	// to batches by the calling goroutines, one at a time, as with
+	// SharedTimer.
+	PreserveOrder bool `mapstructure:"preserve_order"`
+
+	// MinTimeout, when set, makes the timeout adaptive: it shortens
+	// linearly as the batch fills, from Timeout for an empty batch to
+	// MinTimeout for a batch of SendBatchSize items. The batch is sent
+	// once the timeout for its current size has elapsed since the
+	// previous batch was sent. It must not exceed Timeout, and requires
+	// Timeout and SendBatchSize to be set.
+	MinTimeout time.Duration `mapstructure:"min_timeout"`
+
+	// MaxBatchAge, when set, is the maximum time a batch may be pending,
+	// measured from the addition of its oldest item. A batch exceeding it
+	// when sent, e.g. after a stall of the pipeline, is dropped instead,
+	// unless SendExpiredBatches is set, and counted in both cases.
+	MaxBatchAge time.Duration `mapstructure:"max_batch_age"`
+
+	// SendExpiredBatches, when true, sends the batches exceeding
+	// MaxBatchAge instead of dropping them.
+	SendExpiredBatches bool `mapstructure:"send_expired_batches"`
+
+	// SingleScopePerBatch, when true, sends each batch in one request
+	// per distinct instrumentation scope, identified by its name,
+	// version and attributes, the spans, metrics or log records of the
+	// same scope being grouped in the same request whatever their
+	// resource. The first failed request stops the batch, the following
+	// requests not being sent, so only part of the batch may be
+	// delivered.
+	SingleScopePerBatch bool `mapstructure:"single_scope_per_batch"`
+
+	// StorageID, if not empty, is the storage extension the pending
+	// batches are written to on shutdown instead of being sent. They
+	// are restored and sent in the background on the next start, the
+	// batches failing to be sent being written again. The processor must
+	// not be used with a storage in several pipelines of the same
+	// data type, which would share the storage.
+	StorageID *component.ID `mapstructure:"storage"`
+}
+
+// FlushMarkerConfig defines the attribute marking the items which
+// trigger an immediate send of the current batch.
+type FlushMarkerConfig struct {
+	// Key is the name of the marker attribute.
+	Key string `mapstructure:"key"`
+
+	// Value is the value the marker attribute must have. When empty,
+	// the presence of the attribute is enough to trigger a send.
+	Value string `mapstructure:"value"`
 }
 
 var _ component.Config = (*Config)(nil)
@@ -61,8 +127,25 @@ func (cfg *Config) Validate() error {
 		}
 		uniq[l] = true
 	}
+	if cfg.FlushMarker.Key == "" && cfg.FlushMarker.Value != "" {
+		return errors.New("flush_marker::key must be set when flush_marker::value is set")
+	}
 	if cfg.Timeout < 0 {
 		return errors.New("timeout must be greater or equal to 0")
 	}
+	if cfg.MinTimeout < 0 {
+		return errors.New("min_timeout must be greater or equal to 0")
+	}
+	if cfg.MaxBatchAge < 0 {
+		return errors.New("max_batch_age must be greater or equal to 0")
+	}
+	if cfg.MinTimeout > 0 {
+		if cfg.Timeout == 0 || cfg.SendBatchSize == 0 {
+			return errors.New("min_timeout requires timeout and send_batch_size to be set")
+		}
+		if cfg.MinTimeout > cfg.Timeout {
+			return errors.New("min_timeout must be less or equal to timeout")
+		}
+	}
 	return nil
 }
@@ -69,7 +69,36 @@ func TestValidateConfig_InvalidTimeout(t *testing.T) {
 	assert.Error(t, cfg.Validate())
 }
 
+func TestValidateConfig_MinTimeout(t *testing.T) {
+	cfg := &Config{Timeout: time.Second, SendBatchSize: 100, MinTimeout: 100 * time.Millisecond}
+	assert.NoError(t, cfg.Validate())
+
+	cfg.MinTimeout = 2 * time.Second
+	assert.EqualError(t, cfg.Validate(), "min_timeout must be less or equal to timeout")
+
+	cfg.MinTimeout = -time.Second
+	assert.EqualError(t, cfg.Validate(), "min_timeout must be greater or equal to 0")
+
+	cfg = &Config{Timeout: time.Second, MinTimeout: 100 * time.Millisecond}
+	assert.EqualError(t, cfg.Validate(), "min_timeout requires timeout and send_batch_size to be set")
+}
+
 func TestValidateConfig_ValidZero(t *testing.T) {
 	cfg := &Config{}
 	assert.NoError(t, cfg.Validate())
 }
+
+func TestValidateConfig_FlushMarkerValueWithoutKey(t *testing.T) {
+	cfg := &Config{
+		FlushMarker: FlushMarkerConfig{Value: "now"},
+	}
+	assert.EqualError(t, cfg.Validate(), "flush_marker::key must be set when flush_marker::value is set")
+}
+
+func TestValidateConfig_MaxBatchAge(t *testing.T) {
+	cfg := &Config{MaxBatchAge: time.Minute}
+	assert.NoError(t, cfg.Validate())
+
+	cfg.MaxBatchAge = -time.Second
+	assert.EqualError(t, cfg.Validate(), "max_batch_age must be greater or equal to 0")
+}
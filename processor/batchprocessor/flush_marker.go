@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package batchprocessor // import "go.opentelemetry.io/collector/processor/batchprocessor"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// hasFlushMarker returns whether a span, metric data point or log
+// record of item carries the configured flush marker attribute.
+func (bp *batchProcessor) hasFlushMarker(item any) bool {
+	if bp.flushMarker.Key == "" {
+		return false
+	}
+	switch data := item.(type) {
+	case ptrace.Traces:
+		return bp.tracesHaveFlushMarker(data)
+	case pmetric.Metrics:
+		return bp.metricsHaveFlushMarker(data)
+	case plog.Logs:
+		return bp.logsHaveFlushMarker(data)
+	}
+	return false
+}
+
+func (bp *batchProcessor) isFlushMarked(attrs pcommon.Map) bool {
+	v, ok := attrs.Get(bp.flushMarker.Key)
+	return ok && (bp.flushMarker.Value == "" || v.AsString() == bp.flushMarker.Value)
+}
+
+func (bp *batchProcessor) tracesHaveFlushMarker(td ptrace.Traces) bool {
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		sss := rss.At(i).ScopeSpans()
+		for j := 0; j < sss.Len(); j++ {
+			spans := sss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				if bp.isFlushMarked(spans.At(k).Attributes()) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func (bp *batchProcessor) logsHaveFlushMarker(ld plog.Logs) bool {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		sls := rls.At(i).ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			lrs := sls.At(j).LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				if bp.isFlushMarked(lrs.At(k).Attributes()) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func (bp *batchProcessor) metricsHaveFlushMarker(md pmetric.Metrics) bool {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		sms := rms.At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			metrics := sms.At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				if bp.metricHasFlushMarker(metrics.At(k)) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func (bp *batchProcessor) metricHasFlushMarker(m pmetric.Metric) bool {
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		dps := m.Gauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			if bp.isFlushMarked(dps.At(i).Attributes()) {
+				return true
+			}
+		}
+	case pmetric.MetricTypeSum:
+		dps := m.Sum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			if bp.isFlushMarked(dps.At(i).Attributes()) {
+				return true
+			}
+		}
+	case pmetric.MetricTypeHistogram:
+		dps := m.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			if bp.isFlushMarked(dps.At(i).Attributes()) {
+				return true
+			}
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		dps := m.ExponentialHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			if bp.isFlushMarked(dps.At(i).Attributes()) {
+				return true
+			}
+		}
+	case pmetric.MetricTypeSummary:
+		dps := m.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			if bp.isFlushMarked(dps.At(i).Attributes()) {
+				return true
+			}
+		}
+	}
+	return false
+}
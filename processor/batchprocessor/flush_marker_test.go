@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package batchprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/testdata"
+	"go.opentelemetry.io/collector/processor/processortest"
+)
+
+func TestBatchProcessorFlushMarkerLogs(t *testing.T) {
+	for _, sharedTimer := range []bool{false, true} {
+		sink := new(consumertest.LogsSink)
+		cfg := createDefaultConfig().(*Config)
+		cfg.SendBatchSize = 1000
+		cfg.Timeout = time.Hour
+		cfg.SharedTimer = sharedTimer
+		cfg.FlushMarker = FlushMarkerConfig{Key: "otel.flush", Value: "now"}
+
+		batcher, err := newBatchLogsProcessor(processortest.NewNopSettings(), sink, cfg)
+		require.NoError(t, err)
+		require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+
+		require.NoError(t, batcher.ConsumeLogs(context.Background(), testdata.GenerateLogs(3)))
+
+		// A marker attribute with another value does not flush.
+		ld := testdata.GenerateLogs(2)
+		ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(1).Attributes().PutStr("otel.flush", "later")
+		require.NoError(t, batcher.ConsumeLogs(context.Background(), ld))
+
+		ld = testdata.GenerateLogs(2)
+		ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().PutStr("otel.flush", "now")
+		require.NoError(t, batcher.ConsumeLogs(context.Background(), ld))
+
+		// The batch, including the marked record, is sent immediately.
+		assert.Eventually(t, func() bool {
+			return sink.LogRecordCount() == 7
+		}, time.Second, 10*time.Millisecond)
+		assert.Len(t, sink.AllLogs(), 1)
+
+		require.NoError(t, batcher.ConsumeLogs(context.Background(), testdata.GenerateLogs(4)))
+		require.NoError(t, batcher.Shutdown(context.Background()))
+		assert.Equal(t, 11, sink.LogRecordCount())
+		assert.Len(t, sink.AllLogs(), 2)
+	}
+}
+
+func TestBatchProcessorFlushMarkerMaxSize(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	cfg := createDefaultConfig().(*Config)
+	cfg.SendBatchSize = 100
+	cfg.SendBatchMaxSize = 100
+	cfg.Timeout = time.Hour
+	cfg.SharedTimer = true
+	cfg.FlushMarker = FlushMarkerConfig{Key: "otel.flush"}
+
+	batcher, err := newBatchTracesProcessor(processortest.NewNopSettings(), sink, cfg)
+	require.NoError(t, err)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+
+	td := testdata.GenerateTraces(250)
+	td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(249).Attributes().PutBool("otel.flush", true)
+	require.NoError(t, batcher.ConsumeTraces(context.Background(), td))
+
+	// The whole batch is sent, split by send_batch_max_size.
+	assert.Equal(t, 250, sink.SpanCount())
+	require.Len(t, sink.AllTraces(), 3)
+	assert.Equal(t, 50, sink.AllTraces()[2].SpanCount())
+	require.NoError(t, batcher.Shutdown(context.Background()))
+}
+
+func TestBatchProcessorFlushMarkerMetrics(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	cfg := createDefaultConfig().(*Config)
+	cfg.SendBatchSize = 1000
+	cfg.Timeout = time.Hour
+	cfg.SharedTimer = true
+	cfg.FlushMarker = FlushMarkerConfig{Key: "otel.flush", Value: "now"}
+
+	batcher, err := newBatchMetricsProcessor(processortest.NewNopSettings(), sink, cfg)
+	require.NoError(t, err)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+
+	require.NoError(t, batcher.ConsumeMetrics(context.Background(), testdata.GenerateMetrics(2)))
+	assert.Zero(t, sink.DataPointCount())
+
+	md := pmetric.NewMetrics()
+	dp := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptyHistogram().DataPoints().AppendEmpty()
+	dp.Attributes().PutStr("otel.flush", "now")
+	require.NoError(t, batcher.ConsumeMetrics(context.Background(), md))
+	assert.Equal(t, 5, sink.DataPointCount())
+	require.NoError(t, batcher.Shutdown(context.Background()))
+}
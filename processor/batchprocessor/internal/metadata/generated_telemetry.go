@@ -33,8 +33,11 @@ type TelemetryBuilder struct {
 	ProcessorBatchBatchSendSize              metric.Int64Histogram
 	ProcessorBatchBatchSendSizeBytes         metric.Int64Histogram
 	ProcessorBatchBatchSizeTriggerSend       metric.Int64Counter
+	ProcessorBatchExpiredBatches             metric.Int64Counter
+	ProcessorBatchFlushMarkerTriggerSend     metric.Int64Counter
 	ProcessorBatchMetadataCardinality        metric.Int64ObservableUpDownCounter
 	observeProcessorBatchMetadataCardinality func(context.Context, metric.Observer) error
+	ProcessorBatchPreserveOrderTriggerSend   metric.Int64Counter
 	ProcessorBatchTimeoutTriggerSend         metric.Int64Counter
 	meters                                   map[configtelemetry.Level]metric.Meter
 }
@@ -80,13 +83,33 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...teleme
 		metric.WithUnit("{times}"),
 	)
 	errs = errors.Join(errs, err)
+	builder.ProcessorBatchExpiredBatches, err = builder.meters[configtelemetry.LevelBasic].Int64Counter(
+		"otelcol_processor_batch_expired_batches",
+		metric.WithDescription("Number of batches older than max_batch_age when sent, which are dropped unless send_expired_batches is set"),
+		metric.WithUnit("{batches}"),
+	)
+	errs = errors.Join(errs, err)
+	builder.ProcessorBatchFlushMarkerTriggerSend, err = builder.meters[configtelemetry.LevelBasic].Int64Counter(
+		"otelcol_processor_batch_flush_marker_trigger_send",
+		metric.WithDescription("Number of times the batch was sent due to a flush marker attribute"),
+		metric.WithUnit("{times}"),
+	)
+	errs = errors.Join(errs, err)
 	builder.ProcessorBatchMetadataCardinality, err = builder.meters[configtelemetry.LevelBasic].Int64ObservableUpDownCounter(
 		"otelcol_processor_batch_metadata_cardinality",
 		metric.WithDescription("Number of distinct metadata value combinations being processed"),
 		metric.WithUnit("{combinations}"),
 	)
 	errs = errors.Join(errs, err)
-	_, err = builder.meters[configtelemetry.LevelBasic].RegisterCallback(builder.observeProcessorBatchMetadataCardinality, builder.ProcessorBatchMetadataCardinality)
+	if builder.observeProcessorBatchMetadataCardinality != nil {
+		_, err = builder.meters[configtelemetry.LevelBasic].RegisterCallback(builder.observeProcessorBatchMetadataCardinality, builder.ProcessorBatchMetadataCardinality)
+		errs = errors.Join(errs, err)
+	}
+	builder.ProcessorBatchPreserveOrderTriggerSend, err = builder.meters[configtelemetry.LevelBasic].Int64Counter(
+		"otelcol_processor_batch_preserve_order_trigger_send",
+		metric.WithDescription("Number of times the batch was sent to preserve the order of items received by another batcher"),
+		metric.WithUnit("{times}"),
+	)
 	errs = errors.Join(errs, err)
 	builder.ProcessorBatchTimeoutTriggerSend, err = builder.meters[configtelemetry.LevelBasic].Int64Counter(
 		"otelcol_processor_batch_timeout_trigger_send",
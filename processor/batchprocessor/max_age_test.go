@@ -0,0 +1,149 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package batchprocessor
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/testdata"
+)
+
+// fakeClock is a clock which only moves when advanced.
+type fakeClock struct {
+	now atomic.Int64
+}
+
+func newFakeClock() *fakeClock {
+	c := &fakeClock{}
+	c.now.Store(time.Now().UnixNano())
+	return c
+}
+
+func (c *fakeClock) Now() time.Time {
+	return time.Unix(0, c.now.Load())
+}
+
+func (c *fakeClock) advance(d time.Duration) {
+	c.now.Add(int64(d))
+}
+
+func assertExpiredBatches(t *testing.T, tel componentTestTelemetry, count int64) {
+	var md metricdata.ResourceMetrics
+	require.NoError(t, tel.reader.Collect(context.Background(), &md))
+	metricdatatest.AssertEqual(t, metricdata.Metrics{
+		Name:        "otelcol_processor_batch_expired_batches",
+		Description: "Number of batches older than max_batch_age when sent, which are dropped unless send_expired_batches is set",
+		Unit:        "{batches}",
+		Data: metricdata.Sum[int64]{
+			Temporality: metricdata.CumulativeTemporality,
+			IsMonotonic: true,
+			DataPoints: []metricdata.DataPoint[int64]{
+				{
+					Value:      count,
+					Attributes: attribute.NewSet(attribute.String("processor", "batch")),
+				},
+			},
+		},
+	}, tel.getMetric("otelcol_processor_batch_expired_batches", md), metricdatatest.IgnoreTimestamp())
+}
+
+// The tests use the shared timer, so items are added to the batches by the caller, before the clock is advanced.
+
+func TestBatchProcessorMaxBatchAge(t *testing.T) {
+	tel := setupTestTelemetry()
+	sink := new(consumertest.TracesSink)
+	cfg := createDefaultConfig().(*Config)
+	cfg.SendBatchSize = 10
+	cfg.Timeout = time.Hour
+	cfg.SharedTimer = true
+	cfg.MaxBatchAge = time.Minute
+
+	batcher, err := newBatchTracesProcessor(tel.NewSettings(), sink, cfg)
+	require.NoError(t, err)
+	clock := newFakeClock()
+	batcher.now = clock.Now
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+
+	// The batch is sent before the max age.
+	require.NoError(t, batcher.ConsumeTraces(context.Background(), testdata.GenerateTraces(4)))
+	clock.advance(30 * time.Second)
+	require.NoError(t, batcher.ConsumeTraces(context.Background(), testdata.GenerateTraces(6)))
+	assert.Equal(t, 10, sink.SpanCount())
+
+	// The age of the next batch is measured from its own oldest item.
+	require.NoError(t, batcher.ConsumeTraces(context.Background(), testdata.GenerateTraces(4)))
+	clock.advance(2 * time.Minute)
+	require.NoError(t, batcher.ConsumeTraces(context.Background(), testdata.GenerateTraces(6)))
+	assert.Equal(t, 10, sink.SpanCount())
+
+	// The expired batch is dropped, the next one is sent on shutdown.
+	require.NoError(t, batcher.ConsumeTraces(context.Background(), testdata.GenerateTraces(3)))
+	require.NoError(t, batcher.Shutdown(context.Background()))
+	assert.Equal(t, 13, sink.SpanCount())
+	assert.Len(t, sink.AllTraces(), 2)
+	assertExpiredBatches(t, tel, 1)
+	require.NoError(t, tel.Shutdown(context.Background()))
+}
+
+func TestBatchProcessorMaxBatchAgeOnShutdown(t *testing.T) {
+	tel := setupTestTelemetry()
+	sink := new(consumertest.MetricsSink)
+	cfg := createDefaultConfig().(*Config)
+	cfg.SendBatchSize = 100
+	cfg.Timeout = time.Hour
+	cfg.SharedTimer = true
+	cfg.MaxBatchAge = time.Minute
+
+	batcher, err := newBatchMetricsProcessor(tel.NewSettings(), sink, cfg)
+	require.NoError(t, err)
+	clock := newFakeClock()
+	batcher.now = clock.Now
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+
+	require.NoError(t, batcher.ConsumeMetrics(context.Background(), testdata.GenerateMetrics(5)))
+	clock.advance(time.Minute + time.Second)
+	require.NoError(t, batcher.Shutdown(context.Background()))
+
+	assert.Zero(t, sink.DataPointCount())
+	assertExpiredBatches(t, tel, 1)
+	require.NoError(t, tel.Shutdown(context.Background()))
+}
+
+func TestBatchProcessorSendExpiredBatches(t *testing.T) {
+	tel := setupTestTelemetry()
+	sink := new(consumertest.LogsSink)
+	cfg := createDefaultConfig().(*Config)
+	cfg.SendBatchSize = 100
+	cfg.Timeout = time.Hour
+	cfg.SharedTimer = true
+	cfg.MaxBatchAge = time.Minute
+	cfg.SendExpiredBatches = true
+
+	batcher, err := newBatchLogsProcessor(tel.NewSettings(), sink, cfg)
+	require.NoError(t, err)
+	clock := newFakeClock()
+	batcher.now = clock.Now
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+
+	require.NoError(t, batcher.ConsumeLogs(context.Background(), testdata.GenerateLogs(5)))
+	clock.advance(time.Hour)
+	require.NoError(t, batcher.ConsumeLogs(context.Background(), testdata.GenerateLogs(5)))
+	require.NoError(t, batcher.Shutdown(context.Background()))
+
+	// The expired batch is still sent, and counted.
+	assert.Equal(t, 10, sink.LogRecordCount())
+	assertExpiredBatches(t, tel, 1)
+	require.NoError(t, tel.Shutdown(context.Background()))
+}
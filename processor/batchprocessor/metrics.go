@@ -21,6 +21,11 @@ const (
 	typeStr                = "batch"
 	triggerTimeout trigger = iota
 	triggerBatchSize
+	triggerFlushMarker
+	triggerPreserveOrder
+	// triggerRestore is the send of a batch restored from the storage on start, which is not counted by
+	// the trigger metrics.
+	triggerRestore
 )
 
 type batchProcessorTelemetry struct {
@@ -53,12 +58,20 @@ func newBatchProcessorTelemetry(set processor.Settings, currentMetadataCardinali
 	}, nil
 }
 
+func (bpt *batchProcessorTelemetry) recordExpired() {
+	bpt.telemetryBuilder.ProcessorBatchExpiredBatches.Add(bpt.exportCtx, 1, metric.WithAttributeSet(bpt.processorAttr))
+}
+
 func (bpt *batchProcessorTelemetry) record(trigger trigger, sent, bytes int64) {
 	switch trigger {
 	case triggerBatchSize:
 		bpt.telemetryBuilder.ProcessorBatchBatchSizeTriggerSend.Add(bpt.exportCtx, 1, metric.WithAttributeSet(bpt.processorAttr))
 	case triggerTimeout:
 		bpt.telemetryBuilder.ProcessorBatchTimeoutTriggerSend.Add(bpt.exportCtx, 1, metric.WithAttributeSet(bpt.processorAttr))
+	case triggerFlushMarker:
+		bpt.telemetryBuilder.ProcessorBatchFlushMarkerTriggerSend.Add(bpt.exportCtx, 1, metric.WithAttributeSet(bpt.processorAttr))
+	case triggerPreserveOrder:
+		bpt.telemetryBuilder.ProcessorBatchPreserveOrderTriggerSend.Add(bpt.exportCtx, 1, metric.WithAttributeSet(bpt.processorAttr))
 	}
 
 	bpt.telemetryBuilder.ProcessorBatchBatchSendSize.Record(bpt.exportCtx, sent, metric.WithAttributeSet(bpt.processorAttr))
@@ -0,0 +1,210 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package batchprocessor // import "go.opentelemetry.io/collector/processor/batchprocessor"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+)
+
+// pendingBatchesKey is the storage key of the batches pending on shutdown.
+const pendingBatchesKey = "pending_batches"
+
+var (
+	errNoStorageClient    = errors.New("no storage client extension found")
+	errWrongExtensionType = errors.New("requested extension is not a storage extension")
+)
+
+// persistedBatch is a batch pending on shutdown, with the metadata of its shard.
+type persistedBatch struct {
+	Metadata map[string][]string `json:"metadata,omitempty"`
+	// Data is the batch serialized in the OTLP protobuf format.
+	Data []byte `json:"data"`
+}
+
+// batchStore persists the batches pending on shutdown in a storage extension.
+type batchStore struct {
+	storageID component.ID
+	ownerID   component.ID
+	signal    component.DataType
+
+	// client is set by start, nil if the processor was not started.
+	client storage.Client
+
+	mu      sync.Mutex
+	pending []persistedBatch
+}
+
+func newBatchStore(storageID component.ID, ownerID component.ID, signal component.DataType) *batchStore {
+	return &batchStore{storageID: storageID, ownerID: ownerID, signal: signal}
+}
+
+// start gets the storage client and returns the batches persisted on the last
+// shutdown, which are kept in the storage until commitRestored is called.
+func (bs *batchStore) start(ctx context.Context, host component.Host) ([]persistedBatch, error) {
+	ext, found := host.GetExtensions()[bs.storageID]
+	if !found {
+		return nil, fmt.Errorf("%w: %v", errNoStorageClient, bs.storageID)
+	}
+	storageExt, ok := ext.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("%w: %v", errWrongExtensionType, bs.storageID)
+	}
+	storageClient, err := storageExt.GetClient(ctx, component.KindProcessor, bs.ownerID, bs.signal.String())
+	if err != nil {
+		return nil, err
+	}
+
+	buf, err := storageClient.Get(ctx, pendingBatchesKey)
+	if err != nil {
+		return nil, errors.Join(err, storageClient.Close(ctx))
+	}
+	var restored []persistedBatch
+	if buf != nil {
+		if err = json.Unmarshal(buf, &restored); err != nil {
+			return nil, errors.Join(err, storageClient.Close(ctx))
+		}
+	}
+	bs.client = storageClient
+	return restored, nil
+}
+
+// add records the batch of a shard to persist on shutdown. It reports
+// false if the batch cannot be persisted, the processor not being started.
+func (bs *batchStore) add(md map[string][]string, buf []byte) bool {
+	if bs.client == nil {
+		return false
+	}
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	bs.pending = append(bs.pending, persistedBatch{Metadata: md, Data: buf})
+	return true
+}
+
+// commitRestored replaces the batches persisted on the last shutdown with the
+// ones recorded by add, once all the restored batches were handled.
+func (bs *batchStore) commitRestored(ctx context.Context) error {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	if len(bs.pending) == 0 {
+		return bs.client.Delete(ctx, pendingBatchesKey)
+	}
+	buf, err := json.Marshal(bs.pending)
+	if err != nil {
+		return err
+	}
+	return bs.client.Set(ctx, pendingBatchesKey, buf)
+}
+
+// shutdown writes the batches recorded by add and closes the storage client.
+func (bs *batchStore) shutdown(ctx context.Context) error {
+	if bs.client == nil {
+		return nil
+	}
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	var err error
+	if len(bs.pending) > 0 {
+		var buf []byte
+		if buf, err = json.Marshal(bs.pending); err == nil {
+			err = bs.client.Set(ctx, pendingBatchesKey, buf)
+		}
+	}
+	return errors.Join(err, bs.client.Close(ctx))
+}
+
+// sendOnShutdown persists the batch of the shard if a storage is configured,
+// and sends it otherwise or if it cannot be persisted.
+func (b *shard) sendOnShutdown() {
+	if b.batch.itemCount() == 0 {
+		return
+	}
+	if store := b.processor.store; store != nil {
+		buf, err := b.batch.marshal()
+		if err == nil && store.add(b.metadata, buf) {
+			b.batch = b.processor.batchFunc()
+			return
+		}
+		if err != nil {
+			b.processor.logger.Warn("Failed to persist the pending batch, sending it", zap.Error(err))
+		}
+	}
+	b.sendItems(triggerTimeout)
+}
+
+// sendRestored sends the batches restored from the storage, with the metadata
+// of their shard. The items rejected with a permanent error are dropped. The
+// batches which cannot be sent otherwise, and those not sent yet on shutdown,
+// are persisted again, the restored batches being kept in the storage
+// until then so they are not lost if the collector stops in the meantime.
+func (bp *batchProcessor) sendRestored(restored []persistedBatch) {
+	defer bp.goroutines.Done()
+	bp.logger.Info("Sending the batches restored from the storage", zap.Int("batches", len(restored)))
+	for _, p := range restored {
+		select {
+		case <-bp.shutdownC:
+			bp.store.add(p.Metadata, p.Data)
+			continue
+		default:
+		}
+		b := bp.batchFunc()
+		if err := b.restore(p.Data); err != nil {
+			bp.logger.Warn("Failed to restore a persisted batch, dropping it", zap.Error(err))
+			continue
+		}
+		exportCtx := client.NewContext(context.Background(), client.Info{
+			Metadata: client.NewMetadata(p.Metadata),
+		})
+		for b.itemCount() > 0 {
+			sent, bytes, err := b.export(exportCtx, bp.sendBatchMaxSize, bp.telemetry.detailed)
+			if sent > 0 {
+				bp.telemetry.record(triggerRestore, int64(sent), int64(bytes))
+			}
+			if consumererror.IsPermanent(err) {
+				bp.logger.Warn("Sender failed with a permanent error, dropping the rejected items", zap.Error(err))
+				continue
+			}
+			if err != nil {
+				bp.logger.Warn("Sender failed, persisting the restored batch again", zap.Error(err))
+				bp.persistAgain(b, p.Metadata, err)
+				break
+			}
+		}
+	}
+	if err := bp.store.commitRestored(context.Background()); err != nil {
+		bp.logger.Warn("Failed to update the batches persisted in the storage", zap.Error(err))
+	}
+}
+
+// persistAgain records the items of the restored batch which were not sent,
+// including the undelivered data of err, to be persisted again.
+func (bp *batchProcessor) persistAgain(b batch, md map[string][]string, err error) {
+	var tracesErr consumererror.Traces
+	var metricsErr consumererror.Metrics
+	var logsErr consumererror.Logs
+	switch {
+	case errors.As(err, &tracesErr):
+		b.add(tracesErr.Data())
+	case errors.As(err, &metricsErr):
+		b.add(metricsErr.Data())
+	case errors.As(err, &logsErr):
+		b.add(logsErr.Data())
+	}
+	buf, err := b.marshal()
+	if err != nil {
+		bp.logger.Warn("Failed to persist the restored batch, dropping it", zap.Error(err))
+		return
+	}
+	bp.store.add(md, buf)
+}
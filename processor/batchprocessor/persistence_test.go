@@ -0,0 +1,384 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package batchprocessor
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/testdata"
+	"go.opentelemetry.io/collector/processor/processortest"
+)
+
+// memStorage is a storage extension keeping the data in memory, shared by all
+// its clients so that it outlives the processors.
+type memStorage struct {
+	component.StartFunc
+	component.ShutdownFunc
+
+	mu   sync.Mutex
+	data map[string][]byte
+}
+
+func newMemStorage() *memStorage {
+	return &memStorage{data: map[string][]byte{}}
+}
+
+func (ms *memStorage) GetClient(_ context.Context, kind component.Kind, id component.ID, name string) (storage.Client, error) {
+	return &memClient{storage: ms, prefix: kind.String() + "/" + id.String() + "/" + name + "/"}, nil
+}
+
+func (ms *memStorage) keys() []string {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	var keys []string
+	for k := range ms.data {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+type memClient struct {
+	storage *memStorage
+	prefix  string
+}
+
+func (mc *memClient) Get(_ context.Context, key string) ([]byte, error) {
+	mc.storage.mu.Lock()
+	defer mc.storage.mu.Unlock()
+	return mc.storage.data[mc.prefix+key], nil
+}
+
+func (mc *memClient) Set(_ context.Context, key string, value []byte) error {
+	mc.storage.mu.Lock()
+	defer mc.storage.mu.Unlock()
+	mc.storage.data[mc.prefix+key] = value
+	return nil
+}
+
+func (mc *memClient) Delete(_ context.Context, key string) error {
+	mc.storage.mu.Lock()
+	defer mc.storage.mu.Unlock()
+	delete(mc.storage.data, mc.prefix+key)
+	return nil
+}
+
+func (mc *memClient) Batch(context.Context, ...storage.Operation) error {
+	return nil
+}
+
+func (mc *memClient) Close(context.Context) error {
+	return nil
+}
+
+type storageHost struct {
+	component.Host
+	extensions map[component.ID]component.Component
+}
+
+func (sh *storageHost) GetExtensions() map[component.ID]component.Component {
+	return sh.extensions
+}
+
+var testStorageID = component.MustNewID("memstorage")
+
+func newStorageHost(ext component.Component) component.Host {
+	return &storageHost{
+		Host:       componenttest.NewNopHost(),
+		extensions: map[component.ID]component.Component{testStorageID: ext},
+	}
+}
+
+func TestBatchProcessorPersistsPendingBatches(t *testing.T) {
+	for _, sharedTimer := range []bool{false, true} {
+		t.Run(map[bool]string{false: "per_shard_timer", true: "shared_timer"}[sharedTimer], func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.SendBatchSize = 1000
+			cfg.Timeout = 10 * time.Minute
+			cfg.MetadataKeys = []string{"token"}
+			cfg.SharedTimer = sharedTimer
+			cfg.StorageID = &testStorageID
+			set := processortest.NewNopSettings()
+			ext := newMemStorage()
+			host := newStorageHost(ext)
+
+			sink := new(consumertest.TracesSink)
+			first, err := newBatchTracesProcessor(set, sink, cfg)
+			require.NoError(t, err)
+			require.NoError(t, first.Start(context.Background(), host))
+			for _, token := range []string{"a", "b"} {
+				ctx := client.NewContext(context.Background(), client.Info{
+					Metadata: client.NewMetadata(map[string][]string{"token": {token}}),
+				})
+				require.NoError(t, first.ConsumeTraces(ctx, testdata.GenerateTraces(3)))
+			}
+			require.NoError(t, first.Shutdown(context.Background()))
+			assert.Zero(t, sink.SpanCount())
+			assert.Len(t, ext.keys(), 1)
+
+			spansByToken := map[string]int{}
+			var mu sync.Mutex
+			next, err := consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
+				mu.Lock()
+				defer mu.Unlock()
+				spansByToken[client.FromContext(ctx).Metadata.Get("token")[0]] += td.SpanCount()
+				return nil
+			})
+			require.NoError(t, err)
+			second, err := newBatchTracesProcessor(set, next, cfg)
+			require.NoError(t, err)
+			require.NoError(t, second.Start(context.Background(), host))
+			// The restored batches are removed from the storage once sent.
+			require.Eventually(t, func() bool { return len(ext.keys()) == 0 }, time.Second, time.Millisecond)
+			mu.Lock()
+			assert.Equal(t, map[string]int{"a": 3, "b": 3}, spansByToken)
+			mu.Unlock()
+			require.NoError(t, second.Shutdown(context.Background()))
+			assert.Empty(t, ext.keys())
+		})
+	}
+}
+
+func TestBatchProcessorPersistsPendingMetricsAndLogs(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Timeout = 10 * time.Minute
+	cfg.StorageID = &testStorageID
+	set := processortest.NewNopSettings()
+	host := newStorageHost(newMemStorage())
+
+	metricsSink := new(consumertest.MetricsSink)
+	logsSink := new(consumertest.LogsSink)
+	mp, err := newBatchMetricsProcessor(set, metricsSink, cfg)
+	require.NoError(t, err)
+	lp, err := newBatchLogsProcessor(set, logsSink, cfg)
+	require.NoError(t, err)
+	require.NoError(t, mp.Start(context.Background(), host))
+	require.NoError(t, lp.Start(context.Background(), host))
+	md := testdata.GenerateMetrics(4)
+	ld := testdata.GenerateLogs(5)
+	require.NoError(t, mp.ConsumeMetrics(context.Background(), md))
+	require.NoError(t, lp.ConsumeLogs(context.Background(), ld))
+	require.NoError(t, mp.Shutdown(context.Background()))
+	require.NoError(t, lp.Shutdown(context.Background()))
+	assert.Zero(t, metricsSink.DataPointCount())
+	assert.Zero(t, logsSink.LogRecordCount())
+
+	// The metrics and logs processors have distinct storage clients.
+	mp, err = newBatchMetricsProcessor(set, metricsSink, cfg)
+	require.NoError(t, err)
+	lp, err = newBatchLogsProcessor(set, logsSink, cfg)
+	require.NoError(t, err)
+	require.NoError(t, mp.Start(context.Background(), host))
+	require.NoError(t, lp.Start(context.Background(), host))
+	require.Eventually(t, func() bool {
+		return len(metricsSink.AllMetrics()) == 1 && len(logsSink.AllLogs()) == 1
+	}, time.Second, time.Millisecond)
+	require.Len(t, metricsSink.AllMetrics(), 1)
+	assert.Equal(t, testdata.GenerateMetrics(4), metricsSink.AllMetrics()[0])
+	require.Len(t, logsSink.AllLogs(), 1)
+	assert.Equal(t, testdata.GenerateLogs(5), logsSink.AllLogs()[0])
+	require.NoError(t, mp.Shutdown(context.Background()))
+	require.NoError(t, lp.Shutdown(context.Background()))
+}
+
+func TestBatchProcessorRestoredBatchesSplit(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Timeout = 10 * time.Minute
+	cfg.StorageID = &testStorageID
+	set := processortest.NewNopSettings()
+	host := newStorageHost(newMemStorage())
+
+	sink := new(consumertest.LogsSink)
+	first, err := newBatchLogsProcessor(set, sink, cfg)
+	require.NoError(t, err)
+	require.NoError(t, first.Start(context.Background(), host))
+	require.NoError(t, first.ConsumeLogs(context.Background(), testdata.GenerateLogs(25)))
+	require.NoError(t, first.Shutdown(context.Background()))
+	require.Zero(t, sink.LogRecordCount())
+
+	cfg.SendBatchSize = 10
+	cfg.SendBatchMaxSize = 10
+	second, err := newBatchLogsProcessor(set, sink, cfg)
+	require.NoError(t, err)
+	require.NoError(t, second.Start(context.Background(), host))
+	require.Eventually(t, func() bool { return sink.LogRecordCount() == 25 }, time.Second, time.Millisecond)
+	var sizes []int
+	for _, ld := range sink.AllLogs() {
+		sizes = append(sizes, ld.LogRecordCount())
+	}
+	assert.Equal(t, []int{10, 10, 5}, sizes)
+	require.NoError(t, second.Shutdown(context.Background()))
+}
+
+func TestBatchProcessorRestoredBatchesFailure(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Timeout = 10 * time.Minute
+	cfg.SendBatchMaxSize = 10
+	cfg.StorageID = &testStorageID
+	set := processortest.NewNopSettings()
+	ext := newMemStorage()
+	host := newStorageHost(ext)
+
+	sink := new(consumertest.LogsSink)
+	first, err := newBatchLogsProcessor(set, sink, cfg)
+	require.NoError(t, err)
+	require.NoError(t, first.Start(context.Background(), host))
+	require.NoError(t, first.ConsumeLogs(context.Background(), testdata.GenerateLogs(25)))
+	require.NoError(t, first.Shutdown(context.Background()))
+
+	// The second request fails: the logs not sent are persisted again.
+	var calls atomic.Int32
+	failing, err := consumer.NewLogs(func(ctx context.Context, ld plog.Logs) error {
+		if calls.Add(1) == 2 {
+			return errors.New("failed")
+		}
+		return sink.ConsumeLogs(ctx, ld)
+	})
+	require.NoError(t, err)
+	second, err := newBatchLogsProcessor(set, failing, cfg)
+	require.NoError(t, err)
+	require.NoError(t, second.Start(context.Background(), host))
+	require.Eventually(t, func() bool { return calls.Load() == 2 }, time.Second, time.Millisecond)
+	require.NoError(t, second.Shutdown(context.Background()))
+	assert.Equal(t, int32(2), calls.Load())
+	assert.Equal(t, 10, sink.LogRecordCount())
+	assert.Len(t, ext.keys(), 1)
+
+	third, err := newBatchLogsProcessor(set, sink, cfg)
+	require.NoError(t, err)
+	require.NoError(t, third.Start(context.Background(), host))
+	require.Eventually(t, func() bool { return len(ext.keys()) == 0 }, time.Second, time.Millisecond)
+	require.NoError(t, third.Shutdown(context.Background()))
+	assert.Equal(t, 25, sink.LogRecordCount())
+}
+
+func TestBatchProcessorRestoredBatchesPermanentFailure(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Timeout = 10 * time.Minute
+	cfg.SendBatchMaxSize = 10
+	cfg.StorageID = &testStorageID
+	set := processortest.NewNopSettings()
+	ext := newMemStorage()
+	host := newStorageHost(ext)
+
+	first, err := newBatchLogsProcessor(set, consumertest.NewNop(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, first.Start(context.Background(), host))
+	require.NoError(t, first.ConsumeLogs(context.Background(), testdata.GenerateLogs(25)))
+	require.NoError(t, first.Shutdown(context.Background()))
+
+	// The second request is rejected: its logs are dropped, the others are sent.
+	sink := new(consumertest.LogsSink)
+	var calls atomic.Int32
+	rejecting, err := consumer.NewLogs(func(ctx context.Context, ld plog.Logs) error {
+		if calls.Add(1) == 2 {
+			return consumererror.NewPermanent(errors.New("rejected"))
+		}
+		return sink.ConsumeLogs(ctx, ld)
+	})
+	require.NoError(t, err)
+	second, err := newBatchLogsProcessor(set, rejecting, cfg)
+	require.NoError(t, err)
+	require.NoError(t, second.Start(context.Background(), host))
+	require.Eventually(t, func() bool { return len(ext.keys()) == 0 }, time.Second, time.Millisecond)
+	require.NoError(t, second.Shutdown(context.Background()))
+	assert.Equal(t, int32(3), calls.Load())
+	assert.Equal(t, 15, sink.LogRecordCount())
+	assert.Empty(t, ext.keys())
+}
+
+func TestBatchProcessorRestoredBatchesInBackground(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Timeout = 10 * time.Minute
+	cfg.StorageID = &testStorageID
+	set := processortest.NewNopSettings()
+	ext := newMemStorage()
+	host := newStorageHost(ext)
+
+	first, err := newBatchTracesProcessor(set, consumertest.NewNop(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, first.Start(context.Background(), host))
+	require.NoError(t, first.ConsumeTraces(context.Background(), testdata.GenerateTraces(3)))
+	require.NoError(t, first.Shutdown(context.Background()))
+
+	// Start does not wait for the restored batches to be sent.
+	unblock := make(chan struct{})
+	sink := new(consumertest.TracesSink)
+	blocking, err := consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
+		<-unblock
+		return sink.ConsumeTraces(ctx, td)
+	})
+	require.NoError(t, err)
+	second, err := newBatchTracesProcessor(set, blocking, cfg)
+	require.NoError(t, err)
+	require.NoError(t, second.Start(context.Background(), host))
+	assert.Len(t, ext.keys(), 1)
+	close(unblock)
+	require.Eventually(t, func() bool { return len(ext.keys()) == 0 }, time.Second, time.Millisecond)
+	assert.Equal(t, 3, sink.SpanCount())
+	require.NoError(t, second.Shutdown(context.Background()))
+}
+
+func TestBatchProcessorSendsWithoutStorage(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Timeout = 10 * time.Minute
+	sink := new(consumertest.TracesSink)
+	bp, err := newBatchTracesProcessor(processortest.NewNopSettings(), sink, cfg)
+	require.NoError(t, err)
+	require.NoError(t, bp.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, bp.ConsumeTraces(context.Background(), testdata.GenerateTraces(3)))
+	require.NoError(t, bp.Shutdown(context.Background()))
+	assert.Equal(t, 3, sink.SpanCount())
+}
+
+func TestBatchProcessorStorageErrors(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.StorageID = &testStorageID
+
+	bp, err := newBatchTracesProcessor(processortest.NewNopSettings(), consumertest.NewNop(), cfg)
+	require.NoError(t, err)
+	require.ErrorIs(t, bp.Start(context.Background(), componenttest.NewNopHost()), errNoStorageClient)
+	require.NoError(t, bp.Shutdown(context.Background()))
+
+	bp, err = newBatchTracesProcessor(processortest.NewNopSettings(), consumertest.NewNop(), cfg)
+	require.NoError(t, err)
+	notStorage := struct {
+		component.StartFunc
+		component.ShutdownFunc
+	}{}
+	require.ErrorIs(t, bp.Start(context.Background(), newStorageHost(notStorage)), errWrongExtensionType)
+	require.NoError(t, bp.Shutdown(context.Background()))
+}
+
+func TestBatchRestoreInvalidData(t *testing.T) {
+	for _, b := range []batch{newBatchTraces(consumertest.NewNop()), newBatchMetrics(consumertest.NewNop()), newBatchLogs(consumertest.NewNop())} {
+		assert.Error(t, b.restore([]byte{0xff}))
+		assert.Zero(t, b.itemCount())
+	}
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("span")
+	bt := newBatchTraces(consumertest.NewNop())
+	bt.add(td)
+	buf, err := bt.marshal()
+	require.NoError(t, err)
+	restored := newBatchTraces(consumertest.NewNop())
+	require.NoError(t, restored.restore(buf))
+	assert.Equal(t, 1, restored.itemCount())
+}
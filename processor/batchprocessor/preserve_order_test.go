@@ -0,0 +1,123 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package batchprocessor
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/processor/processortest"
+)
+
+func newSequencedLogs(producer string, first, count int) plog.Logs {
+	ld := plog.NewLogs()
+	lrs := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	for i := first; i < first+count; i++ {
+		lr := lrs.AppendEmpty()
+		lr.Attributes().PutStr("producer", producer)
+		lr.Body().SetInt(int64(i))
+	}
+	return ld
+}
+
+// sequences returns the body of the log records received by sink per producer, in the order they were sent.
+func sequences(sink *consumertest.LogsSink) map[string][]int64 {
+	seqs := map[string][]int64{}
+	for _, ld := range sink.AllLogs() {
+		rls := ld.ResourceLogs()
+		for i := 0; i < rls.Len(); i++ {
+			lrs := rls.At(i).ScopeLogs().At(0).LogRecords()
+			for j := 0; j < lrs.Len(); j++ {
+				producer, _ := lrs.At(j).Attributes().Get("producer")
+				seqs[producer.Str()] = append(seqs[producer.Str()], lrs.At(j).Body().Int())
+			}
+		}
+	}
+	return seqs
+}
+
+func TestBatchProcessorPreserveOrderAcrossMetadata(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	cfg := createDefaultConfig().(*Config)
+	cfg.SendBatchSize = 50
+	cfg.Timeout = time.Hour
+	cfg.MetadataKeys = []string{"tenant"}
+	cfg.PreserveOrder = true
+
+	batcher, err := newBatchLogsProcessor(processortest.NewNopSettings(), sink, cfg)
+	require.NoError(t, err)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+
+	tenants := []string{"a", "a", "b", "c", "b", "b", "a", "c"}
+	const count = 7
+	seq := 0
+	for i := 0; i < 100; i++ {
+		ctx := client.NewContext(context.Background(), client.Info{
+			Metadata: client.NewMetadata(map[string][]string{"tenant": {tenants[i%len(tenants)]}}),
+		})
+		require.NoError(t, batcher.ConsumeLogs(ctx, newSequencedLogs("p", seq, count)))
+		seq += count
+	}
+	require.NoError(t, batcher.Shutdown(context.Background()))
+
+	received := sequences(sink)["p"]
+	require.Len(t, received, seq)
+	for i, v := range received {
+		require.Equal(t, int64(i), v)
+	}
+	// Consecutive data of the same tenant is still batched.
+	assert.Less(t, len(sink.AllLogs()), 100)
+}
+
+func TestBatchProcessorPreserveOrderConcurrent(t *testing.T) {
+	for _, metadataKeys := range [][]string{nil, {"tenant"}} {
+		sink := new(consumertest.LogsSink)
+		cfg := createDefaultConfig().(*Config)
+		cfg.SendBatchSize = 64
+		cfg.SendBatchMaxSize = 100
+		cfg.Timeout = time.Millisecond
+		cfg.MetadataKeys = metadataKeys
+		cfg.PreserveOrder = true
+
+		batcher, err := newBatchLogsProcessor(processortest.NewNopSettings(), sink, cfg)
+		require.NoError(t, err)
+		require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+
+		producers := []string{"p1", "p2", "p3", "p4"}
+		const requests, count = 200, 5
+		var wg sync.WaitGroup
+		for i, producer := range producers {
+			wg.Add(1)
+			go func(i int, producer string) {
+				defer wg.Done()
+				for r := 0; r < requests; r++ {
+					ctx := client.NewContext(context.Background(), client.Info{
+						Metadata: client.NewMetadata(map[string][]string{"tenant": {producers[(i+r)%len(producers)]}}),
+					})
+					assert.NoError(t, batcher.ConsumeLogs(ctx, newSequencedLogs(producer, r*count, count)))
+				}
+			}(i, producer)
+		}
+		wg.Wait()
+		require.NoError(t, batcher.Shutdown(context.Background()))
+
+		seqs := sequences(sink)
+		for _, producer := range producers {
+			received := seqs[producer]
+			require.Len(t, received, requests*count)
+			for i, v := range received {
+				require.Equal(t, int64(i), v, "producer %s", producer)
+			}
+		}
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package batchprocessor // import "go.opentelemetry.io/collector/processor/batchprocessor"
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	// sharedTimerResolution is the number of ticks of the shared
+	// timer per configured timeout, bounding how late a batch can
+	// be sent compared with a dedicated timer.
+	sharedTimerResolution = 10
+
+	// minSharedTimerTick bounds the tick rate for very small timeouts.
+	minSharedTimerTick = time.Millisecond
+)
+
+// sharedTimer runs a single goroutine that checks the deadline of every
+// registered shard, and sends the batches of the expired shards, each
+// from its own goroutine so a slow export does not delay the other
+// shards. It replaces the goroutine and timer each shard otherwise
+// owns, which matters when metadata keys produce a large number of
+// shards.
+type sharedTimer struct {
+	processor *batchProcessor
+	tick      time.Duration
+
+	lock   sync.Mutex
+	shards []*shard
+}
+
+func newSharedTimer(bp *batchProcessor) *sharedTimer {
+	tick := bp.timeout / sharedTimerResolution
+	if tick < minSharedTimerTick {
+		tick = minSharedTimerTick
+	}
+	return &sharedTimer{
+		processor: bp,
+		tick:      tick,
+	}
+}
+
+func (st *sharedTimer) start() {
+	st.processor.goroutines.Add(1)
+	go st.startLoop()
+}
+
+// add registers a shard, arming its first deadline.
+func (st *sharedTimer) add(s *shard) {
+	s.mu.Lock()
+	s.resetTimer()
+	s.mu.Unlock()
+
+	st.lock.Lock()
+	defer st.lock.Unlock()
+	st.shards = append(st.shards, s)
+}
+
+// currentShards returns a copy of the registered shards, which can be
+// iterated while shards are added.
+func (st *sharedTimer) currentShards() []*shard {
+	st.lock.Lock()
+	defer st.lock.Unlock()
+	return slices.Clone(st.shards)
+}
+
+func (st *sharedTimer) startLoop() {
+	defer st.processor.goroutines.Done()
+
+	ticker := time.NewTicker(st.tick)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-st.processor.shutdownC:
+			for _, s := range st.currentShards() {
+				st.processor.goroutines.Add(1)
+				go func() {
+					defer st.processor.goroutines.Done()
+					s.mu.Lock()
+					defer s.mu.Unlock()
+					s.sendOnShutdown()
+				}()
+			}
+			return
+		case now := <-ticker.C:
+			for _, s := range st.currentShards() {
+				// A shard still sending its previous batch is checked again at the next tick.
+				if now.UnixNano() < s.deadline.Load() || !s.flushing.CompareAndSwap(false, true) {
+					continue
+				}
+				st.processor.goroutines.Add(1)
+				go func() {
+					defer st.processor.goroutines.Done()
+					defer s.flushing.Store(false)
+					s.expire(now)
+				}()
+			}
+		}
+	}
+}
+
+// expire sends the pending batch of the shard if its deadline has passed.
+func (b *shard) expire(now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	// The deadline may have been pushed back by a batch sent since it
+	// was checked.
+	if now.UnixNano() < b.deadline.Load() {
+		return
+	}
+	if b.batch.itemCount() > 0 {
+		b.sendItems(triggerTimeout)
+	}
+	b.resetTimer()
+}
@@ -0,0 +1,165 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package batchprocessor
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/testdata"
+	"go.opentelemetry.io/collector/processor/processortest"
+)
+
+func TestBatchProcessorSharedTimerManyPartitions(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	cfg := createDefaultConfig().(*Config)
+	cfg.SendBatchSize = 1000
+	cfg.Timeout = 100 * time.Millisecond
+	cfg.MetadataKeys = []string{"tenant"}
+	cfg.SharedTimer = true
+
+	goroutinesBefore := runtime.NumGoroutine()
+	batcher, err := newBatchTracesProcessor(processortest.NewNopSettings(), sink, cfg)
+	require.NoError(t, err)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+
+	partitions := 200
+	spansPerRequest := 5
+	start := time.Now()
+	for i := 0; i < partitions; i++ {
+		ctx := client.NewContext(context.Background(), client.Info{
+			Metadata: client.NewMetadata(map[string][]string{"tenant": {fmt.Sprintf("tenant-%d", i)}}),
+		})
+		require.NoError(t, batcher.ConsumeTraces(ctx, testdata.GenerateTraces(spansPerRequest)))
+	}
+	assert.Equal(t, partitions, batcher.batcher.currentMetadataCardinality())
+
+	// A single goroutine serves all the partitions.
+	assert.LessOrEqual(t, runtime.NumGoroutine()-goroutinesBefore, 1)
+
+	// Nothing is sent before the timeout.
+	assert.Equal(t, 0, sink.SpanCount())
+
+	assert.Eventually(t, func() bool {
+		return sink.SpanCount() == partitions*spansPerRequest
+	}, 10*cfg.Timeout, cfg.Timeout/sharedTimerResolution)
+	assert.GreaterOrEqual(t, time.Since(start), cfg.Timeout)
+	assert.Len(t, sink.AllTraces(), partitions)
+
+	require.NoError(t, batcher.Shutdown(context.Background()))
+	assert.Equal(t, partitions*spansPerRequest, sink.SpanCount())
+}
+
+func TestBatchProcessorSharedTimerSlowShard(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	release := make(chan struct{})
+	next, err := consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
+		if client.FromContext(ctx).Metadata.Get("tenant")[0] == "slow" {
+			<-release
+		}
+		return sink.ConsumeTraces(ctx, td)
+	})
+	require.NoError(t, err)
+	cfg := createDefaultConfig().(*Config)
+	cfg.Timeout = 50 * time.Millisecond
+	cfg.MetadataKeys = []string{"tenant"}
+	cfg.SharedTimer = true
+
+	batcher, err := newBatchTracesProcessor(processortest.NewNopSettings(), next, cfg)
+	require.NoError(t, err)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+
+	consume := func(tenant string) {
+		ctx := client.NewContext(context.Background(), client.Info{
+			Metadata: client.NewMetadata(map[string][]string{"tenant": {tenant}}),
+		})
+		require.NoError(t, batcher.ConsumeTraces(ctx, testdata.GenerateTraces(1)))
+	}
+	consume("slow")
+	consume("fast")
+	// The batch of the fast shard is sent while the export of the slow shard is blocked.
+	assert.Eventually(t, func() bool {
+		return sink.SpanCount() == 1
+	}, 10*cfg.Timeout, cfg.Timeout/sharedTimerResolution)
+
+	// Later batches of the fast shard are not delayed either.
+	consume("fast")
+	assert.Eventually(t, func() bool {
+		return sink.SpanCount() == 2
+	}, 10*cfg.Timeout, cfg.Timeout/sharedTimerResolution)
+
+	close(release)
+	require.NoError(t, batcher.Shutdown(context.Background()))
+	assert.Equal(t, 3, sink.SpanCount())
+}
+
+func TestBatchProcessorSharedTimerSentBySize(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	cfg := createDefaultConfig().(*Config)
+	cfg.SendBatchSize = 10
+	cfg.Timeout = time.Hour
+	cfg.SharedTimer = true
+
+	batcher, err := newBatchLogsProcessor(processortest.NewNopSettings(), sink, cfg)
+	require.NoError(t, err)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+
+	for i := 0; i < 5; i++ {
+		require.NoError(t, batcher.ConsumeLogs(context.Background(), testdata.GenerateLogs(4)))
+	}
+	// The batch is sent synchronously once send_batch_size is reached,
+	// the remaining records wait for the timeout.
+	assert.Equal(t, 12, sink.LogRecordCount())
+	assert.Len(t, sink.AllLogs(), 1)
+
+	require.NoError(t, batcher.Shutdown(context.Background()))
+	assert.Equal(t, 20, sink.LogRecordCount())
+}
+
+func TestBatchProcessorSharedTimerSendWhenClosing(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	cfg := createDefaultConfig().(*Config)
+	cfg.Timeout = time.Hour
+	cfg.MetadataKeys = []string{"tenant"}
+	cfg.SharedTimer = true
+
+	batcher, err := newBatchMetricsProcessor(processortest.NewNopSettings(), sink, cfg)
+	require.NoError(t, err)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+
+	md := testdata.GenerateMetrics(2)
+	dataPoints := md.DataPointCount()
+	require.NoError(t, batcher.ConsumeMetrics(context.Background(), md))
+	require.NoError(t, batcher.Shutdown(context.Background()))
+
+	assert.Equal(t, dataPoints, sink.DataPointCount())
+}
+
+func TestBatchProcessorSharedTimerNoTimeout(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	cfg := createDefaultConfig().(*Config)
+	cfg.Timeout = 0
+	cfg.SharedTimer = true
+
+	batcher, err := newBatchTracesProcessor(processortest.NewNopSettings(), sink, cfg)
+	require.NoError(t, err)
+	assert.Nil(t, batcher.sharedTimer)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+
+	require.NoError(t, batcher.ConsumeTraces(context.Background(), testdata.GenerateTraces(3)))
+	assert.Equal(t, 3, sink.SpanCount())
+
+	require.NoError(t, batcher.Shutdown(context.Background()))
+}
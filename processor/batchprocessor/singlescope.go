@@ -0,0 +1,196 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package batchprocessor // import "go.opentelemetry.io/collector/processor/batchprocessor"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// scopeKey identifies an instrumentation scope by its name, version and attributes.
+type scopeKey struct {
+	name       string
+	version    string
+	attributes pcommon.Fingerprint
+}
+
+func newScopeKey(scope pcommon.InstrumentationScope) scopeKey {
+	return scopeKey{name: scope.Name(), version: scope.Version(), attributes: scope.Attributes().Fingerprint()}
+}
+
+// consumeTracesByScope sends td to next in one request per instrumentation scope, and returns the number of
+// spans and, if sizer is not nil, the bytes of the requests delivered. It stops at the first failed request,
+// returning its error as a consumererror.Traces holding the spans of the failed and following requests, so
+// the delivered spans are not sent again if the undelivered ones are retried.
+func consumeTracesByScope(ctx context.Context, next consumer.Traces, td ptrace.Traces, sizer ptrace.Sizer) (int, int, error) {
+	reqs := splitTracesByScope(td)
+	sent, bytes := 0, 0
+	for i, req := range reqs {
+		count, size := req.SpanCount(), 0
+		if sizer != nil {
+			size = sizer.TracesSize(req)
+		}
+		if err := next.ConsumeTraces(ctx, req); err != nil {
+			undelivered := ptrace.NewTraces()
+			for _, r := range reqs[i:] {
+				r.ResourceSpans().MoveAndAppendTo(undelivered.ResourceSpans())
+			}
+			return sent, bytes, consumererror.NewTraces(err, undelivered)
+		}
+		sent += count
+		bytes += size
+	}
+	return sent, bytes, nil
+}
+
+// splitTracesByScope moves the spans of src into one ptrace.Traces per instrumentation scope,
+// in the order the scopes first appear.
+func splitTracesByScope(src ptrace.Traces) []ptrace.Traces {
+	var dests []ptrace.Traces
+	// last is the index of the resource of src last appended to each dest.
+	var last []int
+	indexes := map[scopeKey]int{}
+	rss := src.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		sss := rs.ScopeSpans()
+		for j := 0; j < sss.Len(); j++ {
+			key := newScopeKey(sss.At(j).Scope())
+			idx, ok := indexes[key]
+			if !ok {
+				idx = len(dests)
+				indexes[key] = idx
+				dests = append(dests, ptrace.NewTraces())
+				last = append(last, -1)
+			}
+			if last[idx] != i {
+				destRs := dests[idx].ResourceSpans().AppendEmpty()
+				rs.Resource().CopyTo(destRs.Resource())
+				destRs.SetSchemaUrl(rs.SchemaUrl())
+				last[idx] = i
+			}
+			destRss := dests[idx].ResourceSpans()
+			sss.At(j).MoveTo(destRss.At(destRss.Len() - 1).ScopeSpans().AppendEmpty())
+		}
+	}
+	return dests
+}
+
+// consumeMetricsByScope sends md to next in one request per instrumentation scope, as consumeTracesByScope,
+// returning the number of data points delivered.
+func consumeMetricsByScope(ctx context.Context, next consumer.Metrics, md pmetric.Metrics, sizer pmetric.Sizer) (int, int, error) {
+	reqs := splitMetricsByScope(md)
+	sent, bytes := 0, 0
+	for i, req := range reqs {
+		count, size := req.DataPointCount(), 0
+		if sizer != nil {
+			size = sizer.MetricsSize(req)
+		}
+		if err := next.ConsumeMetrics(ctx, req); err != nil {
+			undelivered := pmetric.NewMetrics()
+			for _, r := range reqs[i:] {
+				r.ResourceMetrics().MoveAndAppendTo(undelivered.ResourceMetrics())
+			}
+			return sent, bytes, consumererror.NewMetrics(err, undelivered)
+		}
+		sent += count
+		bytes += size
+	}
+	return sent, bytes, nil
+}
+
+// splitMetricsByScope moves the metrics of src into one pmetric.Metrics per instrumentation scope,
+// in the order the scopes first appear.
+func splitMetricsByScope(src pmetric.Metrics) []pmetric.Metrics {
+	var dests []pmetric.Metrics
+	// last is the index of the resource of src last appended to each dest.
+	var last []int
+	indexes := map[scopeKey]int{}
+	rms := src.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		sms := rm.ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			key := newScopeKey(sms.At(j).Scope())
+			idx, ok := indexes[key]
+			if !ok {
+				idx = len(dests)
+				indexes[key] = idx
+				dests = append(dests, pmetric.NewMetrics())
+				last = append(last, -1)
+			}
+			if last[idx] != i {
+				destRm := dests[idx].ResourceMetrics().AppendEmpty()
+				rm.Resource().CopyTo(destRm.Resource())
+				destRm.SetSchemaUrl(rm.SchemaUrl())
+				last[idx] = i
+			}
+			destRms := dests[idx].ResourceMetrics()
+			sms.At(j).MoveTo(destRms.At(destRms.Len() - 1).ScopeMetrics().AppendEmpty())
+		}
+	}
+	return dests
+}
+
+// consumeLogsByScope sends ld to next in one request per instrumentation scope, as consumeTracesByScope,
+// returning the number of log records delivered.
+func consumeLogsByScope(ctx context.Context, next consumer.Logs, ld plog.Logs, sizer plog.Sizer) (int, int, error) {
+	reqs := splitLogsByScope(ld)
+	sent, bytes := 0, 0
+	for i, req := range reqs {
+		count, size := req.LogRecordCount(), 0
+		if sizer != nil {
+			size = sizer.LogsSize(req)
+		}
+		if err := next.ConsumeLogs(ctx, req); err != nil {
+			undelivered := plog.NewLogs()
+			for _, r := range reqs[i:] {
+				r.ResourceLogs().MoveAndAppendTo(undelivered.ResourceLogs())
+			}
+			return sent, bytes, consumererror.NewLogs(err, undelivered)
+		}
+		sent += count
+		bytes += size
+	}
+	return sent, bytes, nil
+}
+
+// splitLogsByScope moves the log records of src into one plog.Logs per instrumentation scope,
+// in the order the scopes first appear.
+func splitLogsByScope(src plog.Logs) []plog.Logs {
+	var dests []plog.Logs
+	// last is the index of the resource of src last appended to each dest.
+	var last []int
+	indexes := map[scopeKey]int{}
+	rls := src.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		sls := rl.ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			key := newScopeKey(sls.At(j).Scope())
+			idx, ok := indexes[key]
+			if !ok {
+				idx = len(dests)
+				indexes[key] = idx
+				dests = append(dests, plog.NewLogs())
+				last = append(last, -1)
+			}
+			if last[idx] != i {
+				destRl := dests[idx].ResourceLogs().AppendEmpty()
+				rl.Resource().CopyTo(destRl.Resource())
+				destRl.SetSchemaUrl(rl.SchemaUrl())
+				last[idx] = i
+			}
+			destRls := dests[idx].ResourceLogs()
+			sls.At(j).MoveTo(destRls.At(destRls.Len() - 1).ScopeLogs().AppendEmpty())
+		}
+	}
+	return dests
+}
@@ -0,0 +1,178 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package batchprocessor
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor/processortest"
+)
+
+// appendScopeSpans appends a scope with the spans named after the scope to rs.
+func appendScopeSpans(rs ptrace.ResourceSpans, scope string, spans int) {
+	ss := rs.ScopeSpans().AppendEmpty()
+	ss.Scope().SetName(scope)
+	for i := 0; i < spans; i++ {
+		ss.Spans().AppendEmpty().SetName(scope)
+	}
+}
+
+func TestBatchProcessorSingleScopePerBatch(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	cfg := createDefaultConfig().(*Config)
+	cfg.SendBatchSize = 6
+	cfg.SingleScopePerBatch = true
+	batcher, err := newBatchTracesProcessor(processortest.NewNopSettings(), sink, cfg)
+	require.NoError(t, err)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", "a")
+	appendScopeSpans(rs, "scope1", 1)
+	appendScopeSpans(rs, "scope2", 2)
+	require.NoError(t, batcher.ConsumeTraces(context.Background(), td))
+	td = ptrace.NewTraces()
+	rs = td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", "b")
+	appendScopeSpans(rs, "scope2", 1)
+	appendScopeSpans(rs, "scope1", 2)
+	require.NoError(t, batcher.ConsumeTraces(context.Background(), td))
+	require.NoError(t, batcher.Shutdown(context.Background()))
+
+	// The batch of 6 spans is sent in one request per scope, holding the spans of both resources.
+	require.Len(t, sink.AllTraces(), 2)
+	for i, scope := range []string{"scope1", "scope2"} {
+		req := sink.AllTraces()[i]
+		assert.Equal(t, 3, req.SpanCount())
+		require.Equal(t, 2, req.ResourceSpans().Len())
+		for j, service := range []string{"a", "b"} {
+			rs := req.ResourceSpans().At(j)
+			assert.Equal(t, map[string]any{"service.name": service}, rs.Resource().Attributes().AsRaw())
+			require.Equal(t, 1, rs.ScopeSpans().Len())
+			ss := rs.ScopeSpans().At(0)
+			assert.Equal(t, scope, ss.Scope().Name())
+			for k := 0; k < ss.Spans().Len(); k++ {
+				assert.Equal(t, scope, ss.Spans().At(k).Name())
+			}
+		}
+	}
+}
+
+func TestSplitTracesByScope(t *testing.T) {
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.SetSchemaUrl("https://opentelemetry.io/schemas/1.26.0")
+	appendScopeSpans(rs, "scope", 1)
+	appendScopeSpans(rs, "scope", 1)
+	// The scopes with distinct versions or attributes are distinct.
+	appendScopeSpans(rs, "scope", 1)
+	rs.ScopeSpans().At(2).Scope().SetVersion("v2")
+	appendScopeSpans(rs, "scope", 1)
+	rs.ScopeSpans().At(3).Scope().Attributes().PutStr("k", "v")
+
+	reqs := splitTracesByScope(td)
+	require.Len(t, reqs, 3)
+	assert.Equal(t, 2, reqs[0].SpanCount())
+	assert.Equal(t, 1, reqs[0].ResourceSpans().Len())
+	// The scopes are not merged, only grouped.
+	assert.Equal(t, 2, reqs[0].ResourceSpans().At(0).ScopeSpans().Len())
+	assert.Equal(t, "https://opentelemetry.io/schemas/1.26.0", reqs[0].ResourceSpans().At(0).SchemaUrl())
+	assert.Equal(t, "v2", reqs[1].ResourceSpans().At(0).ScopeSpans().At(0).Scope().Version())
+	assert.Equal(t, map[string]any{"k": "v"}, reqs[2].ResourceSpans().At(0).ScopeSpans().At(0).Scope().Attributes().AsRaw())
+	assert.Empty(t, splitTracesByScope(ptrace.NewTraces()))
+}
+
+func TestSplitMetricsByScope(t *testing.T) {
+	md := pmetric.NewMetrics()
+	for _, service := range []string{"a", "b"} {
+		rm := md.ResourceMetrics().AppendEmpty()
+		rm.Resource().Attributes().PutStr("service.name", service)
+		for _, scope := range []string{"scope1", "scope2"} {
+			sm := rm.ScopeMetrics().AppendEmpty()
+			sm.Scope().SetName(scope)
+			sm.Metrics().AppendEmpty().SetEmptyGauge().DataPoints().AppendEmpty().SetIntValue(1)
+		}
+	}
+
+	reqs := splitMetricsByScope(md)
+	require.Len(t, reqs, 2)
+	for i, scope := range []string{"scope1", "scope2"} {
+		assert.Equal(t, 2, reqs[i].DataPointCount())
+		rms := reqs[i].ResourceMetrics()
+		require.Equal(t, 2, rms.Len())
+		for j := 0; j < rms.Len(); j++ {
+			require.Equal(t, 1, rms.At(j).ScopeMetrics().Len())
+			assert.Equal(t, scope, rms.At(j).ScopeMetrics().At(0).Scope().Name())
+		}
+	}
+}
+
+func TestConsumeLogsByScope(t *testing.T) {
+	newLogs := func() plog.Logs {
+		ld := plog.NewLogs()
+		rl := ld.ResourceLogs().AppendEmpty()
+		for _, scope := range []string{"scope1", "scope2", "scope1", "scope3"} {
+			sl := rl.ScopeLogs().AppendEmpty()
+			sl.Scope().SetName(scope)
+			sl.LogRecords().AppendEmpty().Body().SetStr(scope)
+		}
+		return ld
+	}
+
+	sink := new(consumertest.LogsSink)
+	sent, bytes, err := consumeLogsByScope(context.Background(), sink, newLogs(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, 4, sent)
+	assert.Zero(t, bytes)
+	require.Len(t, sink.AllLogs(), 3)
+	assert.Equal(t, 2, sink.AllLogs()[0].LogRecordCount())
+	sizer := &plog.ProtoMarshaler{}
+	_, bytes, err = consumeLogsByScope(context.Background(), sink, newLogs(), sizer)
+	require.NoError(t, err)
+	assert.Equal(t, sizer.LogsSize(sink.AllLogs()[3])+sizer.LogsSize(sink.AllLogs()[4])+sizer.LogsSize(sink.AllLogs()[5]), bytes)
+
+	// The requests following the failed one are not sent, and returned with it as undelivered.
+	calls := 0
+	next, err := consumer.NewLogs(func(context.Context, plog.Logs) error {
+		calls++
+		if calls == 2 {
+			return errors.New("failed")
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	sent, _, err = consumeLogsByScope(context.Background(), next, newLogs(), nil)
+	assert.EqualError(t, err, "failed")
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, 2, sent)
+	var logsErr consumererror.Logs
+	require.ErrorAs(t, err, &logsErr)
+	undelivered := logsErr.Data()
+	assert.Equal(t, 2, undelivered.LogRecordCount())
+	assert.Equal(t, "scope2", undelivered.ResourceLogs().At(0).ScopeLogs().At(0).Scope().Name())
+	assert.Equal(t, "scope3", undelivered.ResourceLogs().At(1).ScopeLogs().At(0).Scope().Name())
+
+	// The batch only counts the delivered log records.
+	bl := newBatchLogs(next)
+	bl.singleScope = true
+	bl.add(newLogs())
+	calls = 0
+	sent, _, err = bl.export(context.Background(), 0, false)
+	require.Error(t, err)
+	assert.Equal(t, 2, sent)
+	assert.Zero(t, bl.itemCount())
+}
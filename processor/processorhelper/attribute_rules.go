@@ -0,0 +1,331 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package processorhelper // import "go.opentelemetry.io/collector/processor/processorhelper"
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// The actions of the attribute rules.
+const (
+	attributeActionRename = "rename"
+	attributeActionDrop   = "drop"
+	attributeActionSet    = "set"
+)
+
+// attributeRulesPollInterval is the interval at which the attribute rules file is read to reload it when it changes.
+var attributeRulesPollInterval = time.Second
+
+var errEmptyAttributeRules = errors.New("empty file, use an empty list of rules to apply none")
+
+// attributeRule is a rule of an attribute rules file, applied to the attributes of every span, log record
+// and metric data point.
+type attributeRule struct {
+	// Action is rename, drop or set.
+	Action string `yaml:"action"`
+	// Key is the attribute the rule applies to.
+	Key string `yaml:"key"`
+	// NewKey is the new key of the attribute renamed by a rename rule, overriding the attribute with that key.
+	NewKey string `yaml:"new_key"`
+	// Value is the string value of the attribute set by a set rule.
+	Value string `yaml:"value"`
+}
+
+type attributeRulesFile struct {
+	Rules []attributeRule `yaml:"rules"`
+}
+
+func (r attributeRule) validate() error {
+	if r.Key == "" {
+		return errors.New("missing key")
+	}
+	switch r.Action {
+	case attributeActionRename:
+		if r.NewKey == "" {
+			return fmt.Errorf("missing new_key of the %q attribute", r.Key)
+		}
+		if r.NewKey == r.Key {
+			return fmt.Errorf("new_key of the %q attribute must differ from its key", r.Key)
+		}
+	case attributeActionDrop, attributeActionSet:
+	default:
+		return fmt.Errorf("unknown action %q of the %q attribute", r.Action, r.Key)
+	}
+	return nil
+}
+
+func (r attributeRule) apply(attrs pcommon.Map) {
+	switch r.Action {
+	case attributeActionRename:
+		if v, ok := attrs.Get(r.Key); ok {
+			v.CopyTo(attrs.PutEmpty(r.NewKey))
+			attrs.Remove(r.Key)
+		}
+	case attributeActionDrop:
+		attrs.Remove(r.Key)
+	case attributeActionSet:
+		attrs.PutStr(r.Key, r.Value)
+	}
+}
+
+// parseAttributeRules decodes and validates the rules of the attribute rules file at path, whose content is buf.
+func parseAttributeRules(path string, buf []byte) ([]attributeRule, error) {
+	var file attributeRulesFile
+	decoder := yaml.NewDecoder(bytes.NewReader(buf))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&file); err != nil {
+		if errors.Is(err, io.EOF) {
+			// The file may be read while being written.
+			err = errEmptyAttributeRules
+		}
+		return nil, fmt.Errorf("invalid attribute rules file %q: %w", path, err)
+	}
+	for i, r := range file.Rules {
+		if err := r.validate(); err != nil {
+			return nil, fmt.Errorf("invalid attribute rule %d of %q: %w", i, path, err)
+		}
+	}
+	return file.Rules, nil
+}
+
+// attributeRules applies the rules of an attribute rules file, reloaded when the file changes.
+type attributeRules struct {
+	path   string
+	logger *zap.Logger
+	rules  atomic.Pointer[[]attributeRule]
+
+	// loaded is the content of the file the rules were last loaded, or failed to be loaded, from.
+	loaded []byte
+	// pending is the changed content of the file read by the last poll, loaded if unchanged by the next poll
+	// not to load a file being written.
+	pending []byte
+	// readFailed is whether the last poll failed to read the file, not to log the failure at every poll.
+	readFailed bool
+
+	// stop is set by start, nil if the file is not polled.
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// newAttributeRules returns an attributeRules loading the rules from the file at path, or nil if path is empty.
+func newAttributeRules(path string, logger *zap.Logger) (*attributeRules, error) {
+	if path == "" {
+		return nil, nil
+	}
+	buf, err := os.ReadFile(path) // #nosec G304 The path is given by the processor.
+	if err != nil {
+		return nil, err
+	}
+	rules, err := parseAttributeRules(path, buf)
+	if err != nil {
+		return nil, err
+	}
+	ar := &attributeRules{path: path, logger: logger, loaded: buf}
+	ar.rules.Store(&rules)
+	return ar, nil
+}
+
+// start polls the rules file to reload it when it changes.
+func (ar *attributeRules) start() {
+	ar.stop = make(chan struct{})
+	ar.wg.Add(1)
+	go func() {
+		defer ar.wg.Done()
+		ticker := time.NewTicker(attributeRulesPollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				ar.poll()
+			case <-ar.stop:
+				return
+			}
+		}
+	}()
+}
+
+// poll reloads the rules if the content of the file changed and is the same as at the previous poll, keeping the
+// current rules if it cannot be loaded. The file is read, rather than its modification time checked, since it may
+// be replaced by one with an older modification time, e.g. for the k8s configmaps using symlinks.
+func (ar *attributeRules) poll() {
+	buf, err := os.ReadFile(ar.path) // #nosec G304 The path is given by the processor.
+	if err != nil {
+		if !ar.readFailed {
+			ar.logger.Warn("Failed to read the attribute rules file, keeping the current rules", zap.String("path", ar.path), zap.Error(err))
+		}
+		ar.readFailed = true
+		return
+	}
+	ar.readFailed = false
+	if bytes.Equal(buf, ar.loaded) {
+		ar.pending = nil
+		return
+	}
+	if ar.pending == nil || !bytes.Equal(buf, ar.pending) {
+		ar.pending = buf
+		return
+	}
+	ar.pending = nil
+	ar.loaded = buf
+	rules, err := parseAttributeRules(ar.path, buf)
+	if err != nil {
+		ar.logger.Warn("Failed to reload the attribute rules, keeping the current ones", zap.Error(err))
+		return
+	}
+	ar.rules.Store(&rules)
+	ar.logger.Info("Attribute rules reloaded", zap.String("path", ar.path), zap.Int("rules", len(rules)))
+}
+
+// shutdown stops polling the rules file.
+func (ar *attributeRules) shutdown() {
+	if ar.stop == nil {
+		return
+	}
+	close(ar.stop)
+	ar.wg.Wait()
+	ar.stop = nil
+}
+
+// wrapStart returns a StartFunc polling the rules file before calling start.
+func (ar *attributeRules) wrapStart(start component.StartFunc) component.StartFunc {
+	if ar == nil {
+		return start
+	}
+	return func(ctx context.Context, host component.Host) error {
+		ar.start()
+		return start.Start(ctx, host)
+	}
+}
+
+// wrapShutdown returns a ShutdownFunc calling shutdown then stopping to poll the rules file.
+func (ar *attributeRules) wrapShutdown(shutdown component.ShutdownFunc) component.ShutdownFunc {
+	if ar == nil {
+		return shutdown
+	}
+	return func(ctx context.Context) error {
+		err := shutdown.Shutdown(ctx)
+		ar.shutdown()
+		return err
+	}
+}
+
+func (ar *attributeRules) apply(attrs pcommon.Map) {
+	for _, r := range *ar.rules.Load() {
+		r.apply(attrs)
+	}
+}
+
+// wrapLogs returns a ProcessLogsFunc applying the rules to the log records returned by logsFunc.
+func (ar *attributeRules) wrapLogs(logsFunc ProcessLogsFunc) ProcessLogsFunc {
+	if ar == nil {
+		return logsFunc
+	}
+	return func(ctx context.Context, ld plog.Logs) (plog.Logs, error) {
+		ld, err := logsFunc(ctx, ld)
+		if err != nil {
+			return ld, err
+		}
+		rls := ld.ResourceLogs()
+		for i := 0; i < rls.Len(); i++ {
+			sls := rls.At(i).ScopeLogs()
+			for j := 0; j < sls.Len(); j++ {
+				lrs := sls.At(j).LogRecords()
+				for k := 0; k < lrs.Len(); k++ {
+					ar.apply(lrs.At(k).Attributes())
+				}
+			}
+		}
+		return ld, nil
+	}
+}
+
+// wrapMetrics returns a ProcessMetricsFunc applying the rules to the data points returned by metricsFunc.
+func (ar *attributeRules) wrapMetrics(metricsFunc ProcessMetricsFunc) ProcessMetricsFunc {
+	if ar == nil {
+		return metricsFunc
+	}
+	return func(ctx context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+		md, err := metricsFunc(ctx, md)
+		if err != nil {
+			return md, err
+		}
+		rms := md.ResourceMetrics()
+		for i := 0; i < rms.Len(); i++ {
+			sms := rms.At(i).ScopeMetrics()
+			for j := 0; j < sms.Len(); j++ {
+				ms := sms.At(j).Metrics()
+				for k := 0; k < ms.Len(); k++ {
+					ar.applyMetric(ms.At(k))
+				}
+			}
+		}
+		return md, nil
+	}
+}
+
+func (ar *attributeRules) applyMetric(m pmetric.Metric) {
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		for i := 0; i < m.Gauge().DataPoints().Len(); i++ {
+			ar.apply(m.Gauge().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricTypeSum:
+		for i := 0; i < m.Sum().DataPoints().Len(); i++ {
+			ar.apply(m.Sum().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricTypeHistogram:
+		for i := 0; i < m.Histogram().DataPoints().Len(); i++ {
+			ar.apply(m.Histogram().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		for i := 0; i < m.ExponentialHistogram().DataPoints().Len(); i++ {
+			ar.apply(m.ExponentialHistogram().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricTypeSummary:
+		for i := 0; i < m.Summary().DataPoints().Len(); i++ {
+			ar.apply(m.Summary().DataPoints().At(i).Attributes())
+		}
+	}
+}
+
+// wrapTraces returns a ProcessTracesFunc applying the rules to the spans returned by tracesFunc.
+func (ar *attributeRules) wrapTraces(tracesFunc ProcessTracesFunc) ProcessTracesFunc {
+	if ar == nil {
+		return tracesFunc
+	}
+	return func(ctx context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+		td, err := tracesFunc(ctx, td)
+		if err != nil {
+			return td, err
+		}
+		rss := td.ResourceSpans()
+		for i := 0; i < rss.Len(); i++ {
+			sss := rss.At(i).ScopeSpans()
+			for j := 0; j < sss.Len(); j++ {
+				spans := sss.At(j).Spans()
+				for k := 0; k < spans.Len(); k++ {
+					ar.apply(spans.At(k).Attributes())
+				}
+			}
+		}
+		return td, nil
+	}
+}
@@ -0,0 +1,200 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package processorhelper
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor/processortest"
+)
+
+func writeAttributeRules(t *testing.T, path string, rules string) {
+	require.NoError(t, os.WriteFile(path, []byte(rules), 0600))
+}
+
+func newRulesTestLogs() plog.Logs {
+	ld := plog.NewLogs()
+	attrs := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Attributes()
+	attrs.PutStr("http.method", "GET")
+	attrs.PutStr("user.email", "user@example.com")
+	return ld
+}
+
+// consumeRulesTestLogs sends logs to lp, returning the attributes of the log record received by sink.
+func consumeRulesTestLogs(t *testing.T, lp consumer.Logs, sink *consumertest.LogsSink) map[string]any {
+	sink.Reset()
+	require.NoError(t, lp.ConsumeLogs(context.Background(), newRulesTestLogs()))
+	require.Len(t, sink.AllLogs(), 1)
+	return sink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().AsRaw()
+}
+
+func TestAttributeRulesFileReload(t *testing.T) {
+	pollInterval := attributeRulesPollInterval
+	attributeRulesPollInterval = 10 * time.Millisecond
+	t.Cleanup(func() { attributeRulesPollInterval = pollInterval })
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	writeAttributeRules(t, path, `
+rules:
+  - action: rename
+    key: http.method
+    new_key: http.request.method
+`)
+	sink := new(consumertest.LogsSink)
+	lp, err := NewLogsProcessor(context.Background(), processortest.NewNopSettings(), &testLogsCfg, sink,
+		newTestLProcessor(nil), WithAttributeRulesFile(path))
+	require.NoError(t, err)
+	require.NoError(t, lp.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		require.NoError(t, lp.Shutdown(context.Background()))
+	})
+	assert.Equal(t, map[string]any{"http.request.method": "GET", "user.email": "user@example.com"},
+		consumeRulesTestLogs(t, lp, sink))
+
+	writeAttributeRules(t, path, `
+rules:
+  - action: drop
+    key: user.email
+  - action: set
+    key: deployment.environment
+    value: production
+`)
+	want := map[string]any{"http.method": "GET", "deployment.environment": "production"}
+	assert.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual(want, consumeRulesTestLogs(t, lp, sink))
+	}, 10*time.Second, 10*time.Millisecond)
+
+	// An invalid file is ignored, the current rules being kept until the file is fixed.
+	writeAttributeRules(t, path, `
+rules:
+  - action: uppercase
+    key: http.method
+`)
+	time.Sleep(10 * attributeRulesPollInterval)
+	assert.Equal(t, want, consumeRulesTestLogs(t, lp, sink))
+
+	// The file is replaced, as done by editors.
+	replacement := filepath.Join(filepath.Dir(path), "rules.yaml.tmp")
+	writeAttributeRules(t, replacement, "rules: []\n")
+	require.NoError(t, os.Rename(replacement, path))
+	want = map[string]any{"http.method": "GET", "user.email": "user@example.com"}
+	assert.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual(want, consumeRulesTestLogs(t, lp, sink))
+	}, 10*time.Second, 10*time.Millisecond)
+}
+
+func TestAttributeRulesTracesAndMetrics(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	writeAttributeRules(t, path, `
+rules:
+  - action: rename
+    key: old
+    new_key: new
+  - action: set
+    key: env
+    value: test
+`)
+	newAttrs := func(attrs pcommon.Map) {
+		attrs.PutInt("old", 1)
+		attrs.PutStr("new", "overridden")
+	}
+	want := map[string]any{"new": int64(1), "env": "test"}
+
+	tracesSink := new(consumertest.TracesSink)
+	tp, err := NewTracesProcessor(context.Background(), processortest.NewNopSettings(), &testTracesCfg, tracesSink,
+		newTestTProcessor(nil), WithAttributeRulesFile(path), WithCapabilities(consumer.Capabilities{MutatesData: false}))
+	require.NoError(t, err)
+	// The processor mutates the data to apply the rules.
+	assert.True(t, tp.Capabilities().MutatesData)
+	td := ptrace.NewTraces()
+	newAttrs(td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().Attributes())
+	require.NoError(t, tp.ConsumeTraces(context.Background(), td))
+	assert.Equal(t, want, tracesSink.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes().AsRaw())
+
+	metricsSink := new(consumertest.MetricsSink)
+	mp, err := NewMetricsProcessor(context.Background(), processortest.NewNopSettings(), &testMetricsCfg, metricsSink,
+		newTestMProcessor(nil), WithAttributeRulesFile(path))
+	require.NoError(t, err)
+	md := pmetric.NewMetrics()
+	ms := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	newAttrs(ms.AppendEmpty().SetEmptyGauge().DataPoints().AppendEmpty().Attributes())
+	newAttrs(ms.AppendEmpty().SetEmptySum().DataPoints().AppendEmpty().Attributes())
+	newAttrs(ms.AppendEmpty().SetEmptyHistogram().DataPoints().AppendEmpty().Attributes())
+	newAttrs(ms.AppendEmpty().SetEmptyExponentialHistogram().DataPoints().AppendEmpty().Attributes())
+	newAttrs(ms.AppendEmpty().SetEmptySummary().DataPoints().AppendEmpty().Attributes())
+	require.NoError(t, mp.ConsumeMetrics(context.Background(), md))
+	got := metricsSink.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	assert.Equal(t, want, got.At(0).Gauge().DataPoints().At(0).Attributes().AsRaw())
+	assert.Equal(t, want, got.At(1).Sum().DataPoints().At(0).Attributes().AsRaw())
+	assert.Equal(t, want, got.At(2).Histogram().DataPoints().At(0).Attributes().AsRaw())
+	assert.Equal(t, want, got.At(3).ExponentialHistogram().DataPoints().At(0).Attributes().AsRaw())
+	assert.Equal(t, want, got.At(4).Summary().DataPoints().At(0).Attributes().AsRaw())
+}
+
+func TestAttributeRulesFileInvalid(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name    string
+		rules   string
+		wantErr string
+	}{
+		{
+			name:    "unknown_action",
+			rules:   "rules:\n  - action: uppercase\n    key: a\n",
+			wantErr: `unknown action "uppercase" of the "a" attribute`,
+		},
+		{
+			name:    "missing_key",
+			rules:   "rules:\n  - action: drop\n",
+			wantErr: "missing key",
+		},
+		{
+			name:    "missing_new_key",
+			rules:   "rules:\n  - action: rename\n    key: a\n",
+			wantErr: `missing new_key of the "a" attribute`,
+		},
+		{
+			name:    "same_new_key",
+			rules:   "rules:\n  - action: rename\n    key: a\n    new_key: a\n",
+			wantErr: `new_key of the "a" attribute must differ from its key`,
+		},
+		{
+			name:    "unknown_field",
+			rules:   "rules:\n  - action: drop\n    name: a\n",
+			wantErr: "field name not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, tt.name+".yaml")
+			writeAttributeRules(t, path, tt.rules)
+			_, err := NewLogsProcessor(context.Background(), processortest.NewNopSettings(), &testLogsCfg,
+				consumertest.NewNop(), newTestLProcessor(nil), WithAttributeRulesFile(path))
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+
+	_, err := NewLogsProcessor(context.Background(), processortest.NewNopSettings(), &testLogsCfg,
+		consumertest.NewNop(), newTestLProcessor(nil), WithAttributeRulesFile(filepath.Join(dir, "missing.yaml")))
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	path := filepath.Join(dir, "empty.yaml")
+	writeAttributeRules(t, path, "# No rules.\n")
+	_, err = NewLogsProcessor(context.Background(), processortest.NewNopSettings(), &testLogsCfg,
+		consumertest.NewNop(), newTestLProcessor(nil), WithAttributeRulesFile(path))
+	require.ErrorIs(t, err, errEmptyAttributeRules)
+}
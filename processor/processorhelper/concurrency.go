@@ -0,0 +1,242 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package processorhelper // import "go.opentelemetry.io/collector/processor/processorhelper"
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"go.uber.org/multierr"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// concurrency runs the process functions on the data of every resource concurrently.
+type concurrency struct {
+	obs     *ObsReport
+	workers int
+	// copyShards copies the resources of the incoming data to the shards instead of moving them, when
+	// the processor does not mutate data.
+	copyShards bool
+}
+
+// newConcurrency returns a concurrency, or nil if the data must be processed at once.
+func newConcurrency(obs *ObsReport, workers int, mutatesData bool) *concurrency {
+	if workers <= 1 {
+		return nil
+	}
+	return &concurrency{obs: obs, workers: workers, copyShards: !mutatesData}
+}
+
+// processShards runs f on every shard with up to workers goroutines, and returns the results of the
+// shards in order, with whether each shard was skipped with ErrSkipProcessingData. The other errors
+// are combined. A panic of f is raised again in the calling goroutine once all the shards are done.
+func processShards[T any](ctx context.Context, workers int, shards []T, f func(context.Context, T) (T, error)) ([]T, []bool, error) {
+	results := make([]T, len(shards))
+	errs := make([]error, len(shards))
+	var (
+		wg       sync.WaitGroup
+		panicMu  sync.Mutex
+		panicked bool
+		panicVal any
+	)
+	sem := make(chan struct{}, workers)
+	for i := range shards {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int) {
+			defer func() {
+				if v := recover(); v != nil {
+					panicMu.Lock()
+					if !panicked {
+						panicked, panicVal = true, v
+					}
+					panicMu.Unlock()
+				}
+				<-sem
+				wg.Done()
+			}()
+			results[i], errs[i] = f(ctx, shards[i])
+		}(i)
+	}
+	wg.Wait()
+	if panicked {
+		panic(panicVal)
+	}
+
+	skipped := make([]bool, len(shards))
+	var err error
+	for i, shardErr := range errs {
+		switch {
+		case shardErr == nil:
+		case errors.Is(shardErr, ErrSkipProcessingData):
+			skipped[i] = true
+		default:
+			err = multierr.Append(err, shardErr)
+		}
+	}
+	return results, skipped, err
+}
+
+// discard records the items of the skipped shards, whose incoming items are recorded with the other
+// shards, and returns ErrSkipProcessingData if all the shards were skipped.
+func (c *concurrency) discard(ctx context.Context, dataType component.DataType, counts []int, skipped []bool) error {
+	discarded, kept := 0, 0
+	for i, count := range counts {
+		if skipped[i] {
+			discarded += count
+		} else {
+			kept++
+		}
+	}
+	if kept == 0 {
+		return ErrSkipProcessingData
+	}
+	if discarded > 0 {
+		c.obs.recordDiscarded(ctx, dataType, 0, discarded)
+	}
+	return nil
+}
+
+// wrapLogs returns a ProcessLogsFunc running logsFunc on the data of every resource concurrently.
+func (c *concurrency) wrapLogs(logsFunc ProcessLogsFunc) ProcessLogsFunc {
+	if c == nil {
+		return logsFunc
+	}
+	return func(ctx context.Context, ld plog.Logs) (plog.Logs, error) {
+		rls := ld.ResourceLogs()
+		if rls.Len() < 2 {
+			return logsFunc(ctx, ld)
+		}
+		shards := make([]plog.Logs, rls.Len())
+		counts := make([]int, rls.Len())
+		for i := range shards {
+			shards[i] = plog.NewLogs()
+			if c.copyShards {
+				rls.At(i).CopyTo(shards[i].ResourceLogs().AppendEmpty())
+			} else {
+				rls.At(i).MoveTo(shards[i].ResourceLogs().AppendEmpty())
+			}
+			counts[i] = shards[i].LogRecordCount()
+		}
+
+		results, skipped, err := processShards(ctx, c.workers, shards, logsFunc)
+		if err == nil {
+			err = c.discard(ctx, component.DataTypeLogs, counts, skipped)
+		}
+		if err != nil {
+			if !c.copyShards {
+				// Give the moved resources back so that the data is returned whole.
+				ld.ResourceLogs().RemoveIf(func(plog.ResourceLogs) bool { return true })
+				for _, shard := range shards {
+					shard.ResourceLogs().MoveAndAppendTo(ld.ResourceLogs())
+				}
+			}
+			return ld, err
+		}
+		out := plog.NewLogs()
+		for i, result := range results {
+			if !skipped[i] {
+				result.ResourceLogs().MoveAndAppendTo(out.ResourceLogs())
+			}
+		}
+		return out, nil
+	}
+}
+
+// wrapMetrics returns a ProcessMetricsFunc running metricsFunc on the data of every resource concurrently.
+func (c *concurrency) wrapMetrics(metricsFunc ProcessMetricsFunc) ProcessMetricsFunc {
+	if c == nil {
+		return metricsFunc
+	}
+	return func(ctx context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+		rms := md.ResourceMetrics()
+		if rms.Len() < 2 {
+			return metricsFunc(ctx, md)
+		}
+		shards := make([]pmetric.Metrics, rms.Len())
+		counts := make([]int, rms.Len())
+		for i := range shards {
+			shards[i] = pmetric.NewMetrics()
+			if c.copyShards {
+				rms.At(i).CopyTo(shards[i].ResourceMetrics().AppendEmpty())
+			} else {
+				rms.At(i).MoveTo(shards[i].ResourceMetrics().AppendEmpty())
+			}
+			counts[i] = shards[i].DataPointCount()
+		}
+
+		results, skipped, err := processShards(ctx, c.workers, shards, metricsFunc)
+		if err == nil {
+			err = c.discard(ctx, component.DataTypeMetrics, counts, skipped)
+		}
+		if err != nil {
+			if !c.copyShards {
+				// Give the moved resources back so that the data is returned whole.
+				md.ResourceMetrics().RemoveIf(func(pmetric.ResourceMetrics) bool { return true })
+				for _, shard := range shards {
+					shard.ResourceMetrics().MoveAndAppendTo(md.ResourceMetrics())
+				}
+			}
+			return md, err
+		}
+		out := pmetric.NewMetrics()
+		for i, result := range results {
+			if !skipped[i] {
+				result.ResourceMetrics().MoveAndAppendTo(out.ResourceMetrics())
+			}
+		}
+		return out, nil
+	}
+}
+
+// wrapTraces returns a ProcessTracesFunc running tracesFunc on the data of every resource concurrently.
+func (c *concurrency) wrapTraces(tracesFunc ProcessTracesFunc) ProcessTracesFunc {
+	if c == nil {
+		return tracesFunc
+	}
+	return func(ctx context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+		rss := td.ResourceSpans()
+		if rss.Len() < 2 {
+			return tracesFunc(ctx, td)
+		}
+		shards := make([]ptrace.Traces, rss.Len())
+		counts := make([]int, rss.Len())
+		for i := range shards {
+			shards[i] = ptrace.NewTraces()
+			if c.copyShards {
+				rss.At(i).CopyTo(shards[i].ResourceSpans().AppendEmpty())
+			} else {
+				rss.At(i).MoveTo(shards[i].ResourceSpans().AppendEmpty())
+			}
+			counts[i] = shards[i].SpanCount()
+		}
+
+		results, skipped, err := processShards(ctx, c.workers, shards, tracesFunc)
+		if err == nil {
+			err = c.discard(ctx, component.DataTypeTraces, counts, skipped)
+		}
+		if err != nil {
+			if !c.copyShards {
+				// Give the moved resources back so that the data is returned whole.
+				td.ResourceSpans().RemoveIf(func(ptrace.ResourceSpans) bool { return true })
+				for _, shard := range shards {
+					shard.ResourceSpans().MoveAndAppendTo(td.ResourceSpans())
+				}
+			}
+			return td, err
+		}
+		out := ptrace.NewTraces()
+		for i, result := range results {
+			if !skipped[i] {
+				result.ResourceSpans().MoveAndAppendTo(out.ResourceSpans())
+			}
+		}
+		return out, nil
+	}
+}
@@ -0,0 +1,236 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package processorhelper
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/multierr"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor/processortest"
+)
+
+// newResourceMetrics returns metrics with one resource per name, each with one gauge data point.
+func newResourceMetrics(names ...string) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	for _, name := range names {
+		rm := md.ResourceMetrics().AppendEmpty()
+		rm.Resource().Attributes().PutStr("service.name", name)
+		rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptyGauge().DataPoints().AppendEmpty()
+	}
+	return md
+}
+
+func resourceNames(md pmetric.Metrics) []string {
+	var names []string
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		name, _ := md.ResourceMetrics().At(i).Resource().Attributes().Get("service.name")
+		names = append(names, name.Str())
+	}
+	return names
+}
+
+func TestMetricsProcessor_Concurrency(t *testing.T) {
+	var inFlight, maxInFlight, calls atomic.Int32
+	metricsFunc := func(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+		calls.Add(1)
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			m := maxInFlight.Load()
+			if n <= m || maxInFlight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		if md.ResourceMetrics().Len() != 1 {
+			return md, errors.New("expected a single resource")
+		}
+		md.ResourceMetrics().At(0).Resource().Attributes().PutBool("processed", true)
+		return md, nil
+	}
+
+	set, metricReader := newTestSettingsWithMetricReader()
+	sink := new(consumertest.MetricsSink)
+	mp, err := NewMetricsProcessor(context.Background(), set, &testMetricsCfg, sink, metricsFunc, WithConcurrency(2))
+	require.NoError(t, err)
+
+	require.NoError(t, mp.ConsumeMetrics(context.Background(), newResourceMetrics("a", "b", "c", "d", "e")))
+	assert.Equal(t, int32(5), calls.Load())
+	assert.LessOrEqual(t, maxInFlight.Load(), int32(2))
+	require.Len(t, sink.AllMetrics(), 1)
+	out := sink.AllMetrics()[0]
+	assert.Equal(t, []string{"a", "b", "c", "d", "e"}, resourceNames(out))
+	for i := 0; i < out.ResourceMetrics().Len(); i++ {
+		_, ok := out.ResourceMetrics().At(i).Resource().Attributes().Get("processed")
+		assert.True(t, ok)
+	}
+
+	sums := metricSums(t, metricReader)
+	assert.Equal(t, int64(5), sums["otelcol_processor_incoming_metric_points"])
+	assert.Equal(t, int64(5), sums["otelcol_processor_outgoing_metric_points"])
+}
+
+func TestMetricsProcessor_ConcurrencyErrors(t *testing.T) {
+	metricsFunc := func(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+		name, _ := md.ResourceMetrics().At(0).Resource().Attributes().Get("service.name")
+		switch name.Str() {
+		case "b", "d":
+			return md, errors.New("failed " + name.Str())
+		}
+		return md, nil
+	}
+	sink := new(consumertest.MetricsSink)
+	mp, err := NewMetricsProcessor(context.Background(), processortest.NewNopSettings(), &testMetricsCfg, sink, metricsFunc, WithConcurrency(4))
+	require.NoError(t, err)
+
+	md := newResourceMetrics("a", "b", "c", "d")
+	err = mp.ConsumeMetrics(context.Background(), md)
+	assert.Equal(t, []error{errors.New("failed b"), errors.New("failed d")}, multierr.Errors(err))
+	assert.Empty(t, sink.AllMetrics())
+	// The data is left whole for the caller to retry.
+	assert.Equal(t, newResourceMetrics("a", "b", "c", "d"), md)
+}
+
+func TestMetricsProcessor_ConcurrencySkipProcessingData(t *testing.T) {
+	metricsFunc := func(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+		name, _ := md.ResourceMetrics().At(0).Resource().Attributes().Get("service.name")
+		if name.Str() == "b" {
+			return md, ErrSkipProcessingData
+		}
+		return md, nil
+	}
+	set, metricReader := newTestSettingsWithMetricReader()
+	sink := new(consumertest.MetricsSink)
+	mp, err := NewMetricsProcessor(context.Background(), set, &testMetricsCfg, sink, metricsFunc, WithConcurrency(4))
+	require.NoError(t, err)
+
+	require.NoError(t, mp.ConsumeMetrics(context.Background(), newResourceMetrics("a", "b", "c")))
+	require.Len(t, sink.AllMetrics(), 1)
+	assert.Equal(t, []string{"a", "c"}, resourceNames(sink.AllMetrics()[0]))
+
+	// Data skipped for all the resources is discarded as a whole.
+	require.NoError(t, mp.ConsumeMetrics(context.Background(), newResourceMetrics("b", "b")))
+	assert.Len(t, sink.AllMetrics(), 1)
+
+	sums := metricSums(t, metricReader)
+	assert.Equal(t, int64(5), sums["otelcol_processor_incoming_metric_points"])
+	assert.Equal(t, int64(2), sums["otelcol_processor_outgoing_metric_points"])
+	assert.Equal(t, int64(3), sums["otelcol_processor_discarded_metric_points"])
+}
+
+func TestMetricsProcessor_ConcurrencyDisabled(t *testing.T) {
+	for _, workers := range []int{0, 1} {
+		t.Run(strconv.Itoa(workers), func(t *testing.T) {
+			var resources []int
+			metricsFunc := func(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+				resources = append(resources, md.ResourceMetrics().Len())
+				return md, nil
+			}
+			mp, err := NewMetricsProcessor(context.Background(), processortest.NewNopSettings(), &testMetricsCfg, consumertest.NewNop(), metricsFunc, WithConcurrency(workers))
+			require.NoError(t, err)
+			require.NoError(t, mp.ConsumeMetrics(context.Background(), newResourceMetrics("a", "b", "c")))
+			assert.Equal(t, []int{3}, resources)
+		})
+	}
+}
+
+func TestMetricsProcessor_ConcurrencyNotMutatingData(t *testing.T) {
+	metricsFunc := func(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+		return md, nil
+	}
+	sink := new(consumertest.MetricsSink)
+	mp, err := NewMetricsProcessor(context.Background(), processortest.NewNopSettings(), &testMetricsCfg, sink, metricsFunc,
+		WithConcurrency(2), WithCapabilities(consumer.Capabilities{MutatesData: false}))
+	require.NoError(t, err)
+
+	md := newResourceMetrics("a", "b")
+	require.NoError(t, mp.ConsumeMetrics(context.Background(), md))
+	assert.Equal(t, []string{"a", "b"}, resourceNames(md))
+	assert.Equal(t, 2, md.DataPointCount())
+	require.Len(t, sink.AllMetrics(), 1)
+	assert.Equal(t, []string{"a", "b"}, resourceNames(sink.AllMetrics()[0]))
+}
+
+func TestMetricsProcessor_ConcurrencyPanicRecovery(t *testing.T) {
+	metricsFunc := func(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+		name, _ := md.ResourceMetrics().At(0).Resource().Attributes().Get("service.name")
+		if name.Str() == "b" {
+			panic("malformed data")
+		}
+		return md, nil
+	}
+	set, metricReader := newTestSettingsWithMetricReader()
+	mp, err := NewMetricsProcessor(context.Background(), set, &testMetricsCfg, consumertest.NewNop(), metricsFunc,
+		WithConcurrency(2), WithPanicRecovery())
+	require.NoError(t, err)
+
+	err = mp.ConsumeMetrics(context.Background(), newResourceMetrics("a", "b", "c"))
+	var panicErr *PanicError
+	require.ErrorAs(t, err, &panicErr)
+	assert.Equal(t, "malformed data", panicErr.Value)
+	assert.Equal(t, int64(3), metricSums(t, metricReader)["otelcol_processor_incoming_metric_points"])
+}
+
+func TestLogsProcessor_Concurrency(t *testing.T) {
+	var calls atomic.Int32
+	logsFunc := func(_ context.Context, ld plog.Logs) (plog.Logs, error) {
+		calls.Add(1)
+		if ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().Str() == "skip" {
+			return ld, ErrSkipProcessingData
+		}
+		return ld, nil
+	}
+	sink := new(consumertest.LogsSink)
+	lp, err := NewLogsProcessor(context.Background(), processortest.NewNopSettings(), &testLogsCfg, sink, logsFunc, WithConcurrency(2))
+	require.NoError(t, err)
+
+	ld := plog.NewLogs()
+	for _, body := range []string{"a", "skip", "b"} {
+		ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr(body)
+	}
+	require.NoError(t, lp.ConsumeLogs(context.Background(), ld))
+	assert.Equal(t, int32(3), calls.Load())
+	require.Len(t, sink.AllLogs(), 1)
+	out := sink.AllLogs()[0]
+	require.Equal(t, 2, out.ResourceLogs().Len())
+	assert.Equal(t, "a", out.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().Str())
+	assert.Equal(t, "b", out.ResourceLogs().At(1).ScopeLogs().At(0).LogRecords().At(0).Body().Str())
+}
+
+func TestTracesProcessor_Concurrency(t *testing.T) {
+	var calls atomic.Int32
+	tracesFunc := func(_ context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+		calls.Add(1)
+		if td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Name() == "fail" {
+			return td, errors.New("failed")
+		}
+		return td, nil
+	}
+	tp, err := NewTracesProcessor(context.Background(), processortest.NewNopSettings(), &testTracesCfg, consumertest.NewNop(), tracesFunc, WithConcurrency(2))
+	require.NoError(t, err)
+
+	td := ptrace.NewTraces()
+	for _, name := range []string{"a", "fail", "b"} {
+		td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName(name)
+	}
+	assert.EqualError(t, tp.ConsumeTraces(context.Background(), td), "failed")
+	assert.Equal(t, int32(3), calls.Load())
+	require.Equal(t, 3, td.ResourceSpans().Len())
+	for i, name := range []string{"a", "fail", "b"} {
+		assert.Equal(t, name, td.ResourceSpans().At(i).ScopeSpans().At(0).Spans().At(0).Name())
+	}
+}
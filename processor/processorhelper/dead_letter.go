@@ -0,0 +1,157 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package processorhelper // import "go.opentelemetry.io/collector/processor/processorhelper"
+
+import (
+	"context"
+	"errors"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumerdeadletter"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor"
+)
+
+// skipReason is the dead-letter reason of the data skipped with ErrSkipProcessingData itself.
+const skipReason = "processing skipped"
+
+// deadLetter sends the data dropped by the process functions to the dead-letter consumers.
+type deadLetter struct {
+	// consumers are the ones given with WithDeadLetter, or else the ones of the host, set on start.
+	consumers consumerdeadletter.Consumers
+	fromHost  bool
+	logger    *zap.Logger
+	// copyData copies the data sent to the dead-letter consumers mutating it, when the processor
+	// does not mutate data and so does not own it.
+	copyData bool
+}
+
+// newDeadLetter returns a deadLetter sending the dropped data to the consumers given with WithDeadLetter, or else
+// to the dead-letter consumers the host provides on start, if any.
+func newDeadLetter(bs *baseSettings, set processor.Settings) *deadLetter {
+	dl := &deadLetter{logger: set.Logger, copyData: !bs.mutatesData}
+	if bs.deadLetter != nil {
+		dl.consumers = *bs.deadLetter
+	} else {
+		dl.fromHost = true
+	}
+	return dl
+}
+
+// wrapStart returns a StartFunc getting the dead-letter consumers from the host, if not given with WithDeadLetter,
+// before calling start.
+func (dl *deadLetter) wrapStart(start component.StartFunc) component.StartFunc {
+	if !dl.fromHost {
+		return start
+	}
+	return func(ctx context.Context, host component.Host) error {
+		if provider, ok := host.(consumerdeadletter.Provider); ok {
+			if consumers := provider.DeadLetterConsumers(); consumers != nil {
+				dl.consumers = *consumers
+			}
+		}
+		return start.Start(ctx, host)
+	}
+}
+
+// reason returns the dead-letter reason of the data skipped with err, the message of the error if it
+// wraps ErrSkipProcessingData.
+func (dl *deadLetter) reason(err error) string {
+	if err == ErrSkipProcessingData { //nolint:errorlint
+		return skipReason
+	}
+	return err.Error()
+}
+
+func (dl *deadLetter) logFailure(reason string, err error) {
+	if err != nil {
+		dl.logger.Warn("Failed to send the dropped data to the dead-letter consumer",
+			zap.String("reason", reason), zap.Error(err))
+	}
+}
+
+// sendTraces sends the traces dropped for reason to the dead-letter consumer, copying them if the processor does
+// not own them.
+func (dl *deadLetter) sendTraces(ctx context.Context, td ptrace.Traces, reason string) {
+	if dl.copyData && dl.consumers.Traces.Capabilities().MutatesData {
+		cp := ptrace.NewTraces()
+		td.CopyTo(cp)
+		td = cp
+	}
+	dl.logFailure(reason, dl.consumers.SendTraces(ctx, td, reason))
+}
+
+// sendMetrics sends the metrics dropped for reason to the dead-letter consumer, copying them if the processor
+// does not own them.
+func (dl *deadLetter) sendMetrics(ctx context.Context, md pmetric.Metrics, reason string) {
+	if dl.copyData && dl.consumers.Metrics.Capabilities().MutatesData {
+		cp := pmetric.NewMetrics()
+		md.CopyTo(cp)
+		md = cp
+	}
+	dl.logFailure(reason, dl.consumers.SendMetrics(ctx, md, reason))
+}
+
+// sendLogs sends the logs dropped for reason to the dead-letter consumer, copying them if the processor does not
+// own them.
+func (dl *deadLetter) sendLogs(ctx context.Context, ld plog.Logs, reason string) {
+	if dl.copyData && dl.consumers.Logs.Capabilities().MutatesData {
+		cp := plog.NewLogs()
+		ld.CopyTo(cp)
+		ld = cp
+	}
+	dl.logFailure(reason, dl.consumers.SendLogs(ctx, ld, reason))
+}
+
+// wrapTraces returns a ProcessTracesFunc sending the data skipped by tracesFunc to the dead-letter consumer.
+func (dl *deadLetter) wrapTraces(tracesFunc ProcessTracesFunc) ProcessTracesFunc {
+	return func(ctx context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+		out, err := tracesFunc(ctx, td)
+		if errors.Is(err, ErrSkipProcessingData) && dl.hasTraces() {
+			dl.sendTraces(ctx, td, dl.reason(err))
+		}
+		return out, err
+	}
+}
+
+// wrapMetrics returns a ProcessMetricsFunc sending the data skipped by metricsFunc to the dead-letter consumer.
+func (dl *deadLetter) wrapMetrics(metricsFunc ProcessMetricsFunc) ProcessMetricsFunc {
+	return func(ctx context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+		out, err := metricsFunc(ctx, md)
+		if errors.Is(err, ErrSkipProcessingData) && dl.hasMetrics() {
+			dl.sendMetrics(ctx, md, dl.reason(err))
+		}
+		return out, err
+	}
+}
+
+// wrapLogs returns a ProcessLogsFunc sending the data skipped by logsFunc to the dead-letter consumer.
+func (dl *deadLetter) wrapLogs(logsFunc ProcessLogsFunc) ProcessLogsFunc {
+	return func(ctx context.Context, ld plog.Logs) (plog.Logs, error) {
+		out, err := logsFunc(ctx, ld)
+		if errors.Is(err, ErrSkipProcessingData) && dl.hasLogs() {
+			dl.sendLogs(ctx, ld, dl.reason(err))
+		}
+		return out, err
+	}
+}
+
+// hasTraces returns whether the dropped traces are sent to a dead-letter consumer.
+func (dl *deadLetter) hasTraces() bool {
+	return dl.consumers.Traces != nil
+}
+
+// hasMetrics returns whether the dropped metrics are sent to a dead-letter consumer.
+func (dl *deadLetter) hasMetrics() bool {
+	return dl.consumers.Metrics != nil
+}
+
+// hasLogs returns whether the dropped logs are sent to a dead-letter consumer.
+func (dl *deadLetter) hasLogs() bool {
+	return dl.consumers.Logs != nil
+}
@@ -0,0 +1,267 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package processorhelper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumerdeadletter"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor/processortest"
+)
+
+// deadLetterSink records the data sent to the dead-letter consumers with their reasons.
+type deadLetterSink struct {
+	consumerdeadletter.Consumers
+	reasons []string
+	traces  []ptrace.Traces
+	metrics []pmetric.Metrics
+	logs    []plog.Logs
+}
+
+func newDeadLetterSink(t *testing.T, err error) *deadLetterSink {
+	dls := &deadLetterSink{}
+	var cerr error
+	dls.Traces, cerr = consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
+		dls.reasons = append(dls.reasons, consumerdeadletter.ReasonFromContext(ctx))
+		dls.traces = append(dls.traces, td)
+		return err
+	}, consumer.WithCapabilities(consumer.Capabilities{MutatesData: true}))
+	require.NoError(t, cerr)
+	dls.Metrics, cerr = consumer.NewMetrics(func(ctx context.Context, md pmetric.Metrics) error {
+		dls.reasons = append(dls.reasons, consumerdeadletter.ReasonFromContext(ctx))
+		dls.metrics = append(dls.metrics, md)
+		return err
+	})
+	require.NoError(t, cerr)
+	dls.Logs, cerr = consumer.NewLogs(func(ctx context.Context, ld plog.Logs) error {
+		dls.reasons = append(dls.reasons, consumerdeadletter.ReasonFromContext(ctx))
+		dls.logs = append(dls.logs, ld)
+		return err
+	})
+	require.NoError(t, cerr)
+	return dls
+}
+
+func TestProcessorDeadLetterSkipped(t *testing.T) {
+	dls := newDeadLetterSink(t, nil)
+	skipFiltered := fmt.Errorf("%w: filtered", ErrSkipProcessingData)
+
+	tp, err := NewTracesProcessor(context.Background(), processortest.NewNopSettings(), &testTracesCfg, consumertest.NewNop(),
+		func(context.Context, ptrace.Traces) (ptrace.Traces, error) {
+			return ptrace.Traces{}, ErrSkipProcessingData
+		},
+		WithDeadLetter(dls.Consumers), WithCapabilities(consumer.Capabilities{MutatesData: false}))
+	require.NoError(t, err)
+	mp, err := NewMetricsProcessor(context.Background(), processortest.NewNopSettings(), &testMetricsCfg, consumertest.NewNop(),
+		func(context.Context, pmetric.Metrics) (pmetric.Metrics, error) {
+			return pmetric.Metrics{}, skipFiltered
+		},
+		WithDeadLetter(dls.Consumers))
+	require.NoError(t, err)
+	lp, err := NewLogsProcessor(context.Background(), processortest.NewNopSettings(), &testLogsCfg, consumertest.NewNop(),
+		func(context.Context, plog.Logs) (plog.Logs, error) { return plog.Logs{}, ErrSkipProcessingData },
+		WithDeadLetter(dls.Consumers))
+	require.NoError(t, err)
+
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("span")
+	md := pmetric.NewMetrics()
+	md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptyGauge().DataPoints().AppendEmpty()
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	require.NoError(t, tp.ConsumeTraces(context.Background(), td))
+	require.NoError(t, mp.ConsumeMetrics(context.Background(), md))
+	require.NoError(t, lp.ConsumeLogs(context.Background(), ld))
+
+	assert.Equal(t, []string{skipReason, "sentinel error to skip processing data from the remainder of the pipeline: filtered", skipReason}, dls.reasons)
+	// The traces are copied for the dead-letter consumer mutating them, the processor not owning them.
+	require.Len(t, dls.traces, 1)
+	assert.Equal(t, td, dls.traces[0])
+	dls.traces[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).SetName("mutated")
+	assert.Equal(t, "span", td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Name())
+	require.Len(t, dls.metrics, 1)
+	assert.Equal(t, md, dls.metrics[0])
+	require.Len(t, dls.logs, 1)
+	assert.Equal(t, ld, dls.logs[0])
+}
+
+func TestProcessorDeadLetterNotConfigured(t *testing.T) {
+	dls := newDeadLetterSink(t, nil)
+	// Only the logs are sent to a dead-letter consumer.
+	tp, err := NewTracesProcessor(context.Background(), processortest.NewNopSettings(), &testTracesCfg, consumertest.NewNop(),
+		func(context.Context, ptrace.Traces) (ptrace.Traces, error) {
+			return ptrace.Traces{}, ErrSkipProcessingData
+		},
+		WithDeadLetter(consumerdeadletter.Consumers{Logs: dls.Logs}))
+	require.NoError(t, err)
+	require.NoError(t, tp.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+
+	// The processing errors other than ErrSkipProcessingData are returned.
+	lp, err := NewLogsProcessor(context.Background(), processortest.NewNopSettings(), &testLogsCfg, consumertest.NewNop(),
+		func(context.Context, plog.Logs) (plog.Logs, error) { return plog.Logs{}, errors.New("failed") },
+		WithDeadLetter(dls.Consumers))
+	require.NoError(t, err)
+	require.EqualError(t, lp.ConsumeLogs(context.Background(), plog.NewLogs()), "failed")
+	assert.Empty(t, dls.reasons)
+}
+
+// deadLetterHost is a host providing dead-letter consumers.
+type deadLetterHost struct {
+	component.Host
+	consumers *consumerdeadletter.Consumers
+}
+
+func (h *deadLetterHost) DeadLetterConsumers() *consumerdeadletter.Consumers {
+	return h.consumers
+}
+
+func TestProcessorDeadLetterHost(t *testing.T) {
+	// The dead-letter consumers of the host are used unless WithDeadLetter is given.
+	dls := newDeadLetterSink(t, nil)
+	host := &deadLetterHost{Host: componenttest.NewNopHost(), consumers: &dls.Consumers}
+	mp, err := NewMetricsProcessor(context.Background(), processortest.NewNopSettings(), &testMetricsCfg, consumertest.NewNop(),
+		func(context.Context, pmetric.Metrics) (pmetric.Metrics, error) {
+			return pmetric.Metrics{}, ErrSkipProcessingData
+		})
+	require.NoError(t, err)
+	require.NoError(t, mp.Start(context.Background(), host))
+	require.NoError(t, mp.ConsumeMetrics(context.Background(), pmetric.NewMetrics()))
+	assert.Equal(t, []string{skipReason}, dls.reasons)
+
+	other := newDeadLetterSink(t, nil)
+	lp, err := NewLogsProcessor(context.Background(), processortest.NewNopSettings(), &testLogsCfg, consumertest.NewNop(),
+		func(context.Context, plog.Logs) (plog.Logs, error) { return plog.Logs{}, ErrSkipProcessingData },
+		WithDeadLetter(other.Consumers))
+	require.NoError(t, err)
+	require.NoError(t, lp.Start(context.Background(), host))
+	require.NoError(t, lp.ConsumeLogs(context.Background(), plog.NewLogs()))
+	assert.Equal(t, []string{skipReason}, other.reasons)
+	assert.Len(t, dls.reasons, 1)
+
+	// The data is discarded if the host provides no dead-letter consumers.
+	tp, err := NewTracesProcessor(context.Background(), processortest.NewNopSettings(), &testTracesCfg, consumertest.NewNop(),
+		func(context.Context, ptrace.Traces) (ptrace.Traces, error) {
+			return ptrace.Traces{}, ErrSkipProcessingData
+		})
+	require.NoError(t, err)
+	require.NoError(t, tp.Start(context.Background(), &deadLetterHost{Host: componenttest.NewNopHost()}))
+	require.NoError(t, tp.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+	assert.Len(t, dls.reasons, 1)
+}
+
+func TestProcessorDeadLetterFailure(t *testing.T) {
+	// The failures of the dead-letter consumer are not returned.
+	dls := newDeadLetterSink(t, errors.New("unavailable"))
+	lp, err := NewLogsProcessor(context.Background(), processortest.NewNopSettings(), &testLogsCfg, consumertest.NewNop(),
+		func(context.Context, plog.Logs) (plog.Logs, error) { return plog.Logs{}, ErrSkipProcessingData },
+		WithDeadLetter(dls.Consumers))
+	require.NoError(t, err)
+	require.NoError(t, lp.ConsumeLogs(context.Background(), plog.NewLogs()))
+	assert.Equal(t, []string{skipReason}, dls.reasons)
+}
+
+func TestProcessorDeadLetterConcurrency(t *testing.T) {
+	dls := newDeadLetterSink(t, nil)
+	ld := plog.NewLogs()
+	for _, name := range []string{"keep", "skip", "keep"} {
+		ld.ResourceLogs().AppendEmpty().Resource().Attributes().PutStr("name", name)
+	}
+	sink := new(consumertest.LogsSink)
+	lp, err := NewLogsProcessor(context.Background(), processortest.NewNopSettings(), &testLogsCfg, sink,
+		func(_ context.Context, ld plog.Logs) (plog.Logs, error) {
+			if name, _ := ld.ResourceLogs().At(0).Resource().Attributes().Get("name"); name.Str() == "skip" {
+				return ld, ErrSkipProcessingData
+			}
+			return ld, nil
+		}, WithConcurrency(2), WithDeadLetter(dls.Consumers))
+	require.NoError(t, err)
+	require.NoError(t, lp.ConsumeLogs(context.Background(), ld))
+
+	// Only the skipped resource is sent to the dead-letter consumer.
+	require.Len(t, dls.logs, 1)
+	assert.Equal(t, 1, dls.logs[0].ResourceLogs().Len())
+	name, _ := dls.logs[0].ResourceLogs().At(0).Resource().Attributes().Get("name")
+	assert.Equal(t, "skip", name.Str())
+	require.Len(t, sink.AllLogs(), 1)
+	assert.Equal(t, 2, sink.AllLogs()[0].ResourceLogs().Len())
+}
+
+func TestTracesProcessorWithDropsDeadLetter(t *testing.T) {
+	td := ptrace.NewTraces()
+	for i, names := range [][]string{{"keep-1", "rate-1", "error-1", "rate-2"}, {"rate-3"}} {
+		rs := td.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().PutInt("resource", int64(i))
+		ss := rs.ScopeSpans().AppendEmpty()
+		ss.Scope().SetName("scope")
+		for _, name := range names {
+			ss.Spans().AppendEmpty().SetName(name)
+		}
+	}
+	dropFunc := func(_ context.Context, td ptrace.Traces, drops *SpanDrops) (ptrace.Traces, error) {
+		rss := td.ResourceSpans()
+		for i := 0; i < rss.Len(); i++ {
+			spans := rss.At(i).ScopeSpans().At(0).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				switch spans.At(k).Name()[:4] {
+				case "rate":
+					drops.Drop(spans.At(k), "rate_limited")
+				case "erro":
+					drops.Drop(spans.At(k), "probabilistic")
+				}
+			}
+		}
+		return td, nil
+	}
+
+	dls := newDeadLetterSink(t, nil)
+	sink := new(consumertest.TracesSink)
+	tp, err := NewTracesProcessorWithDrops(context.Background(), processortest.NewNopSettings(), &testTracesCfg, sink, dropFunc,
+		WithDeadLetter(dls.Consumers))
+	require.NoError(t, err)
+	require.NoError(t, tp.ConsumeTraces(context.Background(), td))
+	assert.Equal(t, 1, sink.SpanCount())
+
+	// The dropped spans are sent by reason, in order, with their resource and scope.
+	assert.Equal(t, []string{"probabilistic", "rate_limited"}, dls.reasons)
+	require.Len(t, dls.traces, 2)
+	assert.Equal(t, []string{"error-1"}, spanNamesByResource(dls.traces[0])[0])
+	assert.Equal(t, [][]string{{"rate-1", "rate-2"}, {"rate-3"}}, spanNamesByResource(dls.traces[1]))
+	for _, dropped := range dls.traces {
+		for i := 0; i < dropped.ResourceSpans().Len(); i++ {
+			rs := dropped.ResourceSpans().At(i)
+			assert.Equal(t, "scope", rs.ScopeSpans().At(0).Scope().Name())
+			_, ok := rs.Resource().Attributes().Get("resource")
+			assert.True(t, ok)
+		}
+	}
+}
+
+// spanNamesByResource returns the names of the spans of every resource of td.
+func spanNamesByResource(td ptrace.Traces) [][]string {
+	var names [][]string
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		var resourceNames []string
+		sss := td.ResourceSpans().At(i).ScopeSpans()
+		for j := 0; j < sss.Len(); j++ {
+			for k := 0; k < sss.At(j).Spans().Len(); k++ {
+				resourceNames = append(resourceNames, sss.At(j).Spans().At(k).Name())
+			}
+		}
+		names = append(names, resourceNames)
+	}
+	return names
+}
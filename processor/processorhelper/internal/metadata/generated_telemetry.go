@@ -3,6 +3,7 @@
 package metadata
 
 import (
+	"context"
 	"errors"
 
 	"go.opentelemetry.io/otel/metric"
@@ -28,31 +29,56 @@ func Tracer(settings component.TelemetrySettings) trace.Tracer {
 // TelemetryBuilder provides an interface for components to report telemetry
 // as defined in metadata and user config.
 type TelemetryBuilder struct {
-	meter                         metric.Meter
-	ProcessorAcceptedLogRecords   metric.Int64Counter
-	ProcessorAcceptedMetricPoints metric.Int64Counter
-	ProcessorAcceptedSpans        metric.Int64Counter
-	ProcessorDroppedLogRecords    metric.Int64Counter
-	ProcessorDroppedMetricPoints  metric.Int64Counter
-	ProcessorDroppedSpans         metric.Int64Counter
-	ProcessorIncomingLogRecords   metric.Int64Counter
-	ProcessorIncomingMetricPoints metric.Int64Counter
-	ProcessorIncomingSpans        metric.Int64Counter
-	ProcessorInsertedLogRecords   metric.Int64Counter
-	ProcessorInsertedMetricPoints metric.Int64Counter
-	ProcessorInsertedSpans        metric.Int64Counter
-	ProcessorOutgoingLogRecords   metric.Int64Counter
-	ProcessorOutgoingMetricPoints metric.Int64Counter
-	ProcessorOutgoingSpans        metric.Int64Counter
-	ProcessorRefusedLogRecords    metric.Int64Counter
-	ProcessorRefusedMetricPoints  metric.Int64Counter
-	ProcessorRefusedSpans         metric.Int64Counter
-	meters                        map[configtelemetry.Level]metric.Meter
+	meter                          metric.Meter
+	ProcessorAcceptedLogRecords    metric.Int64Counter
+	ProcessorAcceptedMetricPoints  metric.Int64Counter
+	ProcessorAcceptedSpans         metric.Int64Counter
+	ProcessorDiscardedLogRecords   metric.Int64Counter
+	ProcessorDiscardedMetricPoints metric.Int64Counter
+	ProcessorDiscardedSpans        metric.Int64Counter
+	ProcessorDroppedLogRecords     metric.Int64Counter
+	ProcessorDroppedMetricPoints   metric.Int64Counter
+	ProcessorDroppedSpans          metric.Int64Counter
+	ProcessorIncomingLogRecords    metric.Int64Counter
+	ProcessorIncomingMetricPoints  metric.Int64Counter
+	ProcessorIncomingSpans         metric.Int64Counter
+	ProcessorInsertedLogRecords    metric.Int64Counter
+	ProcessorInsertedMetricPoints  metric.Int64Counter
+	ProcessorInsertedSpans         metric.Int64Counter
+	ProcessorOutgoingLogRecords    metric.Int64Counter
+	ProcessorOutgoingMetricPoints  metric.Int64Counter
+	ProcessorOutgoingSpans         metric.Int64Counter
+	ProcessorPanics                metric.Int64Counter
+	ProcessorProcessDuration       metric.Float64ObservableGauge
+	ProcessorProcessErrors         metric.Int64Counter
+	ProcessorRefusedLogRecords     metric.Int64Counter
+	ProcessorRefusedMetricPoints   metric.Int64Counter
+	ProcessorRefusedSpans          metric.Int64Counter
+	ProcessorSpansDropped          metric.Int64Counter
+	meters                         map[configtelemetry.Level]metric.Meter
 }
 
 // telemetryBuilderOption applies changes to default builder.
 type telemetryBuilderOption func(*TelemetryBuilder)
 
+// InitProcessorProcessDuration configures the ProcessorProcessDuration metric.
+func (builder *TelemetryBuilder) InitProcessorProcessDuration(cb func() float64, opts ...metric.ObserveOption) error {
+	var err error
+	builder.ProcessorProcessDuration, err = builder.meters[configtelemetry.LevelBasic].Float64ObservableGauge(
+		"otelcol_processor_process_duration",
+		metric.WithDescription("Estimated percentile of the duration of the process function, by value of the percentile attribute."),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return err
+	}
+	_, err = builder.meters[configtelemetry.LevelBasic].RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		o.ObserveFloat64(builder.ProcessorProcessDuration, cb(), opts...)
+		return nil
+	}, builder.ProcessorProcessDuration)
+	return err
+}
+
 // NewTelemetryBuilder provides a struct with methods to update all internal telemetry
 // for a component
 func NewTelemetryBuilder(settings component.TelemetrySettings, options ...telemetryBuilderOption) (*TelemetryBuilder, error) {
@@ -80,6 +106,24 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...teleme
 		metric.WithUnit("{spans}"),
 	)
 	errs = errors.Join(errs, err)
+	builder.ProcessorDiscardedLogRecords, err = builder.meters[configtelemetry.LevelBasic].Int64Counter(
+		"otelcol_processor_discarded_log_records",
+		metric.WithDescription("Number of log records discarded by the processor returning ErrSkipProcessingData."),
+		metric.WithUnit("{records}"),
+	)
+	errs = errors.Join(errs, err)
+	builder.ProcessorDiscardedMetricPoints, err = builder.meters[configtelemetry.LevelBasic].Int64Counter(
+		"otelcol_processor_discarded_metric_points",
+		metric.WithDescription("Number of metric points discarded by the processor returning ErrSkipProcessingData."),
+		metric.WithUnit("{datapoints}"),
+	)
+	errs = errors.Join(errs, err)
+	builder.ProcessorDiscardedSpans, err = builder.meters[configtelemetry.LevelBasic].Int64Counter(
+		"otelcol_processor_discarded_spans",
+		metric.WithDescription("Number of spans discarded by the processor returning ErrSkipProcessingData."),
+		metric.WithUnit("{spans}"),
+	)
+	errs = errors.Join(errs, err)
 	builder.ProcessorDroppedLogRecords, err = builder.meters[configtelemetry.LevelBasic].Int64Counter(
 		"otelcol_processor_dropped_log_records",
 		metric.WithDescription("Number of log records that were dropped."),
@@ -152,6 +196,18 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...teleme
 		metric.WithUnit("{spans}"),
 	)
 	errs = errors.Join(errs, err)
+	builder.ProcessorPanics, err = builder.meters[configtelemetry.LevelBasic].Int64Counter(
+		"otelcol_processor_panics",
+		metric.WithDescription("Number of panics of the process function recovered by the processor."),
+		metric.WithUnit("{panics}"),
+	)
+	errs = errors.Join(errs, err)
+	builder.ProcessorProcessErrors, err = builder.meters[configtelemetry.LevelBasic].Int64Counter(
+		"otelcol_processor_process_errors",
+		metric.WithDescription("Number of errors returned by the process function, by classification, permanent or retryable."),
+		metric.WithUnit("{errors}"),
+	)
+	errs = errors.Join(errs, err)
 	builder.ProcessorRefusedLogRecords, err = builder.meters[configtelemetry.LevelBasic].Int64Counter(
 		"otelcol_processor_refused_log_records",
 		metric.WithDescription("Number of log records that were rejected by the next component in the pipeline."),
@@ -170,5 +226,11 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...teleme
 		metric.WithUnit("{spans}"),
 	)
 	errs = errors.Join(errs, err)
+	builder.ProcessorSpansDropped, err = builder.meters[configtelemetry.LevelBasic].Int64Counter(
+		"otelcol_processor_spans_dropped",
+		metric.WithDescription("Number of spans dropped by the processor, by the reason attribute given by the processor. The spans are also counted by otelcol_processor_dropped_spans."),
+		metric.WithUnit("{spans}"),
+	)
+	errs = errors.Join(errs, err)
 	return &builder, errs
 }
@@ -39,16 +39,33 @@ func NewLogsProcessor(
 		return nil, errors.New("nil logsFunc")
 	}
 
+	bs := fromOptions(options)
 	obs, err := newObsReport(ObsReportSettings{
 		ProcessorID:             set.ID,
 		ProcessorCreateSettings: set,
-	})
+		MetricsByComponentType:  bs.metricsByType,
+	}, bs.metricAttributes...)
 	if err != nil {
 		return nil, err
 	}
 
+	dl := newDeadLetter(bs, set)
+	logsFunc = dl.wrapLogs(logsFunc)
+	logsFunc = newConcurrency(obs, bs.workers, bs.mutatesData).wrapLogs(logsFunc)
+	logsFunc = newMutationChecker(set.TelemetrySettings, set.ID, bs.mutatesData).wrapLogs(logsFunc)
+	rules, err := newAttributeRules(bs.attributeRulesFile, set.Logger)
+	if err != nil {
+		return nil, err
+	}
+	logsFunc = rules.wrapLogs(logsFunc)
+	logsFunc = newPanicRecoverer(obs, bs.recoverPanics).wrapLogs(logsFunc)
+	durations, err := newProcessDurations(obs, bs.durationPercentiles)
+	if err != nil {
+		return nil, err
+	}
+	logsFunc = durations.wrapLogs(logsFunc)
+
 	eventOptions := spanAttributes(set.ID)
-	bs := fromOptions(options)
 	logsConsumer, err := consumer.NewLogs(func(ctx context.Context, ld plog.Logs) error {
 		span := trace.SpanFromContext(ctx)
 		span.AddEvent("Start processing.", eventOptions)
@@ -58,8 +75,10 @@ func NewLogsProcessor(
 		span.AddEvent("End processing.", eventOptions)
 		if err != nil {
 			if errors.Is(err, ErrSkipProcessingData) {
+				obs.recordDiscarded(ctx, component.DataTypeLogs, recordsIn, recordsIn)
 				return nil
 			}
+			obs.recordProcessError(ctx, err)
 			return err
 		}
 		recordsOut := ld.LogRecordCount()
@@ -71,8 +90,8 @@ func NewLogsProcessor(
 	}
 
 	return &logProcessor{
-		StartFunc:    bs.StartFunc,
-		ShutdownFunc: bs.ShutdownFunc,
+		StartFunc:    dl.wrapStart(rules.wrapStart(bs.StartFunc)),
+		ShutdownFunc: rules.wrapShutdown(bs.ShutdownFunc),
 		Logs:         logsConsumer,
 	}, nil
 }
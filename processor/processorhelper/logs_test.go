@@ -21,8 +21,10 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/testdata"
 	"go.opentelemetry.io/collector/processor/processortest"
 )
 
@@ -148,3 +150,173 @@ func TestLogsProcessor_RecordInOut(t *testing.T) {
 		},
 	}, outMetric.Data, metricdatatest.IgnoreTimestamp())
 }
+
+func TestLogsProcessor_StaticMetricAttributes(t *testing.T) {
+	incomingLogs := plog.NewLogs()
+	incomingLogs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+
+	metricReader := sdkmetric.NewManualReader()
+	set := processortest.NewNopSettings()
+	set.TelemetrySettings.MetricsLevel = configtelemetry.LevelBasic
+	set.TelemetrySettings.LeveledMeterProvider = func(level configtelemetry.Level) metric.MeterProvider {
+		if level >= configtelemetry.LevelBasic {
+			return sdkmetric.NewMeterProvider(sdkmetric.WithReader(metricReader))
+		}
+		return nil
+	}
+
+	lp, err := NewLogsProcessor(context.Background(), set, &testLogsCfg, consumertest.NewNop(), newTestLProcessor(nil),
+		WithStaticMetricAttributes(attribute.String("region", "eu-west-1"), attribute.String("cluster", "prod")))
+	require.NoError(t, err)
+
+	assert.NoError(t, lp.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, lp.ConsumeLogs(context.Background(), incomingLogs))
+	assert.NoError(t, lp.Shutdown(context.Background()))
+
+	ownMetrics := new(metricdata.ResourceMetrics)
+	require.NoError(t, metricReader.Collect(context.Background(), ownMetrics))
+
+	require.Len(t, ownMetrics.ScopeMetrics, 1)
+	require.Len(t, ownMetrics.ScopeMetrics[0].Metrics, 2)
+	for _, m := range ownMetrics.ScopeMetrics[0].Metrics {
+		metricdatatest.AssertAggregationsEqual(t, metricdata.Sum[int64]{
+			Temporality: metricdata.CumulativeTemporality,
+			IsMonotonic: true,
+			DataPoints: []metricdata.DataPoint[int64]{
+				{
+					Attributes: attribute.NewSet(
+						attribute.String("processor", set.ID.String()),
+						attribute.String("region", "eu-west-1"),
+						attribute.String("cluster", "prod"),
+					),
+					Value: 1,
+				},
+			},
+		}, m.Data, metricdatatest.IgnoreTimestamp())
+	}
+}
+
+func TestLogsProcessor_StaticMetricAttributesReservedKey(t *testing.T) {
+	_, err := NewLogsProcessor(context.Background(), processortest.NewNopSettings(), &testLogsCfg, consumertest.NewNop(), newTestLProcessor(nil),
+		WithStaticMetricAttributes(attribute.String("processor", "other")))
+	assert.EqualError(t, err, `static metric attribute "processor" is reserved`)
+}
+
+func TestLogsProcessor_ProcessErrors(t *testing.T) {
+	set, metricReader := newTestSettingsWithMetricReader()
+	var processErr error
+	lp, err := NewLogsProcessor(context.Background(), set, &testLogsCfg, consumertest.NewNop(),
+		func(_ context.Context, ld plog.Logs) (plog.Logs, error) { return ld, processErr })
+	require.NoError(t, err)
+	assert.NoError(t, lp.Start(context.Background(), componenttest.NewNopHost()))
+
+	for _, processErr = range []error{
+		consumererror.NewPermanent(errors.New("invalid")),
+		errors.New("unavailable"),
+		consumererror.NewPermanent(errors.New("invalid")),
+	} {
+		assert.Equal(t, processErr, lp.ConsumeLogs(context.Background(), plog.NewLogs()))
+	}
+	// Skipping the data and succeeding are not errors.
+	for _, processErr = range []error{ErrSkipProcessingData, nil} {
+		assert.NoError(t, lp.ConsumeLogs(context.Background(), plog.NewLogs()))
+	}
+	assert.NoError(t, lp.Shutdown(context.Background()))
+
+	ownMetrics := new(metricdata.ResourceMetrics)
+	require.NoError(t, metricReader.Collect(context.Background(), ownMetrics))
+	require.Len(t, ownMetrics.ScopeMetrics, 1)
+	var found bool
+	for _, m := range ownMetrics.ScopeMetrics[0].Metrics {
+		if m.Name != "otelcol_processor_process_errors" {
+			continue
+		}
+		found = true
+		metricdatatest.AssertAggregationsEqual(t, metricdata.Sum[int64]{
+			Temporality: metricdata.CumulativeTemporality,
+			IsMonotonic: true,
+			DataPoints: []metricdata.DataPoint[int64]{
+				{
+					Attributes: attribute.NewSet(attribute.String("processor", set.ID.String()), attribute.String("classification", "permanent")),
+					Value:      2,
+				},
+				{
+					Attributes: attribute.NewSet(attribute.String("processor", set.ID.String()), attribute.String("classification", "retryable")),
+					Value:      1,
+				},
+			},
+		}, m.Data, metricdatatest.IgnoreTimestamp())
+	}
+	assert.True(t, found)
+}
+
+func TestLogsProcessor_MetricsByComponentType(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []Option
+		want    string
+	}{
+		{
+			name: "by_id",
+			want: "nop/logs",
+		},
+		{
+			name:    "by_type",
+			options: []Option{WithMetricsByComponentType()},
+			want:    "nop",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			incomingLogs := plog.NewLogs()
+			incomingLogs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+
+			set, metricReader := newTestSettingsWithMetricReader()
+			set.ID = component.MustNewIDWithName("nop", "logs")
+			lp, err := NewLogsProcessor(context.Background(), set, &testLogsCfg, consumertest.NewNop(), newTestLProcessor(nil), tt.options...)
+			require.NoError(t, err)
+
+			assert.NoError(t, lp.Start(context.Background(), componenttest.NewNopHost()))
+			assert.NoError(t, lp.ConsumeLogs(context.Background(), incomingLogs))
+			assert.NoError(t, lp.Shutdown(context.Background()))
+
+			ownMetrics := new(metricdata.ResourceMetrics)
+			require.NoError(t, metricReader.Collect(context.Background(), ownMetrics))
+			require.Len(t, ownMetrics.ScopeMetrics, 1)
+			names := map[string]bool{}
+			for _, m := range ownMetrics.ScopeMetrics[0].Metrics {
+				names[m.Name] = true
+				metricdatatest.AssertAggregationsEqual(t, metricdata.Sum[int64]{
+					Temporality: metricdata.CumulativeTemporality,
+					IsMonotonic: true,
+					DataPoints: []metricdata.DataPoint[int64]{
+						{
+							Attributes: attribute.NewSet(attribute.String("processor", tt.want)),
+							Value:      1,
+						},
+					},
+				}, m.Data, metricdatatest.IgnoreTimestamp())
+			}
+			assert.Equal(t, map[string]bool{
+				"otelcol_processor_incoming_log_records": true,
+				"otelcol_processor_outgoing_log_records": true,
+			}, names)
+		})
+	}
+}
+
+func TestLogsProcessor_RecordInOutSkipProcessingData(t *testing.T) {
+	set, metricReader := newTestSettingsWithMetricReader()
+	sink := new(consumertest.LogsSink)
+	p, err := NewLogsProcessor(context.Background(), set, &testLogsCfg, sink, newTestLProcessor(ErrSkipProcessingData))
+	require.NoError(t, err)
+
+	ld := testdata.GenerateLogs(3)
+	require.NoError(t, p.ConsumeLogs(context.Background(), ld))
+	assert.Zero(t, sink.LogRecordCount())
+
+	sums := metricSums(t, metricReader)
+	assert.Equal(t, int64(ld.LogRecordCount()), sums["otelcol_processor_incoming_log_records"])
+	assert.Equal(t, int64(ld.LogRecordCount()), sums["otelcol_processor_discarded_log_records"])
+	assert.NotContains(t, sums, "otelcol_processor_outgoing_log_records")
+}
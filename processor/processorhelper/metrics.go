@@ -39,16 +39,33 @@ func NewMetricsProcessor(
 		return nil, errors.New("nil metricsFunc")
 	}
 
+	bs := fromOptions(options)
 	obs, err := newObsReport(ObsReportSettings{
 		ProcessorID:             set.ID,
 		ProcessorCreateSettings: set,
-	})
+		MetricsByComponentType:  bs.metricsByType,
+	}, bs.metricAttributes...)
 	if err != nil {
 		return nil, err
 	}
 
+	dl := newDeadLetter(bs, set)
+	metricsFunc = dl.wrapMetrics(metricsFunc)
+	metricsFunc = newConcurrency(obs, bs.workers, bs.mutatesData).wrapMetrics(metricsFunc)
+	metricsFunc = newMutationChecker(set.TelemetrySettings, set.ID, bs.mutatesData).wrapMetrics(metricsFunc)
+	rules, err := newAttributeRules(bs.attributeRulesFile, set.Logger)
+	if err != nil {
+		return nil, err
+	}
+	metricsFunc = rules.wrapMetrics(metricsFunc)
+	metricsFunc = newPanicRecoverer(obs, bs.recoverPanics).wrapMetrics(metricsFunc)
+	durations, err := newProcessDurations(obs, bs.durationPercentiles)
+	if err != nil {
+		return nil, err
+	}
+	metricsFunc = durations.wrapMetrics(metricsFunc)
+
 	eventOptions := spanAttributes(set.ID)
-	bs := fromOptions(options)
 	metricsConsumer, err := consumer.NewMetrics(func(ctx context.Context, md pmetric.Metrics) error {
 		span := trace.SpanFromContext(ctx)
 		span.AddEvent("Start processing.", eventOptions)
@@ -58,8 +75,10 @@ func NewMetricsProcessor(
 		span.AddEvent("End processing.", eventOptions)
 		if err != nil {
 			if errors.Is(err, ErrSkipProcessingData) {
+				obs.recordDiscarded(ctx, component.DataTypeMetrics, pointsIn, pointsIn)
 				return nil
 			}
+			obs.recordProcessError(ctx, err)
 			return err
 		}
 		pointsOut := md.DataPointCount()
@@ -71,8 +90,8 @@ func NewMetricsProcessor(
 	}
 
 	return &metricsProcessor{
-		StartFunc:    bs.StartFunc,
-		ShutdownFunc: bs.ShutdownFunc,
+		StartFunc:    dl.wrapStart(rules.wrapStart(bs.StartFunc)),
+		ShutdownFunc: rules.wrapShutdown(bs.ShutdownFunc),
 		Metrics:      metricsConsumer,
 	}, nil
 }
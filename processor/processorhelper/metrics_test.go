@@ -23,6 +23,7 @@ import (
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/testdata"
 	"go.opentelemetry.io/collector/processor/processortest"
 )
 
@@ -149,3 +150,19 @@ func TestMetricsProcessor_RecordInOut(t *testing.T) {
 		},
 	}, outMetric.Data, metricdatatest.IgnoreTimestamp())
 }
+
+func TestMetricsProcessor_RecordInOutSkipProcessingData(t *testing.T) {
+	set, metricReader := newTestSettingsWithMetricReader()
+	sink := new(consumertest.MetricsSink)
+	p, err := NewMetricsProcessor(context.Background(), set, &testMetricsCfg, sink, newTestMProcessor(ErrSkipProcessingData))
+	require.NoError(t, err)
+
+	md := testdata.GenerateMetrics(2)
+	require.NoError(t, p.ConsumeMetrics(context.Background(), md))
+	assert.Zero(t, sink.DataPointCount())
+
+	sums := metricSums(t, metricReader)
+	assert.Equal(t, int64(md.DataPointCount()), sums["otelcol_processor_incoming_metric_points"])
+	assert.Equal(t, int64(md.DataPointCount()), sums["otelcol_processor_discarded_metric_points"])
+	assert.NotContains(t, sums, "otelcol_processor_outgoing_metric_points")
+}
@@ -0,0 +1,247 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package monotonic helps the processors correct the decreasing values of the monotonic cumulative counters,
+// e.g. emitted by buggy instrumentation, with an Enforcer tracking the last value of every series.
+package monotonic // import "go.opentelemetry.io/collector/processor/processorhelper/monotonic"
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// DecreasePolicy specifies how an Enforcer corrects a decreasing cumulative counter.
+type DecreasePolicy int32
+
+const (
+	// DecreasePolicyClamp replaces the decreased value with the previous value of the series.
+	DecreasePolicyClamp DecreasePolicy = iota
+	// DecreasePolicyReset treats the decrease as a reset of the counter: the start timestamp of the data
+	// point is set to the timestamp of the previous data point of the series.
+	DecreasePolicyReset
+)
+
+// String returns the string representation of the DecreasePolicy.
+func (dp DecreasePolicy) String() string {
+	switch dp {
+	case DecreasePolicyClamp:
+		return "Clamp"
+	case DecreasePolicyReset:
+		return "Reset"
+	}
+	return ""
+}
+
+// Enforcer corrects the data points of monotonic cumulative Sum metrics whose value decreases
+// while their start timestamp does not change, which violates the monotonicity of the counter.
+//
+// It tracks the last value of every series, identified by the resource, scope, metric name and data point
+// attributes. The state is bounded: the series which have not been seen for the expiry are forgotten, and
+// the least recently seen series is forgotten when a new series exceeds the maximum number of series.
+//
+// An Enforcer is safe for concurrent use.
+type Enforcer struct {
+	policy    DecreasePolicy
+	maxSeries int
+	expiry    time.Duration
+	now       func() time.Time
+
+	mu        sync.Mutex
+	series    map[monotonicSeriesKey]*list.Element
+	lru       *list.List
+	corrected int64
+}
+
+type monotonicSeriesKey struct {
+	resource   pcommon.Fingerprint
+	scope      string
+	version    string
+	metric     string
+	attributes pcommon.Fingerprint
+}
+
+type monotonicSeries struct {
+	key monotonicSeriesKey
+	// start is the start timestamp of the series as received, and resetStart the one it is replaced with
+	// after a decrease was treated as a reset.
+	start      pcommon.Timestamp
+	resetStart pcommon.Timestamp
+	timestamp  pcommon.Timestamp
+	value      pmetric.NumberDataPoint
+	lastSeen   time.Time
+}
+
+// NewEnforcer returns an Enforcer correcting the decreases with the policy, tracking
+// at most maxSeries series, each for the expiry after it was last seen.
+// If maxSeries or expiry is not positive, the corresponding bound is not enforced.
+func NewEnforcer(policy DecreasePolicy, maxSeries int, expiry time.Duration) *Enforcer {
+	return &Enforcer{
+		policy:    policy,
+		maxSeries: maxSeries,
+		expiry:    expiry,
+		now:       time.Now,
+		series:    make(map[monotonicSeriesKey]*list.Element),
+		lru:       list.New(),
+	}
+}
+
+// Enforce corrects the decreasing data points of the monotonic cumulative Sum metrics of the pmetric.Metrics, in the
+// order of the data points, and returns the number of corrected data points.
+func (e *Enforcer) Enforce(md pmetric.Metrics) int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	now := e.now()
+	e.expire(now)
+
+	corrected := 0
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		resource := rm.Resource().Fingerprint()
+		sms := rm.ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			sm := sms.At(j)
+			metrics := sm.Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				m := metrics.At(k)
+				if m.Type() != pmetric.MetricTypeSum || !m.Sum().IsMonotonic() || m.Sum().AggregationTemporality() != pmetric.AggregationTemporalityCumulative {
+					continue
+				}
+				dps := m.Sum().DataPoints()
+				for l := 0; l < dps.Len(); l++ {
+					dp := dps.At(l)
+					key := monotonicSeriesKey{
+						resource:   resource,
+						scope:      sm.Scope().Name(),
+						version:    sm.Scope().Version(),
+						metric:     m.Name(),
+						attributes: dp.Attributes().Fingerprint(),
+					}
+					if e.enforce(key, dp, now) {
+						corrected++
+					}
+				}
+			}
+		}
+	}
+	e.corrected += int64(corrected)
+	return corrected
+}
+
+// CorrectedPoints returns the total number of data points corrected by the Enforcer.
+func (e *Enforcer) CorrectedPoints() int64 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.corrected
+}
+
+// Len returns the number of series tracked by the Enforcer.
+func (e *Enforcer) Len() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.lru.Len()
+}
+
+// enforce corrects dp if it decreases, updates the state of its series, and returns whether dp was corrected.
+func (e *Enforcer) enforce(key monotonicSeriesKey, dp pmetric.NumberDataPoint, now time.Time) bool {
+	elem, ok := e.series[key]
+	if !ok {
+		if e.maxSeries > 0 && e.lru.Len() >= e.maxSeries {
+			e.remove(e.lru.Back())
+		}
+		s := &monotonicSeries{key: key, value: pmetric.NewNumberDataPoint()}
+		s.update(dp, now)
+		e.series[key] = e.lru.PushFront(s)
+		return false
+	}
+	e.lru.MoveToFront(elem)
+	s := elem.Value.(*monotonicSeries)
+	// A new start timestamp signals a reset of the counter.
+	if dp.StartTimestamp() != s.start {
+		s.update(dp, now)
+		return false
+	}
+	if s.resetStart != 0 {
+		dp.SetStartTimestamp(s.resetStart)
+	}
+	// Data points out of order are left as is, and do not change the state.
+	if dp.Timestamp() < s.timestamp {
+		s.lastSeen = now
+		return false
+	}
+	if !lessNumber(dp, s.value) {
+		s.record(dp, now)
+		return false
+	}
+	switch e.policy {
+	case DecreasePolicyClamp:
+		switch s.value.ValueType() {
+		case pmetric.NumberDataPointValueTypeInt:
+			if dp.ValueType() == pmetric.NumberDataPointValueTypeInt {
+				dp.SetIntValue(s.value.IntValue())
+			} else {
+				dp.SetDoubleValue(float64(s.value.IntValue()))
+			}
+		case pmetric.NumberDataPointValueTypeDouble:
+			dp.SetDoubleValue(s.value.DoubleValue())
+		}
+	case DecreasePolicyReset:
+		s.resetStart = s.timestamp
+		dp.SetStartTimestamp(s.resetStart)
+	}
+	s.record(dp, now)
+	return true
+}
+
+// update starts tracking the series from dp.
+func (s *monotonicSeries) update(dp pmetric.NumberDataPoint, now time.Time) {
+	s.start = dp.StartTimestamp()
+	s.resetStart = 0
+	s.record(dp, now)
+}
+
+// record records dp as the last data point of the series.
+func (s *monotonicSeries) record(dp pmetric.NumberDataPoint, now time.Time) {
+	s.timestamp = dp.Timestamp()
+	switch dp.ValueType() {
+	case pmetric.NumberDataPointValueTypeInt:
+		s.value.SetIntValue(dp.IntValue())
+	case pmetric.NumberDataPointValueTypeDouble:
+		s.value.SetDoubleValue(dp.DoubleValue())
+	}
+	s.lastSeen = now
+}
+
+// expire forgets the series which have not been seen for the expiry.
+func (e *Enforcer) expire(now time.Time) {
+	if e.expiry <= 0 {
+		return
+	}
+	for elem := e.lru.Back(); elem != nil && now.Sub(elem.Value.(*monotonicSeries).lastSeen) >= e.expiry; elem = e.lru.Back() {
+		e.remove(elem)
+	}
+}
+
+func (e *Enforcer) remove(elem *list.Element) {
+	e.lru.Remove(elem)
+	delete(e.series, elem.Value.(*monotonicSeries).key)
+}
+
+// lessNumber returns whether the value of a is less than the value of b.
+func lessNumber(a, b pmetric.NumberDataPoint) bool {
+	if a.ValueType() == pmetric.NumberDataPointValueTypeInt && b.ValueType() == pmetric.NumberDataPointValueTypeInt {
+		return a.IntValue() < b.IntValue()
+	}
+	return numberValue(a) < numberValue(b)
+}
+
+func numberValue(dp pmetric.NumberDataPoint) float64 {
+	if dp.ValueType() == pmetric.NumberDataPointValueTypeInt {
+		return float64(dp.IntValue())
+	}
+	return dp.DoubleValue()
+}
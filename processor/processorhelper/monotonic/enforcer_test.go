@@ -0,0 +1,143 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package monotonic
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// newCounters returns pmetric.Metrics with a monotonic cumulative counter, whose data points have the given values,
+// the start timestamp 1 and the timestamps 10, 20, ..., and a non-monotonic sum with the same values.
+func newCounters(name string, values ...int64) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	metrics := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	sum := metrics.AppendEmpty()
+	sum.SetName(name)
+	sum.SetEmptySum().SetIsMonotonic(true)
+	sum.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	gauge := metrics.AppendEmpty()
+	gauge.SetName(name)
+	gauge.SetEmptySum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	for i, v := range values {
+		for _, dps := range []pmetric.NumberDataPointSlice{sum.Sum().DataPoints(), gauge.Sum().DataPoints()} {
+			dp := dps.AppendEmpty()
+			dp.SetStartTimestamp(1)
+			dp.SetTimestamp(pcommon.Timestamp(10 * (i + 1)))
+			dp.SetIntValue(v)
+		}
+	}
+	return md
+}
+
+func counterValues(md pmetric.Metrics) ([]int64, []pcommon.Timestamp) {
+	var values []int64
+	var starts []pcommon.Timestamp
+	dps := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints()
+	for i := 0; i < dps.Len(); i++ {
+		values = append(values, dps.At(i).IntValue())
+		starts = append(starts, dps.At(i).StartTimestamp())
+	}
+	return values, starts
+}
+
+func TestEnforcerClamp(t *testing.T) {
+	e := NewEnforcer(DecreasePolicyClamp, 0, 0)
+	md := newCounters("requests", 1, 5, 3, 4, 7, 6)
+	assert.Equal(t, 3, e.Enforce(md))
+	values, starts := counterValues(md)
+	assert.Equal(t, []int64{1, 5, 5, 5, 7, 7}, values)
+	assert.Equal(t, []pcommon.Timestamp{1, 1, 1, 1, 1, 1}, starts)
+
+	// Non-monotonic sums are not corrected.
+	dps := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(1).Sum().DataPoints()
+	assert.Equal(t, int64(3), dps.At(2).IntValue())
+
+	// The state is kept across calls.
+	md = newCounters("requests", 6)
+	md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0).SetTimestamp(100)
+	assert.Equal(t, 1, e.Enforce(md))
+	values, _ = counterValues(md)
+	assert.Equal(t, []int64{7}, values)
+	assert.Equal(t, int64(4), e.CorrectedPoints())
+}
+
+func TestEnforcerReset(t *testing.T) {
+	e := NewEnforcer(DecreasePolicyReset, 0, 0)
+	md := newCounters("requests", 1, 5, 3, 4, 2)
+	assert.Equal(t, 2, e.Enforce(md))
+	values, starts := counterValues(md)
+	assert.Equal(t, []int64{1, 5, 3, 4, 2}, values)
+	// Each decrease starts a new run of the counter after the previous data point.
+	assert.Equal(t, []pcommon.Timestamp{1, 1, 20, 20, 40}, starts)
+	assert.Equal(t, int64(2), e.CorrectedPoints())
+}
+
+func TestEnforcerNotCorrected(t *testing.T) {
+	e := NewEnforcer(DecreasePolicyClamp, 0, 0)
+	md := newCounters("requests", 5, 2)
+	dps := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints()
+	// A new start timestamp is a reset.
+	dps.At(1).SetStartTimestamp(15)
+	// Out of order data points are left as is.
+	dp := dps.AppendEmpty()
+	dp.SetStartTimestamp(15)
+	dp.SetTimestamp(5)
+	dp.SetIntValue(1)
+	dp = dps.AppendEmpty()
+	dp.SetStartTimestamp(15)
+	dp.SetTimestamp(30)
+	dp.SetDoubleValue(1.5)
+	assert.Equal(t, 1, e.Enforce(md))
+	assert.Equal(t, int64(2), dps.At(1).IntValue())
+	assert.Equal(t, int64(1), dps.At(2).IntValue())
+	assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dps.At(3).ValueType())
+	assert.InDelta(t, 2, dps.At(3).DoubleValue(), 1e-9)
+
+	// Series are distinguished by their attributes.
+	md = newCounters("requests", 10, 1)
+	md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(1).Attributes().PutStr("path", "/a")
+	e = NewEnforcer(DecreasePolicyClamp, 0, 0)
+	assert.Zero(t, e.Enforce(md))
+	assert.Equal(t, 2, e.Len())
+}
+
+func TestEnforcerBoundedState(t *testing.T) {
+	now := time.Unix(0, 0)
+	e := NewEnforcer(DecreasePolicyClamp, 2, time.Minute)
+	e.now = func() time.Time { return now }
+
+	require.Zero(t, e.Enforce(newCounters("a", 10)))
+	require.Zero(t, e.Enforce(newCounters("b", 10)))
+	require.Zero(t, e.Enforce(newCounters("c", 10)))
+	// The least recently seen series, "a", was evicted.
+	assert.Equal(t, 2, e.Len())
+	md := newCounters("a", 1)
+	assert.Zero(t, e.Enforce(md))
+	assert.Equal(t, 2, e.Len())
+
+	// "b" was evicted by "a", "c" is still tracked.
+	md = newCounters("c", 1)
+	md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0).SetTimestamp(20)
+	assert.Equal(t, 1, e.Enforce(md))
+
+	// The series expire.
+	now = now.Add(time.Minute)
+	md = newCounters("c", 1)
+	md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0).SetTimestamp(30)
+	assert.Zero(t, e.Enforce(md))
+	assert.Equal(t, 1, e.Len())
+}
+
+func TestDecreasePolicyString(t *testing.T) {
+	assert.Equal(t, "Clamp", DecreasePolicyClamp.String())
+	assert.Equal(t, "Reset", DecreasePolicyReset.String())
+	assert.Equal(t, "", DecreasePolicy(100).String())
+}
@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package processorhelper // import "go.opentelemetry.io/collector/processor/processorhelper"
+
+import (
+	"bytes"
+	"context"
+	"errors"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// checkMutatesDataFeatureGate enables a debug check verifying that processors declaring
+// `MutatesData: false` do not modify their input. The check marshals the input before and
+// after the process function, so it is expensive and only meant for troubleshooting.
+var checkMutatesDataFeatureGate = featuregate.GlobalRegistry().MustRegister(
+	"processorhelper.checkMutatesData",
+	featuregate.StageAlpha,
+	featuregate.WithRegisterDescription("When enabled, processors declaring that they do not mutate data fail "+
+		"when their process function modifies the incoming data."))
+
+// errDataMutated is returned by the mutation check when the processor modified its input.
+var errDataMutated = errors.New("processor declared MutatesData: false but mutated the incoming data")
+
+// mutationChecker compares the incoming data before and after the process function
+// to detect processors mutating data they declared as read-only.
+type mutationChecker struct {
+	logger *zap.Logger
+	id     component.ID
+}
+
+// newMutationChecker returns a mutationChecker, or nil if the check does not apply because
+// the processor declares it mutates data or the feature gate is disabled.
+func newMutationChecker(set component.TelemetrySettings, id component.ID, mutatesData bool) *mutationChecker {
+	if mutatesData || !checkMutatesDataFeatureGate.IsEnabled() {
+		return nil
+	}
+	return &mutationChecker{logger: set.Logger, id: id}
+}
+
+var (
+	logsMarshaler    = &plog.ProtoMarshaler{}
+	metricsMarshaler = &pmetric.ProtoMarshaler{}
+	tracesMarshaler  = &ptrace.ProtoMarshaler{}
+)
+
+// check returns errDataMutated if before and after differ. A failure to marshal disables the check.
+func (mc *mutationChecker) check(before []byte, beforeErr error, after []byte, afterErr error) error {
+	if beforeErr != nil || afterErr != nil || bytes.Equal(before, after) {
+		return nil
+	}
+	mc.logger.Error("Processor mutated the incoming data while declaring MutatesData: false",
+		zap.String("processor", mc.id.String()))
+	return errDataMutated
+}
+
+// wrapLogs returns a ProcessLogsFunc verifying that logsFunc does not mutate its input.
+func (mc *mutationChecker) wrapLogs(logsFunc ProcessLogsFunc) ProcessLogsFunc {
+	if mc == nil {
+		return logsFunc
+	}
+	return func(ctx context.Context, ld plog.Logs) (plog.Logs, error) {
+		before, beforeErr := logsMarshaler.MarshalLogs(ld)
+		out, err := logsFunc(ctx, ld)
+		after, afterErr := logsMarshaler.MarshalLogs(ld)
+		if checkErr := mc.check(before, beforeErr, after, afterErr); checkErr != nil {
+			return out, checkErr
+		}
+		return out, err
+	}
+}
+
+// wrapMetrics returns a ProcessMetricsFunc verifying that metricsFunc does not mutate its input.
+func (mc *mutationChecker) wrapMetrics(metricsFunc ProcessMetricsFunc) ProcessMetricsFunc {
+	if mc == nil {
+		return metricsFunc
+	}
+	return func(ctx context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+		before, beforeErr := metricsMarshaler.MarshalMetrics(md)
+		out, err := metricsFunc(ctx, md)
+		after, afterErr := metricsMarshaler.MarshalMetrics(md)
+		if checkErr := mc.check(before, beforeErr, after, afterErr); checkErr != nil {
+			return out, checkErr
+		}
+		return out, err
+	}
+}
+
+// wrapTraces returns a ProcessTracesFunc verifying that tracesFunc does not mutate its input.
+func (mc *mutationChecker) wrapTraces(tracesFunc ProcessTracesFunc) ProcessTracesFunc {
+	if mc == nil {
+		return tracesFunc
+	}
+	return func(ctx context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+		before, beforeErr := tracesMarshaler.MarshalTraces(td)
+		out, err := tracesFunc(ctx, td)
+		after, afterErr := tracesMarshaler.MarshalTraces(td)
+		if checkErr := mc.check(before, beforeErr, after, afterErr); checkErr != nil {
+			return out, checkErr
+		}
+		return out, err
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package processorhelper
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/testdata"
+	"go.opentelemetry.io/collector/processor/processortest"
+)
+
+func setCheckMutatesDataFeatureGate(t *testing.T, enabled bool) {
+	prev := checkMutatesDataFeatureGate.IsEnabled()
+	require.NoError(t, featuregate.GlobalRegistry().Set(checkMutatesDataFeatureGate.ID(), enabled))
+	t.Cleanup(func() {
+		require.NoError(t, featuregate.GlobalRegistry().Set(checkMutatesDataFeatureGate.ID(), prev))
+	})
+}
+
+func TestMutationCheckLogs(t *testing.T) {
+	setCheckMutatesDataFeatureGate(t, true)
+	core, logs := observer.New(zap.ErrorLevel)
+	set := processortest.NewNopSettings()
+	set.Logger = zap.New(core)
+
+	mutating := func(_ context.Context, ld plog.Logs) (plog.Logs, error) {
+		ld.ResourceLogs().At(0).Resource().Attributes().PutStr("mutated", "true")
+		return ld, nil
+	}
+	lp, err := NewLogsProcessor(context.Background(), set, &testLogsCfg, consumertest.NewNop(), mutating,
+		WithCapabilities(consumer.Capabilities{MutatesData: false}))
+	require.NoError(t, err)
+	assert.ErrorIs(t, lp.ConsumeLogs(context.Background(), testdata.GenerateLogs(2)), errDataMutated)
+	require.Equal(t, 1, logs.Len())
+	assert.Equal(t, set.ID.String(), logs.All()[0].ContextMap()["processor"])
+
+	// Returning new data without touching the input passes the check.
+	copying := func(_ context.Context, ld plog.Logs) (plog.Logs, error) {
+		out := plog.NewLogs()
+		ld.CopyTo(out)
+		out.ResourceLogs().At(0).Resource().Attributes().PutStr("copied", "true")
+		return out, nil
+	}
+	lp, err = NewLogsProcessor(context.Background(), set, &testLogsCfg, consumertest.NewNop(), copying,
+		WithCapabilities(consumer.Capabilities{MutatesData: false}))
+	require.NoError(t, err)
+	assert.NoError(t, lp.ConsumeLogs(context.Background(), testdata.GenerateLogs(2)))
+}
+
+func TestMutationCheckMetrics(t *testing.T) {
+	setCheckMutatesDataFeatureGate(t, true)
+	mutating := func(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+		md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).SetName("renamed")
+		return md, nil
+	}
+	mp, err := NewMetricsProcessor(context.Background(), processortest.NewNopSettings(), &testMetricsCfg, consumertest.NewNop(), mutating,
+		WithCapabilities(consumer.Capabilities{MutatesData: false}))
+	require.NoError(t, err)
+	assert.ErrorIs(t, mp.ConsumeMetrics(context.Background(), testdata.GenerateMetrics(1)), errDataMutated)
+}
+
+func TestMutationCheckTraces(t *testing.T) {
+	setCheckMutatesDataFeatureGate(t, true)
+	mutating := func(_ context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+		td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).SetName("renamed")
+		return td, nil
+	}
+	tp, err := NewTracesProcessor(context.Background(), processortest.NewNopSettings(), &testTracesCfg, consumertest.NewNop(), mutating,
+		WithCapabilities(consumer.Capabilities{MutatesData: false}))
+	require.NoError(t, err)
+	assert.ErrorIs(t, tp.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)), errDataMutated)
+
+	// Processors declaring they mutate data are not checked.
+	tp, err = NewTracesProcessor(context.Background(), processortest.NewNopSettings(), &testTracesCfg, consumertest.NewNop(), mutating)
+	require.NoError(t, err)
+	assert.NoError(t, tp.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+}
+
+func TestMutationCheckDisabled(t *testing.T) {
+	setCheckMutatesDataFeatureGate(t, false)
+	mutating := func(_ context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+		td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).SetName("renamed")
+		return td, nil
+	}
+	tp, err := NewTracesProcessor(context.Background(), processortest.NewNopSettings(), &testTracesCfg, consumertest.NewNop(), mutating,
+		WithCapabilities(consumer.Capabilities{MutatesData: false}))
+	require.NoError(t, err)
+	assert.NoError(t, tp.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+}
@@ -5,12 +5,14 @@ package processorhelper // import "go.opentelemetry.io/collector/processor/proce
 
 import (
 	"context"
+	"fmt"
 	"strings"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/processor/processorhelper/internal/metadata"
@@ -30,6 +32,13 @@ func BuildCustomMetricName(configType, metric string) string {
 	return componentPrefix + configType + obsmetrics.MetricNameSep + metric
 }
 
+const (
+	// errorClassificationKey is the attribute of the processor_process_errors metric classifying the errors.
+	errorClassificationKey       = "classification"
+	errorClassificationPermanent = "permanent"
+	errorClassificationRetryable = "retryable"
+)
+
 // ObsReport is a helper to add observability to a processor.
 type ObsReport struct {
 	otelAttrs        []attribute.KeyValue
@@ -40,6 +49,10 @@ type ObsReport struct {
 type ObsReportSettings struct {
 	ProcessorID             component.ID
 	ProcessorCreateSettings processor.Settings
+
+	// MetricsByComponentType sets the `processor` attribute of the recorded metrics to the type of the
+	// processor instead of its full ID, aggregating the metrics of the processors of the same type.
+	MetricsByComponentType bool
 }
 
 // NewObsReport creates a new Processor.
@@ -47,15 +60,24 @@ func NewObsReport(cfg ObsReportSettings) (*ObsReport, error) {
 	return newObsReport(cfg)
 }
 
-func newObsReport(cfg ObsReportSettings) (*ObsReport, error) {
+func newObsReport(cfg ObsReportSettings, staticAttrs ...attribute.KeyValue) (*ObsReport, error) {
+	for _, attr := range staticAttrs {
+		if attr.Key == obsmetrics.ProcessorKey {
+			return nil, fmt.Errorf("static metric attribute %q is reserved", attr.Key)
+		}
+	}
 	telemetryBuilder, err := metadata.NewTelemetryBuilder(cfg.ProcessorCreateSettings.TelemetrySettings)
 	if err != nil {
 		return nil, err
 	}
+	processorAttr := cfg.ProcessorID.String()
+	if cfg.MetricsByComponentType {
+		processorAttr = cfg.ProcessorID.Type().String()
+	}
 	return &ObsReport{
-		otelAttrs: []attribute.KeyValue{
-			attribute.String(obsmetrics.ProcessorKey, cfg.ProcessorID.String()),
-		},
+		otelAttrs: append([]attribute.KeyValue{
+			attribute.String(obsmetrics.ProcessorKey, processorAttr),
+		}, staticAttrs...),
 		telemetryBuilder: telemetryBuilder,
 	}, nil
 }
@@ -78,6 +100,26 @@ func (or *ObsReport) recordInOut(ctx context.Context, dataType component.DataTyp
 	outgoingCount.Add(ctx, int64(outgoing), metric.WithAttributes(or.otelAttrs...))
 }
 
+// recordDiscarded records incoming items, and items discarded by the process function which are not
+// sent to the next component.
+func (or *ObsReport) recordDiscarded(ctx context.Context, dataType component.DataType, incoming, discarded int) {
+	var incomingCount, discardedCount metric.Int64Counter
+	switch dataType {
+	case component.DataTypeTraces:
+		incomingCount = or.telemetryBuilder.ProcessorIncomingSpans
+		discardedCount = or.telemetryBuilder.ProcessorDiscardedSpans
+	case component.DataTypeMetrics:
+		incomingCount = or.telemetryBuilder.ProcessorIncomingMetricPoints
+		discardedCount = or.telemetryBuilder.ProcessorDiscardedMetricPoints
+	case component.DataTypeLogs:
+		incomingCount = or.telemetryBuilder.ProcessorIncomingLogRecords
+		discardedCount = or.telemetryBuilder.ProcessorDiscardedLogRecords
+	}
+
+	incomingCount.Add(ctx, int64(incoming), metric.WithAttributes(or.otelAttrs...))
+	discardedCount.Add(ctx, int64(discarded), metric.WithAttributes(or.otelAttrs...))
+}
+
 func (or *ObsReport) recordData(ctx context.Context, dataType component.DataType, accepted, refused, dropped, inserted int64) {
 	var acceptedCount, refusedCount, droppedCount, insertedCount metric.Int64Counter
 	switch dataType {
@@ -104,6 +146,26 @@ func (or *ObsReport) recordData(ctx context.Context, dataType component.DataType
 	insertedCount.Add(ctx, inserted, metric.WithAttributes(or.otelAttrs...))
 }
 
+func (or *ObsReport) recordPanic(ctx context.Context) {
+	or.telemetryBuilder.ProcessorPanics.Add(ctx, 1, metric.WithAttributes(or.otelAttrs...))
+}
+
+// recordProcessError records an error returned by the process function, classified as permanent or retryable.
+func (or *ObsReport) recordProcessError(ctx context.Context, err error) {
+	classification := errorClassificationRetryable
+	if consumererror.IsPermanent(err) {
+		classification = errorClassificationPermanent
+	}
+	attrs := append([]attribute.KeyValue{attribute.String(errorClassificationKey, classification)}, or.otelAttrs...)
+	or.telemetryBuilder.ProcessorProcessErrors.Add(ctx, 1, metric.WithAttributes(attrs...))
+}
+
+func (or *ObsReport) recordSpansDropped(ctx context.Context, reason string, numSpans int) {
+	attrs := append([]attribute.KeyValue{attribute.String(spanDropReasonKey, reason)}, or.otelAttrs...)
+	or.telemetryBuilder.ProcessorSpansDropped.Add(ctx, int64(numSpans), metric.WithAttributes(attrs...))
+	or.telemetryBuilder.ProcessorDroppedSpans.Add(ctx, int64(numSpans), metric.WithAttributes(or.otelAttrs...))
+}
+
 // TracesAccepted reports that the trace data was accepted.
 func (or *ObsReport) TracesAccepted(ctx context.Context, numSpans int) {
 	or.recordData(ctx, component.DataTypeTraces, int64(numSpans), int64(0), int64(0), int64(0))
@@ -11,11 +11,14 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/metric/noop"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processortest"
 )
 
 var (
@@ -285,6 +288,36 @@ func TestNoMetrics(t *testing.T) {
 	})
 }
 
+// newTestSettingsWithMetricReader returns processor settings recording the metrics of all the levels
+// to the returned reader.
+func newTestSettingsWithMetricReader() (processor.Settings, *sdkmetric.ManualReader) {
+	metricReader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(metricReader))
+	set := processortest.NewNopSettings()
+	set.TelemetrySettings.MetricsLevel = configtelemetry.LevelBasic
+	set.TelemetrySettings.LeveledMeterProvider = func(configtelemetry.Level) metric.MeterProvider {
+		return mp
+	}
+	return set, metricReader
+}
+
+// metricSums returns the values of the sums collected by the reader, by metric name, for a single
+// processor.
+func metricSums(t *testing.T, metricReader *sdkmetric.ManualReader) map[string]int64 {
+	rm := metricdata.ResourceMetrics{}
+	require.NoError(t, metricReader.Collect(context.Background(), &rm))
+	sums := map[string]int64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			sum, ok := m.Data.(metricdata.Sum[int64])
+			require.True(t, ok)
+			require.Len(t, sum.DataPoints, 1)
+			sums[m.Name] = sum.DataPoints[0].Value
+		}
+	}
+	return sums
+}
+
 func testTelemetry(t *testing.T, id component.ID, testFunc func(t *testing.T, tt componenttest.TestTelemetry)) {
 	tt, err := componenttest.SetupTelemetry(id)
 	require.NoError(t, err)
@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package processorhelper // import "go.opentelemetry.io/collector/processor/processorhelper"
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// PanicError is the error returned by a processor created with WithPanicRecovery when its process
+// function panics.
+type PanicError struct {
+	// Value is the value passed to panic.
+	Value any
+	// Stack is the stack trace of the goroutine at the time of the panic.
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("processor panicked: %v", e.Value)
+}
+
+// panicRecoverer recovers from the panics of the process functions, recording the incoming items
+// of the data being processed and the panic.
+type panicRecoverer struct {
+	obs *ObsReport
+}
+
+// newPanicRecoverer returns a panicRecoverer, or nil if the panics must not be recovered.
+func newPanicRecoverer(obs *ObsReport, recoverPanics bool) *panicRecoverer {
+	if !recoverPanics {
+		return nil
+	}
+	return &panicRecoverer{obs: obs}
+}
+
+// recover converts v, a value returned by a recover call, into a permanent error wrapping a *PanicError,
+// or returns nil if there was no panic. The error is permanent since processing the same data again would
+// panic again. The incoming items are recorded without outgoing items, as no data is sent.
+func (pr *panicRecoverer) recover(ctx context.Context, v any, dataType component.DataType, incoming int) error {
+	if v == nil {
+		return nil
+	}
+	pr.obs.recordInOut(ctx, dataType, incoming, 0)
+	pr.obs.recordPanic(ctx)
+	return consumererror.NewPermanent(&PanicError{Value: v, Stack: debug.Stack()})
+}
+
+// wrapLogs returns a ProcessLogsFunc recovering from the panics of logsFunc.
+func (pr *panicRecoverer) wrapLogs(logsFunc ProcessLogsFunc) ProcessLogsFunc {
+	if pr == nil {
+		return logsFunc
+	}
+	return func(ctx context.Context, ld plog.Logs) (out plog.Logs, err error) {
+		recordsIn := ld.LogRecordCount()
+		defer func() {
+			if panicErr := pr.recover(ctx, recover(), component.DataTypeLogs, recordsIn); panicErr != nil {
+				out, err = ld, panicErr
+			}
+		}()
+		return logsFunc(ctx, ld)
+	}
+}
+
+// wrapMetrics returns a ProcessMetricsFunc recovering from the panics of metricsFunc.
+func (pr *panicRecoverer) wrapMetrics(metricsFunc ProcessMetricsFunc) ProcessMetricsFunc {
+	if pr == nil {
+		return metricsFunc
+	}
+	return func(ctx context.Context, md pmetric.Metrics) (out pmetric.Metrics, err error) {
+		pointsIn := md.DataPointCount()
+		defer func() {
+			if panicErr := pr.recover(ctx, recover(), component.DataTypeMetrics, pointsIn); panicErr != nil {
+				out, err = md, panicErr
+			}
+		}()
+		return metricsFunc(ctx, md)
+	}
+}
+
+// wrapTraces returns a ProcessTracesFunc recovering from the panics of tracesFunc.
+func (pr *panicRecoverer) wrapTraces(tracesFunc ProcessTracesFunc) ProcessTracesFunc {
+	if pr == nil {
+		return tracesFunc
+	}
+	return func(ctx context.Context, td ptrace.Traces) (out ptrace.Traces, err error) {
+		spansIn := td.SpanCount()
+		defer func() {
+			if panicErr := pr.recover(ctx, recover(), component.DataTypeTraces, spansIn); panicErr != nil {
+				out, err = td, panicErr
+			}
+		}()
+		return tracesFunc(ctx, td)
+	}
+}
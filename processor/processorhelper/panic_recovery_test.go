@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package processorhelper
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/testdata"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processortest"
+)
+
+// assertPanicMetrics asserts that one panic was recorded, with the incoming items and no outgoing items.
+func assertPanicMetrics(t *testing.T, set processor.Settings, metricReader *sdkmetric.ManualReader, incomingName, outgoingName string, incoming int64) {
+	rm := metricdata.ResourceMetrics{}
+	require.NoError(t, metricReader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	metrics := map[string]metricdata.Aggregation{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		metrics[m.Name] = m.Data
+	}
+
+	sum := func(v int64) metricdata.Sum[int64] {
+		return metricdata.Sum[int64]{
+			Temporality: metricdata.CumulativeTemporality,
+			IsMonotonic: true,
+			DataPoints: []metricdata.DataPoint[int64]{
+				{
+					Attributes: attribute.NewSet(attribute.String("processor", set.ID.String())),
+					Value:      v,
+				},
+			},
+		}
+	}
+	require.Contains(t, metrics, "otelcol_processor_panics")
+	metricdatatest.AssertAggregationsEqual(t, sum(1), metrics["otelcol_processor_panics"], metricdatatest.IgnoreTimestamp())
+	require.Contains(t, metrics, incomingName)
+	metricdatatest.AssertAggregationsEqual(t, sum(incoming), metrics[incomingName], metricdatatest.IgnoreTimestamp())
+	require.Contains(t, metrics, outgoingName)
+	metricdatatest.AssertAggregationsEqual(t, sum(0), metrics[outgoingName], metricdatatest.IgnoreTimestamp())
+}
+
+func TestLogsProcessor_PanicRecovery(t *testing.T) {
+	set, metricReader := newTestSettingsWithMetricReader()
+	sink := new(consumertest.LogsSink)
+	lp, err := NewLogsProcessor(context.Background(), set, &testLogsCfg, sink, func(context.Context, plog.Logs) (plog.Logs, error) {
+		var attrs map[string]string
+		attrs["key"] = "value"
+		return plog.NewLogs(), nil
+	}, WithPanicRecovery())
+	require.NoError(t, err)
+
+	err = lp.ConsumeLogs(context.Background(), testdata.GenerateLogs(3))
+	assert.True(t, consumererror.IsPermanent(err))
+	var panicErr *PanicError
+	require.ErrorAs(t, err, &panicErr)
+	assert.Contains(t, panicErr.Error(), "processor panicked: assignment to entry in nil map")
+	assert.Contains(t, string(panicErr.Stack), "TestLogsProcessor_PanicRecovery")
+	assert.Empty(t, sink.AllLogs())
+	assertPanicMetrics(t, set, metricReader, "otelcol_processor_incoming_log_records", "otelcol_processor_outgoing_log_records", 3)
+}
+
+func TestMetricsProcessor_PanicRecovery(t *testing.T) {
+	set, metricReader := newTestSettingsWithMetricReader()
+	sink := new(consumertest.MetricsSink)
+	mp, err := NewMetricsProcessor(context.Background(), set, &testMetricsCfg, sink, func(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+		_ = md.ResourceMetrics().At(10)
+		return md, nil
+	}, WithPanicRecovery())
+	require.NoError(t, err)
+
+	err = mp.ConsumeMetrics(context.Background(), testdata.GenerateMetrics(1))
+	assert.True(t, consumererror.IsPermanent(err))
+	var panicErr *PanicError
+	require.ErrorAs(t, err, &panicErr)
+	assert.Empty(t, sink.AllMetrics())
+	assertPanicMetrics(t, set, metricReader, "otelcol_processor_incoming_metric_points", "otelcol_processor_outgoing_metric_points",
+		int64(testdata.GenerateMetrics(1).DataPointCount()))
+}
+
+func TestTracesProcessor_PanicRecovery(t *testing.T) {
+	set, metricReader := newTestSettingsWithMetricReader()
+	sink := new(consumertest.TracesSink)
+	tp, err := NewTracesProcessor(context.Background(), set, &testTracesCfg, sink, func(context.Context, ptrace.Traces) (ptrace.Traces, error) {
+		panic(errors.New("malformed data"))
+	}, WithPanicRecovery())
+	require.NoError(t, err)
+
+	err = tp.ConsumeTraces(context.Background(), testdata.GenerateTraces(2))
+	assert.True(t, consumererror.IsPermanent(err))
+	var panicErr *PanicError
+	require.ErrorAs(t, err, &panicErr)
+	assert.EqualError(t, panicErr, "processor panicked: malformed data")
+	assert.Empty(t, sink.AllTraces())
+	assertPanicMetrics(t, set, metricReader, "otelcol_processor_incoming_spans", "otelcol_processor_outgoing_spans", 2)
+}
+
+func TestProcessor_PanicRecoveryDisabled(t *testing.T) {
+	tp, err := NewTracesProcessor(context.Background(), processortest.NewNopSettings(), &testTracesCfg, consumertest.NewNop(),
+		func(context.Context, ptrace.Traces) (ptrace.Traces, error) {
+			panic("malformed data")
+		})
+	require.NoError(t, err)
+	assert.PanicsWithValue(t, "malformed data", func() {
+		_ = tp.ConsumeTraces(context.Background(), testdata.GenerateTraces(1))
+	})
+}
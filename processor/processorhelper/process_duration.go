@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package processorhelper // import "go.opentelemetry.io/collector/processor/processorhelper"
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/multierr"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// processDurationPercentiles are the percentiles of the durations reported by the processor_process_duration
+// metric, by value of the percentile attribute.
+var processDurationPercentiles = []struct {
+	name       string
+	percentile float64
+}{
+	{name: "p50", percentile: 0.5},
+	{name: "p95", percentile: 0.95},
+	{name: "p99", percentile: 0.99},
+}
+
+// processDurations estimates the percentiles of the durations of the process function since the processor
+// was created.
+type processDurations struct {
+	mu         sync.Mutex
+	estimators []*p2Quantile
+
+	// now returns the current time, it can be replaced in tests.
+	now func() time.Time
+}
+
+// newProcessDurations returns a processDurations reporting the processor_process_duration metric, or nil if
+// the durations must not be estimated.
+func newProcessDurations(obs *ObsReport, enabled bool) (*processDurations, error) {
+	if !enabled {
+		return nil, nil
+	}
+	pd := &processDurations{now: time.Now}
+	var err error
+	for i, p := range processDurationPercentiles {
+		pd.estimators = append(pd.estimators, newP2Quantile(p.percentile))
+		estimator := pd.estimators[i]
+		attrs := append([]attribute.KeyValue{attribute.String("percentile", p.name)}, obs.otelAttrs...)
+		err = multierr.Append(err, obs.telemetryBuilder.InitProcessorProcessDuration(func() float64 {
+			pd.mu.Lock()
+			defer pd.mu.Unlock()
+			return estimator.value()
+		}, metric.WithAttributeSet(attribute.NewSet(attrs...))))
+	}
+	return pd, err
+}
+
+// record adds the duration of a call of the process function started at start.
+func (pd *processDurations) record(start time.Time) {
+	seconds := pd.now().Sub(start).Seconds()
+	pd.mu.Lock()
+	defer pd.mu.Unlock()
+	for _, estimator := range pd.estimators {
+		estimator.add(seconds)
+	}
+}
+
+// wrapLogs returns a ProcessLogsFunc recording the durations of logsFunc.
+func (pd *processDurations) wrapLogs(logsFunc ProcessLogsFunc) ProcessLogsFunc {
+	if pd == nil {
+		return logsFunc
+	}
+	return func(ctx context.Context, ld plog.Logs) (plog.Logs, error) {
+		defer pd.record(pd.now())
+		return logsFunc(ctx, ld)
+	}
+}
+
+// wrapMetrics returns a ProcessMetricsFunc recording the durations of metricsFunc.
+func (pd *processDurations) wrapMetrics(metricsFunc ProcessMetricsFunc) ProcessMetricsFunc {
+	if pd == nil {
+		return metricsFunc
+	}
+	return func(ctx context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+		defer pd.record(pd.now())
+		return metricsFunc(ctx, md)
+	}
+}
+
+// wrapTraces returns a ProcessTracesFunc recording the durations of tracesFunc.
+func (pd *processDurations) wrapTraces(tracesFunc ProcessTracesFunc) ProcessTracesFunc {
+	if pd == nil {
+		return tracesFunc
+	}
+	return func(ctx context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+		defer pd.record(pd.now())
+		return tracesFunc(ctx, td)
+	}
+}
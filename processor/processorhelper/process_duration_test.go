@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package processorhelper
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processortest"
+)
+
+func newProcessDurationSettings() (processor.Settings, *sdkmetric.ManualReader) {
+	metricReader := sdkmetric.NewManualReader()
+	set := processortest.NewNopSettings()
+	set.TelemetrySettings.MetricsLevel = configtelemetry.LevelBasic
+	set.TelemetrySettings.LeveledMeterProvider = func(level configtelemetry.Level) metric.MeterProvider {
+		if level >= configtelemetry.LevelBasic {
+			return sdkmetric.NewMeterProvider(sdkmetric.WithReader(metricReader))
+		}
+		return nil
+	}
+	return set, metricReader
+}
+
+// processDurationGauges returns the values of the processor_process_duration metric by percentile.
+func processDurationGauges(t *testing.T, metricReader *sdkmetric.ManualReader) map[string]float64 {
+	ownMetrics := new(metricdata.ResourceMetrics)
+	require.NoError(t, metricReader.Collect(context.Background(), ownMetrics))
+	gauges := map[string]float64{}
+	for _, sm := range ownMetrics.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != "otelcol_processor_process_duration" {
+				continue
+			}
+			for _, dp := range m.Data.(metricdata.Gauge[float64]).DataPoints {
+				percentile, _ := dp.Attributes.Value("percentile")
+				gauges[percentile.AsString()] = dp.Value
+			}
+		}
+	}
+	return gauges
+}
+
+func TestProcessDurations(t *testing.T) {
+	set, metricReader := newProcessDurationSettings()
+	obs, err := newObsReport(ObsReportSettings{ProcessorID: set.ID, ProcessorCreateSettings: set})
+	require.NoError(t, err)
+	pd, err := newProcessDurations(obs, true)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float64{"p50": 0, "p95": 0, "p99": 0}, processDurationGauges(t, metricReader))
+
+	// The process function takes 1ms to 1000ms, each duration once.
+	now := time.Now()
+	pd.now = func() time.Time { return now }
+	latency := time.Duration(0)
+	logsFunc := pd.wrapLogs(func(_ context.Context, ld plog.Logs) (plog.Logs, error) {
+		now = now.Add(latency)
+		return ld, nil
+	})
+	for i := 0; i < 1000; i++ {
+		latency = time.Duration((i*7919)%1000+1) * time.Millisecond
+		_, err = logsFunc(context.Background(), plog.NewLogs())
+		require.NoError(t, err)
+	}
+
+	gauges := processDurationGauges(t, metricReader)
+	assert.InDelta(t, 0.5, gauges["p50"], 0.02)
+	assert.InDelta(t, 0.95, gauges["p95"], 0.02)
+	assert.InDelta(t, 0.99, gauges["p99"], 0.02)
+}
+
+func TestProcessDurationsDisabled(t *testing.T) {
+	set, metricReader := newProcessDurationSettings()
+	lp, err := NewLogsProcessor(context.Background(), set, &testLogsCfg, consumertest.NewNop(), newTestLProcessor(nil))
+	require.NoError(t, err)
+	require.NoError(t, lp.ConsumeLogs(context.Background(), plog.NewLogs()))
+	assert.Empty(t, processDurationGauges(t, metricReader))
+}
+
+func TestProcessorWithProcessDurationPercentiles(t *testing.T) {
+	set, metricReader := newProcessDurationSettings()
+	lp, err := NewLogsProcessor(context.Background(), set, &testLogsCfg, consumertest.NewNop(),
+		func(_ context.Context, ld plog.Logs) (plog.Logs, error) {
+			time.Sleep(time.Millisecond)
+			return ld, nil
+		}, WithProcessDurationPercentiles())
+	require.NoError(t, err)
+	for i := 0; i < 10; i++ {
+		require.NoError(t, lp.ConsumeLogs(context.Background(), plog.NewLogs()))
+	}
+
+	gauges := processDurationGauges(t, metricReader)
+	require.Len(t, gauges, 3)
+	for percentile, value := range gauges {
+		assert.GreaterOrEqual(t, value, time.Millisecond.Seconds(), percentile)
+	}
+}
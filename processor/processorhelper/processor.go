@@ -13,6 +13,7 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumerdeadletter"
 	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
 )
 
@@ -45,13 +46,108 @@ func WithShutdown(shutdown component.ShutdownFunc) Option {
 func WithCapabilities(capabilities consumer.Capabilities) Option {
 	return func(o *baseSettings) {
 		o.consumerOptions = append(o.consumerOptions, consumer.WithCapabilities(capabilities))
+		o.mutatesData = capabilities.MutatesData
+	}
+}
+
+// WithStaticMetricAttributes adds the given attributes to every metric recorded by the processor,
+// alongside the `processor` attribute identifying it. The `processor` key is reserved and cannot be
+// overridden.
+func WithStaticMetricAttributes(attrs ...attribute.KeyValue) Option {
+	return func(o *baseSettings) {
+		o.metricAttributes = append(o.metricAttributes, attrs...)
+	}
+}
+
+// WithMetricsByComponentType sets the `processor` attribute of the metrics recorded by the processor to
+// its type instead of its full ID, e.g. `batch` instead of `batch/traces`. This bounds the cardinality of
+// the metrics in deployments with many processors of the same type, which are then aggregated.
+func WithMetricsByComponentType() Option {
+	return func(o *baseSettings) {
+		o.metricsByType = true
+	}
+}
+
+// WithPanicRecovery makes the processor recover from the panics of its process function. A recovered
+// panic is returned by the processor as a permanent error, see consumererror.IsPermanent, wrapping a
+// *PanicError, so the data is not retried, and is counted by the processor_panics metric. By default,
+// the panics are not recovered.
+func WithPanicRecovery() Option {
+	return func(o *baseSettings) {
+		o.recoverPanics = true
+	}
+}
+
+// WithProcessDurationPercentiles enables the processor_process_duration metric, reporting the p50, p95 and p99
+// percentiles of the duration of the process function, estimated in constant memory over all the calls since
+// the processor was created. By default, the metric is not reported.
+func WithProcessDurationPercentiles() Option {
+	return func(o *baseSettings) {
+		o.durationPercentiles = true
+	}
+}
+
+// WithConcurrency makes the processor split the incoming data by resource, and run the process function
+// on the data of every resource with up to workers goroutines. The results are merged, in order, before
+// being sent to the next component. The errors of the resources are combined and returned, while
+// ErrSkipProcessingData only discards the data of the resource it is returned for. This is only meant
+// for processors handling every resource independently. By default, or if workers is not greater than 1,
+// the process function is called once with all the data.
+func WithConcurrency(workers int) Option {
+	return func(o *baseSettings) {
+		o.workers = workers
+	}
+}
+
+// WithDeadLetter sends the data dropped by the processor to the dead-letter consumers instead of discarding it:
+// the data for which the process function returns ErrSkipProcessingData, and the spans dropped by a processor
+// created with NewTracesProcessorWithDrops. The reason of the drop, available with
+// consumerdeadletter.ReasonFromContext, is the reason given to SpanDrops.Drop for the spans, and the message of
+// the error for the data skipped with an error wrapping ErrSkipProcessingData. The failures of the dead-letter
+// consumers are logged, the processor not returning them. By default, the dropped data is sent to the dead-letter
+// consumers the host provides on start with consumerdeadletter.Provider, set when the pipeline configures a
+// dead-letter exporter, if any.
+func WithDeadLetter(consumers consumerdeadletter.Consumers) Option {
+	return func(o *baseSettings) {
+		o.deadLetter = &consumers
+	}
+}
+
+// WithAttributeRulesFile makes the processor apply the attribute rules of the YAML file at path to the attributes of
+// the spans, log records and metric data points returned by the process function. The file is read every second
+// while the processor is started, and reloaded once its content changed, the current rules being kept if it is
+// invalid. Its rules, applied in order, either rename, drop or set a string attribute:
+//
+//	rules:
+//	  - action: rename
+//	    key: http.method
+//	    new_key: http.request.method
+//	  - action: drop
+//	    key: user.email
+//	  - action: set
+//	    key: deployment.environment
+//	    value: production
+//
+// The file must not be empty, "rules: []" applying no rules. The processor mutates the data, whatever its capabilities.
+func WithAttributeRulesFile(path string) Option {
+	return func(o *baseSettings) {
+		o.attributeRulesFile = path
 	}
 }
 
 type baseSettings struct {
 	component.StartFunc
 	component.ShutdownFunc
-	consumerOptions []consumer.Option
+	consumerOptions  []consumer.Option
+	metricAttributes []attribute.KeyValue
+	metricsByType    bool
+	mutatesData      bool
+	recoverPanics    bool
+	// durationPercentiles enables the processor_process_duration metric.
+	durationPercentiles bool
+	workers             int
+	deadLetter          *consumerdeadletter.Consumers
+	attributeRulesFile  string
 }
 
 // fromOptions returns the internal settings starting from the default and applying all options.
@@ -59,11 +155,17 @@ func fromOptions(options []Option) *baseSettings {
 	// Start from the default options:
 	opts := &baseSettings{
 		consumerOptions: []consumer.Option{consumer.WithCapabilities(consumer.Capabilities{MutatesData: true})},
+		mutatesData:     true,
 	}
 
 	for _, op := range options {
 		op(opts)
 	}
+	if opts.attributeRulesFile != "" && !opts.mutatesData {
+		// The attribute rules modify the data.
+		opts.consumerOptions = append(opts.consumerOptions, consumer.WithCapabilities(consumer.Capabilities{MutatesData: true}))
+		opts.mutatesData = true
+	}
 
 	return opts
 }
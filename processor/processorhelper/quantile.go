@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package processorhelper // import "go.opentelemetry.io/collector/processor/processorhelper"
+
+import (
+	"math"
+	"sort"
+)
+
+// p2Quantile estimates a quantile of a stream of values in constant memory, with the P² algorithm of Jain and
+// Chlamtac, which keeps five markers whose heights approximate the minimum, the quantile/2, the quantile,
+// (1+quantile)/2 quantiles and the maximum of the values added so far. It is not safe for concurrent use.
+type p2Quantile struct {
+	quantile float64
+	count    int
+	// heights are the heights of the markers, the first count values sorted until five values are added.
+	heights [5]float64
+	// positions are the actual positions of the markers, from 1.
+	positions [5]float64
+	// desired are the desired positions of the markers, incremented by increments for every value.
+	desired    [5]float64
+	increments [5]float64
+}
+
+func newP2Quantile(quantile float64) *p2Quantile {
+	return &p2Quantile{
+		quantile:   quantile,
+		desired:    [5]float64{1, 1 + 2*quantile, 1 + 4*quantile, 3 + 2*quantile, 5},
+		increments: [5]float64{0, quantile / 2, quantile, (1 + quantile) / 2, 1},
+	}
+}
+
+func (q *p2Quantile) add(x float64) {
+	if q.count < len(q.heights) {
+		q.heights[q.count] = x
+		q.count++
+		if q.count == len(q.heights) {
+			sort.Float64s(q.heights[:])
+			q.positions = [5]float64{1, 2, 3, 4, 5}
+		}
+		return
+	}
+	q.count++
+
+	// Find the cell of x, extending the extreme markers if needed, and shift the markers above it.
+	var k int
+	switch {
+	case x < q.heights[0]:
+		q.heights[0] = x
+		k = 0
+	case x >= q.heights[4]:
+		q.heights[4] = x
+		k = 3
+	default:
+		for k = 0; k < 3 && x >= q.heights[k+1]; k++ {
+		}
+	}
+	for i := k + 1; i < len(q.positions); i++ {
+		q.positions[i]++
+	}
+	for i := range q.desired {
+		q.desired[i] += q.increments[i]
+	}
+
+	// Move the middle markers towards their desired positions, adjusting their heights.
+	for i := 1; i < 4; i++ {
+		d := q.desired[i] - q.positions[i]
+		if (d >= 1 && q.positions[i+1]-q.positions[i] > 1) || (d <= -1 && q.positions[i-1]-q.positions[i] < -1) {
+			s := math.Copysign(1, d)
+			h := q.parabolic(i, s)
+			if h <= q.heights[i-1] || h >= q.heights[i+1] {
+				h = q.linear(i, s)
+			}
+			q.heights[i] = h
+			q.positions[i] += s
+		}
+	}
+}
+
+// parabolic returns the height of the marker i moved by s with the piecewise-parabolic prediction.
+func (q *p2Quantile) parabolic(i int, s float64) float64 {
+	n, h := q.positions, q.heights
+	return h[i] + s/(n[i+1]-n[i-1])*((n[i]-n[i-1]+s)*(h[i+1]-h[i])/(n[i+1]-n[i])+(n[i+1]-n[i]-s)*(h[i]-h[i-1])/(n[i]-n[i-1]))
+}
+
+// linear returns the height of the marker i moved by s with the linear prediction.
+func (q *p2Quantile) linear(i int, s float64) float64 {
+	j := i + int(s)
+	return q.heights[i] + s*(q.heights[j]-q.heights[i])/(q.positions[j]-q.positions[i])
+}
+
+// value returns the estimated quantile, the nearest-rank quantile of the values until five are added, or 0 if
+// none was added.
+func (q *p2Quantile) value() float64 {
+	if q.count == 0 {
+		return 0
+	}
+	if q.count < len(q.heights) {
+		values := make([]float64, q.count)
+		copy(values, q.heights[:q.count])
+		sort.Float64s(values)
+		rank := int(math.Ceil(q.quantile*float64(q.count))) - 1
+		if rank < 0 {
+			rank = 0
+		}
+		return values[rank]
+	}
+	return q.heights[2]
+}
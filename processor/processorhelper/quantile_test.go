@@ -0,0 +1,53 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package processorhelper
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestP2QuantileUniform(t *testing.T) {
+	// The values 1 to 100000 in a random order.
+	r := rand.New(rand.NewSource(1))
+	values := r.Perm(100_000)
+	for _, quantile := range []float64{0.5, 0.95, 0.99} {
+		q := newP2Quantile(quantile)
+		for _, v := range values {
+			q.add(float64(v + 1))
+		}
+		assert.InEpsilon(t, quantile*100_000, q.value(), 0.01, "quantile %v", quantile)
+	}
+}
+
+func TestP2QuantileExponential(t *testing.T) {
+	// Latencies of 10ms on average with a long tail, whose quantile q is -ln(1-q)*10ms.
+	r := rand.New(rand.NewSource(1))
+	for quantile, want := range map[float64]float64{0.5: 0.00693, 0.95: 0.02996, 0.99: 0.04605} {
+		q := newP2Quantile(quantile)
+		for i := 0; i < 100_000; i++ {
+			q.add(r.ExpFloat64() * 0.01)
+		}
+		assert.InEpsilon(t, want, q.value(), 0.05, "quantile %v", quantile)
+	}
+}
+
+func TestP2QuantileFewValues(t *testing.T) {
+	q := newP2Quantile(0.5)
+	assert.Zero(t, q.value())
+	q.add(3)
+	assert.Equal(t, float64(3), q.value())
+	q.add(1)
+	q.add(2)
+	// The nearest-rank median until five values are added.
+	assert.Equal(t, float64(2), q.value())
+
+	q = newP2Quantile(0.99)
+	for i := 0; i < 1000; i++ {
+		q.add(5)
+	}
+	assert.Equal(t, float64(5), q.value())
+}
@@ -0,0 +1,131 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package processorhelper // import "go.opentelemetry.io/collector/processor/processorhelper"
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor"
+)
+
+// spanDropReasonKey is the attribute of the processor_spans_dropped metric holding the drop reason.
+const spanDropReasonKey = "reason"
+
+// SpanDrops collects the spans a ProcessTracesWithDropsFunc decides to drop, with the reason of each drop.
+type SpanDrops struct {
+	reasons map[ptrace.Span]string
+}
+
+// Drop marks the span to be dropped for the reason. The span must be part of the traces returned by the
+// ProcessTracesWithDropsFunc; marking the same span again overrides the reason.
+func (sd *SpanDrops) Drop(span ptrace.Span, reason string) {
+	sd.reasons[span] = reason
+}
+
+// Len returns the number of spans marked to be dropped.
+func (sd *SpanDrops) Len() int {
+	return len(sd.reasons)
+}
+
+// ProcessTracesWithDropsFunc is a helper function that processes the incoming data and returns the data to be sent
+// to the next component, marking in drops the spans of the returned data which must be dropped.
+// If error is returned then returned data are ignored. It MUST not call the next component.
+type ProcessTracesWithDropsFunc func(context.Context, ptrace.Traces, *SpanDrops) (ptrace.Traces, error)
+
+// NewTracesProcessorWithDrops creates a processor.Traces like NewTracesProcessor, for a tracesFunc which can drop
+// individual spans. The dropped spans are removed, with the scopes and resources left without spans, before the
+// data is sent to the next component, and are counted by the processor_spans_dropped metric for their reason, as well
+// as by the processor_dropped_spans metric.
+// As dropping spans mutates the data, the processor must not be created with capabilities not mutating data.
+func NewTracesProcessorWithDrops(
+	_ context.Context,
+	set processor.Settings,
+	_ component.Config,
+	nextConsumer consumer.Traces,
+	tracesFunc ProcessTracesWithDropsFunc,
+	options ...Option,
+) (processor.Traces, error) {
+	if tracesFunc == nil {
+		return nil, errors.New("nil tracesFunc")
+	}
+	return newTracesProcessor(set, nextConsumer, func(obs *ObsReport, dl *deadLetter) ProcessTracesFunc {
+		return func(ctx context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+			drops := &SpanDrops{reasons: make(map[ptrace.Span]string)}
+			td, err := tracesFunc(ctx, td, drops)
+			if err != nil || drops.Len() == 0 {
+				return td, err
+			}
+			counts, dropped := drops.remove(td, dl.hasTraces())
+			for reason, count := range counts {
+				obs.recordSpansDropped(ctx, reason, count)
+			}
+			reasons := make([]string, 0, len(dropped))
+			for reason := range dropped {
+				reasons = append(reasons, reason)
+			}
+			sort.Strings(reasons)
+			for _, reason := range reasons {
+				// The dropped spans are copied into new Traces owned by the processor, sent as is.
+				dl.logFailure(reason, dl.consumers.SendTraces(ctx, dropped[reason], reason))
+			}
+			return td, nil
+		}
+	}, options...)
+}
+
+// remove removes the dropped spans from td and returns the number of removed spans per reason, and, if collect
+// is set, the removed spans per reason, with their resource and scope.
+func (sd *SpanDrops) remove(td ptrace.Traces, collect bool) (map[string]int, map[string]ptrace.Traces) {
+	counts := make(map[string]int)
+	dropped := make(map[string]ptrace.Traces)
+	td.ResourceSpans().RemoveIf(func(rs ptrace.ResourceSpans) bool {
+		removedScopes := false
+		droppedResources := make(map[string]ptrace.ResourceSpans)
+		rs.ScopeSpans().RemoveIf(func(ss ptrace.ScopeSpans) bool {
+			removedSpans := false
+			droppedScopes := make(map[string]ptrace.ScopeSpans)
+			ss.Spans().RemoveIf(func(span ptrace.Span) bool {
+				reason, ok := sd.reasons[span]
+				if !ok {
+					return false
+				}
+				counts[reason]++
+				removedSpans = true
+				if collect {
+					dss, found := droppedScopes[reason]
+					if !found {
+						drs, found := droppedResources[reason]
+						if !found {
+							dtd, found := dropped[reason]
+							if !found {
+								dtd = ptrace.NewTraces()
+								dropped[reason] = dtd
+							}
+							drs = dtd.ResourceSpans().AppendEmpty()
+							rs.Resource().CopyTo(drs.Resource())
+							drs.SetSchemaUrl(rs.SchemaUrl())
+							droppedResources[reason] = drs
+						}
+						dss = drs.ScopeSpans().AppendEmpty()
+						ss.Scope().CopyTo(dss.Scope())
+						dss.SetSchemaUrl(ss.SchemaUrl())
+						droppedScopes[reason] = dss
+					}
+					span.MoveTo(dss.Spans().AppendEmpty())
+				}
+				return true
+			})
+			removed := removedSpans && ss.Spans().Len() == 0
+			removedScopes = removedScopes || removed
+			return removed
+		})
+		return removedScopes && rs.ScopeSpans().Len() == 0
+	})
+	return counts, dropped
+}
@@ -0,0 +1,143 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package processorhelper
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor/processortest"
+)
+
+func TestNewTracesProcessorWithDrops_NilRequiredFields(t *testing.T) {
+	_, err := NewTracesProcessorWithDrops(context.Background(), processortest.NewNopSettings(), &testTracesCfg, consumertest.NewNop(), nil)
+	assert.Error(t, err)
+}
+
+func TestNewTracesProcessorWithDrops_ProcessTracesError(t *testing.T) {
+	want := errors.New("my_error")
+	sink := new(consumertest.TracesSink)
+	tp, err := NewTracesProcessorWithDrops(context.Background(), processortest.NewNopSettings(), &testTracesCfg, sink,
+		func(_ context.Context, td ptrace.Traces, drops *SpanDrops) (ptrace.Traces, error) {
+			drops.Drop(td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0), "sampled")
+			return td, want
+		})
+	require.NoError(t, err)
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	assert.Equal(t, want, tp.ConsumeTraces(context.Background(), td))
+	assert.Zero(t, sink.SpanCount())
+}
+
+func TestTracesProcessorWithDrops(t *testing.T) {
+	td := ptrace.NewTraces()
+	for _, names := range [][]string{{"keep-1", "rate-1", "error-1"}, {"rate-2", "rate-3"}} {
+		spans := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+		for _, name := range names {
+			spans.AppendEmpty().SetName(name)
+		}
+	}
+	// A scope without spans is kept.
+	td.ResourceSpans().At(0).ScopeSpans().AppendEmpty()
+
+	dropFunc := func(_ context.Context, td ptrace.Traces, drops *SpanDrops) (ptrace.Traces, error) {
+		rss := td.ResourceSpans()
+		for i := 0; i < rss.Len(); i++ {
+			sss := rss.At(i).ScopeSpans()
+			for j := 0; j < sss.Len(); j++ {
+				spans := sss.At(j).Spans()
+				for k := 0; k < spans.Len(); k++ {
+					switch spans.At(k).Name()[:4] {
+					case "rate":
+						drops.Drop(spans.At(k), "rate_limited")
+					case "erro":
+						drops.Drop(spans.At(k), "probabilistic")
+					}
+				}
+			}
+		}
+		return td, nil
+	}
+
+	metricReader := sdkmetric.NewManualReader()
+	set := processortest.NewNopSettings()
+	set.TelemetrySettings.MetricsLevel = configtelemetry.LevelBasic
+	set.TelemetrySettings.LeveledMeterProvider = func(level configtelemetry.Level) metric.MeterProvider {
+		if level >= configtelemetry.LevelBasic {
+			return sdkmetric.NewMeterProvider(sdkmetric.WithReader(metricReader))
+		}
+		return nil
+	}
+
+	sink := new(consumertest.TracesSink)
+	tp, err := NewTracesProcessorWithDrops(context.Background(), set, &testTracesCfg, sink, dropFunc)
+	require.NoError(t, err)
+	assert.NoError(t, tp.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, tp.ConsumeTraces(context.Background(), td))
+	assert.NoError(t, tp.Shutdown(context.Background()))
+
+	require.Len(t, sink.AllTraces(), 1)
+	got := sink.AllTraces()[0]
+	require.Equal(t, 1, got.SpanCount())
+	require.Equal(t, 1, got.ResourceSpans().Len())
+	assert.Equal(t, 2, got.ResourceSpans().At(0).ScopeSpans().Len())
+	assert.Equal(t, "keep-1", got.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Name())
+
+	ownMetrics := new(metricdata.ResourceMetrics)
+	require.NoError(t, metricReader.Collect(context.Background(), ownMetrics))
+	require.Len(t, ownMetrics.ScopeMetrics, 1)
+	var dropped, total *metricdata.Metrics
+	for i, m := range ownMetrics.ScopeMetrics[0].Metrics {
+		switch m.Name {
+		case "otelcol_processor_spans_dropped":
+			dropped = &ownMetrics.ScopeMetrics[0].Metrics[i]
+		case "otelcol_processor_dropped_spans":
+			total = &ownMetrics.ScopeMetrics[0].Metrics[i]
+		}
+	}
+	require.NotNil(t, dropped)
+	require.NotNil(t, total)
+	metricdatatest.AssertAggregationsEqual(t, metricdata.Sum[int64]{
+		Temporality: metricdata.CumulativeTemporality,
+		IsMonotonic: true,
+		DataPoints: []metricdata.DataPoint[int64]{
+			{
+				Attributes: attribute.NewSet(
+					attribute.String("processor", set.ID.String()),
+					attribute.String("reason", "rate_limited")),
+				Value: 3,
+			},
+			{
+				Attributes: attribute.NewSet(
+					attribute.String("processor", set.ID.String()),
+					attribute.String("reason", "probabilistic")),
+				Value: 1,
+			},
+		},
+	}, dropped.Data, metricdatatest.IgnoreTimestamp())
+	// The dropped spans are also counted by the processor_dropped_spans metric.
+	metricdatatest.AssertAggregationsEqual(t, metricdata.Sum[int64]{
+		Temporality: metricdata.CumulativeTemporality,
+		IsMonotonic: true,
+		DataPoints: []metricdata.DataPoint[int64]{
+			{
+				Attributes: attribute.NewSet(attribute.String("processor", set.ID.String())),
+				Value:      4,
+			},
+		},
+	}, total.Data, metricdatatest.IgnoreTimestamp())
+}
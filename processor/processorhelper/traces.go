@@ -38,28 +38,56 @@ func NewTracesProcessor(
 	if tracesFunc == nil {
 		return nil, errors.New("nil tracesFunc")
 	}
+	return newTracesProcessor(set, nextConsumer, func(*ObsReport, *deadLetter) ProcessTracesFunc { return tracesFunc }, options...)
+}
 
+// newTracesProcessor creates a processor.Traces processing the data with the ProcessTracesFunc
+// returned by newFunc for the ObsReport and the deadLetter of the processor.
+func newTracesProcessor(
+	set processor.Settings,
+	nextConsumer consumer.Traces,
+	newFunc func(*ObsReport, *deadLetter) ProcessTracesFunc,
+	options ...Option,
+) (processor.Traces, error) {
+	bs := fromOptions(options)
 	obs, err := newObsReport(ObsReportSettings{
 		ProcessorID:             set.ID,
 		ProcessorCreateSettings: set,
-	})
+		MetricsByComponentType:  bs.metricsByType,
+	}, bs.metricAttributes...)
+	if err != nil {
+		return nil, err
+	}
+
+	dl := newDeadLetter(bs, set)
+	tracesFunc := newConcurrency(obs, bs.workers, bs.mutatesData).wrapTraces(dl.wrapTraces(newFunc(obs, dl)))
+	tracesFunc = newMutationChecker(set.TelemetrySettings, set.ID, bs.mutatesData).wrapTraces(tracesFunc)
+	rules, err := newAttributeRules(bs.attributeRulesFile, set.Logger)
 	if err != nil {
 		return nil, err
 	}
+	tracesFunc = rules.wrapTraces(tracesFunc)
+	tracesFunc = newPanicRecoverer(obs, bs.recoverPanics).wrapTraces(tracesFunc)
+	durations, err := newProcessDurations(obs, bs.durationPercentiles)
+	if err != nil {
+		return nil, err
+	}
+	tracesFunc = durations.wrapTraces(tracesFunc)
 
 	eventOptions := spanAttributes(set.ID)
-	bs := fromOptions(options)
 	traceConsumer, err := consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
 		span := trace.SpanFromContext(ctx)
 		span.AddEvent("Start processing.", eventOptions)
 		spansIn := td.SpanCount()
 
-		td, err = tracesFunc(ctx, td)
+		td, err := tracesFunc(ctx, td)
 		span.AddEvent("End processing.", eventOptions)
 		if err != nil {
 			if errors.Is(err, ErrSkipProcessingData) {
+				obs.recordDiscarded(ctx, component.DataTypeTraces, spansIn, spansIn)
 				return nil
 			}
+			obs.recordProcessError(ctx, err)
 			return err
 		}
 		spansOut := td.SpanCount()
@@ -72,8 +100,8 @@ func NewTracesProcessor(
 	}
 
 	return &tracesProcessor{
-		StartFunc:    bs.StartFunc,
-		ShutdownFunc: bs.ShutdownFunc,
+		StartFunc:    dl.wrapStart(rules.wrapStart(bs.StartFunc)),
+		ShutdownFunc: rules.wrapShutdown(bs.ShutdownFunc),
 		Traces:       traceConsumer,
 	}, nil
 }
@@ -23,6 +23,7 @@ import (
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/testdata"
 	"go.opentelemetry.io/collector/processor/processortest"
 )
 
@@ -149,3 +150,19 @@ func TestTracesProcessor_RecordInOut(t *testing.T) {
 		},
 	}, outMetric.Data, metricdatatest.IgnoreTimestamp())
 }
+
+func TestTracesProcessor_RecordInOutSkipProcessingData(t *testing.T) {
+	set, metricReader := newTestSettingsWithMetricReader()
+	sink := new(consumertest.TracesSink)
+	p, err := NewTracesProcessor(context.Background(), set, &testTracesCfg, sink, newTestTProcessor(ErrSkipProcessingData))
+	require.NoError(t, err)
+
+	td := testdata.GenerateTraces(3)
+	require.NoError(t, p.ConsumeTraces(context.Background(), td))
+	assert.Zero(t, sink.SpanCount())
+
+	sums := metricSums(t, metricReader)
+	assert.Equal(t, int64(td.SpanCount()), sums["otelcol_processor_incoming_spans"])
+	assert.Equal(t, int64(td.SpanCount()), sums["otelcol_processor_discarded_spans"])
+	assert.NotContains(t, sums, "otelcol_processor_outgoing_spans")
+}
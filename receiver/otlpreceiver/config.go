@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"net/url"
 	"path"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configgrpc"
@@ -32,6 +33,26 @@ type HTTPConfig struct {
 
 	// The URL path to receive logs on. If omitted "/v1/logs" will be used.
 	LogsURLPath string `mapstructure:"logs_url_path,omitempty"`
+
+	// DisableJSON rejects the requests encoded in JSON with 415 Unsupported Media Type.
+	DisableJSON bool `mapstructure:"disable_json,omitempty"`
+
+	// DisableProto rejects the requests encoded in Protobuf with 415 Unsupported Media Type.
+	DisableProto bool `mapstructure:"disable_proto,omitempty"`
+
+	// ConnectionQuota limits the number of requests every connection can send. It is disabled by default.
+	ConnectionQuota ConnectionQuotaConfig `mapstructure:"connection_quota,omitempty"`
+}
+
+// ConnectionQuotaConfig limits the number of requests sent on every HTTP connection per window.
+type ConnectionQuotaConfig struct {
+	// MaxRequests is the number of requests a connection can send per window, the following ones being rejected
+	// with 429 Too Many Requests until the next window starts. Zero disables the quota.
+	MaxRequests int `mapstructure:"max_requests"`
+
+	// Window is the duration of the windows the requests of a connection are counted in, the first one starting
+	// with the first request of the connection. Required if MaxRequests is set.
+	Window time.Duration `mapstructure:"window"`
 }
 
 // Protocols is the configuration for the supported protocols.
@@ -44,6 +65,12 @@ type Protocols struct {
 type Config struct {
 	// Protocols is the configuration for the supported protocols, currently gRPC and HTTP (Proto and JSON).
 	Protocols `mapstructure:"protocols"`
+
+	// WaitForAcknowledgement, when true, makes the receiver respond to its clients once the components
+	// consuming the data acknowledge it with the consumerack package, e.g. the exporters with an in-memory
+	// sending queue created with exporterhelper.WithAcknowledgement once they sent it, instead of once the
+	// data is passed to the pipeline. The failure of the sending is then returned to the clients.
+	WaitForAcknowledgement bool `mapstructure:"wait_for_acknowledgement"`
 }
 
 var _ component.Config = (*Config)(nil)
@@ -54,6 +81,17 @@ func (cfg *Config) Validate() error {
 	if cfg.GRPC == nil && cfg.HTTP == nil {
 		return errors.New("must specify at least one protocol when using the OTLP receiver")
 	}
+	if cfg.HTTP != nil && cfg.HTTP.DisableJSON && cfg.HTTP.DisableProto {
+		return errors.New("must not disable both the JSON and Protobuf encodings of the HTTP protocol")
+	}
+	if cfg.HTTP != nil {
+		if cfg.HTTP.ConnectionQuota.MaxRequests < 0 {
+			return errors.New("connection_quota::max_requests must not be negative")
+		}
+		if cfg.HTTP.ConnectionQuota.MaxRequests > 0 && cfg.HTTP.ConnectionQuota.Window <= 0 {
+			return errors.New("connection_quota::window must be positive when connection_quota::max_requests is set")
+		}
+	}
 	return nil
 }
 
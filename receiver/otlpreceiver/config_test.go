@@ -99,6 +99,7 @@ func TestUnmarshalConfig(t *testing.T) {
 						},
 					},
 					MaxRecvMsgSizeMiB:    32,
+					MaxSendMsgSizeMiB:    64,
 					MaxConcurrentStreams: 16,
 					ReadBufferSize:       1024,
 					WriteBufferSize:      1024,
@@ -140,6 +141,7 @@ func TestUnmarshalConfig(t *testing.T) {
 					LogsURLPath:    "/log/ingest",
 				},
 			},
+			WaitForAcknowledgement: true,
 		}, cfg)
 
 }
@@ -197,6 +199,24 @@ func TestUnmarshalConfigEmptyProtocols(t *testing.T) {
 	assert.EqualError(t, component.ValidateConfig(cfg), "must specify at least one protocol when using the OTLP receiver")
 }
 
+func TestConfigValidateDisabledEncodings(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.HTTP.DisableJSON = true
+	assert.NoError(t, component.ValidateConfig(cfg))
+	cfg.HTTP.DisableProto = true
+	assert.EqualError(t, component.ValidateConfig(cfg), "must not disable both the JSON and Protobuf encodings of the HTTP protocol")
+}
+
+func TestConfigValidateConnectionQuota(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.HTTP.ConnectionQuota.MaxRequests = -1
+	assert.EqualError(t, component.ValidateConfig(cfg), "connection_quota::max_requests must not be negative")
+	cfg.HTTP.ConnectionQuota.MaxRequests = 100
+	assert.EqualError(t, component.ValidateConfig(cfg), "connection_quota::window must be positive when connection_quota::max_requests is set")
+	cfg.HTTP.ConnectionQuota.Window = time.Second
+	assert.NoError(t, component.ValidateConfig(cfg))
+}
+
 func TestUnmarshalConfigInvalidSignalPath(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpreceiver // import "go.opentelemetry.io/collector/receiver/otlpreceiver"
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+type connectionQuotaKey struct{}
+
+// connectionQuota counts the requests sent on an HTTP connection in the current window.
+type connectionQuota struct {
+	cfg ConnectionQuotaConfig
+	now func() time.Time
+
+	mu       sync.Mutex
+	start    time.Time
+	requests int
+}
+
+// connContext returns a context tracking the requests of the connection against the quota, to be used as the
+// ConnContext of the HTTP server.
+func (cfg ConnectionQuotaConfig) connContext(ctx context.Context, _ net.Conn) context.Context {
+	return context.WithValue(ctx, connectionQuotaKey{}, &connectionQuota{cfg: cfg, now: time.Now})
+}
+
+// allow counts a request and returns whether the connection is within its quota, or else the time to wait
+// before the next window starts.
+func (cq *connectionQuota) allow() (bool, time.Duration) {
+	cq.mu.Lock()
+	defer cq.mu.Unlock()
+	now := cq.now()
+	if cq.start.IsZero() || now.Sub(cq.start) >= cq.cfg.Window {
+		cq.start = now
+		cq.requests = 0
+	}
+	if cq.requests >= cq.cfg.MaxRequests {
+		return false, cq.cfg.Window - now.Sub(cq.start)
+	}
+	cq.requests++
+	return true, 0
+}
+
+// checkConnectionQuota writes a 429 Too Many Requests response and returns false if the connection of req
+// exceeded its quota.
+func checkConnectionQuota(resp http.ResponseWriter, req *http.Request, enc encoder) bool {
+	cq, ok := req.Context().Value(connectionQuotaKey{}).(*connectionQuota)
+	if !ok {
+		return true
+	}
+	allowed, retryAfter := cq.allow()
+	if allowed {
+		return true
+	}
+	resp.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	writeError(resp, enc, fmt.Errorf("connection exceeded its quota of %d requests per %v", cq.cfg.MaxRequests, cq.cfg.Window),
+		http.StatusTooManyRequests)
+	return false
+}
@@ -17,6 +17,7 @@ import (
 	"go.opentelemetry.io/collector/component/componentstatus"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumerack"
 	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
 	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
 	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
@@ -127,21 +128,21 @@ func (r *otlpReceiver) startHTTPServer(ctx context.Context, host component.Host)
 	if r.nextTraces != nil {
 		httpTracesReceiver := trace.New(r.nextTraces, r.obsrepHTTP)
 		httpMux.HandleFunc(r.cfg.HTTP.TracesURLPath, func(resp http.ResponseWriter, req *http.Request) {
-			handleTraces(resp, req, httpTracesReceiver)
+			handleTraces(resp, req, httpTracesReceiver, r.cfg.HTTP)
 		})
 	}
 
 	if r.nextMetrics != nil {
 		httpMetricsReceiver := metrics.New(r.nextMetrics, r.obsrepHTTP)
 		httpMux.HandleFunc(r.cfg.HTTP.MetricsURLPath, func(resp http.ResponseWriter, req *http.Request) {
-			handleMetrics(resp, req, httpMetricsReceiver)
+			handleMetrics(resp, req, httpMetricsReceiver, r.cfg.HTTP)
 		})
 	}
 
 	if r.nextLogs != nil {
 		httpLogsReceiver := logs.New(r.nextLogs, r.obsrepHTTP)
 		httpMux.HandleFunc(r.cfg.HTTP.LogsURLPath, func(resp http.ResponseWriter, req *http.Request) {
-			handleLogs(resp, req, httpLogsReceiver)
+			handleLogs(resp, req, httpLogsReceiver, r.cfg.HTTP)
 		})
 	}
 
@@ -149,6 +150,9 @@ func (r *otlpReceiver) startHTTPServer(ctx context.Context, host component.Host)
 	if r.serverHTTP, err = r.cfg.HTTP.ToServer(ctx, host, r.settings.TelemetrySettings, httpMux, confighttp.WithErrorHandler(errorHandler)); err != nil {
 		return err
 	}
+	if r.cfg.HTTP.ConnectionQuota.MaxRequests > 0 {
+		r.serverHTTP.ConnContext = r.cfg.HTTP.ConnectionQuota.connContext
+	}
 
 	r.settings.Logger.Info("Starting HTTP server", zap.String("endpoint", r.cfg.HTTP.ServerConfig.Endpoint))
 	var hln net.Listener
@@ -200,13 +204,22 @@ func (r *otlpReceiver) Shutdown(ctx context.Context) error {
 }
 
 func (r *otlpReceiver) registerTraceConsumer(tc consumer.Traces) {
+	if r.cfg.WaitForAcknowledgement {
+		tc = consumerack.NewTraces(tc)
+	}
 	r.nextTraces = tc
 }
 
 func (r *otlpReceiver) registerMetricsConsumer(mc consumer.Metrics) {
+	if r.cfg.WaitForAcknowledgement {
+		mc = consumerack.NewMetrics(mc)
+	}
 	r.nextMetrics = mc
 }
 
 func (r *otlpReceiver) registerLogsConsumer(lc consumer.Logs) {
+	if r.cfg.WaitForAcknowledgement {
+		lc = consumerack.NewLogs(lc)
+	}
 	r.nextLogs = lc
 }
@@ -15,6 +15,7 @@ import (
 	"net/http"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -35,8 +36,11 @@ import (
 	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumerack"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/internal/otlppassthrough"
 	"go.opentelemetry.io/collector/internal/testutil"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
@@ -322,6 +326,146 @@ func TestHandleInvalidRequests(t *testing.T) {
 	require.NoError(t, recv.Shutdown(context.Background()))
 }
 
+func TestHTTPDisabledEncodings(t *testing.T) {
+	tests := []struct {
+		name          string
+		disableJSON   bool
+		disableProto  bool
+		jsonStatus    int
+		protoStatus   int
+		supportedBody string
+	}{
+		{
+			name:        "both enabled",
+			jsonStatus:  http.StatusOK,
+			protoStatus: http.StatusOK,
+		},
+		{
+			name:          "json disabled",
+			disableJSON:   true,
+			jsonStatus:    http.StatusUnsupportedMediaType,
+			protoStatus:   http.StatusOK,
+			supportedBody: "415 unsupported media type, supported: [application/x-protobuf]",
+		},
+		{
+			name:          "proto disabled",
+			disableProto:  true,
+			jsonStatus:    http.StatusOK,
+			protoStatus:   http.StatusUnsupportedMediaType,
+			supportedBody: "415 unsupported media type, supported: [application/json]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr := testutil.GetAvailableLocalAddress(t)
+			cfg := createDefaultConfig().(*Config)
+			cfg.HTTP.Endpoint = addr
+			cfg.HTTP.DisableJSON = tt.disableJSON
+			cfg.HTTP.DisableProto = tt.disableProto
+			cfg.GRPC = nil
+			require.NoError(t, cfg.Validate())
+			sink := newErrOrSinkConsumer()
+			recv := newReceiver(t, componenttest.NewNopTelemetrySettings(), cfg, otlpReceiverID, sink)
+			require.NoError(t, recv.Start(context.Background(), componenttest.NewNopHost()))
+			t.Cleanup(func() { require.NoError(t, recv.Shutdown(context.Background())) })
+
+			traces := generateTracesRequest(t)
+			for _, req := range []struct {
+				contentType string
+				body        []byte
+				status      int
+			}{
+				{contentType: "application/json", body: traces.jsonBytes, status: tt.jsonStatus},
+				{contentType: "application/x-protobuf", body: traces.protoBytes, status: tt.protoStatus},
+			} {
+				resp, err := http.Post("http://"+addr+defaultTracesURLPath, req.contentType, bytes.NewReader(req.body))
+				require.NoError(t, err)
+				body, err := io.ReadAll(resp.Body)
+				require.NoError(t, err)
+				require.NoError(t, resp.Body.Close())
+				assert.Equal(t, req.status, resp.StatusCode, req.contentType)
+				if req.status == http.StatusUnsupportedMediaType {
+					assert.Equal(t, tt.supportedBody, string(body))
+				}
+			}
+		})
+	}
+}
+
+func TestHTTPConnectionQuota(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	cfg := createDefaultConfig().(*Config)
+	cfg.HTTP.Endpoint = addr
+	cfg.HTTP.ConnectionQuota = ConnectionQuotaConfig{MaxRequests: 3, Window: time.Hour}
+	cfg.GRPC = nil
+	require.NoError(t, cfg.Validate())
+	sink := newErrOrSinkConsumer()
+	recv := newReceiver(t, componenttest.NewNopTelemetrySettings(), cfg, otlpReceiverID, sink)
+	require.NoError(t, recv.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, recv.Shutdown(context.Background())) })
+
+	// newClient returns a client sending all its requests on a single connection.
+	newClient := func() (*http.Client, *atomic.Int64) {
+		dials := &atomic.Int64{}
+		dialer := &net.Dialer{}
+		transport := &http.Transport{
+			MaxConnsPerHost: 1,
+			DialContext: func(ctx context.Context, network, address string) (net.Conn, error) {
+				dials.Add(1)
+				return dialer.DialContext(ctx, network, address)
+			},
+		}
+		t.Cleanup(transport.CloseIdleConnections)
+		return &http.Client{Transport: transport}, dials
+	}
+	traces := generateTracesRequest(t)
+	send := func(client *http.Client) *http.Response {
+		resp, err := client.Post("http://"+addr+defaultTracesURLPath, "application/x-protobuf", bytes.NewReader(traces.protoBytes))
+		require.NoError(t, err)
+		_, err = io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		return resp
+	}
+
+	client, dials := newClient()
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, http.StatusOK, send(client).StatusCode)
+	}
+	for i := 0; i < 2; i++ {
+		resp := send(client)
+		assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+		assert.Equal(t, "3600", resp.Header.Get("Retry-After"))
+	}
+	assert.Equal(t, int64(1), dials.Load())
+	assert.Len(t, sink.AllTraces(), 3)
+
+	// A new connection has its own quota.
+	otherClient, otherDials := newClient()
+	assert.Equal(t, http.StatusOK, send(otherClient).StatusCode)
+	assert.Equal(t, int64(1), otherDials.Load())
+	assert.Equal(t, http.StatusTooManyRequests, send(client).StatusCode)
+	assert.Len(t, sink.AllTraces(), 4)
+}
+
+func TestConnectionQuotaWindow(t *testing.T) {
+	now := time.Unix(1000, 0)
+	cq := &connectionQuota{cfg: ConnectionQuotaConfig{MaxRequests: 2, Window: time.Minute}, now: func() time.Time { return now }}
+	for i := 0; i < 2; i++ {
+		allowed, _ := cq.allow()
+		assert.True(t, allowed)
+	}
+	now = now.Add(20 * time.Second)
+	allowed, retryAfter := cq.allow()
+	assert.False(t, allowed)
+	assert.Equal(t, 40*time.Second, retryAfter)
+	// The requests are counted again once the window is over.
+	now = now.Add(40 * time.Second)
+	allowed, _ = cq.allow()
+	assert.True(t, allowed)
+}
+
 func TestProtoHttp(t *testing.T) {
 	tests := []struct {
 		name               string
@@ -821,6 +965,127 @@ func TestHTTPMaxRequestBodySize(t *testing.T) {
 	}
 }
 
+func TestHTTPOTLPPassthrough(t *testing.T) {
+	prev := otlppassthrough.Gate.IsEnabled()
+	require.NoError(t, featuregate.GlobalRegistry().Set(otlppassthrough.Gate.ID(), true))
+	t.Cleanup(func() {
+		require.NoError(t, featuregate.GlobalRegistry().Set(otlppassthrough.Gate.ID(), prev))
+	})
+
+	addr := testutil.GetAvailableLocalAddress(t)
+	pc := &passthroughConsumer{}
+	recv := newHTTPReceiver(t, componenttest.NewNopTelemetrySettings(), addr, pc)
+	require.NoError(t, recv.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, recv.Shutdown(context.Background())) })
+
+	for _, dr := range generateDataRequests(t) {
+		// The protobuf requests are available to forward as is, even when received compressed.
+		pc.raw = nil
+		doHTTPRequest(t, "http://"+addr+dr.path, "gzip", "application/x-protobuf", dr.protoBytes, 0)
+		assert.Equal(t, [][]byte{dr.protoBytes}, pc.raw)
+
+		// The JSON requests are not.
+		pc.raw = nil
+		doHTTPRequest(t, "http://"+addr+dr.path, "", "application/json", dr.jsonBytes, 0)
+		assert.Equal(t, [][]byte{nil}, pc.raw)
+	}
+}
+
+func TestWaitForAcknowledgement(t *testing.T) {
+	acks := make(chan func(error), 1)
+	next, err := consumer.NewTraces(func(ctx context.Context, _ ptrace.Traces) error {
+		ack := consumerack.Defer(ctx)
+		assert.NotNil(t, ack)
+		acks <- ack
+		return nil
+	})
+	require.NoError(t, err)
+
+	grpcAddr := testutil.GetAvailableLocalAddress(t)
+	httpAddr := testutil.GetAvailableLocalAddress(t)
+	cfg := createDefaultConfig().(*Config)
+	cfg.GRPC.NetAddr.Endpoint = grpcAddr
+	cfg.HTTP.Endpoint = httpAddr
+	cfg.WaitForAcknowledgement = true
+	set := receivertest.NewNopSettings()
+	r, err := newOtlpReceiver(cfg, &set)
+	require.NoError(t, err)
+	r.registerTraceConsumer(next)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, r.Shutdown(context.Background())) })
+
+	cc, err := grpc.NewClient(grpcAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, cc.Close()) }()
+	td := testdata.GenerateTraces(1)
+	tracesReq := generateTracesRequest(t)
+	for _, tt := range []struct {
+		name string
+		send func() error
+	}{
+		{name: "grpc", send: func() error { return exportTraces(cc, td) }},
+		{name: "http", send: func() error {
+			resp, err := http.Post("http://"+httpAddr+defaultTracesURLPath, "application/x-protobuf", bytes.NewReader(tracesReq.protoBytes))
+			if err != nil {
+				return err
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != http.StatusOK {
+				return fmt.Errorf("status %d", resp.StatusCode)
+			}
+			return nil
+		}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, ackErr := range []error{nil, consumererror.NewPermanent(errors.New("not sent"))} {
+				result := make(chan error, 1)
+				go func() { result <- tt.send() }()
+				ack := <-acks
+				// The response is deferred until the data is acknowledged.
+				select {
+				case err := <-result:
+					t.Fatalf("responded before the acknowledgement: %v", err)
+				case <-time.After(50 * time.Millisecond):
+				}
+				ack(ackErr)
+				if ackErr == nil {
+					require.NoError(t, <-result)
+				} else {
+					require.Error(t, <-result)
+				}
+			}
+		})
+	}
+}
+
+// passthroughConsumer records the received requests available to forward as is.
+type passthroughConsumer struct {
+	consumertest.Consumer
+	raw [][]byte
+}
+
+func (pc *passthroughConsumer) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{}
+}
+
+func (pc *passthroughConsumer) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	raw, _ := otlppassthrough.TracesFromContext(ctx, td)
+	pc.raw = append(pc.raw, raw)
+	return nil
+}
+
+func (pc *passthroughConsumer) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	raw, _ := otlppassthrough.MetricsFromContext(ctx, md)
+	pc.raw = append(pc.raw, raw)
+	return nil
+}
+
+func (pc *passthroughConsumer) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	raw, _ := otlppassthrough.LogsFromContext(ctx, ld)
+	pc.raw = append(pc.raw, raw)
+	return nil
+}
+
 func newGRPCReceiver(t *testing.T, settings component.TelemetrySettings, endpoint string, c consumertest.Consumer) component.Component {
 	cfg := createDefaultConfig().(*Config)
 	cfg.GRPC.NetAddr.Endpoint = endpoint
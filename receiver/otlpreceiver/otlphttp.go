@@ -8,11 +8,13 @@ import (
 	"io"
 	"mime"
 	"net/http"
+	"strings"
 
 	spb "google.golang.org/genproto/googleapis/rpc/status"
 	"google.golang.org/grpc/status"
 
 	"go.opentelemetry.io/collector/internal/httphelper"
+	"go.opentelemetry.io/collector/internal/otlppassthrough"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/errors"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/logs"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/metrics"
@@ -24,11 +26,14 @@ var fallbackMsg = []byte(`{"code": 13, "message": "failed to marshal error messa
 
 const fallbackContentType = "application/json"
 
-func handleTraces(resp http.ResponseWriter, req *http.Request, tracesReceiver *trace.Receiver) {
-	enc, ok := readContentType(resp, req)
+func handleTraces(resp http.ResponseWriter, req *http.Request, tracesReceiver *trace.Receiver, cfg *HTTPConfig) {
+	enc, ok := readContentType(resp, req, cfg)
 	if !ok {
 		return
 	}
+	if !checkConnectionQuota(resp, req, enc) {
+		return
+	}
 
 	body, ok := readAndCloseBody(resp, req, enc)
 	if !ok {
@@ -41,7 +46,11 @@ func handleTraces(resp http.ResponseWriter, req *http.Request, tracesReceiver *t
 		return
 	}
 
-	otlpResp, err := tracesReceiver.Export(req.Context(), otlpReq)
+	ctx := req.Context()
+	if enc == pbEncoder {
+		ctx = otlppassthrough.ContextWithTraces(ctx, otlpReq.Traces(), body)
+	}
+	otlpResp, err := tracesReceiver.Export(ctx, otlpReq)
 	if err != nil {
 		writeError(resp, enc, err, http.StatusInternalServerError)
 		return
@@ -55,11 +64,14 @@ func handleTraces(resp http.ResponseWriter, req *http.Request, tracesReceiver *t
 	writeResponse(resp, enc.contentType(), http.StatusOK, msg)
 }
 
-func handleMetrics(resp http.ResponseWriter, req *http.Request, metricsReceiver *metrics.Receiver) {
-	enc, ok := readContentType(resp, req)
+func handleMetrics(resp http.ResponseWriter, req *http.Request, metricsReceiver *metrics.Receiver, cfg *HTTPConfig) {
+	enc, ok := readContentType(resp, req, cfg)
 	if !ok {
 		return
 	}
+	if !checkConnectionQuota(resp, req, enc) {
+		return
+	}
 
 	body, ok := readAndCloseBody(resp, req, enc)
 	if !ok {
@@ -72,7 +84,11 @@ func handleMetrics(resp http.ResponseWriter, req *http.Request, metricsReceiver
 		return
 	}
 
-	otlpResp, err := metricsReceiver.Export(req.Context(), otlpReq)
+	ctx := req.Context()
+	if enc == pbEncoder {
+		ctx = otlppassthrough.ContextWithMetrics(ctx, otlpReq.Metrics(), body)
+	}
+	otlpResp, err := metricsReceiver.Export(ctx, otlpReq)
 	if err != nil {
 		writeError(resp, enc, err, http.StatusInternalServerError)
 		return
@@ -86,11 +102,14 @@ func handleMetrics(resp http.ResponseWriter, req *http.Request, metricsReceiver
 	writeResponse(resp, enc.contentType(), http.StatusOK, msg)
 }
 
-func handleLogs(resp http.ResponseWriter, req *http.Request, logsReceiver *logs.Receiver) {
-	enc, ok := readContentType(resp, req)
+func handleLogs(resp http.ResponseWriter, req *http.Request, logsReceiver *logs.Receiver, cfg *HTTPConfig) {
+	enc, ok := readContentType(resp, req, cfg)
 	if !ok {
 		return
 	}
+	if !checkConnectionQuota(resp, req, enc) {
+		return
+	}
 
 	body, ok := readAndCloseBody(resp, req, enc)
 	if !ok {
@@ -103,7 +122,11 @@ func handleLogs(resp http.ResponseWriter, req *http.Request, logsReceiver *logs.
 		return
 	}
 
-	otlpResp, err := logsReceiver.Export(req.Context(), otlpReq)
+	ctx := req.Context()
+	if enc == pbEncoder {
+		ctx = otlppassthrough.ContextWithLogs(ctx, otlpReq.Logs(), body)
+	}
+	otlpResp, err := logsReceiver.Export(ctx, otlpReq)
 	if err != nil {
 		writeError(resp, enc, err, http.StatusInternalServerError)
 		return
@@ -117,7 +140,7 @@ func handleLogs(resp http.ResponseWriter, req *http.Request, logsReceiver *logs.
 	writeResponse(resp, enc.contentType(), http.StatusOK, msg)
 }
 
-func readContentType(resp http.ResponseWriter, req *http.Request) (encoder, bool) {
+func readContentType(resp http.ResponseWriter, req *http.Request, cfg *HTTPConfig) (encoder, bool) {
 	if req.Method != http.MethodPost {
 		handleUnmatchedMethod(resp)
 		return nil, false
@@ -125,13 +148,16 @@ func readContentType(resp http.ResponseWriter, req *http.Request) (encoder, bool
 
 	switch getMimeTypeFromContentType(req.Header.Get("Content-Type")) {
 	case pbContentType:
-		return pbEncoder, true
+		if !cfg.DisableProto {
+			return pbEncoder, true
+		}
 	case jsonContentType:
-		return jsEncoder, true
-	default:
-		handleUnmatchedContentType(resp)
-		return nil, false
+		if !cfg.DisableJSON {
+			return jsEncoder, true
+		}
 	}
+	handleUnmatchedContentType(resp, cfg)
+	return nil, false
 }
 
 func readAndCloseBody(resp http.ResponseWriter, req *http.Request, enc encoder) ([]byte, bool) {
@@ -203,7 +229,14 @@ func handleUnmatchedMethod(resp http.ResponseWriter) {
 	writeResponse(resp, "text/plain", status, []byte(fmt.Sprintf("%v method not allowed, supported: [POST]", status)))
 }
 
-func handleUnmatchedContentType(resp http.ResponseWriter) {
+func handleUnmatchedContentType(resp http.ResponseWriter, cfg *HTTPConfig) {
+	var supported []string
+	if !cfg.DisableJSON {
+		supported = append(supported, jsonContentType)
+	}
+	if !cfg.DisableProto {
+		supported = append(supported, pbContentType)
+	}
 	status := http.StatusUnsupportedMediaType
-	writeResponse(resp, "text/plain", status, []byte(fmt.Sprintf("%v unsupported media type, supported: [%s, %s]", status, jsonContentType, pbContentType)))
+	writeResponse(resp, "text/plain", status, []byte(fmt.Sprintf("%v unsupported media type, supported: [%s]", status, strings.Join(supported, ", "))))
 }
@@ -0,0 +1,242 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package receiverhelper // import "go.opentelemetry.io/collector/receiver/receiverhelper"
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// defaultAttributeCardinalityWindow is the window of AttributeCardinalitySettings when not configured.
+const defaultAttributeCardinalityWindow = time.Minute
+
+// AttributeCardinalitySettings configures the warnings logged when the number of distinct values received
+// for an attribute key exceeds a threshold, before the cardinality overwhelms the backends.
+//
+// The attributes are observed by the RecordXAttributes functions, which the receiver calls with the data it
+// receives. The resource attributes are always observed, and the attributes of the spans, metric data points
+// and log records are sampled.
+type AttributeCardinalitySettings struct {
+	// Threshold is the number of distinct values of an attribute key within a window above which a warning
+	// is logged, once per key and window. Zero disables the detection.
+	Threshold int
+	// Window is the period over which the distinct values are counted, one minute if zero.
+	Window time.Duration
+	// SampleEvery is the sampling of the spans, metric data points and log records: the attributes of one
+	// out of SampleEvery are observed, all of them if zero or one.
+	SampleEvery int
+}
+
+// RecordTracesAttributes observes the attributes of td for the attribute cardinality warnings.
+func (rec *ObsReport) RecordTracesAttributes(td ptrace.Traces) {
+	d := rec.attributeCardinality
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	d.rotate()
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		d.observe(rss.At(i).Resource().Attributes())
+		sss := rss.At(i).ScopeSpans()
+		for j := 0; j < sss.Len(); j++ {
+			spans := sss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				if d.sample() {
+					d.observe(spans.At(k).Attributes())
+				}
+			}
+		}
+	}
+	d.mu.Unlock()
+	d.warn()
+}
+
+// RecordMetricsAttributes observes the attributes of md for the attribute cardinality warnings.
+func (rec *ObsReport) RecordMetricsAttributes(md pmetric.Metrics) {
+	d := rec.attributeCardinality
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	d.rotate()
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		d.observe(rms.At(i).Resource().Attributes())
+		sms := rms.At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			metrics := sms.At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				d.observeMetric(metrics.At(k))
+			}
+		}
+	}
+	d.mu.Unlock()
+	d.warn()
+}
+
+// RecordLogsAttributes observes the attributes of ld for the attribute cardinality warnings.
+func (rec *ObsReport) RecordLogsAttributes(ld plog.Logs) {
+	d := rec.attributeCardinality
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	d.rotate()
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		d.observe(rls.At(i).Resource().Attributes())
+		sls := rls.At(i).ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			lrs := sls.At(j).LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				if d.sample() {
+					d.observe(lrs.At(k).Attributes())
+				}
+			}
+		}
+	}
+	d.mu.Unlock()
+	d.warn()
+}
+
+// cardinalityDetector counts the distinct values of the attribute keys per window, and logs a warning for
+// the keys exceeding the threshold.
+type cardinalityDetector struct {
+	threshold   int
+	window      time.Duration
+	sampleEvery int
+	logger      *zap.Logger
+	now         func() time.Time
+
+	mu sync.Mutex
+	// start is the start of the current window.
+	start time.Time
+	// values are the distinct values of the keys within the window. The keys exceeding the threshold are
+	// kept with a nil set, so that they are only reported once per window.
+	values map[string]map[pcommon.Fingerprint]struct{}
+	// exceeded are the keys which exceeded the threshold since the last warnings were logged.
+	exceeded []string
+	sampled  int
+}
+
+func newCardinalityDetector(set AttributeCardinalitySettings, logger *zap.Logger, now func() time.Time) *cardinalityDetector {
+	d := &cardinalityDetector{
+		threshold:   set.Threshold,
+		window:      set.Window,
+		sampleEvery: set.SampleEvery,
+		logger:      logger,
+		now:         now,
+		start:       now(),
+		values:      map[string]map[pcommon.Fingerprint]struct{}{},
+	}
+	if d.window <= 0 {
+		d.window = defaultAttributeCardinalityWindow
+	}
+	return d
+}
+
+// rotate starts a new window if the current one is complete. It must be called with mu held.
+func (d *cardinalityDetector) rotate() {
+	elapsed := d.now().Sub(d.start)
+	if elapsed < d.window {
+		return
+	}
+	d.values = map[string]map[pcommon.Fingerprint]struct{}{}
+	d.start = d.start.Add(elapsed.Truncate(d.window))
+}
+
+// sample reports whether the attributes of the next item are observed. It must be called with mu held.
+func (d *cardinalityDetector) sample() bool {
+	if d.sampleEvery <= 1 {
+		return true
+	}
+	d.sampled++
+	if d.sampled < d.sampleEvery {
+		return false
+	}
+	d.sampled = 0
+	return true
+}
+
+// observe adds the values of the attributes of m to the distinct values of their keys. It must be called
+// with mu held.
+func (d *cardinalityDetector) observe(m pcommon.Map) {
+	m.Range(func(k string, v pcommon.Value) bool {
+		values, ok := d.values[k]
+		if !ok {
+			values = map[pcommon.Fingerprint]struct{}{}
+			d.values[k] = values
+		}
+		if values == nil {
+			return true
+		}
+		values[v.Fingerprint()] = struct{}{}
+		if len(values) > d.threshold {
+			d.values[k] = nil
+			d.exceeded = append(d.exceeded, k)
+		}
+		return true
+	})
+}
+
+// observeMetric observes the attributes of the sampled data points of m. It must be called with mu held.
+func (d *cardinalityDetector) observeMetric(m pmetric.Metric) {
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		dps := m.Gauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			if d.sample() {
+				d.observe(dps.At(i).Attributes())
+			}
+		}
+	case pmetric.MetricTypeSum:
+		dps := m.Sum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			if d.sample() {
+				d.observe(dps.At(i).Attributes())
+			}
+		}
+	case pmetric.MetricTypeHistogram:
+		dps := m.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			if d.sample() {
+				d.observe(dps.At(i).Attributes())
+			}
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		dps := m.ExponentialHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			if d.sample() {
+				d.observe(dps.At(i).Attributes())
+			}
+		}
+	case pmetric.MetricTypeSummary:
+		dps := m.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			if d.sample() {
+				d.observe(dps.At(i).Attributes())
+			}
+		}
+	}
+}
+
+// warn logs a warning for each key which exceeded the threshold since the last call.
+func (d *cardinalityDetector) warn() {
+	d.mu.Lock()
+	exceeded := d.exceeded
+	d.exceeded = nil
+	d.mu.Unlock()
+	for _, k := range exceeded {
+		d.logger.Warn("The number of distinct values of an attribute exceeds the cardinality threshold",
+			zap.String("attribute", k), zap.Int("threshold", d.threshold), zap.Duration("window", d.window))
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package receiverhelper
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+)
+
+func newCardinalityObsReport(t *testing.T, set AttributeCardinalitySettings) (*ObsReport, *observer.ObservedLogs, *time.Time) {
+	core, logs := observer.New(zapcore.WarnLevel)
+	rset := receivertest.NewNopSettings()
+	rset.Logger = zap.New(core)
+	rec, err := newReceiver(ObsReportSettings{
+		ReceiverID:             receiverID,
+		Transport:              transport,
+		ReceiverCreateSettings: rset,
+		AttributeCardinality:   set,
+	})
+	require.NoError(t, err)
+	now := time.Now()
+	rec.attributeCardinality.now = func() time.Time { return now }
+	rec.attributeCardinality.start = now
+	return rec, logs, &now
+}
+
+// newCardinalityLogs returns logs with a record per user ID from first to first+count excluded.
+func newCardinalityLogs(first, count int) plog.Logs {
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", "frontend")
+	lrs := rl.ScopeLogs().AppendEmpty().LogRecords()
+	for i := first; i < first+count; i++ {
+		lr := lrs.AppendEmpty()
+		lr.Attributes().PutStr("user.id", strconv.Itoa(i))
+		lr.Attributes().PutStr("http.method", "GET")
+	}
+	return ld
+}
+
+func warnedAttributes(logs *observer.ObservedLogs) []string {
+	var keys []string
+	for _, entry := range logs.All() {
+		keys = append(keys, entry.ContextMap()["attribute"].(string))
+	}
+	return keys
+}
+
+func TestAttributeCardinalityWarning(t *testing.T) {
+	rec, logs, now := newCardinalityObsReport(t, AttributeCardinalitySettings{Threshold: 3, Window: time.Minute})
+
+	rec.RecordLogsAttributes(newCardinalityLogs(0, 3))
+	assert.Zero(t, logs.Len())
+	rec.RecordLogsAttributes(newCardinalityLogs(3, 1))
+	assert.Equal(t, []string{"user.id"}, warnedAttributes(logs))
+	entry := logs.All()[0]
+	assert.Equal(t, zapcore.WarnLevel, entry.Level)
+	assert.Equal(t, int64(3), entry.ContextMap()["threshold"])
+
+	// The warning is logged once per window.
+	rec.RecordLogsAttributes(newCardinalityLogs(4, 10))
+	*now = now.Add(59 * time.Second)
+	rec.RecordLogsAttributes(newCardinalityLogs(14, 10))
+	assert.Equal(t, 1, logs.Len())
+
+	// The distinct values are counted again in the next window.
+	*now = now.Add(time.Second)
+	rec.RecordLogsAttributes(newCardinalityLogs(0, 3))
+	assert.Equal(t, 1, logs.Len())
+	rec.RecordLogsAttributes(newCardinalityLogs(3, 1))
+	assert.Equal(t, []string{"user.id", "user.id"}, warnedAttributes(logs))
+}
+
+func TestAttributeCardinalitySampling(t *testing.T) {
+	rec, logs, _ := newCardinalityObsReport(t, AttributeCardinalitySettings{Threshold: 2, SampleEvery: 2})
+	assert.Equal(t, time.Minute, rec.attributeCardinality.window)
+
+	// One record out of two is observed.
+	rec.RecordLogsAttributes(newCardinalityLogs(0, 4))
+	assert.Zero(t, logs.Len())
+	rec.RecordLogsAttributes(newCardinalityLogs(4, 2))
+	assert.Equal(t, []string{"user.id"}, warnedAttributes(logs))
+}
+
+func TestAttributeCardinalityTracesAndMetrics(t *testing.T) {
+	rec, logs, _ := newCardinalityObsReport(t, AttributeCardinalitySettings{Threshold: 1})
+
+	td := ptrace.NewTraces()
+	for _, host := range []string{"a", "b"} {
+		rs := td.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().PutStr("host.name", host)
+		rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty().Attributes().PutStr("span.kind", "server")
+	}
+	rec.RecordTracesAttributes(td)
+	assert.Equal(t, []string{"host.name"}, warnedAttributes(logs))
+
+	md := pmetric.NewMetrics()
+	dps := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptySum().DataPoints()
+	dps.AppendEmpty().Attributes().PutInt("status", 200)
+	dps.AppendEmpty().Attributes().PutInt("status", 500)
+	rec.RecordMetricsAttributes(md)
+	assert.Equal(t, []string{"host.name", "status"}, warnedAttributes(logs))
+}
+
+func TestAttributeCardinalityDisabled(t *testing.T) {
+	rec, err := newReceiver(ObsReportSettings{
+		ReceiverID:             receiverID,
+		Transport:              transport,
+		ReceiverCreateSettings: receivertest.NewNopSettings(),
+	})
+	require.NoError(t, err)
+	assert.Nil(t, rec.attributeCardinality)
+	rec.RecordLogsAttributes(newCardinalityLogs(0, 10))
+}
@@ -0,0 +1,144 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package receiverhelper // import "go.opentelemetry.io/collector/receiver/receiverhelper"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/baggage"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// BaggageSettings configures the W3C baggage entries of the incoming requests set as attributes
+// on the received data.
+//
+// The baggage is taken from the context passed to the StartXOp functions, in which receivers
+// without a propagator extracting it can store the baggage header of the request with
+// ContextWithBaggage. The StampX functions, which the receiver calls with the context returned
+// by StartXOp, then set the attribute named after each allowed key present in the baggage on
+// every span, metric data point or log record, unless the record already has this attribute.
+type BaggageSettings struct {
+	// Keys are the baggage keys copied as attributes, the other keys are ignored.
+	// No key is copied when empty.
+	Keys []string
+}
+
+// ContextWithBaggage returns a copy of ctx holding the baggage parsed from header, the value
+// of the W3C baggage header of the request. The baggage of ctx is left unchanged if the header
+// is empty or invalid, in which case the parse error is returned.
+func ContextWithBaggage(ctx context.Context, header string) (context.Context, error) {
+	if header == "" {
+		return ctx, nil
+	}
+	b, err := baggage.Parse(header)
+	if err != nil {
+		return ctx, err
+	}
+	return baggage.ContextWithBaggage(ctx, b), nil
+}
+
+// baggageAttributes returns the attributes to set from the allowed keys of the baggage of ctx.
+func (rec *ObsReport) baggageAttributes(ctx context.Context) map[string]string {
+	if len(rec.baggageKeys) == 0 {
+		return nil
+	}
+	b := baggage.FromContext(ctx)
+	if b.Len() == 0 {
+		return nil
+	}
+	var attrs map[string]string
+	for _, key := range rec.baggageKeys {
+		member := b.Member(key)
+		if member.Key() == "" {
+			continue
+		}
+		if attrs == nil {
+			attrs = make(map[string]string, len(rec.baggageKeys))
+		}
+		attrs[key] = member.Value()
+	}
+	return attrs
+}
+
+func putMissing(dest pcommon.Map, attrs map[string]string) {
+	for k, v := range attrs {
+		if _, ok := dest.Get(k); !ok {
+			dest.PutStr(k, v)
+		}
+	}
+}
+
+func stampBaggageTraces(td ptrace.Traces, attrs map[string]string) {
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		sss := rss.At(i).ScopeSpans()
+		for j := 0; j < sss.Len(); j++ {
+			spans := sss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				putMissing(spans.At(k).Attributes(), attrs)
+			}
+		}
+	}
+}
+
+func stampBaggageLogs(ld plog.Logs, attrs map[string]string) {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		sls := rls.At(i).ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			lrs := sls.At(j).LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				putMissing(lrs.At(k).Attributes(), attrs)
+			}
+		}
+	}
+}
+
+func stampBaggageMetrics(md pmetric.Metrics, attrs map[string]string) {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		sms := rms.At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			ms := sms.At(j).Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				stampBaggageMetric(ms.At(k), attrs)
+			}
+		}
+	}
+}
+
+func stampBaggageMetric(m pmetric.Metric, attrs map[string]string) {
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		dps := m.Gauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			putMissing(dps.At(i).Attributes(), attrs)
+		}
+	case pmetric.MetricTypeSum:
+		dps := m.Sum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			putMissing(dps.At(i).Attributes(), attrs)
+		}
+	case pmetric.MetricTypeHistogram:
+		dps := m.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			putMissing(dps.At(i).Attributes(), attrs)
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		dps := m.ExponentialHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			putMissing(dps.At(i).Attributes(), attrs)
+		}
+	case pmetric.MetricTypeSummary:
+		dps := m.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			putMissing(dps.At(i).Attributes(), attrs)
+		}
+	case pmetric.MetricTypeEmpty:
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package receiverhelper
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+)
+
+func newBaggageReceiver(t *testing.T, keys ...string) *ObsReport {
+	rec, err := newReceiver(ObsReportSettings{
+		ReceiverID:             receiverID,
+		Transport:              transport,
+		ReceiverCreateSettings: receivertest.NewNopSettings(),
+		Baggage:                BaggageSettings{Keys: keys},
+	})
+	require.NoError(t, err)
+	return rec
+}
+
+func baggageContext(t *testing.T) context.Context {
+	ctx, err := ContextWithBaggage(context.Background(), "tenant=acme,user.id=42,secret=hunter2")
+	require.NoError(t, err)
+	return ctx
+}
+
+func TestBaggageTraces(t *testing.T) {
+	rec := newBaggageReceiver(t, "tenant", "user.id", "region")
+	ctx := rec.StartTracesOp(baggageContext(t))
+
+	td := ptrace.NewTraces()
+	spans := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	spans.AppendEmpty()
+	spans.AppendEmpty().Attributes().PutStr("tenant", "own")
+	rec.StampTraces(ctx, td)
+	rec.EndTracesOp(ctx, format, 2, nil)
+
+	// Only the allowed keys present in the baggage are copied, without overwriting existing attributes.
+	assert.Equal(t, map[string]any{"tenant": "acme", "user.id": "42"}, spans.At(0).Attributes().AsRaw())
+	assert.Equal(t, map[string]any{"tenant": "own", "user.id": "42"}, spans.At(1).Attributes().AsRaw())
+	assert.Zero(t, td.ResourceSpans().At(0).Resource().Attributes().Len())
+}
+
+func TestBaggageMetrics(t *testing.T) {
+	rec := newBaggageReceiver(t, "tenant")
+	ctx := rec.StartMetricsOp(baggageContext(t))
+
+	md := pmetric.NewMetrics()
+	ms := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	ms.AppendEmpty().SetEmptyGauge().DataPoints().AppendEmpty()
+	ms.AppendEmpty().SetEmptySum().DataPoints().AppendEmpty()
+	ms.AppendEmpty().SetEmptyHistogram().DataPoints().AppendEmpty()
+	ms.AppendEmpty().SetEmptyExponentialHistogram().DataPoints().AppendEmpty()
+	ms.AppendEmpty().SetEmptySummary().DataPoints().AppendEmpty()
+	rec.StampMetrics(ctx, md)
+	rec.EndMetricsOp(ctx, format, 5, nil)
+
+	want := map[string]any{"tenant": "acme"}
+	assert.Equal(t, want, ms.At(0).Gauge().DataPoints().At(0).Attributes().AsRaw())
+	assert.Equal(t, want, ms.At(1).Sum().DataPoints().At(0).Attributes().AsRaw())
+	assert.Equal(t, want, ms.At(2).Histogram().DataPoints().At(0).Attributes().AsRaw())
+	assert.Equal(t, want, ms.At(3).ExponentialHistogram().DataPoints().At(0).Attributes().AsRaw())
+	assert.Equal(t, want, ms.At(4).Summary().DataPoints().At(0).Attributes().AsRaw())
+}
+
+func TestBaggageLogs(t *testing.T) {
+	rec := newBaggageReceiver(t, "user.id")
+	ctx := rec.StartLogsOp(baggageContext(t))
+
+	ld := plog.NewLogs()
+	lr := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	rec.StampLogs(ctx, ld)
+	rec.EndLogsOp(ctx, format, 1, nil)
+
+	assert.Equal(t, map[string]any{"user.id": "42"}, lr.Attributes().AsRaw())
+}
+
+func TestBaggageDisabled(t *testing.T) {
+	rec := newBaggageReceiver(t)
+	ctx := rec.StartLogsOp(baggageContext(t))
+
+	ld := plog.NewLogs()
+	lr := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	rec.StampLogs(ctx, ld)
+	rec.EndLogsOp(ctx, format, 1, nil)
+
+	assert.Zero(t, lr.Attributes().Len())
+}
+
+func TestContextWithBaggage(t *testing.T) {
+	ctx := context.Background()
+	got, err := ContextWithBaggage(ctx, "")
+	require.NoError(t, err)
+	assert.Equal(t, ctx, got)
+
+	got, err = ContextWithBaggage(ctx, "invalid baggage")
+	require.Error(t, err)
+	assert.Equal(t, ctx, got)
+}
@@ -0,0 +1,132 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package receiverhelper // import "go.opentelemetry.io/collector/receiver/receiverhelper"
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"strconv"
+	"sync/atomic"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// correlationIDKey is the attribute of the receive operation span holding the correlation ID.
+const correlationIDKey = "correlation_id"
+
+// CorrelationIDSettings configures the correlation ID generated for every receive operation,
+// identifying the data of the operation through the pipeline.
+//
+// The correlation ID is made of a random prefix, drawn once per ObsReport, and of the sequence
+// number of the operation, so the IDs of an ObsReport are unique and ordered. It is stored in
+// the context returned by the StartXOp functions, from which it can be retrieved with
+// CorrelationIDFromContext by the downstream components receiving this context.
+type CorrelationIDSettings struct {
+	// Enabled enables the generation of correlation IDs.
+	Enabled bool
+	// ResourceAttribute, when not empty, is the resource attribute set to the correlation ID by the
+	// StampX functions, which the receiver calls with the context returned by StartXOp.
+	ResourceAttribute string
+}
+
+type correlationIDCtxKey struct{}
+
+// CorrelationIDFromContext returns the correlation ID of the receive operation of ctx,
+// and whether there is one.
+func CorrelationIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(correlationIDCtxKey{}).(string)
+	return id, ok
+}
+
+func contextWithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationIDCtxKey{}, id)
+}
+
+type correlationIDGenerator struct {
+	prefix    string
+	attribute string
+	sequence  atomic.Uint64
+}
+
+// newCorrelationIDGenerator returns the generator for the settings, or nil if they are disabled.
+func newCorrelationIDGenerator(set CorrelationIDSettings) (*correlationIDGenerator, error) {
+	if !set.Enabled {
+		return nil, nil
+	}
+	var prefix [8]byte
+	if _, err := rand.Read(prefix[:]); err != nil {
+		return nil, err
+	}
+	return &correlationIDGenerator{
+		prefix:    hex.EncodeToString(prefix[:]) + "-",
+		attribute: set.ResourceAttribute,
+	}, nil
+}
+
+func (g *correlationIDGenerator) next() string {
+	return g.prefix + strconv.FormatUint(g.sequence.Add(1), 10)
+}
+
+// resourceAttribute returns the resource attribute to set to the correlation ID of ctx, and the ID.
+func (rec *ObsReport) resourceAttribute(ctx context.Context) (string, string, bool) {
+	if rec.correlation == nil || rec.correlation.attribute == "" {
+		return "", "", false
+	}
+	id, ok := CorrelationIDFromContext(ctx)
+	return rec.correlation.attribute, id, ok
+}
+
+// StampTraces sets the configured resource attribute of every resource of td to the correlation ID
+// of receiverCtx, the context returned by StartTracesOp, and the allowed baggage entries of receiverCtx
+// as attributes of the spans.
+func (rec *ObsReport) StampTraces(receiverCtx context.Context, td ptrace.Traces) {
+	if attrs := rec.baggageAttributes(receiverCtx); attrs != nil {
+		stampBaggageTraces(td, attrs)
+	}
+	key, id, ok := rec.resourceAttribute(receiverCtx)
+	if !ok {
+		return
+	}
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rss.At(i).Resource().Attributes().PutStr(key, id)
+	}
+}
+
+// StampMetrics sets the configured resource attribute of every resource of md to the correlation ID
+// of receiverCtx, the context returned by StartMetricsOp, and the allowed baggage entries of receiverCtx
+// as attributes of the data points.
+func (rec *ObsReport) StampMetrics(receiverCtx context.Context, md pmetric.Metrics) {
+	if attrs := rec.baggageAttributes(receiverCtx); attrs != nil {
+		stampBaggageMetrics(md, attrs)
+	}
+	key, id, ok := rec.resourceAttribute(receiverCtx)
+	if !ok {
+		return
+	}
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rms.At(i).Resource().Attributes().PutStr(key, id)
+	}
+}
+
+// StampLogs sets the configured resource attribute of every resource of ld to the correlation ID
+// of receiverCtx, the context returned by StartLogsOp, and the allowed baggage entries of receiverCtx
+// as attributes of the log records.
+func (rec *ObsReport) StampLogs(receiverCtx context.Context, ld plog.Logs) {
+	if attrs := rec.baggageAttributes(receiverCtx); attrs != nil {
+		stampBaggageLogs(ld, attrs)
+	}
+	key, id, ok := rec.resourceAttribute(receiverCtx)
+	if !ok {
+		return
+	}
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rls.At(i).Resource().Attributes().PutStr(key, id)
+	}
+}
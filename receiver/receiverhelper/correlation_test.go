@@ -0,0 +1,141 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package receiverhelper
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+)
+
+func newCorrelatedReceiver(t *testing.T, set CorrelationIDSettings) *ObsReport {
+	rec, err := newReceiver(ObsReportSettings{
+		ReceiverID:             receiverID,
+		Transport:              transport,
+		ReceiverCreateSettings: receivertest.NewNopSettings(),
+		CorrelationID:          set,
+	})
+	require.NoError(t, err)
+	return rec
+}
+
+func TestCorrelationIDDisabled(t *testing.T) {
+	rec := newCorrelatedReceiver(t, CorrelationIDSettings{ResourceAttribute: "correlation.id"})
+	ctx := rec.StartTracesOp(context.Background())
+	_, ok := CorrelationIDFromContext(ctx)
+	assert.False(t, ok)
+
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty()
+	rec.StampTraces(ctx, td)
+	assert.Zero(t, td.ResourceSpans().At(0).Resource().Attributes().Len())
+	rec.EndTracesOp(ctx, format, 0, nil)
+}
+
+func TestCorrelationIDTraces(t *testing.T) {
+	rec := newCorrelatedReceiver(t, CorrelationIDSettings{Enabled: true, ResourceAttribute: "correlation.id"})
+
+	// The exporter receives the context passed along by a processor.
+	var exported []string
+	exporter, err := consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
+		id, ok := CorrelationIDFromContext(ctx)
+		assert.True(t, ok)
+		exported = append(exported, id)
+		for i := 0; i < td.ResourceSpans().Len(); i++ {
+			v, found := td.ResourceSpans().At(i).Resource().Attributes().Get("correlation.id")
+			assert.True(t, found)
+			assert.Equal(t, id, v.Str())
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	processor, err := consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
+		return exporter.ConsumeTraces(ctx, td)
+	})
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		td := ptrace.NewTraces()
+		td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+		td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+		ctx := rec.StartTracesOp(context.Background())
+		rec.StampTraces(ctx, td)
+		err = processor.ConsumeTraces(ctx, td)
+		rec.EndTracesOp(ctx, format, td.SpanCount(), err)
+		require.NoError(t, err)
+	}
+
+	require.Len(t, exported, 3)
+	assert.NotEqual(t, exported[0], exported[1])
+	assert.NotEqual(t, exported[1], exported[2])
+	assert.Regexp(t, "^[0-9a-f]{16}-1$", exported[0])
+	assert.Regexp(t, "^[0-9a-f]{16}-3$", exported[2])
+}
+
+func TestCorrelationIDSpanAttribute(t *testing.T) {
+	tt, err := componenttest.SetupTelemetry(receiverID)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	set := receivertest.NewNopSettings()
+	set.TelemetrySettings = tt.TelemetrySettings()
+	rec, err := newReceiver(ObsReportSettings{
+		ReceiverID:             receiverID,
+		ReceiverCreateSettings: set,
+		CorrelationID:          CorrelationIDSettings{Enabled: true},
+	})
+	require.NoError(t, err)
+	ctx := rec.StartLogsOp(context.Background())
+	id, ok := CorrelationIDFromContext(ctx)
+	require.True(t, ok)
+	rec.EndLogsOp(ctx, format, 0, nil)
+
+	spans := tt.SpanRecorder.Ended()
+	require.Len(t, spans, 1)
+	var found bool
+	for _, attr := range spans[0].Attributes() {
+		if string(attr.Key) == "correlation_id" {
+			found = true
+			assert.Equal(t, id, attr.Value.AsString())
+		}
+	}
+	assert.True(t, found)
+}
+
+func TestCorrelationIDStampMetricsLogs(t *testing.T) {
+	rec := newCorrelatedReceiver(t, CorrelationIDSettings{Enabled: true, ResourceAttribute: "correlation.id"})
+
+	ctx := rec.StartMetricsOp(context.Background())
+	id, ok := CorrelationIDFromContext(ctx)
+	require.True(t, ok)
+	md := pmetric.NewMetrics()
+	md.ResourceMetrics().AppendEmpty()
+	md.ResourceMetrics().AppendEmpty()
+	rec.StampMetrics(ctx, md)
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		v, _ := md.ResourceMetrics().At(i).Resource().Attributes().Get("correlation.id")
+		assert.Equal(t, id, v.Str())
+	}
+	rec.EndMetricsOp(ctx, format, 0, nil)
+
+	ctx = rec.StartLogsOp(context.Background())
+	logsID, ok := CorrelationIDFromContext(ctx)
+	require.True(t, ok)
+	assert.NotEqual(t, id, logsID)
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty()
+	rec.StampLogs(ctx, ld)
+	v, _ := ld.ResourceLogs().At(0).Resource().Attributes().Get("correlation.id")
+	assert.Equal(t, logsID, v.Str())
+	rec.EndLogsOp(ctx, format, 0, nil)
+}
@@ -3,6 +3,7 @@
 package metadata
 
 import (
+	"context"
 	"errors"
 
 	"go.opentelemetry.io/otel/metric"
@@ -32,6 +33,7 @@ type TelemetryBuilder struct {
 	ReceiverAcceptedLogRecords   metric.Int64Counter
 	ReceiverAcceptedMetricPoints metric.Int64Counter
 	ReceiverAcceptedSpans        metric.Int64Counter
+	ReceiverDistinctResources    metric.Int64ObservableGauge
 	ReceiverRefusedLogRecords    metric.Int64Counter
 	ReceiverRefusedMetricPoints  metric.Int64Counter
 	ReceiverRefusedSpans         metric.Int64Counter
@@ -41,6 +43,24 @@ type TelemetryBuilder struct {
 // telemetryBuilderOption applies changes to default builder.
 type telemetryBuilderOption func(*TelemetryBuilder)
 
+// InitReceiverDistinctResources configures the ReceiverDistinctResources metric.
+func (builder *TelemetryBuilder) InitReceiverDistinctResources(cb func() int64, opts ...metric.ObserveOption) error {
+	var err error
+	builder.ReceiverDistinctResources, err = builder.meters[configtelemetry.LevelBasic].Int64ObservableGauge(
+		"otelcol_receiver_distinct_resources",
+		metric.WithDescription("Estimated number of distinct resources received during the last complete resource cardinality interval."),
+		metric.WithUnit("{resources}"),
+	)
+	if err != nil {
+		return err
+	}
+	_, err = builder.meters[configtelemetry.LevelBasic].RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		o.ObserveInt64(builder.ReceiverDistinctResources, cb(), opts...)
+		return nil
+	}, builder.ReceiverDistinctResources)
+	return err
+}
+
 // NewTelemetryBuilder provides a struct with methods to update all internal telemetry
 // for a component
 func NewTelemetryBuilder(settings component.TelemetrySettings, options ...telemetryBuilderOption) (*TelemetryBuilder, error) {
@@ -7,6 +7,7 @@ package receiverhelper // import "go.opentelemetry.io/collector/receiver/receive
 
 import (
 	"context"
+	"time"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
@@ -25,6 +26,16 @@ type ObsReport struct {
 	transport      string
 	longLivedCtx   bool
 	tracer         trace.Tracer
+	correlation    *correlationIDGenerator
+	baggageKeys    []string
+
+	// The counters of the distinct resources received by signal, nil if disabled.
+	tracesResources  *resourceCounter
+	metricsResources *resourceCounter
+	logsResources    *resourceCounter
+
+	// The detector of the attributes exceeding the cardinality threshold, nil if disabled.
+	attributeCardinality *cardinalityDetector
 
 	otelAttrs        []attribute.KeyValue
 	telemetryBuilder *metadata.TelemetryBuilder
@@ -41,6 +52,18 @@ type ObsReportSettings struct {
 	// operations without a corresponding new context per operation.
 	LongLivedCtx           bool
 	ReceiverCreateSettings receiver.Settings
+	// CorrelationID configures the generation of a correlation ID for every
+	// receive operation, see CorrelationIDSettings.
+	CorrelationID CorrelationIDSettings
+	// Baggage configures the baggage entries set as attributes on the
+	// received data, see BaggageSettings.
+	Baggage BaggageSettings
+	// ResourceCardinality configures the estimation of the number of
+	// distinct resources received, see ResourceCardinalitySettings.
+	ResourceCardinality ResourceCardinalitySettings
+	// AttributeCardinality configures the warnings logged for the attributes
+	// with too many distinct values, see AttributeCardinalitySettings.
+	AttributeCardinality AttributeCardinalitySettings
 }
 
 // NewObsReport creates a new ObsReport.
@@ -53,18 +76,43 @@ func newReceiver(cfg ObsReportSettings) (*ObsReport, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &ObsReport{
+	correlation, err := newCorrelationIDGenerator(cfg.CorrelationID)
+	if err != nil {
+		return nil, err
+	}
+	rec := &ObsReport{
 		spanNamePrefix: obsmetrics.ReceiverPrefix + cfg.ReceiverID.String(),
 		transport:      cfg.Transport,
 		longLivedCtx:   cfg.LongLivedCtx,
 		tracer:         cfg.ReceiverCreateSettings.TracerProvider.Tracer(cfg.ReceiverID.String()),
+		correlation:    correlation,
+		baggageKeys:    cfg.Baggage.Keys,
 
 		otelAttrs: []attribute.KeyValue{
 			attribute.String(obsmetrics.ReceiverKey, cfg.ReceiverID.String()),
 			attribute.String(obsmetrics.TransportKey, cfg.Transport),
 		},
 		telemetryBuilder: telemetryBuilder,
-	}, nil
+	}
+	if cfg.ResourceCardinality.Interval > 0 {
+		rec.tracesResources = newResourceCounter(cfg.ResourceCardinality.Interval, time.Now)
+		rec.metricsResources = newResourceCounter(cfg.ResourceCardinality.Interval, time.Now)
+		rec.logsResources = newResourceCounter(cfg.ResourceCardinality.Interval, time.Now)
+		for dataType, counter := range map[component.DataType]*resourceCounter{
+			component.DataTypeTraces:  rec.tracesResources,
+			component.DataTypeMetrics: rec.metricsResources,
+			component.DataTypeLogs:    rec.logsResources,
+		} {
+			attrs := attribute.NewSet(append([]attribute.KeyValue{attribute.String(obsmetrics.DataTypeKey, dataType.String())}, rec.otelAttrs...)...)
+			if err = telemetryBuilder.InitReceiverDistinctResources(counter.estimate, metric.WithAttributeSet(attrs)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if cfg.AttributeCardinality.Threshold > 0 {
+		rec.attributeCardinality = newCardinalityDetector(cfg.AttributeCardinality, cfg.ReceiverCreateSettings.Logger, time.Now)
+	}
+	return rec, nil
 }
 
 // StartTracesOp is called when a request is received from a client.
@@ -143,6 +191,11 @@ func (rec *ObsReport) startOp(receiverCtx context.Context, operationSuffix strin
 	if rec.transport != "" {
 		span.SetAttributes(attribute.String(obsmetrics.TransportKey, rec.transport))
 	}
+	if rec.correlation != nil {
+		id := rec.correlation.next()
+		span.SetAttributes(attribute.String(correlationIDKey, id))
+		ctx = contextWithCorrelationID(ctx, id)
+	}
 	return ctx
 }
 
@@ -0,0 +1,156 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package receiverhelper // import "go.opentelemetry.io/collector/receiver/receiverhelper"
+
+import (
+	"encoding/binary"
+	"math"
+	"math/bits"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// ResourceCardinalitySettings configures the estimation of the number of distinct resources received
+// per interval, reported by the receiver_distinct_resources metric for each signal.
+//
+// The resources are identified by their attributes, and counted by the RecordXResources functions, which
+// the receiver calls with the data it receives. The estimate uses a HyperLogLog sketch of fixed size, with a
+// standard error of about 1.6%.
+type ResourceCardinalitySettings struct {
+	// Interval is the period over which the distinct resources are counted. The metric reports the estimate
+	// of the last complete interval, 0 during the first one. Zero disables the estimation.
+	Interval time.Duration
+}
+
+// RecordTracesResources counts the resources of td in the distinct resources received by the receiver.
+func (rec *ObsReport) RecordTracesResources(td ptrace.Traces) {
+	if rec.tracesResources == nil {
+		return
+	}
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rec.tracesResources.add(rss.At(i).Resource())
+	}
+}
+
+// RecordMetricsResources counts the resources of md in the distinct resources received by the receiver.
+func (rec *ObsReport) RecordMetricsResources(md pmetric.Metrics) {
+	if rec.metricsResources == nil {
+		return
+	}
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rec.metricsResources.add(rms.At(i).Resource())
+	}
+}
+
+// RecordLogsResources counts the resources of ld in the distinct resources received by the receiver.
+func (rec *ObsReport) RecordLogsResources(ld plog.Logs) {
+	if rec.logsResources == nil {
+		return
+	}
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rec.logsResources.add(rls.At(i).Resource())
+	}
+}
+
+// resourceCounter estimates the number of distinct resources received per interval.
+type resourceCounter struct {
+	interval time.Duration
+	now      func() time.Time
+
+	mu sync.Mutex
+	// start is the start of the current interval.
+	start   time.Time
+	current hyperLogLog
+	// last is the estimate of the last complete interval.
+	last int64
+}
+
+func newResourceCounter(interval time.Duration, now func() time.Time) *resourceCounter {
+	return &resourceCounter{interval: interval, now: now, start: now()}
+}
+
+func (rc *resourceCounter) add(res pcommon.Resource) {
+	fp := res.Fingerprint()
+	hash := mix64(binary.LittleEndian.Uint64(fp[:8]) ^ binary.LittleEndian.Uint64(fp[8:]))
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.rotate()
+	rc.current.add(hash)
+}
+
+// estimate returns the estimate of the last complete interval.
+func (rc *resourceCounter) estimate() int64 {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.rotate()
+	return rc.last
+}
+
+// rotate starts a new interval if the current one is complete. It must be called with mu held.
+func (rc *resourceCounter) rotate() {
+	elapsed := rc.now().Sub(rc.start)
+	if elapsed < rc.interval {
+		return
+	}
+	rc.last = 0
+	// No resource was received during the last complete interval if more than one elapsed.
+	if elapsed < 2*rc.interval {
+		rc.last = int64(rc.current.estimate())
+	}
+	rc.current = hyperLogLog{}
+	rc.start = rc.start.Add(elapsed.Truncate(rc.interval))
+}
+
+// mix64 is the finalizer of SplitMix64, spreading the bits of the fingerprints over the whole hash.
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// hllPrecision is the number of bits of the hashes selecting the register of a HyperLogLog.
+const hllPrecision = 12
+
+// hyperLogLog estimates the number of distinct hashes added to it.
+type hyperLogLog struct {
+	registers [1 << hllPrecision]uint8
+}
+
+func (h *hyperLogLog) add(hash uint64) {
+	idx := hash >> (64 - hllPrecision)
+	// The bit set below the remaining bits bounds the rank when they are all zeros.
+	rank := uint8(bits.LeadingZeros64(hash<<hllPrecision|1<<(hllPrecision-1))) + 1
+	if rank > h.registers[idx] {
+		h.registers[idx] = rank
+	}
+}
+
+func (h *hyperLogLog) estimate() uint64 {
+	m := float64(len(h.registers))
+	sum := 0.0
+	zeros := 0
+	for _, r := range h.registers {
+		sum += math.Ldexp(1, -int(r))
+		if r == 0 {
+			zeros++
+		}
+	}
+	estimate := 0.7213 / (1 + 1.079/m) * m * m / sum
+	// Linear counting is more accurate for the small cardinalities.
+	if estimate <= 2.5*m && zeros > 0 {
+		estimate = m * math.Log(m/float64(zeros))
+	}
+	return uint64(math.Round(estimate))
+}
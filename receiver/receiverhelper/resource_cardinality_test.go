@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package receiverhelper
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+)
+
+// distinctResources returns the value of the receiver_distinct_resources gauge by data type.
+func distinctResources(t *testing.T, reader *sdkmetric.ManualReader) map[string]int64 {
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	got := map[string]int64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != "otelcol_receiver_distinct_resources" {
+				continue
+			}
+			gauge, ok := m.Data.(metricdata.Gauge[int64])
+			require.True(t, ok)
+			for _, dp := range gauge.DataPoints {
+				receiver, _ := dp.Attributes.Value(obsmetrics.ReceiverKey)
+				assert.Equal(t, receiverID.String(), receiver.AsString())
+				dataType, _ := dp.Attributes.Value(obsmetrics.DataTypeKey)
+				got[dataType.AsString()] = dp.Value
+			}
+		}
+	}
+	return got
+}
+
+func TestResourceCardinality(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	set := receivertest.NewNopSettings()
+	set.MeterProvider = mp
+	set.LeveledMeterProvider = func(configtelemetry.Level) metric.MeterProvider { return mp }
+	rec, err := newReceiver(ObsReportSettings{
+		ReceiverID:             receiverID,
+		Transport:              transport,
+		ReceiverCreateSettings: set,
+		ResourceCardinality:    ResourceCardinalitySettings{Interval: time.Minute},
+	})
+	require.NoError(t, err)
+	now := time.Unix(1000, 0)
+	for _, rc := range []*resourceCounter{rec.tracesResources, rec.metricsResources, rec.logsResources} {
+		rc.now = func() time.Time { return now }
+		rc.start = now
+	}
+
+	const distinct = 20_000
+	td := ptrace.NewTraces()
+	for i := 0; i < distinct; i++ {
+		td.ResourceSpans().AppendEmpty().Resource().Attributes().PutStr("service.instance.id", strconv.Itoa(i))
+	}
+	// The resources received several times are counted once.
+	rec.RecordTracesResources(td)
+	rec.RecordTracesResources(td)
+	md := pmetric.NewMetrics()
+	for i := 0; i < 100; i++ {
+		md.ResourceMetrics().AppendEmpty().Resource().Attributes().PutInt("host.id", int64(i%10))
+	}
+	rec.RecordMetricsResources(md)
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty()
+	rec.RecordLogsResources(ld)
+
+	// The estimates are reported once the interval is complete.
+	assert.Equal(t, map[string]int64{"traces": 0, "metrics": 0, "logs": 0}, distinctResources(t, reader))
+	now = now.Add(time.Minute)
+	got := distinctResources(t, reader)
+	assert.InDelta(t, distinct, got["traces"], distinct*0.05)
+	assert.Equal(t, int64(10), got["metrics"])
+	assert.Equal(t, int64(1), got["logs"])
+
+	// The next interval starts empty.
+	rec.RecordLogsResources(ld)
+	now = now.Add(time.Minute)
+	assert.Equal(t, map[string]int64{"traces": 0, "metrics": 0, "logs": 1}, distinctResources(t, reader))
+	// No resource was received during the last complete interval.
+	rec.RecordLogsResources(ld)
+	now = now.Add(3 * time.Minute)
+	assert.Equal(t, map[string]int64{"traces": 0, "metrics": 0, "logs": 0}, distinctResources(t, reader))
+}
+
+func TestResourceCardinalityDisabled(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	set := receivertest.NewNopSettings()
+	set.MeterProvider = mp
+	set.LeveledMeterProvider = func(configtelemetry.Level) metric.MeterProvider { return mp }
+	rec, err := newReceiver(ObsReportSettings{ReceiverID: receiverID, Transport: transport, ReceiverCreateSettings: set})
+	require.NoError(t, err)
+
+	rec.RecordTracesResources(ptrace.NewTraces())
+	rec.RecordMetricsResources(pmetric.NewMetrics())
+	rec.RecordLogsResources(plog.NewLogs())
+	assert.Empty(t, distinctResources(t, reader))
+}
+
+func TestHyperLogLogEstimate(t *testing.T) {
+	for _, distinct := range []int{0, 1, 100, 1_000, 10_000, 100_000} {
+		var h hyperLogLog
+		for i := 0; i < distinct; i++ {
+			h.add(mix64(uint64(i)))
+		}
+		assert.InDeltaf(t, distinct, h.estimate(), float64(distinct)*0.05, "%d distinct hashes", distinct)
+	}
+}
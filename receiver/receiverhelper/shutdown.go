@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package receiverhelper // import "go.opentelemetry.io/collector/receiver/receiverhelper"
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrShuttingDown is returned by ShutdownGuard.Accept once the receiver started shutting down.
+// It is not a permanent error, so it maps to the retryable UNAVAILABLE gRPC status
+// and to the HTTP 503 status code, telling clients to retry on another instance or later.
+var ErrShuttingDown = errors.New("receiver is shutting down")
+
+// ShutdownGuard rejects the incoming requests of a receiver once its shutdown began,
+// instead of accepting data that would then fail downstream. It also tracks the requests
+// being processed, so Shutdown can wait for them to complete.
+// The zero value is ready to use. A ShutdownGuard must not be copied after first use.
+type ShutdownGuard struct {
+	mu           sync.RWMutex
+	shuttingDown bool
+	inFlight     sync.WaitGroup
+}
+
+// Accept must be called by the receiver before processing a request. It returns ErrShuttingDown
+// if the shutdown began, in which case the request must be rejected with this error. Otherwise,
+// the returned function must be called once the request is processed.
+func (g *ShutdownGuard) Accept() (func(), error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	if g.shuttingDown {
+		return nil, ErrShuttingDown
+	}
+	g.inFlight.Add(1)
+	return g.inFlight.Done, nil
+}
+
+// Shutdown makes the following calls to Accept fail with ErrShuttingDown, then waits for the
+// accepted requests to complete or the context to be done, returning the context error in that case.
+func (g *ShutdownGuard) Shutdown(ctx context.Context) error {
+	g.mu.Lock()
+	g.shuttingDown = true
+	g.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		g.inFlight.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package receiverhelper
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+)
+
+func TestShutdownGuard(t *testing.T) {
+	var g ShutdownGuard
+	done, err := g.Accept()
+	require.NoError(t, err)
+	done()
+
+	require.NoError(t, g.Shutdown(context.Background()))
+
+	done, err = g.Accept()
+	assert.Nil(t, done)
+	require.ErrorIs(t, err, ErrShuttingDown)
+	// The error is retryable, so it maps to UNAVAILABLE / 503.
+	assert.False(t, consumererror.IsPermanent(err))
+}
+
+func TestShutdownGuardWaitsInFlight(t *testing.T) {
+	var g ShutdownGuard
+	done, err := g.Accept()
+	require.NoError(t, err)
+
+	shutdownErr := make(chan error)
+	go func() {
+		shutdownErr <- g.Shutdown(context.Background())
+	}()
+
+	// New requests are rejected as soon as the shutdown began, while the accepted one is still processed.
+	assert.Eventually(t, func() bool {
+		_, err := g.Accept()
+		return err != nil
+	}, time.Second, time.Millisecond)
+	select {
+	case <-shutdownErr:
+		t.Fatal("Shutdown returned before the accepted request completed")
+	default:
+	}
+
+	done()
+	require.NoError(t, <-shutdownErr)
+}
+
+func TestShutdownGuardContextDone(t *testing.T) {
+	var g ShutdownGuard
+	done, err := g.Accept()
+	require.NoError(t, err)
+	defer done()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, g.Shutdown(ctx), context.DeadlineExceeded)
+
+	_, err = g.Accept()
+	assert.ErrorIs(t, err, ErrShuttingDown)
+}
@@ -4,7 +4,9 @@
 package service // import "go.opentelemetry.io/collector/service"
 
 import (
+	"errors"
 	"fmt"
+	"time"
 
 	"go.opentelemetry.io/collector/service/extensions"
 	"go.opentelemetry.io/collector/service/pipelines"
@@ -21,6 +23,34 @@ type Config struct {
 
 	// Pipelines are the set of data pipelines configured for the service.
 	Pipelines pipelines.Config `mapstructure:"pipelines"`
+
+	// StatusDebounce is the time window during which the transitions of a component between the
+	// StatusOK and StatusRecoverableError statuses are coalesced, so only the settled status is
+	// reported to the status listeners. Zero, the default, reports every transition.
+	StatusDebounce time.Duration `mapstructure:"status_debounce"`
+
+	// Warmup is the period after the start of the pipelines during which the receivers reject the
+	// data they receive with a retryable error, e.g. UNAVAILABLE, giving time to the exporters to
+	// establish their connections. Zero, the default, accepts data as soon as the pipelines start.
+	Warmup time.Duration `mapstructure:"warmup"`
+
+	// IngestLimit caps the rate of the items received by all the pipelines. It is disabled by default.
+	IngestLimit IngestLimitConfig `mapstructure:"ingest_limit"`
+
+	// DataFreshness reports the age of the newest spans, metric points and log records sent by the receivers
+	// to the pipelines in the pipeline_data_freshness metric, by data type, e.g. to alert on stale data. It
+	// is disabled by default, since the timestamps of all the records are read.
+	DataFreshness bool `mapstructure:"data_freshness"`
+
+	// GoroutineLimit rejects the data received by all the pipelines while the collector runs too many
+	// goroutines. It is disabled by default.
+	GoroutineLimit GoroutineLimitConfig `mapstructure:"goroutine_limit"`
+
+	// ValidateExtensionReferences makes the configuration invalid if a component references, e.g. as its
+	// authenticator or storage, an extension which is not configured or not enabled in Extensions, instead
+	// of failing once the component starts. Every component ID of a component configuration, other than
+	// a pipeline ID, is considered an extension reference.
+	ValidateExtensionReferences bool `mapstructure:"validate_extension_references"`
 }
 
 func (cfg *Config) Validate() error {
@@ -28,9 +58,82 @@ func (cfg *Config) Validate() error {
 		return fmt.Errorf("service::pipelines config validation failed: %w", err)
 	}
 
+	if cfg.StatusDebounce < 0 {
+		return errors.New("service::status_debounce must not be negative")
+	}
+
+	if cfg.Warmup < 0 {
+		return errors.New("service::warmup must not be negative")
+	}
+
+	if cfg.IngestLimit.ItemsPerSecond < 0 {
+		return errors.New("service::ingest_limit::items_per_second must not be negative")
+	}
+
+	if cfg.IngestLimit.Burst < 0 {
+		return errors.New("service::ingest_limit::burst must not be negative")
+	}
+
+	if cfg.GoroutineLimit.MaxGoroutines < 0 {
+		return errors.New("service::goroutine_limit::max_goroutines must not be negative")
+	}
+
+	if cfg.GoroutineLimit.ResumeGoroutines < 0 {
+		return errors.New("service::goroutine_limit::resume_goroutines must not be negative")
+	}
+
+	if cfg.GoroutineLimit.ResumeGoroutines > 0 && cfg.GoroutineLimit.ResumeGoroutines >= cfg.GoroutineLimit.MaxGoroutines {
+		return errors.New("service::goroutine_limit::resume_goroutines must be lower than max_goroutines")
+	}
+
+	// The invalid process metrics and status changes settings are rejected, while the errors of
+	// cfg.Telemetry.Validate are only printed.
+	if err := cfg.Telemetry.Metrics.Process.Validate(); err != nil {
+		return fmt.Errorf("service::telemetry::metrics::process config validation failed: %w", err)
+	}
+
+	if cfg.Telemetry.Logs.StatusChanges != nil {
+		if err := cfg.Telemetry.Logs.StatusChanges.Validate(); err != nil {
+			return fmt.Errorf("service::telemetry::logs::status_changes config validation failed: %w", err)
+		}
+	}
+
 	if err := cfg.Telemetry.Validate(); err != nil {
 		fmt.Printf("service::telemetry config validation failed: %v\n", err)
 	}
 
 	return nil
 }
+
+// IngestLimitConfig defines a token bucket capping the items, i.e. spans, metric points, log records and
+// profile samples, sent by all the receivers to the pipelines. The items of a receiver configured in several
+// pipelines are counted once per pipeline type.
+type IngestLimitConfig struct {
+	// ItemsPerSecond is the rate of items accepted over time. Zero, the default, disables the limit.
+	ItemsPerSecond float64 `mapstructure:"items_per_second"`
+
+	// Burst is the number of items which can be accepted at once, ItemsPerSecond if zero. A request with
+	// more items than Burst is only accepted when no items were accepted during Burst/ItemsPerSecond
+	// seconds, and delays the next requests accordingly.
+	Burst int `mapstructure:"burst"`
+
+	// Drop drops the requests exceeding the limit, the receivers reporting them as successfully received.
+	// By default, they are rejected with a retryable error, e.g. UNAVAILABLE with gRPC or 503 with HTTP,
+	// applying backpressure to the clients.
+	Drop bool `mapstructure:"drop"`
+}
+
+// GoroutineLimitConfig defines a ceiling of the goroutines run by the collector, e.g. by the receivers handling
+// each request in a goroutine, above which the receivers reject the data they receive. The number of goroutines
+// is reported in the process_runtime_goroutines metric, and the rejected items in the
+// pipeline_goroutine_limited_items metric.
+type GoroutineLimitConfig struct {
+	// MaxGoroutines is the number of goroutines from which the data sent by the receivers to the pipelines is
+	// rejected with a retryable error, e.g. UNAVAILABLE with gRPC or 503 with HTTP, applying backpressure to the
+	// clients until goroutines complete. Zero, the default, disables the limit.
+	MaxGoroutines int `mapstructure:"max_goroutines"`
+	// ResumeGoroutines is the number of goroutines below which the data is accepted again once the limit was
+	// reached, so the receivers do not flap between accepting and rejecting the data around MaxGoroutines. It
+	// must be lower than MaxGoroutines, and defaults to 90% of MaxGoroutines if zero.
+	ResumeGoroutines int `mapstructure:"resume_goroutines"`
+}
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/zap/zapcore"
@@ -71,6 +72,101 @@ func TestConfigValidate(t *testing.T) {
 			},
 			expected: nil,
 		},
+		{
+			name: "negative-status-debounce",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.StatusDebounce = -time.Second
+				return cfg
+			},
+			expected: errors.New("service::status_debounce must not be negative"),
+		},
+		{
+			name: "negative-warmup",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Warmup = -time.Second
+				return cfg
+			},
+			expected: errors.New("service::warmup must not be negative"),
+		},
+		{
+			name: "negative-ingest-limit-rate",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.IngestLimit.ItemsPerSecond = -1
+				return cfg
+			},
+			expected: errors.New("service::ingest_limit::items_per_second must not be negative"),
+		},
+		{
+			name: "negative-ingest-limit-burst",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.IngestLimit.Burst = -1
+				return cfg
+			},
+			expected: errors.New("service::ingest_limit::burst must not be negative"),
+		},
+		{
+			name: "negative-goroutine-limit",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.GoroutineLimit.MaxGoroutines = -1
+				return cfg
+			},
+			expected: errors.New("service::goroutine_limit::max_goroutines must not be negative"),
+		},
+		{
+			name: "unknown-process-metric",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Telemetry.Metrics.Process.Metrics = []string{"process_uptime", "process_unknown"}
+				return cfg
+			},
+			expected: fmt.Errorf(`service::telemetry::metrics::process config validation failed: %w`,
+				fmt.Errorf(`collector telemetry process metrics are invalid: %w`, errors.New(`unknown process metric "process_unknown"`))),
+		},
+		{
+			name: "negative-process-mem-stats-interval",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Telemetry.Metrics.Process.MemStatsInterval = -time.Second
+				return cfg
+			},
+			expected: fmt.Errorf(`service::telemetry::metrics::process config validation failed: %w`,
+				errors.New("collector telemetry process metrics memory statistics interval must not be negative")),
+		},
+		{
+			name: "negative-status-changes-interval",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Telemetry.Logs.StatusChanges = &telemetry.LogsStatusChangesConfig{Interval: -time.Second}
+				return cfg
+			},
+			expected: fmt.Errorf(`service::telemetry::logs::status_changes config validation failed: %w`,
+				errors.New("collector telemetry logs status changes interval must not be negative")),
+		},
+		{
+			name: "negative-goroutine-resume",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.GoroutineLimit.MaxGoroutines = 100
+				cfg.GoroutineLimit.ResumeGoroutines = -1
+				return cfg
+			},
+			expected: errors.New("service::goroutine_limit::resume_goroutines must not be negative"),
+		},
+		{
+			name: "goroutine-resume-above-limit",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.GoroutineLimit.MaxGoroutines = 100
+				cfg.GoroutineLimit.ResumeGoroutines = 100
+				return cfg
+			},
+			expected: errors.New("service::goroutine_limit::resume_goroutines must be lower than max_goroutines"),
+		},
 	}
 
 	for _, test := range testCases {
@@ -0,0 +1,24 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package hostcapabilities provides interfaces that can be implemented by the host
+// to provide additional capabilities.
+//
+// This package is currently under development and is exempt from the Collector SIG's
+// breaking change policy.
+package hostcapabilities // import "go.opentelemetry.io/collector/service/hostcapabilities"
+
+import (
+	"go.opentelemetry.io/collector/component"
+)
+
+// ProcessorToggler is an extra interface for `component.Host` implementations.
+// It allows an extension to enable or disable a processor of a pipeline at runtime,
+// e.g. for A/B testing, without reloading the configuration.
+type ProcessorToggler interface {
+	// SetProcessorEnabled enables or disables the processor of the pipeline. A disabled
+	// processor is short-circuited, the data being passed directly to its next consumer
+	// until it is enabled again. It returns an error if the pipeline does not exist or
+	// does not contain the processor.
+	SetProcessorEnabled(pipelineID, processorID component.ID, enabled bool) error
+}
@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package confighash reports a hash of the effective configuration of the collector, to detect configuration
+// drift across instances.
+package confighash // import "go.opentelemetry.io/collector/service/internal/confighash"
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/service/internal/confighash/internal/metadata"
+)
+
+// hashAttributeKey is the attribute of the otelcol_config_info metric holding the configuration hash.
+const hashAttributeKey = "config.hash"
+
+// Hash returns the hex encoded SHA-256 hash of the configuration. The configuration is expected to be
+// marshaled from the typed component configurations, so the sensitive values are already redacted.
+// The hash does not depend on the order of the keys, so identical configurations have the same hash.
+func Hash(conf *confmap.Conf) (string, error) {
+	// Maps are marshaled with sorted keys, which makes the encoding stable.
+	b, err := json.Marshal(conf.ToStringMap())
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// RegisterMetric registers the otelcol_config_info metric reporting the hash of the configuration.
+func RegisterMetric(set component.TelemetrySettings, conf *confmap.Conf) error {
+	hash, err := Hash(conf)
+	if err != nil {
+		return err
+	}
+	_, err = metadata.NewTelemetryBuilder(set,
+		metadata.WithConfigInfoCallback(func() int64 { return 1 }, metric.WithAttributes(attribute.String(hashAttributeKey, hash))))
+	return err
+}
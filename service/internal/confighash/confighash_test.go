@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confighash
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/confmap"
+)
+
+func newConf(endpoint string) *confmap.Conf {
+	return confmap.NewFromStringMap(map[string]any{
+		"receivers": map[string]any{
+			"otlp": map[string]any{"protocols": map[string]any{"grpc": nil}},
+		},
+		"exporters": map[string]any{
+			"otlp": map[string]any{
+				"endpoint": endpoint,
+				"headers":  map[string]any{"authorization": "[REDACTED]"},
+			},
+		},
+		"service": map[string]any{
+			"pipelines": map[string]any{
+				"traces": map[string]any{"receivers": []any{"otlp"}, "exporters": []any{"otlp"}},
+			},
+		},
+	})
+}
+
+func TestHash(t *testing.T) {
+	hash, err := Hash(newConf("localhost:4317"))
+	require.NoError(t, err)
+	assert.Len(t, hash, 64)
+
+	// Identical configurations have the same hash, whatever the order their keys were set in.
+	same := confmap.New()
+	require.NoError(t, same.Merge(confmap.NewFromStringMap(map[string]any{"service": newConf("localhost:4317").Get("service")})))
+	require.NoError(t, same.Merge(confmap.NewFromStringMap(map[string]any{"exporters": newConf("localhost:4317").Get("exporters")})))
+	require.NoError(t, same.Merge(confmap.NewFromStringMap(map[string]any{"receivers": newConf("localhost:4317").Get("receivers")})))
+	sameHash, err := Hash(same)
+	require.NoError(t, err)
+	assert.Equal(t, hash, sameHash)
+
+	otherHash, err := Hash(newConf("otherhost:4317"))
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, otherHash)
+}
+
+func TestRegisterMetric(t *testing.T) {
+	tel := setupTestTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+	set := componenttest.NewNopTelemetrySettings()
+	set.MetricsLevel = configtelemetry.LevelBasic
+	set.LeveledMeterProvider = func(configtelemetry.Level) metric.MeterProvider {
+		return tel.meterProvider
+	}
+
+	conf := newConf("localhost:4317")
+	require.NoError(t, RegisterMetric(set, conf))
+	hash, err := Hash(conf)
+	require.NoError(t, err)
+
+	tel.assertMetrics(t, []metricdata.Metrics{
+		{
+			Name:        "otelcol_config_info",
+			Description: "Information about the effective configuration of the collector. The config.hash attribute holds the hash of the redacted configuration, the value is always 1.",
+			Unit:        "1",
+			Data: metricdata.Gauge[int64]{
+				DataPoints: []metricdata.DataPoint[int64]{
+					{
+						Attributes: attribute.NewSet(attribute.String("config.hash", hash)),
+						Value:      1,
+					},
+				},
+			},
+		},
+	})
+}
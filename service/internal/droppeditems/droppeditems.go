@@ -0,0 +1,134 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package droppeditems reports the otelcol_pipeline_dropped_items metric, summing the items dropped
+// by the processors and the exporters of all the pipelines, which allows alerting on a single metric.
+package droppeditems // import "go.opentelemetry.io/collector/service/internal/droppeditems"
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configtelemetry"
+)
+
+const (
+	scopeName  = "go.opentelemetry.io/collector/service"
+	metricName = "otelcol_pipeline_dropped_items"
+
+	signalKey = "signal"
+	reasonKey = "reason"
+
+	// reasonProcessor is the reason of the items dropped by a processor.
+	reasonProcessor = "processor"
+	// reasonSendFailed is the reason of the items an exporter failed to send.
+	reasonSendFailed = "send_failed"
+	// reasonEnqueueFailed is the reason of the items an exporter failed to add to its sending queue.
+	reasonEnqueueFailed = "enqueue_failed"
+)
+
+type source struct {
+	signal string
+	reason string
+}
+
+// sources maps the drop counters of processorhelper and exporterhelper to their signal and reason. The
+// otelcol_processor_spans_dropped counter is not listed, its spans being also counted by otelcol_processor_dropped_spans.
+var sources = map[string]source{
+	"otelcol_processor_dropped_spans":               {signal: "traces", reason: reasonProcessor},
+	"otelcol_processor_dropped_metric_points":       {signal: "metrics", reason: reasonProcessor},
+	"otelcol_processor_dropped_log_records":         {signal: "logs", reason: reasonProcessor},
+	"otelcol_exporter_send_failed_spans":            {signal: "traces", reason: reasonSendFailed},
+	"otelcol_exporter_send_failed_metric_points":    {signal: "metrics", reason: reasonSendFailed},
+	"otelcol_exporter_send_failed_log_records":      {signal: "logs", reason: reasonSendFailed},
+	"otelcol_exporter_enqueue_failed_spans":         {signal: "traces", reason: reasonEnqueueFailed},
+	"otelcol_exporter_enqueue_failed_metric_points": {signal: "metrics", reason: reasonEnqueueFailed},
+	"otelcol_exporter_enqueue_failed_log_records":   {signal: "logs", reason: reasonEnqueueFailed},
+}
+
+// Aggregator sums the drop counters collected through its Reader into the otelcol_pipeline_dropped_items
+// metric, with the signal and reason attributes.
+//
+// The metric is observed with a single callback for all the signals and reasons, so its instrument is
+// created directly rather than through a generated telemetry builder.
+type Aggregator struct {
+	reader *sdkmetric.ManualReader
+}
+
+// collectingKey marks the context of the collections of the Reader, which call the callback of the metric.
+type collectingKey struct{}
+
+// NewAggregator returns an Aggregator. Its Reader must be registered with the meter provider of the
+// service before the metric is registered.
+func NewAggregator() *Aggregator {
+	return &Aggregator{reader: sdkmetric.NewManualReader()}
+}
+
+// Reader returns the reader collecting the drop counters.
+func (a *Aggregator) Reader() sdkmetric.Reader {
+	return a.reader
+}
+
+// RegisterMetric registers the otelcol_pipeline_dropped_items metric.
+func (a *Aggregator) RegisterMetric(set component.TelemetrySettings) error {
+	meter := set.LeveledMeterProvider(configtelemetry.LevelBasic).Meter(scopeName)
+	counter, err := meter.Int64ObservableCounter(
+		metricName,
+		metric.WithDescription("Number of items dropped by the processors and exporters of all the pipelines, by signal and reason."),
+		metric.WithUnit("{items}"),
+	)
+	if err != nil {
+		return err
+	}
+	_, err = meter.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
+		if ctx.Value(collectingKey{}) != nil {
+			return nil
+		}
+		totals, err := a.collect(ctx)
+		if err != nil {
+			return err
+		}
+		for src, total := range totals {
+			o.ObserveInt64(counter, total, metric.WithAttributes(
+				attribute.String(signalKey, src.signal),
+				attribute.String(reasonKey, src.reason),
+			))
+		}
+		return nil
+	}, counter)
+	return err
+}
+
+// collect returns the sum of the drop counters per signal and reason.
+func (a *Aggregator) collect(ctx context.Context) (map[source]int64, error) {
+	var rm metricdata.ResourceMetrics
+	if err := a.reader.Collect(context.WithValue(ctx, collectingKey{}, true), &rm); err != nil {
+		return nil, err
+	}
+	totals := map[source]int64{}
+	for _, sm := range rm.ScopeMetrics {
+		if !strings.HasPrefix(sm.Scope.Name, "go.opentelemetry.io/collector/") {
+			continue
+		}
+		for _, m := range sm.Metrics {
+			src, ok := sources[m.Name]
+			if !ok {
+				continue
+			}
+			sum, ok := m.Data.(metricdata.Sum[int64])
+			if !ok {
+				continue
+			}
+			for _, dp := range sum.DataPoints {
+				totals[src] += dp.Value
+			}
+		}
+	}
+	return totals, nil
+}
@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package droppeditems
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+func newTraces(spans int) ptrace.Traces {
+	td := ptrace.NewTraces()
+	ss := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	for i := 0; i < spans; i++ {
+		ss.AppendEmpty()
+	}
+	return td
+}
+
+func TestAggregator(t *testing.T) {
+	agg := NewAggregator()
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader), sdkmetric.WithReader(agg.Reader()))
+	t.Cleanup(func() { require.NoError(t, mp.Shutdown(context.Background())) })
+
+	set := componenttest.NewNopTelemetrySettings()
+	set.MeterProvider = mp
+	set.MetricsLevel = configtelemetry.LevelBasic
+	set.LeveledMeterProvider = func(configtelemetry.Level) metric.MeterProvider { return mp }
+	require.NoError(t, agg.RegisterMetric(set))
+
+	// A processor dropping spans and log records.
+	obsrep, err := processorhelper.NewObsReport(processorhelper.ObsReportSettings{
+		ProcessorID:             component.MustNewID("filter"),
+		ProcessorCreateSettings: processor.Settings{ID: component.MustNewID("filter"), TelemetrySettings: set, BuildInfo: component.NewDefaultBuildInfo()},
+	})
+	require.NoError(t, err)
+	obsrep.TracesDropped(context.Background(), 3)
+	obsrep.LogsDropped(context.Background(), 4)
+
+	// An exporter failing to send spans.
+	exp, err := exporterhelper.NewTracesExporter(context.Background(),
+		exporter.Settings{ID: component.MustNewID("otlp"), TelemetrySettings: set, BuildInfo: component.NewDefaultBuildInfo()},
+		struct{}{},
+		func(context.Context, ptrace.Traces) error { return errors.New("unavailable") },
+		exporterhelper.WithQueue(exporterhelper.QueueSettings{Enabled: false}))
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	assert.Error(t, exp.ConsumeTraces(context.Background(), newTraces(2)))
+	require.NoError(t, exp.Shutdown(context.Background()))
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	var dropped *metricdata.Metrics
+	for _, sm := range rm.ScopeMetrics {
+		for i := range sm.Metrics {
+			if sm.Metrics[i].Name == metricName {
+				dropped = &sm.Metrics[i]
+			}
+		}
+	}
+	require.NotNil(t, dropped)
+	metricdatatest.AssertAggregationsEqual(t, metricdata.Sum[int64]{
+		Temporality: metricdata.CumulativeTemporality,
+		IsMonotonic: true,
+		DataPoints: []metricdata.DataPoint[int64]{
+			{
+				Attributes: attribute.NewSet(attribute.String(signalKey, "traces"), attribute.String(reasonKey, reasonProcessor)),
+				Value:      3,
+			},
+			{
+				Attributes: attribute.NewSet(attribute.String(signalKey, "logs"), attribute.String(reasonKey, reasonProcessor)),
+				Value:      4,
+			},
+			{
+				Attributes: attribute.NewSet(attribute.String(signalKey, "traces"), attribute.String(reasonKey, reasonSendFailed)),
+				Value:      2,
+			},
+		},
+	}, dropped.Data, metricdatatest.IgnoreTimestamp())
+}
+
+func TestAggregatorReaders(t *testing.T) {
+	agg := NewAggregator()
+	readers := []*sdkmetric.ManualReader{sdkmetric.NewManualReader(), sdkmetric.NewManualReader()}
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(readers[0]), sdkmetric.WithReader(readers[1]), sdkmetric.WithReader(agg.Reader()))
+	t.Cleanup(func() { require.NoError(t, mp.Shutdown(context.Background())) })
+
+	set := componenttest.NewNopTelemetrySettings()
+	set.MeterProvider = mp
+	set.MetricsLevel = configtelemetry.LevelBasic
+	set.LeveledMeterProvider = func(configtelemetry.Level) metric.MeterProvider { return mp }
+	require.NoError(t, agg.RegisterMetric(set))
+	obsrep, err := processorhelper.NewObsReport(processorhelper.ObsReportSettings{
+		ProcessorID:             component.MustNewID("filter"),
+		ProcessorCreateSettings: processor.Settings{ID: component.MustNewID("filter"), TelemetrySettings: set, BuildInfo: component.NewDefaultBuildInfo()},
+	})
+	require.NoError(t, err)
+	obsrep.MetricsDropped(context.Background(), 5)
+
+	// Every reader collecting at the same time observes the metric. The values are not compared, as the SDK
+	// records the observations of a callback for all the readers.
+	var wg sync.WaitGroup
+	for _, reader := range readers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var rm metricdata.ResourceMetrics
+			assert.NoError(t, reader.Collect(context.Background(), &rm))
+			var observed bool
+			for _, sm := range rm.ScopeMetrics {
+				for _, m := range sm.Metrics {
+					observed = observed || m.Name == metricName
+				}
+			}
+			assert.True(t, observed)
+		}()
+	}
+	wg.Wait()
+}
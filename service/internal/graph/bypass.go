@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"context"
+	"sync/atomic"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumerprofiles"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// processorBypass short-circuits a processor disabled at runtime, passing the data sent to the processor
+// directly to its next consumer. The data already held by the processor, e.g. batched, is still sent by it.
+type processorBypass struct {
+	disabled atomic.Bool
+}
+
+func (pb *processorBypass) traces(proc, next consumer.Traces) consumer.Traces {
+	tr, _ := consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
+		if pb.disabled.Load() {
+			return next.ConsumeTraces(ctx, td)
+		}
+		return proc.ConsumeTraces(ctx, td)
+	}, consumer.WithCapabilities(proc.Capabilities()))
+	return tr
+}
+
+func (pb *processorBypass) metrics(proc, next consumer.Metrics) consumer.Metrics {
+	mr, _ := consumer.NewMetrics(func(ctx context.Context, md pmetric.Metrics) error {
+		if pb.disabled.Load() {
+			return next.ConsumeMetrics(ctx, md)
+		}
+		return proc.ConsumeMetrics(ctx, md)
+	}, consumer.WithCapabilities(proc.Capabilities()))
+	return mr
+}
+
+func (pb *processorBypass) logs(proc, next consumer.Logs) consumer.Logs {
+	lr, _ := consumer.NewLogs(func(ctx context.Context, ld plog.Logs) error {
+		if pb.disabled.Load() {
+			return next.ConsumeLogs(ctx, ld)
+		}
+		return proc.ConsumeLogs(ctx, ld)
+	}, consumer.WithCapabilities(proc.Capabilities()))
+	return lr
+}
+
+func (pb *processorBypass) profiles(proc, next consumerprofiles.Profiles) consumerprofiles.Profiles {
+	pr, _ := consumerprofiles.NewProfiles(func(ctx context.Context, pd pprofile.Profiles) error {
+		if pb.disabled.Load() {
+			return next.ConsumeProfiles(ctx, pd)
+		}
+		return proc.ConsumeProfiles(ctx, pd)
+	}, consumer.WithCapabilities(proc.Capabilities()))
+	return pr
+}
@@ -0,0 +1,213 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/multierr"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/service/internal/metadata"
+)
+
+// freshnessWindow is the duration of the windows the newest timestamps are tracked in. The age is the one of the
+// newest records sent during the current or the previous window, so it reflects the records sent recently, whatever
+// the number and the frequency of the collections of the metric.
+const freshnessWindow = time.Minute
+
+// freshnessTracker reports the age of the newest records sent by the receivers to the pipelines in the
+// pipeline_data_freshness metric, by data type.
+type freshnessTracker struct {
+	byType map[component.DataType]*dataFreshness
+}
+
+// dataFreshness tracks the newest timestamp of the records of a data type.
+type dataFreshness struct {
+	now func() time.Time
+
+	mu sync.Mutex
+	// windowStart is the start of the current window.
+	windowStart time.Time
+	// current and previous are the newest timestamps of the records sent during the current and the previous
+	// windows, zero if none.
+	current  pcommon.Timestamp
+	previous pcommon.Timestamp
+	// newest is the newest timestamp of the records of the last window in which records were sent, the creation
+	// time until records are sent, so that the age grows if no records are sent.
+	newest pcommon.Timestamp
+}
+
+// newFreshnessTracker returns a freshnessTracker, or nil if the freshness is not reported.
+func newFreshnessTracker(enabled bool, tel component.TelemetrySettings) (*freshnessTracker, error) {
+	if !enabled {
+		return nil, nil
+	}
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(tel)
+	if err != nil {
+		return nil, err
+	}
+	ft := &freshnessTracker{byType: make(map[component.DataType]*dataFreshness)}
+	for _, dataType := range []component.DataType{component.DataTypeTraces, component.DataTypeMetrics, component.DataTypeLogs} {
+		now := time.Now()
+		df := &dataFreshness{now: time.Now, windowStart: now, newest: pcommon.NewTimestampFromTime(now)}
+		ft.byType[dataType] = df
+		err = multierr.Append(err, telemetryBuilder.InitPipelineDataFreshness(df.age,
+			metric.WithAttributeSet(attribute.NewSet(attribute.String(obsmetrics.DataTypeKey, dataType.String())))))
+	}
+	return ft, err
+}
+
+// observe records the newest timestamp of the records sent, zero if none has a timestamp.
+func (df *dataFreshness) observe(ts pcommon.Timestamp) {
+	if ts == 0 {
+		return
+	}
+	df.mu.Lock()
+	defer df.mu.Unlock()
+	df.roll(df.now())
+	df.current = max(df.current, ts)
+}
+
+// age returns the age, in seconds, of the newest record sent during the current or the previous window, or of the
+// newest record sent before if none was sent since. It is negative if the record is timestamped in the future.
+func (df *dataFreshness) age() float64 {
+	df.mu.Lock()
+	defer df.mu.Unlock()
+	now := df.now()
+	df.roll(now)
+	newest := max(df.current, df.previous)
+	if newest == 0 {
+		newest = df.newest
+	}
+	return now.Sub(newest.AsTime()).Seconds()
+}
+
+// roll starts the window now is in, if the current one elapsed.
+func (df *dataFreshness) roll(now time.Time) {
+	elapsed := now.Sub(df.windowStart)
+	if elapsed < freshnessWindow {
+		return
+	}
+	if df.current != 0 {
+		df.newest = df.current
+	}
+	df.previous = df.current
+	if elapsed >= 2*freshnessWindow {
+		// No record was sent during the window before the new one.
+		df.previous = 0
+	}
+	df.current = 0
+	df.windowStart = df.windowStart.Add(elapsed - elapsed%freshnessWindow)
+}
+
+func (ft *freshnessTracker) traces(next consumer.Traces) consumer.Traces {
+	tr, _ := consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
+		ft.byType[component.DataTypeTraces].observe(newestSpanTimestamp(td))
+		return next.ConsumeTraces(ctx, td)
+	}, consumer.WithCapabilities(next.Capabilities()))
+	return tr
+}
+
+func (ft *freshnessTracker) metrics(next consumer.Metrics) consumer.Metrics {
+	mr, _ := consumer.NewMetrics(func(ctx context.Context, md pmetric.Metrics) error {
+		ft.byType[component.DataTypeMetrics].observe(newestDataPointTimestamp(md))
+		return next.ConsumeMetrics(ctx, md)
+	}, consumer.WithCapabilities(next.Capabilities()))
+	return mr
+}
+
+func (ft *freshnessTracker) logs(next consumer.Logs) consumer.Logs {
+	lr, _ := consumer.NewLogs(func(ctx context.Context, ld plog.Logs) error {
+		ft.byType[component.DataTypeLogs].observe(newestLogTimestamp(ld))
+		return next.ConsumeLogs(ctx, ld)
+	}, consumer.WithCapabilities(next.Capabilities()))
+	return lr
+}
+
+// newestSpanTimestamp returns the newest end, or start if it has no end, timestamp of the spans of td.
+func newestSpanTimestamp(td ptrace.Traces) pcommon.Timestamp {
+	var newest pcommon.Timestamp
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		sss := rss.At(i).ScopeSpans()
+		for j := 0; j < sss.Len(); j++ {
+			spans := sss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				newest = max(newest, span.StartTimestamp(), span.EndTimestamp())
+			}
+		}
+	}
+	return newest
+}
+
+// newestDataPointTimestamp returns the newest timestamp of the data points of md.
+func newestDataPointTimestamp(md pmetric.Metrics) pcommon.Timestamp {
+	var newest pcommon.Timestamp
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		sms := rms.At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			ms := sms.At(j).Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				m := ms.At(k)
+				switch m.Type() {
+				case pmetric.MetricTypeGauge:
+					for l := 0; l < m.Gauge().DataPoints().Len(); l++ {
+						newest = max(newest, m.Gauge().DataPoints().At(l).Timestamp())
+					}
+				case pmetric.MetricTypeSum:
+					for l := 0; l < m.Sum().DataPoints().Len(); l++ {
+						newest = max(newest, m.Sum().DataPoints().At(l).Timestamp())
+					}
+				case pmetric.MetricTypeHistogram:
+					for l := 0; l < m.Histogram().DataPoints().Len(); l++ {
+						newest = max(newest, m.Histogram().DataPoints().At(l).Timestamp())
+					}
+				case pmetric.MetricTypeExponentialHistogram:
+					for l := 0; l < m.ExponentialHistogram().DataPoints().Len(); l++ {
+						newest = max(newest, m.ExponentialHistogram().DataPoints().At(l).Timestamp())
+					}
+				case pmetric.MetricTypeSummary:
+					for l := 0; l < m.Summary().DataPoints().Len(); l++ {
+						newest = max(newest, m.Summary().DataPoints().At(l).Timestamp())
+					}
+				}
+			}
+		}
+	}
+	return newest
+}
+
+// newestLogTimestamp returns the newest timestamp, or observed timestamp if it has none, of the log records of ld.
+func newestLogTimestamp(ld plog.Logs) pcommon.Timestamp {
+	var newest pcommon.Timestamp
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		sls := rls.At(i).ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			lrs := sls.At(j).LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				lr := lrs.At(k)
+				ts := lr.Timestamp()
+				if ts == 0 {
+					ts = lr.ObservedTimestamp()
+				}
+				newest = max(newest, ts)
+			}
+		}
+	}
+	return newest
+}
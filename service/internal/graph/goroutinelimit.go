@@ -0,0 +1,120 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentprofiles"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumerprofiles"
+	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/service/internal/metadata"
+)
+
+// errGoroutineLimitExceeded is returned to the receivers while the collector runs too many goroutines. It is not
+// a permanent error, so the receivers report it as retryable to their clients, applying backpressure.
+var errGoroutineLimitExceeded = errors.New("the collector goroutine limit is exceeded, retry later")
+
+// goroutineLimiter rejects the data sent by the receivers once the number of goroutines reached max, until it
+// falls below resume.
+type goroutineLimiter struct {
+	max          int
+	resume       int
+	numGoroutine func() int
+	// limited is set while the data is rejected.
+	limited atomic.Bool
+
+	telemetryBuilder *metadata.TelemetryBuilder
+}
+
+// newGoroutineLimiter returns a goroutineLimiter, or nil if there is no limit.
+func newGoroutineLimiter(maxGoroutines, resumeGoroutines int, tel component.TelemetrySettings) (*goroutineLimiter, error) {
+	if maxGoroutines <= 0 {
+		return nil, nil
+	}
+	if resumeGoroutines <= 0 || resumeGoroutines >= maxGoroutines {
+		resumeGoroutines = maxGoroutines * 9 / 10
+	}
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(tel)
+	if err != nil {
+		return nil, err
+	}
+	return &goroutineLimiter{
+		max:              maxGoroutines,
+		resume:           resumeGoroutines,
+		numGoroutine:     runtime.NumGoroutine,
+		telemetryBuilder: telemetryBuilder,
+	}, nil
+}
+
+// reject returns errGoroutineLimitExceeded if the items must not be passed to the pipelines.
+func (gl *goroutineLimiter) reject(ctx context.Context, dataType component.DataType, items int) error {
+	n := gl.numGoroutine()
+	if gl.limited.Load() {
+		if n < gl.resume {
+			gl.limited.Store(false)
+			return nil
+		}
+	} else {
+		if n < gl.max {
+			return nil
+		}
+		gl.limited.Store(true)
+	}
+	gl.telemetryBuilder.PipelineGoroutineLimitedItems.Add(ctx, int64(items),
+		metric.WithAttributes(attribute.String(obsmetrics.DataTypeKey, dataType.String())))
+	return errGoroutineLimitExceeded
+}
+
+func (gl *goroutineLimiter) traces(next consumer.Traces) consumer.Traces {
+	tr, _ := consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
+		if err := gl.reject(ctx, component.DataTypeTraces, td.SpanCount()); err != nil {
+			return err
+		}
+		return next.ConsumeTraces(ctx, td)
+	}, consumer.WithCapabilities(next.Capabilities()))
+	return tr
+}
+
+func (gl *goroutineLimiter) metrics(next consumer.Metrics) consumer.Metrics {
+	mr, _ := consumer.NewMetrics(func(ctx context.Context, md pmetric.Metrics) error {
+		if err := gl.reject(ctx, component.DataTypeMetrics, md.DataPointCount()); err != nil {
+			return err
+		}
+		return next.ConsumeMetrics(ctx, md)
+	}, consumer.WithCapabilities(next.Capabilities()))
+	return mr
+}
+
+func (gl *goroutineLimiter) logs(next consumer.Logs) consumer.Logs {
+	lr, _ := consumer.NewLogs(func(ctx context.Context, ld plog.Logs) error {
+		if err := gl.reject(ctx, component.DataTypeLogs, ld.LogRecordCount()); err != nil {
+			return err
+		}
+		return next.ConsumeLogs(ctx, ld)
+	}, consumer.WithCapabilities(next.Capabilities()))
+	return lr
+}
+
+func (gl *goroutineLimiter) profiles(next consumerprofiles.Profiles) consumerprofiles.Profiles {
+	pr, _ := consumerprofiles.NewProfiles(func(ctx context.Context, pd pprofile.Profiles) error {
+		if err := gl.reject(ctx, componentprofiles.DataTypeProfiles, pd.SampleCount()); err != nil {
+			return err
+		}
+		return next.ConsumeProfiles(ctx, pd)
+	}, consumer.WithCapabilities(next.Capabilities()))
+	return pr
+}
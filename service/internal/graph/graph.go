@@ -16,8 +16,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"sort"
 	"strings"
+	"time"
 
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
 	"gonum.org/v1/gonum/graph"
@@ -27,10 +32,16 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componentprofiles"
 	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/connector"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumerdeadletter"
 	"go.opentelemetry.io/collector/consumer/consumerprofiles"
 	"go.opentelemetry.io/collector/internal/fanoutconsumer"
+	"go.opentelemetry.io/collector/internal/otlppassthrough"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/service/internal/builders"
 	"go.opentelemetry.io/collector/service/internal/capabilityconsumer"
 	"go.opentelemetry.io/collector/service/internal/status"
@@ -51,6 +62,26 @@ type Settings struct {
 	PipelineConfigs pipelines.Config
 
 	ReportStatus status.ServiceStatusFunc
+
+	// MetricsLevels overrides the metrics level of Telemetry for the listed components.
+	MetricsLevels map[component.ID]configtelemetry.Level
+
+	// Warmup is the period after the start of the pipelines during which the data sent by the receivers
+	// is rejected with a retryable error. Zero disables the warmup.
+	Warmup time.Duration
+
+	// IngestLimit caps the rate of the items sent by all the receivers to the pipelines.
+	IngestLimit IngestLimit
+
+	// DataFreshness reports the age of the newest records sent by the receivers to the pipelines.
+	DataFreshness bool
+
+	// MaxGoroutines is the number of goroutines from which the data sent by the receivers is rejected with a
+	// retryable error. Zero disables the limit.
+	MaxGoroutines int
+	// ResumeGoroutines is the number of goroutines below which the data is accepted again once MaxGoroutines was
+	// reached, 90% of MaxGoroutines if zero.
+	ResumeGoroutines int
 }
 
 type Graph struct {
@@ -64,6 +95,22 @@ type Graph struct {
 	instanceIDs map[int64]*componentstatus.InstanceID
 
 	telemetry component.TelemetrySettings
+
+	// warmup rejects the data sent by the receivers until the warmup period elapses, nil if disabled.
+	warmup *warmupGate
+
+	// ingestLimiter throttles the data sent by the receivers above the ingest limit, nil if disabled.
+	ingestLimiter *ingestLimiter
+
+	// freshness reports the age of the newest records sent by the receivers, nil if disabled.
+	freshness *freshnessTracker
+
+	// goroutineLimiter rejects the data sent by the receivers while there are too many goroutines, nil if disabled.
+	goroutineLimiter *goroutineLimiter
+
+	// deadLetters is the dead-letter exporter of the processor and exporter nodes of the pipelines configuring one,
+	// by node ID.
+	deadLetters map[int64]*exporterNode
 }
 
 // Build builds a full pipeline graph.
@@ -74,6 +121,8 @@ func Build(ctx context.Context, set Settings) (*Graph, error) {
 		pipelines:      make(map[component.ID]*pipelineNodes, len(set.PipelineConfigs)),
 		instanceIDs:    make(map[int64]*componentstatus.InstanceID),
 		telemetry:      set.Telemetry,
+		warmup:         newWarmupGate(set.Warmup),
+		deadLetters:    make(map[int64]*exporterNode),
 	}
 	for pipelineID := range set.PipelineConfigs {
 		pipelines.pipelines[pipelineID] = &pipelineNodes{
@@ -81,7 +130,17 @@ func Build(ctx context.Context, set Settings) (*Graph, error) {
 			exporters: make(map[int64]graph.Node),
 		}
 	}
-	if err := pipelines.createNodes(set); err != nil {
+	var err error
+	if pipelines.ingestLimiter, err = newIngestLimiter(set.IngestLimit, set.Telemetry); err != nil {
+		return nil, err
+	}
+	if pipelines.freshness, err = newFreshnessTracker(set.DataFreshness, set.Telemetry); err != nil {
+		return nil, err
+	}
+	if pipelines.goroutineLimiter, err = newGoroutineLimiter(set.MaxGoroutines, set.ResumeGoroutines, set.Telemetry); err != nil {
+		return nil, err
+	}
+	if err = pipelines.createNodes(set); err != nil {
 		return nil, err
 	}
 	pipelines.createEdges()
@@ -98,6 +157,9 @@ func (g *Graph) createNodes(set Settings) error {
 	connectorsAsExporter := make(map[component.ID][]component.ID)
 	connectorsAsReceiver := make(map[component.ID][]component.ID)
 
+	// Keep track of the dead-letter exporter of each exporter, nil if none, as they are shared by the pipelines.
+	exporterDeadLetters := make(map[int64]*exporterNode)
+
 	// Build each pipelineNodes struct for each pipeline by parsing the pipelineCfg.
 	// Also populates the connectors, connectorsAsExporter and connectorsAsReceiver maps.
 	for pipelineID, pipelineCfg := range set.PipelineConfigs {
@@ -122,6 +184,19 @@ func (g *Graph) createNodes(set Settings) error {
 
 		pipe.fanOutNode = newFanOutNode(pipelineID)
 
+		// The dead-letter exporter only receives the data dropped by the processors and exporters of the pipeline.
+		var deadLetter *exporterNode
+		if pipelineCfg.DeadLetter != nil {
+			if set.ConnectorBuilder.IsConfigured(*pipelineCfg.DeadLetter) {
+				return fmt.Errorf("pipeline %q: dead-letter exporter %q cannot be a connector", pipelineID, *pipelineCfg.DeadLetter)
+			}
+			deadLetter = g.createExporter(pipelineID, *pipelineCfg.DeadLetter)
+			pipe.deadLetter = deadLetter
+			for _, procNode := range pipe.processors {
+				g.deadLetters[procNode.ID()] = deadLetter
+			}
+		}
+
 		for _, exprID := range pipelineCfg.Exporters {
 			if set.ConnectorBuilder.IsConfigured(exprID) {
 				connectors[exprID] = struct{}{}
@@ -130,9 +205,20 @@ func (g *Graph) createNodes(set Settings) error {
 			}
 			expNode := g.createExporter(pipelineID, exprID)
 			pipe.exporters[expNode.ID()] = expNode
+			if dl, ok := exporterDeadLetters[expNode.ID()]; ok && dl != deadLetter {
+				return fmt.Errorf("exporter %q is used by %s pipelines with different dead-letter exporters", exprID, pipelineID.Type())
+			}
+			exporterDeadLetters[expNode.ID()] = deadLetter
+			if deadLetter != nil {
+				g.deadLetters[expNode.ID()] = deadLetter
+			}
 		}
 	}
 
+	if err := deadLetterCycleErr(exporterDeadLetters); err != nil {
+		return err
+	}
+
 	for connID := range connectors {
 		factory := set.ConnectorBuilder.Factory(connID.Type())
 		if factory == nil {
@@ -270,6 +356,31 @@ func (g *Graph) createEdges() {
 			g.componentGraph.SetEdge(g.componentGraph.NewEdge(pg.fanOutNode, exporter))
 		}
 	}
+
+	// Draw edges from the components to their dead-letter exporter, so it is built and started before them, and
+	// shut down after them.
+	for nodeID, deadLetter := range g.deadLetters {
+		g.componentGraph.SetEdge(g.componentGraph.NewEdge(g.componentGraph.Node(nodeID), deadLetter))
+	}
+}
+
+// componentTelemetry returns the telemetry settings of the component with the given ID, recording
+// the metrics of the level set for the component in set.MetricsLevels, if any.
+func componentTelemetry(set Settings, id component.ID) component.TelemetrySettings {
+	tel := set.Telemetry
+	level, ok := set.MetricsLevels[id]
+	if !ok {
+		return tel
+	}
+	mp := tel.MeterProvider
+	tel.MetricsLevel = level
+	tel.LeveledMeterProvider = func(l configtelemetry.Level) metric.MeterProvider {
+		if l <= level {
+			return mp
+		}
+		return noop.MeterProvider{}
+	}
+	return tel
 }
 
 // Uses the already built graph g to instantiate the actual components for each component of each pipeline.
@@ -286,14 +397,14 @@ func (g *Graph) buildComponents(ctx context.Context, set Settings) error {
 
 		switch n := node.(type) {
 		case *receiverNode:
-			err = n.buildComponent(ctx, set.Telemetry, set.BuildInfo, set.ReceiverBuilder, g.nextConsumers(n.ID()))
+			err = n.buildComponent(ctx, componentTelemetry(set, n.componentID), set.BuildInfo, set.ReceiverBuilder, g.nextConsumers(n.ID()), g.warmup, g.ingestLimiter, g.freshness, g.goroutineLimiter)
 		case *processorNode:
 			// nextConsumers is guaranteed to be length 1.  Either it is the next processor or it is the fanout node for the exporters.
-			err = n.buildComponent(ctx, set.Telemetry, set.BuildInfo, set.ProcessorBuilder, g.nextConsumers(n.ID())[0])
+			err = n.buildComponent(ctx, componentTelemetry(set, n.componentID), set.BuildInfo, set.ProcessorBuilder, g.nextConsumers(n.ID())[0])
 		case *exporterNode:
-			err = n.buildComponent(ctx, set.Telemetry, set.BuildInfo, set.ExporterBuilder)
+			err = n.buildComponent(ctx, componentTelemetry(set, n.componentID), set.BuildInfo, set.ExporterBuilder)
 		case *connectorNode:
-			err = n.buildComponent(ctx, set.Telemetry, set.BuildInfo, set.ConnectorBuilder, g.nextConsumers(n.ID()))
+			err = n.buildComponent(ctx, componentTelemetry(set, n.componentID), set.BuildInfo, set.ConnectorBuilder, g.nextConsumers(n.ID()))
 		case *capabilitiesNode:
 			capability := consumer.Capabilities{
 				// The fanOutNode represents the aggregate capabilities of the exporters in the pipeline.
@@ -303,51 +414,70 @@ func (g *Graph) buildComponents(ctx context.Context, set Settings) error {
 				capability.MutatesData = capability.MutatesData || proc.getConsumer().Capabilities().MutatesData
 			}
 			next := g.nextConsumers(n.ID())[0]
+			// The pipelines which may mutate the data remove the received OTLP requests from the context, so the
+			// exporters do not forward them as is.
 			switch n.pipelineID.Type() {
 			case component.DataTypeTraces:
 				cc := capabilityconsumer.NewTraces(next.(consumer.Traces), capability)
 				n.baseConsumer = cc
 				n.ConsumeTracesFunc = cc.ConsumeTraces
+				if capability.MutatesData {
+					n.ConsumeTracesFunc = func(ctx context.Context, td ptrace.Traces) error {
+						return cc.ConsumeTraces(otlppassthrough.Without(ctx), td)
+					}
+				}
 			case component.DataTypeMetrics:
 				cc := capabilityconsumer.NewMetrics(next.(consumer.Metrics), capability)
 				n.baseConsumer = cc
 				n.ConsumeMetricsFunc = cc.ConsumeMetrics
+				if capability.MutatesData {
+					n.ConsumeMetricsFunc = func(ctx context.Context, md pmetric.Metrics) error {
+						return cc.ConsumeMetrics(otlppassthrough.Without(ctx), md)
+					}
+				}
 			case component.DataTypeLogs:
 				cc := capabilityconsumer.NewLogs(next.(consumer.Logs), capability)
 				n.baseConsumer = cc
 				n.ConsumeLogsFunc = cc.ConsumeLogs
+				if capability.MutatesData {
+					n.ConsumeLogsFunc = func(ctx context.Context, ld plog.Logs) error {
+						return cc.ConsumeLogs(otlppassthrough.Without(ctx), ld)
+					}
+				}
 			case componentprofiles.DataTypeProfiles:
 				cc := capabilityconsumer.NewProfiles(next.(consumerprofiles.Profiles), capability)
 				n.baseConsumer = cc
 				n.ConsumeProfilesFunc = cc.ConsumeProfiles
 			}
 		case *fanOutNode:
-			nexts := g.nextConsumers(n.ID())
+			pipelineCfg := set.PipelineConfigs[n.pipelineID]
+			nexts := g.exporterConsumers(n.ID(), pipelineCfg.Exporters)
+			fanoutCfg := newFanoutConfig(pipelineCfg.Fanout)
 			switch n.pipelineID.Type() {
 			case component.DataTypeTraces:
 				consumers := make([]consumer.Traces, 0, len(nexts))
 				for _, next := range nexts {
 					consumers = append(consumers, next.(consumer.Traces))
 				}
-				n.baseConsumer = fanoutconsumer.NewTraces(consumers)
+				n.baseConsumer = fanoutconsumer.NewTracesWithConfig(consumers, fanoutCfg)
 			case component.DataTypeMetrics:
 				consumers := make([]consumer.Metrics, 0, len(nexts))
 				for _, next := range nexts {
 					consumers = append(consumers, next.(consumer.Metrics))
 				}
-				n.baseConsumer = fanoutconsumer.NewMetrics(consumers)
+				n.baseConsumer = fanoutconsumer.NewMetricsWithConfig(consumers, fanoutCfg)
 			case component.DataTypeLogs:
 				consumers := make([]consumer.Logs, 0, len(nexts))
 				for _, next := range nexts {
 					consumers = append(consumers, next.(consumer.Logs))
 				}
-				n.baseConsumer = fanoutconsumer.NewLogs(consumers)
+				n.baseConsumer = fanoutconsumer.NewLogsWithConfig(consumers, fanoutCfg)
 			case componentprofiles.DataTypeProfiles:
 				consumers := make([]consumerprofiles.Profiles, 0, len(nexts))
 				for _, next := range nexts {
 					consumers = append(consumers, next.(consumerprofiles.Profiles))
 				}
-				n.baseConsumer = fanoutconsumer.NewProfiles(consumers)
+				n.baseConsumer = fanoutconsumer.NewProfilesWithConfig(consumers, fanoutCfg)
 			}
 		}
 		if err != nil {
@@ -357,16 +487,74 @@ func (g *Graph) buildComponents(ctx context.Context, set Settings) error {
 	return nil
 }
 
+// exporterConsumers returns the consumers of the exporters the fan-out node nodeID emits to, in the order of
+// exporters, the exporters of its pipeline.
+func (g *Graph) exporterConsumers(nodeID int64, exporters []component.ID) []baseConsumer {
+	var nodes []graph.Node
+	for nextNodes := g.componentGraph.From(nodeID); nextNodes.Next(); {
+		nodes = append(nodes, nextNodes.Node())
+	}
+	index := func(node graph.Node) int {
+		switch n := node.(type) {
+		case *exporterNode:
+			return slices.Index(exporters, n.componentID)
+		case *connectorNode:
+			return slices.Index(exporters, n.componentID)
+		}
+		return -1
+	}
+	sort.SliceStable(nodes, func(i, j int) bool { return index(nodes[i]) < index(nodes[j]) })
+	nexts := make([]baseConsumer, 0, len(nodes))
+	for _, node := range nodes {
+		nexts = append(nexts, node.(consumerNode).getConsumer())
+	}
+	return nexts
+}
+
+// newFanoutConfig returns the fan-out consumer configuration of the fan-out configuration of a pipeline.
+func newFanoutConfig(cfg pipelines.FanoutConfig) fanoutconsumer.Config {
+	fanoutCfg := fanoutconsumer.Config{StopOnPermanentError: cfg.StopOnPermanentError}
+	switch cfg.Delivery {
+	case pipelines.FanoutDeliverySequential:
+		fanoutCfg.Delivery = fanoutconsumer.DeliverySequential
+	case pipelines.FanoutDeliveryConcurrent:
+		fanoutCfg.Delivery = fanoutconsumer.DeliveryConcurrent
+	}
+	return fanoutCfg
+}
+
 // Find all nodes
 func (g *Graph) nextConsumers(nodeID int64) []baseConsumer {
 	nextNodes := g.componentGraph.From(nodeID)
 	nexts := make([]baseConsumer, 0, nextNodes.Len())
+	deadLetter, hasDeadLetter := g.deadLetters[nodeID]
 	for nextNodes.Next() {
+		// The dead-letter exporter is not sent the data of the pipeline.
+		if hasDeadLetter && nextNodes.Node().ID() == deadLetter.ID() {
+			continue
+		}
 		nexts = append(nexts, nextNodes.Node().(consumerNode).getConsumer())
 	}
 	return nexts
 }
 
+// deadLetterConsumers returns the consumers of the dead-letter exporter of the node, or nil if it has none.
+func (g *Graph) deadLetterConsumers(nodeID int64, dataType component.DataType) *consumerdeadletter.Consumers {
+	deadLetter, ok := g.deadLetters[nodeID]
+	if !ok {
+		return nil
+	}
+	switch dataType {
+	case component.DataTypeTraces:
+		return &consumerdeadletter.Consumers{Traces: deadLetter.getConsumer().(consumer.Traces)}
+	case component.DataTypeMetrics:
+		return &consumerdeadletter.Consumers{Metrics: deadLetter.getConsumer().(consumer.Metrics)}
+	case component.DataTypeLogs:
+		return &consumerdeadletter.Consumers{Logs: deadLetter.getConsumer().(consumer.Logs)}
+	}
+	return nil
+}
+
 // A node-based representation of a pipeline configuration.
 type pipelineNodes struct {
 	// Use map to assist with deduplication of connector instances.
@@ -384,6 +572,9 @@ type pipelineNodes struct {
 
 	// Use map to assist with deduplication of connector instances.
 	exporters map[int64]graph.Node
+
+	// Receives the data dropped by the processors and exporters, nil if not configured.
+	deadLetter *exporterNode
 }
 
 func (g *Graph) StartAll(ctx context.Context, host *Host) error {
@@ -414,7 +605,14 @@ func (g *Graph) StartAll(ctx context.Context, host *Host) error {
 			componentstatus.NewEvent(componentstatus.StatusStarting),
 		)
 
-		if compErr := comp.Start(ctx, &HostWrapper{Host: host, InstanceID: instanceID}); compErr != nil {
+		hostWrapper := &HostWrapper{Host: host, InstanceID: instanceID}
+		switch n := node.(type) {
+		case *processorNode:
+			hostWrapper.deadLetter = g.deadLetterConsumers(n.ID(), n.pipelineID.Type())
+		case *exporterNode:
+			hostWrapper.deadLetter = g.deadLetterConsumers(n.ID(), n.pipelineType)
+		}
+		if compErr := comp.Start(ctx, hostWrapper); compErr != nil {
 			host.Reporter.ReportStatus(
 				instanceID,
 				componentstatus.NewPermanentErrorEvent(compErr),
@@ -431,10 +629,21 @@ func (g *Graph) StartAll(ctx context.Context, host *Host) error {
 
 		host.Reporter.ReportOKIfStarting(instanceID)
 	}
+
+	if g.warmup != nil {
+		g.telemetry.Logger.Info("Receivers reject data until the warmup period elapses.", zap.Duration("warmup", g.warmup.duration))
+		g.warmup.start(func() {
+			g.telemetry.Logger.Info("Warmup period elapsed. Receivers accept data.")
+		})
+	}
 	return nil
 }
 
 func (g *Graph) ShutdownAll(ctx context.Context, reporter status.Reporter) error {
+	if g.warmup != nil {
+		g.warmup.stop()
+	}
+
 	nodes, err := topo.Sort(g.componentGraph)
 	if err != nil {
 		return err
@@ -477,6 +686,22 @@ func (g *Graph) ShutdownAll(ctx context.Context, reporter status.Reporter) error
 	return errs
 }
 
+// SetProcessorEnabled enables or disables the processor of the pipeline at runtime. The data sent to a disabled
+// processor is passed directly to its next consumer, the processor being short-circuited until enabled again.
+func (g *Graph) SetProcessorEnabled(pipelineID, procID component.ID, enabled bool) error {
+	pg, ok := g.pipelines[pipelineID]
+	if !ok {
+		return fmt.Errorf("pipeline %q not found", pipelineID)
+	}
+	for _, proc := range pg.processors {
+		if proc.componentID == procID {
+			proc.bypass.disabled.Store(!enabled)
+			return nil
+		}
+	}
+	return fmt.Errorf("processor %q not found in pipeline %q", procID, pipelineID)
+}
+
 // Deprecated: [0.79.0] This function will be removed in the future.
 // Several components in the contrib repository use this function so it cannot be removed
 // before those cases are removed. In most cases, use of this function can be replaced by a
@@ -497,6 +722,9 @@ func (g *Graph) GetExporters() map[component.DataType]map[component.ID]component
 				exportersMap[expNode.pipelineType][expNode.componentID] = expNode.Component
 			}
 		}
+		if pg.deadLetter != nil {
+			exportersMap[pg.deadLetter.pipelineType][pg.deadLetter.componentID] = pg.deadLetter.Component
+		}
 	}
 	return exportersMap
 }
@@ -514,8 +742,9 @@ func cycleErr(err error, cycles [][]graph.Node) error {
 	// Remove it because we may start from a different node.
 	cycle = cycle[:len(cycle)-1]
 
-	// A cycle always contains a connector. For the sake of consistent
-	// error messages report the cycle starting from a connector.
+	// A cycle contains a connector, unless it is only made of exporters sending
+	// their dropped data to each other, which createNodes rejects. For the sake
+	// of consistent error messages report the cycle starting from a connector.
 	for i := 0; i < len(cycle); i++ {
 		if _, ok := cycle[i].(*connectorNode); ok {
 			cycle = append(cycle[i:], cycle[:i]...)
@@ -534,6 +763,8 @@ func cycleErr(err error, cycles [][]graph.Node) error {
 			componentDetails = append(componentDetails, fmt.Sprintf("processor %q in pipeline %q", n.componentID, n.pipelineID))
 		case *connectorNode:
 			componentDetails = append(componentDetails, fmt.Sprintf("connector %q (%s to %s)", n.componentID, n.exprPipelineType, n.rcvrPipelineType))
+		case *exporterNode:
+			componentDetails = append(componentDetails, fmt.Sprintf("exporter %q (%s)", n.componentID, n.pipelineType))
 		default:
 			continue // skip capabilities/fanout nodes
 		}
@@ -541,6 +772,54 @@ func cycleErr(err error, cycles [][]graph.Node) error {
 	return fmt.Errorf("cycle detected: %s", strings.Join(componentDetails, " -> "))
 }
 
+// deadLetterCycleErr returns an error naming the exporters of a cycle in which each exporter sends the data it drops
+// to the next one, or nil if there is none. deadLetters is the dead-letter exporter of each exporter, nil if it has
+// none, so the exporters of a cycle are all dead-letter exporters.
+func deadLetterCycleErr(deadLetters map[int64]*exporterNode) error {
+	starts := make([]*exporterNode, 0, len(deadLetters))
+	for _, deadLetter := range deadLetters {
+		if deadLetter != nil {
+			starts = append(starts, deadLetter)
+		}
+	}
+	// Sort the exporters so the same cycle is reported for a given configuration.
+	sort.Slice(starts, func(i, j int) bool { return exporterNodeLess(starts[i], starts[j]) })
+
+	for _, start := range starts {
+		var chain []*exporterNode
+		for node := start; node != nil; node = deadLetters[node.ID()] {
+			i := slices.Index(chain, node)
+			if i < 0 {
+				chain = append(chain, node)
+				continue
+			}
+			cycle := chain[i:]
+			// Report the cycle starting from its first exporter, then repeat it at the end to clarify the cycle.
+			first := 0
+			for j := range cycle {
+				if exporterNodeLess(cycle[j], cycle[first]) {
+					first = j
+				}
+			}
+			cycle = append(cycle[first:], cycle[:first]...)
+			cycle = append(cycle, cycle[0])
+			componentDetails := make([]string, 0, len(cycle))
+			for _, n := range cycle {
+				componentDetails = append(componentDetails, fmt.Sprintf("exporter %q (%s)", n.componentID, n.pipelineType))
+			}
+			return fmt.Errorf("dead-letter cycle detected: %s", strings.Join(componentDetails, " -> "))
+		}
+	}
+	return nil
+}
+
+func exporterNodeLess(a, b *exporterNode) bool {
+	if a.pipelineType != b.pipelineType {
+		return a.pipelineType.String() < b.pipelineType.String()
+	}
+	return a.componentID.String() < b.componentID.String()
+}
+
 func connectorStability(f connector.Factory, expType, recType component.Type) component.StabilityLevel {
 	switch expType {
 	case component.DataTypeTraces:
@@ -594,10 +873,19 @@ func connectorStability(f connector.Factory, expType, recType component.Type) co
 var _ getExporters = (*HostWrapper)(nil)
 var _ component.Host = (*HostWrapper)(nil)
 var _ componentstatus.Reporter = (*HostWrapper)(nil)
+var _ consumerdeadletter.Provider = (*HostWrapper)(nil)
 
 type HostWrapper struct {
 	*Host
 	InstanceID *componentstatus.InstanceID
+
+	// deadLetter is the dead-letter consumers of the processor or exporter, nil if it has none.
+	deadLetter *consumerdeadletter.Consumers
+}
+
+// DeadLetterConsumers implements consumerdeadletter.Provider.
+func (host *HostWrapper) DeadLetterConsumers() *consumerdeadletter.Consumers {
+	return host.deadLetter
 }
 
 func (host *HostWrapper) Report(event *componentstatus.Event) {
@@ -7,34 +7,53 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/metric"
+	noopmetric "go.opentelemetry.io/otel/metric/noop"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"gonum.org/v1/gonum/graph/simple"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componentprofiles"
 	"go.opentelemetry.io/collector/component/componentstatus"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/connector"
 	"go.opentelemetry.io/collector/connector/connectorprofiles"
 	"go.opentelemetry.io/collector/connector/connectortest"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumerdeadletter"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumerprofiles"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exporterprofiles"
 	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/internal/otlppassthrough"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/pdata/testdata"
 	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processorhelper"
 	"go.opentelemetry.io/collector/processor/processorprofiles"
 	"go.opentelemetry.io/collector/processor/processortest"
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/receiver/receiverprofiles"
 	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.opentelemetry.io/collector/service/hostcapabilities"
 	"go.opentelemetry.io/collector/service/internal/builders"
 	"go.opentelemetry.io/collector/service/internal/status"
 	"go.opentelemetry.io/collector/service/internal/status/statustest"
@@ -228,6 +247,973 @@ func TestGraphStartStopComponentError(t *testing.T) {
 	assert.EqualError(t, pg.ShutdownAll(context.Background(), statustest.NewNopStatusReporter()), "bar")
 }
 
+func TestGraphWarmup(t *testing.T) {
+	rcvrID := component.MustNewID("examplereceiver")
+	expID := component.MustNewID("exampleexporter")
+	set := Settings{
+		Telemetry: componenttest.NewNopTelemetrySettings(),
+		BuildInfo: component.NewDefaultBuildInfo(),
+		ReceiverBuilder: builders.NewReceiver(
+			map[component.ID]component.Config{
+				rcvrID: testcomponents.ExampleReceiverFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]receiver.Factory{
+				testcomponents.ExampleReceiverFactory.Type(): testcomponents.ExampleReceiverFactory,
+			},
+		),
+		ProcessorBuilder: builders.NewProcessor(map[component.ID]component.Config{}, map[component.Type]processor.Factory{}),
+		ExporterBuilder: builders.NewExporter(
+			map[component.ID]component.Config{
+				expID: testcomponents.ExampleExporterFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]exporter.Factory{
+				testcomponents.ExampleExporterFactory.Type(): testcomponents.ExampleExporterFactory,
+			},
+		),
+		ConnectorBuilder: builders.NewConnector(map[component.ID]component.Config{}, map[component.Type]connector.Factory{}),
+		PipelineConfigs: pipelines.Config{
+			component.MustNewID("traces"): {
+				Receivers: []component.ID{rcvrID},
+				Exporters: []component.ID{expID},
+			},
+		},
+		Warmup: 100 * time.Millisecond,
+	}
+
+	pg, err := Build(context.Background(), set)
+	require.NoError(t, err)
+	tracesReceiver := pg.getReceivers()[component.DataTypeTraces][rcvrID].(*testcomponents.ExampleReceiver)
+	tracesExporter := pg.GetExporters()[component.DataTypeTraces][expID].(*testcomponents.ExampleExporter)
+
+	// Data is rejected with a retryable error before the start and during the warmup.
+	err = tracesReceiver.ConsumeTraces(context.Background(), testdata.GenerateTraces(1))
+	require.ErrorIs(t, err, errWarmingUp)
+	assert.False(t, consumererror.IsPermanent(err))
+
+	require.NoError(t, pg.StartAll(context.Background(), &Host{Reporter: status.NewReporter(func(*componentstatus.InstanceID, *componentstatus.Event) {}, func(error) {})}))
+	require.ErrorIs(t, tracesReceiver.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)), errWarmingUp)
+	assert.Empty(t, tracesExporter.Traces)
+
+	// Data is accepted once the warmup period elapsed.
+	assert.Eventually(t, func() bool {
+		return tracesReceiver.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)) == nil
+	}, time.Second, 10*time.Millisecond)
+	assert.Len(t, tracesExporter.Traces, 1)
+
+	require.NoError(t, pg.ShutdownAll(context.Background(), statustest.NewNopStatusReporter()))
+}
+
+func TestGraphIngestLimit(t *testing.T) {
+	for _, drop := range []bool{false, true} {
+		t.Run(fmt.Sprintf("drop=%v", drop), func(t *testing.T) {
+			rcvrID := component.MustNewID("examplereceiver")
+			expID := component.MustNewID("exampleexporter")
+			reader := sdkmetric.NewManualReader()
+			mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+			tel := componenttest.NewNopTelemetrySettings()
+			tel.MeterProvider = mp
+			tel.LeveledMeterProvider = func(configtelemetry.Level) metric.MeterProvider { return mp }
+			set := Settings{
+				Telemetry: tel,
+				BuildInfo: component.NewDefaultBuildInfo(),
+				ReceiverBuilder: builders.NewReceiver(
+					map[component.ID]component.Config{
+						rcvrID: testcomponents.ExampleReceiverFactory.CreateDefaultConfig(),
+					},
+					map[component.Type]receiver.Factory{
+						testcomponents.ExampleReceiverFactory.Type(): testcomponents.ExampleReceiverFactory,
+					},
+				),
+				ProcessorBuilder: builders.NewProcessor(map[component.ID]component.Config{}, map[component.Type]processor.Factory{}),
+				ExporterBuilder: builders.NewExporter(
+					map[component.ID]component.Config{
+						expID: testcomponents.ExampleExporterFactory.CreateDefaultConfig(),
+					},
+					map[component.Type]exporter.Factory{
+						testcomponents.ExampleExporterFactory.Type(): testcomponents.ExampleExporterFactory,
+					},
+				),
+				ConnectorBuilder: builders.NewConnector(map[component.ID]component.Config{}, map[component.Type]connector.Factory{}),
+				PipelineConfigs: pipelines.Config{
+					component.MustNewID("traces"): {
+						Receivers: []component.ID{rcvrID},
+						Exporters: []component.ID{expID},
+					},
+					component.MustNewID("logs"): {
+						Receivers: []component.ID{rcvrID},
+						Exporters: []component.ID{expID},
+					},
+				},
+				// The bucket does not fill up again during the test.
+				IngestLimit: IngestLimit{ItemsPerSecond: 0.001, Burst: 10, Drop: drop},
+			}
+
+			pg, err := Build(context.Background(), set)
+			require.NoError(t, err)
+			require.NoError(t, pg.StartAll(context.Background(), &Host{Reporter: status.NewReporter(func(*componentstatus.InstanceID, *componentstatus.Event) {}, func(error) {})}))
+			tracesReceiver := pg.getReceivers()[component.DataTypeTraces][rcvrID].(*testcomponents.ExampleReceiver)
+			logsReceiver := pg.getReceivers()[component.DataTypeLogs][rcvrID].(*testcomponents.ExampleReceiver)
+			tracesExporter := pg.GetExporters()[component.DataTypeTraces][expID].(*testcomponents.ExampleExporter)
+			logsExporter := pg.GetExporters()[component.DataTypeLogs][expID].(*testcomponents.ExampleExporter)
+
+			// The limit is shared by the pipelines.
+			require.NoError(t, tracesReceiver.ConsumeTraces(context.Background(), testdata.GenerateTraces(6)))
+			require.NoError(t, logsReceiver.ConsumeLogs(context.Background(), testdata.GenerateLogs(3)))
+			err = tracesReceiver.ConsumeTraces(context.Background(), testdata.GenerateTraces(2))
+			if drop {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, errIngestLimitExceeded)
+				assert.False(t, consumererror.IsPermanent(err))
+			}
+			require.NoError(t, logsReceiver.ConsumeLogs(context.Background(), testdata.GenerateLogs(1)))
+			assert.Len(t, tracesExporter.Traces, 1)
+			assert.Len(t, logsExporter.Logs, 2)
+			require.NoError(t, pg.ShutdownAll(context.Background(), statustest.NewNopStatusReporter()))
+
+			var rm metricdata.ResourceMetrics
+			require.NoError(t, reader.Collect(context.Background(), &rm))
+			require.Len(t, rm.ScopeMetrics, 1)
+			require.Len(t, rm.ScopeMetrics[0].Metrics, 1)
+			throttled := rm.ScopeMetrics[0].Metrics[0]
+			assert.Equal(t, "otelcol_pipeline_throttled_items", throttled.Name)
+			sum, ok := throttled.Data.(metricdata.Sum[int64])
+			require.True(t, ok)
+			require.Len(t, sum.DataPoints, 1)
+			assert.Equal(t, int64(2), sum.DataPoints[0].Value)
+			dataType, _ := sum.DataPoints[0].Attributes.Value("data_type")
+			assert.Equal(t, "traces", dataType.AsString())
+		})
+	}
+}
+
+func TestGraphDataFreshness(t *testing.T) {
+	rcvrID := component.MustNewID("examplereceiver")
+	expID := component.MustNewID("exampleexporter")
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	tel := componenttest.NewNopTelemetrySettings()
+	tel.MeterProvider = mp
+	tel.LeveledMeterProvider = func(configtelemetry.Level) metric.MeterProvider { return mp }
+	set := Settings{
+		Telemetry: tel,
+		BuildInfo: component.NewDefaultBuildInfo(),
+		ReceiverBuilder: builders.NewReceiver(
+			map[component.ID]component.Config{
+				rcvrID: testcomponents.ExampleReceiverFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]receiver.Factory{
+				testcomponents.ExampleReceiverFactory.Type(): testcomponents.ExampleReceiverFactory,
+			},
+		),
+		ProcessorBuilder: builders.NewProcessor(map[component.ID]component.Config{}, map[component.Type]processor.Factory{}),
+		ExporterBuilder: builders.NewExporter(
+			map[component.ID]component.Config{
+				expID: testcomponents.ExampleExporterFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]exporter.Factory{
+				testcomponents.ExampleExporterFactory.Type(): testcomponents.ExampleExporterFactory,
+			},
+		),
+		ConnectorBuilder: builders.NewConnector(map[component.ID]component.Config{}, map[component.Type]connector.Factory{}),
+		PipelineConfigs: pipelines.Config{
+			component.MustNewID("traces"): {
+				Receivers: []component.ID{rcvrID},
+				Exporters: []component.ID{expID},
+			},
+			component.MustNewID("logs"): {
+				Receivers: []component.ID{rcvrID},
+				Exporters: []component.ID{expID},
+			},
+		},
+		DataFreshness: true,
+	}
+
+	pg, err := Build(context.Background(), set)
+	require.NoError(t, err)
+	now := time.Unix(1000, 0)
+	for _, df := range pg.freshness.byType {
+		df.now = func() time.Time { return now }
+		df.windowStart = now
+		df.newest = pcommon.NewTimestampFromTime(now)
+	}
+	require.NoError(t, pg.StartAll(context.Background(), &Host{Reporter: status.NewReporter(func(*componentstatus.InstanceID, *componentstatus.Event) {}, func(error) {})}))
+	tracesReceiver := pg.getReceivers()[component.DataTypeTraces][rcvrID].(*testcomponents.ExampleReceiver)
+	logsReceiver := pg.getReceivers()[component.DataTypeLogs][rcvrID].(*testcomponents.ExampleReceiver)
+
+	collectFreshness := func() map[string]float64 {
+		var rm metricdata.ResourceMetrics
+		require.NoError(t, reader.Collect(context.Background(), &rm))
+		require.Len(t, rm.ScopeMetrics, 1)
+		require.Len(t, rm.ScopeMetrics[0].Metrics, 1)
+		freshness := rm.ScopeMetrics[0].Metrics[0]
+		assert.Equal(t, "otelcol_pipeline_data_freshness", freshness.Name)
+		gauge, ok := freshness.Data.(metricdata.Gauge[float64])
+		require.True(t, ok)
+		got := make(map[string]float64)
+		for _, dp := range gauge.DataPoints {
+			dataType, _ := dp.Attributes.Value("data_type")
+			got[dataType.AsString()] = dp.Value
+		}
+		return got
+	}
+
+	td := ptrace.NewTraces()
+	spans := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	spans.AppendEmpty().SetEndTimestamp(pcommon.NewTimestampFromTime(now.Add(-time.Minute)))
+	span := spans.AppendEmpty()
+	span.SetStartTimestamp(pcommon.NewTimestampFromTime(now.Add(-40 * time.Second)))
+	span.SetEndTimestamp(pcommon.NewTimestampFromTime(now.Add(-30 * time.Second)))
+	require.NoError(t, tracesReceiver.ConsumeTraces(context.Background(), td))
+	ld := plog.NewLogs()
+	lrs := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	lrs.AppendEmpty().SetTimestamp(pcommon.NewTimestampFromTime(now.Add(-2 * time.Minute)))
+	// The observed timestamp is used for the log records without timestamp.
+	lrs.AppendEmpty().SetObservedTimestamp(pcommon.NewTimestampFromTime(now.Add(-5 * time.Second)))
+	require.NoError(t, logsReceiver.ConsumeLogs(context.Background(), ld))
+	assert.Equal(t, map[string]float64{"traces": 30, "metrics": 0, "logs": 5}, collectFreshness())
+	// The collections do not change the age.
+	assert.Equal(t, map[string]float64{"traces": 30, "metrics": 0, "logs": 5}, collectFreshness())
+
+	// The records of the previous window are still reported.
+	now = now.Add(70 * time.Second)
+	assert.Equal(t, map[string]float64{"traces": 100, "metrics": 70, "logs": 75}, collectFreshness())
+
+	// The age is the one of the newest records sent during the current or the previous window, growing if none
+	// is sent.
+	now = now.Add(2 * time.Minute)
+	td = ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().
+		SetEndTimestamp(pcommon.NewTimestampFromTime(now.Add(-time.Hour)))
+	require.NoError(t, tracesReceiver.ConsumeTraces(context.Background(), td))
+	ld = plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().
+		SetTimestamp(pcommon.NewTimestampFromTime(now.Add(-time.Second)))
+	require.NoError(t, logsReceiver.ConsumeLogs(context.Background(), ld))
+	assert.Equal(t, map[string]float64{"traces": 3600, "metrics": 190, "logs": 1}, collectFreshness())
+	require.NoError(t, pg.ShutdownAll(context.Background(), statustest.NewNopStatusReporter()))
+}
+
+func TestGraphGoroutineLimit(t *testing.T) {
+	rcvrID := component.MustNewID("examplereceiver")
+	expID := component.MustNewID("exampleexporter")
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	tel := componenttest.NewNopTelemetrySettings()
+	tel.MeterProvider = mp
+	tel.LeveledMeterProvider = func(configtelemetry.Level) metric.MeterProvider { return mp }
+	set := Settings{
+		Telemetry: tel,
+		BuildInfo: component.NewDefaultBuildInfo(),
+		ReceiverBuilder: builders.NewReceiver(
+			map[component.ID]component.Config{
+				rcvrID: testcomponents.ExampleReceiverFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]receiver.Factory{
+				testcomponents.ExampleReceiverFactory.Type(): testcomponents.ExampleReceiverFactory,
+			},
+		),
+		ProcessorBuilder: builders.NewProcessor(map[component.ID]component.Config{}, map[component.Type]processor.Factory{}),
+		ExporterBuilder: builders.NewExporter(
+			map[component.ID]component.Config{
+				expID: testcomponents.ExampleExporterFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]exporter.Factory{
+				testcomponents.ExampleExporterFactory.Type(): testcomponents.ExampleExporterFactory,
+			},
+		),
+		ConnectorBuilder: builders.NewConnector(map[component.ID]component.Config{}, map[component.Type]connector.Factory{}),
+		PipelineConfigs: pipelines.Config{
+			component.MustNewID("traces"): {
+				Receivers: []component.ID{rcvrID},
+				Exporters: []component.ID{expID},
+			},
+			component.MustNewID("logs"): {
+				Receivers: []component.ID{rcvrID},
+				Exporters: []component.ID{expID},
+			},
+		},
+		MaxGoroutines: 100,
+	}
+
+	pg, err := Build(context.Background(), set)
+	require.NoError(t, err)
+	goroutines := 99
+	pg.goroutineLimiter.numGoroutine = func() int { return goroutines }
+	require.NoError(t, pg.StartAll(context.Background(), &Host{Reporter: status.NewReporter(func(*componentstatus.InstanceID, *componentstatus.Event) {}, func(error) {})}))
+	tracesReceiver := pg.getReceivers()[component.DataTypeTraces][rcvrID].(*testcomponents.ExampleReceiver)
+	logsReceiver := pg.getReceivers()[component.DataTypeLogs][rcvrID].(*testcomponents.ExampleReceiver)
+	tracesExporter := pg.GetExporters()[component.DataTypeTraces][expID].(*testcomponents.ExampleExporter)
+	logsExporter := pg.GetExporters()[component.DataTypeLogs][expID].(*testcomponents.ExampleExporter)
+
+	require.NoError(t, tracesReceiver.ConsumeTraces(context.Background(), testdata.GenerateTraces(2)))
+	// The data is rejected with a retryable error while the goroutines reach the limit.
+	goroutines = 100
+	err = tracesReceiver.ConsumeTraces(context.Background(), testdata.GenerateTraces(3))
+	require.ErrorIs(t, err, errGoroutineLimitExceeded)
+	assert.False(t, consumererror.IsPermanent(err))
+	goroutines = 5000
+	require.ErrorIs(t, logsReceiver.ConsumeLogs(context.Background(), testdata.GenerateLogs(4)), errGoroutineLimitExceeded)
+	// The data is still rejected until the goroutines fall below 90% of the limit.
+	goroutines = 95
+	require.ErrorIs(t, logsReceiver.ConsumeLogs(context.Background(), testdata.GenerateLogs(4)), errGoroutineLimitExceeded)
+	// The data is received again once the goroutines complete.
+	goroutines = 89
+	require.NoError(t, logsReceiver.ConsumeLogs(context.Background(), testdata.GenerateLogs(1)))
+	assert.Len(t, tracesExporter.Traces, 1)
+	assert.Len(t, logsExporter.Logs, 1)
+	require.NoError(t, pg.ShutdownAll(context.Background(), statustest.NewNopStatusReporter()))
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	got := make(map[string]metricdata.Aggregation)
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		got[m.Name] = m.Data
+	}
+	// The number of goroutines is reported with the process metrics, not by the limiter.
+	require.Len(t, got, 1)
+	sum, ok := got["otelcol_pipeline_goroutine_limited_items"].(metricdata.Sum[int64])
+	require.True(t, ok)
+	limited := make(map[string]int64)
+	for _, dp := range sum.DataPoints {
+		dataType, _ := dp.Attributes.Value("data_type")
+		limited[dataType.AsString()] = dp.Value
+	}
+	assert.Equal(t, map[string]int64{"traces": 3, "logs": 8}, limited)
+}
+
+func TestNewestDataPointTimestamp(t *testing.T) {
+	tests := []struct {
+		name       string
+		dataPoints func(pmetric.Metric, pcommon.Timestamp)
+	}{
+		{
+			name: "gauge",
+			dataPoints: func(m pmetric.Metric, ts pcommon.Timestamp) {
+				m.SetEmptyGauge().DataPoints().AppendEmpty().SetTimestamp(ts)
+			},
+		},
+		{
+			name: "sum",
+			dataPoints: func(m pmetric.Metric, ts pcommon.Timestamp) {
+				m.SetEmptySum().DataPoints().AppendEmpty().SetTimestamp(ts)
+			},
+		},
+		{
+			name: "histogram",
+			dataPoints: func(m pmetric.Metric, ts pcommon.Timestamp) {
+				m.SetEmptyHistogram().DataPoints().AppendEmpty().SetTimestamp(ts)
+			},
+		},
+		{
+			name: "exponential_histogram",
+			dataPoints: func(m pmetric.Metric, ts pcommon.Timestamp) {
+				m.SetEmptyExponentialHistogram().DataPoints().AppendEmpty().SetTimestamp(ts)
+			},
+		},
+		{
+			name: "summary",
+			dataPoints: func(m pmetric.Metric, ts pcommon.Timestamp) {
+				m.SetEmptySummary().DataPoints().AppendEmpty().SetTimestamp(ts)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			md := pmetric.NewMetrics()
+			ms := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+			assert.Equal(t, pcommon.Timestamp(0), newestDataPointTimestamp(md))
+			tt.dataPoints(ms.AppendEmpty(), 20)
+			tt.dataPoints(ms.AppendEmpty(), 30)
+			tt.dataPoints(ms.AppendEmpty(), 10)
+			assert.Equal(t, pcommon.Timestamp(30), newestDataPointTimestamp(md))
+		})
+	}
+}
+
+func TestIngestLimiterAllow(t *testing.T) {
+	il, err := newIngestLimiter(IngestLimit{ItemsPerSecond: 10, Burst: 20}, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	now := time.Unix(1000, 0)
+	il.now = func() time.Time { return now }
+
+	assert.True(t, il.allow(15))
+	assert.False(t, il.allow(10))
+	assert.True(t, il.allow(5))
+	// The bucket fills up at the rate, and not above the burst.
+	now = now.Add(500 * time.Millisecond)
+	assert.False(t, il.allow(6))
+	assert.True(t, il.allow(5))
+	// A request larger than the burst is only accepted with a full bucket, and delays the next ones.
+	assert.False(t, il.allow(30))
+	now = now.Add(time.Hour)
+	assert.True(t, il.allow(30))
+	now = now.Add(time.Second)
+	assert.False(t, il.allow(1))
+	now = now.Add(200 * time.Millisecond)
+	assert.True(t, il.allow(1))
+
+	il, err = newIngestLimiter(IngestLimit{}, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	assert.Nil(t, il)
+}
+
+func TestGraphSetProcessorEnabled(t *testing.T) {
+	rcvrID := component.MustNewID("examplereceiver")
+	procID := component.MustNewID("stamp")
+	expID := component.MustNewID("exampleexporter")
+	pipelineID := component.MustNewID("traces")
+	// The stamp processor marks the spans it processes.
+	stampFactory := processor.NewFactory(procID.Type(), func() component.Config { return &struct{}{} },
+		processor.WithTraces(func(ctx context.Context, set processor.Settings, cfg component.Config, next consumer.Traces) (processor.Traces, error) {
+			return processorhelper.NewTracesProcessor(ctx, set, cfg, next, func(_ context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+				td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes().PutBool("processed", true)
+				return td, nil
+			})
+		}, component.StabilityLevelDevelopment))
+	set := Settings{
+		Telemetry: componenttest.NewNopTelemetrySettings(),
+		BuildInfo: component.NewDefaultBuildInfo(),
+		ReceiverBuilder: builders.NewReceiver(
+			map[component.ID]component.Config{rcvrID: testcomponents.ExampleReceiverFactory.CreateDefaultConfig()},
+			map[component.Type]receiver.Factory{testcomponents.ExampleReceiverFactory.Type(): testcomponents.ExampleReceiverFactory},
+		),
+		ProcessorBuilder: builders.NewProcessor(
+			map[component.ID]component.Config{procID: stampFactory.CreateDefaultConfig()},
+			map[component.Type]processor.Factory{stampFactory.Type(): stampFactory},
+		),
+		ExporterBuilder: builders.NewExporter(
+			map[component.ID]component.Config{expID: testcomponents.ExampleExporterFactory.CreateDefaultConfig()},
+			map[component.Type]exporter.Factory{testcomponents.ExampleExporterFactory.Type(): testcomponents.ExampleExporterFactory},
+		),
+		ConnectorBuilder: builders.NewConnector(map[component.ID]component.Config{}, map[component.Type]connector.Factory{}),
+		PipelineConfigs: pipelines.Config{
+			pipelineID: {
+				Receivers:  []component.ID{rcvrID},
+				Processors: []component.ID{procID},
+				Exporters:  []component.ID{expID},
+			},
+		},
+	}
+
+	pg, err := Build(context.Background(), set)
+	require.NoError(t, err)
+	host := &Host{Pipelines: pg, Reporter: status.NewReporter(func(*componentstatus.InstanceID, *componentstatus.Event) {}, func(error) {})}
+	require.NoError(t, pg.StartAll(context.Background(), host))
+	defer func() { assert.NoError(t, pg.ShutdownAll(context.Background(), statustest.NewNopStatusReporter())) }()
+	rcvr := pg.getReceivers()[component.DataTypeTraces][rcvrID].(*testcomponents.ExampleReceiver)
+	exp := pg.GetExporters()[component.DataTypeTraces][expID].(*testcomponents.ExampleExporter)
+	processed := func() []bool {
+		var got []bool
+		for _, td := range exp.Traces {
+			_, ok := td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes().Get("processed")
+			got = append(got, ok)
+		}
+		return got
+	}
+
+	// The extensions toggle the processors through the host capability.
+	toggler, ok := component.Host(host).(hostcapabilities.ProcessorToggler)
+	require.True(t, ok)
+
+	require.NoError(t, rcvr.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	require.NoError(t, toggler.SetProcessorEnabled(pipelineID, procID, false))
+	require.NoError(t, rcvr.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	require.NoError(t, toggler.SetProcessorEnabled(pipelineID, procID, true))
+	require.NoError(t, rcvr.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	// The data bypasses the processor only while it is disabled.
+	assert.Equal(t, []bool{true, false, true}, processed())
+
+	require.EqualError(t, toggler.SetProcessorEnabled(component.MustNewID("logs"), procID, false), `pipeline "logs" not found`)
+	require.EqualError(t, toggler.SetProcessorEnabled(pipelineID, component.MustNewID("batch"), false), `processor "batch" not found in pipeline "traces"`)
+}
+
+func TestGraphDeadLetter(t *testing.T) {
+	rcvrID := component.MustNewID("examplereceiver")
+	procID := component.MustNewID("drop")
+	expID := component.MustNewID("exampleexporter")
+	deadLetterID := component.MustNewIDWithName("exampleexporter", "dead_letter")
+	// The drop processor skips the logs without a body.
+	dropFactory := processor.NewFactory(procID.Type(), func() component.Config { return &struct{}{} },
+		processor.WithLogs(func(ctx context.Context, set processor.Settings, cfg component.Config, next consumer.Logs) (processor.Logs, error) {
+			return processorhelper.NewLogsProcessor(ctx, set, cfg, next, func(_ context.Context, ld plog.Logs) (plog.Logs, error) {
+				if ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().Str() == "" {
+					return ld, fmt.Errorf("%w: empty body", processorhelper.ErrSkipProcessingData)
+				}
+				return ld, nil
+			})
+		}, component.StabilityLevelDevelopment))
+	deadLetterFactory := exporter.NewFactory(deadLetterID.Type(), testcomponents.ExampleExporterFactory.CreateDefaultConfig,
+		exporter.WithLogs(func(ctx context.Context, set exporter.Settings, cfg component.Config) (exporter.Logs, error) {
+			exp, err := testcomponents.ExampleExporterFactory.CreateLogsExporter(ctx, set, cfg)
+			if err != nil {
+				return nil, err
+			}
+			return &reasonExporter{Logs: exp}, nil
+		}, component.StabilityLevelDevelopment))
+	set := Settings{
+		Telemetry: componenttest.NewNopTelemetrySettings(),
+		BuildInfo: component.NewDefaultBuildInfo(),
+		ReceiverBuilder: builders.NewReceiver(
+			map[component.ID]component.Config{rcvrID: testcomponents.ExampleReceiverFactory.CreateDefaultConfig()},
+			map[component.Type]receiver.Factory{testcomponents.ExampleReceiverFactory.Type(): testcomponents.ExampleReceiverFactory},
+		),
+		ProcessorBuilder: builders.NewProcessor(
+			map[component.ID]component.Config{procID: dropFactory.CreateDefaultConfig()},
+			map[component.Type]processor.Factory{dropFactory.Type(): dropFactory},
+		),
+		ExporterBuilder: builders.NewExporter(
+			map[component.ID]component.Config{
+				expID:        testcomponents.ExampleExporterFactory.CreateDefaultConfig(),
+				deadLetterID: deadLetterFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]exporter.Factory{deadLetterFactory.Type(): deadLetterFactory},
+		),
+		ConnectorBuilder: builders.NewConnector(map[component.ID]component.Config{}, map[component.Type]connector.Factory{}),
+		PipelineConfigs: pipelines.Config{
+			component.MustNewID("logs"): {
+				Receivers:  []component.ID{rcvrID},
+				Processors: []component.ID{procID},
+				Exporters:  []component.ID{expID},
+				DeadLetter: &deadLetterID,
+			},
+		},
+	}
+
+	pg, err := Build(context.Background(), set)
+	require.NoError(t, err)
+	require.NoError(t, pg.StartAll(context.Background(), &Host{Reporter: status.NewReporter(func(*componentstatus.InstanceID, *componentstatus.Event) {}, func(error) {})}))
+	defer func() { assert.NoError(t, pg.ShutdownAll(context.Background(), statustest.NewNopStatusReporter())) }()
+	rcvr := pg.getReceivers()[component.DataTypeLogs][rcvrID].(*testcomponents.ExampleReceiver)
+	exps := pg.GetExporters()[component.DataTypeLogs]
+	require.Len(t, exps, 2)
+	exp := exps[expID].(*reasonExporter).Logs.(*testcomponents.ExampleExporter)
+	deadLetter := exps[deadLetterID].(*reasonExporter).Logs.(*testcomponents.ExampleExporter)
+
+	ld := testdata.GenerateLogs(1)
+	ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().SetStr("kept")
+	require.NoError(t, rcvr.ConsumeLogs(context.Background(), ld))
+	dropped := testdata.GenerateLogs(1)
+	dropped.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().SetStr("")
+	require.NoError(t, rcvr.ConsumeLogs(context.Background(), dropped))
+
+	// The dead-letter exporter only receives the dropped logs, with the reason of the drop.
+	require.Len(t, exp.Logs, 1)
+	assert.Equal(t, "kept", exp.Logs[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().Str())
+	require.Len(t, deadLetter.Logs, 1)
+	assert.Equal(t, dropped, deadLetter.Logs[0])
+	assert.Equal(t, []string{processorhelper.ErrSkipProcessingData.Error() + ": empty body"}, exps[deadLetterID].(*reasonExporter).reasons)
+}
+
+// reasonExporter records the dead-letter reasons of the logs it exports.
+type reasonExporter struct {
+	exporter.Logs
+	reasons []string
+}
+
+func (e *reasonExporter) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	e.reasons = append(e.reasons, consumerdeadletter.ReasonFromContext(ctx))
+	return e.Logs.ConsumeLogs(ctx, ld)
+}
+
+func TestGraphFanoutDelivery(t *testing.T) {
+	tests := []struct {
+		name   string
+		fanout map[string]any
+		// concurrent makes every exporter wait until all the exporters received the data.
+		concurrent bool
+		// failing is the exporter returning a permanent error.
+		failing string
+		// sends is the number of logs sent by the receiver.
+		sends    int
+		expected []string
+		wantErr  bool
+	}{
+		{
+			name:     "sequential",
+			fanout:   map[string]any{"delivery": "sequential"},
+			sends:    2,
+			expected: []string{"c", "a", "b", "c", "a", "b"},
+		},
+		{
+			name:     "sequential_errors",
+			fanout:   map[string]any{"delivery": "sequential"},
+			failing:  "a",
+			sends:    1,
+			expected: []string{"c", "a", "b"},
+			wantErr:  true,
+		},
+		{
+			name:     "sequential_stop_on_permanent_error",
+			fanout:   map[string]any{"delivery": "sequential", "stop_on_permanent_error": true},
+			failing:  "a",
+			sends:    1,
+			expected: []string{"c", "a"},
+			wantErr:  true,
+		},
+		{
+			name:       "concurrent",
+			fanout:     map[string]any{"delivery": "concurrent"},
+			concurrent: true,
+			sends:      2,
+			expected:   []string{"a", "a", "b", "b", "c", "c"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rcvrID := component.MustNewID("examplereceiver")
+			var mu sync.Mutex
+			var received []string
+			var started sync.WaitGroup
+			recorderFactory := exporter.NewFactory(component.MustNewType("recorder"), func() component.Config { return &struct{}{} },
+				exporter.WithLogs(func(_ context.Context, set exporter.Settings, _ component.Config) (exporter.Logs, error) {
+					return &recordingExporter{Logs: consumertest.NewNop(), consume: func() error {
+						mu.Lock()
+						received = append(received, set.ID.Name())
+						mu.Unlock()
+						if tt.concurrent {
+							started.Done()
+							done := make(chan struct{})
+							go func() {
+								started.Wait()
+								close(done)
+							}()
+							select {
+							case <-done:
+							case <-time.After(time.Second):
+								return errors.New("the exporters did not receive the data at the same time")
+							}
+						}
+						if set.ID.Name() == tt.failing {
+							return consumererror.NewPermanent(errors.New("failed"))
+						}
+						return nil
+					}}, nil
+				}, component.StabilityLevelDevelopment))
+
+			conf := confmap.NewFromStringMap(map[string]any{
+				"logs": map[string]any{
+					"receivers": []any{"examplereceiver"},
+					"exporters": []any{"recorder/c", "recorder/a", "recorder/b"},
+					"fanout":    tt.fanout,
+				},
+			})
+			var pipelinesCfg pipelines.Config
+			require.NoError(t, conf.Unmarshal(&pipelinesCfg))
+			require.NoError(t, pipelinesCfg.Validate())
+			exporterCfgs := map[component.ID]component.Config{}
+			for _, name := range []string{"a", "b", "c"} {
+				exporterCfgs[component.MustNewIDWithName("recorder", name)] = recorderFactory.CreateDefaultConfig()
+			}
+			set := Settings{
+				Telemetry: componenttest.NewNopTelemetrySettings(),
+				BuildInfo: component.NewDefaultBuildInfo(),
+				ReceiverBuilder: builders.NewReceiver(
+					map[component.ID]component.Config{rcvrID: testcomponents.ExampleReceiverFactory.CreateDefaultConfig()},
+					map[component.Type]receiver.Factory{testcomponents.ExampleReceiverFactory.Type(): testcomponents.ExampleReceiverFactory},
+				),
+				ProcessorBuilder: builders.NewProcessor(map[component.ID]component.Config{}, map[component.Type]processor.Factory{}),
+				ExporterBuilder:  builders.NewExporter(exporterCfgs, map[component.Type]exporter.Factory{recorderFactory.Type(): recorderFactory}),
+				ConnectorBuilder: builders.NewConnector(map[component.ID]component.Config{}, map[component.Type]connector.Factory{}),
+				PipelineConfigs:  pipelinesCfg,
+			}
+
+			pg, err := Build(context.Background(), set)
+			require.NoError(t, err)
+			require.NoError(t, pg.StartAll(context.Background(), &Host{Reporter: status.NewReporter(func(*componentstatus.InstanceID, *componentstatus.Event) {}, func(error) {})}))
+			defer func() { assert.NoError(t, pg.ShutdownAll(context.Background(), statustest.NewNopStatusReporter())) }()
+			rcvr := pg.getReceivers()[component.DataTypeLogs][rcvrID].(*testcomponents.ExampleReceiver)
+
+			for i := 0; i < tt.sends; i++ {
+				started.Add(3)
+				err = rcvr.ConsumeLogs(context.Background(), testdata.GenerateLogs(1))
+				if tt.wantErr {
+					require.Error(t, err)
+					assert.True(t, consumererror.IsPermanent(err))
+				} else {
+					require.NoError(t, err)
+				}
+			}
+			if tt.concurrent {
+				// The order of the concurrent deliveries is not deterministic.
+				slices.Sort(received)
+			}
+			assert.Equal(t, tt.expected, received)
+		})
+	}
+}
+
+// recordingExporter calls consume for every logs it exports.
+type recordingExporter struct {
+	component.StartFunc
+	component.ShutdownFunc
+	consumer.Logs
+	consume func() error
+}
+
+func (e *recordingExporter) ConsumeLogs(context.Context, plog.Logs) error {
+	return e.consume()
+}
+
+func TestGraphStageDurations(t *testing.T) {
+	rcvrID := component.MustNewID("examplereceiver")
+	fastID := component.MustNewIDWithName("sleep", "fast")
+	slowID := component.MustNewIDWithName("sleep", "slow")
+	expID := component.MustNewID("exampleexporter")
+	type sleepConfig struct{ duration time.Duration }
+	sleepFactory := processor.NewFactory(fastID.Type(), func() component.Config { return &sleepConfig{} },
+		processor.WithTraces(func(ctx context.Context, set processor.Settings, cfg component.Config, next consumer.Traces) (processor.Traces, error) {
+			return processorhelper.NewTracesProcessor(ctx, set, cfg, next, func(_ context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+				time.Sleep(cfg.(*sleepConfig).duration)
+				return td, nil
+			})
+		}, component.StabilityLevelDevelopment))
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	tel := componenttest.NewNopTelemetrySettings()
+	tel.MeterProvider = mp
+	tel.MetricsLevel = configtelemetry.LevelDetailed
+	tel.LeveledMeterProvider = func(configtelemetry.Level) metric.MeterProvider { return mp }
+	set := Settings{
+		Telemetry: tel,
+		BuildInfo: component.NewDefaultBuildInfo(),
+		ReceiverBuilder: builders.NewReceiver(
+			map[component.ID]component.Config{rcvrID: testcomponents.ExampleReceiverFactory.CreateDefaultConfig()},
+			map[component.Type]receiver.Factory{testcomponents.ExampleReceiverFactory.Type(): testcomponents.ExampleReceiverFactory},
+		),
+		ProcessorBuilder: builders.NewProcessor(
+			map[component.ID]component.Config{fastID: &sleepConfig{}, slowID: &sleepConfig{duration: 20 * time.Millisecond}},
+			map[component.Type]processor.Factory{sleepFactory.Type(): sleepFactory},
+		),
+		ExporterBuilder: builders.NewExporter(
+			map[component.ID]component.Config{expID: testcomponents.ExampleExporterFactory.CreateDefaultConfig()},
+			map[component.Type]exporter.Factory{testcomponents.ExampleExporterFactory.Type(): testcomponents.ExampleExporterFactory},
+		),
+		ConnectorBuilder: builders.NewConnector(map[component.ID]component.Config{}, map[component.Type]connector.Factory{}),
+		PipelineConfigs: pipelines.Config{
+			component.MustNewID("traces"): {
+				Receivers:  []component.ID{rcvrID},
+				Processors: []component.ID{fastID, slowID},
+				Exporters:  []component.ID{expID},
+			},
+		},
+	}
+
+	pg, err := Build(context.Background(), set)
+	require.NoError(t, err)
+	require.NoError(t, pg.StartAll(context.Background(), &Host{Reporter: status.NewReporter(func(*componentstatus.InstanceID, *componentstatus.Event) {}, func(error) {})}))
+	rcvr := pg.getReceivers()[component.DataTypeTraces][rcvrID].(*testcomponents.ExampleReceiver)
+
+	// The receiver stage starts with its operation span.
+	ctx, span := sdktrace.NewTracerProvider().Tracer("test").Start(context.Background(), "receiver/examplereceiver/TraceDataReceived")
+	time.Sleep(10 * time.Millisecond)
+	require.NoError(t, rcvr.ConsumeTraces(ctx, testdata.GenerateTraces(1)))
+	span.End()
+	require.NoError(t, pg.ShutdownAll(context.Background(), statustest.NewNopStatusReporter()))
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	var durations map[string]metricdata.HistogramDataPoint[float64]
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != "otelcol_pipeline_stage_duration" {
+				continue
+			}
+			durations = make(map[string]metricdata.HistogramDataPoint[float64])
+			for _, dp := range m.Data.(metricdata.Histogram[float64]).DataPoints {
+				id, _ := dp.Attributes.Value("component")
+				stage, _ := dp.Attributes.Value("stage")
+				durations[stage.AsString()+"/"+id.AsString()] = dp
+			}
+		}
+	}
+	require.Len(t, durations, 4)
+	for key, dp := range durations {
+		assert.Equal(t, uint64(1), dp.Count, key)
+	}
+	assert.GreaterOrEqual(t, durations["receiver/examplereceiver"].Sum, 0.01)
+	assert.GreaterOrEqual(t, durations["processor/sleep/slow"].Sum, 0.02)
+	// The time spent in the next processor is not counted.
+	assert.Less(t, durations["processor/sleep/fast"].Sum, 0.02)
+	assert.Less(t, durations["exporter/exampleexporter"].Sum, 0.02)
+}
+
+func TestGraphOTLPPassthrough(t *testing.T) {
+	prev := otlppassthrough.Gate.IsEnabled()
+	require.NoError(t, featuregate.GlobalRegistry().Set(otlppassthrough.Gate.ID(), true))
+	t.Cleanup(func() {
+		require.NoError(t, featuregate.GlobalRegistry().Set(otlppassthrough.Gate.ID(), prev))
+	})
+
+	tests := []struct {
+		name            string
+		processor       component.ID
+		wantPassthrough bool
+	}{
+		{
+			name:            "non_mutating_processor",
+			processor:       component.MustNewID("exampleprocessor"),
+			wantPassthrough: true,
+		},
+		{
+			name:            "mutating_processor",
+			processor:       component.MustNewIDWithName("exampleprocessor", "mutate"),
+			wantPassthrough: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rcvrID := component.MustNewID("examplereceiver")
+			expID := component.MustNewID("passthrough")
+			pe := &passthroughExporter{}
+			expFactory := exporter.NewFactory(expID.Type(), func() component.Config { return &struct{}{} },
+				exporter.WithTraces(func(context.Context, exporter.Settings, component.Config) (exporter.Traces, error) {
+					return pe, nil
+				}, component.StabilityLevelDevelopment))
+			set := Settings{
+				Telemetry: componenttest.NewNopTelemetrySettings(),
+				BuildInfo: component.NewDefaultBuildInfo(),
+				ReceiverBuilder: builders.NewReceiver(
+					map[component.ID]component.Config{
+						rcvrID: testcomponents.ExampleReceiverFactory.CreateDefaultConfig(),
+					},
+					map[component.Type]receiver.Factory{
+						testcomponents.ExampleReceiverFactory.Type(): testcomponents.ExampleReceiverFactory,
+					},
+				),
+				ProcessorBuilder: builders.NewProcessor(
+					map[component.ID]component.Config{
+						tt.processor: testcomponents.ExampleProcessorFactory.CreateDefaultConfig(),
+					},
+					map[component.Type]processor.Factory{
+						testcomponents.ExampleProcessorFactory.Type(): testcomponents.ExampleProcessorFactory,
+					},
+				),
+				ExporterBuilder: builders.NewExporter(
+					map[component.ID]component.Config{expID: expFactory.CreateDefaultConfig()},
+					map[component.Type]exporter.Factory{expFactory.Type(): expFactory},
+				),
+				ConnectorBuilder: builders.NewConnector(map[component.ID]component.Config{}, map[component.Type]connector.Factory{}),
+				PipelineConfigs: pipelines.Config{
+					component.MustNewID("traces"): {
+						Receivers:  []component.ID{rcvrID},
+						Processors: []component.ID{tt.processor},
+						Exporters:  []component.ID{expID},
+					},
+				},
+			}
+
+			pg, err := Build(context.Background(), set)
+			require.NoError(t, err)
+			require.NoError(t, pg.StartAll(context.Background(), &Host{Reporter: status.NewReporter(func(*componentstatus.InstanceID, *componentstatus.Event) {}, func(error) {})}))
+			t.Cleanup(func() {
+				require.NoError(t, pg.ShutdownAll(context.Background(), statustest.NewNopStatusReporter()))
+			})
+
+			td := testdata.GenerateTraces(1)
+			ctx := otlppassthrough.ContextWithTraces(context.Background(), td, []byte("request"))
+			rcvr := pg.getReceivers()[component.DataTypeTraces][rcvrID].(*testcomponents.ExampleReceiver)
+			require.NoError(t, rcvr.ConsumeTraces(ctx, td))
+			assert.Equal(t, []bool{tt.wantPassthrough}, pe.passthrough)
+		})
+	}
+}
+
+func TestGraphMetricsLevels(t *testing.T) {
+	rcvrID := component.MustNewID("examplereceiver")
+	detailedID := component.MustNewIDWithName("telemetry", "detailed")
+	globalID := component.MustNewID("telemetry")
+	expID := component.MustNewID("exampleexporter")
+
+	procSettings := map[component.ID]processor.Settings{}
+	procFactory := processor.NewFactory(globalID.Type(), func() component.Config { return &struct{}{} },
+		processor.WithTraces(func(_ context.Context, set processor.Settings, _ component.Config, next consumer.Traces) (processor.Traces, error) {
+			procSettings[set.ID] = set
+			return processortest.NewNopFactory().CreateTracesProcessor(context.Background(), processortest.NewNopSettings(), &struct{}{}, next)
+		}, component.StabilityLevelDevelopment))
+
+	mp := sdkmetric.NewMeterProvider()
+	tel := componenttest.NewNopTelemetrySettings()
+	tel.MeterProvider = mp
+	tel.MetricsLevel = configtelemetry.LevelBasic
+	tel.LeveledMeterProvider = func(level configtelemetry.Level) metric.MeterProvider {
+		if level <= configtelemetry.LevelBasic {
+			return mp
+		}
+		return noopmetric.MeterProvider{}
+	}
+	set := Settings{
+		Telemetry: tel,
+		BuildInfo: component.NewDefaultBuildInfo(),
+		ReceiverBuilder: builders.NewReceiver(
+			map[component.ID]component.Config{
+				rcvrID: testcomponents.ExampleReceiverFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]receiver.Factory{
+				testcomponents.ExampleReceiverFactory.Type(): testcomponents.ExampleReceiverFactory,
+			},
+		),
+		ProcessorBuilder: builders.NewProcessor(
+			map[component.ID]component.Config{
+				detailedID: procFactory.CreateDefaultConfig(),
+				globalID:   procFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]processor.Factory{procFactory.Type(): procFactory},
+		),
+		ExporterBuilder: builders.NewExporter(
+			map[component.ID]component.Config{
+				expID: testcomponents.ExampleExporterFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]exporter.Factory{
+				testcomponents.ExampleExporterFactory.Type(): testcomponents.ExampleExporterFactory,
+			},
+		),
+		ConnectorBuilder: builders.NewConnector(map[component.ID]component.Config{}, map[component.Type]connector.Factory{}),
+		PipelineConfigs: pipelines.Config{
+			component.MustNewID("traces"): {
+				Receivers:  []component.ID{rcvrID},
+				Processors: []component.ID{detailedID, globalID},
+				Exporters:  []component.ID{expID},
+			},
+		},
+		MetricsLevels: map[component.ID]configtelemetry.Level{
+			detailedID: configtelemetry.LevelDetailed,
+		},
+	}
+
+	_, err := Build(context.Background(), set)
+	require.NoError(t, err)
+	require.Len(t, procSettings, 2)
+
+	detailed := procSettings[detailedID]
+	assert.Equal(t, configtelemetry.LevelDetailed, detailed.MetricsLevel)
+	assert.Equal(t, mp, detailed.LeveledMeterProvider(configtelemetry.LevelBasic))
+	assert.Equal(t, mp, detailed.LeveledMeterProvider(configtelemetry.LevelDetailed))
+
+	global := procSettings[globalID]
+	assert.Equal(t, configtelemetry.LevelBasic, global.MetricsLevel)
+	assert.Equal(t, mp, global.LeveledMeterProvider(configtelemetry.LevelBasic))
+	assert.Equal(t, noopmetric.MeterProvider{}, global.LeveledMeterProvider(configtelemetry.LevelDetailed))
+}
+
+// passthroughExporter records whether the received requests were available to forward as is.
+type passthroughExporter struct {
+	component.StartFunc
+	component.ShutdownFunc
+	passthrough []bool
+}
+
+func (pe *passthroughExporter) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	_, ok := otlppassthrough.TracesFromContext(ctx, td)
+	pe.passthrough = append(pe.passthrough, ok)
+	return nil
+}
+
+func (pe *passthroughExporter) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{}
+}
+
 func TestConnectorPipelinesGraph(t *testing.T) {
 	tests := []struct {
 		name                string
@@ -1475,6 +2461,9 @@ func TestGraphBuildErrors(t *testing.T) {
 	badExporterFactory := newBadExporterFactory()
 	badConnectorFactory := newBadConnectorFactory()
 
+	deadLetter1 := component.MustNewIDWithName("nop", "1")
+	deadLetter2 := component.MustNewIDWithName("nop", "2")
+	connDeadLetter := component.MustNewIDWithName("nop", "conn")
 	tests := []struct {
 		name          string
 		receiverCfgs  map[component.ID]component.Config
@@ -2626,6 +3615,73 @@ func TestGraphBuildErrors(t *testing.T) {
 			},
 			expected: "connector factory not available for: \"unknown\"",
 		},
+		{
+			name: "different_dead_letter_exporters",
+			receiverCfgs: map[component.ID]component.Config{
+				component.MustNewID("nop"): nopReceiverFactory.CreateDefaultConfig(),
+			},
+			exporterCfgs: map[component.ID]component.Config{
+				component.MustNewID("nop"): nopExporterFactory.CreateDefaultConfig(),
+				deadLetter1:                nopExporterFactory.CreateDefaultConfig(),
+				deadLetter2:                nopExporterFactory.CreateDefaultConfig(),
+			},
+			pipelineCfgs: pipelines.Config{
+				component.MustNewIDWithName("logs", "1"): {
+					Receivers:  []component.ID{component.MustNewID("nop")},
+					Exporters:  []component.ID{component.MustNewID("nop")},
+					DeadLetter: &deadLetter1,
+				},
+				component.MustNewIDWithName("logs", "2"): {
+					Receivers:  []component.ID{component.MustNewID("nop")},
+					Exporters:  []component.ID{component.MustNewID("nop")},
+					DeadLetter: &deadLetter2,
+				},
+			},
+			expected: "exporter \"nop\" is used by logs pipelines with different dead-letter exporters",
+		},
+		{
+			name: "dead_letter_cycle",
+			receiverCfgs: map[component.ID]component.Config{
+				component.MustNewID("nop"): nopReceiverFactory.CreateDefaultConfig(),
+			},
+			exporterCfgs: map[component.ID]component.Config{
+				deadLetter1: nopExporterFactory.CreateDefaultConfig(),
+				deadLetter2: nopExporterFactory.CreateDefaultConfig(),
+			},
+			pipelineCfgs: pipelines.Config{
+				component.MustNewIDWithName("logs", "1"): {
+					Receivers:  []component.ID{component.MustNewID("nop")},
+					Exporters:  []component.ID{deadLetter1},
+					DeadLetter: &deadLetter2,
+				},
+				component.MustNewIDWithName("logs", "2"): {
+					Receivers:  []component.ID{component.MustNewID("nop")},
+					Exporters:  []component.ID{deadLetter2},
+					DeadLetter: &deadLetter1,
+				},
+			},
+			expected: `dead-letter cycle detected: exporter "nop/1" (logs) -> exporter "nop/2" (logs) -> exporter "nop/1" (logs)`,
+		},
+		{
+			name: "connector_dead_letter_exporter",
+			receiverCfgs: map[component.ID]component.Config{
+				component.MustNewID("nop"): nopReceiverFactory.CreateDefaultConfig(),
+			},
+			exporterCfgs: map[component.ID]component.Config{
+				component.MustNewID("nop"): nopExporterFactory.CreateDefaultConfig(),
+			},
+			connectorCfgs: map[component.ID]component.Config{
+				connDeadLetter: nopConnectorFactory.CreateDefaultConfig(),
+			},
+			pipelineCfgs: pipelines.Config{
+				component.MustNewID("logs"): {
+					Receivers:  []component.ID{component.MustNewID("nop")},
+					Exporters:  []component.ID{component.MustNewID("nop")},
+					DeadLetter: &connDeadLetter,
+				},
+			},
+			expected: "pipeline \"logs\": dead-letter exporter \"nop/conn\" cannot be a connector",
+		},
 	}
 
 	for _, test := range tests {
@@ -14,6 +14,7 @@ import (
 	"go.opentelemetry.io/collector/extension"
 	"go.opentelemetry.io/collector/featuregate"
 	"go.opentelemetry.io/collector/service/extensions"
+	"go.opentelemetry.io/collector/service/hostcapabilities"
 	"go.opentelemetry.io/collector/service/internal/builders"
 	"go.opentelemetry.io/collector/service/internal/status"
 	"go.opentelemetry.io/collector/service/internal/zpages"
@@ -26,6 +27,7 @@ type getExporters interface {
 
 var _ getExporters = (*Host)(nil)
 var _ component.Host = (*Host)(nil)
+var _ hostcapabilities.ProcessorToggler = (*Host)(nil)
 
 type Host struct {
 	AsyncErrorChannel chan error
@@ -74,6 +76,11 @@ func (host *Host) GetExporters() map[component.DataType]map[component.ID]compone
 	return host.Pipelines.GetExporters()
 }
 
+// SetProcessorEnabled implements hostcapabilities.ProcessorToggler.
+func (host *Host) SetProcessorEnabled(pipelineID, processorID component.ID, enabled bool) error {
+	return host.Pipelines.SetProcessorEnabled(pipelineID, processorID, enabled)
+}
+
 func (host *Host) NotifyComponentStatusChange(source *componentstatus.InstanceID, event *componentstatus.Event) {
 	host.ServiceExtensions.NotifyComponentStatusChange(source, event)
 	if event.Status() == componentstatus.StatusFatalError {
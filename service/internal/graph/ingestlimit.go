@@ -0,0 +1,155 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentprofiles"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumerprofiles"
+	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/service/internal/metadata"
+)
+
+// errIngestLimitExceeded is returned to the receivers sending more items than the ingest limit. It is not a
+// permanent error, so the receivers report it as retryable to their clients, applying backpressure.
+var errIngestLimitExceeded = errors.New("the collector ingest limit is exceeded, retry later")
+
+// IngestLimit configures the cap of the items sent by all the receivers to the pipelines.
+type IngestLimit struct {
+	// ItemsPerSecond is the rate at which the bucket of items fills up. Zero disables the limit.
+	ItemsPerSecond float64
+	// Burst is the size of the bucket of items, ItemsPerSecond if zero.
+	Burst int
+	// Drop drops the items exceeding the limit instead of rejecting them with a retryable error.
+	Drop bool
+}
+
+// ingestLimiter throttles the data sent by the receivers with a token bucket shared by all the pipelines.
+type ingestLimiter struct {
+	rate float64
+	// burst is the capacity of the bucket.
+	burst float64
+	drop  bool
+	now   func() time.Time
+
+	telemetryBuilder *metadata.TelemetryBuilder
+
+	mu sync.Mutex
+	// tokens is the number of items which can be received, negative if a request larger than the bucket
+	// was accepted and the bucket did not fill up again yet.
+	tokens float64
+	last   time.Time
+}
+
+// newIngestLimiter returns an ingestLimiter with a full bucket, or nil if there is no limit.
+func newIngestLimiter(cfg IngestLimit, tel component.TelemetrySettings) (*ingestLimiter, error) {
+	if cfg.ItemsPerSecond <= 0 {
+		return nil, nil
+	}
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(tel)
+	if err != nil {
+		return nil, err
+	}
+	burst := float64(cfg.Burst)
+	if burst <= 0 {
+		burst = cfg.ItemsPerSecond
+	}
+	return &ingestLimiter{
+		rate:             cfg.ItemsPerSecond,
+		burst:            burst,
+		drop:             cfg.Drop,
+		now:              time.Now,
+		telemetryBuilder: telemetryBuilder,
+		tokens:           burst,
+	}, nil
+}
+
+// allow takes items tokens from the bucket, and reports whether the items can be received. A request with
+// more items than the bucket capacity is accepted when the bucket is full, the deficit delaying the next ones.
+func (il *ingestLimiter) allow(items int) bool {
+	il.mu.Lock()
+	defer il.mu.Unlock()
+	now := il.now()
+	if !il.last.IsZero() {
+		il.tokens += now.Sub(il.last).Seconds() * il.rate
+		if il.tokens > il.burst {
+			il.tokens = il.burst
+		}
+	}
+	il.last = now
+
+	n := float64(items)
+	if n > il.tokens && (n <= il.burst || il.tokens < il.burst) {
+		return false
+	}
+	il.tokens -= n
+	return true
+}
+
+// throttle reports whether the items must not be passed to the pipelines. It returns the error to return to
+// the receiver, nil if the items are dropped.
+func (il *ingestLimiter) throttle(ctx context.Context, dataType component.DataType, items int) (bool, error) {
+	if il.allow(items) {
+		return false, nil
+	}
+	il.telemetryBuilder.PipelineThrottledItems.Add(ctx, int64(items),
+		metric.WithAttributes(attribute.String(obsmetrics.DataTypeKey, dataType.String())))
+	if il.drop {
+		return true, nil
+	}
+	return true, errIngestLimitExceeded
+}
+
+func (il *ingestLimiter) traces(next consumer.Traces) consumer.Traces {
+	tr, _ := consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
+		if throttled, err := il.throttle(ctx, component.DataTypeTraces, td.SpanCount()); throttled {
+			return err
+		}
+		return next.ConsumeTraces(ctx, td)
+	}, consumer.WithCapabilities(next.Capabilities()))
+	return tr
+}
+
+func (il *ingestLimiter) metrics(next consumer.Metrics) consumer.Metrics {
+	mr, _ := consumer.NewMetrics(func(ctx context.Context, md pmetric.Metrics) error {
+		if throttled, err := il.throttle(ctx, component.DataTypeMetrics, md.DataPointCount()); throttled {
+			return err
+		}
+		return next.ConsumeMetrics(ctx, md)
+	}, consumer.WithCapabilities(next.Capabilities()))
+	return mr
+}
+
+func (il *ingestLimiter) logs(next consumer.Logs) consumer.Logs {
+	lr, _ := consumer.NewLogs(func(ctx context.Context, ld plog.Logs) error {
+		if throttled, err := il.throttle(ctx, component.DataTypeLogs, ld.LogRecordCount()); throttled {
+			return err
+		}
+		return next.ConsumeLogs(ctx, ld)
+	}, consumer.WithCapabilities(next.Capabilities()))
+	return lr
+}
+
+func (il *ingestLimiter) profiles(next consumerprofiles.Profiles) consumerprofiles.Profiles {
+	pr, _ := consumerprofiles.NewProfiles(func(ctx context.Context, pd pprofile.Profiles) error {
+		if throttled, err := il.throttle(ctx, componentprofiles.DataTypeProfiles, pd.SampleCount()); throttled {
+			return err
+		}
+		return next.ConsumeProfiles(ctx, pd)
+	}, consumer.WithCapabilities(next.Capabilities()))
+	return pr
+}
@@ -18,6 +18,7 @@ import (
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/internal/fanoutconsumer"
 	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processorprofiles"
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/service/internal/builders"
 	"go.opentelemetry.io/collector/service/internal/capabilityconsumer"
@@ -76,35 +77,103 @@ func (n *receiverNode) buildComponent(ctx context.Context,
 	info component.BuildInfo,
 	builder *builders.ReceiverBuilder,
 	nexts []baseConsumer,
+	warmup *warmupGate,
+	limiter *ingestLimiter,
+	freshness *freshnessTracker,
+	goroutineLimiter *goroutineLimiter,
 ) error {
 	tel.Logger = components.ReceiverLogger(tel.Logger, n.componentID, n.pipelineType)
 	set := receiver.Settings{ID: n.componentID, TelemetrySettings: tel, BuildInfo: info}
-	var err error
+	timer, err := newStageTimer(stageReceiver, n.componentID, tel)
+	if err != nil {
+		return err
+	}
 	switch n.pipelineType {
 	case component.DataTypeTraces:
 		var consumers []consumer.Traces
 		for _, next := range nexts {
 			consumers = append(consumers, next.(consumer.Traces))
 		}
-		n.Component, err = builder.CreateTraces(ctx, set, fanoutconsumer.NewTraces(consumers))
+		next := fanoutconsumer.NewTraces(consumers)
+		if freshness != nil {
+			next = freshness.traces(next)
+		}
+		if limiter != nil {
+			next = limiter.traces(next)
+		}
+		if goroutineLimiter != nil {
+			next = goroutineLimiter.traces(next)
+		}
+		if warmup != nil {
+			next = warmup.traces(next)
+		}
+		if timer != nil {
+			next = timer.traces(next)
+		}
+		n.Component, err = builder.CreateTraces(ctx, set, next)
 	case component.DataTypeMetrics:
 		var consumers []consumer.Metrics
 		for _, next := range nexts {
 			consumers = append(consumers, next.(consumer.Metrics))
 		}
-		n.Component, err = builder.CreateMetrics(ctx, set, fanoutconsumer.NewMetrics(consumers))
+		next := fanoutconsumer.NewMetrics(consumers)
+		if freshness != nil {
+			next = freshness.metrics(next)
+		}
+		if limiter != nil {
+			next = limiter.metrics(next)
+		}
+		if goroutineLimiter != nil {
+			next = goroutineLimiter.metrics(next)
+		}
+		if warmup != nil {
+			next = warmup.metrics(next)
+		}
+		if timer != nil {
+			next = timer.metrics(next)
+		}
+		n.Component, err = builder.CreateMetrics(ctx, set, next)
 	case component.DataTypeLogs:
 		var consumers []consumer.Logs
 		for _, next := range nexts {
 			consumers = append(consumers, next.(consumer.Logs))
 		}
-		n.Component, err = builder.CreateLogs(ctx, set, fanoutconsumer.NewLogs(consumers))
+		next := fanoutconsumer.NewLogs(consumers)
+		if freshness != nil {
+			next = freshness.logs(next)
+		}
+		if limiter != nil {
+			next = limiter.logs(next)
+		}
+		if goroutineLimiter != nil {
+			next = goroutineLimiter.logs(next)
+		}
+		if warmup != nil {
+			next = warmup.logs(next)
+		}
+		if timer != nil {
+			next = timer.logs(next)
+		}
+		n.Component, err = builder.CreateLogs(ctx, set, next)
 	case componentprofiles.DataTypeProfiles:
 		var consumers []consumerprofiles.Profiles
 		for _, next := range nexts {
 			consumers = append(consumers, next.(consumerprofiles.Profiles))
 		}
-		n.Component, err = builder.CreateProfiles(ctx, set, fanoutconsumer.NewProfiles(consumers))
+		next := fanoutconsumer.NewProfiles(consumers)
+		if limiter != nil {
+			next = limiter.profiles(next)
+		}
+		if goroutineLimiter != nil {
+			next = goroutineLimiter.profiles(next)
+		}
+		if warmup != nil {
+			next = warmup.profiles(next)
+		}
+		if timer != nil {
+			next = timer.profiles(next)
+		}
+		n.Component, err = builder.CreateProfiles(ctx, set, next)
 	default:
 		return fmt.Errorf("error creating receiver %q for data type %q is not supported", set.ID, n.pipelineType)
 	}
@@ -123,6 +192,10 @@ type processorNode struct {
 	componentID component.ID
 	pipelineID  component.ID
 	component.Component
+
+	// consumer is the processor wrapped by bypass, passing the data to the next consumer when disabled.
+	consumer baseConsumer
+	bypass   processorBypass
 }
 
 func newProcessorNode(pipelineID, procID component.ID) *processorNode {
@@ -134,7 +207,7 @@ func newProcessorNode(pipelineID, procID component.ID) *processorNode {
 }
 
 func (n *processorNode) getConsumer() baseConsumer {
-	return n.Component.(baseConsumer)
+	return n.consumer
 }
 
 func (n *processorNode) buildComponent(ctx context.Context,
@@ -145,22 +218,39 @@ func (n *processorNode) buildComponent(ctx context.Context,
 ) error {
 	tel.Logger = components.ProcessorLogger(tel.Logger, n.componentID, n.pipelineID)
 	set := processor.Settings{ID: n.componentID, TelemetrySettings: tel, BuildInfo: info}
-	var err error
+	timer, err := newStageTimer(stageProcessor, n.componentID, tel)
+	if err != nil {
+		return err
+	}
 	switch n.pipelineID.Type() {
 	case component.DataTypeTraces:
-		n.Component, err = builder.CreateTraces(ctx, set, next.(consumer.Traces))
+		var proc processor.Traces
+		if proc, err = builder.CreateTraces(ctx, set, next.(consumer.Traces)); err == nil {
+			n.Component, n.consumer = proc, n.bypass.traces(proc, next.(consumer.Traces))
+		}
 	case component.DataTypeMetrics:
-		n.Component, err = builder.CreateMetrics(ctx, set, next.(consumer.Metrics))
+		var proc processor.Metrics
+		if proc, err = builder.CreateMetrics(ctx, set, next.(consumer.Metrics)); err == nil {
+			n.Component, n.consumer = proc, n.bypass.metrics(proc, next.(consumer.Metrics))
+		}
 	case component.DataTypeLogs:
-		n.Component, err = builder.CreateLogs(ctx, set, next.(consumer.Logs))
+		var proc processor.Logs
+		if proc, err = builder.CreateLogs(ctx, set, next.(consumer.Logs)); err == nil {
+			n.Component, n.consumer = proc, n.bypass.logs(proc, next.(consumer.Logs))
+		}
 	case componentprofiles.DataTypeProfiles:
-		n.Component, err = builder.CreateProfiles(ctx, set, next.(consumerprofiles.Profiles))
+		var proc processorprofiles.Profiles
+		if proc, err = builder.CreateProfiles(ctx, set, next.(consumerprofiles.Profiles)); err == nil {
+			n.Component, n.consumer = proc, n.bypass.profiles(proc, next.(consumerprofiles.Profiles))
+		}
 	default:
 		return fmt.Errorf("error creating processor %q in pipeline %q, data type %q is not supported", set.ID, n.pipelineID, n.pipelineID.Type())
 	}
 	if err != nil {
 		return fmt.Errorf("failed to create %q processor, in pipeline %q: %w", set.ID, n.pipelineID, err)
 	}
+	// The time of the data bypassing the disabled processor is that of its next consumer, so is not counted.
+	n.consumer = timer.wrap(n.pipelineID.Type(), n.consumer)
 	return nil
 }
 
@@ -173,6 +263,9 @@ type exporterNode struct {
 	componentID  component.ID
 	pipelineType component.DataType
 	component.Component
+
+	// consumer is the exporter, wrapped by the stage timer if any.
+	consumer baseConsumer
 }
 
 func newExporterNode(pipelineType component.DataType, exprID component.ID) *exporterNode {
@@ -184,7 +277,7 @@ func newExporterNode(pipelineType component.DataType, exprID component.ID) *expo
 }
 
 func (n *exporterNode) getConsumer() baseConsumer {
-	return n.Component.(baseConsumer)
+	return n.consumer
 }
 
 func (n *exporterNode) buildComponent(
@@ -211,6 +304,11 @@ func (n *exporterNode) buildComponent(
 	if err != nil {
 		return fmt.Errorf("failed to create %q exporter for data type %q: %w", set.ID, n.pipelineType, err)
 	}
+	timer, err := newStageTimer(stageExporter, n.componentID, tel)
+	if err != nil {
+		return err
+	}
+	n.consumer = timer.wrap(n.pipelineType, n.Component.(baseConsumer))
 	return nil
 }
 
@@ -417,6 +515,11 @@ func (n *connectorNode) buildComponent(
 			n.baseConsumer = capabilityconsumer.NewProfiles(conn, capability)
 		}
 	}
+	timer, err := newStageTimer(stageConnector, n.componentID, tel)
+	if err != nil {
+		return err
+	}
+	n.baseConsumer = timer.wrap(n.exprPipelineType, n.baseConsumer)
 	return nil
 }
 
@@ -0,0 +1,143 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentprofiles"
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumerprofiles"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/service/internal/metadata"
+)
+
+// The stages of the pipelines, reported in the stage attribute of the pipeline_stage_duration metric.
+const (
+	stageReceiver  = "receiver"
+	stageProcessor = "processor"
+	stageExporter  = "exporter"
+	stageConnector = "connector"
+)
+
+// stageCallKey is the context key holding the stageCall of the component passing the data.
+type stageCallKey struct{}
+
+// stageCall accumulates the time spent in the components called synchronously by a component.
+type stageCall struct {
+	downstream atomic.Int64
+}
+
+// stageTimer records the time spent by the data in a component in the pipeline_stage_duration metric.
+//
+// The time of the processors, connectors and exporters is the duration of the calls to their consumer, minus
+// the time spent in their calls to the next components with the context they were given. The time of the
+// receivers is the duration from the start of their operation span, e.g. started by receiverhelper, to the
+// call to the pipeline. It is only recorded if the span records its start time, i.e. with tracing enabled.
+type stageTimer struct {
+	stage            string
+	telemetryBuilder *metadata.TelemetryBuilder
+	attributes       metric.RecordOption
+	now              func() time.Time
+}
+
+// newStageTimer returns a stageTimer for the component with the given ID, or nil if its metrics level is
+// lower than detailed.
+func newStageTimer(stage string, id component.ID, tel component.TelemetrySettings) (*stageTimer, error) {
+	if tel.MetricsLevel < configtelemetry.LevelDetailed {
+		return nil, nil
+	}
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(tel)
+	if err != nil {
+		return nil, err
+	}
+	return &stageTimer{
+		stage:            stage,
+		telemetryBuilder: telemetryBuilder,
+		attributes:       metric.WithAttributes(attribute.String("component", id.String()), attribute.String("stage", stage)),
+		now:              time.Now,
+	}, nil
+}
+
+// consume calls consume, and records the time spent by the data in the component.
+func (st *stageTimer) consume(ctx context.Context, consume func(context.Context) error) error {
+	if st.stage == stageReceiver {
+		if span, ok := trace.SpanFromContext(ctx).(interface{ StartTime() time.Time }); ok && !span.StartTime().IsZero() {
+			st.record(ctx, st.now().Sub(span.StartTime()))
+		}
+		return consume(ctx)
+	}
+
+	call := &stageCall{}
+	start := st.now()
+	err := consume(context.WithValue(ctx, stageCallKey{}, call))
+	elapsed := st.now().Sub(start)
+	if parent, ok := ctx.Value(stageCallKey{}).(*stageCall); ok {
+		parent.downstream.Add(int64(elapsed))
+	}
+	st.record(ctx, elapsed-time.Duration(call.downstream.Load()))
+	return err
+}
+
+func (st *stageTimer) record(ctx context.Context, d time.Duration) {
+	st.telemetryBuilder.PipelineStageDuration.Record(ctx, d.Seconds(), st.attributes)
+}
+
+// wrap returns c, a consumer of dataType, recording the time spent by the data in the component. It returns c
+// as is if st is nil.
+func (st *stageTimer) wrap(dataType component.DataType, c baseConsumer) baseConsumer {
+	if st == nil {
+		return c
+	}
+	switch dataType {
+	case component.DataTypeTraces:
+		return st.traces(c.(consumer.Traces))
+	case component.DataTypeMetrics:
+		return st.metrics(c.(consumer.Metrics))
+	case component.DataTypeLogs:
+		return st.logs(c.(consumer.Logs))
+	case componentprofiles.DataTypeProfiles:
+		return st.profiles(c.(consumerprofiles.Profiles))
+	}
+	return c
+}
+
+func (st *stageTimer) traces(next consumer.Traces) consumer.Traces {
+	tr, _ := consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
+		return st.consume(ctx, func(ctx context.Context) error { return next.ConsumeTraces(ctx, td) })
+	}, consumer.WithCapabilities(next.Capabilities()))
+	return tr
+}
+
+func (st *stageTimer) metrics(next consumer.Metrics) consumer.Metrics {
+	mr, _ := consumer.NewMetrics(func(ctx context.Context, md pmetric.Metrics) error {
+		return st.consume(ctx, func(ctx context.Context) error { return next.ConsumeMetrics(ctx, md) })
+	}, consumer.WithCapabilities(next.Capabilities()))
+	return mr
+}
+
+func (st *stageTimer) logs(next consumer.Logs) consumer.Logs {
+	lr, _ := consumer.NewLogs(func(ctx context.Context, ld plog.Logs) error {
+		return st.consume(ctx, func(ctx context.Context) error { return next.ConsumeLogs(ctx, ld) })
+	}, consumer.WithCapabilities(next.Capabilities()))
+	return lr
+}
+
+func (st *stageTimer) profiles(next consumerprofiles.Profiles) consumerprofiles.Profiles {
+	pr, _ := consumerprofiles.NewProfiles(func(ctx context.Context, pd pprofile.Profiles) error {
+		return st.consume(ctx, func(ctx context.Context) error { return next.ConsumeProfiles(ctx, pd) })
+	}, consumer.WithCapabilities(next.Capabilities()))
+	return pr
+}
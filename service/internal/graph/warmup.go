@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumerprofiles"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// errWarmingUp is returned to the receivers until the warmup period elapses. It is not a permanent error,
+// so the receivers report it as retryable to their clients, e.g. UNAVAILABLE with gRPC or 503 with HTTP.
+var errWarmingUp = errors.New("the collector is warming up, retry later")
+
+// warmupGate rejects the data sent by the receivers until the warmup period elapsed after the start of the pipelines.
+type warmupGate struct {
+	duration time.Duration
+	ready    atomic.Bool
+
+	mu    sync.Mutex
+	timer *time.Timer
+}
+
+// newWarmupGate returns a closed warmupGate, or nil if there is no warmup period.
+func newWarmupGate(duration time.Duration) *warmupGate {
+	if duration <= 0 {
+		return nil
+	}
+	return &warmupGate{duration: duration}
+}
+
+// start opens the gate once the warmup period elapses.
+func (wg *warmupGate) start(onReady func()) {
+	wg.mu.Lock()
+	defer wg.mu.Unlock()
+	wg.timer = time.AfterFunc(wg.duration, func() {
+		wg.ready.Store(true)
+		onReady()
+	})
+}
+
+// stop cancels the warmup if it is still in progress.
+func (wg *warmupGate) stop() {
+	wg.mu.Lock()
+	defer wg.mu.Unlock()
+	if wg.timer != nil {
+		wg.timer.Stop()
+	}
+}
+
+func (wg *warmupGate) traces(next consumer.Traces) consumer.Traces {
+	tr, _ := consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
+		if !wg.ready.Load() {
+			return errWarmingUp
+		}
+		return next.ConsumeTraces(ctx, td)
+	}, consumer.WithCapabilities(next.Capabilities()))
+	return tr
+}
+
+func (wg *warmupGate) metrics(next consumer.Metrics) consumer.Metrics {
+	mr, _ := consumer.NewMetrics(func(ctx context.Context, md pmetric.Metrics) error {
+		if !wg.ready.Load() {
+			return errWarmingUp
+		}
+		return next.ConsumeMetrics(ctx, md)
+	}, consumer.WithCapabilities(next.Capabilities()))
+	return mr
+}
+
+func (wg *warmupGate) logs(next consumer.Logs) consumer.Logs {
+	lr, _ := consumer.NewLogs(func(ctx context.Context, ld plog.Logs) error {
+		if !wg.ready.Load() {
+			return errWarmingUp
+		}
+		return next.ConsumeLogs(ctx, ld)
+	}, consumer.WithCapabilities(next.Capabilities()))
+	return lr
+}
+
+func (wg *warmupGate) profiles(next consumerprofiles.Profiles) consumerprofiles.Profiles {
+	pr, _ := consumerprofiles.NewProfiles(func(ctx context.Context, pd pprofile.Profiles) error {
+		if !wg.ready.Load() {
+			return errWarmingUp
+		}
+		return next.ConsumeProfiles(ctx, pd)
+	}, consumer.WithCapabilities(next.Capabilities()))
+	return pr
+}
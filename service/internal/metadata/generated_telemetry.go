@@ -30,10 +30,20 @@ func Tracer(settings component.TelemetrySettings) trace.Tracer {
 // as defined in metadata and user config.
 type TelemetryBuilder struct {
 	meter                                    metric.Meter
+	PipelineDataFreshness                    metric.Float64ObservableGauge
+	PipelineGoroutineLimitedItems            metric.Int64Counter
+	PipelineStageDuration                    metric.Float64Histogram
+	PipelineThrottledItems                   metric.Int64Counter
 	ProcessCPUSeconds                        metric.Float64ObservableCounter
 	observeProcessCPUSeconds                 func(context.Context, metric.Observer) error
 	ProcessMemoryRss                         metric.Int64ObservableGauge
 	observeProcessMemoryRss                  func(context.Context, metric.Observer) error
+	ProcessRuntimeGcCount                    metric.Int64ObservableCounter
+	observeProcessRuntimeGcCount             func(context.Context, metric.Observer) error
+	ProcessRuntimeGcPauseTotal               metric.Float64ObservableCounter
+	observeProcessRuntimeGcPauseTotal        func(context.Context, metric.Observer) error
+	ProcessRuntimeGoroutines                 metric.Int64ObservableGauge
+	observeProcessRuntimeGoroutines          func(context.Context, metric.Observer) error
 	ProcessRuntimeHeapAllocBytes             metric.Int64ObservableGauge
 	observeProcessRuntimeHeapAllocBytes      func(context.Context, metric.Observer) error
 	ProcessRuntimeTotalAllocBytes            metric.Int64ObservableCounter
@@ -48,6 +58,24 @@ type TelemetryBuilder struct {
 // telemetryBuilderOption applies changes to default builder.
 type telemetryBuilderOption func(*TelemetryBuilder)
 
+// InitPipelineDataFreshness configures the PipelineDataFreshness metric.
+func (builder *TelemetryBuilder) InitPipelineDataFreshness(cb func() float64, opts ...metric.ObserveOption) error {
+	var err error
+	builder.PipelineDataFreshness, err = builder.meters[configtelemetry.LevelBasic].Float64ObservableGauge(
+		"otelcol_pipeline_data_freshness",
+		metric.WithDescription("Age of the newest record, i.e. span, metric point or log record, sent by the receivers to the pipelines during the current or the previous minute, or of the newest record sent before if none was sent since, by data type."),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return err
+	}
+	_, err = builder.meters[configtelemetry.LevelBasic].RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		o.ObserveFloat64(builder.PipelineDataFreshness, cb(), opts...)
+		return nil
+	}, builder.PipelineDataFreshness)
+	return err
+}
+
 // WithProcessCPUSecondsCallback sets callback for observable ProcessCPUSeconds metric.
 func WithProcessCPUSecondsCallback(cb func() float64, opts ...metric.ObserveOption) telemetryBuilderOption {
 	return func(builder *TelemetryBuilder) {
@@ -68,6 +96,36 @@ func WithProcessMemoryRssCallback(cb func() int64, opts ...metric.ObserveOption)
 	}
 }
 
+// WithProcessRuntimeGcCountCallback sets callback for observable ProcessRuntimeGcCount metric.
+func WithProcessRuntimeGcCountCallback(cb func() int64, opts ...metric.ObserveOption) telemetryBuilderOption {
+	return func(builder *TelemetryBuilder) {
+		builder.observeProcessRuntimeGcCount = func(_ context.Context, o metric.Observer) error {
+			o.ObserveInt64(builder.ProcessRuntimeGcCount, cb(), opts...)
+			return nil
+		}
+	}
+}
+
+// WithProcessRuntimeGcPauseTotalCallback sets callback for observable ProcessRuntimeGcPauseTotal metric.
+func WithProcessRuntimeGcPauseTotalCallback(cb func() float64, opts ...metric.ObserveOption) telemetryBuilderOption {
+	return func(builder *TelemetryBuilder) {
+		builder.observeProcessRuntimeGcPauseTotal = func(_ context.Context, o metric.Observer) error {
+			o.ObserveFloat64(builder.ProcessRuntimeGcPauseTotal, cb(), opts...)
+			return nil
+		}
+	}
+}
+
+// WithProcessRuntimeGoroutinesCallback sets callback for observable ProcessRuntimeGoroutines metric.
+func WithProcessRuntimeGoroutinesCallback(cb func() int64, opts ...metric.ObserveOption) telemetryBuilderOption {
+	return func(builder *TelemetryBuilder) {
+		builder.observeProcessRuntimeGoroutines = func(_ context.Context, o metric.Observer) error {
+			o.ObserveInt64(builder.ProcessRuntimeGoroutines, cb(), opts...)
+			return nil
+		}
+	}
+}
+
 // WithProcessRuntimeHeapAllocBytesCallback sets callback for observable ProcessRuntimeHeapAllocBytes metric.
 func WithProcessRuntimeHeapAllocBytesCallback(cb func() int64, opts ...metric.ObserveOption) telemetryBuilderOption {
 	return func(builder *TelemetryBuilder) {
@@ -116,54 +174,115 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...teleme
 		op(&builder)
 	}
 	builder.meters[configtelemetry.LevelBasic] = LeveledMeter(settings, configtelemetry.LevelBasic)
+	builder.meters[configtelemetry.LevelDetailed] = LeveledMeter(settings, configtelemetry.LevelDetailed)
 	var err, errs error
+	builder.PipelineGoroutineLimitedItems, err = builder.meters[configtelemetry.LevelBasic].Int64Counter(
+		"otelcol_pipeline_goroutine_limited_items",
+		metric.WithDescription("Number of items, i.e. spans, metric points, log records or profile samples, refused by the receivers while the number of goroutines reached the service goroutine limit."),
+		metric.WithUnit("{items}"),
+	)
+	errs = errors.Join(errs, err)
+	builder.PipelineStageDuration, err = builder.meters[configtelemetry.LevelDetailed].Float64Histogram(
+		"otelcol_pipeline_stage_duration",
+		metric.WithDescription("Time spent by the data in each receiver, processor, connector and exporter of the pipelines, excluding the time spent in the components it is passed to synchronously."),
+		metric.WithUnit("s"), metric.WithExplicitBucketBoundaries([]float64{0.0001, 0.0005, 0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1, 5, 10}...),
+	)
+	errs = errors.Join(errs, err)
+	builder.PipelineThrottledItems, err = builder.meters[configtelemetry.LevelBasic].Int64Counter(
+		"otelcol_pipeline_throttled_items",
+		metric.WithDescription("Number of items, i.e. spans, metric points, log records or profile samples, sent by the receivers in excess of the service ingest limit."),
+		metric.WithUnit("{items}"),
+	)
+	errs = errors.Join(errs, err)
 	builder.ProcessCPUSeconds, err = builder.meters[configtelemetry.LevelBasic].Float64ObservableCounter(
 		"otelcol_process_cpu_seconds",
 		metric.WithDescription("Total CPU user and system time in seconds"),
 		metric.WithUnit("s"),
 	)
 	errs = errors.Join(errs, err)
-	_, err = builder.meters[configtelemetry.LevelBasic].RegisterCallback(builder.observeProcessCPUSeconds, builder.ProcessCPUSeconds)
-	errs = errors.Join(errs, err)
+	if builder.observeProcessCPUSeconds != nil {
+		_, err = builder.meters[configtelemetry.LevelBasic].RegisterCallback(builder.observeProcessCPUSeconds, builder.ProcessCPUSeconds)
+		errs = errors.Join(errs, err)
+	}
 	builder.ProcessMemoryRss, err = builder.meters[configtelemetry.LevelBasic].Int64ObservableGauge(
 		"otelcol_process_memory_rss",
 		metric.WithDescription("Total physical memory (resident set size)"),
 		metric.WithUnit("By"),
 	)
 	errs = errors.Join(errs, err)
-	_, err = builder.meters[configtelemetry.LevelBasic].RegisterCallback(builder.observeProcessMemoryRss, builder.ProcessMemoryRss)
+	if builder.observeProcessMemoryRss != nil {
+		_, err = builder.meters[configtelemetry.LevelBasic].RegisterCallback(builder.observeProcessMemoryRss, builder.ProcessMemoryRss)
+		errs = errors.Join(errs, err)
+	}
+	builder.ProcessRuntimeGcCount, err = builder.meters[configtelemetry.LevelBasic].Int64ObservableCounter(
+		"otelcol_process_runtime_gc_count",
+		metric.WithDescription("Number of completed garbage collection cycles (see 'go doc runtime.MemStats.NumGC')"),
+		metric.WithUnit("{cycles}"),
+	)
+	errs = errors.Join(errs, err)
+	if builder.observeProcessRuntimeGcCount != nil {
+		_, err = builder.meters[configtelemetry.LevelBasic].RegisterCallback(builder.observeProcessRuntimeGcCount, builder.ProcessRuntimeGcCount)
+		errs = errors.Join(errs, err)
+	}
+	builder.ProcessRuntimeGcPauseTotal, err = builder.meters[configtelemetry.LevelBasic].Float64ObservableCounter(
+		"otelcol_process_runtime_gc_pause_total",
+		metric.WithDescription("Cumulative time spent in the stop-the-world pauses of the garbage collection (see 'go doc runtime.MemStats.PauseTotalNs')"),
+		metric.WithUnit("s"),
+	)
+	errs = errors.Join(errs, err)
+	if builder.observeProcessRuntimeGcPauseTotal != nil {
+		_, err = builder.meters[configtelemetry.LevelBasic].RegisterCallback(builder.observeProcessRuntimeGcPauseTotal, builder.ProcessRuntimeGcPauseTotal)
+		errs = errors.Join(errs, err)
+	}
+	builder.ProcessRuntimeGoroutines, err = builder.meters[configtelemetry.LevelBasic].Int64ObservableGauge(
+		"otelcol_process_runtime_goroutines",
+		metric.WithDescription("Number of goroutines of the process (see 'go doc runtime.NumGoroutine')"),
+		metric.WithUnit("{goroutines}"),
+	)
 	errs = errors.Join(errs, err)
+	if builder.observeProcessRuntimeGoroutines != nil {
+		_, err = builder.meters[configtelemetry.LevelBasic].RegisterCallback(builder.observeProcessRuntimeGoroutines, builder.ProcessRuntimeGoroutines)
+		errs = errors.Join(errs, err)
+	}
 	builder.ProcessRuntimeHeapAllocBytes, err = builder.meters[configtelemetry.LevelBasic].Int64ObservableGauge(
 		"otelcol_process_runtime_heap_alloc_bytes",
 		metric.WithDescription("Bytes of allocated heap objects (see 'go doc runtime.MemStats.HeapAlloc')"),
 		metric.WithUnit("By"),
 	)
 	errs = errors.Join(errs, err)
-	_, err = builder.meters[configtelemetry.LevelBasic].RegisterCallback(builder.observeProcessRuntimeHeapAllocBytes, builder.ProcessRuntimeHeapAllocBytes)
-	errs = errors.Join(errs, err)
+	if builder.observeProcessRuntimeHeapAllocBytes != nil {
+		_, err = builder.meters[configtelemetry.LevelBasic].RegisterCallback(builder.observeProcessRuntimeHeapAllocBytes, builder.ProcessRuntimeHeapAllocBytes)
+		errs = errors.Join(errs, err)
+	}
 	builder.ProcessRuntimeTotalAllocBytes, err = builder.meters[configtelemetry.LevelBasic].Int64ObservableCounter(
 		"otelcol_process_runtime_total_alloc_bytes",
 		metric.WithDescription("Cumulative bytes allocated for heap objects (see 'go doc runtime.MemStats.TotalAlloc')"),
 		metric.WithUnit("By"),
 	)
 	errs = errors.Join(errs, err)
-	_, err = builder.meters[configtelemetry.LevelBasic].RegisterCallback(builder.observeProcessRuntimeTotalAllocBytes, builder.ProcessRuntimeTotalAllocBytes)
-	errs = errors.Join(errs, err)
+	if builder.observeProcessRuntimeTotalAllocBytes != nil {
+		_, err = builder.meters[configtelemetry.LevelBasic].RegisterCallback(builder.observeProcessRuntimeTotalAllocBytes, builder.ProcessRuntimeTotalAllocBytes)
+		errs = errors.Join(errs, err)
+	}
 	builder.ProcessRuntimeTotalSysMemoryBytes, err = builder.meters[configtelemetry.LevelBasic].Int64ObservableGauge(
 		"otelcol_process_runtime_total_sys_memory_bytes",
 		metric.WithDescription("Total bytes of memory obtained from the OS (see 'go doc runtime.MemStats.Sys')"),
 		metric.WithUnit("By"),
 	)
 	errs = errors.Join(errs, err)
-	_, err = builder.meters[configtelemetry.LevelBasic].RegisterCallback(builder.observeProcessRuntimeTotalSysMemoryBytes, builder.ProcessRuntimeTotalSysMemoryBytes)
-	errs = errors.Join(errs, err)
+	if builder.observeProcessRuntimeTotalSysMemoryBytes != nil {
+		_, err = builder.meters[configtelemetry.LevelBasic].RegisterCallback(builder.observeProcessRuntimeTotalSysMemoryBytes, builder.ProcessRuntimeTotalSysMemoryBytes)
+		errs = errors.Join(errs, err)
+	}
 	builder.ProcessUptime, err = builder.meters[configtelemetry.LevelBasic].Float64ObservableCounter(
 		"otelcol_process_uptime",
 		metric.WithDescription("Uptime of the process"),
 		metric.WithUnit("s"),
 	)
 	errs = errors.Join(errs, err)
-	_, err = builder.meters[configtelemetry.LevelBasic].RegisterCallback(builder.observeProcessUptime, builder.ProcessUptime)
-	errs = errors.Join(errs, err)
+	if builder.observeProcessUptime != nil {
+		_, err = builder.meters[configtelemetry.LevelBasic].RegisterCallback(builder.observeProcessUptime, builder.ProcessUptime)
+		errs = errors.Join(errs, err)
+	}
 	return &builder, errs
 }
@@ -5,8 +5,10 @@ package proctelemetry // import "go.opentelemetry.io/collector/service/internal/
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"runtime"
+	"slices"
 	"sync"
 	"time"
 
@@ -23,6 +25,9 @@ type processMetrics struct {
 	proc              *process.Process
 	context           context.Context
 
+	// memStatsInterval is the minimum period between two reads of the runtime memory statistics.
+	memStatsInterval time.Duration
+
 	// mu protects everything bellow.
 	mu         sync.Mutex
 	lastMsRead time.Time
@@ -34,7 +39,9 @@ type RegisterOption interface {
 }
 
 type registerOption struct {
-	hostProc string
+	hostProc         string
+	metrics          []string
+	memStatsInterval time.Duration
 }
 
 type registerOptionFunc func(*registerOption)
@@ -50,10 +57,28 @@ func WithHostProc(hostProc string) RegisterOption {
 	})
 }
 
+// WithMetrics limits the process metrics to the given metrics, e.g. process_uptime. By default, all the
+// process metrics are registered.
+func WithMetrics(metrics ...string) RegisterOption {
+	return registerOptionFunc(func(uo *registerOption) {
+		uo.metrics = metrics
+	})
+}
+
+// WithMemStatsInterval overrides the minimum period between two reads of the runtime memory statistics,
+// one second by default, reported by the process_runtime_* metrics other than process_runtime_goroutines. It is
+// the refresh period of these statistics, not the cadence of the metrics, which the metric readers collect at
+// their own interval: the collections closer than the interval report the same values.
+func WithMemStatsInterval(interval time.Duration) RegisterOption {
+	return registerOptionFunc(func(uo *registerOption) {
+		uo.memStatsInterval = interval
+	})
+}
+
 // RegisterProcessMetrics creates a new set of processMetrics (mem, cpu) that can be used to measure
 // basic information about this process.
 func RegisterProcessMetrics(cfg component.TelemetrySettings, opts ...RegisterOption) error {
-	set := registerOption{}
+	set := registerOption{memStatsInterval: time.Second}
 	for _, opt := range opts {
 		opt.apply(&set)
 	}
@@ -61,6 +86,7 @@ func RegisterProcessMetrics(cfg component.TelemetrySettings, opts ...RegisterOpt
 	pm := &processMetrics{
 		startTimeUnixNano: time.Now().UnixNano(),
 		ms:                &runtime.MemStats{},
+		memStatsInterval:  set.memStatsInterval,
 	}
 
 	ctx := context.Background()
@@ -73,17 +99,79 @@ func RegisterProcessMetrics(cfg component.TelemetrySettings, opts ...RegisterOpt
 		return err
 	}
 
-	_, err = metadata.NewTelemetryBuilder(cfg,
-		metadata.WithProcessUptimeCallback(pm.updateProcessUptime),
-		metadata.WithProcessRuntimeHeapAllocBytesCallback(pm.updateAllocMem),
-		metadata.WithProcessRuntimeTotalAllocBytesCallback(pm.updateTotalAllocMem),
-		metadata.WithProcessRuntimeTotalSysMemoryBytesCallback(pm.updateSysMem),
-		metadata.WithProcessCPUSecondsCallback(pm.updateCPUSeconds),
-		metadata.WithProcessMemoryRssCallback(pm.updateRSSMemory),
-	)
+	builderOpt, err := pm.builderOption(set.metrics)
+	if err != nil {
+		return err
+	}
+	_, err = metadata.NewTelemetryBuilder(cfg, builderOpt)
 	return err
 }
 
+// processMetric pairs the name of a process metric with the telemetry builder option registering its callback.
+type processMetric struct {
+	name   string
+	option func(pm *processMetrics) func(*metadata.TelemetryBuilder)
+}
+
+// processMetricOptions are all the process metrics.
+var processMetricOptions = []processMetric{
+	{"process_uptime", func(pm *processMetrics) func(*metadata.TelemetryBuilder) {
+		return metadata.WithProcessUptimeCallback(pm.updateProcessUptime)
+	}},
+	{"process_runtime_heap_alloc_bytes", func(pm *processMetrics) func(*metadata.TelemetryBuilder) {
+		return metadata.WithProcessRuntimeHeapAllocBytesCallback(pm.updateAllocMem)
+	}},
+	{"process_runtime_total_alloc_bytes", func(pm *processMetrics) func(*metadata.TelemetryBuilder) {
+		return metadata.WithProcessRuntimeTotalAllocBytesCallback(pm.updateTotalAllocMem)
+	}},
+	{"process_runtime_total_sys_memory_bytes", func(pm *processMetrics) func(*metadata.TelemetryBuilder) {
+		return metadata.WithProcessRuntimeTotalSysMemoryBytesCallback(pm.updateSysMem)
+	}},
+	{"process_runtime_gc_count", func(pm *processMetrics) func(*metadata.TelemetryBuilder) {
+		return metadata.WithProcessRuntimeGcCountCallback(pm.updateGCCount)
+	}},
+	{"process_runtime_gc_pause_total", func(pm *processMetrics) func(*metadata.TelemetryBuilder) {
+		return metadata.WithProcessRuntimeGcPauseTotalCallback(pm.updateGCPauseTotal)
+	}},
+	{"process_runtime_goroutines", func(pm *processMetrics) func(*metadata.TelemetryBuilder) {
+		return metadata.WithProcessRuntimeGoroutinesCallback(pm.updateGoroutines)
+	}},
+	{"process_cpu_seconds", func(pm *processMetrics) func(*metadata.TelemetryBuilder) {
+		return metadata.WithProcessCPUSecondsCallback(pm.updateCPUSeconds)
+	}},
+	{"process_memory_rss", func(pm *processMetrics) func(*metadata.TelemetryBuilder) {
+		return metadata.WithProcessMemoryRssCallback(pm.updateRSSMemory)
+	}},
+}
+
+// ValidateMetrics returns an error if one of metrics is not a process metric, e.g. process_uptime.
+func ValidateMetrics(metrics []string) error {
+	for _, metric := range metrics {
+		if !slices.ContainsFunc(processMetricOptions, func(m processMetric) bool { return m.name == metric }) {
+			return fmt.Errorf("unknown process metric %q", metric)
+		}
+	}
+	return nil
+}
+
+// builderOption returns a telemetry builder option registering the callbacks of the selected metrics, or of all
+// the metrics if none is selected.
+func (pm *processMetrics) builderOption(selected []string) (func(*metadata.TelemetryBuilder), error) {
+	if err := ValidateMetrics(selected); err != nil {
+		return nil, err
+	}
+	var opts []func(*metadata.TelemetryBuilder)
+	for _, m := range processMetricOptions {
+		if len(selected) == 0 || slices.Contains(selected, m.name) {
+			opts = append(opts, m.option(pm))
+		}
+	}
+	return func(tb *metadata.TelemetryBuilder) {
+		for _, opt := range opts {
+			opt(tb)
+		}
+	}, nil
+}
 func (pm *processMetrics) updateProcessUptime() float64 {
 	now := time.Now().UnixNano()
 	return float64(now-pm.startTimeUnixNano) / 1e9
@@ -110,6 +198,24 @@ func (pm *processMetrics) updateSysMem() int64 {
 	return int64(pm.ms.Sys)
 }
 
+func (pm *processMetrics) updateGCCount() int64 {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pm.readMemStatsIfNeeded()
+	return int64(pm.ms.NumGC)
+}
+
+func (pm *processMetrics) updateGCPauseTotal() float64 {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pm.readMemStatsIfNeeded()
+	return float64(pm.ms.PauseTotalNs) / 1e9
+}
+
+func (pm *processMetrics) updateGoroutines() int64 {
+	return int64(runtime.NumGoroutine())
+}
+
 func (pm *processMetrics) updateCPUSeconds() float64 {
 	times, err := pm.proc.TimesWithContext(pm.context)
 	if err != nil {
@@ -130,8 +236,8 @@ func (pm *processMetrics) updateRSSMemory() int64 {
 
 func (pm *processMetrics) readMemStatsIfNeeded() {
 	now := time.Now()
-	// If last time we read was less than one second ago just reuse the values
-	if now.Sub(pm.lastMsRead) < time.Second {
+	// If last time we read was less than the interval ago just reuse the values
+	if now.Sub(pm.lastMsRead) < pm.memStatsInterval {
 		return
 	}
 	pm.lastMsRead = now
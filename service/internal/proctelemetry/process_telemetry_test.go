@@ -7,8 +7,10 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"runtime"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -36,6 +38,9 @@ var expectedMetrics = []string{
 	"otelcol_process_runtime_heap_alloc_bytes",
 	"otelcol_process_runtime_total_alloc_bytes",
 	"otelcol_process_runtime_total_sys_memory_bytes",
+	"otelcol_process_runtime_gc_count",
+	"otelcol_process_runtime_gc_pause_total",
+	"otelcol_process_runtime_goroutines",
 	"otelcol_process_cpu_seconds",
 	"otelcol_process_memory_rss",
 }
@@ -100,7 +105,8 @@ func TestProcessTelemetry(t *testing.T) {
 		} else {
 			metricValue = metric.Metric[0].GetGauge().GetValue()
 		}
-		if strings.HasPrefix(metricName, "otelcol_process_uptime") || strings.HasPrefix(metricName, "otelcol_process_cpu_seconds") {
+		if strings.HasPrefix(metricName, "otelcol_process_uptime") || strings.HasPrefix(metricName, "otelcol_process_cpu_seconds") ||
+			strings.HasPrefix(metricName, "otelcol_process_runtime_gc_") {
 			// This likely will still be zero when running the test.
 			assert.GreaterOrEqual(t, metricValue, float64(0), metricName)
 			continue
@@ -109,3 +115,69 @@ func TestProcessTelemetry(t *testing.T) {
 		assert.Greater(t, metricValue, float64(0), metricName)
 	}
 }
+
+func TestProcessTelemetryWithMetrics(t *testing.T) {
+	tel := setupTelemetry(t)
+
+	require.NoError(t, RegisterProcessMetrics(tel.TelemetrySettings,
+		WithMetrics("process_uptime", "process_runtime_heap_alloc_bytes", "process_runtime_gc_count", "process_runtime_goroutines")))
+
+	mp, err := fetchPrometheusMetrics(tel.promHandler)
+	require.NoError(t, err)
+
+	for _, metricName := range expectedMetrics {
+		metric, ok := mp[metricName]
+		switch metricName {
+		case "otelcol_process_uptime", "otelcol_process_runtime_heap_alloc_bytes", "otelcol_process_runtime_gc_count", "otelcol_process_runtime_goroutines":
+			require.True(t, ok, metricName)
+			assert.Len(t, metric.Metric, 1, metricName)
+		default:
+			assert.False(t, ok, metricName)
+		}
+	}
+}
+
+func TestProcessTelemetryWithUnknownMetric(t *testing.T) {
+	tel := setupTelemetry(t)
+
+	err := RegisterProcessMetrics(tel.TelemetrySettings, WithMetrics("process_uptime", "process_unknown"))
+	require.EqualError(t, err, `unknown process metric "process_unknown"`)
+	require.EqualError(t, ValidateMetrics([]string{"process_uptime", "process_unknown"}), `unknown process metric "process_unknown"`)
+	var names []string
+	for _, m := range processMetricOptions {
+		names = append(names, m.name)
+	}
+	require.NoError(t, ValidateMetrics(names))
+	require.Len(t, names, len(expectedMetrics))
+}
+
+func TestProcessTelemetryWithMemStatsInterval(t *testing.T) {
+	var data [][]byte
+	allocate := func() {
+		for i := 0; i < 100; i++ {
+			data = append(data, make([]byte, 1024))
+		}
+	}
+
+	cached := &processMetrics{ms: &runtime.MemStats{}, memStatsInterval: time.Hour}
+	first := cached.updateTotalAllocMem()
+	allocate()
+	assert.Equal(t, first, cached.updateTotalAllocMem())
+
+	uncached := &processMetrics{ms: &runtime.MemStats{}, memStatsInterval: time.Nanosecond}
+	first = uncached.updateTotalAllocMem()
+	allocate()
+	time.Sleep(time.Millisecond)
+	assert.Greater(t, uncached.updateTotalAllocMem(), first)
+	assert.NotEmpty(t, data)
+}
+
+func TestProcessTelemetryGC(t *testing.T) {
+	pm := &processMetrics{ms: &runtime.MemStats{}, memStatsInterval: time.Nanosecond}
+	first := pm.updateGCCount()
+	runtime.GC()
+	time.Sleep(time.Millisecond)
+	assert.Greater(t, pm.updateGCCount(), first)
+	assert.Greater(t, pm.updateGCPauseTotal(), float64(0))
+	assert.Positive(t, pm.updateGoroutines())
+}
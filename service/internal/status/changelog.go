@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package status // import "go.opentelemetry.io/collector/service/internal/status"
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component/componentstatus"
+)
+
+// ChangeLogger logs the status changes of the components before passing them to a NotifyStatusFunc.
+// At most one status change of a component is logged every interval: the first change is logged
+// immediately, and the changes happening during the rest of the interval are collapsed into a
+// single log of the latest status, with the number of collapsed changes, at the end of the interval.
+type ChangeLogger struct {
+	logger   *zap.Logger
+	interval time.Duration
+	next     NotifyStatusFunc
+
+	mu        sync.Mutex
+	stopped   bool
+	instances map[*componentstatus.InstanceID]*loggedInstance
+}
+
+type loggedInstance struct {
+	lastLogged time.Time
+	// pending is the latest status change not logged yet, and collapsed the number of changes it stands for.
+	pending   *componentstatus.Event
+	collapsed int
+	timer     *time.Timer
+}
+
+// NewChangeLogger returns a ChangeLogger logging at most one status change per component every interval.
+// If interval is not positive, every status change is logged.
+func NewChangeLogger(logger *zap.Logger, interval time.Duration, next NotifyStatusFunc) *ChangeLogger {
+	return &ChangeLogger{
+		logger:    logger,
+		interval:  interval,
+		next:      next,
+		instances: make(map[*componentstatus.InstanceID]*loggedInstance),
+	}
+}
+
+// NotifyComponentStatusChange implements NotifyStatusFunc.
+func (cl *ChangeLogger) NotifyComponentStatusChange(id *componentstatus.InstanceID, ev *componentstatus.Event) {
+	cl.mu.Lock()
+	cl.record(id, ev)
+	cl.mu.Unlock()
+	cl.next(id, ev)
+}
+
+// Shutdown logs the collapsed status changes and stops the pending timers. The status changes
+// reported afterward are all logged.
+func (cl *ChangeLogger) Shutdown() {
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+	cl.stopped = true
+	for id, inst := range cl.instances {
+		if inst.timer != nil {
+			inst.timer.Stop()
+			cl.flush(id, inst)
+		}
+	}
+}
+
+// Note: a lock must be acquired before calling this method.
+func (cl *ChangeLogger) record(id *componentstatus.InstanceID, ev *componentstatus.Event) {
+	if cl.stopped || cl.interval <= 0 {
+		cl.log(id, ev, 1)
+		return
+	}
+	inst, ok := cl.instances[id]
+	if !ok {
+		inst = &loggedInstance{}
+		cl.instances[id] = inst
+	}
+	if inst.timer == nil && ev.Timestamp().Sub(inst.lastLogged) >= cl.interval {
+		inst.lastLogged = ev.Timestamp()
+		cl.log(id, ev, 1)
+		return
+	}
+	inst.pending = ev
+	inst.collapsed++
+	if inst.timer == nil {
+		inst.timer = time.AfterFunc(cl.interval-ev.Timestamp().Sub(inst.lastLogged), func() {
+			cl.mu.Lock()
+			defer cl.mu.Unlock()
+			// The instance was flushed by Shutdown.
+			if cl.stopped {
+				return
+			}
+			cl.flush(id, inst)
+		})
+	}
+}
+
+// Note: a lock must be acquired before calling this method.
+func (cl *ChangeLogger) flush(id *componentstatus.InstanceID, inst *loggedInstance) {
+	if inst.pending != nil {
+		cl.log(id, inst.pending, inst.collapsed)
+	}
+	inst.lastLogged = time.Now()
+	inst.pending = nil
+	inst.collapsed = 0
+	inst.timer = nil
+}
+
+func (cl *ChangeLogger) log(id *componentstatus.InstanceID, ev *componentstatus.Event, changes int) {
+	fields := []zap.Field{
+		zap.String("kind", id.Kind().String()),
+		zap.String("id", id.ComponentID().String()),
+		zap.String("status", ev.Status().String()),
+	}
+	if changes > 1 {
+		fields = append(fields, zap.Int("changes", changes))
+	}
+	if ev.Err() != nil {
+		cl.logger.Warn("Component status changed", append(fields, zap.Error(ev.Err()))...)
+		return
+	}
+	cl.logger.Info("Component status changed", fields...)
+}
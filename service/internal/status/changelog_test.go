@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package status
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+)
+
+func TestChangeLoggerBoundsLogs(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	rec := &statusRecorder{}
+	cl := NewChangeLogger(zap.New(core), 100*time.Millisecond, rec.notify)
+
+	id := componentstatus.NewInstanceID(component.MustNewID("otlp"), component.KindExporter)
+	other := componentstatus.NewInstanceID(component.MustNewID("debug"), component.KindExporter)
+	cl.NotifyComponentStatusChange(other, componentstatus.NewEvent(componentstatus.StatusOK))
+	for i := 0; i < 50; i++ {
+		cl.NotifyComponentStatusChange(id, componentstatus.NewRecoverableErrorEvent(errors.New("flapping")))
+		cl.NotifyComponentStatusChange(id, componentstatus.NewEvent(componentstatus.StatusOK))
+	}
+
+	// Every change is passed through, only the first one of each component is logged.
+	assert.Len(t, rec.get(id), 100)
+	require.Equal(t, 2, logs.Len())
+	first := logs.All()[1]
+	assert.Equal(t, zap.WarnLevel, first.Level)
+	assert.Equal(t, map[string]any{
+		"kind":   "Exporter",
+		"id":     "otlp",
+		"status": componentstatus.StatusRecoverableError.String(),
+		"error":  "flapping",
+	}, first.ContextMap())
+
+	// The others are collapsed into a single log at the end of the interval.
+	assert.Eventually(t, func() bool { return logs.Len() == 3 }, time.Second, 10*time.Millisecond)
+	collapsed := logs.All()[2]
+	assert.Equal(t, zap.InfoLevel, collapsed.Level)
+	assert.Equal(t, map[string]any{
+		"kind":    "Exporter",
+		"id":      "otlp",
+		"status":  componentstatus.StatusOK.String(),
+		"changes": int64(99),
+	}, collapsed.ContextMap())
+	time.Sleep(200 * time.Millisecond)
+	assert.Equal(t, 3, logs.Len())
+
+	// After a quiet interval, a change is logged right away again.
+	cl.NotifyComponentStatusChange(id, componentstatus.NewEvent(componentstatus.StatusStopping))
+	assert.Equal(t, 4, logs.Len())
+	cl.Shutdown()
+}
+
+func TestChangeLoggerShutdown(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	rec := &statusRecorder{}
+	cl := NewChangeLogger(zap.New(core), time.Hour, rec.notify)
+
+	id := componentstatus.NewInstanceID(component.MustNewID("otlp"), component.KindReceiver)
+	cl.NotifyComponentStatusChange(id, componentstatus.NewEvent(componentstatus.StatusStarting))
+	cl.NotifyComponentStatusChange(id, componentstatus.NewEvent(componentstatus.StatusOK))
+	cl.NotifyComponentStatusChange(id, componentstatus.NewEvent(componentstatus.StatusStopping))
+	require.Equal(t, 1, logs.Len())
+
+	// Shutdown logs the collapsed changes, later changes are all logged.
+	cl.Shutdown()
+	require.Equal(t, 2, logs.Len())
+	assert.Equal(t, int64(2), logs.All()[1].ContextMap()["changes"])
+	cl.NotifyComponentStatusChange(id, componentstatus.NewEvent(componentstatus.StatusStopped))
+	cl.NotifyComponentStatusChange(id, componentstatus.NewEvent(componentstatus.StatusStopped))
+	assert.Equal(t, 4, logs.Len())
+}
+
+func TestChangeLoggerNoInterval(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	cl := NewChangeLogger(zap.New(core), 0, func(*componentstatus.InstanceID, *componentstatus.Event) {})
+	id := componentstatus.NewInstanceID(component.MustNewID("otlp"), component.KindReceiver)
+	for i := 0; i < 10; i++ {
+		cl.NotifyComponentStatusChange(id, componentstatus.NewEvent(componentstatus.StatusOK))
+	}
+	assert.Equal(t, 10, logs.Len())
+	cl.Shutdown()
+}
@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package status // import "go.opentelemetry.io/collector/service/internal/status"
+
+import (
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component/componentstatus"
+)
+
+// Debouncer coalesces rapid status transitions of a component before passing them to a NotifyStatusFunc.
+// Only transitions between componentstatus.StatusOK and componentstatus.StatusRecoverableError are debounced:
+// they are held until the component status has not changed for the debounce window, and only the settled
+// status is reported, if it differs from the last reported one. Any other status is reported immediately
+// and supersedes the pending one.
+type Debouncer struct {
+	window time.Duration
+	next   NotifyStatusFunc
+
+	mu       sync.Mutex
+	stopped  bool
+	pending  map[*componentstatus.InstanceID]*pendingEvent
+	reported map[*componentstatus.InstanceID]componentstatus.Status
+}
+
+type pendingEvent struct {
+	ev    *componentstatus.Event
+	timer *time.Timer
+}
+
+// NewDebouncer returns a Debouncer reporting the settled status transitions to next.
+func NewDebouncer(window time.Duration, next NotifyStatusFunc) *Debouncer {
+	return &Debouncer{
+		window:   window,
+		next:     next,
+		pending:  make(map[*componentstatus.InstanceID]*pendingEvent),
+		reported: make(map[*componentstatus.InstanceID]componentstatus.Status),
+	}
+}
+
+// NotifyComponentStatusChange implements NotifyStatusFunc.
+func (d *Debouncer) NotifyComponentStatusChange(id *componentstatus.InstanceID, ev *componentstatus.Event) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	p, hasPending := d.pending[id]
+	if d.stopped || !debounced(ev.Status()) {
+		if hasPending {
+			p.timer.Stop()
+			delete(d.pending, id)
+		}
+		d.report(id, ev)
+		return
+	}
+
+	if hasPending {
+		p.ev = ev
+		p.timer.Reset(d.window)
+		return
+	}
+	p = &pendingEvent{ev: ev}
+	p.timer = time.AfterFunc(d.window, func() { d.fire(id, p) })
+	d.pending[id] = p
+}
+
+// Shutdown stops the pending timers. The statuses reported afterward are passed through immediately.
+func (d *Debouncer) Shutdown() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.stopped = true
+	for id, p := range d.pending {
+		p.timer.Stop()
+		delete(d.pending, id)
+	}
+}
+
+func (d *Debouncer) fire(id *componentstatus.InstanceID, p *pendingEvent) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	// The event was superseded, or reported already by a previous expiration of the reset timer.
+	if d.pending[id] != p {
+		return
+	}
+	delete(d.pending, id)
+	if d.reported[id] != p.ev.Status() {
+		d.report(id, p.ev)
+	}
+}
+
+// Note: a lock must be acquired before calling this method.
+func (d *Debouncer) report(id *componentstatus.InstanceID, ev *componentstatus.Event) {
+	d.reported[id] = ev.Status()
+	d.next(id, ev)
+}
+
+func debounced(status componentstatus.Status) bool {
+	return status == componentstatus.StatusOK || status == componentstatus.StatusRecoverableError
+}
@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package status
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componentstatus"
+)
+
+type statusRecorder struct {
+	mu       sync.Mutex
+	statuses map[*componentstatus.InstanceID][]componentstatus.Status
+}
+
+func (r *statusRecorder) notify(id *componentstatus.InstanceID, ev *componentstatus.Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.statuses == nil {
+		r.statuses = make(map[*componentstatus.InstanceID][]componentstatus.Status)
+	}
+	r.statuses[id] = append(r.statuses[id], ev.Status())
+}
+
+func (r *statusRecorder) get(id *componentstatus.InstanceID) []componentstatus.Status {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]componentstatus.Status(nil), r.statuses[id]...)
+}
+
+func TestDebouncerCoalescesTransitions(t *testing.T) {
+	rec := &statusRecorder{}
+	d := NewDebouncer(50*time.Millisecond, rec.notify)
+	rep := NewReporter(d.NotifyComponentStatusChange, func(err error) { require.NoError(t, err) })
+	rep.Ready()
+
+	id := &componentstatus.InstanceID{}
+	rep.ReportStatus(id, componentstatus.NewEvent(componentstatus.StatusStarting))
+	assert.Equal(t, []componentstatus.Status{componentstatus.StatusStarting}, rec.get(id))
+
+	// Flap between OK and recoverable error, settling on a recoverable error.
+	rep.ReportStatus(id, componentstatus.NewEvent(componentstatus.StatusOK))
+	for i := 0; i < 10; i++ {
+		rep.ReportStatus(id, componentstatus.NewRecoverableErrorEvent(errors.New("flapping")))
+		rep.ReportStatus(id, componentstatus.NewEvent(componentstatus.StatusOK))
+	}
+	rep.ReportStatus(id, componentstatus.NewRecoverableErrorEvent(errors.New("settled")))
+	assert.Equal(t, []componentstatus.Status{componentstatus.StatusStarting}, rec.get(id))
+
+	expected := []componentstatus.Status{componentstatus.StatusStarting, componentstatus.StatusRecoverableError}
+	assert.Eventually(t, func() bool { return assert.ObjectsAreEqual(expected, rec.get(id)) }, time.Second, 10*time.Millisecond)
+
+	// Flapping back to the already reported status reports nothing.
+	rep.ReportStatus(id, componentstatus.NewEvent(componentstatus.StatusOK))
+	rep.ReportStatus(id, componentstatus.NewRecoverableErrorEvent(errors.New("again")))
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, expected, rec.get(id))
+
+	// Other statuses are reported immediately and supersede the pending one.
+	rep.ReportStatus(id, componentstatus.NewEvent(componentstatus.StatusOK))
+	rep.ReportStatus(id, componentstatus.NewEvent(componentstatus.StatusStopping))
+	rep.ReportStatus(id, componentstatus.NewEvent(componentstatus.StatusStopped))
+	expected = append(expected, componentstatus.StatusStopping, componentstatus.StatusStopped)
+	assert.Equal(t, expected, rec.get(id))
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, expected, rec.get(id))
+}
+
+func TestDebouncerPerInstance(t *testing.T) {
+	rec := &statusRecorder{}
+	d := NewDebouncer(50*time.Millisecond, rec.notify)
+
+	id1 := &componentstatus.InstanceID{}
+	id2 := &componentstatus.InstanceID{}
+	d.NotifyComponentStatusChange(id1, componentstatus.NewEvent(componentstatus.StatusOK))
+	d.NotifyComponentStatusChange(id2, componentstatus.NewRecoverableErrorEvent(errors.New("err")))
+
+	assert.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual([]componentstatus.Status{componentstatus.StatusOK}, rec.get(id1)) &&
+			assert.ObjectsAreEqual([]componentstatus.Status{componentstatus.StatusRecoverableError}, rec.get(id2))
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestDebouncerShutdown(t *testing.T) {
+	rec := &statusRecorder{}
+	d := NewDebouncer(50*time.Millisecond, rec.notify)
+
+	id := &componentstatus.InstanceID{}
+	d.NotifyComponentStatusChange(id, componentstatus.NewEvent(componentstatus.StatusOK))
+	d.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+	assert.Empty(t, rec.get(id))
+
+	// After shutdown, statuses are passed through.
+	d.NotifyComponentStatusChange(id, componentstatus.NewRecoverableErrorEvent(errors.New("err")))
+	assert.Equal(t, []componentstatus.Status{componentstatus.StatusRecoverableError}, rec.get(id))
+}
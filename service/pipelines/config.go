@@ -40,6 +40,10 @@ func (cfg Config) Validate() error {
 		if err := pipeline.Validate(); err != nil {
 			return fmt.Errorf("pipeline %q: %w", pipelineID, err)
 		}
+
+		if pipeline.DeadLetter != nil && pipelineID.Type() == componentprofiles.DataTypeProfiles {
+			return fmt.Errorf("pipeline %q: dead-letter exporters are not supported by %q pipelines", pipelineID, pipelineID.Type())
+		}
 	}
 
 	return nil
@@ -50,6 +54,48 @@ type PipelineConfig struct {
 	Receivers  []component.ID `mapstructure:"receivers"`
 	Processors []component.ID `mapstructure:"processors"`
 	Exporters  []component.ID `mapstructure:"exporters"`
+	// DeadLetter is the exporter the processors and exporters of the pipeline send the data they drop to, with the
+	// reason of the drop, see consumerdeadletter, instead of discarding it. It only receives the dropped data, and so
+	// must not be one of the exporters of the pipeline. If nil, the dropped data is discarded.
+	DeadLetter *component.ID `mapstructure:"dead_letter"`
+	// Fanout configures how the data of the pipeline is delivered to its exporters.
+	Fanout FanoutConfig `mapstructure:"fanout"`
+}
+
+// FanoutDelivery is the way the data of a pipeline is delivered to its exporters.
+type FanoutDelivery string
+
+const (
+	// FanoutDeliveryDefault delivers the data to the exporters mutating it first, then to the other exporters,
+	// one exporter at a time.
+	FanoutDeliveryDefault FanoutDelivery = ""
+	// FanoutDeliverySequential delivers the data to the exporters in their declared order, one exporter at a time.
+	FanoutDeliverySequential FanoutDelivery = "sequential"
+	// FanoutDeliveryConcurrent delivers the data to all the exporters at the same time, and waits for all of them
+	// to return.
+	FanoutDeliveryConcurrent FanoutDelivery = "concurrent"
+)
+
+// FanoutConfig configures the delivery of the data of a pipeline to its exporters.
+type FanoutConfig struct {
+	// Delivery is the way the data is delivered to the exporters, FanoutDeliveryDefault if empty.
+	Delivery FanoutDelivery `mapstructure:"delivery"`
+	// StopOnPermanentError stops the FanoutDeliverySequential delivery at the first exporter returning a permanent
+	// error, the following exporters not receiving the data. It requires the FanoutDeliverySequential delivery.
+	StopOnPermanentError bool `mapstructure:"stop_on_permanent_error"`
+}
+
+// Validate checks that the delivery is supported.
+func (cfg *FanoutConfig) Validate() error {
+	switch cfg.Delivery {
+	case FanoutDeliveryDefault, FanoutDeliverySequential, FanoutDeliveryConcurrent:
+	default:
+		return fmt.Errorf("unsupported fanout delivery %q", cfg.Delivery)
+	}
+	if cfg.StopOnPermanentError && cfg.Delivery != FanoutDeliverySequential {
+		return fmt.Errorf("fanout stop_on_permanent_error requires the %q delivery", FanoutDeliverySequential)
+	}
+	return nil
 }
 
 func (cfg *PipelineConfig) Validate() error {
@@ -73,5 +119,17 @@ func (cfg *PipelineConfig) Validate() error {
 		procSet[ref] = struct{}{}
 	}
 
+	if cfg.DeadLetter != nil {
+		for _, ref := range cfg.Exporters {
+			if ref == *cfg.DeadLetter {
+				return fmt.Errorf("references exporter %q as both an exporter and the dead-letter exporter", ref)
+			}
+		}
+	}
+
+	if err := cfg.Fanout.Validate(); err != nil {
+		return err
+	}
+
 	return nil
 }
@@ -52,6 +52,68 @@ func TestConfigValidate(t *testing.T) {
 			},
 			expected: fmt.Errorf(`pipeline "traces": %w`, errMissingServicePipelineExporters),
 		},
+		{
+			name: "dead-letter",
+			cfgFn: func() Config {
+				cfg := generateConfig()
+				deadLetter := component.MustNewIDWithName("nop", "dead_letter")
+				cfg[component.MustNewID("traces")].DeadLetter = &deadLetter
+				return cfg
+			},
+			expected: nil,
+		},
+		{
+			name: "dead-letter-exporter-reference",
+			cfgFn: func() Config {
+				cfg := generateConfig()
+				deadLetter := component.MustNewID("nop")
+				cfg[component.MustNewID("traces")].DeadLetter = &deadLetter
+				return cfg
+			},
+			expected: fmt.Errorf(`pipeline "traces": %w`,
+				errors.New(`references exporter "nop" as both an exporter and the dead-letter exporter`)),
+		},
+		{
+			name: "dead-letter-profiles",
+			cfgFn: func() Config {
+				cfg := generateConfig()
+				deadLetter := component.MustNewIDWithName("nop", "dead_letter")
+				cfg[component.MustNewID("profiles")] = &PipelineConfig{
+					Receivers:  []component.ID{component.MustNewID("nop")},
+					Exporters:  []component.ID{component.MustNewID("nop")},
+					DeadLetter: &deadLetter,
+				}
+				return cfg
+			},
+			expected: errors.New(`pipeline "profiles": dead-letter exporters are not supported by "profiles" pipelines`),
+		},
+		{
+			name: "fanout-sequential",
+			cfgFn: func() Config {
+				cfg := generateConfig()
+				cfg[component.MustNewID("traces")].Fanout = FanoutConfig{Delivery: FanoutDeliverySequential, StopOnPermanentError: true}
+				return cfg
+			},
+			expected: nil,
+		},
+		{
+			name: "fanout-unsupported-delivery",
+			cfgFn: func() Config {
+				cfg := generateConfig()
+				cfg[component.MustNewID("traces")].Fanout = FanoutConfig{Delivery: "random"}
+				return cfg
+			},
+			expected: fmt.Errorf(`pipeline "traces": %w`, errors.New(`unsupported fanout delivery "random"`)),
+		},
+		{
+			name: "fanout-stop-on-permanent-error-concurrent",
+			cfgFn: func() Config {
+				cfg := generateConfig()
+				cfg[component.MustNewID("traces")].Fanout = FanoutConfig{Delivery: FanoutDeliveryConcurrent, StopOnPermanentError: true}
+				return cfg
+			},
+			expected: fmt.Errorf(`pipeline "traces": %w`, errors.New(`fanout stop_on_permanent_error requires the "sequential" delivery`)),
+		},
 		{
 			name: "missing-pipelines",
 			cfgFn: func() Config {
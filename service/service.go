@@ -30,6 +30,8 @@ import (
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/service/extensions"
 	"go.opentelemetry.io/collector/service/internal/builders"
+	"go.opentelemetry.io/collector/service/internal/confighash"
+	"go.opentelemetry.io/collector/service/internal/droppeditems"
 	"go.opentelemetry.io/collector/service/internal/graph"
 	"go.opentelemetry.io/collector/service/internal/proctelemetry"
 	"go.opentelemetry.io/collector/service/internal/resource"
@@ -84,6 +86,8 @@ type Service struct {
 	telemetrySettings component.TelemetrySettings
 	host              *graph.Host
 	collectorConf     *confmap.Conf
+	statusDebouncer   *status.Debouncer
+	statusLogger      *status.ChangeLogger
 }
 
 // New creates a new Service, its telemetry, and Components.
@@ -128,14 +132,17 @@ func New(ctx context.Context, set Settings, cfg Config) (*Service, error) {
 
 	logger.Info("Setting up own telemetry...")
 
-	mp, err := newMeterProvider(
-		meterProviderSettings{
-			res:               res,
-			cfg:               cfg.Telemetry.Metrics,
-			asyncErrorChannel: set.AsyncErrorChannel,
-		},
-		disableHighCard,
-	)
+	mpSettings := meterProviderSettings{
+		res:               res,
+		cfg:               cfg.Telemetry.Metrics,
+		asyncErrorChannel: set.AsyncErrorChannel,
+	}
+	var droppedItems *droppeditems.Aggregator
+	if cfg.Telemetry.Metrics.PipelineDroppedItems {
+		droppedItems = droppeditems.NewAggregator()
+		mpSettings.readers = append(mpSettings.readers, droppedItems.Reader())
+	}
+	mp, err := newMeterProvider(mpSettings, disableHighCard)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create metric provider: %w", err)
 	}
@@ -155,7 +162,16 @@ func New(ctx context.Context, set Settings, cfg Config) (*Service, error) {
 		// Construct telemetry attributes from build info and config's resource attributes.
 		Resource: pcommonRes,
 	}
-	srv.host.Reporter = status.NewReporter(srv.host.NotifyComponentStatusChange, func(err error) {
+	notifyStatusChange := srv.host.NotifyComponentStatusChange
+	if sc := cfg.Telemetry.Logs.StatusChanges; sc != nil && sc.Enabled {
+		srv.statusLogger = status.NewChangeLogger(logger, sc.Interval, notifyStatusChange)
+		notifyStatusChange = srv.statusLogger.NotifyComponentStatusChange
+	}
+	if cfg.StatusDebounce > 0 {
+		srv.statusDebouncer = status.NewDebouncer(cfg.StatusDebounce, notifyStatusChange)
+		notifyStatusChange = srv.statusDebouncer.NotifyComponentStatusChange
+	}
+	srv.host.Reporter = status.NewReporter(notifyStatusChange, func(err error) {
 		if errors.Is(err, status.ErrStatusNotReady) {
 			logger.Warn("Invalid transition", zap.Error(err))
 		}
@@ -174,11 +190,27 @@ func New(ctx context.Context, set Settings, cfg Config) (*Service, error) {
 	}
 
 	if cfg.Telemetry.Metrics.Level != configtelemetry.LevelNone && cfg.Telemetry.Metrics.Address != "" {
-		if err = proctelemetry.RegisterProcessMetrics(srv.telemetrySettings); err != nil {
+		processOpts := []proctelemetry.RegisterOption{proctelemetry.WithMetrics(cfg.Telemetry.Metrics.Process.Metrics...)}
+		if cfg.Telemetry.Metrics.Process.MemStatsInterval > 0 {
+			processOpts = append(processOpts, proctelemetry.WithMemStatsInterval(cfg.Telemetry.Metrics.Process.MemStatsInterval))
+		}
+		if err = proctelemetry.RegisterProcessMetrics(srv.telemetrySettings, processOpts...); err != nil {
 			return nil, fmt.Errorf("failed to register process metrics: %w", err)
 		}
 	}
 
+	if cfg.Telemetry.Metrics.ConfigHash && srv.collectorConf != nil {
+		if err = confighash.RegisterMetric(srv.telemetrySettings, srv.collectorConf); err != nil {
+			return nil, fmt.Errorf("failed to register config hash metric: %w", err)
+		}
+	}
+
+	if droppedItems != nil {
+		if err = droppedItems.RegisterMetric(srv.telemetrySettings); err != nil {
+			return nil, fmt.Errorf("failed to register pipeline dropped items metric: %w", err)
+		}
+	}
+
 	return srv, nil
 }
 
@@ -287,6 +319,14 @@ func (srv *Service) Shutdown(ctx context.Context) error {
 		errs = multierr.Append(errs, fmt.Errorf("failed to shutdown extensions: %w", err))
 	}
 
+	if srv.statusDebouncer != nil {
+		srv.statusDebouncer.Shutdown()
+	}
+
+	if srv.statusLogger != nil {
+		srv.statusLogger.Shutdown()
+	}
+
 	srv.telemetrySettings.Logger.Info("Shutdown complete.")
 
 	errs = multierr.Append(errs, srv.shutdownTelemetry(ctx))
@@ -321,6 +361,16 @@ func (srv *Service) initGraph(ctx context.Context, cfg Config) error {
 		ConnectorBuilder: srv.host.Connectors,
 		PipelineConfigs:  cfg.Pipelines,
 		ReportStatus:     srv.host.Reporter.ReportStatus,
+		MetricsLevels:    cfg.Telemetry.Metrics.ComponentLevels,
+		Warmup:           cfg.Warmup,
+		IngestLimit: graph.IngestLimit{
+			ItemsPerSecond: cfg.IngestLimit.ItemsPerSecond,
+			Burst:          cfg.IngestLimit.Burst,
+			Drop:           cfg.IngestLimit.Drop,
+		},
+		DataFreshness:    cfg.DataFreshness,
+		MaxGoroutines:    cfg.GoroutineLimit.MaxGoroutines,
+		ResumeGoroutines: cfg.GoroutineLimit.ResumeGoroutines,
 	}); err != nil {
 		return fmt.Errorf("failed to build pipelines: %w", err)
 	}
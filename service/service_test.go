@@ -19,6 +19,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componentprofiles"
@@ -253,6 +254,62 @@ func TestServiceTelemetryCleanupOnError(t *testing.T) {
 	assert.NoError(t, srv.Shutdown(context.Background()))
 }
 
+func TestServiceStatusDebounce(t *testing.T) {
+	cfg := newNopConfig()
+	cfg.StatusDebounce = time.Minute
+	srv, err := New(context.Background(), newNopSettings(), cfg)
+	require.NoError(t, err)
+	require.NotNil(t, srv.statusDebouncer)
+
+	// Starting and shutting down reports non debounced statuses, so it does not wait for the window.
+	require.NoError(t, srv.Start(context.Background()))
+	assert.NoError(t, srv.Shutdown(context.Background()))
+}
+
+func TestServiceStatusChangesLogging(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	set := newNopSettings()
+	set.LoggingOptions = []zap.Option{zap.WrapCore(func(c zapcore.Core) zapcore.Core {
+		return zapcore.NewTee(c, core)
+	})}
+	cfg := newNopConfig()
+	cfg.Telemetry.Logs.StatusChanges = &telemetry.LogsStatusChangesConfig{Enabled: true, Interval: time.Hour}
+	srv, err := New(context.Background(), set, cfg)
+	require.NoError(t, err)
+	require.NotNil(t, srv.statusLogger)
+
+	require.NoError(t, srv.Start(context.Background()))
+	assert.NoError(t, srv.Shutdown(context.Background()))
+
+	// Each component goes through four statuses: the first one is logged, and the three others are
+	// collapsed into a single log at shutdown.
+	changes := logs.FilterMessage("Component status changed")
+	require.NotZero(t, changes.Len())
+	collapsed := changes.FilterField(zap.Int("changes", 3))
+	assert.Equal(t, changes.Len(), 2*collapsed.Len())
+}
+
+func TestServiceConfigHash(t *testing.T) {
+	cfg := newNopConfig()
+	cfg.Telemetry.Metrics.ConfigHash = true
+	set := newNopSettings()
+	set.CollectorConf = confmap.NewFromStringMap(map[string]any{"service": map[string]any{"extensions": []any{"nop"}}})
+	srv, err := New(context.Background(), set, cfg)
+	require.NoError(t, err)
+	require.NoError(t, srv.Start(context.Background()))
+	assert.NoError(t, srv.Shutdown(context.Background()))
+}
+
+func TestServicePipelineDroppedItems(t *testing.T) {
+	cfg := newNopConfig()
+	cfg.Telemetry.Metrics.PipelineDroppedItems = true
+	cfg.Telemetry.Metrics.Address = testutil.GetAvailableLocalAddress(t)
+	srv, err := New(context.Background(), newNopSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, srv.Start(context.Background()))
+	assert.NoError(t, srv.Shutdown(context.Background()))
+}
+
 func TestServiceTelemetry(t *testing.T) {
 	for _, tc := range ownMetricsTestCases() {
 		t.Run(fmt.Sprintf("ipv4_%s", tc.name), func(t *testing.T) {
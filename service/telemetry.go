@@ -38,6 +38,8 @@ type meterProviderSettings struct {
 	res               *resource.Resource
 	cfg               telemetry.MetricsConfig
 	asyncErrorChannel chan error
+	// readers are registered with the meter provider in addition to the configured readers.
+	readers []sdkmetric.Reader
 }
 
 func newMeterProvider(set meterProviderSettings, disableHighCardinality bool) (metric.MeterProvider, error) {
@@ -83,6 +85,9 @@ func newMeterProvider(set meterProviderSettings, disableHighCardinality bool) (m
 		}
 		opts = append(opts, sdkmetric.WithReader(r))
 	}
+	for _, r := range set.readers {
+		opts = append(opts, sdkmetric.WithReader(r))
+	}
 
 	var err error
 	mp.MeterProvider, err = proctelemetry.InitOpenTelemetry(set.res, opts, disableHighCardinality)
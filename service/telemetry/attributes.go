@@ -10,7 +10,20 @@ func attributes(set Settings, cfg Config) map[string]interface{} {
 		string(semconv.ServiceNameKey):    set.BuildInfo.Command,
 		string(semconv.ServiceVersionKey): set.BuildInfo.Version,
 	}
-	for k, v := range cfg.Resource {
+	applyResource(attrs, cfg.Resource)
+	return attrs
+}
+
+// tracerAttributes returns the resource attributes of the internal tracer, which are the attributes
+// of all the emitted telemetry overridden by the resource attributes of the traces.
+func tracerAttributes(set Settings, cfg Config) map[string]interface{} {
+	attrs := attributes(set, cfg)
+	applyResource(attrs, cfg.Traces.Resource)
+	return attrs
+}
+
+func applyResource(attrs map[string]interface{}, resource map[string]*string) {
+	for k, v := range resource {
 		if v != nil {
 			attrs[k] = *v
 		}
@@ -20,5 +33,4 @@ func attributes(set Settings, cfg Config) map[string]interface{} {
 			delete(attrs, k)
 		}
 	}
-	return attrs
 }
@@ -10,7 +10,9 @@ import (
 	"go.opentelemetry.io/contrib/config"
 	"go.uber.org/zap/zapcore"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/service/internal/proctelemetry"
 )
 
 // Config defines the configurable settings for service telemetry.
@@ -64,6 +66,10 @@ type LogsConfig struct {
 	// Sampling can be disabled by setting 'enabled' to false
 	Sampling *LogsSamplingConfig `mapstructure:"sampling"`
 
+	// StatusChanges configures the logging of the status changes of the components.
+	// By default, the status changes are not logged.
+	StatusChanges *LogsStatusChangesConfig `mapstructure:"status_changes"`
+
 	// OutputPaths is a list of URLs or file paths to write logging output to.
 	// The URLs could only be with "file" schema or without schema.
 	// The URLs with "file" schema must be an absolute path.
@@ -109,6 +115,16 @@ type LogsSamplingConfig struct {
 	Thereafter int `mapstructure:"thereafter"`
 }
 
+// LogsStatusChangesConfig sets the logging of the status changes of the components.
+type LogsStatusChangesConfig struct {
+	// Enabled enables the logging of the status changes.
+	Enabled bool `mapstructure:"enabled"`
+	// Interval is the minimum time between two logs of the status changes of a component. The status
+	// changes happening within the interval are collapsed into a single log of the latest status at the
+	// end of the interval, with the number of changes. Zero logs every status change.
+	Interval time.Duration `mapstructure:"interval"`
+}
+
 // MetricsConfig exposes the common Telemetry configuration for one component.
 // Experimental: *NOTE* this structure is subject to change or removal in the future.
 type MetricsConfig struct {
@@ -125,6 +141,35 @@ type MetricsConfig struct {
 	// Readers allow configuration of metric readers to emit metrics to
 	// any number of supported backends.
 	Readers []config.MetricReader `mapstructure:"readers"`
+
+	// ConfigHash enables the otelcol_config_info metric, whose config.hash attribute holds a hash of the
+	// effective configuration with the sensitive values redacted. Instances running identical
+	// configurations report the same hash, which allows to detect configuration drift.
+	ConfigHash bool `mapstructure:"config_hash"`
+
+	// PipelineDroppedItems enables the otelcol_pipeline_dropped_items metric, summing the items dropped
+	// by the processors and the exporters of all the pipelines by signal and reason, for alerting.
+	PipelineDroppedItems bool `mapstructure:"pipeline_dropped_items"`
+
+	// ComponentLevels overrides Level for the metrics recorded by the listed receivers, processors,
+	// exporters and connectors, e.g. to record the detailed metrics of a few components only.
+	ComponentLevels map[component.ID]configtelemetry.Level `mapstructure:"component_levels"`
+
+	// Process configures the metrics of the collector process, e.g. its memory and cpu usage.
+	Process ProcessMetricsConfig `mapstructure:"process"`
+}
+
+// ProcessMetricsConfig configures the metrics of the collector process.
+type ProcessMetricsConfig struct {
+	// Metrics lists the process metrics to emit, e.g. process_uptime. By default, all the
+	// process metrics are emitted.
+	Metrics []string `mapstructure:"metrics"`
+
+	// MemStatsInterval is the minimum period between two reads of the runtime memory statistics
+	// reported by the process_runtime_* metrics, except process_runtime_goroutines. The default is one second.
+	// It is the refresh period of these statistics, not the emission interval of the metrics, which is
+	// the interval of the metric readers, e.g. readers::periodic::interval.
+	MemStatsInterval time.Duration `mapstructure:"mem_stats_interval"`
 }
 
 // TracesConfig exposes the common Telemetry configuration for collector's internal spans.
@@ -141,6 +186,11 @@ type TracesConfig struct {
 	// Processors allow configuration of span processors to emit spans to
 	// any number of suported backends.
 	Processors []config.SpanProcessor `mapstructure:"processors"`
+	// Resource specifies user-defined attributes to include only with the spans emitted by the
+	// collector, e.g. deployment metadata. They are applied on top of the attributes of
+	// service::telemetry::resource, and an attribute with a null YAML value (nil string pointer)
+	// is suppressed from the spans.
+	Resource map[string]*string `mapstructure:"resource"`
 }
 
 // Validate checks whether the current configuration is valid
@@ -150,5 +200,40 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("collector telemetry metric address or reader should exist when metric level is not none")
 	}
 
+	if c.Metrics.Level == configtelemetry.LevelNone && len(c.Metrics.ComponentLevels) != 0 {
+		return fmt.Errorf("collector telemetry metric component levels require the metric level not to be none")
+	}
+
+	if err := c.Metrics.Process.Validate(); err != nil {
+		return err
+	}
+
+	if c.Logs.StatusChanges != nil {
+		if err := c.Logs.StatusChanges.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Validate checks that the process metrics are known and that the memory statistics interval is not negative.
+func (c *ProcessMetricsConfig) Validate() error {
+	if err := proctelemetry.ValidateMetrics(c.Metrics); err != nil {
+		return fmt.Errorf("collector telemetry process metrics are invalid: %w", err)
+	}
+
+	if c.MemStatsInterval < 0 {
+		return fmt.Errorf("collector telemetry process metrics memory statistics interval must not be negative")
+	}
+
+	return nil
+}
+
+// Validate checks that the interval is not negative.
+func (c *LogsStatusChangesConfig) Validate() error {
+	if c.Interval < 0 {
+		return fmt.Errorf("collector telemetry logs status changes interval must not be negative")
+	}
 	return nil
 }
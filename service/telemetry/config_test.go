@@ -5,10 +5,12 @@ package telemetry
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"go.opentelemetry.io/contrib/config"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configtelemetry"
 )
 
@@ -50,6 +52,79 @@ func TestLoadConfig(t *testing.T) {
 			},
 			success: true,
 		},
+		{
+			name: "invalid status changes logging interval",
+			cfg: &Config{
+				Logs: LogsConfig{
+					StatusChanges: &LogsStatusChangesConfig{Enabled: true, Interval: -time.Second},
+				},
+				Metrics: MetricsConfig{
+					Level: configtelemetry.LevelNone,
+				},
+			},
+			success: false,
+		},
+		{
+			name: "component metric levels",
+			cfg: &Config{
+				Metrics: MetricsConfig{
+					Level:   configtelemetry.LevelBasic,
+					Address: "127.0.0.1:3333",
+					ComponentLevels: map[component.ID]configtelemetry.Level{
+						component.MustNewID("batch"): configtelemetry.LevelDetailed,
+					},
+				},
+			},
+			success: true,
+		},
+		{
+			name: "component metric levels without metric telemetry",
+			cfg: &Config{
+				Metrics: MetricsConfig{
+					Level: configtelemetry.LevelNone,
+					ComponentLevels: map[component.ID]configtelemetry.Level{
+						component.MustNewID("batch"): configtelemetry.LevelDetailed,
+					},
+				},
+			},
+			success: false,
+		},
+		{
+			name: "process metrics",
+			cfg: &Config{
+				Metrics: MetricsConfig{
+					Level:   configtelemetry.LevelBasic,
+					Address: "127.0.0.1:3333",
+					Process: ProcessMetricsConfig{
+						Metrics:          []string{"process_uptime"},
+						MemStatsInterval: 10 * time.Second,
+					},
+				},
+			},
+			success: true,
+		},
+		{
+			name: "unknown process metric",
+			cfg: &Config{
+				Metrics: MetricsConfig{
+					Level:   configtelemetry.LevelBasic,
+					Address: "127.0.0.1:3333",
+					Process: ProcessMetricsConfig{Metrics: []string{"process_uptime", "process_unknown"}},
+				},
+			},
+			success: false,
+		},
+		{
+			name: "invalid process metrics memory statistics interval",
+			cfg: &Config{
+				Metrics: MetricsConfig{
+					Level:   configtelemetry.LevelBasic,
+					Address: "127.0.0.1:3333",
+					Process: ProcessMetricsConfig{MemStatsInterval: -time.Second},
+				},
+			},
+			success: false,
+		},
 	}
 
 	for _, tt := range tests {
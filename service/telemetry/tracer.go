@@ -57,7 +57,7 @@ func newTracerProvider(ctx context.Context, set Settings, cfg Config) (trace.Tra
 	sch := semconv.SchemaURL
 	res := config.Resource{
 		SchemaUrl:  &sch,
-		Attributes: attributes(set, cfg),
+		Attributes: tracerAttributes(set, cfg),
 	}
 
 	sdk, err := config.NewSDK(
@@ -7,9 +7,11 @@ import (
 	"context"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/featuregate"
 	"go.opentelemetry.io/collector/internal/globalgates"
@@ -61,3 +63,40 @@ func TestNewTracerProvider(t *testing.T) {
 func ptr[T any](v T) *T {
 	return &v
 }
+
+func TestTracerProviderResource(t *testing.T) {
+	cfg := Config{
+		Resource: map[string]*string{
+			"deployment.environment": ptr("staging"),
+			"host.name":              ptr("collector-0"),
+		},
+		Traces: TracesConfig{
+			Level: configtelemetry.LevelBasic,
+			Resource: map[string]*string{
+				"deployment.environment": ptr("production"),
+				"k8s.cluster.name":       ptr("east"),
+				"host.name":              nil,
+			},
+		},
+	}
+	set := internal.Settings{BuildInfo: component.BuildInfo{Command: "otelcol", Version: "1.0"}}
+	provider, err := newTracerProvider(context.Background(), set, cfg)
+	require.NoError(t, err)
+	tp, ok := provider.(*sdktrace.TracerProvider)
+	require.True(t, ok)
+	t.Cleanup(func() { assert.NoError(t, tp.Shutdown(context.Background())) })
+
+	_, span := provider.Tracer("test").Start(context.Background(), "span")
+	span.End()
+	ro, ok := span.(sdktrace.ReadOnlySpan)
+	require.True(t, ok)
+	attrs := map[string]string{}
+	for _, kv := range ro.Resource().Attributes() {
+		attrs[string(kv.Key)] = kv.Value.Emit()
+	}
+	assert.Equal(t, "otelcol", attrs["service.name"])
+	assert.Equal(t, "1.0", attrs["service.version"])
+	assert.Equal(t, "production", attrs["deployment.environment"])
+	assert.Equal(t, "east", attrs["k8s.cluster.name"])
+	assert.NotContains(t, attrs, "host.name")
+}